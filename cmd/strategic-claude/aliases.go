@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addAlias registers name as another name of target, with the same arguments,
+// flags and completions, and returns the alias command. Unlike cobra aliases,
+// these are completed and listed in the help. It must be called once target's
+// flags and completions are registered.
+//
+// When a command is renamed, its old name is kept working for scripts with an
+// alias whose deprecated message names the new command; deprecated aliases are
+// left out of the help and completions and print the message when used.
+func addAlias(target *cobra.Command, name, deprecated string) *cobra.Command {
+	alias := &cobra.Command{
+		Use:               strings.Replace(target.Use, target.Name(), name, 1),
+		Short:             fmt.Sprintf("Same as '%s': %s", target.Name(), target.Short),
+		Long:              fmt.Sprintf("'%s' is another name for '%s', with the same arguments and flags.\n\n%s", name, target.Name(), target.Long),
+		Args:              target.Args,
+		ValidArgsFunction: target.ValidArgsFunction,
+		GroupID:           aliasGroupID,
+		Deprecated:        deprecated,
+		RunE:              target.RunE,
+	}
+	// The flags are shared, so their values, completions and constraints are too
+	alias.Flags().AddFlagSet(target.Flags())
+	target.Parent().AddCommand(alias)
+	return alias
+}
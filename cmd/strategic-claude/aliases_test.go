@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestAliases(t *testing.T) {
+	tests := []struct {
+		alias  string
+		target *cobra.Command
+		flag   string
+	}{
+		{alias: "install", target: initCmd, flag: "force-core"},
+		{alias: "uninstall", target: cleanCmd, flag: "trash"},
+		{alias: "upgrade", target: updateCmd, flag: "check"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			alias, _, err := rootCmd.Find([]string{tt.alias})
+			if err != nil || alias.Name() != tt.alias {
+				t.Fatalf("Find(%q) = %v, %v", tt.alias, alias, err)
+			}
+			if alias.Flags().Lookup(tt.flag) != tt.target.Flags().Lookup(tt.flag) {
+				t.Errorf("%s does not share --%s with %s", tt.alias, tt.flag, tt.target.Name())
+			}
+			if _, ok := alias.GetFlagCompletionFunc("target"); !ok {
+				t.Errorf("%s has no completion for the global flags", tt.alias)
+			}
+			if alias.GroupID != aliasGroupID {
+				t.Errorf("%s GroupID = %q, want %q", tt.alias, alias.GroupID, aliasGroupID)
+			}
+		})
+	}
+}
+
+func TestAliasesCompletion(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{cobra.ShellCompNoDescRequestCmd, "upg"})
+	defer rootCmd.SetArgs(nil)
+	defer rootCmd.SetOut(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "upgrade\n") {
+		t.Errorf("Completions of 'upg' = %q, want upgrade", out.String())
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/document"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:     "archive <document>...",
+	Short:   "Move completed plan, research or summary documents into archives",
+	GroupID: groupMaintenance,
+	Long: `Retire completed documents by moving them from plan/, research/ or summary/
+into a dated folder below archives/, e.g. archives/2025-01-31/plan/<name>.md, and
+adding an entry to archives/INDEX.md.
+
+Documents can be given as paths relative to the current directory, absolute
+paths, or paths relative to .strategic-claude-basic (e.g. plan/feature.md).
+
+Examples:
+  strategic-claude-basic-cli archive plan/2025-01-02-user-authentication.md
+  strategic-claude-basic-cli archive .strategic-claude-basic/research/*.md
+  strategic-claude-basic-cli archive summary/sprint-12.md --target ./my-project`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runArchive(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+}
+
+// runArchive executes the archive command logic
+func runArchive(docPaths []string) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	documentService := document.New()
+	for _, docPath := range docPaths {
+		doc, err := documentService.Archive(absTarget, docPath)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to archive %s: %w", docPath, err))
+			return err
+		}
+		fmt.Printf(utils.Symbols("  %s → %s\n"), doc.From, doc.To)
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Archived %d document(s); see %s/%s/%s",
+		len(docPaths), config.FrameworkDir(), config.ArchivesDir, config.ArchiveIndexFile))
+	return nil
+}
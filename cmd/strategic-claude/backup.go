@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/backup"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:     "backup",
+	Short:   "Manage backups of a Strategic Claude Basic installation",
+	GroupID: groupMaintenance,
+	Long: `Manage the backups created by init, update, clean and mcp.
+
+Backups of the framework directory, .claude/settings.json, .codex/config.toml and
+.mcp.json are kept in ` + config.BackupsDir + `/ in the project. Set
+` + config.BackupsDirEnvVar + ` to use another location; relative paths resolve against the project.
+
+Examples:
+  strategic-claude-basic-cli backup list                                  # List the backups
+  strategic-claude-basic-cli backup restore --path core/commands/plan.md  # Restore a file from the newest backup
+  strategic-claude-basic-cli backup prune --dry-run                       # Show which backups would be removed`,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list [directory]",
+	Short: "List the backups of a project",
+	Long: `List the backups of a project, newest first.
+
+Framework backups are named after the template and commit of the installation
+they hold, e.g. strategic-claude-basic-backup-20250101-120000-main-1a2b3c4, which
+is also recorded in ` + config.BackupInfoFile + ` inside the backup and shown under CONTENTS.
+
+Backups that older versions left in the project root, .claude or .codex are
+moved into the backups directory first.
+
+Examples:
+  strategic-claude-basic-cli backup list                 # Backups of current directory
+  strategic-claude-basic-cli backup list ./my-project    # Backups of specific directory`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runBackupList(target)
+	},
+}
+
+var (
+	backupPruneDryRun bool
+	backupPruneTrash  bool
+)
+
+var backupPruneCmd = &cobra.Command{
+	Use:   "prune [directory]",
+	Short: "Remove old backups of a project",
+	Long: `Remove the backups beyond the number the CLI keeps, and the ones older than
+it keeps them; the newest backup is always kept. Both limits come from the
+max_backups and max_backup_age settings (see 'config').
+
+--trash moves the backups to the trash instead of deleting them. Set
+` + config.TrashDirEnvVar + ` or trash_dir in the config file to use a directory of your own.
+
+Examples:
+  strategic-claude-basic-cli backup prune              # Remove old backups
+  strategic-claude-basic-cli backup prune --dry-run    # List what would be removed
+  strategic-claude-basic-cli backup prune --trash      # Move them to the trash`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+			if err := loadConfig(cmd, target); err != nil {
+				return err
+			}
+		}
+		return runBackupPrune(target)
+	},
+}
+
+var (
+	backupRestorePaths  []string
+	backupRestoreName   string
+	backupRestoreDryRun bool
+)
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore [directory]",
+	Short: "Restore files of the framework directory from a backup",
+	Long: `Copy individual files back from a framework backup into the installation, for
+when one file was overwritten and a full rollback would undo too much.
+
+Paths are relative to the framework directory; a directory restores everything
+below it. The newest framework backup is used unless --backup names another one
+from 'backup list'; backups kept as ` + config.BackupArchiveExtension + ` archives work as well. Restored
+files replace the ones in the installation.
+
+Examples:
+  strategic-claude-basic-cli backup restore --path core/commands/foo.md             # From the newest backup
+  strategic-claude-basic-cli backup restore --path core/commands --backup <name>    # A directory from a given backup
+  strategic-claude-basic-cli backup restore --path core/hooks --dry-run             # List what would be restored`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runBackupRestore(target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupListCmd, backupPruneCmd, backupRestoreCmd)
+
+	backupRestoreCmd.Flags().StringArrayVar(&backupRestorePaths, "path", nil, "path to restore, relative to the framework directory (repeatable)")
+	backupRestoreCmd.Flags().StringVar(&backupRestoreName, "backup", "", "name of the framework backup to restore from (default: the newest)")
+	backupRestoreCmd.Flags().BoolVar(&backupRestoreDryRun, "dry-run", false, "list the files that would be restored")
+
+	if err := backupRestoreCmd.RegisterFlagCompletionFunc("backup", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		entries, err := backup.New().List(target)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for _, entry := range entries {
+			if entry.Kind == backup.KindFramework {
+				names = append(names, entry.Name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --backup flag: %v\n", err)
+	}
+
+	backupPruneCmd.Flags().BoolVar(&backupPruneDryRun, "dry-run", false, "list the backups that would be removed")
+	backupPruneCmd.Flags().BoolVar(&backupPruneTrash, "trash", false, "move the backups to the trash instead of deleting them")
+}
+
+// runBackupRestore executes the backup restore command logic
+func runBackupRestore(target string) error {
+	if len(backupRestorePaths) == 0 {
+		err := models.NewValidationError("path", "", "name at least one path to restore, e.g. --path core/commands/foo.md")
+		utils.DisplayError(err)
+		return err
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	statusInfo, err := status.NewService().CheckInstallation(absTarget)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to check installation status: %w", err))
+		return err
+	}
+	if !statusInfo.StrategicClaudeDir {
+		err := models.NewAppError(models.ErrorCodeNotInstalled, "Strategic Claude Basic is not installed in this directory", nil)
+		utils.DisplayError(err)
+		return err
+	}
+
+	backupService := backup.New()
+	entry, err := backupService.Find(absTarget, backupRestoreName)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	restored, err := backupService.Restore(absTarget, entry, backupRestorePaths, backupRestoreDryRun)
+	for _, path := range restored {
+		if backupRestoreDryRun {
+			fmt.Printf("Would restore %s\n", path)
+		} else {
+			utils.VerbosePrintf(verbose, "Restored %s\n", path)
+		}
+	}
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	if !backupRestoreDryRun {
+		from := entry.Name
+		if entry.Info != nil && entry.Info.Label() != "" {
+			from = fmt.Sprintf("%s (%s)", entry.Name, entry.Info.Label())
+		}
+		utils.DisplaySuccess(fmt.Sprintf("Restored %d file(s) from %s", len(restored), from))
+	}
+	return nil
+}
+
+// runBackupPrune executes the backup prune command logic
+func runBackupPrune(target string) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	backupService := backup.New()
+	if backupPruneTrash {
+		backupService.SetTrash(trash.New())
+	}
+
+	pruned, err := backupService.Prune(absTarget, backupPruneDryRun)
+	for _, entry := range pruned {
+		switch {
+		case backupPruneDryRun:
+			fmt.Printf("Would remove %s (%s, %s)\n", entry.Name, entry.Created.Format(time.DateTime), utils.FormatSize(entry.Size))
+		case entry.TrashPath != "":
+			fmt.Printf("Moved %s to %s\n", entry.Name, entry.TrashPath)
+		default:
+			fmt.Printf("Removed %s\n", entry.Name)
+		}
+	}
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	if len(pruned) == 0 {
+		utils.DisplayInfo("No backups to prune")
+	} else if !backupPruneDryRun {
+		utils.DisplaySuccess(fmt.Sprintf("Pruned %d backup(s)", len(pruned)))
+	}
+	return nil
+}
+
+// runBackupList executes the backup list command logic
+func runBackupList(target string) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	backupService := backup.New()
+
+	moved, err := backupService.MigrateLegacyBackups(absTarget)
+	if err != nil {
+		utils.DisplayWarning(fmt.Sprintf("Failed to migrate old backups: %v", err))
+	}
+	for _, path := range moved {
+		utils.VerbosePrintf(verbose, "Moved old backup to %s\n", path)
+	}
+
+	entries, err := backupService.List(absTarget)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	backupsRoot := config.GetBackupsRoot(absTarget)
+	if len(entries) == 0 {
+		utils.DisplayInfo(fmt.Sprintf("No backups in %s", backupsRoot))
+		return nil
+	}
+
+	var total int64
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "KIND\tCREATED\tSIZE\tCONTENTS\tNAME")
+	for _, entry := range entries {
+		contents := "-"
+		if entry.Info != nil && entry.Info.Label() != "" {
+			contents = entry.Info.Label()
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			entry.Kind, entry.Created.Format(time.DateTime), utils.FormatSize(entry.Size), contents, entry.Name)
+		total += entry.Size
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d backup(s), %s in %s\n", len(entries), utils.FormatSize(total), backupsRoot)
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	bundleTemplateID string
+	bundleOutput     string
+	bundleAuthToken  string
+)
+
+var bundleCmd = &cobra.Command{
+	Use:     "bundle",
+	Short:   "Package templates for offline installation",
+	GroupID: groupInstall,
+	Long: `Package templates for installation on machines without network access.
+
+Bundles contain the template repository at its pinned commit, the template
+definition and a tree hash of the content. They are verified before installing.
+
+Examples:
+  strategic-claude-basic-cli bundle create                          # Bundle the default template
+  strategic-claude-basic-cli bundle create --template main -o scb.tar.gz
+  strategic-claude-basic-cli init --from-bundle scb.tar.gz          # Install from the bundle offline`,
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an offline bundle of a template",
+	Long: `Create a single archive containing a template at its pinned commit.
+
+Copy the archive to an isolated machine and install it with 'init --from-bundle'.
+
+Examples:
+  strategic-claude-basic-cli bundle create                          # Bundle the default template
+  strategic-claude-basic-cli bundle create --template=ccr           # Bundle the CCR template
+  strategic-claude-basic-cli bundle create --output=/media/scb.tar.gz # Choose the output file
+  strategic-claude-basic-cli init --from-bundle=/media/scb.tar.gz   # Install from the bundle`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBundleCreate()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleCreateCmd)
+
+	bundleCreateCmd.Flags().StringVar(&bundleTemplateID, "template", templates.DefaultTemplateID, "template ID to bundle")
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "output file (default: strategic-claude-basic-<template>-<commit>.tar.gz)")
+	bundleCreateCmd.Flags().StringVar(&bundleAuthToken, "auth-token", "", "token for private HTTPS template repositories (default: $SCB_GIT_TOKEN)")
+
+	if err := bundleCreateCmd.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return templates.GetTemplateIDs(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --template flag: %v\n", err)
+	}
+}
+
+// runBundleCreate executes the bundle create command logic
+func runBundleCreate() error {
+	template, err := templates.GetTemplate(bundleTemplateID)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("invalid template ID '%s': %w", bundleTemplateID, err))
+		return err
+	}
+
+	if err := validatePrerequisites(); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	output := bundleOutput
+	if output == "" {
+		output = bundle.DefaultBundleName(template)
+	}
+	absOutput, err := filepath.Abs(output)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve output path: %w", err))
+		return err
+	}
+
+	utils.DisplayInfo(fmt.Sprintf("Bundling template %s at commit %s...", template.ID, template.Commit))
+
+	bundleService := bundle.New()
+	bundleService.SetAuthToken(bundleAuthToken)
+
+	manifest, err := bundleService.Create(template, absOutput)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("bundle creation failed: %w", err))
+		return err
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Bundle written to %s", absOutput))
+	utils.VerbosePrintf(verbose, "Tree hash: %s\n", manifest.TreeHash)
+	fmt.Printf("Install it offline with: strategic-claude-basic-cli init --from-bundle=%s\n", absOutput)
+
+	return nil
+}
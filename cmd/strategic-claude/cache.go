@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheCleanOlderThan string
+	cacheCleanTemplate  string
+	cacheCleanForce     bool
+	cacheCleanTemp      bool
+)
+
+var cacheCmd = &cobra.Command{
+	Use:     "cache",
+	Short:   "Manage the local template clone cache",
+	GroupID: groupMaintenance,
+	Long: `Manage the local cache of fetched template repositories.
+
+Each template commit is fetched once and reused by later installations.
+The cache lives in $XDG_CACHE_HOME/strategic-claude-basic when XDG_CACHE_HOME is
+set, otherwise in the platform cache directory.
+
+Examples:
+  strategic-claude-basic-cli cache list                       # List the cached template commits
+  strategic-claude-basic-cli cache clean --older-than 30d     # Remove entries unused for a month
+  strategic-claude-basic-cli cache clean --temp               # Remove leftover template checkouts
+  du -sh "$(strategic-claude-basic-cli cache dir)"            # Size of the cache`,
+}
+
+var cacheDirCmd = &cobra.Command{
+	Use:   "dir",
+	Short: "Print the cache location",
+	Long: `Print the directory the clone cache and cached status results are kept in.
+
+Examples:
+  strategic-claude-basic-cli cache dir
+  SCB_CACHE_DIR=/var/cache/scb strategic-claude-basic-cli cache dir`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := cache.Dir()
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		fmt.Println(dir)
+		return nil
+	},
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached template repositories",
+	Long: `List the cached template commits with their size and when they were last used.
+
+Examples:
+  strategic-claude-basic-cli cache list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCacheList()
+	},
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove cached template repositories",
+	Long: `Remove cached template repositories.
+
+Without filters the whole cache is cleared. Filters can be combined.
+
+--temp removes the template checkouts that crashed runs, or --keep-temp, left
+in the temporary directory instead; those older than --older-than, by default
+` + config.StaleTempDirAge.String() + `, which are also removed whenever a command starts.
+
+Examples:
+  strategic-claude-basic-cli cache clean                    # Clear the whole cache
+  strategic-claude-basic-cli cache clean --older-than=30d   # Entries unused for 30 days
+  strategic-claude-basic-cli cache clean --template=ccr     # Entries of the CCR template
+  strategic-claude-basic-cli cache clean --temp --older-than=1h # Leftover checkouts`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCacheClean()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheDirCmd, cacheListCmd, cacheCleanCmd)
+
+	cacheCleanCmd.Flags().StringVar(&cacheCleanOlderThan, "older-than", "", "only remove entries not used within this age (e.g. 30d, 12h)")
+	cacheCleanCmd.Flags().StringVar(&cacheCleanTemplate, "template", "", "only remove entries of this template")
+	cacheCleanCmd.Flags().BoolVarP(&cacheCleanForce, "force", "f", false, "remove without confirmation")
+	cacheCleanCmd.Flags().BoolVar(&cacheCleanTemp, "temp", false, "remove leftover template checkouts in the temporary directory instead of cache entries")
+	cacheCleanCmd.MarkFlagsMutuallyExclusive("temp", "template")
+
+	if err := cacheCleanCmd.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return templates.GetTemplateIDs(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --template flag: %v\n", err)
+	}
+}
+
+// runCacheList executes the cache list command logic
+func runCacheList() error {
+	cacheService := cache.New()
+
+	entries, err := cacheService.List()
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	if len(entries) == 0 {
+		utils.DisplayInfo("The template cache is empty")
+		return nil
+	}
+
+	var total int64
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TEMPLATE\tCOMMIT\tSIZE\tLAST USED\tREPOSITORY")
+	for _, entry := range entries {
+		templateID := entry.TemplateID
+		if templateID == "" {
+			templateID = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			templateID, shortCommit(entry.Commit), utils.FormatSize(entry.Size),
+			entry.LastUsed.Local().Format(time.DateTime), entry.RepoURL)
+		total += entry.Size
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d cached commit(s), %s in %s\n", len(entries), utils.FormatSize(total), cacheService.Root())
+	return nil
+}
+
+// runCacheClean executes the cache clean command logic
+func runCacheClean() error {
+	opts := cache.CleanOptions{TemplateID: cacheCleanTemplate}
+	if cacheCleanOlderThan != "" {
+		age, err := utils.ParseAge(cacheCleanOlderThan)
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		opts.OlderThan = age
+	}
+	if cacheCleanTemp {
+		return runCacheCleanTemp(opts.OlderThan)
+	}
+
+	cacheService := cache.New()
+
+	if !cacheCleanForce && opts.OlderThan == 0 && opts.TemplateID == "" {
+		confirmed, err := utils.NewInteractionService().ConfirmPrompt(
+			i18n.T("Remove all cached templates in %s?", cacheService.Root()))
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to get user confirmation: %w", err))
+			return err
+		}
+		if !confirmed {
+			utils.DisplayInfo(i18n.T("Cache clean cancelled by user"))
+			return nil
+		}
+	}
+
+	removed, err := cacheService.Clean(opts)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("cache clean failed: %w", err))
+		return err
+	}
+
+	var freed int64
+	for _, entry := range removed {
+		utils.VerbosePrintf(verbose, "Removed %s@%s\n", entry.RepoURL, shortCommit(entry.Commit))
+		freed += entry.Size
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Removed %d cached commit(s), freed %s", len(removed), utils.FormatSize(freed)))
+	return nil
+}
+
+// runCacheCleanTemp removes the template checkouts in the temporary directory
+// not modified within olderThan, or StaleTempDirAge when it is 0
+func runCacheCleanTemp(olderThan time.Duration) error {
+	if olderThan == 0 {
+		olderThan = config.StaleTempDirAge
+	}
+
+	removed, err := cache.CleanTempDirs(olderThan)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("temporary directory clean failed: %w", err))
+		return err
+	}
+
+	var freed int64
+	for _, dir := range removed {
+		utils.VerbosePrintf(verbose, "Removed %s\n", dir.Path)
+		freed += dir.Size
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Removed %d leftover template checkout(s) from %s, freed %s", len(removed), config.TempDir(), utils.FormatSize(freed)))
+	return nil
+}
+
+// removeStaleTempDirs removes the template checkouts that crashed runs left
+// in the temporary directory. It runs quietly when a command starts, so the
+// output of the command stays as it is, and never fails it.
+func removeStaleTempDirs() {
+	_, _ = cache.CleanTempDirs(config.StaleTempDirAge)
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/catalog"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// maxDescriptionWidth keeps catalog tables readable in a terminal
+const maxDescriptionWidth = 80
+
+var catalogOverrideReset bool
+
+var agentsCmd = &cobra.Command{
+	Use:     "agents",
+	Short:   "Inspect the agents available to Claude Code",
+	GroupID: groupInspect,
+	Long: `Inspect, disable and customize the framework and user agents available to
+Claude Code.
+
+Examples:
+  strategic-claude-basic-cli agents list
+  strategic-claude-basic-cli agents disable codebase-analyzer
+  strategic-claude-basic-cli agents override codebase-analyzer`,
+}
+
+var agentsListCmd = &cobra.Command{
+	Use:   "list [directory]",
+	Short: "List framework and user agents",
+	Long: `List the agents provided by the installed template in
+.strategic-claude-basic/core/agents and the ones you added in .claude/agents,
+with the description from their front matter.
+
+Examples:
+  strategic-claude-basic-cli agents list                # Current directory
+  strategic-claude-basic-cli agents list ./my-project   # Specific directory`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogList(catalog.KindAgents, args)
+	},
+}
+
+var commandsCmd = &cobra.Command{
+	Use:     "commands",
+	Short:   "Inspect the slash commands available to Claude Code",
+	GroupID: groupInspect,
+	Long: `Inspect, disable and customize the framework and user slash commands
+available to Claude Code.
+
+Examples:
+  strategic-claude-basic-cli commands list
+  strategic-claude-basic-cli commands disable research/deep
+  strategic-claude-basic-cli commands override research/deep`,
+}
+
+var commandsListCmd = &cobra.Command{
+	Use:   "list [directory]",
+	Short: "List framework and user slash commands",
+	Long: `List the slash commands provided by the installed template in
+.strategic-claude-basic/core/commands and the ones you added in .claude/commands,
+with the description from their front matter.
+
+Examples:
+  strategic-claude-basic-cli commands list                # Current directory
+  strategic-claude-basic-cli commands list ./my-project   # Specific directory`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogList(catalog.KindCommands, args)
+	},
+}
+
+var agentsDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Hide a framework agent from Claude Code",
+	Long: `Hide an agent provided by the installed template without deleting it.
+
+The agent is recorded in .strategic-claude-basic/disabled.json and left out of a
+generated view of core/agents that .claude/agents/strategic then points to. The
+list is kept across core updates. Use the name shown by 'agents list' or the
+file's path below core/agents without the extension.
+
+Examples:
+  strategic-claude-basic-cli agents disable codebase-analyzer
+  strategic-claude-basic-cli agents enable codebase-analyzer`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogToggle(catalog.KindAgents, args[0], false)
+	},
+}
+
+var agentsEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Make a disabled framework agent available again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogToggle(catalog.KindAgents, args[0], true)
+	},
+}
+
+var commandsDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Hide a framework slash command from Claude Code",
+	Long: `Hide a slash command provided by the installed template without deleting it.
+
+The command is recorded in .strategic-claude-basic/disabled.json and left out of
+a generated view of core/commands that .claude/commands/strategic (and
+.codex/prompts/strategic) then point to. The list is kept across core updates.
+Use the name shown by 'commands list'.
+
+Examples:
+  strategic-claude-basic-cli commands disable research/deep
+  strategic-claude-basic-cli commands enable research/deep`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogToggle(catalog.KindCommands, args[0], false)
+	},
+}
+
+var commandsEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Make a disabled framework slash command available again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogToggle(catalog.KindCommands, args[0], true)
+	},
+}
+
+var agentsOverrideCmd = &cobra.Command{
+	Use:   "override <name>",
+	Short: "Copy a framework agent into overrides/ to customize it",
+	Long: `Copy an agent provided by the installed template to
+.strategic-claude-basic/overrides/agents, where you can edit it. Files in
+overrides/agents take precedence over core/agents files of the same relative
+path, and unlike core/ they are kept when the framework is updated.
+
+Use --reset to delete the copy and go back to the framework's version.
+
+Examples:
+  strategic-claude-basic-cli agents override codebase-analyzer
+  strategic-claude-basic-cli agents override --reset codebase-analyzer`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogOverride(catalog.KindAgents, args[0])
+	},
+}
+
+var commandsOverrideCmd = &cobra.Command{
+	Use:   "override <name>",
+	Short: "Copy a framework slash command into overrides/ to customize it",
+	Long: `Copy a slash command provided by the installed template to
+.strategic-claude-basic/overrides/commands, where you can edit it. Files in
+overrides/commands take precedence over core/commands files of the same
+relative path, and unlike core/ they are kept when the framework is updated.
+
+Use --reset to delete the copy and go back to the framework's version.
+
+Examples:
+  strategic-claude-basic-cli commands override research/deep
+  strategic-claude-basic-cli commands override --reset research/deep`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalogOverride(catalog.KindCommands, args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(agentsCmd)
+	agentsCmd.AddCommand(agentsListCmd)
+	agentsCmd.AddCommand(agentsDisableCmd)
+	agentsCmd.AddCommand(agentsEnableCmd)
+	agentsCmd.AddCommand(agentsOverrideCmd)
+	rootCmd.AddCommand(commandsCmd)
+	commandsCmd.AddCommand(commandsListCmd)
+	commandsCmd.AddCommand(commandsDisableCmd)
+	commandsCmd.AddCommand(commandsEnableCmd)
+	commandsCmd.AddCommand(commandsOverrideCmd)
+
+	for _, cmd := range []*cobra.Command{agentsOverrideCmd, commandsOverrideCmd} {
+		cmd.Flags().BoolVar(&catalogOverrideReset, "reset", false, "delete the override and use the framework's version again")
+	}
+}
+
+// runCatalogList executes the agents list and commands list command logic
+func runCatalogList(kind string, args []string) error {
+	target := targetDir
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	entries, err := catalog.New().List(absTarget, kind)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to list %s: %w", kind, err))
+		return err
+	}
+
+	if len(entries) == 0 {
+		utils.DisplayInfo(fmt.Sprintf("No %s found in %s", kind, absTarget))
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if verbose {
+		fmt.Fprintln(writer, "NAME\tSOURCE\tPATH\tDESCRIPTION")
+	} else {
+		fmt.Fprintln(writer, "NAME\tSOURCE\tDESCRIPTION")
+	}
+	for _, entry := range entries {
+		var states []string
+		if entry.Disabled {
+			states = append(states, "disabled")
+		}
+		if entry.Override != "" {
+			states = append(states, "overridden")
+		}
+		source := entry.Source
+		if len(states) > 0 {
+			source += " (" + strings.Join(states, ", ") + ")"
+		}
+		path := entry.Path
+		if entry.Override != "" {
+			path = entry.Override
+		}
+		description := truncateText(entry.Description, maxDescriptionWidth)
+		if verbose {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", entry.Name, source, path, description)
+		} else {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", entry.Name, source, description)
+		}
+	}
+	writer.Flush()
+
+	return nil
+}
+
+// runCatalogToggle executes the disable and enable command logic
+func runCatalogToggle(kind, name string, enable bool) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	catalogService := catalog.New()
+	if enable {
+		relPath, err := catalogService.Enable(absTarget, kind, name)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to enable %s: %w", name, err))
+			return err
+		}
+		utils.DisplaySuccess(fmt.Sprintf("Enabled %s (%s/%s)", name, kind, relPath))
+		return nil
+	}
+
+	entry, err := catalogService.Disable(absTarget, kind, name)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to disable %s: %w", name, err))
+		return err
+	}
+	utils.DisplaySuccess(fmt.Sprintf("Disabled %s (%s)", entry.Name, entry.Path))
+	return nil
+}
+
+// runCatalogOverride executes the override command logic
+func runCatalogOverride(kind, name string) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	catalogService := catalog.New()
+	if catalogOverrideReset {
+		overridePath, err := catalogService.ResetOverride(absTarget, kind, name)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to reset override of %s: %w", name, err))
+			return err
+		}
+		utils.DisplaySuccess(fmt.Sprintf("Deleted %s, using the framework's %s again", overridePath, name))
+		return nil
+	}
+
+	overridePath, err := catalogService.Override(absTarget, kind, name)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to override %s: %w", name, err))
+		return err
+	}
+	utils.DisplaySuccess(fmt.Sprintf("Copied %s to %s", name, overridePath))
+	utils.DisplayInfo("Edit the copy to customize it; it is kept across framework updates")
+	return nil
+}
+
+// truncateText shortens text to at most width runes, marking the cut with an ellipsis
+func truncateText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	ellipsis := []rune(utils.Symbols("…"))
+	if width <= len(ellipsis) {
+		return string(runes[:width])
+	}
+	return string(runes[:width-len(ellipsis)]) + string(ellipsis)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/ci"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciProvider         string
+	ciOutput           string
+	ciBranches         []string
+	ciCLIVersion       string
+	ciGoVersion        string
+	ciWorkingDirectory string
+	ciStdout           bool
+	ciForce            bool
+)
+
+var ciCmd = &cobra.Command{
+	Use:     "ci",
+	Short:   "Check the framework installation in CI",
+	GroupID: groupInstall,
+	Long: `Set up CI checks of the framework installation.
+
+Examples:
+  strategic-claude-basic-cli ci generate                      # Write a GitHub Actions workflow
+  strategic-claude-basic-cli ci generate --provider gitlab --stdout`,
+}
+
+var ciGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a CI workflow that checks the framework installation",
+	Long: `Generate a CI workflow that installs the CLI and runs 'status --quiet' on pull
+or merge requests, so changes that break the framework installation (missing
+symlinks, broken hooks, deleted framework directories) fail CI.
+
+Providers:
+- github: .github/workflows/strategic-claude-basic.yml
+- gitlab: .gitlab/strategic-claude-basic.gitlab-ci.yml, to be included from .gitlab-ci.yml
+
+The check needs the framework committed to the repository, as installed with
+--gitignore-mode=track. Existing files are only replaced with --force.
+
+Examples:
+  strategic-claude-basic-cli ci generate                                  # GitHub Actions
+  strategic-claude-basic-cli ci generate --provider=gitlab                # GitLab CI
+  strategic-claude-basic-cli ci generate --branch=main --cli-version=v0.2.0
+  strategic-claude-basic-cli ci generate --directory=services/api --stdout`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCIGenerate()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+	ciCmd.AddCommand(ciGenerateCmd)
+
+	ciGenerateCmd.Flags().StringVar(&ciProvider, "provider", ci.ProviderGitHub, "CI provider: github or gitlab")
+	ciGenerateCmd.Flags().StringVarP(&ciOutput, "output", "o", "", "workflow file to write (default: the provider's location in the project)")
+	ciGenerateCmd.Flags().StringSliceVar(&ciBranches, "branch", nil, "branches whose pushes are checked too (repeatable; default: every push on github, none on gitlab)")
+	ciGenerateCmd.Flags().StringVar(&ciCLIVersion, "cli-version", ci.DefaultCLIVersion, "CLI version installed in CI")
+	ciGenerateCmd.Flags().StringVar(&ciGoVersion, "go-version", ci.DefaultGoVersion, "Go version used to install the CLI")
+	ciGenerateCmd.Flags().StringVar(&ciWorkingDirectory, "directory", "", "project directory within the repository, when not its root")
+	ciGenerateCmd.Flags().BoolVar(&ciStdout, "stdout", false, "print the workflow instead of writing it")
+	ciGenerateCmd.Flags().BoolVarP(&ciForce, "force", "f", false, "replace an existing workflow file")
+
+	if err := ciGenerateCmd.RegisterFlagCompletionFunc("provider", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return ci.GetProviders(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --provider flag: %v\n", err)
+	}
+}
+
+// runCIGenerate executes the ci generate command logic
+func runCIGenerate() error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	outputPath := ciOutput
+	if outputPath == "" {
+		outputPath = filepath.Join(absTarget, ci.DefaultPath(ciProvider))
+	} else if outputPath, err = filepath.Abs(outputPath); err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve output path: %w", err))
+		return err
+	}
+
+	relPath, err := filepath.Rel(absTarget, outputPath)
+	if err != nil {
+		relPath = outputPath
+	}
+
+	ciService := ci.New()
+	content, err := ciService.Render(ci.Options{
+		Provider:         ciProvider,
+		Branches:         ciBranches,
+		CLIVersion:       ciCLIVersion,
+		GoVersion:        ciGoVersion,
+		WorkingDirectory: ciWorkingDirectory,
+	}, relPath)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	if ciStdout {
+		fmt.Print(string(content))
+		return nil
+	}
+
+	if err := ciService.Write(outputPath, content, ciForce); err != nil {
+		if models.IsErrorCode(err, models.ErrorCodeFileAlreadyExists) {
+			err = fmt.Errorf("%s already exists; use --force to replace it", relPath)
+		}
+		utils.DisplayError(err)
+		return err
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Wrote %s", relPath))
+	if ciProvider == ci.ProviderGitLab {
+		utils.DisplayInfo(fmt.Sprintf("Include it from .gitlab-ci.yml with: include: [{local: %s}]", filepath.ToSlash(relPath)))
+	}
+	return nil
+}
@@ -1,23 +1,38 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cleaner"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
 var (
-	cleanForce bool
+	cleanForce   bool
+	cleanAll     bool
+	cleanReport  string
+	cleanTrash   bool
+	cleanStrict  bool
+	cleanTimeout time.Duration
 )
 
 var cleanCmd = &cobra.Command{
-	Use:   "clean [directory]",
-	Short: "Remove Strategic Claude Basic framework installation",
+	Use:     "clean [directory]",
+	Short:   "Remove Strategic Claude Basic framework installation",
+	GroupID: groupInstall,
 	Long: `Remove Strategic Claude Basic framework files from the specified directory.
 
 This command will:
@@ -26,21 +41,58 @@ This command will:
 - Remove framework-specific entries from CLAUDE.md
 - Preserve user-created content and configurations
 
+With --all, everything the installation left behind is removed as well, so the
+project is back to how it was before the first install:
+- The backups directory and backups written by older versions
+- The ` + config.ConfigFileName + ` project config file
+- The entries added to .claude/.gitignore and to ignore files of other tools
+  such as .dockerignore
+- The cached status of the project
+Shared files are kept while another named installation still uses them, and
+backups configured outside the project are never removed.
+
+--trash moves the framework directory, and with --all the backups and left-over
+files, to the trash instead of deleting them, so they can be restored. Set
+` + config.TrashDirEnvVar + ` or trash_dir in the config file to use a directory of your own.
+
+--report writes a JSON report listing every removed path, every preserved user
+file and suggested follow-ups, such as restoring documents removed with the
+framework directory from an export-user-content archive.
+
+--strict fails the command, with a non-zero exit code and the remaining paths
+listed as errors in the report, when the framework directory, a symlink into it
+or the Cursor rules are still present after the cleanup. Without it they are
+reported as warnings.
+
+--timeout stops the cleanup before its next step once it takes longer than the
+given duration, e.g. 1m; the framework paths that remain are listed, and running
+clean again removes them.
+
 Safety features:
-- Confirmation prompt (unless --force is used)
+- Confirmation prompt (unless --force is used); removing more files than
+  typed_confirm_files in the config asks to type the directory name instead
+- A second prompt, with an offer to run export-user-content first, when the
+  user directories hold more than ` + fmt.Sprint(config.LargeUserContentFiles) + ` documents or ` + utils.FormatSize(config.LargeUserContentBytes) + `
 - Preserves user content in guides/ and templates/ directories
 - Creates backup before removal (unless --no-backup was used during installation)
 
 Examples:
   strategic-claude-basic-cli clean                  # Clean current directory
   strategic-claude-basic-cli clean ./my-project    # Clean specific directory
-  strategic-claude-basic-cli clean --force         # Clean without confirmation`,
+  strategic-claude-basic-cli clean --force         # Clean without confirmation
+  strategic-claude-basic-cli clean --all           # Also remove backups and left-over files
+  strategic-claude-basic-cli clean --report clean.json  # Write what was removed and kept as JSON
+  strategic-claude-basic-cli clean --trash         # Move to the trash instead of deleting
+  strategic-claude-basic-cli clean --force --strict  # Fail unless every framework path is gone`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Determine target directory
 		target := targetDir
 		if len(args) > 0 {
 			target = args[0]
+			if err := loadConfig(cmd, target); err != nil {
+				return err
+			}
 		}
 
 		// Convert to absolute path
@@ -52,10 +104,17 @@ Examples:
 		if verbose {
 			fmt.Printf("Cleaning directory: %s\n", absTarget)
 			fmt.Printf("Force: %v\n", cleanForce)
+			fmt.Printf("All: %v\n", cleanAll)
 		}
 
 		// Initialize services
 		cleanerService := cleaner.New()
+		cleanerService.SetReporter(newReporter())
+		cleanerService.SetAll(cleanAll)
+		cleanerService.SetStrict(cleanStrict)
+		if cleanTrash {
+			cleanerService.SetTrash(trash.New())
+		}
 		statusService := status.NewService()
 		interactionService := utils.NewInteractionService()
 
@@ -76,7 +135,8 @@ Examples:
 
 		hasStrategicContent := statusInfo.StrategicClaudeDir || // Has .strategic-claude-basic
 			hasValidSymlinks || // Has valid or existing strategic symlinks
-			statusInfo.IsInstalled // Fully installed
+			statusInfo.IsInstalled || // Fully installed
+			(cleanAll && cleaner.HasLeftovers(absTarget)) // Backups or files left by an earlier clean
 
 		if !hasStrategicContent {
 			utils.DisplayWarning("No Strategic Claude Basic installation found")
@@ -85,26 +145,60 @@ Examples:
 
 		// Confirm cleanup operation unless --force is used
 		if !cleanForce {
-			confirmed, err := interactionService.ConfirmCleanup(absTarget)
+			files := countFiles(filepath.Join(absTarget, config.FrameworkDir()))
+			if cleanAll {
+				files += countFiles(config.GetBackupsRoot(absTarget))
+			}
+			confirmed, err := interactionService.ConfirmCleanup(absTarget, cleanAll, files)
 			if err != nil {
 				return fmt.Errorf("failed to get user confirmation: %w", err)
 			}
 			if !confirmed {
-				fmt.Println("Cleanup cancelled by user")
+				fmt.Println(i18n.T("Cleanup cancelled by user"))
+				return nil
+			}
+
+			confirmed, err = confirmLargeUserContent(absTarget, interactionService)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println(i18n.T("Cleanup cancelled by user"))
 				return nil
 			}
 		}
 
-		// Perform cleanup
+		// Perform cleanup; one stopped at the deadline is reported like any other
+		ctx, cancel := operationContext(cleanTimeout)
+		defer cancel()
+		cleanerService.SetContext(ctx)
 		result, err := cleanerService.RemoveInstallation(absTarget)
-		if err != nil {
+		if err != nil && !models.IsErrorCode(err, models.ErrorCodeOperationTimeout) {
 			return fmt.Errorf("cleanup failed: %w", err)
 		}
+		timeoutErr := err
+		if result.Success {
+			forgetManaged(absTarget)
+		}
 
 		// Display results
 		displayCleanupResults(result, verbose)
 
+		if cleanReport != "" {
+			if err := cleaner.NewReport(absTarget, result).WriteFile(cleanReport); err != nil {
+				utils.DisplayError(err)
+				return err
+			}
+			utils.DisplayInfo(fmt.Sprintf("Cleanup report written to %s", cleanReport))
+		}
+
+		if timeoutErr != nil {
+			return fmt.Errorf("cleanup failed: %w", timeoutErr)
+		}
 		if !result.Success {
+			if cleanStrict && len(result.Remaining) > 0 {
+				return cleanerService.ValidateCleanup(absTarget)
+			}
 			return fmt.Errorf("cleanup completed with errors")
 		}
 
@@ -116,6 +210,11 @@ func init() {
 	rootCmd.AddCommand(cleanCmd)
 
 	cleanCmd.Flags().BoolVarP(&cleanForce, "force", "f", false, "force cleanup without confirmation")
+	cleanCmd.Flags().BoolVar(&cleanAll, "all", false, "also remove backups, the project config file and .gitignore entries")
+	cleanCmd.Flags().StringVar(&cleanReport, "report", "", "write a JSON report of removed and preserved paths to this file")
+	cleanCmd.Flags().BoolVar(&cleanTrash, "trash", false, "move the removed directories and files to the trash instead of deleting them")
+	cleanCmd.Flags().BoolVar(&cleanStrict, "strict", false, "fail when framework directories or symlinks remain after the cleanup")
+	cleanCmd.Flags().DurationVar(&cleanTimeout, "timeout", 0, "stop the cleanup before its next step when it takes longer than this, e.g. 1m (default: no limit)")
 
 	// Custom completion for directory argument
 	cleanCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -124,6 +223,49 @@ func init() {
 		}
 		return []string{}, cobra.ShellCompDirectiveNoFileComp
 	}
+
+	addAlias(cleanCmd, "uninstall", "")
+}
+
+// countFiles returns how many files are below dir, 0 when it does not exist
+func countFiles(dir string) int {
+	files := 0
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files++
+		}
+		return nil
+	})
+	return files
+}
+
+// confirmLargeUserContent asks before removing more user documents than
+// clean removes without asking, offering to export them first
+func confirmLargeUserContent(absTarget string, interactionService *utils.InteractionService) (bool, error) {
+	summary := cleaner.ScanUserContent(absTarget)
+	if !summary.Large() {
+		return true, nil
+	}
+
+	fmt.Println()
+	utils.DisplayWarning(i18n.T("%s holds %d user document(s) (%s) that will be removed with it",
+		config.FrameworkDir(), summary.Files, utils.FormatSize(summary.Bytes)))
+
+	export, err := interactionService.ConfirmPrompt(i18n.T("Export them with export-user-content first?"))
+	if err != nil {
+		return false, fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	if export {
+		if err := runExportUserContent(absTarget); err != nil {
+			return false, err
+		}
+	}
+
+	confirmed, err := interactionService.ConfirmPrompt(i18n.T("Remove the %d user document(s)?", summary.Files))
+	if err != nil {
+		return false, fmt.Errorf("failed to get user confirmation: %w", err)
+	}
+	return confirmed, nil
 }
 
 // displayCleanupResults shows the results of the cleanup operation
@@ -132,43 +274,92 @@ func displayCleanupResults(result *cleaner.CleanupResult, verbose bool) {
 
 	if result.Success {
 		if result.RemovedDirectory {
-			utils.DisplaySuccess("Removed .strategic-claude-basic directory")
+			utils.DisplaySuccess(i18n.T("Removed %s directory", config.FrameworkDir()))
+		}
+
+		if len(result.Trashed) > 0 {
+			utils.DisplayInfo(i18n.T("Moved %d path(s) to the trash instead of deleting them", len(result.Trashed)))
+			if verbose {
+				for _, path := range slices.Sorted(maps.Keys(result.Trashed)) {
+					fmt.Printf(utils.Symbols("  • %s → %s\n"), path, result.Trashed[path])
+				}
+			}
+		}
+
+		if symlinks := result.SymlinkPaths(); len(symlinks) > 0 {
+			utils.DisplaySuccess(i18n.T("Removed %d Strategic Claude symlink(s)", len(symlinks)))
+			if verbose {
+				for _, symlink := range symlinks {
+					fmt.Printf(utils.Symbols("  • %s\n"), symlink)
+				}
+			}
+		}
+
+		if result.RemovedSettingsFile {
+			utils.DisplaySuccess(i18n.T("Removed %s/%s, which was empty after removing the framework hooks", config.ClaudeDir, config.ClaudeSettingsFile))
+		} else if result.CleanedSettings {
+			utils.DisplaySuccess(i18n.T("Removed the framework hooks from %s/%s", config.ClaudeDir, config.ClaudeSettingsFile))
 		}
 
-		if len(result.RemovedSymlinks) > 0 {
-			utils.DisplaySuccess(fmt.Sprintf("Removed %d Strategic Claude symlink(s)", len(result.RemovedSymlinks)))
+		if len(result.RemovedUserContent) > 0 {
+			utils.DisplayWarning(i18n.T("Removed %d user document(s) with %s", len(result.RemovedUserContent), config.FrameworkDir()))
 			if verbose {
-				for _, symlink := range result.RemovedSymlinks {
-					fmt.Printf("  • %s\n", symlink)
+				for _, file := range result.RemovedUserContent {
+					fmt.Printf(utils.Symbols("  • %s\n"), file)
+				}
+			}
+		}
+
+		if result.RemovedCursorRules {
+			utils.DisplaySuccess(i18n.T("Removed Cursor rules"))
+		}
+
+		if result.CleanedEnvrc {
+			utils.DisplaySuccess(i18n.T("Removed the Strategic Claude Basic block from .envrc"))
+		}
+
+		for _, file := range result.RemovedToolConfigs {
+			utils.DisplaySuccess(i18n.T("Removed framework conventions from %s", file))
+		}
+
+		if result.RemovedBackups {
+			utils.DisplaySuccess(i18n.T("Removed backups"))
+		}
+
+		if len(result.RemovedFiles) > 0 {
+			utils.DisplaySuccess(i18n.T("Removed %d left-over file(s)", len(result.RemovedFiles)))
+			if verbose {
+				for _, file := range result.RemovedFiles {
+					fmt.Printf(utils.Symbols("  • %s\n"), file)
 				}
 			}
 		}
 
 		if len(result.CleanedDirectories) > 0 {
-			utils.DisplaySuccess(fmt.Sprintf("Cleaned up %d empty director(ies)", len(result.CleanedDirectories)))
+			utils.DisplaySuccess(i18n.T("Cleaned up %d empty director(ies)", len(result.CleanedDirectories)))
 			if verbose {
 				for _, dir := range result.CleanedDirectories {
-					fmt.Printf("  • %s\n", dir)
+					fmt.Printf(utils.Symbols("  • %s\n"), dir)
 				}
 			}
 		}
 
 		if len(result.PreservedFiles) > 0 {
-			utils.DisplayInfo(fmt.Sprintf("Preserved %d user file(s)", len(result.PreservedFiles)))
+			utils.DisplayInfo(i18n.T("Preserved %d user file(s)", len(result.PreservedFiles)))
 			if verbose {
 				for _, file := range result.PreservedFiles {
-					fmt.Printf("  • %s\n", file)
+					fmt.Printf(utils.Symbols("  • %s\n"), file)
 				}
 			}
 		}
 
-		if len(result.RemovedSymlinks) == 0 && !result.RemovedDirectory && len(result.CleanedDirectories) == 0 {
-			utils.DisplayInfo("No Strategic Claude Basic installation found to clean")
+		if len(result.SymlinkPaths()) == 0 && !result.RemovedDirectory && !result.RemovedCursorRules && len(result.RemovedToolConfigs) == 0 && !result.CleanedEnvrc && len(result.CleanedDirectories) == 0 && !result.RemovedBackups && len(result.RemovedFiles) == 0 {
+			utils.DisplayInfo(i18n.T("No Strategic Claude Basic installation found to clean"))
 		} else {
-			utils.DisplaySuccess("Strategic Claude Basic cleanup completed successfully")
+			utils.DisplaySuccess(i18n.T("Strategic Claude Basic cleanup completed successfully"))
 		}
 	} else {
-		utils.DisplayError(fmt.Errorf("cleanup completed with errors"))
+		utils.DisplayError(errors.New(i18n.T("cleanup completed with errors")))
 	}
 
 	// Display warnings
@@ -180,4 +371,11 @@ func displayCleanupResults(result *cleaner.CleanupResult, verbose bool) {
 	for _, err := range result.Errors {
 		utils.DisplayError(fmt.Errorf("%s", err))
 	}
+
+	if len(result.FollowUps) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("Next steps:"))
+		for _, step := range result.FollowUps {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
 }
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cleaner"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/scaffold"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
 )
 
@@ -98,6 +103,49 @@ func TestDisplayCleanupResults(t *testing.T) {
 	displayCleanupResults(result, true)
 }
 
+func TestCleanCommand_ReportMatchesSummary(t *testing.T) {
+	t.Setenv(config.CacheDirEnvVar, t.TempDir())
+	t.Setenv(config.DataDirEnvVar, t.TempDir())
+	t.Setenv(config.NoUpdateCheckEnvVar, "1")
+	t.Setenv(config.ASCIIEnvVar, "")
+
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	target := filepath.Join(t.TempDir(), "project")
+	if err := os.Mkdir(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runCommand(t, "init", "--dev", "--template-path", checkout, "--yes", "--integrations", "claude,codex", target)
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	output := runCommand(t, "clean", "--force", "--report", reportPath, target)
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var report cleaner.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	var symlinks []string
+	for _, entry := range report.Removed {
+		if entry.Kind == cleaner.EntrySymlink {
+			symlinks = append(symlinks, entry.Path)
+		}
+	}
+	if !slices.ContainsFunc(symlinks, func(path string) bool { return strings.HasPrefix(path, config.CodexDir+"/") }) {
+		t.Errorf("report symlinks = %v, want the %s links", symlinks, config.CodexDir)
+	}
+
+	want := fmt.Sprintf("Removed %d Strategic Claude symlink(s)", len(symlinks))
+	if !strings.Contains(output, want) {
+		t.Errorf("clean output is missing %q:\n%s", want, output)
+	}
+}
+
 // setupTestInstallation creates a test installation
 func setupTestInstallation(t *testing.T, tmpDir string) {
 	fsService := filesystem.New()
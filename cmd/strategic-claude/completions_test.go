@@ -3,9 +3,15 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/registry"
+
 	"github.com/spf13/cobra"
 )
 
@@ -207,6 +213,9 @@ func TestTargetFlagCompletion(t *testing.T) {
 }
 
 func TestDirectoryArgumentCompletion(t *testing.T) {
+	// Without managed projects, directories are completed
+	t.Setenv(config.DataDirEnvVar, t.TempDir())
+
 	tests := []struct {
 		name         string
 		cmd          *cobra.Command
@@ -283,6 +292,61 @@ func TestDirectoryArgumentCompletion(t *testing.T) {
 	}
 }
 
+func TestProjectDirCompletion(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv(config.DataDirEnvVar, dataDir)
+	projects := t.TempDir()
+	api := filepath.Join(projects, "api")
+	web := filepath.Join(projects, "web")
+	if err := os.Mkdir(api, 0755); err != nil {
+		t.Fatal(err)
+	}
+	registryService := registry.NewWithPath(filepath.Join(dataDir, config.RegistryFile))
+	for _, project := range []string{api, web} {
+		if err := registryService.Add(project, "main"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// web no longer exists and is not offered
+	for _, cmd := range []*cobra.Command{statusCmd, updateCmd} {
+		completions, directive := cmd.ValidArgsFunction(cmd, []string{}, projects)
+		if !reflect.DeepEqual(completions, []string{api}) || directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("%s completions = %v, %d, want the managed project", cmd.Name(), completions, directive)
+		}
+
+		completions, directive = cmd.ValidArgsFunction(cmd, []string{}, "./sub")
+		if len(completions) != 0 || directive != cobra.ShellCompDirectiveFilterDirs {
+			t.Errorf("%s completions of a relative path = %v, %d, want directories", cmd.Name(), completions, directive)
+		}
+	}
+}
+
+func TestInstanceFlagCompletion(t *testing.T) {
+	project := t.TempDir()
+	for _, dir := range []string{config.InstanceDir("docs"), config.InstanceDir("ops")} {
+		if err := os.MkdirAll(filepath.Join(project, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(project, dir, config.TemplateInfoFile), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Without template metadata it is not an installation
+	if err := os.MkdirAll(filepath.Join(project, config.InstanceDir("empty")), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	instanceCompFunc, exists := rootCmd.GetFlagCompletionFunc("instance")
+	if !exists {
+		t.Fatal("Expected --instance flag to have a completion function")
+	}
+	completions, directive := instanceCompFunc(statusCmd, []string{project}, "")
+	if !reflect.DeepEqual(completions, []string{"docs", "ops"}) || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("--instance completions = %v, %d, want the instances of the directory argument", completions, directive)
+	}
+}
+
 func TestCompletionsCommandHelp(t *testing.T) {
 	// Test that the help text contains installation instructions for all supported shells
 	expectedShells := []string{"bash", "zsh", "fish", "powershell"}
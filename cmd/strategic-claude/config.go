@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:     "config",
+	Short:   "Show the configuration commands run with",
+	GroupID: groupInspect,
+	Long: `Show the configuration commands run with.
+
+Settings are read in this order, later sources taking precedence:
+- the defaults
+- the user config file: --config, $` + config.ConfigFileEnvVar + ` or ` + config.UserConfigDirName + `/` + config.UserConfigFile + `
+  in the user config directory
+- ` + config.ConfigFileName + ` in the target directory
+- the environment: $` + config.FrameworkDirEnvVar + `, $` + config.BackupsDirEnvVar + `, $` + config.TrashDirEnvVar + `,
+  $` + config.TempDirEnvVar + `, $` + config.GitTimeoutEnvVar + ` and $` + config.RequireGitRepoEnvVar + `
+- flags such as --framework-dir and --temp-dir
+
+Both config files are JSON objects with any of the keys framework_dir,
+backups_dir, trash_dir, temp_dir, git_timeout, status_cache_ttl, max_backups,
+max_backup_age, require_git_repo, template_warn_bytes, template_max_bytes,
+template_warn_files, template_max_files, typed_confirm_files and script_hashes;
+durations are written like "45s" or "720h", and script_hashes maps
+pre-install.sh and post-install.sh to the SHA-256 they must have to be run by
+init. The template limits make init warn about, or stop before, templates with
+more bytes or files; 0 turns a limit off. An overwrite or clean replacing or
+removing more than typed_confirm_files files asks to type the directory name
+instead of y/N; 0 always asks y/N.
+
+Only the user config file may also set plugins, which maps the points
+` + config.PluginPointPreInstall + `, ` + config.PluginPointPostCopy + ` and ` + config.PluginPointPostInstall + ` to absolute paths of executables init
+runs there, and plugins_dir, the directory searched for more of them
+(default: ` + config.PluginsDirName + ` next to the user config file; see 'init --help').`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration as JSON",
+	Long: `Print the configuration commands run with, after reading every source, as JSON.
+
+Examples:
+  strategic-claude-basic-cli config show
+  strategic-claude-basic-cli config show --target ./my-project --framework-dir .ai`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := json.MarshalIndent(config.Current(), "", "  ")
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the locations of the config files",
+	Long: `Print the locations of the user and project config files, and whether they exist.
+
+Examples:
+  strategic-claude-basic-cli config path
+  strategic-claude-basic-cli config path --config ./ci-config.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userConfigPath := configFile
+		if userConfigPath == "" {
+			userConfigPath = config.UserConfigPath()
+		}
+		absTarget, err := filepath.Abs(targetDir)
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+
+		fmt.Printf("user:    %s\n", userConfigPath)
+		fmt.Printf("project: %s\n", config.ProjectConfigPath(absTarget))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd, configPathCmd)
+}
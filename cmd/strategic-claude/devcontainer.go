@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/devcontainer"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	devcontainerTemplateID string
+	devcontainerCommand    string
+)
+
+var devcontainerCmd = &cobra.Command{
+	Use:     "devcontainer",
+	Short:   "Install the framework automatically in dev containers",
+	GroupID: groupInstall,
+	Long: `Add the framework to .devcontainer/devcontainer.json so containers get it
+installed on first start and the core updated on every rebuild.
+
+The following is merged into the file, which is created if needed:
+- postCreateCommand runs 'init --force-core --yes' with the project's template;
+  an existing command is kept and both run
+- A volume mounted at /var/cache/strategic-claude-basic keeps fetched templates
+  across rebuilds, and containerEnv points $SCB_CACHE_DIR at it
+
+Other settings are kept. Comments are not, so the previous file is backed up.
+The CLI must be available in the container image.
+
+Examples:
+  strategic-claude-basic-cli devcontainer                     # Use the installed template
+  strategic-claude-basic-cli devcontainer --template=ccr      # Install the CCR template
+  strategic-claude-basic-cli devcontainer --command="make scb" # Run a custom command`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDevcontainer()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(devcontainerCmd)
+
+	devcontainerCmd.Flags().StringVar(&devcontainerTemplateID, "template", "", "template the container installs (default: installed template)")
+	devcontainerCmd.Flags().StringVar(&devcontainerCommand, "command", "", "postCreateCommand to run instead of init")
+	devcontainerCmd.MarkFlagsMutuallyExclusive("template", "command")
+
+	if err := devcontainerCmd.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return templates.GetTemplateIDs(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --template flag: %v\n", err)
+	}
+}
+
+// runDevcontainer executes the devcontainer command logic
+func runDevcontainer() error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	command := devcontainerCommand
+	if command == "" {
+		templateID, err := resolveDevcontainerTemplateID(absTarget)
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		command = fmt.Sprintf("%s --template=%s", devcontainer.DefaultCommand, templateID)
+	}
+
+	result, err := devcontainer.New().Apply(absTarget, command)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	relPath := filepath.Join(config.DevcontainerDir, config.DevcontainerFile)
+	if len(result.Changes) == 0 {
+		utils.DisplayInfo(fmt.Sprintf("%s already installs Strategic Claude Basic", relPath))
+		return nil
+	}
+
+	for _, change := range result.Changes {
+		fmt.Printf(utils.Symbols("  • %s\n"), change)
+	}
+	if result.BackupPath != "" {
+		utils.VerbosePrintf(verbose, "Previous %s backed up to %s\n", config.DevcontainerFile, result.BackupPath)
+	}
+
+	if result.Created {
+		utils.DisplaySuccess(fmt.Sprintf("Created %s", relPath))
+	} else {
+		utils.DisplaySuccess(fmt.Sprintf("Updated %s", relPath))
+	}
+	utils.DisplayInfo(fmt.Sprintf("Make sure the container image provides the %s executable", config.BinaryName))
+	return nil
+}
+
+// resolveDevcontainerTemplateID returns the template containers install: the
+// requested one, else the installed one, else the default
+func resolveDevcontainerTemplateID(absTarget string) (string, error) {
+	if devcontainerTemplateID != "" {
+		if err := templates.ValidateTemplateID(devcontainerTemplateID); err != nil {
+			return "", fmt.Errorf("invalid template ID '%s': %w", devcontainerTemplateID, err)
+		}
+		return devcontainerTemplateID, nil
+	}
+
+	statusInfo, err := status.NewService().CheckInstallation(absTarget)
+	if err == nil && statusInfo.InstalledTemplate != nil && statusInfo.InstalledTemplate.Template.ID != "" {
+		return statusInfo.InstalledTemplate.Template.ID, nil
+	}
+
+	return templates.DefaultTemplateID, nil
+}
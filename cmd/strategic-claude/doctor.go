@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/migrate"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFixPermissions bool
+	doctorMigrate        bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:     "doctor [directory]",
+	Short:   "Diagnose and repair a Strategic Claude Basic installation",
+	GroupID: groupInspect,
+	Long: `Diagnose problems with a Strategic Claude Basic installation.
+
+This command will:
+- Report the issues found by 'status', including hooks that cannot run
+- Probe the file system for symlink, hardlink and reflink support and case
+  sensitivity
+- Detect layouts left by older versions: installations made before .codex
+  support, missing core subdirectories and symlinks with outdated targets
+- Check file and directory permissions in .strategic-claude-basic and .claude
+
+With --migrate, older layouts are brought up to date: the integrations are
+recorded, missing directories created, symlinks rewritten and backups left
+outside the backups directory moved into it. 'update' migrates them as well.
+
+Directories are expected to use mode 0755 and files mode 0644. Files that are
+already executable and scripts in hooks directories keep their execute bits.
+Symlinks are not followed. When the installation was made with --readonly-core,
+files in core, guides and templates are expected to be read-only (0444, or 0555
+for executables), so --fix-permissions re-asserts it after an editor or tool made
+them writable.
+
+Examples:
+  strategic-claude-basic-cli doctor                     # Diagnose current directory
+  strategic-claude-basic-cli doctor ./my-project        # Diagnose specific directory
+  strategic-claude-basic-cli doctor --fix-permissions   # Normalize file and directory modes
+  strategic-claude-basic-cli doctor --migrate           # Migrate an older layout`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runDoctor(target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFixPermissions, "fix-permissions", false, "normalize file and directory modes and report every change")
+	doctorCmd.Flags().BoolVar(&doctorMigrate, "migrate", false, "migrate layouts left by older versions and report every step")
+}
+
+// runDoctor executes the doctor command logic
+func runDoctor(target string) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Checking directory: %s\n", absTarget)
+
+	statusInfo, err := status.NewService().CheckInstallation(absTarget)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to check installation status: %w", err))
+		return err
+	}
+
+	if !statusInfo.IsInstalled {
+		utils.DisplayWarning("Strategic Claude Basic is not installed in this directory")
+		return nil
+	}
+
+	if doctorMigrate {
+		applied, err := migrate.New().Migrate(absTarget)
+		for _, step := range applied {
+			fmt.Printf(utils.Symbols("  • %s: %s\n"), step.Layout, step.Description)
+		}
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to migrate legacy layout: %w", err))
+			return err
+		}
+		if len(applied) == 0 {
+			utils.DisplaySuccess("The installation already has the current layout")
+		} else {
+			utils.DisplaySuccess(fmt.Sprintf("Applied %d migration step(s)", len(applied)))
+		}
+		fmt.Println()
+
+		// Report what is left after the migration
+		statusInfo, err = status.NewService().CheckInstallation(absTarget)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to check installation status: %w", err))
+			return err
+		}
+	}
+
+	if statusInfo.HasIssues() {
+		fmt.Printf("Installation issues:\n")
+		for _, issue := range statusInfo.Issues {
+			fmt.Printf(utils.Symbols("  • %s\n"), issue)
+		}
+		fmt.Println()
+	}
+
+	if capabilities, err := filesystem.ProbeCapabilities(absTarget); err != nil {
+		utils.DisplayWarning(fmt.Sprintf("Could not probe the file system: %v", err))
+	} else {
+		fmt.Printf("File system: %s\n", capabilities)
+		if !capabilities.Symlinks {
+			utils.DisplayWarning("The file system does not support symlinks; the framework links cannot work here")
+		}
+		fmt.Println()
+	}
+
+	filesystemService := filesystem.New()
+	roots := []string{
+		filepath.Join(absTarget, config.FrameworkDir()),
+		filepath.Join(absTarget, config.ClaudeDir),
+	}
+	var readOnly []string
+	if statusInfo.InstalledTemplate != nil && statusInfo.InstalledTemplate.ReadOnlyCore {
+		for _, dir := range config.GetCoreDirectories() {
+			readOnly = append(readOnly, filepath.Join(roots[0], dir))
+		}
+	}
+
+	var total int
+	for _, root := range roots {
+		changes, err := filesystemService.NormalizePermissions(root, doctorFixPermissions, readOnly...)
+		for _, change := range changes {
+			relPath, relErr := filepath.Rel(absTarget, change.Path)
+			if relErr != nil {
+				relPath = change.Path
+			}
+			fmt.Printf(utils.Symbols("  %s: %04o → %04o\n"), relPath, change.OldMode, change.NewMode)
+		}
+		total += len(changes)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("permission check failed: %w", err))
+			return err
+		}
+	}
+
+	switch {
+	case total == 0:
+		utils.DisplaySuccess("All file and directory permissions are normal")
+	case doctorFixPermissions:
+		utils.DisplaySuccess(fmt.Sprintf("Normalized permissions of %d path(s)", total))
+	default:
+		utils.DisplayWarning(fmt.Sprintf("%d path(s) have unexpected permissions", total))
+		utils.DisplayInfo("Run 'doctor --fix-permissions' to normalize them")
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var errorsCmd = &cobra.Command{
+	Use:     "errors",
+	Short:   "Explain error codes and how to resolve them",
+	GroupID: groupInspect,
+	Long: `Explain the error codes commands fail with, such as NETWORK_ERROR, and how to
+resolve them.
+
+Examples:
+  strategic-claude-basic-cli errors explain                 # List all codes
+  strategic-claude-basic-cli errors explain NETWORK_ERROR   # Explain one`,
+}
+
+var errorsExplainCmd = &cobra.Command{
+	Use:   "explain <CODE>",
+	Short: "Explain an error code and how to resolve it",
+	Long: `Explain an error code shown in a failure, such as GIT_AUTH_FAILED, and list the
+steps that usually resolve it and the README sections to read.
+
+Codes are matched ignoring case, and dashes may be used for underscores.
+Run without a code to list all codes. With --verbose, the steps are also
+printed automatically after a command fails.
+
+Examples:
+  strategic-claude-basic-cli errors explain GIT_AUTH_FAILED
+  strategic-claude-basic-cli errors explain permission-denied
+  strategic-claude-basic-cli errors explain                    # List all codes`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var codes []string
+		for _, code := range models.AllErrorCodes() {
+			codes = append(codes, string(code))
+		}
+		return codes, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runErrorsExplain(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(errorsCmd)
+	errorsCmd.AddCommand(errorsExplainCmd)
+}
+
+// runErrorsExplain executes the errors explain command logic
+func runErrorsExplain(args []string) error {
+	if len(args) == 0 {
+		for _, code := range models.AllErrorCodes() {
+			explanation, _ := models.ExplainErrorCode(string(code))
+			fmt.Printf("  %-26s %s\n", code, explanation.Description)
+		}
+		return nil
+	}
+
+	explanation, ok := models.ExplainErrorCode(args[0])
+	if !ok {
+		return models.NewValidationError("code", args[0],
+			"unknown error code; run 'errors explain' to list all codes")
+	}
+
+	fmt.Printf("%s\n\n%s\n", explanation.Code, explanation.Description)
+	if explanation.Message != "" && explanation.Message != explanation.Description {
+		fmt.Printf("\n%s\n", explanation.Message)
+	}
+	writeRemediation(os.Stdout, explanation)
+	return nil
+}
+
+// displayRemediation prints how to resolve a failure, when err carries an error code
+func displayRemediation(err error) {
+	explanation, ok := models.GetRemediation(err)
+	if !ok {
+		return
+	}
+	writeRemediation(os.Stderr, explanation)
+	fmt.Fprintf(os.Stderr, "\n%s\n", i18n.T("Run 'strategic-claude-basic-cli errors explain %s' for details.", explanation.Code))
+}
+
+// writeRemediation writes the remediation steps and docs of an explanation
+func writeRemediation(w io.Writer, explanation models.ErrorExplanation) {
+	if len(explanation.Remediation) > 0 {
+		fmt.Fprintf(w, "\n%s\n", i18n.T("To resolve it:"))
+		for _, step := range explanation.Remediation {
+			fmt.Fprintf(w, utils.Symbols("  • %s\n"), step)
+		}
+	}
+	if len(explanation.Docs) > 0 {
+		fmt.Fprintf(w, "\n%s\n", i18n.T("See README.md: %s", strings.Join(explanation.Docs, ", ")))
+	}
+}
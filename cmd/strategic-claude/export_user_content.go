@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/usercontent"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput  string
+	exportInclude []string
+)
+
+var exportUserContentCmd = &cobra.Command{
+	Use:     "export-user-content [directory]",
+	Short:   "Archive your plans, research and other user content",
+	GroupID: groupMaintenance,
+	Long: `Pack the user directories of an installation into a tar.gz archive.
+
+The archive contains the directories that updates preserve (archives, decisions,
+issues, plan, product, research, summary, tools, validation), so your work can be
+kept before a project is cleaned or reinstalled.
+
+Use --include to export only part of it. Patterns without a slash match any path
+element, e.g. "plan" or "*.md"; patterns with a slash match paths relative to
+.strategic-claude-basic, e.g. "research/2024-*". Files matching any pattern are
+exported.
+
+Examples:
+  strategic-claude-basic-cli export-user-content                       # Export everything
+  strategic-claude-basic-cli export-user-content ./my-project          # Export a specific project
+  strategic-claude-basic-cli export-user-content --include=plan --include=research
+  strategic-claude-basic-cli export-user-content --include='*.md' -o notes.tar.gz`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runExportUserContent(target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportUserContentCmd)
+
+	exportUserContentCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default: strategic-claude-user-content-<timestamp>.tar.gz)")
+	exportUserContentCmd.Flags().StringSliceVar(&exportInclude, "include", nil, "only export files matching these patterns (repeatable)")
+}
+
+// runExportUserContent executes the export-user-content command logic
+func runExportUserContent(target string) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	output := exportOutput
+	if output == "" {
+		output = usercontent.DefaultArchiveName()
+	}
+	absOutput, err := filepath.Abs(output)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve output path: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Exporting user content of %s\n", absTarget)
+	if len(exportInclude) > 0 {
+		utils.VerbosePrintf(verbose, "Include patterns: %s\n", strings.Join(exportInclude, ", "))
+	}
+
+	manifest, err := usercontent.New().Export(absTarget, absOutput, exportInclude)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("export failed: %w", err))
+		return err
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Exported %d file(s) from %s to %s",
+		manifest.Files, strings.Join(manifest.Directories, ", "), absOutput))
+
+	return nil
+}
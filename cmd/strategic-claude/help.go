@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Groups of the commands in the help, in the order they are listed
+const (
+	groupInstall     = "install"
+	groupInspect     = "inspect"
+	groupMaintenance = "maintenance"
+	groupAuthoring   = "authoring"
+	aliasGroupID     = "aliases"
+)
+
+// helpJSONFlag prints the command tree instead of running a command
+const helpJSONFlag = "help-json"
+
+var helpJSON bool
+
+// helpCommand is a command in the --help-json output
+type helpCommand struct {
+	Name       string        `json:"name"`
+	Path       string        `json:"path"`
+	Use        string        `json:"use"`
+	Short      string        `json:"short"`
+	Long       string        `json:"long,omitempty"`
+	Group      string        `json:"group,omitempty"`
+	Aliases    []string      `json:"aliases,omitempty"`
+	Runnable   bool          `json:"runnable"`
+	Deprecated string        `json:"deprecated,omitempty"`
+	Flags      []helpFlag    `json:"flags"`
+	Commands   []helpCommand `json:"commands,omitempty"`
+}
+
+// helpFlag is a flag in the --help-json output
+type helpFlag struct {
+	Name       string `json:"name"`
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type"`
+	Default    string `json:"default,omitempty"`
+	Usage      string `json:"usage"`
+	Persistent bool   `json:"persistent,omitempty"` // Inherited by subcommands
+}
+
+func init() {
+	rootCmd.AddGroup(
+		&cobra.Group{ID: groupInstall, Title: "Install & Update:"},
+		&cobra.Group{ID: groupInspect, Title: "Inspect:"},
+		&cobra.Group{ID: groupMaintenance, Title: "Maintenance:"},
+		&cobra.Group{ID: groupAuthoring, Title: "Authoring:"},
+		&cobra.Group{ID: aliasGroupID, Title: "Aliases:"},
+	)
+
+	// Handled by Execute before the command runs, like --help
+	rootCmd.PersistentFlags().BoolVar(&helpJSON, helpJSONFlag, false, "print the command and its subcommands, with their flags, as JSON for documentation tools")
+}
+
+// helpJSONCommand returns the command --help-json was given for in args, the
+// command line without the program name
+func helpJSONCommand(args []string) (*cobra.Command, bool) {
+	found := false
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--"+helpJSONFlag || arg == "--"+helpJSONFlag+"=true" {
+			found = true
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		// Unknown subcommands describe the command they were given to
+		return rootCmd, true
+	}
+	return cmd, true
+}
+
+// writeHelpJSON writes cmd and its subcommands as JSON
+func writeHelpJSON(w io.Writer, cmd *cobra.Command) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(describeCommand(cmd))
+}
+
+// describeCommand returns cmd and its available subcommands for --help-json
+func describeCommand(cmd *cobra.Command) helpCommand {
+	described := helpCommand{
+		Name:       cmd.Name(),
+		Path:       cmd.CommandPath(),
+		Use:        cmd.Use,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Group:      cmd.GroupID,
+		Aliases:    cmd.Aliases,
+		Runnable:   cmd.Runnable(),
+		Deprecated: cmd.Deprecated,
+		Flags:      []helpFlag{},
+	}
+
+	persistent := cmd.PersistentFlags()
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		described.Flags = append(described.Flags, helpFlag{
+			Name:       flag.Name,
+			Shorthand:  flag.Shorthand,
+			Type:       flag.Value.Type(),
+			Default:    flag.DefValue,
+			Usage:      flag.Usage,
+			Persistent: persistent.Lookup(flag.Name) != nil,
+		})
+	})
+	sort.Slice(described.Flags, func(i, j int) bool { return described.Flags[i].Name < described.Flags[j].Name })
+
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			described.Commands = append(described.Commands, describeCommand(sub))
+		}
+	}
+	return described
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestHelpJSONCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+		ok   bool
+	}{
+		{name: "root", args: []string{"--help-json"}, want: "strategic-claude-basic-cli", ok: true},
+		{name: "subcommand", args: []string{"backup", "restore", "--help-json", "--path", "x"}, want: "restore", ok: true},
+		{name: "before the command", args: []string{"--verbose", "--help-json", "status"}, want: "status", ok: true},
+		{name: "after --", args: []string{"new", "--", "--help-json"}, ok: false},
+		{name: "absent", args: []string{"status", "--verbose"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, ok := helpJSONCommand(tt.args)
+			if ok != tt.ok {
+				t.Fatalf("helpJSONCommand(%v) ok = %v, want %v", tt.args, ok, tt.ok)
+			}
+			if ok && cmd.Name() != tt.want {
+				t.Errorf("helpJSONCommand(%v) = %s, want %s", tt.args, cmd.Name(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteHelpJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeHelpJSON(&out, rootCmd); err != nil {
+		t.Fatalf("writeHelpJSON() error = %v", err)
+	}
+	var root helpCommand
+	if err := json.Unmarshal(out.Bytes(), &root); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+
+	commands := make(map[string]helpCommand)
+	for _, cmd := range root.Commands {
+		commands[cmd.Name] = cmd
+	}
+	if _, ok := commands["help"]; ok {
+		t.Error("Output lists the help command")
+	}
+	for name, group := range map[string]string{"init": groupInstall, "status": groupInspect, "backup": groupMaintenance, "template": groupAuthoring, "install": aliasGroupID} {
+		if commands[name].Group != group {
+			t.Errorf("%s group = %q, want %q", name, commands[name].Group, group)
+		}
+	}
+
+	var verboseFlag *helpFlag
+	for i, flag := range root.Flags {
+		if flag.Name == "verbose" {
+			verboseFlag = &root.Flags[i]
+		}
+	}
+	if verboseFlag == nil || verboseFlag.Shorthand != "v" || !verboseFlag.Persistent {
+		t.Errorf("root flags = %+v, want the persistent --verbose flag", root.Flags)
+	}
+
+	backup := commands["backup"]
+	if backup.Runnable || len(backup.Commands) == 0 {
+		t.Errorf("backup = %+v, want a group with subcommands", backup)
+	}
+}
+
+func TestCommandGroups(t *testing.T) {
+	// Every command is in a group, except the ones cobra and completions add
+	additional := map[string]bool{"help": true, "completion": true, "completions": true}
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.IsAvailableCommand() && cmd.GroupID == "" && !additional[cmd.Name()] {
+			t.Errorf("%s is not in a help group", cmd.Name())
+		}
+	}
+}
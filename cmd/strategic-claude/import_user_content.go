@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/usercontent"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var importOnConflict string
+
+var importUserContentCmd = &cobra.Command{
+	Use:     "import-user-content <archive> [directory]",
+	Short:   "Restore user content from an export archive",
+	GroupID: groupMaintenance,
+	Long: `Unpack an archive created by 'export-user-content' into the user directories
+of an installation, e.g. to restore your work after a reinstall or to move plans
+and research to another project.
+
+The target must be a working Strategic Claude Basic installation. Files that
+already exist are handled according to --on-conflict:
+- skip: keep the existing file (default)
+- rename: import the file as <name>-1.<ext>, <name>-2.<ext>, ...
+- overwrite: replace the existing file
+
+Examples:
+  strategic-claude-basic-cli import-user-content export.tar.gz                  # Import into current directory
+  strategic-claude-basic-cli import-user-content export.tar.gz ./other-project  # Import into another project
+  strategic-claude-basic-cli import-user-content export.tar.gz --on-conflict=rename`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 1 {
+			target = args[1]
+		}
+		return runImportUserContent(args[0], target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importUserContentCmd)
+
+	importUserContentCmd.Flags().StringVar(&importOnConflict, "on-conflict", usercontent.CollisionSkip, "how to handle existing files: skip, rename or overwrite")
+
+	if err := importUserContentCmd.RegisterFlagCompletionFunc("on-conflict", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return usercontent.GetCollisionPolicies(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --on-conflict flag: %v\n", err)
+	}
+}
+
+// runImportUserContent executes the import-user-content command logic
+func runImportUserContent(archive, target string) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Importing %s into %s\n", archive, absTarget)
+
+	result, err := usercontent.New().Import(absTarget, archive, importOnConflict)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("import failed: %w", err))
+		return err
+	}
+
+	if result.Manifest.SourceDir != "" {
+		utils.VerbosePrintf(verbose, "Archive exported from %s at %s\n", result.Manifest.SourceDir, result.Manifest.CreatedAt)
+	}
+	for _, relPath := range result.Skipped {
+		utils.VerbosePrintf(verbose, "Skipped existing %s\n", relPath)
+	}
+
+	renamed := make([]string, 0, len(result.Renamed))
+	for from := range result.Renamed {
+		renamed = append(renamed, from)
+	}
+	sort.Strings(renamed)
+	for _, from := range renamed {
+		fmt.Printf(utils.Symbols("  %s → %s\n"), from, result.Renamed[from])
+	}
+	for _, relPath := range result.Overwritten {
+		fmt.Printf("  ~ %s\n", relPath)
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Imported %d file(s), renamed %d, overwrote %d",
+		len(result.Imported), len(result.Renamed), len(result.Overwritten)))
+	if len(result.Skipped) > 0 {
+		utils.DisplayInfo(fmt.Sprintf("Skipped %d existing file(s); use --on-conflict=rename or --on-conflict=overwrite to import them", len(result.Skipped)))
+	}
+
+	return nil
+}
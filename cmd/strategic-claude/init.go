@@ -1,13 +1,26 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cleaner"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/installer"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/patch"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/plugin"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/ui"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
@@ -16,18 +29,65 @@ import (
 )
 
 var (
-	force         bool
-	forceCore     bool
-	yes           bool
-	noBackup      bool
-	dryRun        bool
-	templateID    string
-	gitignoreMode string
+	force              bool
+	forceCore          bool
+	yes                bool
+	confirmMode        string
+	defaultTemplate    string
+	noBackup           bool
+	dryRun             bool
+	templateID         string
+	gitignoreMode      string
+	authToken          string
+	sparse             bool
+	noVerify           bool
+	fromBundle         string
+	noCache            bool
+	allowNested        bool
+	iKnowWhatIAmDoing  bool
+	requireGitRepo     bool
+	chownUser          bool
+	hookPython         string
+	hookRunner         string
+	installHookDeps    bool
+	verifyHooks        bool
+	direnvBlock        bool
+	showSettingsDiff   bool
+	emitPatch          string
+	profile            bool
+	devMode            bool
+	devTemplatePath    string
+	integrations       string
+	cursorMode         string
+	symlinkStyle       string
+	symlinkPolicy      string
+	readonlyCore       bool
+	readonlyCoreSet    bool // --readonly-core was given, either way
+	installReport      string
+	onScriptError      string
+	continueOnScript   bool
+	allowUnpinned      bool
+	scriptIsolation    string
+	allowLargeTemplate bool
+	keepTemp           bool
+	initTimeout        time.Duration
+	initOutput         string
+	installOnly        string
+	installSkip        string
+	noPlugins          bool
+	preserveDirs       []string
+)
+
+// Formats of the init --dry-run output
+const (
+	initOutputText = "text"
+	initOutputJSON = "json"
 )
 
 var initCmd = &cobra.Command{
-	Use:   "init [directory]",
-	Short: "Install Strategic Claude Basic framework",
+	Use:     "init [directory]",
+	Short:   "Install Strategic Claude Basic framework",
+	GroupID: groupInstall,
 	Long: `Install Strategic Claude Basic framework in the specified directory.
 
 This command will:
@@ -40,16 +100,205 @@ This command will:
 Installation modes:
 - New installation: Install in a clean directory
 - Update core only (--force-core): Update only core framework files, preserve user content
-- Full overwrite (--force): Replace all framework files
+- Full overwrite (--force): Replace all framework files; a checklist of the user
+  directories holding files asks which to carry over into the fresh install, all
+  of them checked, and --preserve=plan,research names them without asking
+- An existing installation without either flag asks whether to update the core
+  files, overwrite everything or cancel; without a terminal, or when prompts are
+  skipped, init fails with ` + string(models.ErrorCodeAlreadyInstalled) + ` instead
+
+Components:
+- --only=guides,templates installs only the named components into an existing
+  installation, e.g. to refresh the guides without touching hooks or settings;
+  --skip=hooks,settings installs all the others
+- core, guides, templates: the framework directories, replaced from the template
+- hooks: the .claude and .codex symlinks into core and hook dependencies
+- settings: .claude/settings.json and .codex/config.toml
+- gitignore: the framework's .gitignore entries and the entries of other tools'
+  ignore files, such as .dockerignore
+- Installing only some components runs no installation scripts and leaves the
+  integrations (Cursor rules, Aider, OpenCode, .envrc) alone; it cannot be
+  combined with --force or --dev
 
 Template selection:
 - Use --template to specify a template ID directly
 - Without --template, you'll be prompted to choose interactively
+- When prompts are skipped, --default-template names the template to install
+  instead of ` + templates.DefaultTemplateID + `
+
+Confirmation:
+- --confirm=always asks at every prompt (default)
+- --confirm=destructive-only takes the defaults at selection prompts and installs
+  without asking, except for installations that destroy files: full overwrites
+  (--force) and updates replacing files without a backup (--no-backup). Those
+  are only run after confirming at a terminal and fail without one, so
+  automation can approve safe updates while refusing destructive ones
+- --confirm=never (or --yes) takes every default and approves every installation
+
+Verification:
+- Templates may declare a tree hash and/or an ed25519 signing key
+- They cover the framework directory and the pre-install.sh and post-install.sh scripts
+- Fetched content is verified before anything is copied or run; --no-verify skips this
+
+Offline installation:
+- Create a bundle on a connected machine with 'bundle create'
+- Install from it with --from-bundle; no network access or git is required
+- The bundle must hold a known template at its pinned commit and is verified like fetched content
+- Projects with a vendored template (see 'vendor') install from that copy
+
+Dev mode (for template authors):
+- --dev --template-path=<checkout> links core, guides and templates to a local
+  template checkout instead of copying them, so edits show up immediately
+- --template only selects the registry entry recorded for the install (default: main)
+- 'status' reports dev mode installs; a later 'init --force-core' replaces the links
+  with copies and 'clean' removes the links without touching the checkout
+
+Integrations:
+- --integrations selects the tool directories to set up (default: claude,codex);
+  new installations prompt for them unless prompts are skipped (--yes or --confirm)
+- The choice is recorded in template-info and kept by later installs, status and
+  clean; pass --integrations again to change it, e.g. to drop codex
+- claude: .claude agents, commands, hooks and settings.json (required)
+- codex: .codex prompts, hooks and config.toml
+- cursor: the template's Cursor rules in .cursor/rules/strategic, linked to
+  templates/cursor/rules or copied with --cursor-mode=copy
+- aider, opencode: .aider.conf.yml reads and opencode.json lists as instructions
+  .strategic-claude-basic/.conventions.md, generated from the template's
+  templates/conventions/CONVENTIONS.md and hints for the framework commands;
+  the CLI's entries are marked so your own settings are kept
+
+Framework directory:
+- --framework-dir=.ai-framework installs the framework into another directory than
+  .strategic-claude-basic; the name is recorded in strategic-claude-basic.json in
+  the project, so later commands such as status and clean find it without the flag
+
+Symlinks:
+- The .claude and .codex symlinks have relative targets such as
+  ../../.strategic-claude-basic/core/agents, which keep working when the project
+  moves; --symlink-style=absolute writes full paths instead, for tools that do not
+  resolve relative links
+- The style is recorded in template-info and kept by later installs; status
+  checks the symlinks against it
+- Symlinks inside the template content are copied into the project with relative
+  targets; links that lead out of the template are refused. --symlink-policy=skip
+  leaves them out instead and --symlink-policy=follow copies what they point to
+
+Read-only framework files:
+- --readonly-core removes the write bits of the core, guides and templates files
+  once they are installed, so edits that the next update would discard fail in
+  editors instead of being lost; directories stay writable for updates
+- The choice is recorded in template-info and kept by later installs;
+  --readonly-core=false turns it off, and doctor --fix-permissions re-asserts it
+
+Caching:
+- Fetched template commits are cached (see 'cache dir'); --no-cache always clones
+
+Private templates:
+- HTTPS: pass --auth-token or set SCB_GIT_TOKEN (git credential helpers are used otherwise)
+- SSH: keys loaded in ssh-agent are used for git@host:org/repo.git URLs
+
+Hook interpreter:
+- Strategic hooks are Python scripts; the interpreter is detected in this order:
+  active virtualenv, the project's .venv, python3 on PATH, 'py -3' on Windows
+- Use --hook-python to set it explicitly, e.g. --hook-python=/opt/homebrew/bin/python3
+- Use --hook-runner=uv, --hook-runner=poetry or --hook-runner='custom:<command>' to run
+  hooks through a project tool, e.g. 'uv run .claude/hooks/strategic/<hook>.py'
+- Pass the same flags on later updates; hook commands are rewritten on every install
+- --install-hook-deps creates .strategic-claude-basic/.venv and installs the hooks'
+  requirements.txt or pyproject.toml into it; later installs use it automatically
+- --verify-hooks runs every strategic hook with a test payload once installed and
+  fails when one exits with an error, like 'verify-hooks'
+
+direnv:
+- --direnv writes a managed block to .envrc that exports CLAUDE_PROJECT_DIR and
+  adds .strategic-claude-basic/tools to PATH, for hooks and tools run outside
+  Claude Code; the rest of .envrc is kept and clean removes only the block
+
+Settings preview:
+- --show-settings-diff prints a unified diff of the .claude/settings.json merge
+  before asking for confirmation; --dry-run always includes it
+
+Dry run:
+- --dry-run compares the template with the installed framework files and lists
+  what happens to each one: create, replace, remove, preserve or skip; files that
+  would be overwritten with the same content are listed as unchanged (shown with
+  --verbose)
+- It also lists the installation steps in the order they run (clone, verify,
+  backup, scripts, copy, symlinks, settings, codex, gitignore, metadata,
+  validation, ...) and which ones this installation skips
+- --dry-run --output json prints the whole plan as JSON, with a files array of
+  {"path", "action", "changed"} entries, for review tools and CI
+
+Change-controlled environments:
+- --emit-patch=<file> installs into a temporary copy of the project and writes the
+  changes as a patch instead; apply it with 'git apply <file>'
+- Symlink changes go to <file>.sh, to run from the project after the patch
+
+Profiling:
+- Verbose output includes how long each installation step took
+- --profile prints a breakdown of the steps (clone, copy, symlinks, settings,
+  scripts, gitignore, ...) after installing, to diagnose slow installs
+- Templates are checked out in the system's temporary directory; --temp-dir,
+  $` + config.TempDirEnvVar + ` or temp_dir in the config pick another one, e.g. when /tmp is
+  a small tmpfs
+- --keep-temp leaves the checkout in place after installing and prints where it
+  is, to inspect what was installed from
+
+Installation scripts:
+- The template's pre-install.sh and post-install.sh are listed with their size
+  and SHA-256 before asking for confirmation and in --dry-run
+- --report=<file> writes a JSON report of the installation with how long each
+  step took and, for every script run, its duration, exit code and the end of
+  its output
+- --on-script-error decides what a script exiting with a non-zero code does:
+  abort stops the installation (default), continue warns and carries on, and
+  rollback also undoes the installation when post-install.sh fails: a new
+  installation is removed, an update gets back the framework directory from its
+  backup. --continue-on-script-error is short for --on-script-error=continue
+- script_hashes in ` + config.ConfigFileName + ` pins the SHA-256 of each script, e.g.
+  {"script_hashes": {"post-install.sh": "<sha256>"}}; once set, scripts that do not
+  match or are not pinned stop the installation before anything is changed,
+  unless --allow-unpinned-scripts is given; --report lists the full hashes
+- --script-isolation=docker runs the scripts in a throwaway ` + config.ScriptContainerImage + ` container
+  with only the target directory mounted; when Docker is not available, the plan
+  warns before you confirm and the scripts run directly
+
+Plugins:
+- Executables in ` + config.PluginsDirName + `/<point>/ next to the user config file, or listed under
+  "plugins" in it, run at the points ` + config.PluginPointPreInstall + ` (after the pre-install script),
+  ` + config.PluginPointPostCopy + ` (after the framework files are copied) and ` + config.PluginPointPostInstall + `
+  (after validation), with the installation described as JSON on stdin
+- They run in the target directory, are listed with the steps in --dry-run, and
+  fail like installation scripts, following --on-script-error
+- --no-plugins installs without running them
+
+Nested installations:
+- Installing below a directory that already has an installation is refused
+- Use --allow-nested to install anyway; hooks from both installations will run
+
+Safety:
+- Running under sudo creates root-owned files; --chown-user gives them to the
+  user who invoked sudo
+- Targets outside a git repository produce a warning; --require-git-repo (or
+  SCB_REQUIRE_GIT_REPO=1) turns it into an error
+- Installing into /, $HOME, system directories or paths listed in SCB_FORBIDDEN_PATHS
+  is refused; --i-know-what-im-doing overrides this
+- Templates over 50 MiB or 5000 files produce a warning; over 500 MiB or 50000
+  files the installation stops and is rolled back unless --allow-large-template
+  is given. template_warn_bytes, template_max_bytes, template_warn_files and
+  template_max_files in the config change the limits (see 'config')
+- --timeout=5m limits the whole installation, from fetching the template to the
+  post-install script; at the deadline git, scripts and copies are stopped and
+  the installation is rolled back like with --on-script-error=rollback
 
 Gitignore behavior:
 - track: Track all files (default)
 - all: Ignore entire framework directories
 - non-user: Ignore only framework files (core, guides, templates)
+- In every mode, templates shipping templates/ignore/dot_dockerignore.template
+  or dot_eslintignore.template add their entries to .dockerignore and
+  .eslintignore; the files are only created when the project has a Dockerfile,
+  compose file or ESLint config
 
 Examples:
   strategic-claude-basic-cli init                      # Install with template selection
@@ -57,10 +306,27 @@ Examples:
   strategic-claude-basic-cli init --template=ccr      # Install CCR template
   strategic-claude-basic-cli init ./my-project        # Install in specific directory
   strategic-claude-basic-cli init --force-core        # Update core files only
+  strategic-claude-basic-cli init --only=guides       # Refresh the guides only
   strategic-claude-basic-cli init --gitignore-mode=all # Ignore all framework files
-  strategic-claude-basic-cli init --dry-run           # Preview what would be done`,
+  strategic-claude-basic-cli init --dry-run           # Preview what would be done
+  strategic-claude-basic-cli init --force-core --dry-run --output json # Plan as JSON
+  strategic-claude-basic-cli init --emit-patch=scb.patch # Write the changes as a patch
+  strategic-claude-basic-cli init --from-bundle=main.tar.gz # Install offline from a bundle
+  strategic-claude-basic-cli init --dev --template-path=../my-template # Link a template checkout
+  strategic-claude-basic-cli init --integrations=claude,codex,cursor # Also install Cursor rules
+  strategic-claude-basic-cli init --report=install.json # Record the steps and script results as JSON
+  strategic-claude-basic-cli init --framework-dir=.ai-framework # Use another framework directory name`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			if err := loadConfig(cmd, args[0]); err != nil {
+				return err
+			}
+		}
+		if !cmd.Flags().Changed("require-git-repo") {
+			requireGitRepo = config.Current().RequireGitRepo
+		}
+		readonlyCoreSet = cmd.Flags().Changed("readonly-core")
 		return runInit(args)
 	},
 }
@@ -70,11 +336,55 @@ func init() {
 
 	initCmd.Flags().BoolVarP(&force, "force", "f", false, "force installation, overwriting existing files")
 	initCmd.Flags().BoolVar(&forceCore, "force-core", false, "update only core framework files, preserving user content")
-	initCmd.Flags().BoolVarP(&yes, "yes", "y", false, "automatically answer yes to all prompts")
+	initCmd.Flags().BoolVarP(&yes, "yes", "y", false, "automatically answer yes to all prompts (--confirm=never)")
+	initCmd.Flags().StringVar(&confirmMode, "confirm", "", "when to ask for confirmation: always, destructive-only or never (default: always)")
+	initCmd.MarkFlagsMutuallyExclusive("yes", "confirm")
+	initCmd.Flags().StringVar(&defaultTemplate, "default-template", "", "template installed when prompts are skipped and --template is not given (default: "+templates.DefaultTemplateID+")")
 	initCmd.Flags().BoolVar(&noBackup, "no-backup", false, "skip creating backups of existing files")
 	initCmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be done without making changes")
 	initCmd.Flags().StringVar(&templateID, "template", "", "template ID to install (main, ccr, etc.)")
 	initCmd.Flags().StringVar(&gitignoreMode, "gitignore-mode", "", "gitignore behavior: track, all, or non-user (default: track)")
+	initCmd.Flags().BoolVar(&sparse, "sparse", false, "check out only the framework directory from the template repository")
+	initCmd.Flags().BoolVar(&noVerify, "no-verify", false, "skip tree hash and signature verification of template content")
+	initCmd.Flags().StringVar(&authToken, "auth-token", "", "token for private HTTPS template repositories (default: $SCB_GIT_TOKEN)")
+	initCmd.Flags().BoolVar(&allowNested, "allow-nested", false, "install even if a parent directory already has an installation")
+	initCmd.Flags().BoolVar(&iKnowWhatIAmDoing, "i-know-what-im-doing", false, "allow installing into /, $HOME or other sensitive directories")
+	initCmd.Flags().BoolVar(&requireGitRepo, "require-git-repo", false, "fail unless the target is inside a git repository (default: require_git_repo in the config or $SCB_REQUIRE_GIT_REPO)")
+	initCmd.Flags().BoolVar(&chownUser, "chown-user", false, "when run with sudo, make the invoking user own the installed files")
+	initCmd.Flags().StringVar(&hookPython, "hook-python", "", "interpreter command for strategic hooks (default: detected)")
+	initCmd.Flags().StringVar(&hookRunner, "hook-runner", "", "runner for strategic hooks: python, uv, poetry or custom:<command> (default: python)")
+	initCmd.Flags().BoolVar(&installHookDeps, "install-hook-deps", false, "install hook Python dependencies into .strategic-claude-basic/.venv")
+	initCmd.Flags().BoolVar(&verifyHooks, "verify-hooks", false, "run the strategic hooks with a test payload after installing and fail if one errors")
+	initCmd.Flags().BoolVar(&direnvBlock, "direnv", false, "write a managed block exporting CLAUDE_PROJECT_DIR to .envrc")
+	initCmd.Flags().BoolVar(&showSettingsDiff, "show-settings-diff", false, "show a diff of the .claude/settings.json changes before installing (always shown in --dry-run)")
+	initCmd.Flags().StringVar(&emitPatch, "emit-patch", "", "write the changes to a patch file (and symlinks to <file>.sh) instead of applying them")
+	initCmd.MarkFlagsMutuallyExclusive("dry-run", "emit-patch")
+	initCmd.Flags().StringVar(&initOutput, "output", initOutputText, "dry-run output format: text or json")
+	initCmd.Flags().BoolVar(&profile, "profile", false, "print how long each installation step took")
+	initCmd.Flags().BoolVar(&noCache, "no-cache", false, "always clone the template instead of using the local cache")
+	initCmd.Flags().StringVar(&fromBundle, "from-bundle", "", "install offline from a bundle created with 'bundle create'")
+	initCmd.Flags().BoolVar(&devMode, "dev", false, "link the framework directories from a local template checkout (requires --template-path)")
+	initCmd.Flags().StringVar(&devTemplatePath, "template-path", "", "local template checkout to link in dev mode")
+	initCmd.MarkFlagsRequiredTogether("dev", "template-path")
+	initCmd.Flags().StringVar(&integrations, "integrations", "", "comma-separated integrations to set up: claude, codex, cursor, aider, opencode (default: claude,codex)")
+	initCmd.Flags().StringVar(&installOnly, "only", "", "comma-separated components to install into an existing installation: core, guides, templates, hooks, settings, gitignore")
+	initCmd.Flags().StringVar(&installSkip, "skip", "", "comma-separated components to leave alone when updating an existing installation")
+	initCmd.MarkFlagsMutuallyExclusive("only", "skip")
+	initCmd.Flags().StringVar(&cursorMode, "cursor-mode", "", "how Cursor rules are installed: symlink or copy (default: previous choice or symlink)")
+	initCmd.Flags().StringVar(&symlinkStyle, "symlink-style", "", "how symlink targets are written: relative or absolute (default: previous choice or relative)")
+	initCmd.Flags().StringVar(&symlinkPolicy, "symlink-policy", "", "how symlinks in the template content are copied: rewrite-relative, skip or follow (default: rewrite-relative)")
+	initCmd.Flags().BoolVar(&readonlyCore, "readonly-core", false, "make the core, guides and templates files read-only after the install (default: previous choice)")
+	initCmd.Flags().StringVar(&installReport, "report", "", "write a JSON report of the installation steps and script results to this file")
+	initCmd.Flags().StringVar(&onScriptError, "on-script-error", "", "what a failing installation script does: abort, continue or rollback (default: abort)")
+	initCmd.Flags().BoolVar(&continueOnScript, "continue-on-script-error", false, "keep installing when an installation script fails (--on-script-error=continue)")
+	initCmd.MarkFlagsMutuallyExclusive("on-script-error", "continue-on-script-error")
+	initCmd.Flags().BoolVar(&allowUnpinned, "allow-unpinned-scripts", false, "run installation scripts that do not match script_hashes in the config")
+	initCmd.Flags().StringVar(&scriptIsolation, "script-isolation", "", "where installation scripts run: none or docker (default: none)")
+	initCmd.Flags().BoolVar(&allowLargeTemplate, "allow-large-template", false, "install templates above the configured maximum size or file count")
+	initCmd.Flags().DurationVar(&initTimeout, "timeout", 0, "stop and roll back the installation when it takes longer than this, e.g. 5m (default: no limit)")
+	initCmd.Flags().BoolVar(&keepTemp, "keep-temp", false, "keep the template checkout after installing and print where it is, for debugging")
+	initCmd.Flags().BoolVar(&noPlugins, "no-plugins", false, "install without running the plugins from the user config")
+	initCmd.Flags().StringSliceVar(&preserveDirs, "preserve", nil, "user directories --force carries over into the fresh install, e.g. plan,research (default: asked, or none)")
 
 	// Custom completion for directory argument
 	initCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -93,6 +403,30 @@ func init() {
 		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --template flag: %v\n", err)
 	}
 
+	// Add completion for preserve flag
+	if err := initCmd.RegisterFlagCompletionFunc("preserve", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.GetUserPreservedDirectories(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --preserve flag: %v\n", err)
+	}
+
+	// Add completion for default-template flag
+	if err := initCmd.RegisterFlagCompletionFunc("default-template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return templates.GetTemplateIDs(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --default-template flag: %v\n", err)
+	}
+
+	// Add completion for confirm flag
+	if err := initCmd.RegisterFlagCompletionFunc("confirm", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{models.ConfirmAlways, models.ConfirmDestructiveOnly, models.ConfirmNever}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --confirm flag: %v\n", err)
+	}
+
 	// Add completion for gitignore-mode flag
 	if err := initCmd.RegisterFlagCompletionFunc("gitignore-mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"track", "all", "non-user"}, cobra.ShellCompDirectiveNoFileComp
@@ -100,6 +434,82 @@ func init() {
 		// This should not happen in normal operation, but we handle it for completeness
 		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --gitignore-mode flag: %v\n", err)
 	}
+
+	// Add completion for integrations flag
+	if err := initCmd.RegisterFlagCompletionFunc("integrations", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return models.GetIntegrations(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --integrations flag: %v\n", err)
+	}
+
+	// Add completion for component flags
+	for _, flag := range []string{"only", "skip"} {
+		if err := initCmd.RegisterFlagCompletionFunc(flag, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return models.GetComponents(), cobra.ShellCompDirectiveNoFileComp
+		}); err != nil {
+			// This should not happen in normal operation, but we handle it for completeness
+			fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --%s flag: %v\n", flag, err)
+		}
+	}
+
+	// Add completion for cursor-mode flag
+	if err := initCmd.RegisterFlagCompletionFunc("cursor-mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{models.CursorModeSymlink, models.CursorModeCopy}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --cursor-mode flag: %v\n", err)
+	}
+
+	// Add completion for symlink-style flag
+	if err := initCmd.RegisterFlagCompletionFunc("symlink-style", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.GetSymlinkStyles(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --symlink-style flag: %v\n", err)
+	}
+
+	// Add completion for hook-runner flag
+	if err := initCmd.RegisterFlagCompletionFunc("hook-runner", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{models.HookRunnerPython, models.HookRunnerUV, models.HookRunnerPoetry, models.HookRunnerCustomPrefix}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --hook-runner flag: %v\n", err)
+	}
+
+	// Add completion for symlink-policy flag
+	if err := initCmd.RegisterFlagCompletionFunc("symlink-policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return models.GetSymlinkPolicies(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --symlink-policy flag: %v\n", err)
+	}
+
+	// Add completion for on-script-error flag
+	if err := initCmd.RegisterFlagCompletionFunc("on-script-error", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{models.ScriptErrorAbort, models.ScriptErrorContinue, models.ScriptErrorRollback}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --on-script-error flag: %v\n", err)
+	}
+
+	// Add completion for output flag
+	if err := initCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{initOutputText, initOutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --output flag: %v\n", err)
+	}
+
+	// Add completion for script-isolation flag
+	if err := initCmd.RegisterFlagCompletionFunc("script-isolation", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{models.ScriptIsolationNone, models.ScriptIsolationDocker}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --script-isolation flag: %v\n", err)
+	}
+
+	addAlias(initCmd, "install", "")
 }
 
 // runInit executes the init command logic
@@ -117,21 +527,82 @@ func runInit(args []string) error {
 		return err
 	}
 
-	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
-	utils.VerbosePrintf(verbose, "Flags - Force: %v, Force Core: %v, Yes: %v, No Backup: %v, Dry Run: %v, Template: %s, Gitignore Mode: %s\n",
-		force, forceCore, yes, noBackup, dryRun, templateID, gitignoreMode)
+	if !slices.Contains([]string{initOutputText, initOutputJSON}, initOutput) {
+		err := models.NewValidationError("output", initOutput, "must be one of: text, json")
+		utils.DisplayError(err)
+		return err
+	}
+	if initOutput == initOutputJSON && !dryRun {
+		err := models.NewValidationError("output", initOutput, "json output is only available with --dry-run")
+		utils.DisplayError(err)
+		return err
+	}
 
-	// Handle template selection
-	selectedTemplateID, err := selectTemplate(templateID, yes)
-	if err != nil {
+	if err := models.ValidateConfirmMode(confirmMode); err != nil {
 		utils.DisplayError(err)
 		return err
 	}
+	confirm := confirmMode
+	switch {
+	case yes:
+		confirm = models.ConfirmNever
+	case confirm == "":
+		confirm = models.ConfirmAlways
+	}
+	// Selection prompts take their defaults unless every prompt is asked
+	skipPrompts := confirm != models.ConfirmAlways
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+	utils.VerbosePrintf(verbose, "Flags - Force: %v, Force Core: %v, Confirm: %s, No Backup: %v, Dry Run: %v, Template: %s, Gitignore Mode: %s\n",
+		force, forceCore, confirm, noBackup, dryRun, templateID, gitignoreMode)
+
+	// Resolve the dev mode checkout relative to the working directory
+	var absDevTemplatePath string
+	if devMode {
+		absDevTemplatePath, err = filepath.Abs(devTemplatePath)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to resolve template path: %w", err))
+			return err
+		}
+	}
+
+	// Handle template selection; bundles carry their own template
+	var selectedTemplateID string
+	if devMode {
+		// Dev mode content comes from the checkout, the ID only names the registry entry
+		selectedTemplateID = templateID
+		if selectedTemplateID == "" {
+			selectedTemplateID = templates.DefaultTemplateID
+		}
+		utils.VerbosePrintf(verbose, "Linking template checkout: %s\n", absDevTemplatePath)
+	} else if fromBundle != "" {
+		if templateID != "" {
+			err := fmt.Errorf("cannot specify both --template and --from-bundle")
+			utils.DisplayError(err)
+			return err
+		}
+		utils.VerbosePrintf(verbose, "Installing from bundle: %s\n", fromBundle)
+	} else if templateID == "" && bundle.IsVendored(absTarget) {
+		// A vendored copy determines the template unless one is requested explicitly
+		manifest, err := bundle.New().ReadVendorManifest(bundle.VendorPath(absTarget))
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		selectedTemplateID = manifest.Template.ID
+		utils.VerbosePrintf(verbose, "Using vendored template: %s\n", selectedTemplateID)
+	} else {
+		selectedTemplateID, err = selectTemplate(templateID, defaultTemplate, skipPrompts)
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
 
-	utils.VerbosePrintf(verbose, "Selected template: %s\n", selectedTemplateID)
+		utils.VerbosePrintf(verbose, "Selected template: %s\n", selectedTemplateID)
+	}
 
 	// Handle gitignore mode selection
-	selectedGitignoreMode, err := selectGitignoreMode(gitignoreMode, yes)
+	selectedGitignoreMode, err := selectGitignoreMode(gitignoreMode, skipPrompts)
 	if err != nil {
 		utils.DisplayError(err)
 		return err
@@ -139,22 +610,76 @@ func runInit(args []string) error {
 
 	utils.VerbosePrintf(verbose, "Selected gitignore mode: %s\n", selectedGitignoreMode)
 
-	// Validate prerequisites
-	if err := validatePrerequisites(); err != nil {
+	// Handle integration selection; existing installations keep their choice
+	selectedIntegrations, err := selectIntegrations(integrations, skipPrompts, absTarget)
+	if err != nil {
 		utils.DisplayError(err)
 		return err
 	}
 
+	// Select the components to install; all of them unless --only or --skip is given
+	selectedComponents, err := models.SelectComponents(installOnly, installSkip)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	// Warn about root-owned files when running through sudo
+	warnAboutSudo(chownUser)
+
+	// Validate prerequisites; offline and dev mode installs do not need git
+	if fromBundle == "" && !devMode && !bundle.IsVendored(absTarget) {
+		if err := validatePrerequisites(); err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+	}
+
+	if continueOnScript {
+		onScriptError = models.ScriptErrorContinue
+	}
+
 	// Create install configuration
 	installConfig := models.InstallConfig{
-		TargetDir:     absTarget,
-		TemplateID:    selectedTemplateID,
-		Force:         force,
-		ForceCore:     forceCore,
-		SkipConfirm:   yes,
-		NoBackup:      noBackup,
-		Verbose:       verbose,
-		GitignoreMode: selectedGitignoreMode,
+		TargetDir:            absTarget,
+		TemplateID:           selectedTemplateID,
+		Force:                force,
+		ForceCore:            forceCore,
+		SkipConfirm:          confirm == models.ConfirmNever,
+		NoBackup:             noBackup,
+		Verbose:              verbose,
+		GitignoreMode:        selectedGitignoreMode,
+		AuthToken:            authToken,
+		SparseCheckout:       sparse,
+		NoVerify:             noVerify,
+		BundlePath:           fromBundle,
+		NoCache:              noCache,
+		AllowNested:          allowNested,
+		AllowSensitiveTarget: iKnowWhatIAmDoing,
+		RequireGitRepo:       requireGitRepo,
+		GitTimeout:           config.Current().GitTimeout,
+		Instance:             config.Instance(),
+		ChownUser:            chownUser,
+		HookPython:           hookPython,
+		HookRunner:           hookRunner,
+		InstallHookDeps:      installHookDeps,
+		VerifyHooks:          verifyHooks,
+		Direnv:               direnvBlock,
+		DevTemplatePath:      absDevTemplatePath,
+		Integrations:         selectedIntegrations,
+		Components:           selectedComponents,
+		CursorMode:           cursorMode,
+		SymlinkStyle:         symlinkStyle,
+		SymlinkPolicy:        symlinkPolicy,
+		ScriptErrorPolicy:    onScriptError,
+		AllowUnpinnedScripts: allowUnpinned,
+		ScriptIsolation:      scriptIsolation,
+		AllowLargeTemplate:   allowLargeTemplate,
+		KeepTemp:             keepTemp,
+		CarryOver:            preserveDirs,
+	}
+	if readonlyCoreSet {
+		installConfig.ReadOnlyCore = &readonlyCore
 	}
 
 	// Validate install configuration
@@ -165,43 +690,285 @@ func runInit(args []string) error {
 
 	// Create installer service
 	installerService := installer.New()
+	installerService.SetReporter(newReporter())
+	if initOutput == initOutputJSON && logFormat == reporter.FormatText {
+		// Keep stdout for the plan
+		installerService.SetReporter(reporter.NewText(os.Stderr, verbose))
+	}
+	if !noPlugins {
+		plugins, err := plugin.Discover()
+		if err == nil {
+			err = installerService.SetPlugins(plugins)
+		}
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to load plugins: %w", err))
+			return err
+		}
+		for _, p := range plugins {
+			utils.VerbosePrintf(verbose, "Plugin: %s (%s)\n", p.Path, p.Point)
+		}
+	}
 
 	// Step 1: Analyze installation requirements
 	utils.VerbosePrintln(verbose, "Analyzing installation requirements...")
 	plan, err := installerService.AnalyzeInstallation(installConfig)
+	if models.IsErrorCode(err, models.ErrorCodeAlreadyInstalled) && confirm == models.ConfirmAlways &&
+		initOutput == initOutputText && utils.IsInteractive() {
+		// Without --force or --force-core, ask how to proceed
+		choice, selectErr := ui.SelectConflictResolution(absTarget)
+		if selectErr != nil {
+			utils.DisplayError(selectErr)
+			return selectErr
+		}
+		switch choice {
+		case ui.ConflictUpdate:
+			installConfig.ForceCore = true
+		case ui.ConflictOverwrite:
+			installConfig.Force = true
+		default:
+			utils.DisplayInfo(i18n.T("Installation cancelled by user"))
+			return nil
+		}
+		plan, err = installerService.AnalyzeInstallation(installConfig)
+	}
 	if err != nil {
 		utils.DisplayError(fmt.Errorf("installation analysis failed: %w", err))
+		if models.IsErrorCode(err, models.ErrorCodeSensitiveDirectory) || models.IsErrorCode(err, models.ErrorCodeAlreadyInstalled) {
+			utils.DisplayInfo(models.GetUserFriendlyMessage(err))
+		}
 		return err
 	}
 
+	if plan.GitRepoRoot != "" {
+		utils.VerbosePrintf(verbose, "Git repository: %s\n", plan.GitRepoRoot)
+	}
+	utils.VerbosePrintf(verbose, "Hook command: %s (%s runner)\n", plan.HookCommand, plan.HookRunner)
+	if plan.Filesystem != nil {
+		utils.VerbosePrintf(verbose, "File system: %s (copy strategy: %s)\n", plan.Filesystem, plan.CopyStrategy)
+	}
+
 	// Step 2: Display installation plan and get confirmation
 	if dryRun {
-		return displayDryRun(plan)
+		var settingsDiff *string
+		if plan.IsValid() {
+			if diff, err := installerService.PreviewSettingsDiff(installConfig, plan); err != nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("Could not preview settings changes: %v", err))
+			} else {
+				settingsDiff = &diff
+			}
+			if err := installerService.PreviewScripts(installConfig, plan); err != nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("Could not inspect installation scripts: %v", err))
+			}
+			if err := installerService.PreviewFiles(installConfig, plan); err != nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("Could not compare the template with the installed files: %v", err))
+			}
+		}
+		if initOutput == initOutputJSON {
+			return displayDryRunJSON(plan, settingsDiff)
+		}
+		return displayDryRun(plan, settingsDiff)
 	}
 
-	if !installConfig.SkipConfirm {
+	if !plan.IsValid() {
+		for _, planErr := range plan.Errors {
+			utils.DisplayError(fmt.Errorf("%s", planErr))
+		}
+		return models.NewAppError(models.ErrorCodeInstallationFailed, "installation plan has errors", nil)
+	}
+
+	if emitPatch != "" {
+		ctx, cancel := operationContext(initTimeout)
+		defer cancel()
+		installerService.SetContext(ctx)
+		return runEmitPatch(installerService, installConfig, emitPatch)
+	}
+
+	// Ask which user directories a full overwrite carries over, unless --preserve says
+	if plan.InstallationType == models.InstallationTypeOverwrite && len(preserveDirs) == 0 &&
+		confirm == models.ConfirmAlways && utils.IsInteractive() {
+		if options := preserveOptions(plan.TargetDir); len(options) > 0 {
+			carryOver, err := ui.SelectPreserved(plan.TargetDir, options)
+			if err != nil {
+				utils.DisplayError(err)
+				return err
+			}
+			installConfig.CarryOver = carryOver
+			if plan, err = installerService.AnalyzeInstallation(installConfig); err != nil {
+				utils.DisplayError(fmt.Errorf("installation analysis failed: %w", err))
+				return err
+			}
+		}
+	}
+
+	if showSettingsDiff {
+		diff, err := installerService.PreviewSettingsDiff(installConfig, plan)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to preview settings changes: %w", err))
+			return err
+		}
+		displaySettingsDiff(diff)
+	}
+
+	// With --confirm=destructive-only, only installations that destroy files are confirmed
+	needsConfirmation := !installConfig.SkipConfirm &&
+		(confirm == models.ConfirmAlways || plan.IsDestructive(installConfig.NoBackup))
+	if needsConfirmation && confirm == models.ConfirmDestructiveOnly && !utils.IsInteractive() {
+		err := models.NewAppError(
+			models.ErrorCodeConfirmationRequired,
+			fmt.Sprintf("%s needs confirmation, but there is no terminal to confirm at", plan.InstallationType),
+			nil,
+		)
+		utils.DisplayError(err)
+		return err
+	}
+	if needsConfirmation {
+		if err := installerService.PreviewScripts(installConfig, plan); err != nil {
+			utils.DisplayError(fmt.Errorf("failed to inspect installation scripts: %w", err))
+			return err
+		}
+		if !plan.IsValid() {
+			for _, planErr := range plan.Errors {
+				utils.DisplayError(fmt.Errorf("%s", planErr))
+			}
+			return models.NewAppError(models.ErrorCodeScriptHashMismatch, "installation scripts do not match the pinned hashes", nil)
+		}
 		confirmed, err := getInstallationConfirmation(plan)
 		if err != nil {
 			utils.DisplayError(fmt.Errorf("confirmation failed: %w", err))
 			return err
 		}
 		if !confirmed {
-			utils.DisplayInfo("Installation cancelled by user")
+			utils.DisplayInfo(i18n.T("Installation cancelled by user"))
 			return nil
 		}
 	}
 
 	// Step 3: Perform installation
-	utils.DisplayInfo(fmt.Sprintf("Installing Strategic Claude Basic in %s...", plan.TargetDir))
-
-	if err := installerService.Install(installConfig); err != nil {
+	utils.DisplayInfo(i18n.T("Installing Strategic Claude Basic in %s...", plan.TargetDir))
+
+	started := time.Now()
+	ctx, cancel := operationContext(initTimeout)
+	defer cancel()
+	installerService.SetContext(ctx)
+	installErr := installerService.Install(installConfig)
+	displayScriptResults(installerService.ScriptResults())
+	if installReport != "" {
+		if err := installerService.Report(plan, installErr).WriteFile(installReport); err != nil {
+			utils.DisplayError(err)
+			if installErr == nil {
+				return err
+			}
+		} else {
+			utils.DisplayInfo(fmt.Sprintf("Installation report written to %s", installReport))
+		}
+	}
+	if err := installErr; err != nil {
 		utils.DisplayError(fmt.Errorf("installation failed: %w", err))
+		if models.IsErrorCode(err, models.ErrorCodeGitAuthFailed) || models.IsErrorCode(err, models.ErrorCodeNetworkError) || models.IsErrorCode(err, models.ErrorCodeOperationTimeout) {
+			utils.DisplayInfo(models.GetUserFriendlyMessage(err))
+		}
+		if profile {
+			displayProfile(installerService.Timings(), time.Since(started))
+		}
 		return err
 	}
+	elapsed := time.Since(started)
+
+	// Templates without Cursor rules leave .cursor alone
+	if plan.HasIntegration(models.IntegrationCursor) && !cursor.New().HasRules(plan.TargetDir) {
+		utils.DisplayWarning(fmt.Sprintf("The template has no Cursor rules in %s; .cursor was not set up", config.CursorRulesTemplateDir))
+	}
+
+	recordManaged(plan.TargetDir, installConfig.TemplateID)
 
 	// Step 4: Display success message
-	utils.DisplaySuccess("Strategic Claude Basic installation completed successfully!")
+	utils.DisplaySuccess(i18n.T("Strategic Claude Basic installation completed successfully!"))
 	displayPostInstallInfo(plan)
+	if verbose {
+		fmt.Printf("Installation took %s.\n", utils.FormatDuration(elapsed))
+	}
+	if profile {
+		displayProfile(installerService.Timings(), elapsed)
+	}
+
+	return nil
+}
+
+// runEmitPatch installs into a staged copy of the project and writes the
+// differences to patchPath, with symlink changes in a script next to it
+func runEmitPatch(installerService *installer.Service, installConfig models.InstallConfig, patchPath string) error {
+	patchPath, err := filepath.Abs(patchPath)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve patch path: %w", err))
+		return err
+	}
+	patchService := patch.New()
+
+	utils.VerbosePrintln(verbose, "Staging the project in a temporary directory...")
+	stageDir, err := patchService.Stage(installConfig.TargetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to stage the project: %w", err))
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(stageDir)
+	}()
+
+	// The real target has been validated; the staged copy lives in the temporary directory
+	stagedConfig := installConfig
+	stagedConfig.TargetDir = stageDir
+	stagedConfig.SkipConfirm = true
+	stagedConfig.NoBackup = true
+	stagedConfig.ChownUser = false
+	stagedConfig.AllowNested = true
+	stagedConfig.AllowSensitiveTarget = true
+	stagedConfig.RequireGitRepo = false
+	stagedConfig.InstallHookDeps = false
+
+	if err := installerService.Install(stagedConfig); err != nil {
+		utils.DisplayError(fmt.Errorf("installation failed: %w", err))
+		return err
+	}
+
+	changes, err := patchService.Compare(installConfig.TargetDir, stageDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to compare the staged installation: %w", err))
+		return err
+	}
+	if len(changes) == 0 {
+		utils.DisplayInfo("The installation would not change any files; no patch written")
+		return nil
+	}
+
+	var patchContent strings.Builder
+	if err := patchService.WritePatch(&patchContent, changes); err != nil {
+		return err
+	}
+	if err := os.WriteFile(patchPath, []byte(patchContent.String()), 0644); err != nil {
+		err = models.NewFileSystemError(models.ErrorCodeFileSystemError, patchPath, err)
+		utils.DisplayError(err)
+		return err
+	}
+	utils.DisplaySuccess(fmt.Sprintf("Wrote %d change(s) to %s", len(changes), patchPath))
+	fmt.Printf("Apply from %s with: git apply %s\n", installConfig.TargetDir, patchPath)
+
+	if patch.HasSymlinkChanges(changes) {
+		scriptPath := patchPath + ".sh"
+		var script strings.Builder
+		if err := patchService.WriteScript(&script, changes); err != nil {
+			return err
+		}
+		if err := os.WriteFile(scriptPath, []byte(script.String()), 0755); err != nil {
+			err = models.NewFileSystemError(models.ErrorCodeFileSystemError, scriptPath, err)
+			utils.DisplayError(err)
+			return err
+		}
+		fmt.Printf("Then create the symlinks with: sh %s\n", scriptPath)
+	}
+
+	if installConfig.InstallHookDeps {
+		utils.DisplayWarning("Hook dependencies are not part of the patch; install them with --install-hook-deps after applying it")
+	}
 
 	return nil
 }
@@ -219,8 +986,33 @@ func validatePrerequisites() error {
 	return nil
 }
 
-// selectTemplate handles template selection based on flags and user input
-func selectTemplate(templateFlag string, skipPrompt bool) (string, error) {
+// warnAboutSudo warns when running as root through sudo without --chown-user
+func warnAboutSudo(chownUser bool) {
+	sudo, ok := utils.DetectSudo()
+	if !ok {
+		return
+	}
+
+	if chownUser {
+		utils.VerbosePrintf(verbose, "Running under sudo; installed files will be owned by uid %d\n", sudo.UID)
+		return
+	}
+
+	invoker := sudo.User
+	if invoker == "" {
+		invoker = fmt.Sprintf("uid %d", sudo.UID)
+	}
+
+	fmt.Println()
+	utils.DisplayWarning(fmt.Sprintf("Running as root via sudo (invoked by %s)", invoker))
+	utils.DisplayWarning("Installed files will be owned by root and may not be editable by you.")
+	utils.DisplayWarning("Re-run without sudo, or pass --chown-user to hand the files to " + invoker + ".")
+	fmt.Println()
+}
+
+// selectTemplate handles template selection based on flags and user input;
+// defaultTemplate, or the default template when empty, is used without prompting
+func selectTemplate(templateFlag, defaultTemplate string, skipPrompt bool) (string, error) {
 	// If template is specified via flag, validate and use it
 	if templateFlag != "" {
 		if err := templates.ValidateTemplateID(templateFlag); err != nil {
@@ -231,7 +1023,13 @@ func selectTemplate(templateFlag string, skipPrompt bool) (string, error) {
 
 	// If skipping prompts, use default template
 	if skipPrompt {
-		return templates.DefaultTemplateID, nil
+		if defaultTemplate == "" {
+			return templates.DefaultTemplateID, nil
+		}
+		if err := templates.ValidateTemplateID(defaultTemplate); err != nil {
+			return "", fmt.Errorf("invalid default template ID '%s': %w", defaultTemplate, err)
+		}
+		return defaultTemplate, nil
 	}
 
 	// Interactive template selection
@@ -270,11 +1068,46 @@ func selectGitignoreMode(modeFlag string, skipPrompt bool) (string, error) {
 	return selectGitignoreModeInteractively()
 }
 
+// selectIntegrations returns the integrations to set up. An empty result keeps
+// the choice recorded by an existing installation, or the defaults.
+func selectIntegrations(integrationsFlag string, skipPrompt bool, targetDir string) ([]string, error) {
+	// If integrations are specified via flag, validate and use them
+	if integrationsFlag != "" {
+		return models.ParseIntegrations(integrationsFlag)
+	}
+
+	// Existing installations keep their integrations unless --integrations is given
+	if _, err := os.Stat(filepath.Join(targetDir, config.FrameworkDir())); err == nil {
+		return nil, nil
+	}
+
+	// If skipping prompts, use the default integrations
+	if skipPrompt {
+		return nil, nil
+	}
+
+	// Interactive integration selection
+	return ui.SelectIntegrations(models.DefaultIntegrations())
+}
+
 // selectGitignoreModeInteractively presents gitignore mode options to the user for selection using Bubble Tea
 func selectGitignoreModeInteractively() (string, error) {
 	return ui.SelectGitignoreMode()
 }
 
+// preserveOptions returns the user directories of the installation in
+// targetDir that hold user documents, which a full overwrite can carry over
+func preserveOptions(targetDir string) []ui.PreserveOption {
+	summary := cleaner.ScanUserContent(targetDir)
+	var options []ui.PreserveOption
+	for _, dir := range config.GetUserPreservedDirectories() {
+		if documents := summary.Dirs[dir]; documents > 0 {
+			options = append(options, ui.PreserveOption{Dir: dir, Documents: documents})
+		}
+	}
+	return options
+}
+
 // getInstallationConfirmation displays the installation plan and asks for user confirmation
 func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	fmt.Println() // Empty line for readability
@@ -289,6 +1122,32 @@ func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	}
 	fmt.Printf("Branch: %s\n", template.Branch)
 	fmt.Printf("Commit: %s\n", template.Commit)
+	if plan.TemplateSource != models.TemplateSourceRepository {
+		fmt.Printf("Source: %s\n", plan.TemplateSource)
+	}
+	if template.RequiresVerification() {
+		if noVerify {
+			fmt.Println("Verification: skipped (--no-verify)")
+		} else {
+			fmt.Println("Verification: content will be verified before installation")
+		}
+	}
+	if plan.InstallHookDeps {
+		fmt.Printf("Hook dependencies: installed into a virtualenv, hooks run with %s\n", plan.HookCommand)
+	}
+	if plan.Direnv {
+		fmt.Println("direnv: managed block written to .envrc")
+	}
+	fmt.Printf("Integrations: %s\n", strings.Join(plan.Integrations, ", "))
+	if plan.SymlinkStyle == config.SymlinkStyleAbsolute {
+		fmt.Println("Symlinks: absolute targets")
+	}
+	if plan.ReadOnlyCore {
+		fmt.Println("Framework files: read-only")
+	}
+	if plan.IsPartial() {
+		fmt.Printf("Components: %s\n", strings.Join(plan.Components, ", "))
+	}
 	fmt.Println()
 
 	// Display what will happen
@@ -303,7 +1162,7 @@ func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	if len(plan.SymlinksToCreate) > 0 {
 		fmt.Println("Symlinks to be created:")
 		for _, symlink := range plan.SymlinksToCreate {
-			fmt.Printf("  → %s\n", symlink)
+			fmt.Printf(utils.Symbols("  → %s\n"), symlink)
 		}
 		fmt.Println()
 	}
@@ -319,7 +1178,7 @@ func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	if len(plan.WillPreserve) > 0 {
 		fmt.Println("User content to be preserved:")
 		for _, item := range plan.WillPreserve {
-			fmt.Printf("  ✓ %s\n", item)
+			fmt.Printf(utils.Symbols("  ✓ %s\n"), item)
 		}
 		fmt.Println()
 	}
@@ -330,7 +1189,7 @@ func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	}
 
 	if len(plan.Warnings) > 0 {
-		fmt.Println("⚠️  Warnings:")
+		fmt.Println(utils.Symbols("⚠️  Warnings:"))
 		for _, warning := range plan.Warnings {
 			fmt.Printf("  - %s\n", warning)
 		}
@@ -338,31 +1197,103 @@ func getInstallationConfirmation(plan *models.InstallationPlan) (bool, error) {
 	}
 
 	// Display script execution information
-	if plan.HasPreInstallScript || plan.HasPostInstallScript {
+	if len(plan.Scripts) > 0 {
 		fmt.Println("Scripts to be executed:")
-		if plan.HasPreInstallScript {
-			fmt.Printf("  📜 %s (before installation)\n", "pre-install.sh")
+		displayScripts(plan.Scripts)
+		if plan.ScriptIsolation == models.ScriptIsolationDocker {
+			fmt.Printf("These scripts will be executed in a %s container with only the target directory mounted.\n", config.ScriptContainerImage)
+		} else {
+			fmt.Println(utils.Symbols("⚠️  WARNING: These scripts will be executed with your user permissions."))
 		}
-		if plan.HasPostInstallScript {
-			fmt.Printf("  📜 %s (after installation)\n", "post-install.sh")
-		}
-		fmt.Println("⚠️  WARNING: These scripts will be executed with your user permissions.")
 		fmt.Println()
 	}
 
-	// Ask for confirmation
+	// Ask for confirmation; an overwrite replacing many files asks to type the
+	// directory name
+	files := 0
+	if plan.InstallationType == models.InstallationTypeOverwrite {
+		strategicDir := filepath.Join(plan.TargetDir, config.FrameworkDir())
+		files = countFiles(strategicDir)
+		for _, dir := range plan.CarryOver {
+			files -= countFiles(filepath.Join(strategicDir, dir))
+		}
+	}
 	interactionService := utils.NewInteractionService()
-	return interactionService.ConfirmPrompt("This will install Strategic Claude Basic in the above directory.\nAre you sure you want to proceed?")
+	return interactionService.ConfirmFiles(i18n.T("This will install Strategic Claude Basic in the above directory.\nAre you sure you want to proceed?"), plan.TargetDir, files)
+}
+
+// displayScripts lists installation scripts with when they run, their size and hash
+func displayScripts(scripts []models.ScriptInfo) {
+	for _, script := range scripts {
+		when := "after installation"
+		if script.Name == config.PreInstallScript {
+			when = "before installation"
+		}
+		fmt.Printf(utils.Symbols("  📜 %s (%s, %s, sha256 %s)\n"), script.Name, when, utils.FormatSize(script.Size), shortHash(script.SHA256))
+	}
+}
+
+// displayScriptResults shows how the installation scripts exited; successful
+// scripts are only shown in verbose output
+func displayScriptResults(results []models.ScriptResult) {
+	for _, result := range results {
+		message := fmt.Sprintf("%s exited with code %d after %s", result.Name, result.ExitCode, utils.FormatDuration(result.Duration))
+		if result.Succeeded() {
+			utils.VerbosePrintln(verbose, message)
+		} else {
+			utils.DisplayWarning(message)
+		}
+	}
+}
+
+// shortHash abbreviates a hex digest for display
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	if hash == "" {
+		return "unknown"
+	}
+	return hash
 }
 
-// displayDryRun shows what would happen without making changes
-func displayDryRun(plan *models.InstallationPlan) error {
+// displaySettingsDiff shows the changes an installation makes to .claude/settings.json
+func displaySettingsDiff(diff string) {
+	if diff == "" {
+		fmt.Println("No changes to .claude/settings.json")
+		return
+	}
+	fmt.Println("Changes to .claude/settings.json:")
+	fmt.Print(diff)
+}
+
+// displayDryRun shows what would happen without making changes.
+// settingsDiff is nil when the settings changes could not be previewed.
+func displayDryRun(plan *models.InstallationPlan, settingsDiff *string) error {
 	fmt.Println("=== DRY RUN MODE ===")
 	fmt.Println("This shows what would happen without making any changes.")
 	fmt.Println()
 
 	fmt.Printf("Target directory: %s\n", plan.TargetDir)
 	fmt.Printf("Installation type: %s\n", plan.InstallationType)
+	fmt.Printf("Template source: %s\n", plan.TemplateSource)
+	fmt.Printf("Hook command: %s\n", plan.HookCommand)
+	if plan.InstallHookDeps {
+		fmt.Println("Hook dependencies: will be installed into the hook virtualenv")
+	}
+	if plan.Direnv {
+		fmt.Println("direnv: would write the managed block to .envrc")
+	}
+	fmt.Printf("Integrations: %s\n", strings.Join(plan.Integrations, ", "))
+	if plan.SymlinkStyle == config.SymlinkStyleAbsolute {
+		fmt.Println("Symlinks: absolute targets")
+	}
+	if plan.ReadOnlyCore {
+		fmt.Println("Framework files: read-only")
+	}
+	if plan.IsPartial() {
+		fmt.Printf("Components: %s\n", strings.Join(plan.Components, ", "))
+	}
 	fmt.Println()
 
 	if len(plan.WillCreate) > 0 {
@@ -384,11 +1315,16 @@ func displayDryRun(plan *models.InstallationPlan) error {
 	if len(plan.WillPreserve) > 0 {
 		fmt.Println("Would preserve:")
 		for _, item := range plan.WillPreserve {
-			fmt.Printf("  ✓ %s\n", item)
+			fmt.Printf(utils.Symbols("  ✓ %s\n"), item)
 		}
 		fmt.Println()
 	}
 
+	if len(plan.Files) > 0 {
+		displayPlannedFiles(plan.Files)
+		fmt.Println()
+	}
+
 	if len(plan.DirectoriesToCreate) > 0 {
 		fmt.Println("Would create directories:")
 		for _, dir := range plan.DirectoriesToCreate {
@@ -400,7 +1336,7 @@ func displayDryRun(plan *models.InstallationPlan) error {
 	if len(plan.SymlinksToCreate) > 0 {
 		fmt.Println("Would create symlinks:")
 		for _, symlink := range plan.SymlinksToCreate {
-			fmt.Printf("  → %s\n", symlink)
+			fmt.Printf(utils.Symbols("  → %s\n"), symlink)
 		}
 		fmt.Println()
 	}
@@ -408,7 +1344,7 @@ func displayDryRun(plan *models.InstallationPlan) error {
 	if len(plan.SymlinksToUpdate) > 0 {
 		fmt.Println("Would update symlinks:")
 		for _, symlink := range plan.SymlinksToUpdate {
-			fmt.Printf("  ↻ %s\n", symlink)
+			fmt.Printf(utils.Symbols("  ↻ %s\n"), symlink)
 		}
 		fmt.Println()
 	}
@@ -418,15 +1354,32 @@ func displayDryRun(plan *models.InstallationPlan) error {
 		fmt.Println()
 	}
 
+	if plan.Filesystem != nil {
+		fmt.Printf("File system: %s (copy strategy: %s)\n", plan.Filesystem, plan.CopyStrategy)
+		fmt.Println()
+	}
+
+	if settingsDiff != nil {
+		displaySettingsDiff(*settingsDiff)
+		fmt.Println()
+	}
+
+	if len(plan.Steps) > 0 {
+		fmt.Println("Would run steps:")
+		for i, step := range plan.Steps {
+			if step.Skipped {
+				fmt.Printf("  %2d. %s (skipped)\n", i+1, step.Name)
+			} else {
+				fmt.Printf("  %2d. %s: %s\n", i+1, step.Name, step.Description)
+			}
+		}
+		fmt.Println()
+	}
+
 	// Display script execution information
-	if plan.HasPreInstallScript || plan.HasPostInstallScript {
+	if len(plan.Scripts) > 0 {
 		fmt.Println("Would execute scripts:")
-		if plan.HasPreInstallScript {
-			fmt.Printf("  📜 %s (before installation)\n", "pre-install.sh")
-		}
-		if plan.HasPostInstallScript {
-			fmt.Printf("  📜 %s (after installation)\n", "post-install.sh")
-		}
+		displayScripts(plan.Scripts)
 		fmt.Println()
 	}
 
@@ -450,10 +1403,100 @@ func displayDryRun(plan *models.InstallationPlan) error {
 	return nil
 }
 
+// displayPlannedFiles prints how many framework files each action applies to
+// and lists the files that change; unchanged and preserved files are listed in
+// verbose mode only
+func displayPlannedFiles(files []models.PlannedFile) {
+	actions := []string{models.PlannedCreate, models.PlannedReplace, models.PlannedRemove, models.PlannedLink,
+		models.PlannedUnchanged, models.PlannedPreserve, models.PlannedSkip}
+	symbols := map[string]string{
+		models.PlannedCreate:    "+",
+		models.PlannedReplace:   "~",
+		models.PlannedRemove:    "-",
+		models.PlannedLink:      "→",
+		models.PlannedUnchanged: "=",
+		models.PlannedPreserve:  "✓",
+		models.PlannedSkip:      "·",
+	}
+	if utils.ASCII() {
+		// One character each, like the other markers
+		symbols[models.PlannedLink] = ">"
+		symbols[models.PlannedPreserve] = "*"
+		symbols[models.PlannedSkip] = "."
+	}
+
+	counts := make(map[string]int)
+	for _, file := range files {
+		counts[file.Action]++
+	}
+	var summary []string
+	for _, action := range actions {
+		if counts[action] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[action], action))
+		}
+	}
+	fmt.Printf("Framework files: %s\n", strings.Join(summary, ", "))
+
+	for _, file := range files {
+		if file.Changed || file.Action == models.PlannedLink || verbose {
+			fmt.Printf("  %s %s (%s)\n", symbols[file.Action], file.Path, file.Action)
+		}
+	}
+}
+
+// displayDryRunJSON prints the plan as JSON, with the settings diff when it
+// could be previewed; like the text output, it fails when the plan has errors
+func displayDryRunJSON(plan *models.InstallationPlan, settingsDiff *string) error {
+	output := struct {
+		*models.InstallationPlan
+		SettingsDiff *string `json:"settings_diff,omitempty"`
+	}{plan, settingsDiff}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("failed to encode installation plan: %w", err)
+	}
+	if len(plan.Errors) > 0 {
+		return fmt.Errorf("installation plan has errors")
+	}
+	return nil
+}
+
+// displayProfile prints the time spent in each installation step and the share of the total
+func displayProfile(timings models.StepTimings, total time.Duration) {
+	fmt.Println()
+	fmt.Println("Installation profile:")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, step := range timings {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\n", step.Name, utils.FormatDuration(step.Duration), percentOf(step.Duration, total))
+	}
+	if other := total - timings.Total(); other > 0 {
+		fmt.Fprintf(writer, "  other\t%s\t%s\n", utils.FormatDuration(other), percentOf(other, total))
+	}
+	fmt.Fprintf(writer, "  total\t%s\n", utils.FormatDuration(total))
+	writer.Flush()
+}
+
+// percentOf formats part as a percentage of total
+func percentOf(part, total time.Duration) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
 // displayPostInstallInfo shows helpful information after successful installation
 func displayPostInstallInfo(plan *models.InstallationPlan) {
 	fmt.Println()
-	fmt.Println("🎉 Strategic Claude Basic has been installed!")
+	fmt.Println(utils.Symbols("🎉 " + i18n.T("Strategic Claude Basic has been installed!")))
 	fmt.Println()
-	fmt.Printf("Use 'strategic-claude-basic-cli status -t %s' to check installation status.\n", plan.TargetDir)
+	statusArgs := "-t " + plan.TargetDir
+	if config.Instance() != "" {
+		statusArgs += " --instance " + config.Instance()
+	}
+	fmt.Println(i18n.T("Use 'strategic-claude-basic-cli status %s' to check installation status.", statusArgs))
+	if plan.Direnv {
+		fmt.Println(i18n.T("Run 'direnv allow' to load the updated .envrc."))
+	}
 }
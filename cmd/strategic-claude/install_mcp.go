@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/mcp"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/ui"
@@ -14,8 +15,9 @@ import (
 )
 
 var installMcpCmd = &cobra.Command{
-	Use:   "install-mcp",
-	Short: "Install MCP servers from available templates",
+	Use:     "install-mcp",
+	Short:   "Install MCP servers from available templates",
+	GroupID: groupInstall,
 	Long: `Install MCP (Model Context Protocol) servers from available templates.
 
 This command will:
@@ -52,7 +54,7 @@ func runInstallMCP(cmd *cobra.Command, args []string) error {
 	mcpService := mcp.New()
 
 	// Step 1: Scan for available MCP templates
-	strategicDir := filepath.Join(absTargetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(absTargetDir, config.FrameworkDir())
 	utils.VerbosePrintln(verbose, "Scanning for available MCP templates...")
 
 	availableMCPs, err := mcpService.ScanAvailableMCPs(strategicDir)
@@ -97,7 +99,7 @@ func runInstallMCP(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !confirmed {
-		utils.DisplayInfo("MCP installation cancelled by user")
+		utils.DisplayInfo(i18n.T("MCP installation cancelled by user"))
 		return nil
 	}
 
@@ -131,31 +133,31 @@ func getMCPInstallationConfirmation(plan *models.MCPInstallationPlan) (bool, err
 	fmt.Println()
 	fmt.Printf("MCP servers to install (%d):\n", len(plan.SelectedMCPs))
 	for _, mcp := range plan.SelectedMCPs {
-		fmt.Printf("  • %s (%s %v)\n", mcp.Name, mcp.Server.Command, mcp.Server.Args)
+		fmt.Printf(utils.Symbols("  • %s (%s %v)\n"), mcp.Name, mcp.Server.Command, mcp.Server.Args)
 	}
 	fmt.Println()
 
 	// Warning about MCP installation
-	utils.DisplayWarning("This will modify your project's .mcp.json configuration file.")
+	utils.DisplayWarning(i18n.T("This will modify your project's .mcp.json configuration file."))
 	if plan.HasExistingMCP {
-		utils.DisplayWarning("Existing configuration will be backed up before modification.")
+		utils.DisplayWarning(i18n.T("Existing configuration will be backed up before modification."))
 	}
 	fmt.Println()
 
 	// Ask for confirmation
 	interactionService := utils.NewInteractionService()
-	return interactionService.ConfirmPrompt("Do you want to proceed with MCP server installation?")
+	return interactionService.ConfirmPrompt(i18n.T("Do you want to proceed with MCP server installation?"))
 }
 
 // displayMCPPostInstallInfo shows information after successful installation
 func displayMCPPostInstallInfo(plan *models.MCPInstallationPlan) {
 	fmt.Println()
 	fmt.Println("MCP Installation Complete!")
-	fmt.Printf("• Configuration file: %s\n", plan.ExistingMCPPath)
-	fmt.Printf("• Installed %d MCP server(s)\n", len(plan.SelectedMCPs))
+	fmt.Printf(utils.Symbols("• Configuration file: %s\n"), plan.ExistingMCPPath)
+	fmt.Printf(utils.Symbols("• Installed %d MCP server(s)\n"), len(plan.SelectedMCPs))
 
 	if plan.HasExistingMCP {
-		fmt.Printf("• Backup created: %s\n", plan.BackupPath)
+		fmt.Printf(utils.Symbols("• Backup created: %s\n"), plan.BackupPath)
 	}
 
 	fmt.Println()
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/registry"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var listManagedPrune bool
+
+var listManagedCmd = &cobra.Command{
+	Use:     "list-managed",
+	Short:   "List the projects this CLI installed into",
+	GroupID: groupInspect,
+	Long: `List the projects in the registry of installations, which init and update
+record every project they install into and clean removes them from. 'update --all'
+and 'status --all' work on these projects.
+
+The registry is projects.json in $SCB_DATA_DIR, or in
+$XDG_DATA_HOME/strategic-claude-basic when XDG_DATA_HOME is set, otherwise in
+~/.local/share/strategic-claude-basic.
+
+Projects whose directory no longer exists are marked missing; --prune removes
+them from the registry.
+
+Examples:
+  strategic-claude-basic-cli list-managed          # List the projects
+  strategic-claude-basic-cli list-managed --prune  # Forget projects that were deleted`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runListManaged()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listManagedCmd)
+
+	listManagedCmd.Flags().BoolVar(&listManagedPrune, "prune", false, "remove projects whose directory no longer exists")
+}
+
+// runListManaged executes the list-managed command logic
+func runListManaged() error {
+	registryService := registry.New()
+
+	if listManagedPrune {
+		pruned, err := registryService.Prune()
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		for _, entry := range pruned {
+			utils.VerbosePrintf(verbose, "Removed %s\n", entry.Path)
+		}
+		utils.DisplaySuccess(fmt.Sprintf("Removed %d missing project(s) from the registry", len(pruned)))
+	}
+
+	entries, err := registryService.List()
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+	if len(entries) == 0 {
+		utils.DisplayInfo("No projects in the registry")
+		return nil
+	}
+
+	missing := 0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PROJECT\tTEMPLATE\tUPDATED\tSTATE")
+	for _, entry := range entries {
+		state := "ok"
+		if !registry.Exists(entry) {
+			state = "missing"
+			missing++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", entry.Path, entry.Template, entry.UpdatedAt.Local().Format(time.DateTime), state)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d project(s) in %s\n", len(entries), registryService.Path())
+	if missing > 0 {
+		utils.DisplayInfo(fmt.Sprintf("%d project(s) no longer exist; remove them with 'list-managed --prune'", missing))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/document"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var newCmd = &cobra.Command{
+	Use:     "new <plan|research|summary> <name>",
+	Short:   "Create a plan, research or summary document from its template",
+	GroupID: groupAuthoring,
+	Long: `Create a new document in the matching user directory of the installation.
+
+The framework template .strategic-claude-basic/templates/documents/<kind>.template.md
+is copied to .strategic-claude-basic/<kind>/<date>-<name>.md. When the installed
+framework has no template for the kind, a minimal document with front matter is
+created instead.
+
+Placeholders filled in:
+- {{title}}, {{name}}: the name as given
+- {{slug}}: the name as used in the file name
+- {{date}}, {{datetime}}: today's date and the current time
+- {{kind}}: plan, research or summary
+Empty title, name and date front matter fields are filled in as well.
+
+Examples:
+  strategic-claude-basic-cli new plan "User authentication"       # plan/2025-01-31-user-authentication.md
+  strategic-claude-basic-cli new research "Caching options"
+  strategic-claude-basic-cli new summary "Sprint 12" --target ./my-project`,
+	Args: cobra.MinimumNArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return document.GetKinds(), cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runNew(args[0], strings.Join(args[1:], " "))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+}
+
+// runNew executes the new command logic
+func runNew(kind, name string) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	doc, err := document.New().Create(absTarget, kind, name)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to create %s: %w", kind, err))
+		return err
+	}
+
+	if doc.TemplatePath == "" {
+		utils.DisplayWarning(fmt.Sprintf("No %s template found at %s; used a minimal document",
+			kind, document.TemplatePath(absTarget, kind)))
+	} else {
+		utils.VerbosePrintf(verbose, "Template: %s\n", doc.TemplatePath)
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Created %s", doc.Path))
+	return nil
+}
@@ -1,15 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/ui"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	verbose   bool
-	targetDir string
+	verbose      bool
+	targetDir    string
+	logFormat    string
+	frameworkDir string
+	configFile   string
+	instance     string
+	tempRoot     string
+	lang         string
+	accessible   bool
+	asciiOutput  bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -22,20 +42,101 @@ of the Strategic Claude Basic framework into your development projects.
 It provides commands to install, update, check status, and clean up the framework
 installation while preserving your custom configurations and user content.`,
 	Version: getVersion(),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setLocale(); err != nil {
+			return err
+		}
+		ui.SetAccessible(accessibleMode())
+		utils.SetASCII(asciiMode())
+		if _, err := reporter.New(logFormat, verbose); err != nil {
+			return err
+		}
+		if err := loadConfig(cmd, targetDir); err != nil {
+			return err
+		}
+		removeStaleTempDirs()
+		startUpdateCheck(cmd)
+		return nil
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		printUpdateHint()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	if cmd, ok := helpJSONCommand(os.Args[1:]); ok {
+		if err := writeHelpJSON(os.Stdout, cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(config.ExitGeneralError)
+		}
+		return
+	}
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		var codeErr *exitCodeError
+		if !errors.As(err, &codeErr) || codeErr.err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", i18n.T("Error"), err)
+			if verbose {
+				displayRemediation(err)
+			}
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCodeError makes the process exit with a specific code instead of the general error code.
+// Without err, the command already reported the outcome the code stands for.
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode wraps err so that the process exits with code when it fails the command
+func withExitCode(err error, code int) error {
+	return &exitCodeError{err: err, code: code}
+}
+
+// silentExit makes the process exit with code without printing an error, for
+// commands whose exit code is part of their output
+func silentExit(code int) error {
+	return &exitCodeError{code: code}
+}
+
+// exitCode returns the code the process exits with when err fails a command
+func exitCode(err error) int {
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
 	}
+	return config.ExitGeneralError
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().StringVarP(&targetDir, "target", "t", ".", "target directory for operations")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", reporter.FormatText, "how progress and warnings are reported: text, json (to stderr) or silent")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "user config file (default: $SCB_CONFIG, else "+config.UserConfigFile+" in the user config directory)")
+	rootCmd.PersistentFlags().StringVar(&frameworkDir, "framework-dir", "", "name of the framework directory (default: from the config, else "+config.StrategicClaudeBasicDir+"; see 'config')")
+	rootCmd.PersistentFlags().StringVar(&instance, "instance", "", "work on the named installation in "+config.InstanceDirPrefix+"<name> instead of the default one")
+	rootCmd.MarkFlagsMutuallyExclusive("framework-dir", "instance")
+	rootCmd.PersistentFlags().StringVar(&tempRoot, "temp-dir", "", "directory template checkouts are made in (default: $"+config.TempDirEnvVar+" or temp_dir in the config, else the system's)")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "language of messages: "+strings.Join(i18n.Locales(), ", ")+" (default: from $LC_ALL, $LC_MESSAGES or $LANG, else en)")
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "ask with plain numbered prompts and print no colors or screen clears, for screen readers and dumb terminals (default: $"+config.AccessibleEnvVar+", or when $TERM is dumb)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "print ASCII markers instead of emoji and Unicode symbols (default: $"+config.ASCIIEnvVar+", or in the Linux console, dumb terminals and non-UTF-8 locales)")
+	rootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "do not check once a day whether a newer template commit is available (default: $SCB_NO_UPDATE_CHECK)")
 
 	// Custom completions for flags
 	if err := rootCmd.RegisterFlagCompletionFunc("target", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -44,4 +145,105 @@ func init() {
 		// This should not happen in normal operation, but we handle it for completeness
 		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --target flag: %v\n", err)
 	}
+	if err := rootCmd.RegisterFlagCompletionFunc("temp-dir", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{}, cobra.ShellCompDirectiveFilterDirs
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --temp-dir flag: %v\n", err)
+	}
+	if err := rootCmd.RegisterFlagCompletionFunc("lang", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return i18n.Locales(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --lang flag: %v\n", err)
+	}
+	if err := rootCmd.RegisterFlagCompletionFunc("instance", completeInstance); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --instance flag: %v\n", err)
+	}
+	if err := rootCmd.RegisterFlagCompletionFunc("log-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return reporter.GetFormats(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --log-format flag: %v\n", err)
+	}
+}
+
+// loadConfig makes commands use the configuration for target: the defaults,
+// the user and project config files and the environment, with flags taking
+// precedence over all of them
+func loadConfig(cmd *cobra.Command, target string) error {
+	cfg, err := resolveConfig(cmd, target)
+	if err != nil {
+		return err
+	}
+	if err := config.SetCurrent(cfg); err != nil {
+		return models.NewValidationError("framework-dir", cfg.FrameworkDir, err.Error())
+	}
+	return nil
+}
+
+// setLocale selects the language of messages: --lang, else the one of the environment
+func setLocale() error {
+	if !i18n.Set(lang) {
+		return models.NewValidationError("lang", lang, "must be one of: "+strings.Join(i18n.Locales(), ", "))
+	}
+	return nil
+}
+
+// accessibleMode reports whether output should suit screen readers and dumb
+// terminals: with --accessible or SCB_ACCESSIBLE, or when TERM is dumb
+func accessibleMode() bool {
+	return accessible || utils.EnvBool(config.AccessibleEnvVar) || os.Getenv("TERM") == "dumb"
+}
+
+// asciiMode reports whether output should use ASCII markers: with --ascii or
+// SCB_ASCII, or in a terminal unlikely to show emoji
+func asciiMode() bool {
+	return asciiOutput || utils.EnvBool(config.ASCIIEnvVar) || utils.LimitedTerminal()
+}
+
+// resolveConfig returns the configuration loadConfig would use for target
+// without making commands use it
+func resolveConfig(cmd *cobra.Command, target string) (config.Config, error) {
+	cfg, err := config.Load(config.LoadOptions{TargetDir: target, UserConfigPath: configFile})
+	if err != nil {
+		return cfg, models.NewAppError(models.ErrorCodeInvalidConfiguration, "Failed to load the configuration", err)
+	}
+	if cmd.Flags().Changed("framework-dir") {
+		cfg.FrameworkDir = frameworkDir
+	}
+	if tempRoot != "" {
+		absTempRoot, err := filepath.Abs(tempRoot)
+		if err != nil {
+			return cfg, models.NewValidationError("temp-dir", tempRoot, err.Error())
+		}
+		cfg.TempDir = absTempRoot
+	}
+	if instance != "" {
+		if err := config.ValidateInstanceName(instance); err != nil {
+			return cfg, models.NewValidationError("instance", instance, err.Error())
+		}
+		cfg = cfg.WithInstance(instance)
+	}
+	return cfg, nil
+}
+
+// operationContext returns the context an installation or cleanup runs in:
+// one that expires after timeout, counted from now, or none when it is 0
+func operationContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// newReporter returns the reporter selected by --log-format and --verbose for services
+func newReporter() reporter.Reporter {
+	r, err := reporter.New(logFormat, verbose)
+	if err != nil {
+		// The format is validated before any command runs
+		return reporter.Default()
+	}
+	return r
 }
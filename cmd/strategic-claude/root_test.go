@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/scaffold"
+)
+
+// runCommand runs the CLI with args and returns what it printed to stdout. The
+// flags it set are reset afterwards, as the commands are shared by all tests.
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, reader)
+		output <- buf.String()
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	rootCmd.SetArgs(args)
+	cmd, err := rootCmd.ExecuteC()
+	os.Stdout = stdout
+	writer.Close()
+	printed := <-output
+
+	rootCmd.SetArgs(nil)
+	for _, c := range []*cobra.Command{rootCmd, cmd} {
+		c.Flags().VisitAll(func(flag *pflag.Flag) {
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				_ = slice.Replace(nil)
+			} else {
+				_ = flag.Value.Set(flag.DefValue)
+			}
+			flag.Changed = false
+		})
+	}
+	i18n.Set(i18n.English)
+
+	if err != nil {
+		t.Fatalf("%s failed: %v\n%s", strings.Join(args, " "), err, printed)
+	}
+	return printed
+}
+
+func TestLang_Spanish(t *testing.T) {
+	t.Setenv(config.CacheDirEnvVar, t.TempDir())
+	t.Setenv(config.DataDirEnvVar, t.TempDir())
+	t.Setenv(config.NoUpdateCheckEnvVar, "1")
+	t.Setenv(config.ASCIIEnvVar, "")
+
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	target := filepath.Join(t.TempDir(), "project")
+	if err := os.Mkdir(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "init",
+			args: []string{"--lang", "es", "init", "--dev", "--template-path", checkout, "--yes", target},
+			want: []string{"¡Strategic Claude Basic se ha instalado!", "para comprobar el estado de la instalación"},
+		},
+		{
+			name: "status",
+			args: []string{"--lang", "es", "status", target},
+			want: []string{"Strategic Claude Basic está instalado y configurado correctamente", "Directorios:", "Información de la plantilla:"},
+		},
+		{
+			name: "clean",
+			args: []string{"--lang", "es", "clean", "--force", target},
+			want: []string{"Se eliminó el directorio " + config.StrategicClaudeBasicDir, "La limpieza de Strategic Claude Basic se completó correctamente"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := runCommand(t, tt.args...)
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("%s output is missing %q:\n%s", tt.name, want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAccessibleMode(t *testing.T) {
+	tests := []struct {
+		name string
+		flag bool
+		env  string
+		term string
+		want bool
+	}{
+		{name: "default", term: "xterm-256color", want: false},
+		{name: "flag", flag: true, term: "xterm-256color", want: true},
+		{name: "environment", env: "1", term: "xterm-256color", want: true},
+		{name: "environment off", env: "false", term: "xterm-256color", want: false},
+		{name: "dumb terminal", term: "dumb", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.AccessibleEnvVar, tt.env)
+			t.Setenv("TERM", tt.term)
+			accessible = tt.flag
+			t.Cleanup(func() { accessible = false })
+
+			if got := accessibleMode(); got != tt.want {
+				t.Errorf("accessibleMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
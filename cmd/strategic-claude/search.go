@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/document"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchIn            []string
+	searchFields        []string
+	searchSince         string
+	searchUntil         string
+	searchRegexp        bool
+	searchCaseSensitive bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:     "search <query>",
+	Short:   "Search plans, research, summaries and issues",
+	GroupID: groupInspect,
+	Long: `Search the user documents of an installation and print matching lines as
+<path>:<line>: <text>, with paths relative to .strategic-claude-basic.
+
+By default plan/, research/, summary/ and issues/ are searched for the query as
+plain, case-insensitive text. Documents can be narrowed down by their front
+matter:
+- --field key=value keeps documents whose front matter field has that value
+  (case-insensitive, repeatable)
+- --since and --until keep documents dated within the range (YYYY-MM-DD), using
+  the front matter date or the date at the start of the file name
+
+Examples:
+  strategic-claude-basic-cli search "rate limit"                        # Search all documents
+  strategic-claude-basic-cli search auth --in plan --field status=draft # Draft plans mentioning auth
+  strategic-claude-basic-cli search TODO --since 2025-01-01 --case-sensitive
+  strategic-claude-basic-cli search 'cach(e|ing)' --regexp`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSearch(strings.Join(args, " "))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringSliceVar(&searchIn, "in", nil, "user directories to search (default: plan, research, summary, issues)")
+	searchCmd.Flags().StringArrayVar(&searchFields, "field", nil, "only documents whose front matter has key=value (repeatable)")
+	searchCmd.Flags().StringVar(&searchSince, "since", "", "only documents dated on or after YYYY-MM-DD")
+	searchCmd.Flags().StringVar(&searchUntil, "until", "", "only documents dated on or before YYYY-MM-DD")
+	searchCmd.Flags().BoolVarP(&searchRegexp, "regexp", "E", false, "treat the query as a regular expression")
+	searchCmd.Flags().BoolVar(&searchCaseSensitive, "case-sensitive", false, "match case exactly")
+
+	if err := searchCmd.RegisterFlagCompletionFunc("in", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.GetUserPreservedDirectories(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --in flag: %v\n", err)
+	}
+}
+
+// runSearch executes the search command logic
+func runSearch(pattern string) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	query, err := buildSearchQuery(pattern)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	matches, err := document.New().Search(absTarget, query)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("search failed: %w", err))
+		return err
+	}
+
+	if len(matches) == 0 {
+		utils.DisplayInfo("No matches found")
+		return nil
+	}
+
+	files := make(map[string]bool)
+	for _, match := range matches {
+		fmt.Printf("%s:%d: %s\n", match.Path, match.Line, match.Text)
+		files[match.Path] = true
+	}
+	utils.VerbosePrintf(verbose, "%d match(es) in %d file(s)\n", len(matches), len(files))
+
+	return nil
+}
+
+// buildSearchQuery converts the search flags into a query
+func buildSearchQuery(pattern string) (document.SearchQuery, error) {
+	query := document.SearchQuery{
+		Pattern:       pattern,
+		Regexp:        searchRegexp,
+		CaseSensitive: searchCaseSensitive,
+		Dirs:          searchIn,
+		Fields:        make(map[string]string),
+	}
+
+	for _, field := range searchFields {
+		key, value, found := strings.Cut(field, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return query, models.NewValidationError("field", field, "must be key=value")
+		}
+		query.Fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	var err error
+	if query.Since, err = parseSearchDate("since", searchSince); err != nil {
+		return query, err
+	}
+	if query.Until, err = parseSearchDate("until", searchUntil); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseSearchDate parses an optional YYYY-MM-DD flag value
+func parseSearchDate(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, models.NewValidationError(flag, value, "must be a date in YYYY-MM-DD format")
+	}
+	return date, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/stats"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats supported by stats
+const (
+	statsOutputText = "text"
+	statsOutputJSON = "json"
+)
+
+var statsOutput string
+
+var statsCmd = &cobra.Command{
+	Use:     "stats [directory]",
+	Short:   "Summarize how an installation is used",
+	GroupID: groupInspect,
+	Long: `Report the number and size of documents in each user directory, the installed
+agents, commands and hooks, when the framework was last installed or updated,
+and how much space backups take.
+
+Use --output json for machine-readable output, e.g. to track adoption across
+repositories in a dashboard.
+
+Examples:
+  strategic-claude-basic-cli stats                       # Current directory
+  strategic-claude-basic-cli stats ./my-project          # Specific directory
+  strategic-claude-basic-cli stats --output json | jq .documents`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runStats(target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsOutput, "output", statsOutputText, "output format: text or json")
+
+	if err := statsCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{statsOutputText, statsOutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --output flag: %v\n", err)
+	}
+}
+
+// runStats executes the stats command logic
+func runStats(target string) error {
+	formats := []string{statsOutputText, statsOutputJSON}
+	if !slices.Contains(formats, statsOutput) {
+		err := models.NewValidationError("output", statsOutput, fmt.Sprintf("must be one of: %s", strings.Join(formats, ", ")))
+		utils.DisplayError(err)
+		return err
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	result, err := stats.New().Collect(absTarget)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to collect stats: %w", err))
+		return err
+	}
+
+	if statsOutput == statsOutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	displayStats(result)
+	return nil
+}
+
+// displayStats prints stats as text
+func displayStats(result *stats.Stats) {
+	fmt.Printf("Installation: %s\n", result.TargetDir)
+	if result.Template != "" {
+		fmt.Printf("  Template:     %s (%s)\n", result.Template, shortCommit(result.Commit))
+	}
+	if result.LastUpdated != "" {
+		fmt.Printf("  Last updated: %s\n", result.LastUpdated)
+	}
+
+	fmt.Printf("\nUser documents:\n")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, dir := range result.UserDirectories {
+		fmt.Fprintf(writer, "  %s/\t%d\t%s\n", dir.Name, dir.Documents, utils.FormatSize(dir.Size))
+	}
+	fmt.Fprintf(writer, "  total\t%d\t%s\n", result.Documents, utils.FormatSize(result.UserContentSize))
+	writer.Flush()
+
+	fmt.Printf("\nFramework:\n")
+	fmt.Printf("  Agents:   %d\n", result.Agents)
+	fmt.Printf("  Commands: %d\n", result.Commands)
+	fmt.Printf("  Hooks:    %d\n", result.Hooks)
+
+	fmt.Printf("\nBackups: %d (%s)\n", result.Backups, utils.FormatSize(result.BackupSize))
+}
@@ -1,37 +1,185 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/registry"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/watch"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/ui"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statusQuiet   bool
+	statusNoCache bool
+	statusFast    bool
+	statusDeep    bool
+	statusWatch   bool
+	statusFailOn  []string
+	statusAll     bool
+	statusRoot    string
+	statusJobs    int
+)
+
+// States of a project in status --all
+const (
+	projectStateHealthy      = "healthy"
+	projectStateIssues       = "issues"
+	projectStateDrift        = "drift"
+	projectStateNotInstalled = "not installed"
+	projectStateMissing      = "missing"
+	projectStateError        = "error"
+)
+
+// projectStatus is the result of one project in status --all
+type projectStatus struct {
+	project  string
+	template string
+	state    string
+	detail   string
+	failure  error // Of --quiet or --fail-on
+}
+
+// Conditions that --fail-on turns into a failing exit code
+const (
+	failOnIssues       = "issues"
+	failOnNotInstalled = "not-installed"
+	failOnDrift        = "drift"
+)
+
+// statusFailOnConditions lists the --fail-on conditions in the order they are checked;
+// severities fail on findings of that severity or a more severe one
+var statusFailOnConditions = []string{
+	failOnNotInstalled,
+	failOnIssues,
+	string(models.SeverityError),
+	string(models.SeverityWarning),
+	string(models.SeverityInfo),
+	failOnDrift,
+}
+
+// Styles of the finding groups; lipgloss drops the colors when output is not a terminal
+var findingStyles = map[models.Severity]lipgloss.Style{
+	models.SeverityError:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true),
+	models.SeverityWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true),
+	models.SeverityInfo:    lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true),
+}
+
+// findingHeadings are the group headings of the findings, by severity
+var findingHeadings = map[models.Severity]string{
+	models.SeverityError:   "Errors",
+	models.SeverityWarning: "Warnings",
+	models.SeverityInfo:    "Info",
+}
+
 var statusCmd = &cobra.Command{
-	Use:   "status [directory]",
-	Short: "Check Strategic Claude Basic installation status",
+	Use:     "status [directory]",
+	Short:   "Check Strategic Claude Basic installation status",
+	GroupID: groupInspect,
 	Long: `Check the installation status of Strategic Claude Basic framework in the specified directory.
 
 This command will:
 - Check for .strategic-claude-basic directory
 - Verify .claude directory structure
 - Check symlink integrity
+- Check that hook scripts in settings.json exist, are executable and have an interpreter
 - Report any configuration issues
+- Summarize the backups, their total size and age
 - Display detailed installation information
 
 Examples:
   strategic-claude-basic-cli status                 # Check current directory
   strategic-claude-basic-cli status ./my-project   # Check specific directory
-  strategic-claude-basic-cli status --verbose      # Show detailed information`,
+  strategic-claude-basic-cli status --verbose      # Show detailed information and a symlink table
+  strategic-claude-basic-cli status --quiet        # Only fail when unhealthy, for CI
+  strategic-claude-basic-cli status --quiet --fast # Cheap check for shell prompts
+  strategic-claude-basic-cli status --watch        # Check again whenever framework files change
+  strategic-claude-basic-cli status --quiet --fail-on=not-installed,issues,drift
+  strategic-claude-basic-cli status --all          # Check every project in the registry
+
+With --quiet nothing is printed on success, and the command fails when the
+framework is not installed or has issues.
+
+--fail-on selects the conditions that fail the command, with or without
+--quiet, each with its own exit code:
+  not-installed  the framework is not installed           (exit 8)
+  issues         the installation has issues              (exit 2)
+  drift          the installed template commit differs
+                 from the one this CLI installs, or
+                 framework files changed since install    (exit 6)
+  error          findings with severity error             (exit 2)
+  warning        findings with severity warning or error  (exit 2)
+  info           any finding                              (exit 2)
+
+Findings are grouped by severity; each has a stable ID, shown in brackets,
+that scripts can match on. Info findings are shown with --verbose.
+
+Results are cached for a few seconds in the cache directory (see 'cache dir')
+and reused while the installation is unchanged; --no-cache always checks.
+--fast checks symlinks by their link text only and skips following them to
+hook scripts, interpreters and Cursor rules.
+
+Installations record the hashes of their framework files. Status lists the
+framework files modified or removed since: every file is checked for existence
+and size, and the content of a sample of them is hashed. --deep hashes every
+file and always checks instead of reusing a recent result.
+
+--watch shows the status and checks again whenever files in .strategic-claude-basic
+or .claude change, until interrupted with Ctrl+C.
+
+--all checks every project in the registry of installations (see
+'list-managed'), or every installation found under --root, --jobs at once, and
+prints a table of their states. With --quiet or --fail-on, the command fails
+when any project meets a condition, with the exit code of the first one.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if statusAll {
+			if len(args) > 0 {
+				err := models.NewValidationError("directory", args[0], "cannot be combined with --all; use --root")
+				utils.DisplayError(err)
+				return err
+			}
+			return runStatusAll(cmd)
+		}
+		for _, name := range []string{"root", "jobs"} {
+			if cmd.Flags().Changed(name) {
+				err := models.NewValidationError(name, cmd.Flags().Lookup(name).Value.String(), "requires --all")
+				utils.DisplayError(err)
+				return err
+			}
+		}
+
 		// Determine target directory
 		target := targetDir
 		if len(args) > 0 {
 			target = args[0]
+			if err := loadConfig(cmd, target); err != nil {
+				return err
+			}
+		}
+
+		failOn, err := parseFailOn(statusFailOn)
+		if err != nil {
+			return err
 		}
 
 		// Convert to absolute path
@@ -46,127 +194,534 @@ Examples:
 
 		// Create status service and check installation
 		statusService := status.NewService()
+		statusService.SetFast(statusFast)
+		statusService.SetDeep(statusDeep)
+		if !statusNoCache && !statusWatch && !statusDeep {
+			if cacheRoot, err := cache.Dir(); err == nil {
+				statusService.SetCache(status.NewCache(filepath.Join(cacheRoot, config.StatusCacheDir), config.Current().StatusCacheTTL))
+			}
+		}
+		if statusWatch {
+			return watchStatus(absTarget, statusService)
+		}
+
 		statusInfo, err := statusService.CheckInstallation(absTarget)
 		if err != nil {
 			return fmt.Errorf("failed to check installation status: %w", err)
 		}
 
-		// Display status information
-		displayStatus(statusInfo, statusService, verbose)
+		if !statusQuiet {
+			// Display status information
+			displayStatus(statusInfo, statusService, verbose)
+		}
+
+		if statusQuiet || len(failOn) > 0 {
+			// Failing is the report; usage would only obscure it in CI logs
+			cmd.SilenceUsage = true
+			return statusFailure(statusInfo, failOn)
+		}
 
 		return nil
 	},
 }
 
+// runStatusAll checks every project in the registry or under --root and prints
+// a table of their states
+func runStatusAll(cmd *cobra.Command) error {
+	failOn, err := parseFailOn(statusFailOn)
+	if err != nil {
+		return err
+	}
+	if err := validateJobs(statusJobs); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	projects, base, err := workspaceProjects(statusRoot)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+	if len(projects) == 0 {
+		if !statusQuiet {
+			utils.DisplayInfo(noWorkspaceProjects(base))
+		}
+		return nil
+	}
+
+	results := make([]projectStatus, len(projects))
+	checkErr := runWorkspace(cmd, projects, statusJobs, func(i int, project string) error {
+		results[i] = checkProjectStatus(base, project, failOn)
+		if results[i].state == projectStateError {
+			return errors.New(results[i].detail)
+		}
+		return nil
+	}, func(i int, err error) {
+		results[i] = projectStatus{project: relativeProject(base, projects[i]), state: projectStateError, detail: err.Error()}
+	})
+
+	if !statusQuiet {
+		fmt.Println()
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, i18n.T("PROJECT\tTEMPLATE\tSTATE\tDETAIL"))
+		for _, result := range results {
+			template := result.template
+			if template == "" {
+				template = "-"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", result.project, template, i18n.T(result.state), result.detail)
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("\n%s\n", i18n.T("%d project(s) checked", len(results)))
+	}
+
+	if checkErr != nil {
+		utils.DisplayError(fmt.Errorf("failed to check installation status: %w", checkErr))
+		return checkErr
+	}
+	var failures []error
+	code := config.ExitSuccess
+	for _, result := range results {
+		if result.failure == nil {
+			continue
+		}
+		failures = append(failures, fmt.Errorf("%s: %w", result.project, result.failure))
+		if code == config.ExitSuccess {
+			code = exitCode(result.failure)
+		}
+	}
+	if len(failures) > 0 {
+		// Failing is the report; usage would only obscure it in CI logs
+		cmd.SilenceUsage = true
+		return withExitCode(models.NewAppError(models.ErrorCodeValidationFailed,
+			fmt.Sprintf("%d of %d projects failed the status check", len(failures), len(results)), errors.Join(failures...)), code)
+	}
+	return nil
+}
+
+// checkProjectStatus checks one project of status --all with the current
+// configuration; failOn is only applied with --quiet or --fail-on
+func checkProjectStatus(base, project string, failOn map[string]bool) projectStatus {
+	result := projectStatus{project: relativeProject(base, project)}
+	if !registry.Exists(registry.Entry{Path: project}) {
+		result.state = projectStateMissing
+		result.detail = "directory no longer exists; see list-managed --prune"
+		return result
+	}
+
+	statusService := status.NewService()
+	statusService.SetFast(statusFast)
+	statusService.SetDeep(statusDeep)
+	if !statusNoCache && !statusDeep {
+		if cacheRoot, err := cache.Dir(); err == nil {
+			statusService.SetCache(status.NewCache(filepath.Join(cacheRoot, config.StatusCacheDir), config.Current().StatusCacheTTL))
+		}
+	}
+	statusInfo, err := statusService.CheckInstallation(project)
+	if err != nil {
+		result.state = projectStateError
+		result.detail = err.Error()
+		return result
+	}
+	if statusInfo.InstalledTemplate != nil {
+		result.template = statusInfo.InstalledTemplate.Template.ID
+	}
+
+	switch {
+	case !statusInfo.IsInstalled:
+		result.state = projectStateNotInstalled
+	case statusInfo.HasIssues():
+		result.state = projectStateIssues
+		result.detail = fmt.Sprintf("%d issue(s): %s", len(statusInfo.Issues), statusInfo.Issues[0])
+	case statusInfo.HasDrift():
+		result.state = projectStateDrift
+		if len(statusInfo.Drift) > 0 {
+			result.detail = statusInfo.Drift[0]
+		} else {
+			result.detail = fmt.Sprintf("%d framework file(s) changed since installation", len(statusInfo.DriftCheck.Changed))
+		}
+	default:
+		result.state = projectStateHealthy
+	}
+
+	if statusQuiet || len(failOn) > 0 {
+		result.failure = statusFailure(statusInfo, failOn)
+	}
+	return result
+}
+
+// watchStatus displays the status and displays it again after every change to
+// the framework files, until the process is interrupted
+func watchStatus(absTarget string, statusService *status.Service) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	render := func() {
+		statusInfo, err := statusService.CheckInstallation(absTarget)
+		if ui.Accessible() {
+			// Screen readers and dumb terminals get each check after the last one
+			fmt.Println()
+		} else {
+			// Clear the screen and move the cursor home
+			fmt.Print("\033[H\033[2J")
+		}
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to check installation status: %w", err))
+		} else {
+			displayStatus(statusInfo, statusService, verbose)
+		}
+		fmt.Printf("\nWatching %s and %s for changes (last checked %s, Ctrl+C to stop)\n",
+			config.FrameworkDir(), config.ClaudeDir, time.Now().Format("15:04:05"))
+	}
+
+	render()
+	return watch.New(absTarget, config.FrameworkDir(), config.ClaudeDir).Run(ctx, render)
+}
+
+// parseFailOn validates the --fail-on conditions; values may also be comma separated
+func parseFailOn(values []string) (map[string]bool, error) {
+	failOn := make(map[string]bool)
+	for _, value := range values {
+		condition := strings.TrimSpace(value)
+		valid := false
+		for _, known := range statusFailOnConditions {
+			if condition == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, models.NewValidationError("fail-on", value,
+				fmt.Sprintf("must be one of: %s", strings.Join(statusFailOnConditions, ", ")))
+		}
+		failOn[condition] = true
+	}
+	return failOn, nil
+}
+
+// statusFailure returns why an installation fails the selected conditions, or nil
+// when it passes them. Without conditions, a missing installation and issues fail.
+func statusFailure(statusInfo *models.StatusInfo, failOn map[string]bool) error {
+	if len(failOn) == 0 {
+		failOn = map[string]bool{failOnNotInstalled: true, failOnIssues: true}
+	}
+
+	if failOn[failOnNotInstalled] && !statusInfo.IsInstalled {
+		return withExitCode(models.NewAppError(models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", statusInfo.TargetDir), nil), config.ExitNotInstalled)
+	}
+	if failOn[failOnIssues] && statusInfo.HasIssues() {
+		return withExitCode(models.NewAppError(models.ErrorCodeValidationFailed,
+			fmt.Sprintf("%d installation issue(s): %s", len(statusInfo.Issues), strings.Join(statusInfo.Issues, "; ")), nil), config.ExitValidationError)
+	}
+	for _, severity := range models.Severities() {
+		if failOn[string(severity)] && statusInfo.HasFindingsAtLeast(severity) {
+			var messages []string
+			for _, finding := range statusInfo.Findings {
+				if finding.Severity.AtLeast(severity) {
+					messages = append(messages, fmt.Sprintf("[%s] %s", finding.ID, finding.Message))
+				}
+			}
+			return withExitCode(models.NewAppError(models.ErrorCodeValidationFailed,
+				fmt.Sprintf("%d finding(s) with severity %s or higher: %s", len(messages), severity, strings.Join(messages, "; ")), nil), config.ExitValidationError)
+		}
+	}
+	if failOn[failOnDrift] && statusInfo.HasDrift() {
+		return withExitCode(models.NewAppError(models.ErrorCodeValidationFailed,
+			fmt.Sprintf("installation drifted: %s", strings.Join(statusInfo.Drift, "; ")), nil), config.ExitInstallationError)
+	}
+	return nil
+}
+
+// Styles of the symlink table cells
+var (
+	symlinkHeaderStyle = lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	symlinkCellStyle   = lipgloss.NewStyle().Padding(0, 1)
+	symlinkYesStyle    = symlinkCellStyle.Foreground(lipgloss.Color("10"))
+	symlinkNoStyle     = symlinkCellStyle.Foreground(lipgloss.Color("9"))
+)
+
+// symlinkTable renders every required Claude and Codex symlink with where it points
+// and where it should point, so partially broken installations are easy to debug
+func symlinkTable(statusInfo *models.StatusInfo) string {
+	yesNo := func(value bool) string {
+		if value {
+			return i18n.T("yes")
+		}
+		return i18n.T("no")
+	}
+
+	// Links are sorted by path, since they are checked in map order
+	symlinks := append(slices.Clone(statusInfo.Symlinks), statusInfo.CodexSymlinks...)
+	slices.SortFunc(symlinks, func(a, b models.SymlinkStatus) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	var rows [][]string
+	for _, symlink := range symlinks {
+		name := symlink.Path
+		if relPath, err := filepath.Rel(statusInfo.TargetDir, symlink.Path); err == nil {
+			name = relPath
+		}
+
+		pointsTo, targetExists := "-", "-"
+		if symlink.Target != "" {
+			pointsTo = symlink.Target
+		}
+		if symlink.TargetExists != nil {
+			targetExists = yesNo(*symlink.TargetExists)
+		}
+		rows = append(rows, []string{name, yesNo(symlink.Exists), pointsTo, targetExists, symlink.Expected})
+	}
+
+	border := lipgloss.NormalBorder()
+	if utils.ASCII() {
+		border = lipgloss.ASCIIBorder()
+	}
+	t := table.New().
+		Border(border).
+		Headers(i18n.T("NAME"), i18n.T("EXISTS"), i18n.T("POINTS TO"), i18n.T("TARGET EXISTS"), i18n.T("EXPECTED")).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return symlinkHeaderStyle
+			case (col == 1 || col == 3) && rows[row][col] == i18n.T("yes"):
+				return symlinkYesStyle
+			case (col == 1 || col == 3) && rows[row][col] == i18n.T("no"):
+				return symlinkNoStyle
+			case col == 2 && symlinks[row].Exists && !symlinks[row].Valid:
+				return symlinkNoStyle
+			default:
+				return symlinkCellStyle
+			}
+		})
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(t.String())
+}
+
 // displayStatus formats and displays the installation status information
 func displayStatus(statusInfo *models.StatusInfo, statusService *status.Service, verbose bool) {
 	// Display main status summary
 	summary := statusService.GetStatusSummary(statusInfo)
 	if statusInfo.IsInstalled {
 		if statusInfo.HasIssues() {
-			fmt.Printf("⚠️  %s\n", summary)
+			fmt.Printf(utils.Symbols("⚠️  %s\n"), summary)
 		} else {
-			fmt.Printf("✅ %s\n", summary)
+			fmt.Printf(utils.Symbols("✅ %s\n"), summary)
 		}
 	} else {
-		fmt.Printf("❌ %s\n", summary)
+		fmt.Printf(utils.Symbols("❌ %s\n"), summary)
 	}
 
 	// Display directory information
-	fmt.Printf("\nDirectories:\n")
+	fmt.Printf("\n%s\n", i18n.T("Directories:"))
 	if statusInfo.StrategicClaudeDir {
-		fmt.Printf("  ✅ Strategic Claude Basic: %s\n", statusInfo.StrategicClaudeDirPath)
+		fmt.Printf(utils.Symbols("  ✅ %s\n"), i18n.T("Strategic Claude Basic: %s", statusInfo.StrategicClaudeDirPath))
+		if statusInfo.DevTemplatePath != "" {
+			fmt.Printf(utils.Symbols("  🔧 %s\n"), i18n.T("Dev mode: framework linked from %s", statusInfo.DevTemplatePath))
+		}
 	} else {
-		fmt.Printf("  ❌ Strategic Claude Basic: %s (not found)\n", statusInfo.StrategicClaudeDirPath)
+		fmt.Printf(utils.Symbols("  ❌ %s\n"), i18n.T("Strategic Claude Basic: %s (not found)", statusInfo.StrategicClaudeDirPath))
 	}
 
 	if statusInfo.ClaudeDir {
-		fmt.Printf("  ✅ Claude Integration: %s\n", statusInfo.ClaudeDirPath)
+		fmt.Printf(utils.Symbols("  ✅ %s\n"), i18n.T("Claude Integration: %s", statusInfo.ClaudeDirPath))
 	} else {
-		fmt.Printf("  ❌ Claude Integration: %s (not found)\n", statusInfo.ClaudeDirPath)
+		fmt.Printf(utils.Symbols("  ❌ %s\n"), i18n.T("Claude Integration: %s (not found)", statusInfo.ClaudeDirPath))
+	}
+
+	if rules := statusInfo.CursorRules; rules != nil {
+		if rules.Valid() {
+			fmt.Printf(utils.Symbols("  ✅ %s\n"), i18n.T("Cursor Rules: %s (%s, %d rules)", rules.Path, rules.Mode, rules.Rules))
+		} else {
+			fmt.Printf(utils.Symbols("  ⚠️  %s\n"), i18n.T("Cursor Rules: %s (%s, needs attention)", rules.Path, rules.Mode))
+		}
+	}
+
+	// Named installations side by side with the checked one
+	var others []string
+	for _, instance := range statusInfo.Instances {
+		if instance != statusInfo.Instance {
+			others = append(others, instance)
+		}
+	}
+	if statusInfo.Instance != "" || len(others) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("Instances:"))
+		if statusInfo.Instance != "" {
+			fmt.Printf("  %s\n", i18n.T("Checked: %s", statusInfo.Instance))
+		}
+		for _, instance := range others {
+			fmt.Printf("  - %s\n", i18n.T("%s (check with --instance %s)", instance, instance))
+		}
 	}
 
 	// Display template information
 	if statusInfo.InstalledTemplate != nil {
-		fmt.Printf("\nTemplate Information:\n")
+		fmt.Printf("\n%s\n", i18n.T("Template Information:"))
 		template := statusInfo.InstalledTemplate.Template
-		fmt.Printf("  Name: %s\n", template.DisplayName())
-		fmt.Printf("  ID: %s\n", template.ID)
-		fmt.Printf("  Description: %s\n", template.Description)
-		fmt.Printf("  Branch: %s\n", template.Branch)
-		fmt.Printf("  Commit: %s\n", template.Commit)
+		fmt.Printf("  %s\n", i18n.T("Name: %s", template.DisplayName()))
+		fmt.Printf("  %s\n", i18n.T("ID: %s", template.ID))
+		fmt.Printf("  %s\n", i18n.T("Description: %s", template.Description))
+		fmt.Printf("  %s\n", i18n.T("Branch: %s", template.Branch))
+		fmt.Printf("  %s\n", i18n.T("Commit: %s", template.Commit))
 		if statusInfo.InstalledTemplate.InstalledAt != "" {
-			fmt.Printf("  Installed At: %s\n", statusInfo.InstalledTemplate.InstalledAt)
+			fmt.Printf("  %s\n", i18n.T("Installed At: %s", statusInfo.InstalledTemplate.InstalledAt))
 		}
 		if template.Language != "" {
-			fmt.Printf("  Language: %s\n", template.Language)
+			fmt.Printf("  %s\n", i18n.T("Language: %s", template.Language))
 		}
 		if len(template.Tags) > 0 {
-			fmt.Printf("  Tags: %v\n", template.Tags)
+			fmt.Printf("  %s\n", i18n.T("Tags: %v", template.Tags))
 		}
 	}
 
 	// Display symlink information
-	if len(statusInfo.Symlinks) > 0 {
-		fmt.Printf("\nSymlinks:\n")
+	if verbose && len(statusInfo.Symlinks)+len(statusInfo.CodexSymlinks) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("Symlinks:"))
+		fmt.Println(symlinkTable(statusInfo))
+	} else if len(statusInfo.Symlinks) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("Symlinks:"))
 		for _, symlink := range statusInfo.Symlinks {
 			switch {
 			case symlink.Valid:
-				fmt.Printf("  ✅ %s → %s\n", symlink.Name, symlink.Target)
+				fmt.Printf(utils.Symbols("  ✅ %s → %s\n"), symlink.Name, symlink.Target)
 			case symlink.Exists:
-				fmt.Printf("  ⚠️  %s → %s (%s)\n", symlink.Name, symlink.Target, symlink.Error)
+				fmt.Printf(utils.Symbols("  ⚠️  %s → %s (%s)\n"), symlink.Name, symlink.Target, symlink.Error)
 			default:
-				fmt.Printf("  ❌ %s (not found)\n", symlink.Name)
+				fmt.Printf(utils.Symbols("  ❌ %s\n"), i18n.T("%s (not found)", symlink.Name))
 			}
 		}
 	}
 
-	// Display issues
-	if statusInfo.HasIssues() {
-		fmt.Printf("\nIssues Found:\n")
-		for _, issue := range statusInfo.Issues {
-			fmt.Printf("  - %s\n", issue)
+	// Display hook information
+	if len(statusInfo.Hooks) > 0 {
+		fmt.Printf("\n%s\n", i18n.T("Hooks:"))
+		for _, hook := range statusInfo.Hooks {
+			if hook.Valid() {
+				if verbose {
+					fmt.Printf(utils.Symbols("  ✅ %s: %s\n"), hook.Event, hook.Script)
+				}
+				continue
+			}
+			fmt.Printf(utils.Symbols("  ❌ %s: %s (%s)\n"), hook.Event, hook.Script, hook.Error)
+		}
+		if !verbose && len(statusInfo.BrokenHooks()) == 0 {
+			fmt.Printf(utils.Symbols("  ✅ %s\n"), i18n.T("%d hook(s) ready to run", len(statusInfo.Hooks)))
+		}
+	}
+
+	// Display backup footprint
+	if backups := statusInfo.Backups; backups != nil {
+		fmt.Printf("\n%s\n", i18n.T("Backups:"))
+		fmt.Printf("  %s\n", i18n.T("%d backup(s), %s in %s", backups.Count, utils.FormatSize(backups.TotalSize), backups.Dir))
+		fmt.Printf("  %s\n", i18n.T("Newest: %s (%s)", backups.Newest.Format(time.DateTime), utils.FormatAge(time.Since(*backups.Newest))))
+		if backups.Count > 1 {
+			fmt.Printf("  %s\n", i18n.T("Oldest: %s (%s)", backups.Oldest.Format(time.DateTime), utils.FormatAge(time.Since(*backups.Oldest))))
+		}
+		if backups.NeedsPruning() {
+			fmt.Printf("  %s\n", i18n.T("Consider removing old backups with '%s backup prune'", "strategic-claude-basic-cli"))
+		}
+	}
+
+	// Display findings grouped by severity
+	for _, severity := range models.Severities() {
+		if severity == models.SeverityInfo && !verbose {
+			continue
+		}
+		findings := statusInfo.FindingsOf(severity)
+		if len(findings) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s\n", findingStyles[severity].Render(fmt.Sprintf("%s (%d):", i18n.T(findingHeadings[severity]), len(findings))))
+		for _, finding := range findings {
+			fmt.Printf("  - %s [%s]\n", finding.Message, finding.ID)
+		}
+	}
+
+	// Display drift
+	if statusInfo.HasDrift() {
+		fmt.Printf("\n%s\n", i18n.T("Drift:"))
+		for _, drift := range statusInfo.Drift {
+			fmt.Printf("  - %s\n", drift)
+		}
+		if check := statusInfo.DriftCheck; check != nil {
+			for _, file := range check.Changed {
+				fmt.Printf("  - %s/%s (%s)\n", config.FrameworkDir(), file.Path, i18n.T(file.Change))
+			}
+		}
+	}
+	if check := statusInfo.DriftCheck; check != nil && verbose {
+		if check.Deep {
+			fmt.Printf("\n%s\n", i18n.T("Compared all %d framework files with the install manifest", check.Files))
+		} else {
+			fmt.Printf("\n%s\n", i18n.T("Compared %d of %d framework files with the install manifest (--deep compares all)", check.Hashed, check.Files))
 		}
 	}
 
 	// Verbose information
 	if verbose {
-		fmt.Printf("\nDetailed Information:\n")
-		fmt.Printf("  Target Directory: %s\n", statusInfo.TargetDir)
-		fmt.Printf("  Valid Symlinks: %d/%d\n", statusInfo.ValidSymlinks(), len(statusInfo.Symlinks))
+		fmt.Printf("\n%s\n", i18n.T("Detailed Information:"))
+		fmt.Printf("  %s\n", i18n.T("Target Directory: %s", statusInfo.TargetDir))
+		fmt.Printf("  %s\n", i18n.T("Valid Symlinks: %d/%d", statusInfo.ValidSymlinks(), len(statusInfo.Symlinks)))
 
 		if statusInfo.InstallationDate != nil {
-			fmt.Printf("  Installation Date: %s\n", statusInfo.InstallationDate.Format("2006-01-02 15:04:05"))
+			fmt.Printf("  %s\n", i18n.T("Installation Date: %s", statusInfo.InstallationDate.Format("2006-01-02 15:04:05")))
 		}
 
 		if statusInfo.Version != "" {
-			fmt.Printf("  Version: %s\n", statusInfo.Version)
+			fmt.Printf("  %s\n", i18n.T("Version: %s", statusInfo.Version))
 		}
 
 		if statusInfo.CommitHash != "" {
-			fmt.Printf("  Commit Hash: %s\n", statusInfo.CommitHash)
+			fmt.Printf("  %s\n", i18n.T("Commit Hash: %s", statusInfo.CommitHash))
 		}
 	}
 
 	// Add recommendation for next steps
 	if !statusInfo.IsInstalled {
-		fmt.Printf("\nTo install Strategic Claude Basic, run:\n")
+		fmt.Printf("\n%s\n", i18n.T("To install Strategic Claude Basic, run:"))
 		fmt.Printf("  %s init\n", "strategic-claude-basic-cli")
 	} else if statusInfo.HasIssues() {
-		fmt.Printf("\nTo fix issues, you may need to:\n")
-		fmt.Printf("  - Run '%s clean' to remove the installation\n", "strategic-claude-basic-cli")
-		fmt.Printf("  - Then run '%s init' to reinstall\n", "strategic-claude-basic-cli")
+		fmt.Printf("\n%s\n", i18n.T("To fix issues, you may need to:"))
+		fmt.Printf("  - %s\n", i18n.T("Run '%s clean' to remove the installation", "strategic-claude-basic-cli"))
+		fmt.Printf("  - %s\n", i18n.T("Then run '%s init' to reinstall", "strategic-claude-basic-cli"))
 	}
 }
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
 
-	// Custom completion for directory argument
-	statusCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		if len(args) == 0 {
-			return []string{}, cobra.ShellCompDirectiveFilterDirs
-		}
-		return []string{}, cobra.ShellCompDirectiveNoFileComp
+	statusCmd.Flags().BoolVarP(&statusQuiet, "quiet", "q", false, "print nothing and fail when the framework is not installed or has issues")
+	statusCmd.Flags().BoolVar(&statusNoCache, "no-cache", false, "always check instead of reusing a recent result")
+	statusCmd.Flags().BoolVar(&statusFast, "fast", false, "skip following symlinks to hook scripts, interpreters and Cursor rules")
+	statusCmd.Flags().BoolVar(&statusDeep, "deep", false, "hash every framework file listed in the install manifest instead of a sample")
+	statusCmd.Flags().BoolVar(&statusWatch, "watch", false, "check again whenever framework files change, until interrupted")
+	statusCmd.Flags().StringSliceVar(&statusFailOn, "fail-on", nil, "fail with a dedicated exit code on: not-installed, issues, drift, or a severity: error, warning, info (comma separated)")
+	statusCmd.MarkFlagsMutuallyExclusive("watch", "quiet")
+	statusCmd.MarkFlagsMutuallyExclusive("fast", "deep")
+	statusCmd.MarkFlagsMutuallyExclusive("watch", "fail-on")
+	statusCmd.Flags().BoolVar(&statusAll, "all", false, "check every project in the registry, or every installation found under --root")
+	statusCmd.Flags().StringVar(&statusRoot, "root", "", "directory searched for installations by --all instead of using the registry")
+	statusCmd.Flags().IntVar(&statusJobs, "jobs", config.DefaultWorkspaceJobs, "projects --all checks at once")
+	statusCmd.MarkFlagsMutuallyExclusive("watch", "all")
+
+	if err := statusCmd.RegisterFlagCompletionFunc("fail-on", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return statusFailOnConditions, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --fail-on flag: %v\n", err)
+	}
+
+	if err := statusCmd.RegisterFlagCompletionFunc("root", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{}, cobra.ShellCompDirectiveFilterDirs
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --root flag: %v\n", err)
 	}
+
+	// Custom completion for directory argument: managed projects, else directories
+	statusCmd.ValidArgsFunction = completeProjectDir
 }
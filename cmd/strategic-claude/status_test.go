@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestParseFailOn(t *testing.T) {
+	failOn, err := parseFailOn([]string{"issues", " drift"})
+	if err != nil {
+		t.Fatalf("parseFailOn() error = %v", err)
+	}
+	if !failOn[failOnIssues] || !failOn[failOnDrift] || failOn[failOnNotInstalled] {
+		t.Errorf("parseFailOn() = %v, want issues and drift", failOn)
+	}
+
+	if _, err := parseFailOn([]string{"warnings"}); !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Errorf("parseFailOn(warnings) error = %v, want a validation error", err)
+	}
+}
+
+func TestStatusFailure(t *testing.T) {
+	healthy := &models.StatusInfo{IsInstalled: true}
+	missing := &models.StatusInfo{Issues: []string{"Strategic Claude Basic directory not found"}}
+	broken := &models.StatusInfo{IsInstalled: true, Issues: []string{"Broken symlink"}}
+	drifted := &models.StatusInfo{IsInstalled: true, Drift: []string{"Template main is installed at commit 1234"}}
+	devMode := &models.StatusInfo{IsInstalled: true}
+	devMode.AddFinding(models.FindingDevMode, models.SeverityInfo, "Framework is linked from a template checkout")
+	nested := &models.StatusInfo{IsInstalled: true}
+	nested.AddFinding(models.FindingParentInstallation, models.SeverityWarning, "Parent directory also contains an installation")
+
+	tests := []struct {
+		name       string
+		statusInfo *models.StatusInfo
+		failOn     []string
+		wantCode   int // 0 when the status passes
+	}{
+		{name: "healthy", statusInfo: healthy, failOn: statusFailOnConditions},
+		{name: "not installed by default", statusInfo: missing, wantCode: config.ExitNotInstalled},
+		{name: "issues by default", statusInfo: broken, wantCode: config.ExitValidationError},
+		{name: "drift ignored by default", statusInfo: drifted},
+		{name: "drift selected", statusInfo: drifted, failOn: []string{failOnDrift}, wantCode: config.ExitInstallationError},
+		{name: "issues not selected", statusInfo: broken, failOn: []string{failOnDrift}},
+		{name: "not installed reported as issues", statusInfo: missing, failOn: []string{failOnIssues}, wantCode: config.ExitValidationError},
+		{name: "info ignored by default", statusInfo: devMode},
+		{name: "info selected", statusInfo: devMode, failOn: []string{"info"}, wantCode: config.ExitValidationError},
+		{name: "info below warning", statusInfo: devMode, failOn: []string{"warning"}},
+		{name: "warning at least warning", statusInfo: nested, failOn: []string{"warning"}, wantCode: config.ExitValidationError},
+		{name: "warning below error", statusInfo: nested, failOn: []string{"error"}},
+		{name: "not installed first", statusInfo: missing, failOn: statusFailOnConditions, wantCode: config.ExitNotInstalled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failOn, err := parseFailOn(tt.failOn)
+			if err != nil {
+				t.Fatalf("parseFailOn() error = %v", err)
+			}
+
+			err = statusFailure(tt.statusInfo, failOn)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("statusFailure() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("statusFailure() = nil, want an error")
+			}
+			if code := exitCode(err); code != tt.wantCode {
+				t.Errorf("exitCode() = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/usercontent"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncRemote    string
+	syncBranch    string
+	syncAuthToken string
+)
+
+var syncCmd = &cobra.Command{
+	Use:     "sync",
+	Short:   "Sync your plans, research and other user content with a personal git remote",
+	GroupID: groupMaintenance,
+	Long: `Keep the user directories of an installation (archives, decisions, issues, plan,
+product, research, summary, tools, validation) in a git repository of your own, so
+your work follows you across checkouts and machines without being committed to
+the project repository.
+
+The sync repository lives in .strategic-claude-basic/.sync and only tracks the
+user directories. Pass --remote on the first sync; the remote and branch are
+remembered afterwards.
+
+Authentication:
+- SSH: uses your ssh-agent or default key files
+- HTTPS: pass --auth-token or set SCB_GIT_TOKEN (git credential helpers are used otherwise)
+
+Examples:
+  strategic-claude-basic-cli sync push --remote=git@github.com:me/notes.git  # First push
+  strategic-claude-basic-cli sync push                                       # Push later changes
+  strategic-claude-basic-cli sync pull --remote=git@github.com:me/notes.git  # Set up a fresh checkout
+  strategic-claude-basic-cli sync pull ./other-project                       # Pull into another project`,
+}
+
+var syncPushCmd = &cobra.Command{
+	Use:   "push [directory]",
+	Short: "Commit and push user content to the sync remote",
+	Long: `Commit every change in the user directories and push it to the sync remote.
+
+The push is refused when the remote branch has changes that are not present
+locally; run 'sync pull' first to merge them.
+
+Examples:
+  strategic-claude-basic-cli sync push                                        # Push the current project
+  strategic-claude-basic-cli sync push --remote=https://github.com/me/notes.git --branch=work`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runSync(target, true)
+	},
+}
+
+var syncPullCmd = &cobra.Command{
+	Use:   "pull [directory]",
+	Short: "Fetch user content from the sync remote",
+	Long: `Fetch the sync branch and merge it into the user directories.
+
+Local changes are committed before merging so they are never lost. When the same
+file was changed on both sides the merge is aborted, nothing is modified, and the
+git command to resolve the conflict by hand is shown. A branch holding files
+outside the user directories, such as core hooks, is refused before anything
+is checked out.
+
+Examples:
+  strategic-claude-basic-cli sync pull                                        # Pull into the current project
+  strategic-claude-basic-cli sync pull --remote=git@github.com:me/notes.git   # First pull on a new machine`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runSync(target, false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncPushCmd)
+	syncCmd.AddCommand(syncPullCmd)
+
+	syncCmd.PersistentFlags().StringVar(&syncRemote, "remote", "", "git remote URL to sync with (remembered after the first sync)")
+	syncCmd.PersistentFlags().StringVar(&syncBranch, "branch", "", "branch to sync on (default: main)")
+	syncCmd.PersistentFlags().StringVar(&syncAuthToken, "auth-token", "", "token for private HTTPS sync remotes (default: $SCB_GIT_TOKEN)")
+}
+
+// runSync executes the sync push and sync pull command logic
+func runSync(target string, push bool) error {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	service := usercontent.New()
+	service.SetAuthToken(syncAuthToken)
+	opts := usercontent.SyncOptions{Remote: syncRemote, Branch: syncBranch}
+
+	action := "pull"
+	sync := service.SyncPull
+	if push {
+		action = "push"
+		sync = service.SyncPush
+	}
+
+	result, err := sync(absTarget, opts)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("sync %s failed: %w", action, err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Remote: %s (branch %s)\n", result.Remote, result.Branch)
+
+	switch {
+	case result.Commit == "":
+		utils.DisplayInfo("No user content to sync yet")
+	case result.UpToDate:
+		utils.DisplaySuccess(fmt.Sprintf("User content is up to date with %s (%s)", result.Remote, result.Branch))
+	case push:
+		utils.DisplaySuccess(fmt.Sprintf("Pushed user content to %s (%s) at %s", result.Remote, result.Branch, shortCommit(result.Commit)))
+	default:
+		utils.DisplaySuccess(fmt.Sprintf("Pulled user content from %s (%s) at %s", result.Remote, result.Branch, shortCommit(result.Commit)))
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/scaffold"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/templatelint"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateLintBranch    string
+	templateLintCommit    string
+	templateLintAuthToken string
+	templateNewName       string
+)
+
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Short:   "Tools for template authors",
+	GroupID: groupAuthoring,
+	Long: `Tools for authors of template repositories.
+
+Examples:
+  strategic-claude-basic-cli template new ./my-template       # Create a template skeleton
+  strategic-claude-basic-cli template lint ./my-template      # Check a local checkout
+  strategic-claude-basic-cli template lint https://github.com/me/my-template.git --branch dev`,
+}
+
+var templateLintCmd = &cobra.Command{
+	Use:   "lint <path|repo>",
+	Short: "Check a template repository for problems before pinning it",
+	Long: `Check the structure of a template repository, either a local checkout or a
+git URL, and report what to fix before a commit is pinned in the registry.
+
+The following is checked:
+- .strategic-claude-basic/ with the core, guides and templates directories
+- The directories the .claude and .codex symlinks point to after install
+- Symlinks within the template are relative and stay inside it
+- The settings template parses and its strategic hooks exist in core/hooks
+- The gitignore templates used by --gitignore-mode exist
+- Install scripts and hook scripts are executable
+
+Errors make the command fail; warnings point out things that install but
+probably not as intended.
+
+Examples:
+  strategic-claude-basic-cli template lint .                                   # Local checkout
+  strategic-claude-basic-cli template lint https://github.com/me/template.git  # Default branch
+  strategic-claude-basic-cli template lint git@github.com:me/template.git --commit=abc1234`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTemplateLint(args[0])
+	},
+}
+
+var templateNewCmd = &cobra.Command{
+	Use:   "new <dir>",
+	Short: "Create a new template repository",
+	Long: `Create the skeleton of a template repository in a new or empty directory, as a
+starting point for an organization-specific template.
+
+The skeleton contains the core, guides and templates directories with an example
+agent, command and hook, a settings template registering the hook, the gitignore
+templates, the user directories, and pre-install and post-install script stubs.
+It passes 'template lint' as generated.
+
+Examples:
+  strategic-claude-basic-cli template new ./acme-template
+  strategic-claude-basic-cli template new ./acme-template --name "ACME Engineering"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTemplateNew(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateLintCmd)
+	templateCmd.AddCommand(templateNewCmd)
+
+	templateLintCmd.Flags().StringVar(&templateLintBranch, "branch", "", "branch to lint when linting a repository (default: the default branch)")
+	templateLintCmd.Flags().StringVar(&templateLintCommit, "commit", "", "commit to lint when linting a repository")
+	templateLintCmd.Flags().StringVar(&templateLintAuthToken, "auth-token", "", "HTTPS token for private repositories (default: $SCB_GIT_TOKEN)")
+
+	templateNewCmd.Flags().StringVar(&templateNewName, "name", "", "template name used in the README (default: the directory name)")
+}
+
+// runTemplateLint executes the template lint command logic
+func runTemplateLint(source string) error {
+	lintService := templatelint.New()
+
+	var report *templatelint.Report
+	var err error
+	if templatelint.IsRepositoryURL(source) {
+		utils.VerbosePrintf(verbose, "Cloning %s\n", source)
+		lintService.SetAuthToken(templateLintAuthToken)
+		report, err = lintService.LintRepository(source, templateLintBranch, templateLintCommit)
+	} else {
+		var absSource string
+		absSource, err = filepath.Abs(source)
+		if err != nil {
+			utils.DisplayError(fmt.Errorf("failed to resolve template directory: %w", err))
+			return err
+		}
+		report, err = lintService.Lint(absSource)
+	}
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to lint template: %w", err))
+		return err
+	}
+
+	for _, issue := range report.Issues {
+		if issue.Severity == templatelint.SeverityError {
+			fmt.Printf(utils.Symbols("❌ %s: %s\n"), issue.Path, issue.Message)
+		} else {
+			fmt.Printf(utils.Symbols("⚠️  %s: %s\n"), issue.Path, issue.Message)
+		}
+		if issue.Fix != "" {
+			fmt.Printf(utils.Symbols("   → %s\n"), issue.Fix)
+		}
+	}
+	if len(report.Issues) > 0 {
+		fmt.Println()
+	}
+
+	if errorCount := report.Errors(); errorCount > 0 {
+		err := models.NewAppError(
+			models.ErrorCodeValidationFailed,
+			fmt.Sprintf("Template %s has %d error(s) and %d warning(s)", report.Source, errorCount, report.Warnings()),
+			nil,
+		)
+		utils.DisplayError(err)
+		return err
+	}
+
+	if warnings := report.Warnings(); warnings > 0 {
+		utils.DisplayWarning(fmt.Sprintf("Template %s has %d warning(s)", report.Source, warnings))
+		return nil
+	}
+	utils.DisplaySuccess(fmt.Sprintf("Template %s has no issues", report.Source))
+	return nil
+}
+
+// runTemplateNew executes the template new command logic
+func runTemplateNew(dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve template directory: %w", err))
+		return err
+	}
+
+	name := templateNewName
+	if name == "" {
+		name = filepath.Base(absDir)
+	}
+
+	files, err := scaffold.New().Create(absDir, name)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to create template: %w", err))
+		return err
+	}
+
+	for _, file := range files {
+		utils.VerbosePrintf(verbose, "  %s\n", file)
+	}
+	utils.DisplaySuccess(fmt.Sprintf("Created template %s in %s (%d files)", name, absDir, len(files)))
+	utils.DisplayInfo("Replace the examples, commit, and check the result with 'template lint'")
+	return nil
+}
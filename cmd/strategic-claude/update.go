@@ -0,0 +1,407 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/registry"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/update"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats supported by update --check
+const (
+	updateOutputText = "text"
+	updateOutputJSON = "json"
+)
+
+var (
+	updateCheckOnly bool
+	updateOutput    string
+	updateNoBackup  bool
+	updateTimeout   time.Duration
+	updateAll       bool
+	updateRoot      string
+	updateJobs      int
+)
+
+// Results of a project in update --all
+const (
+	updateResultUpdated   = "updated"
+	updateResultAvailable = "update available"
+	updateResultUpToDate  = "up to date"
+	updateResultSkipped   = "skipped"
+	updateResultFailed    = "failed"
+)
+
+// updateOutcome is the result of one project in update --all
+type updateOutcome struct {
+	project  string
+	template string
+	result   string
+	detail   string
+}
+
+var updateCmd = &cobra.Command{
+	Use:     "update [directory]",
+	Short:   "Update the installed template to the commit this CLI pins",
+	GroupID: groupInstall,
+	Long: `Update the core files of an installation to the template commit this CLI
+installs for it, like 'init --force-core' with the installed template: user
+content and settings are kept, and the framework directory is backed up first.
+Installations from a vendored copy are updated to the commit the copy pins.
+Layouts left by older versions are migrated first, as with 'doctor --migrate'.
+
+--check only compares the installation with that commit and changes nothing.
+It is meant for cron jobs, CI and git hooks, and exits with:
+  0   the installation is up to date, or follows a dev mode checkout
+  ` + fmt.Sprint(config.ExitUpdateAvailable) + `  an update is available; the target commit is printed
+  ` + fmt.Sprint(config.ExitNotInstalled) + `   the framework is not installed
+
+--all updates every project in the registry of installations instead (see
+'list-managed'), or every installation found under --root, each with its own
+configuration and pinned commit, and prints a summary of the projects
+updated, skipped and failed. Hidden directories, node_modules and vendor are
+not searched. --jobs projects are handled at once; their progress is only
+shown with --jobs 1.
+
+Examples:
+  strategic-claude-basic-cli update                        # Update the current directory
+  strategic-claude-basic-cli update ./my-project           # Update a specific directory
+  strategic-claude-basic-cli update --check                # Exit 10 when an update is available
+  strategic-claude-basic-cli update --check --output json  # Machine-readable result
+  strategic-claude-basic-cli update --all                  # Update every project in the registry
+  strategic-claude-basic-cli update --all --root ~/code    # Update every project under ~/code`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateAll {
+			if len(args) > 0 {
+				err := models.NewValidationError("directory", args[0], "cannot be combined with --all; use --root")
+				utils.DisplayError(err)
+				return err
+			}
+			return runUpdateAll(cmd)
+		}
+		for _, name := range []string{"root", "jobs"} {
+			if cmd.Flags().Changed(name) {
+				err := models.NewValidationError(name, cmd.Flags().Lookup(name).Value.String(), "requires --all")
+				utils.DisplayError(err)
+				return err
+			}
+		}
+
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+			if err := loadConfig(cmd, target); err != nil {
+				return err
+			}
+		}
+		return runUpdate(cmd, target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().BoolVar(&updateCheckOnly, "check", false, "only report whether an update is available (exit "+fmt.Sprint(config.ExitUpdateAvailable)+" when it is)")
+	updateCmd.Flags().StringVar(&updateOutput, "output", updateOutputText, "output format of --check: text or json")
+	updateCmd.Flags().BoolVar(&updateNoBackup, "no-backup", false, "skip backing up the framework directory")
+	updateCmd.Flags().DurationVar(&updateTimeout, "timeout", 0, "stop and roll back the update when it takes longer than this, e.g. 5m (default: no limit)")
+	updateCmd.Flags().BoolVar(&updateAll, "all", false, "update every project in the registry, or every installation found under --root")
+	updateCmd.Flags().StringVar(&updateRoot, "root", "", "directory searched for installations by --all instead of using the registry")
+	updateCmd.Flags().IntVar(&updateJobs, "jobs", config.DefaultWorkspaceJobs, "projects --all handles at once")
+
+	if err := updateCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{updateOutputText, updateOutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --output flag: %v\n", err)
+	}
+	if err := updateCmd.RegisterFlagCompletionFunc("root", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{}, cobra.ShellCompDirectiveFilterDirs
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --root flag: %v\n", err)
+	}
+
+	// Custom completion for directory argument: managed projects, else directories
+	updateCmd.ValidArgsFunction = completeProjectDir
+
+	addAlias(updateCmd, "upgrade", "")
+}
+
+// validateUpdateOutput checks the --output flag of the update command
+func validateUpdateOutput() error {
+	formats := []string{updateOutputText, updateOutputJSON}
+	if !slices.Contains(formats, updateOutput) {
+		return models.NewValidationError("output", updateOutput, fmt.Sprintf("must be one of: %s", strings.Join(formats, ", ")))
+	}
+	if updateOutput == updateOutputJSON && !updateCheckOnly {
+		return models.NewValidationError("output", updateOutput, "json output is only available with --check")
+	}
+	return nil
+}
+
+// runUpdate executes the update command logic
+func runUpdate(cmd *cobra.Command, target string) error {
+	if err := validateUpdateOutput(); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	updateService := update.New()
+	updateService.SetReporter(newReporter())
+	updateStatus, err := updateService.Check(absTarget)
+	if err != nil {
+		utils.DisplayError(err)
+		if models.IsErrorCode(err, models.ErrorCodeNotInstalled) {
+			cmd.SilenceUsage = true
+			return withExitCode(err, config.ExitNotInstalled)
+		}
+		return err
+	}
+
+	if updateCheckOnly {
+		return reportUpdateCheck(cmd, updateStatus)
+	}
+
+	if updateStatus.Unsupported != "" {
+		utils.DisplayInfo(fmt.Sprintf("Nothing to update: %s", updateStatus.Unsupported))
+		return nil
+	}
+	if !updateStatus.UpdateAvailable {
+		utils.DisplaySuccess(fmt.Sprintf("Template %s is up to date (%s)", updateStatus.Template, shortHash(updateStatus.InstalledCommit)))
+		return nil
+	}
+
+	utils.DisplayInfo(fmt.Sprintf("Updating template %s from %s to %s in %s...",
+		updateStatus.Template, shortHash(updateStatus.InstalledCommit), shortHash(updateStatus.TargetCommit), absTarget))
+	ctx, cancel := operationContext(updateTimeout)
+	defer cancel()
+	updateService.SetContext(ctx)
+	if err := updateService.Apply(updateStatus, models.InstallConfig{NoBackup: updateNoBackup, Verbose: verbose}); err != nil {
+		utils.DisplayError(fmt.Errorf("update failed: %w", err))
+		return err
+	}
+
+	recordManaged(absTarget, updateStatus.Template)
+	utils.DisplaySuccess(fmt.Sprintf("Template %s updated to %s", updateStatus.Template, shortHash(updateStatus.TargetCommit)))
+	return nil
+}
+
+// reportUpdateCheck prints the result of update --check and exits with
+// ExitUpdateAvailable when there is an update
+func reportUpdateCheck(cmd *cobra.Command, updateStatus *models.UpdateStatus) error {
+	if updateOutput == updateOutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(updateStatus); err != nil {
+			return err
+		}
+	} else {
+		switch {
+		case updateStatus.Unsupported != "":
+			fmt.Printf("Nothing to check: %s\n", updateStatus.Unsupported)
+		case updateStatus.UpdateAvailable:
+			fmt.Printf(utils.Symbols("Update available for template %s: %s → %s\n"),
+				updateStatus.Template, shortHash(updateStatus.InstalledCommit), shortHash(updateStatus.TargetCommit))
+			fmt.Printf("Target commit: %s\n", updateStatus.TargetCommit)
+		default:
+			fmt.Printf("Template %s is up to date at %s\n", updateStatus.Template, updateStatus.InstalledCommit)
+		}
+	}
+
+	if updateStatus.UpdateAvailable {
+		// The exit code is the answer, not a failure to report
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return silentExit(config.ExitUpdateAvailable)
+	}
+	return nil
+}
+
+// runUpdateAll checks or updates every installation under --root, or in the
+// registry, and prints a summary of the projects
+func runUpdateAll(cmd *cobra.Command) error {
+	if err := validateUpdateOutput(); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+	if err := validateJobs(updateJobs); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	projects, base, err := workspaceProjects(updateRoot)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+	if len(projects) == 0 {
+		if updateOutput == updateOutputJSON {
+			fmt.Println("[]")
+		} else {
+			utils.DisplayInfo(noWorkspaceProjects(base))
+		}
+		return nil
+	}
+
+	outcomes := make([]updateOutcome, len(projects))
+	projectStatuses := make([]*models.UpdateStatus, len(projects))
+	failures := runWorkspace(cmd, projects, updateJobs, func(i int, project string) error {
+		var err error
+		projectStatuses[i], outcomes[i], err = updateProject(base, project)
+		return err
+	}, func(i int, err error) {
+		outcomes[i] = failedOutcome(base, projects[i], err)
+	})
+
+	if updateOutput == updateOutputJSON {
+		statuses := make([]*models.UpdateStatus, 0, len(projectStatuses))
+		for _, updateStatus := range projectStatuses {
+			if updateStatus != nil {
+				statuses = append(statuses, updateStatus)
+			}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(statuses); err != nil {
+			return err
+		}
+	} else if err := printUpdateSummary(outcomes); err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, outcome := range outcomes {
+		counts[outcome.result]++
+	}
+	cmd.SilenceUsage = true
+	if failed := counts[updateResultFailed]; failed > 0 {
+		err := models.NewAppError(
+			models.ErrorCodeInstallationFailed,
+			fmt.Sprintf("%d of %d projects failed to update", failed, len(outcomes)),
+			failures,
+		)
+		utils.DisplayError(err)
+		return err
+	}
+	if counts[updateResultAvailable] > 0 {
+		cmd.SilenceErrors = true
+		return silentExit(config.ExitUpdateAvailable)
+	}
+	return nil
+}
+
+// failedOutcome returns the outcome of a project of update --all that failed with err
+func failedOutcome(base, project string, err error) updateOutcome {
+	return updateOutcome{project: relativeProject(base, project), result: updateResultFailed, detail: err.Error()}
+}
+
+// updateProject checks one installation of update --all, with the current
+// configuration, and updates it unless --check is set. The status is nil when
+// the check failed; the error is that of a failed check or update. Projects
+// of the registry that are gone or no longer installed are skipped.
+func updateProject(base, project string) (*models.UpdateStatus, updateOutcome, error) {
+	outcome := updateOutcome{project: relativeProject(base, project)}
+	if !registry.Exists(registry.Entry{Path: project}) {
+		outcome.result = updateResultSkipped
+		outcome.detail = "directory no longer exists; see list-managed --prune"
+		return nil, outcome, nil
+	}
+
+	updateService := update.New()
+	if updateJobs > 1 {
+		// Progress of projects handled at once would interleave
+		updateService.SetReporter(reporter.NewSilent())
+	} else {
+		updateService.SetReporter(newReporter())
+	}
+	updateStatus, err := updateService.Check(project)
+	if models.IsErrorCode(err, models.ErrorCodeNotInstalled) {
+		outcome.result = updateResultSkipped
+		outcome.detail = "not installed"
+		return nil, outcome, nil
+	}
+	if err != nil {
+		return nil, failedOutcome(base, project, err), err
+	}
+	outcome.template = updateStatus.Template
+
+	switch {
+	case updateStatus.Unsupported != "":
+		outcome.result = updateResultSkipped
+		outcome.detail = updateStatus.Unsupported
+		return updateStatus, outcome, nil
+	case !updateStatus.UpdateAvailable:
+		outcome.result = updateResultUpToDate
+		outcome.detail = shortHash(updateStatus.InstalledCommit)
+		return updateStatus, outcome, nil
+	}
+
+	outcome.detail = fmt.Sprintf(utils.Symbols("%s → %s"), shortHash(updateStatus.InstalledCommit), shortHash(updateStatus.TargetCommit))
+	if updateCheckOnly {
+		outcome.result = updateResultAvailable
+		return updateStatus, outcome, nil
+	}
+
+	utils.DisplayInfo(fmt.Sprintf("Updating template %s in %s (%s)...", updateStatus.Template, project, outcome.detail))
+	ctx, cancel := operationContext(updateTimeout)
+	defer cancel()
+	updateService.SetContext(ctx)
+	if err := updateService.Apply(updateStatus, models.InstallConfig{NoBackup: updateNoBackup, Verbose: verbose}); err != nil {
+		outcome.result = updateResultFailed
+		outcome.detail = err.Error()
+		return updateStatus, outcome, err
+	}
+	recordManaged(project, updateStatus.Template)
+	outcome.result = updateResultUpdated
+	return updateStatus, outcome, nil
+}
+
+// printUpdateSummary prints the table of projects of update --all
+func printUpdateSummary(outcomes []updateOutcome) error {
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, i18n.T("PROJECT\tTEMPLATE\tRESULT\tDETAIL"))
+	counts := make(map[string]int)
+	for _, outcome := range outcomes {
+		template := outcome.template
+		if template == "" {
+			template = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", outcome.project, template, i18n.T(outcome.result), outcome.detail)
+		counts[outcome.result]++
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	var parts []string
+	for _, result := range []string{updateResultUpdated, updateResultAvailable, updateResultUpToDate, updateResultSkipped, updateResultFailed} {
+		if counts[result] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[result], i18n.T(result)))
+		}
+	}
+	fmt.Printf("\n%s\n", i18n.T("%d project(s): %s", len(outcomes), strings.Join(parts, ", ")))
+	return nil
+}
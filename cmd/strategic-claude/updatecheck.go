@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/updatecheck"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var noUpdateCheck bool
+
+// updateCheck is the check started before the command ran, nil when it is skipped
+var updateCheck *runningUpdateCheck
+
+type runningUpdateCheck struct {
+	service *updatecheck.Service
+	done    chan struct{}
+	cancel  context.CancelFunc
+}
+
+// startUpdateCheck refreshes the release metadata in the background while cmd runs,
+// unless update checks are disabled or cmd is not run interactively
+func startUpdateCheck(cmd *cobra.Command) {
+	if !updateCheckEnabled(cmd) {
+		return
+	}
+	cacheRoot, err := cache.Dir()
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.UpdateCheckTimeout)
+	check := &runningUpdateCheck{
+		service: updatecheck.New(filepath.Join(cacheRoot, config.UpdateCheckFile)),
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+	go func() {
+		defer close(check.done)
+		// The check is best effort; failures are retried after the check interval
+		_ = check.service.Refresh(ctx)
+	}()
+	updateCheck = check
+}
+
+// printUpdateHint prints a one-line hint after a successful command when the newest
+// release pins a newer commit of the template installed in the target directory
+func printUpdateHint() {
+	if updateCheck == nil {
+		return
+	}
+	// Commands that finish quickly do not wait for a slow network
+	select {
+	case <-updateCheck.done:
+	case <-time.After(config.UpdateCheckWait):
+	}
+	updateCheck.cancel()
+
+	installed, err := status.NewService().LoadTemplateInfo(targetDir)
+	if err != nil || installed == nil {
+		return
+	}
+	pinned, err := templates.GetTemplate(installed.Template.ID)
+	if err != nil {
+		return
+	}
+	if hint := updatecheck.Hint(updateCheck.service.Latest(), installed, pinned, version); hint != "" {
+		fmt.Fprintf(os.Stderr, utils.Symbols("\n💡 %s\n"), hint)
+	}
+}
+
+// updateCheckEnabled reports whether cmd checks for updates: not when disabled by
+// flag or environment, in CI, with machine-readable output, or without a terminal
+func updateCheckEnabled(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case "version", "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
+	}
+	if cmd.Parent() != nil && cmd.Parent().Name() == "completion" {
+		return false
+	}
+	if noUpdateCheck || utils.EnvBool(config.NoUpdateCheckEnvVar) || os.Getenv("CI") != "" {
+		return false
+	}
+	if logFormat != reporter.FormatText {
+		return false
+	}
+	if quiet := cmd.Flags().Lookup("quiet"); quiet != nil && quiet.Value.String() == "true" {
+		return false
+	}
+
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
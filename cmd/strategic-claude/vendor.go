@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	vendorTemplateID string
+	vendorAuthToken  string
+)
+
+var vendorCmd = &cobra.Command{
+	Use:     "vendor",
+	Short:   "Store the template source inside the project",
+	GroupID: groupInstall,
+	Long: `Store the template repository content inside the project so that installs and
+updates never need network access.
+
+The template is fetched once at its pinned commit and kept in
+.strategic-claude-basic/.vendor/. While a vendored copy of the requested template
+exists, 'init' (including --force and --force-core) reads from it instead of
+cloning. Use 'vendor refresh' to update the vendored copy to the template's
+currently pinned commit.
+
+Template selection:
+- --template selects the template explicitly
+- Otherwise the installed template is used, falling back to the default template
+
+Examples:
+  strategic-claude-basic-cli vendor                  # Vendor the installed template
+  strategic-claude-basic-cli vendor --template=ccr   # Vendor the CCR template
+  strategic-claude-basic-cli vendor refresh          # Update the vendored copy`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVendor(false)
+	},
+}
+
+var vendorRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Update the vendored template copy",
+	Long: `Fetch the vendored template again at its currently pinned commit and replace
+the vendored copy. The previous copy is kept until the new one is complete.
+
+Examples:
+  strategic-claude-basic-cli vendor refresh                 # Refresh the vendored template
+  strategic-claude-basic-cli vendor refresh --template=main # Switch the vendored template`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVendor(true)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vendorCmd)
+	vendorCmd.AddCommand(vendorRefreshCmd)
+
+	vendorCmd.PersistentFlags().StringVar(&vendorTemplateID, "template", "", "template ID to vendor (default: installed template)")
+	vendorCmd.PersistentFlags().StringVar(&vendorAuthToken, "auth-token", "", "token for private HTTPS template repositories (default: $SCB_GIT_TOKEN)")
+
+	if err := vendorCmd.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return templates.GetTemplateIDs(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --template flag: %v\n", err)
+	}
+}
+
+// runVendor executes the vendor and vendor refresh command logic
+func runVendor(refresh bool) error {
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	utils.VerbosePrintf(verbose, "Target directory: %s\n", absTarget)
+
+	bundleService := bundle.New()
+	bundleService.SetAuthToken(vendorAuthToken)
+	vendorDir := bundle.VendorPath(absTarget)
+
+	if refresh && !bundle.IsVendored(absTarget) {
+		err := fmt.Errorf("no vendored template found in %s; run 'vendor' first", vendorDir)
+		utils.DisplayError(err)
+		return err
+	}
+
+	if !refresh && bundle.IsVendored(absTarget) && vendorTemplateID == "" {
+		manifest, err := bundleService.ReadVendorManifest(vendorDir)
+		if err != nil {
+			utils.DisplayError(err)
+			return err
+		}
+		utils.DisplayInfo(fmt.Sprintf("Template %s is already vendored at commit %s", manifest.Template.ID, manifest.Template.Commit))
+		utils.DisplayInfo("Run 'vendor refresh' to update the vendored copy")
+		return nil
+	}
+
+	templateID, err := resolveVendorTemplateID(absTarget, bundleService)
+	if err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	template, err := templates.GetTemplate(templateID)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("invalid template ID '%s': %w", templateID, err))
+		return err
+	}
+
+	if err := validatePrerequisites(); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+
+	utils.DisplayInfo(fmt.Sprintf("Vendoring template %s at commit %s...", template.ID, template.Commit))
+
+	manifest, err := bundleService.Vendor(template, vendorDir)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("vendoring failed: %w", err))
+		return err
+	}
+
+	utils.DisplaySuccess(fmt.Sprintf("Template %s vendored in %s", manifest.Template.ID, vendorDir))
+	utils.VerbosePrintf(verbose, "Tree hash: %s\n", manifest.TreeHash)
+
+	return nil
+}
+
+// resolveVendorTemplateID picks the template to vendor: the --template flag, the
+// currently vendored template, the installed template, or the default template
+func resolveVendorTemplateID(absTarget string, bundleService *bundle.Service) (string, error) {
+	if vendorTemplateID != "" {
+		return vendorTemplateID, nil
+	}
+
+	if bundle.IsVendored(absTarget) {
+		manifest, err := bundleService.ReadVendorManifest(bundle.VendorPath(absTarget))
+		if err != nil {
+			return "", err
+		}
+		return manifest.Template.ID, nil
+	}
+
+	if _, err := os.Stat(absTarget); err == nil {
+		statusInfo, err := status.NewService().CheckInstallation(absTarget)
+		if err == nil && statusInfo.InstalledTemplate != nil {
+			return statusInfo.InstalledTemplate.Template.ID, nil
+		}
+	}
+
+	return templates.DefaultTemplateID, nil
+}
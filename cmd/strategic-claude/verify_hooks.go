@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/hookcheck"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats supported by verify-hooks
+const (
+	verifyHooksOutputText = "text"
+	verifyHooksOutputJSON = "json"
+)
+
+var verifyHooksOutput string
+
+var verifyHooksCmd = &cobra.Command{
+	Use:     "verify-hooks [directory]",
+	Short:   "Run the strategic hooks with a test payload",
+	GroupID: groupInspect,
+	Long: `Run every strategic hook registered in .claude/settings.json the way Claude Code
+would: through the shell in the project directory, with a synthetic payload for
+its event on stdin. A hook that does not exit with code 0 fails the check, which
+catches missing Python dependencies and syntax errors right after installing
+rather than in the middle of a coding session.
+
+Tool hooks get the first tool their matcher names with harmless input, such as
+'ls' for Bash. $` + config.HookCheckEnvVar + ` is set to 1 while a hook runs, so hooks can leave out
+side effects such as desktop notifications. Each hook gets ` + config.HookCheckTimeout.String() + `.
+
+Examples:
+  strategic-claude-basic-cli verify-hooks                   # Current directory
+  strategic-claude-basic-cli verify-hooks ./my-project      # Specific directory
+  strategic-claude-basic-cli verify-hooks --output json     # Machine-readable results`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := targetDir
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runVerifyHooks(cmd, target)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyHooksCmd)
+
+	verifyHooksCmd.Flags().StringVar(&verifyHooksOutput, "output", verifyHooksOutputText, "output format: text or json")
+
+	if err := verifyHooksCmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{verifyHooksOutputText, verifyHooksOutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		// This should not happen in normal operation, but we handle it for completeness
+		fmt.Fprintf(os.Stderr, "Warning: failed to register completion for --output flag: %v\n", err)
+	}
+}
+
+// runVerifyHooks executes the verify-hooks command logic
+func runVerifyHooks(cmd *cobra.Command, target string) error {
+	formats := []string{verifyHooksOutputText, verifyHooksOutputJSON}
+	if !slices.Contains(formats, verifyHooksOutput) {
+		err := models.NewValidationError("output", verifyHooksOutput, fmt.Sprintf("must be one of: %s", strings.Join(formats, ", ")))
+		utils.DisplayError(err)
+		return err
+	}
+
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to resolve target directory: %w", err))
+		return err
+	}
+
+	statusInfo, err := status.NewService().CheckInstallation(absTarget)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to check installation status: %w", err))
+		return err
+	}
+	if !statusInfo.IsInstalled {
+		err := models.NewAppError(models.ErrorCodeNotInstalled, "Strategic Claude Basic is not installed in this directory", nil)
+		utils.DisplayError(err)
+		return err
+	}
+
+	results, err := hookcheck.New().Check(absTarget)
+	if err != nil {
+		utils.DisplayError(fmt.Errorf("failed to run hooks: %w", err))
+		return err
+	}
+	// Failing hooks are the report; usage would only obscure it
+	cmd.SilenceUsage = true
+
+	if verifyHooksOutput == verifyHooksOutputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+		return hookcheck.Err(results)
+	}
+
+	if len(results) == 0 {
+		utils.DisplayInfo("No strategic hooks are registered in .claude/settings.json")
+		return nil
+	}
+	displayHookResults(results)
+
+	if err := hookcheck.Err(results); err != nil {
+		utils.DisplayError(err)
+		return err
+	}
+	utils.DisplaySuccess(fmt.Sprintf("All %d hooks ran successfully", len(results)))
+	return nil
+}
+
+// displayHookResults prints a line per hook, with the output of the failed ones
+func displayHookResults(results []models.HookCheckResult) {
+	for _, result := range results {
+		event := result.Event
+		if result.Matcher != "" {
+			event = fmt.Sprintf("%s (%s)", result.Event, result.Matcher)
+		}
+		if result.Succeeded() {
+			fmt.Printf(utils.Symbols("  ✓ %-40s %s\n"), event, result.Script)
+			utils.VerbosePrintf(verbose, "      %s in %s\n", result.Command, result.Duration.Round(time.Millisecond))
+			continue
+		}
+
+		problem := fmt.Sprintf("exit code %d", result.ExitCode)
+		if result.Error != "" {
+			problem = result.Error
+		}
+		fmt.Printf(utils.Symbols("  ✗ %-40s %s: %s\n"), event, result.Script, problem)
+		fmt.Printf("      %s\n", result.Command)
+		for _, line := range strings.Split(strings.TrimSpace(result.Output), "\n") {
+			if line != "" {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+	fmt.Println()
+}
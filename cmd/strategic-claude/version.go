@@ -1,9 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"runtime"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/updatecheck"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 
 	"github.com/spf13/cobra"
@@ -15,15 +19,30 @@ var (
 	date    = "unknown"
 )
 
+var versionJSON bool
+
 func getVersion() string {
 	return fmt.Sprintf("%s (%s)", version, commit)
 }
 
 var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print the version information",
-	Long:  `Print the version information including version number, commit hash, build date, and Go version.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:     "version",
+	Short:   "Print the version information",
+	GroupID: groupInspect,
+	Long: `Print the version information including version number, commit hash, build date, and Go version.
+
+With --json, the version and the template commits this version pins are printed
+as the release metadata published with every release, which the daily update
+check of older versions reads.
+
+Examples:
+  strategic-claude-basic-cli version
+  strategic-claude-basic-cli version --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if versionJSON {
+			return printReleaseMetadata()
+		}
+
 		fmt.Printf("strategic-claude-basic-cli version %s\n", version)
 		fmt.Printf("Git commit: %s\n", commit)
 		fmt.Printf("Build date: %s\n", date)
@@ -39,9 +58,27 @@ var versionCmd = &cobra.Command{
 				template.Commit[:7],
 				template.Branch)
 		}
+		return nil
 	},
 }
 
+// printReleaseMetadata writes the release metadata of this version to stdout
+func printReleaseMetadata() error {
+	release := updatecheck.Release{
+		FormatVersion: config.ReleaseFormatVersion,
+		Version:       version,
+		Templates:     make(map[string]string),
+	}
+	for _, template := range templates.ListTemplates() {
+		release.Templates[template.ID] = template.Commit
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(release)
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "print the release metadata: version and pinned template commits")
 }
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/registry"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/workspace"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// workspaceProjects returns the projects a command with --all works on: the
+// installations found under root, or the projects in the registry when root is
+// empty. base is what projects are shown relative to, empty for absolute paths.
+func workspaceProjects(root string) (projects []string, base string, err error) {
+	if root == "" {
+		entries, err := registry.New().List()
+		if err != nil {
+			return nil, "", err
+		}
+		for _, entry := range entries {
+			projects = append(projects, entry.Path)
+		}
+		return projects, "", nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, "", models.NewFileSystemError(models.ErrorCodeInvalidPath, root, err)
+	}
+	projects, err = workspace.Discover(absRoot)
+	return projects, absRoot, err
+}
+
+// noWorkspaceProjects returns what to say when --all finds no projects
+func noWorkspaceProjects(base string) string {
+	if base == "" {
+		return "No projects in the registry; installations are recorded by init and update, or pass --root to search a directory"
+	}
+	return fmt.Sprintf("No installations found under %s", base)
+}
+
+// relativeProject returns how a command with --all shows a project
+func relativeProject(base, project string) string {
+	if base == "" {
+		return project
+	}
+	if rel, err := filepath.Rel(base, project); err == nil {
+		return rel
+	}
+	return project
+}
+
+// validateJobs checks the --jobs flag of a command with --all
+func validateJobs(jobs int) error {
+	if jobs < 1 {
+		return models.NewValidationError("jobs", fmt.Sprint(jobs), "must be at least 1")
+	}
+	return nil
+}
+
+// configGroup is a set of projects, by index, that share a configuration
+type configGroup struct {
+	cfg     config.Config
+	indexes []int
+}
+
+// runWorkspace calls fn for each project with the project's configuration,
+// with at most jobs calls running at once, and returns the errors of the
+// projects joined. Services read the configuration of the process, so only
+// projects sharing one run at once. Projects whose configuration fails to load
+// are passed to failed instead of fn.
+func runWorkspace(cmd *cobra.Command, projects []string, jobs int, fn func(i int, project string) error, failed func(i int, err error)) error {
+	var errs []error
+	var groups []*configGroup
+	for i, project := range projects {
+		cfg, err := resolveConfig(cmd, project)
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err != nil {
+			failed(i, err)
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+		index := slices.IndexFunc(groups, func(group *configGroup) bool { return reflect.DeepEqual(group.cfg, cfg) })
+		if index < 0 {
+			groups = append(groups, &configGroup{cfg: cfg})
+			index = len(groups) - 1
+		}
+		groups[index].indexes = append(groups[index].indexes, i)
+	}
+
+	for _, group := range groups {
+		if err := config.SetCurrent(group.cfg); err != nil {
+			// Validated above
+			return err
+		}
+		groupProjects := make([]string, len(group.indexes))
+		for j, i := range group.indexes {
+			groupProjects[j] = projects[i]
+		}
+		errs = append(errs, workspace.Run(groupProjects, jobs, func(j int, project string) error {
+			return fn(group.indexes[j], project)
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// completeProjectDir completes the directory argument of commands working on
+// projects: the projects in the registry starting with what was typed, else
+// directories as usual
+func completeProjectDir(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp
+	}
+	entries, err := registry.New().List()
+	if err != nil {
+		return []string{}, cobra.ShellCompDirectiveFilterDirs
+	}
+	var projects []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Path, toComplete) && registry.Exists(entry) {
+			projects = append(projects, entry.Path)
+		}
+	}
+	if len(projects) == 0 {
+		return []string{}, cobra.ShellCompDirectiveFilterDirs
+	}
+	return projects, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeInstance completes --instance with the named installations of the
+// project the command works on: its directory argument, else --target
+func completeInstance(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	target := targetDir
+	if len(args) > 0 {
+		if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+			target = args[0]
+		}
+	}
+	return config.FindInstances(target), cobra.ShellCompDirectiveNoFileComp
+}
+
+// recordManaged adds an installation to the registry; a registry that cannot be
+// written does not fail the installation
+func recordManaged(targetDir, templateID string) {
+	if err := registry.New().Add(targetDir, templateID); err != nil {
+		utils.VerbosePrintf(verbose, "Failed to record %s in the project registry: %v\n", targetDir, err)
+	}
+}
+
+// forgetManaged removes a project from the registry once no installation is left in it
+func forgetManaged(targetDir string) {
+	if workspace.IsInstalled(targetDir) {
+		return
+	}
+	if err := registry.New().Remove(targetDir); err != nil {
+		utils.VerbosePrintf(verbose, "Failed to remove %s from the project registry: %v\n", targetDir, err)
+	}
+}
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -15,10 +19,15 @@ const (
 	// Directory configuration
 	DefaultTargetDir        = "."
 	TempDirPrefix           = "strategic-claude-base-"
-	StrategicClaudeBasicDir = ".strategic-claude-basic"
+	StrategicClaudeBasicDir = ".strategic-claude-basic" // Default name of the framework directory, see FrameworkDir
+	InstanceDirPrefix       = ".strategic-claude-"      // Framework directories of named installations
+	StrategicLink           = "strategic"               // Symlinks in the tool directories, see StrategicLinkName
 	ClaudeDir               = ".claude"
 	CodexDir                = ".codex"
+	CursorDir               = ".cursor"
 	BackupDirPrefix         = "strategic-claude-basic-backup-"
+	BackupsDir              = ".strategic-claude-basic-backups" // Holds every backup the CLI creates
+	BackupInfoFile          = ".backup-info.json"               // Records what a framework backup contains
 
 	// Framework directory structure within .strategic-claude-basic/
 	CoreDir      = "core"
@@ -47,15 +56,85 @@ const (
 	ClaudeCommandsDir = "commands"
 	ClaudeConfigFile  = "CLAUDE.md"
 
+	// How the targets of the .claude and .codex symlinks are written
+	SymlinkStyleRelative = "relative" // ../../.strategic-claude-basic/core/..., keeps working when the project moves
+	SymlinkStyleAbsolute = "absolute" // Full paths, for tools that do not resolve relative links
+
 	// Settings files
 	SettingsTemplateFile = "templates/hooks/dot_claude.settings.template.json"
 	ClaudeSettingsFile   = "settings.json"
 	SettingsBackupPrefix = "settings-backup-"
 
+	// Gitignore templates applied by --gitignore-mode, within templates/
+	IgnoreTemplatesDir             = "templates/ignore"
+	ClaudeIgnoreTemplate           = "dot_claude-strategic-ignore.template"
+	StrategicIgnoreAllTemplate     = "dot_strategic-claude-basic-ignore-all.template"
+	StrategicIgnoreNonUserTemplate = "dot_strategic-claude-basic-ignore-non-user-dirs.template"
+
+	// Optional templates for the ignore files of other tools, within templates/ignore
+	DockerIgnoreTemplate = "dot_dockerignore.template"
+	ESLintIgnoreTemplate = "dot_eslintignore.template"
+
+	// Document templates used by 'new', as <kind>.template.md
+	DocumentTemplatesDir   = "templates/documents"
+	DocumentTemplateSuffix = ".template.md"
+
+	// Index of documents retired by 'archive', within archives/
+	ArchiveIndexFile = "INDEX.md"
+
+	// Agents and commands disabled with 'agents disable' and 'commands disable',
+	// and the generated views of core/ without them, within .strategic-claude-basic/
+	DisabledItemsFile = "disabled.json"
+	OverlayDir        = ".overlay"
+
+	// User copies of agents and commands that shadow the core files of the same
+	// relative path, within .strategic-claude-basic/ (kept across core updates)
+	OverridesDir = "overrides"
+
+	// Interpreter for strategic hooks when no Python installation is detected
+	DefaultHookPython = "/usr/bin/python3"
+
+	// Virtualenv for hook dependencies within .strategic-claude-basic/, and the
+	// dependency files looked for in core/hooks
+	HookVenvDir          = ".venv"
+	HookRequirementsFile = "requirements.txt"
+	HookPyprojectFile    = "pyproject.toml"
+
 	// Codex configuration files
 	CodexConfigTemplateFile = "templates/hooks/dot_codex.config.template.toml"
 	CodexConfigFile         = "config.toml"
-	CodexConfigBackupPrefix = "config-backup-"
+	CodexConfigBackupPrefix = "codex-config-backup-"
+
+	// Cursor rules shipped by templates, and where they are installed within .cursor/
+	CursorRulesTemplateDir = "templates/cursor/rules"
+	CursorRulesDir         = "rules"
+	CursorRulesLink        = "rules/strategic"
+
+	// Conventions shared with Aider and OpenCode: the conventions file a template ships,
+	// the generated file combining it with command hints within .strategic-claude-basic/,
+	// and the tool configurations referencing it in the project root
+	ConventionsTemplateFile = "templates/conventions/CONVENTIONS.md"
+	ConventionsFile         = ".conventions.md"
+	AiderConfigFile         = ".aider.conf.yml"
+	OpenCodeConfigFile      = "opencode.json"
+
+	// Dev container configuration updated by 'devcontainer', the volume that keeps
+	// the clone cache across container rebuilds, and where it is mounted
+	DevcontainerDir          = ".devcontainer"
+	DevcontainerFile         = "devcontainer.json"
+	DevcontainerBackupPrefix = "devcontainer-backup-"
+	DevcontainerCacheVolume  = "strategic-claude-basic-cache"
+	DevcontainerCacheMount   = "/var/cache/strategic-claude-basic"
+
+	// direnv configuration holding the block written by init --direnv
+	EnvrcFile = ".envrc"
+
+	// MCP configuration backups
+	MCPBackupPrefix = "mcp-backup-"
+
+	// Backup names used before backups were collected in BackupsDir
+	LegacyCodexConfigBackupPrefix = "config-backup-" // In .codex/
+	LegacyMCPBackupPrefix         = ".mcp-backup-"   // In the project root
 
 	// Directories that are replaced during updates
 	ReplacedDirs = "core/,guides/,templates/"
@@ -63,27 +142,169 @@ const (
 	// User directories preserved during updates
 	UserPreservedDirs = "archives/,decisions/,issues/,plan/,product/,research/,summary/,tools/,validation/"
 
+	// Environment variable holding an HTTPS token for private template repositories
+	GitTokenEnvVar = "SCB_GIT_TOKEN"
+
+	// Environment variable listing extra directories installs must never target (path-list separated)
+	ForbiddenPathsEnvVar = "SCB_FORBIDDEN_PATHS"
+
+	// Environment variable overriding the backups directory; relative paths resolve against the project
+	BackupsDirEnvVar = "SCB_BACKUP_DIR"
+
+	// Environment variable setting the directory --trash moves removed files to; must be absolute
+	TrashDirEnvVar = "SCB_TRASH_DIR"
+
+	// Environment variable setting the directory template checkouts are made in; must be absolute
+	TempDirEnvVar = "SCB_TEMP_DIR"
+
+	// Environment variable overriding the clone cache directory; must be absolute
+	CacheDirEnvVar = "SCB_CACHE_DIR"
+
+	// Environment variable overriding the data directory of the project registry; must be absolute
+	DataDirEnvVar = "SCB_DATA_DIR"
+
+	// Environment variable that makes --require-git-repo the default when set to a true value
+	RequireGitRepoEnvVar = "SCB_REQUIRE_GIT_REPO"
+
+	// Environment variables overriding the config files
+	FrameworkDirEnvVar = "SCB_FRAMEWORK_DIR"
+	GitTimeoutEnvVar   = "SCB_GIT_TIMEOUT" // A duration such as 1m
+	ConfigFileEnvVar   = "SCB_CONFIG"      // Path of the user config file
+
+	// User config file, below the platform's user config directory
+	UserConfigDirName = "strategic-claude-basic"
+	UserConfigFile    = "config.json"
+
+	// Directory of executable plugins next to the user config file, with a
+	// subdirectory for each point of an installation plugins run at
+	PluginsDirName = "plugins"
+
+	// Points of an installation plugins run at
+	PluginPointPreInstall  = "pre-install"  // The template is fetched and verified, nothing is changed yet
+	PluginPointPostCopy    = "post-copy"    // The framework files are copied
+	PluginPointPostInstall = "post-install" // The installation is complete and validated
+
+	// Environment variable that disables the update check like --no-update-check when set to a true value
+	NoUpdateCheckEnvVar = "SCB_NO_UPDATE_CHECK"
+
+	// Environment variable that turns on accessibility mode like --accessible when set to a true value
+	AccessibleEnvVar = "SCB_ACCESSIBLE"
+
+	// Environment variable that limits output to ASCII markers like --ascii when set to a true value
+	ASCIIEnvVar = "SCB_ASCII"
+
+	// Update check: release metadata listing the template commits the newest CLI release pins
+	ReleaseMetadataURL   = "https://github.com/Fomo-Driven-Development/strategic-claude-basic-cli/releases/latest/download/release.json"
+	UpdateCheckFile      = "update-check.json" // Within the cache directory
+	UpdateCheckInterval  = 24 * time.Hour
+	UpdateCheckTimeout   = 2 * time.Second        // For fetching the release metadata
+	UpdateCheckWait      = 300 * time.Millisecond // How long a finished command waits for the check
+	ReleaseFormatVersion = 1
+
+	// Projects commands working on a whole workspace handle at once
+	DefaultWorkspaceJobs = 4
+
 	// Default timeout values
 	DefaultGitTimeout     = 30 * time.Second
 	DefaultNetworkTimeout = 30 * time.Second
 
+	// Advisory locks on settings files: how long to wait, and when a lock is abandoned
+	SettingsLockTimeout = 10 * time.Second
+	StaleLockAge        = 2 * time.Minute
+
+	// How long a cached status result is used while the installation is unchanged
+	StatusCacheTTL = 10 * time.Second
+
 	// Validation constants
 	MaxPathLength       = 260 // Windows compatibility
 	MaxDirectoryNameLen = 255
+	MaxInstanceNameLen  = 64
 	MinDirectoryNameLen = 1
 
+	// Symlinks to directories CopyDirectory follows below each other before
+	// giving up, when the file system cannot tell that they form a loop
+	MaxFollowedSymlinks = 40
+
 	// Application metadata
 	AppName        = "strategic-claude-basic-cli"
 	AppDescription = "CLI tool for managing Strategic Claude Basic framework installations"
-	ConfigFileName = "strategic-claude-basic.json"
+	ConfigFileName = "strategic-claude-basic.json" // Project config file in the target directory
+	BinaryName     = "strategic-claude"            // Name of the installed executable, as built by go install and make
 
 	// Template metadata file
 	TemplateInfoFile = ".template-info"
 
+	// Hashes of the installed framework files, within .strategic-claude-basic/
+	InstallManifestFile          = ".install-manifest.json"
+	InstallManifestFormatVersion = 1
+	DriftSampleSize              = 50 // Files status hashes without --deep
+
+	// Detached signature over the framework tree hash, at the template repository root
+	TemplateSignatureFile = "strategic-claude-basic.sig"
+
+	// Offline bundles
+	BundleManifestFile  = "bundle.json"
+	BundleContentDir    = "content"
+	BundleFileExtension = ".tar.gz"
+	BundleFormatVersion = 1
+	VendorDir           = ".vendor" // Vendored template copy within .strategic-claude-basic/
+
+	// User content archives created by export-user-content
+	UserContentArchivePrefix = "strategic-claude-user-content-"
+	UserContentManifestFile  = "user-content.json"
+	UserContentFormatVersion = 1
+
+	// Git directory used by 'sync', relative to .strategic-claude-basic
+	UserContentSyncDir    = ".sync"
+	UserContentSyncBranch = "main"
+
+	// Clone cache, below $XDG_CACHE_HOME or the platform cache directory
+	CacheDirName   = "strategic-claude-basic"
+	CacheEntryFile = "entry.json"
+
+	// Registry of the projects this CLI installed into, below $XDG_DATA_HOME or
+	// ~/.local/share
+	DataDirName           = "strategic-claude-basic"
+	RegistryFile          = "projects.json"
+	RegistryFormatVersion = 1
+	RegistryLockTimeout   = 10 * time.Second
+
+	// Status results cached for repeated invocations, below the cache directory
+	StatusCacheDir           = "status"
+	StatusCacheFormatVersion = 10
+
+	// Created and removed again to check that a directory is writable
+	WriteProbeFile = ".strategic-claude-basic-test-write"
+
+	// Size of the buffers files are copied through
+	CopyBufferSize = 256 << 10 // 256 KiB
+
+	// Template checkouts in the temporary directory older than this are left
+	// over from crashed runs and removed when a command starts
+	StaleTempDirAge = 24 * time.Hour
+
+	// Prefix of the scratch directory the file system capabilities are probed in
+	ProbeDirPrefix = ".strategic-claude-basic-probe-"
+
 	// Installation scripts
 	PreInstallScript  = "pre-install.sh"
 	PostInstallScript = "post-install.sh"
 
+	// Output of an installation script kept for the install report
+	MaxScriptOutputBytes = 4 << 10 // 4 KiB, the end of the output
+
+	// Image installation scripts run in with --script-isolation=docker, and
+	// where the project is mounted in it
+	ScriptContainerImage   = "bash:5"
+	ScriptContainerWorkdir = "/project"
+	DockerCheckTimeout     = 10 * time.Second
+	ScriptStopTimeout      = 5 * time.Second // How long a script stopped at the deadline gets to exit
+
+	// How long a hook run by verify-hooks gets to handle its synthetic payload,
+	// and the variable set while it runs so hooks can leave out side effects
+	HookCheckTimeout = 30 * time.Second
+	HookCheckEnvVar  = "SCB_HOOK_CHECK"
+
 	// Exit codes
 	ExitSuccess           = 0
 	ExitGeneralError      = 1
@@ -94,14 +315,38 @@ const (
 	ExitInstallationError = 6
 	ExitAlreadyInstalled  = 7
 	ExitNotInstalled      = 8
+	ExitUpdateAvailable   = 10 // update --check found a newer commit to install
 
 	// File permissions
-	DirPermissions  = 0755
-	FilePermissions = 0644
+	DirPermissions          = 0755
+	FilePermissions         = 0644
+	ReadOnlyFilePermissions = 0444 // Framework files with --readonly-core
 
 	// Backup configuration
 	MaxBackupAge = 30 * 24 * time.Hour // 30 days
 	MaxBackups   = 10                  // Maximum number of backups to keep
+
+	// Timestamp embedded in backup names
+	BackupTimestampLayout = "20060102-150405"
+	BackupCommitLength    = 7 // Characters of the commit in framework backup names
+
+	// Extension of framework backups kept as an archive rather than a directory
+	BackupArchiveExtension = ".tar.gz"
+
+	// User content above either limit makes clean ask before removing it
+	LargeUserContentFiles = 50
+	LargeUserContentBytes = 5 << 20 // 5 MiB
+
+	// Overwrites and cleanups replacing or removing more files than this ask to
+	// type the directory name instead of y/N
+	TypedConfirmFiles = 200
+
+	// Templates above the warning limits get a warning; installing one above
+	// the maximum stops unless --allow-large-template is given
+	TemplateWarnBytes = 50 << 20  // 50 MiB
+	TemplateMaxBytes  = 500 << 20 // 500 MiB
+	TemplateWarnFiles = 5000
+	TemplateMaxFiles  = 50000
 )
 
 // GetFrameworkDirectories returns the list of framework directories
@@ -122,6 +367,11 @@ func GetCoreDirectories() []string {
 	}
 }
 
+// GetPluginPoints returns the points of an installation plugins run at, in order
+func GetPluginPoints() []string {
+	return []string{PluginPointPreInstall, PluginPointPostCopy, PluginPointPostInstall}
+}
+
 // GetUserPreservedDirectories returns directories that should be preserved during selective updates
 func GetUserPreservedDirectories() []string {
 	return []string{
@@ -137,26 +387,219 @@ func GetUserPreservedDirectories() []string {
 	}
 }
 
+// GetSensitiveDirectories returns system directories that must never be used as an installation target
+func GetSensitiveDirectories() []string {
+	return []string{
+		"/",
+		"/bin", "/boot", "/dev", "/etc", "/lib", "/lib64", "/opt", "/proc",
+		"/root", "/sbin", "/sys", "/tmp", "/usr", "/usr/bin", "/usr/local", "/var",
+		"/Applications", "/Library", "/System", "/Users", "/home",
+	}
+}
+
+// GetInstalledPaths returns the project paths an installation writes, relative to the target directory
+func GetInstalledPaths() []string {
+	return []string{
+		FrameworkDir(),
+		ClaudeDir,
+		CodexDir,
+		CursorDir,
+		AiderConfigFile,
+		OpenCodeConfigFile,
+		EnvrcFile,
+	}
+}
+
+// GetHookScriptExtensions returns the file extensions recognized as hook scripts
+func GetHookScriptExtensions() []string {
+	return []string{".py", ".sh", ".bash", ".js", ".ts", ".rb"}
+}
+
+// GetIgnoreTemplates returns the gitignore templates a template ships in IgnoreTemplatesDir
+func GetIgnoreTemplates() []string {
+	return []string{ClaudeIgnoreTemplate, StrategicIgnoreAllTemplate, StrategicIgnoreNonUserTemplate}
+}
+
+// GetVerifiedTemplatePaths returns the paths, relative to the template repository
+// root, covered by a template's tree hash and signature: the framework directory,
+// which holds the ignore templates too, and the installation scripts the
+// installer runs
+func GetVerifiedTemplatePaths() []string {
+	return []string{StrategicClaudeBasicDir, PreInstallScript, PostInstallScript}
+}
+
+// ToolIgnoreFile maps an ignore template to the ignore file of a tool other than git
+type ToolIgnoreFile struct {
+	Template string   // Within IgnoreTemplatesDir
+	Target   string   // Relative to the project
+	Markers  []string // Files showing the project uses the tool
+}
+
+// GetToolIgnoreFiles returns the ignore files of other tools that templates can
+// add entries to. An ignore file is only created when the project has one of
+// its markers; an existing one is always merged into.
+func GetToolIgnoreFiles() []ToolIgnoreFile {
+	return []ToolIgnoreFile{
+		{
+			Template: DockerIgnoreTemplate,
+			Target:   ".dockerignore",
+			Markers:  []string{"Dockerfile", "Containerfile", "compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"},
+		},
+		{
+			Template: ESLintIgnoreTemplate,
+			Target:   ".eslintignore",
+			Markers:  []string{".eslintrc", ".eslintrc.js", ".eslintrc.cjs", ".eslintrc.json", ".eslintrc.yaml", ".eslintrc.yml"},
+		},
+	}
+}
+
 // GetRequiredSymlinks returns the symlinks that should be created for .claude
 func GetRequiredSymlinks() map[string]string {
 	return map[string]string{
-		"agents/strategic":   "../../" + StrategicClaudeBasicDir + "/core/agents",
-		"commands/strategic": "../../" + StrategicClaudeBasicDir + "/core/commands",
-		"hooks/strategic":    "../../" + StrategicClaudeBasicDir + "/core/hooks",
+		AgentsDir + "/" + StrategicLinkName():   "../../" + FrameworkDir() + "/core/agents",
+		CommandsDir + "/" + StrategicLinkName(): "../../" + FrameworkDir() + "/core/commands",
+		HooksDir + "/" + StrategicLinkName():    "../../" + FrameworkDir() + "/core/hooks",
 	}
 }
 
 // GetCodexRequiredSymlinks returns the symlinks that should be created for .codex
 func GetCodexRequiredSymlinks() map[string]string {
 	return map[string]string{
-		"prompts/strategic": "../../" + StrategicClaudeBasicDir + "/core/commands",
-		"hooks/strategic":   "../../" + StrategicClaudeBasicDir + "/core/hooks",
+		PromptsDir + "/" + StrategicLinkName(): "../../" + FrameworkDir() + "/core/commands",
+		HooksDir + "/" + StrategicLinkName():   "../../" + FrameworkDir() + "/core/hooks",
 	}
 }
 
+// GetCursorRulesSymlinkTarget returns the target of the .cursor/rules/strategic symlink
+func GetCursorRulesSymlinkTarget() string {
+	return "../../" + FrameworkDir() + "/" + CursorRulesTemplateDir
+}
+
+// GetOverlayKinds returns the core directories whose items can be disabled or overridden
+func GetOverlayKinds() []string {
+	return []string{AgentsDir, CommandsDir}
+}
+
+// GetOverlaySymlinkTarget returns the symlink target for the overlay of a core directory
+func GetOverlaySymlinkTarget(kind string) string {
+	return "../../" + FrameworkDir() + "/" + OverlayDir + "/" + kind
+}
+
+// GetSymlinkStyles returns the ways symlink targets can be written, the default first
+func GetSymlinkStyles() []string {
+	return []string{SymlinkStyleRelative, SymlinkStyleAbsolute}
+}
+
+// InstalledSymlinkStyle returns the symlink style recorded in the template
+// metadata of the installation in targetDir; relative when none is recorded
+func InstalledSymlinkStyle(targetDir string) string {
+	data, err := os.ReadFile(filepath.Join(targetDir, FrameworkDir(), TemplateInfoFile))
+	if err != nil {
+		return SymlinkStyleRelative
+	}
+	var info struct {
+		SymlinkStyle string `json:"symlink_style"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil || info.SymlinkStyle == "" {
+		return SymlinkStyleRelative
+	}
+	return info.SymlinkStyle
+}
+
+// ResolveSymlinkTargets returns symlinks with the targets used in targetDir:
+// core directories with a generated overlay are linked through the overlay,
+// and targets are written in the symlink style the installation recorded
+func ResolveSymlinkTargets(targetDir string, symlinks map[string]string) map[string]string {
+	return ResolveSymlinkTargetsInStyle(targetDir, symlinks, InstalledSymlinkStyle(targetDir))
+}
+
+// ResolveSymlinkTargetsInStyle is ResolveSymlinkTargets with the targets
+// written in style, for installations that change it
+func ResolveSymlinkTargetsInStyle(targetDir string, symlinks map[string]string, style string) map[string]string {
+	resolved := make(map[string]string, len(symlinks))
+	for symlinkPath, target := range symlinks {
+		resolved[symlinkPath] = target
+		for _, kind := range GetOverlayKinds() {
+			if target != "../../"+FrameworkDir()+"/"+CoreDir+"/"+kind {
+				continue
+			}
+			overlayPath := filepath.Join(targetDir, FrameworkDir(), OverlayDir, kind)
+			if info, err := os.Stat(overlayPath); err == nil && info.IsDir() {
+				resolved[symlinkPath] = GetOverlaySymlinkTarget(kind)
+			}
+		}
+
+		// Targets are relative to the directory of the link; the tool
+		// directories all sit in targetDir, so .claude stands for any of them
+		if style == SymlinkStyleAbsolute {
+			if absTarget, err := filepath.Abs(filepath.Join(targetDir, ClaudeDir, filepath.Dir(symlinkPath), resolved[symlinkPath])); err == nil {
+				resolved[symlinkPath] = absTarget
+			}
+		}
+	}
+	return resolved
+}
+
 // GetBackupDirName generates a backup directory name with timestamp
 func GetBackupDirName() string {
-	return BackupDirPrefix + time.Now().Format("20060102-150405")
+	return BackupDirPrefix + time.Now().Format(BackupTimestampLayout)
+}
+
+// BackupLabel returns the suffix that names the template and commit a framework
+// backup contains, e.g. "-main-1a2b3c4", or "" when neither is known
+func BackupLabel(templateID, commit string) string {
+	var label strings.Builder
+	for _, r := range strings.ToLower(templateID) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			label.WriteRune(r)
+		default:
+			label.WriteRune('_')
+		}
+	}
+	if len(commit) > BackupCommitLength {
+		commit = commit[:BackupCommitLength]
+	}
+	if commit != "" {
+		if label.Len() > 0 {
+			label.WriteString("-")
+		}
+		label.WriteString(commit)
+	}
+	if label.Len() == 0 {
+		return ""
+	}
+	return "-" + label.String()
+}
+
+// UniqueBackupName returns a timestamped backup name that is not yet taken in dir.
+// Backups created within the same second get a counter: prefix<timestamp>-2<ext>, ...
+func UniqueBackupName(dir, prefix, ext string) string {
+	base := prefix + time.Now().Format(BackupTimestampLayout)
+
+	name := base + ext
+	for counter := 2; ; counter++ {
+		if _, err := os.Lstat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d%s", base, counter, ext)
+	}
+}
+
+// GetBackupsRoot returns the directory backups are written to for a project, honoring
+// $SCB_BACKUP_DIR and the configured backups directory
+func GetBackupsRoot(targetDir string) string {
+	dir := strings.TrimSpace(os.Getenv(BackupsDirEnvVar))
+	if dir == "" {
+		dir = current.BackupsDir
+	}
+	if dir != "" {
+		if filepath.IsAbs(dir) {
+			return filepath.Clean(dir)
+		}
+		return filepath.Join(targetDir, dir)
+	}
+	return filepath.Join(targetDir, BackupsDir)
 }
 
 // IsUserPreservedPath checks if a path should be preserved during selective updates
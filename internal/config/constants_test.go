@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -517,3 +519,65 @@ func TestContainsHelper(t *testing.T) {
 		t.Error("Expected contains to not find item in empty slice")
 	}
 }
+
+func TestGetBackupsRoot(t *testing.T) {
+	tests := []struct {
+		name   string
+		envDir string
+		want   string
+	}{
+		{"default", "", filepath.Join("/project", BackupsDir)},
+		{"relative override", "backups", filepath.Join("/project", "backups")},
+		{"absolute override", "/var/backups/project", "/var/backups/project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(BackupsDirEnvVar, tt.envDir)
+			if got := GetBackupsRoot("/project"); got != tt.want {
+				t.Errorf("GetBackupsRoot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueBackupName(t *testing.T) {
+	dir := t.TempDir()
+
+	// Backups created in quick succession must never share a name
+	seen := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		name := UniqueBackupName(dir, SettingsBackupPrefix, ".json")
+		if seen[name] {
+			t.Fatalf("UniqueBackupName() returned %s twice", name)
+		}
+		seen[name] = true
+
+		if !strings.HasPrefix(name, SettingsBackupPrefix) || !strings.HasSuffix(name, ".json") {
+			t.Errorf("UniqueBackupName() = %s, want %s<timestamp>.json", name, SettingsBackupPrefix)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBackupLabel(t *testing.T) {
+	tests := []struct {
+		template string
+		commit   string
+		want     string
+	}{
+		{"main", "1a2b3c4d5e6f", "-main-1a2b3c4"},
+		{"main", "", "-main"},
+		{"", "1a2b3c4", "-1a2b3c4"},
+		{"", "", ""},
+		{"Team/Python Stack", "abc", "-team_python_stack-abc"},
+	}
+
+	for _, tt := range tests {
+		if got := BackupLabel(tt.template, tt.commit); got != tt.want {
+			t.Errorf("BackupLabel(%q, %q) = %q, want %q", tt.template, tt.commit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,590 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings that can change at runtime. It is loaded once per
+// command from the defaults, the user and project config files, the
+// environment and flags; the constants of this package are its defaults.
+type Config struct {
+	// Named installation commands work on, empty for the default one. Each
+	// instance has its own framework directory, InstanceDir(Instance), and
+	// symlinks named StrategicLinkName().
+	Instance string
+
+	// Name of the framework directory in the project, e.g. .ai-framework
+	FrameworkDir string
+
+	// Directory backups are written to; relative paths resolve against the
+	// project, empty for BackupsDir in the project
+	BackupsDir string
+
+	// Directory --trash moves removed files to; empty for the trash of the OS
+	TrashDir string
+
+	// Directory template checkouts and other temporary files are created in;
+	// empty for the temporary directory of the OS
+	TempDir string
+
+	// Limit for each git command run while fetching templates
+	GitTimeout time.Duration
+
+	// How long a cached status result is used while the installation is unchanged
+	StatusCacheTTL time.Duration
+
+	// Backups kept, and how old they get, before status suggests pruning
+	MaxBackups   int
+	MaxBackupAge time.Duration
+
+	// Default of init --require-git-repo
+	RequireGitRepo bool
+
+	// Template size and file count above which init warns, and above which it
+	// stops unless --allow-large-template is given; 0 turns a limit off
+	TemplateWarnBytes int64
+	TemplateMaxBytes  int64
+	TemplateWarnFiles int
+	TemplateMaxFiles  int
+
+	// Files an overwrite or cleanup replaces or removes above which it asks to
+	// type the directory name instead of y/N; 0 always asks y/N
+	TypedConfirmFiles int
+
+	// Expected SHA-256 of the template's installation scripts, by script name;
+	// when set, scripts that do not match are not run
+	ScriptHashes map[string]string
+
+	// Executables init runs at each plugin point, before the ones found in
+	// PluginsDir; only the user config file may set them
+	Plugins map[string][]string
+
+	// Directory plugins are discovered in; empty for PluginsDirName next to the
+	// user config file
+	PluginsDir string
+}
+
+// fileConfig is the format of the config files; unset fields keep the value
+// of the previous source
+type fileConfig struct {
+	FrameworkDir   string `json:"framework_dir,omitempty"`
+	BackupsDir     string `json:"backups_dir,omitempty"`
+	TrashDir       string `json:"trash_dir,omitempty"`
+	TempDir        string `json:"temp_dir,omitempty"`
+	GitTimeout     string `json:"git_timeout,omitempty"`
+	StatusCacheTTL string `json:"status_cache_ttl,omitempty"`
+	MaxBackups     *int   `json:"max_backups,omitempty"`
+	MaxBackupAge   string `json:"max_backup_age,omitempty"`
+	RequireGitRepo *bool  `json:"require_git_repo,omitempty"`
+
+	TemplateWarnBytes *int64 `json:"template_warn_bytes,omitempty"`
+	TemplateMaxBytes  *int64 `json:"template_max_bytes,omitempty"`
+	TemplateWarnFiles *int   `json:"template_warn_files,omitempty"`
+	TemplateMaxFiles  *int   `json:"template_max_files,omitempty"`
+
+	TypedConfirmFiles *int `json:"typed_confirm_files,omitempty"`
+
+	ScriptHashes map[string]string `json:"script_hashes,omitempty"`
+
+	Plugins    map[string][]string `json:"plugins,omitempty"`
+	PluginsDir string              `json:"plugins_dir,omitempty"`
+}
+
+// LoadOptions selects the sources Load reads
+type LoadOptions struct {
+	// Project whose config file is read
+	TargetDir string
+
+	// User config file; UserConfigPath() when empty
+	UserConfigPath string
+
+	// Environment lookup; os.Getenv when nil
+	Getenv func(string) string
+}
+
+// current is the configuration commands run with
+var current = DefaultConfig()
+
+// DefaultConfig returns the configuration used without config files, environment or flags
+func DefaultConfig() Config {
+	return Config{
+		FrameworkDir:   StrategicClaudeBasicDir,
+		GitTimeout:     DefaultGitTimeout,
+		StatusCacheTTL: StatusCacheTTL,
+		MaxBackups:     MaxBackups,
+		MaxBackupAge:   MaxBackupAge,
+
+		TemplateWarnBytes: TemplateWarnBytes,
+		TemplateMaxBytes:  TemplateMaxBytes,
+		TemplateWarnFiles: TemplateWarnFiles,
+		TemplateMaxFiles:  TemplateMaxFiles,
+
+		TypedConfirmFiles: TypedConfirmFiles,
+	}
+}
+
+// Current returns the configuration commands run with
+func Current() Config {
+	return current
+}
+
+// SetCurrent validates cfg and makes it the configuration commands run with
+func SetCurrent(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	current = cfg
+	return nil
+}
+
+// FrameworkDir returns the name of the framework directory in the project
+func FrameworkDir() string {
+	return current.FrameworkDir
+}
+
+// PluginsDir returns the directory plugins are discovered in: the configured
+// one, else PluginsDirName next to the user config file. It is empty when
+// neither is available.
+func PluginsDir() string {
+	if current.PluginsDir != "" {
+		return current.PluginsDir
+	}
+	userConfigPath := UserConfigPath()
+	if userConfigPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(userConfigPath), PluginsDirName)
+}
+
+// TempDir returns the directory temporary files are created in: the
+// configured one, else the temporary directory of the OS
+func TempDir() string {
+	if current.TempDir == "" {
+		return os.TempDir()
+	}
+	return current.TempDir
+}
+
+// Instance returns the named installation commands work on, empty for the default one
+func Instance() string {
+	return current.Instance
+}
+
+// StrategicLinkName returns the name of the symlinks in the tool directories:
+// strategic for the default installation, strategic-<instance> for named ones
+func StrategicLinkName() string {
+	if current.Instance == "" {
+		return StrategicLink
+	}
+	return StrategicLink + "-" + current.Instance
+}
+
+// WithInstance returns the configuration for a named installation, which lives
+// in its own framework directory; an empty name keeps the default installation
+func (c Config) WithInstance(name string) Config {
+	if name == "" {
+		return c
+	}
+	c.Instance = name
+	c.FrameworkDir = InstanceDir(name)
+	return c
+}
+
+// InstanceDir returns the framework directory of a named installation
+func InstanceDir(name string) string {
+	return InstanceDirPrefix + name
+}
+
+// FindInstances returns the names of the named installations in targetDir,
+// recognized by the template metadata in their framework directories
+func FindInstances(targetDir string) []string {
+	entries, err := os.ReadDir(targetDir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), InstanceDirPrefix)
+		if !ok || !entry.IsDir() || ValidateInstanceName(name) != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(targetDir, entry.Name(), TemplateInfoFile)); err == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ValidateInstanceName checks that name can name an installation: lowercase
+// letters, digits and hyphens, starting with a letter or digit
+func ValidateInstanceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("instance name cannot be empty")
+	}
+	if len(name) > MaxInstanceNameLen {
+		return fmt.Errorf("instance name is longer than %d characters", MaxInstanceNameLen)
+	}
+	for i, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && (r != '-' || i == 0) {
+			return fmt.Errorf("instance name %q may only contain lowercase letters, digits and hyphens, and cannot start with a hyphen", name)
+		}
+	}
+	if InstanceDir(name) == StrategicClaudeBasicDir || InstanceDir(name) == BackupsDir {
+		return fmt.Errorf("instance name %q is reserved", name)
+	}
+	return nil
+}
+
+// Load returns the defaults overridden, in this order, by the user config
+// file, the project config file in TargetDir and the environment. Missing
+// config files are skipped.
+func Load(opts LoadOptions) (Config, error) {
+	getenv := opts.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	userConfigPath := opts.UserConfigPath
+	if userConfigPath == "" {
+		userConfigPath = userConfigPathFrom(getenv)
+	}
+
+	cfg := DefaultConfig()
+	if userConfigPath != "" {
+		if err := applyFile(&cfg, userConfigPath, true); err != nil {
+			return cfg, err
+		}
+		// Plugins are looked for next to the config file in use
+		if cfg.PluginsDir == "" {
+			if absPath, err := filepath.Abs(userConfigPath); err == nil {
+				cfg.PluginsDir = filepath.Join(filepath.Dir(absPath), PluginsDirName)
+			}
+		}
+	}
+	if err := applyFile(&cfg, ProjectConfigPath(opts.TargetDir), false); err != nil {
+		return cfg, err
+	}
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// LoadProjectConfig returns the defaults overridden by the project config file
+// in targetDir alone; without the file the defaults are returned
+func LoadProjectConfig(targetDir string) (Config, error) {
+	cfg := DefaultConfig()
+	if err := applyFile(&cfg, ProjectConfigPath(targetDir), false); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid %s: %w", ConfigFileName, err)
+	}
+	return cfg, nil
+}
+
+// SaveProjectFrameworkDir sets the framework directory name in the project
+// config file in targetDir, keeping its other settings
+func SaveProjectFrameworkDir(targetDir, name string) error {
+	path := ProjectConfigPath(targetDir)
+
+	var file fileConfig
+	if err := readFile(path, &file); err != nil {
+		return err
+	}
+	file.FrameworkDir = name
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), FilePermissions)
+}
+
+// ProjectConfigPath returns the path of the project config file in targetDir
+func ProjectConfigPath(targetDir string) string {
+	return filepath.Join(targetDir, ConfigFileName)
+}
+
+// UserConfigPath returns the path of the user config file: $SCB_CONFIG, or
+// config.json below the platform's user config directory. It is empty when
+// neither is available.
+func UserConfigPath() string {
+	return userConfigPathFrom(os.Getenv)
+}
+
+func userConfigPathFrom(getenv func(string) string) string {
+	if path := strings.TrimSpace(getenv(ConfigFileEnvVar)); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, UserConfigDirName, UserConfigFile)
+}
+
+// Validate checks that the configuration can be used
+func (c Config) Validate() error {
+	if c.Instance != "" {
+		if err := ValidateInstanceName(c.Instance); err != nil {
+			return err
+		}
+		if c.FrameworkDir != InstanceDir(c.Instance) {
+			return fmt.Errorf("instance %q lives in %s, not %s", c.Instance, InstanceDir(c.Instance), c.FrameworkDir)
+		}
+	}
+	if err := ValidateFrameworkDirName(c.FrameworkDir); err != nil {
+		return err
+	}
+	if c.TrashDir != "" && !filepath.IsAbs(c.TrashDir) {
+		return fmt.Errorf("trash directory must be an absolute path, got %s", c.TrashDir)
+	}
+	if c.TempDir != "" && !filepath.IsAbs(c.TempDir) {
+		return fmt.Errorf("temporary directory must be an absolute path, got %s", c.TempDir)
+	}
+	if c.GitTimeout <= 0 {
+		return fmt.Errorf("git timeout must be positive, got %s", c.GitTimeout)
+	}
+	if c.StatusCacheTTL < 0 {
+		return fmt.Errorf("status cache TTL cannot be negative, got %s", c.StatusCacheTTL)
+	}
+	if c.MaxBackups < 1 {
+		return fmt.Errorf("max backups must be at least 1, got %d", c.MaxBackups)
+	}
+	if c.MaxBackupAge <= 0 {
+		return fmt.Errorf("max backup age must be positive, got %s", c.MaxBackupAge)
+	}
+	if c.TemplateWarnBytes < 0 || c.TemplateMaxBytes < 0 || c.TemplateWarnFiles < 0 || c.TemplateMaxFiles < 0 {
+		return fmt.Errorf("template size limits cannot be negative")
+	}
+	if c.TemplateMaxBytes > 0 && c.TemplateWarnBytes > c.TemplateMaxBytes {
+		return fmt.Errorf("template warning size %d exceeds the maximum size %d", c.TemplateWarnBytes, c.TemplateMaxBytes)
+	}
+	if c.TemplateMaxFiles > 0 && c.TemplateWarnFiles > c.TemplateMaxFiles {
+		return fmt.Errorf("template warning file count %d exceeds the maximum file count %d", c.TemplateWarnFiles, c.TemplateMaxFiles)
+	}
+	if c.TypedConfirmFiles < 0 {
+		return fmt.Errorf("typed confirmation file count cannot be negative, got %d", c.TypedConfirmFiles)
+	}
+	if c.PluginsDir != "" && !filepath.IsAbs(c.PluginsDir) {
+		return fmt.Errorf("plugins directory must be an absolute path, got %s", c.PluginsDir)
+	}
+	for point, paths := range c.Plugins {
+		if !slices.Contains(GetPluginPoints(), point) {
+			return fmt.Errorf("plugins can run at %s, got %s", strings.Join(GetPluginPoints(), ", "), point)
+		}
+		for _, path := range paths {
+			if !filepath.IsAbs(path) {
+				return fmt.Errorf("plugin must be an absolute path, got %s", path)
+			}
+		}
+	}
+	for name, hash := range c.ScriptHashes {
+		if name != PreInstallScript && name != PostInstallScript {
+			return fmt.Errorf("script hashes can only pin %s and %s, got %s", PreInstallScript, PostInstallScript, name)
+		}
+		if !isSHA256(hash) {
+			return fmt.Errorf("script hash of %s must be 64 hexadecimal characters, got %q", name, hash)
+		}
+	}
+	return nil
+}
+
+// isSHA256 reports whether s is a hex encoded SHA-256 digest
+func isSHA256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON writes the configuration in the format of the config files
+func (c Config) MarshalJSON() ([]byte, error) {
+	maxBackups := c.MaxBackups
+	requireGitRepo := c.RequireGitRepo
+	templateWarnBytes, templateMaxBytes := c.TemplateWarnBytes, c.TemplateMaxBytes
+	templateWarnFiles, templateMaxFiles := c.TemplateWarnFiles, c.TemplateMaxFiles
+	typedConfirmFiles := c.TypedConfirmFiles
+	return json.Marshal(fileConfig{
+		FrameworkDir:   c.FrameworkDir,
+		BackupsDir:     c.BackupsDir,
+		TrashDir:       c.TrashDir,
+		TempDir:        c.TempDir,
+		GitTimeout:     c.GitTimeout.String(),
+		StatusCacheTTL: c.StatusCacheTTL.String(),
+		MaxBackups:     &maxBackups,
+		MaxBackupAge:   c.MaxBackupAge.String(),
+		RequireGitRepo: &requireGitRepo,
+
+		TemplateWarnBytes: &templateWarnBytes,
+		TemplateMaxBytes:  &templateMaxBytes,
+		TemplateWarnFiles: &templateWarnFiles,
+		TemplateMaxFiles:  &templateMaxFiles,
+
+		TypedConfirmFiles: &typedConfirmFiles,
+
+		ScriptHashes: c.ScriptHashes,
+
+		Plugins:    c.Plugins,
+		PluginsDir: c.PluginsDir,
+	})
+}
+
+// ValidateFrameworkDirName checks that name can be used as the framework
+// directory: a single path element that no other installed path uses
+func ValidateFrameworkDirName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("framework directory name cannot be empty")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("framework directory name %q must be a single directory name", name)
+	}
+	if len(name) > MaxDirectoryNameLen {
+		return fmt.Errorf("framework directory name is longer than %d characters", MaxDirectoryNameLen)
+	}
+	for _, reserved := range []string{ClaudeDir, CodexDir, CursorDir, BackupsDir, DevcontainerDir, ".git"} {
+		if name == reserved {
+			return fmt.Errorf("framework directory name %q is already used for %s", name, reserved)
+		}
+	}
+	return nil
+}
+
+// readFile decodes a config file into file; a missing file leaves it unchanged
+func readFile(path string, file *fileConfig) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, file); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyFile overrides cfg with the settings of a config file. Plugins run
+// programs, so only the user's own config file, userFile, may configure them;
+// a project's config file comes with the project.
+func applyFile(cfg *Config, path string, userFile bool) error {
+	var file fileConfig
+	if err := readFile(path, &file); err != nil {
+		return err
+	}
+
+	if file.Plugins != nil || file.PluginsDir != "" {
+		if !userFile {
+			return fmt.Errorf("invalid config file %s: plugins and plugins_dir can only be set in the user config file", path)
+		}
+		if file.Plugins != nil {
+			cfg.Plugins = file.Plugins
+		}
+		if file.PluginsDir != "" {
+			cfg.PluginsDir = file.PluginsDir
+		}
+	}
+
+	if file.FrameworkDir != "" {
+		cfg.FrameworkDir = file.FrameworkDir
+	}
+	if file.BackupsDir != "" {
+		cfg.BackupsDir = file.BackupsDir
+	}
+	if file.TrashDir != "" {
+		cfg.TrashDir = file.TrashDir
+	}
+	if file.TempDir != "" {
+		cfg.TempDir = file.TempDir
+	}
+	if file.ScriptHashes != nil {
+		cfg.ScriptHashes = file.ScriptHashes
+	}
+	durations := []struct {
+		key   string
+		value string
+		dest  *time.Duration
+	}{
+		{"git_timeout", file.GitTimeout, &cfg.GitTimeout},
+		{"status_cache_ttl", file.StatusCacheTTL, &cfg.StatusCacheTTL},
+		{"max_backup_age", file.MaxBackupAge, &cfg.MaxBackupAge},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		value, err := time.ParseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s in %s: %w", d.key, path, err)
+		}
+		*d.dest = value
+	}
+	if file.MaxBackups != nil {
+		cfg.MaxBackups = *file.MaxBackups
+	}
+	if file.RequireGitRepo != nil {
+		cfg.RequireGitRepo = *file.RequireGitRepo
+	}
+	if file.TemplateWarnBytes != nil {
+		cfg.TemplateWarnBytes = *file.TemplateWarnBytes
+	}
+	if file.TemplateMaxBytes != nil {
+		cfg.TemplateMaxBytes = *file.TemplateMaxBytes
+	}
+	if file.TemplateWarnFiles != nil {
+		cfg.TemplateWarnFiles = *file.TemplateWarnFiles
+	}
+	if file.TemplateMaxFiles != nil {
+		cfg.TemplateMaxFiles = *file.TemplateMaxFiles
+	}
+	if file.TypedConfirmFiles != nil {
+		cfg.TypedConfirmFiles = *file.TypedConfirmFiles
+	}
+	return nil
+}
+
+// applyEnv overrides cfg with the environment variables that are set
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if name := strings.TrimSpace(getenv(FrameworkDirEnvVar)); name != "" {
+		cfg.FrameworkDir = name
+	}
+	if dir := strings.TrimSpace(getenv(BackupsDirEnvVar)); dir != "" {
+		cfg.BackupsDir = dir
+	}
+	if dir := strings.TrimSpace(getenv(TrashDirEnvVar)); dir != "" {
+		cfg.TrashDir = dir
+	}
+	if dir := strings.TrimSpace(getenv(TempDirEnvVar)); dir != "" {
+		cfg.TempDir = dir
+	}
+	if value := strings.TrimSpace(getenv(GitTimeoutEnvVar)); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid $%s: %w", GitTimeoutEnvVar, err)
+		}
+		cfg.GitTimeout = timeout
+	}
+	// Like other boolean variables, values that are not true turn it off
+	if value := strings.TrimSpace(getenv(RequireGitRepoEnvVar)); value != "" {
+		require, err := strconv.ParseBool(value)
+		cfg.RequireGitRepo = err == nil && require
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useConfig makes cfg current until the test ends
+func useConfig(t *testing.T, cfg Config) {
+	t.Helper()
+
+	previous := Current()
+	if err := SetCurrent(cfg); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	t.Cleanup(func() { current = previous })
+}
+
+func TestValidateFrameworkDirName(t *testing.T) {
+	tests := []struct {
+		name    string
+		dirName string
+		wantErr bool
+	}{
+		{"default", StrategicClaudeBasicDir, false},
+		{"custom", ".ai-framework", false},
+		{"empty", "", true},
+		{"blank", "  ", true},
+		{"dot", ".", true},
+		{"parent", "..", true},
+		{"nested", "a/b", true},
+		{"backslash", `a\b`, true},
+		{"claude directory", ClaudeDir, true},
+		{"backups directory", BackupsDir, true},
+		{"git directory", ".git", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFrameworkDirName(tt.dirName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFrameworkDirName(%q) error = %v, wantErr %v", tt.dirName, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // Not written when empty
+		want    string
+		wantErr bool
+	}{
+		{"missing file", "", StrategicClaudeBasicDir, false},
+		{"framework directory", `{"framework_dir": ".ai-framework"}`, ".ai-framework", false},
+		{"no framework directory", `{}`, StrategicClaudeBasicDir, false},
+		{"invalid name", `{"framework_dir": "../outside"}`, "", true},
+		{"invalid JSON", `{`, "", true},
+		{"plugins", `{"plugins": {"post-install": ["/usr/local/bin/register"]}}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(tt.content), 0644); err != nil {
+					t.Fatalf("Failed to write config: %v", err)
+				}
+			}
+
+			cfg, err := LoadProjectConfig(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadProjectConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.FrameworkDir != tt.want {
+				t.Errorf("LoadProjectConfig().FrameworkDir = %q, want %q", cfg.FrameworkDir, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveProjectFrameworkDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(`{"max_backups": 3}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := SaveProjectFrameworkDir(dir, ".ai-framework"); err != nil {
+		t.Fatalf("SaveProjectFrameworkDir() error = %v", err)
+	}
+
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig() error = %v", err)
+	}
+	if cfg.FrameworkDir != ".ai-framework" {
+		t.Errorf("FrameworkDir = %q, want .ai-framework", cfg.FrameworkDir)
+	}
+	if cfg.MaxBackups != 3 {
+		t.Errorf("MaxBackups = %d, want the 3 set before", cfg.MaxBackups)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	userConfig := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(userConfig, []byte(`{"framework_dir": ".user", "git_timeout": "1m", "max_backups": 5}`), 0644); err != nil {
+		t.Fatalf("Failed to write user config: %v", err)
+	}
+	targetDir := t.TempDir()
+	if err := os.WriteFile(ProjectConfigPath(targetDir), []byte(`{"framework_dir": ".project"}`), 0644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		env            map[string]string
+		userConfig     string
+		wantDir        string
+		wantGitTimeout time.Duration
+		wantErr        bool
+	}{
+		{"project only", nil, filepath.Join(t.TempDir(), "missing.json"), ".project", DefaultGitTimeout, false},
+		{"project over user", nil, userConfig, ".project", time.Minute, false},
+		{"environment over files", map[string]string{FrameworkDirEnvVar: ".env", GitTimeoutEnvVar: "2m"}, userConfig, ".env", 2 * time.Minute, false},
+		{"invalid environment", map[string]string{GitTimeoutEnvVar: "soon"}, userConfig, "", 0, true},
+		{"invalid name", map[string]string{FrameworkDirEnvVar: ".claude"}, userConfig, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(LoadOptions{
+				TargetDir:      targetDir,
+				UserConfigPath: tt.userConfig,
+				Getenv:         func(key string) string { return tt.env[key] },
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.FrameworkDir != tt.wantDir {
+				t.Errorf("FrameworkDir = %q, want %q", cfg.FrameworkDir, tt.wantDir)
+			}
+			if cfg.GitTimeout != tt.wantGitTimeout {
+				t.Errorf("GitTimeout = %v, want %v", cfg.GitTimeout, tt.wantGitTimeout)
+			}
+		})
+	}
+}
+
+func TestFrameworkDir_Symlinks(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FrameworkDir = ".ai-framework"
+	useConfig(t, cfg)
+
+	if got := GetRequiredSymlinks()["agents/strategic"]; got != "../../.ai-framework/core/agents" {
+		t.Errorf("GetRequiredSymlinks()[agents/strategic] = %q, want ../../.ai-framework/core/agents", got)
+	}
+	if got := GetCodexRequiredSymlinks()["hooks/strategic"]; got != "../../.ai-framework/core/hooks" {
+		t.Errorf("GetCodexRequiredSymlinks()[hooks/strategic] = %q, want ../../.ai-framework/core/hooks", got)
+	}
+	if got := GetInstalledPaths()[0]; got != ".ai-framework" {
+		t.Errorf("GetInstalledPaths()[0] = %q, want .ai-framework", got)
+	}
+
+	// Overlays are found in the configured directory
+	targetDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(targetDir, ".ai-framework", OverlayDir, AgentsDir), 0755); err != nil {
+		t.Fatalf("Failed to create overlay: %v", err)
+	}
+	resolved := ResolveSymlinkTargets(targetDir, GetRequiredSymlinks())
+	if got := resolved["agents/strategic"]; got != "../../.ai-framework/"+OverlayDir+"/"+AgentsDir {
+		t.Errorf("ResolveSymlinkTargets()[agents/strategic] = %q, want the overlay", got)
+	}
+}
+
+func TestTempDir(t *testing.T) {
+	useConfig(t, DefaultConfig())
+	if got := TempDir(); got != os.TempDir() {
+		t.Errorf("TempDir() = %q by default, want %q", got, os.TempDir())
+	}
+
+	cfg, err := Load(LoadOptions{
+		TargetDir:      t.TempDir(),
+		UserConfigPath: filepath.Join(t.TempDir(), "missing.json"),
+		Getenv:         func(name string) string { return map[string]string{TempDirEnvVar: "/var/tmp"}[name] },
+	})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	useConfig(t, cfg)
+	if got := TempDir(); got != "/var/tmp" {
+		t.Errorf("TempDir() = %q with $%s set, want /var/tmp", got, TempDirEnvVar)
+	}
+
+	cfg.TempDir = "tmp"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() with a relative temporary directory returned no error")
+	}
+}
+
+func TestSetCurrent_Invalid(t *testing.T) {
+	previous := Current()
+	cfg := DefaultConfig()
+	cfg.FrameworkDir = ".."
+	if err := SetCurrent(cfg); err == nil {
+		t.Error("SetCurrent() with an invalid name returned no error")
+	}
+	if !reflect.DeepEqual(Current(), previous) {
+		t.Errorf("Current() = %+v after a failed SetCurrent(), want %+v", Current(), previous)
+	}
+}
+
+func TestConfig_Validate_ScriptHashes(t *testing.T) {
+	hash := strings.Repeat("0f", 32)
+	tests := []struct {
+		name    string
+		hashes  map[string]string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"both scripts", map[string]string{PreInstallScript: hash, PostInstallScript: hash}, false},
+		{"unknown script", map[string]string{"setup.sh": hash}, true},
+		{"short hash", map[string]string{PostInstallScript: "0f0f"}, true},
+		{"not hexadecimal", map[string]string{PostInstallScript: strings.Repeat("zz", 32)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ScriptHashes = tt.hashes
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_TemplateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"defaults", func(c *Config) {}, false},
+		{"limits off", func(c *Config) { c.TemplateMaxBytes, c.TemplateMaxFiles = 0, 0 }, false},
+		{"negative", func(c *Config) { c.TemplateWarnFiles = -1 }, true},
+		{"warning above maximum size", func(c *Config) { c.TemplateWarnBytes = c.TemplateMaxBytes + 1 }, true},
+		{"warning above maximum files", func(c *Config) { c.TemplateWarnFiles = c.TemplateMaxFiles + 1 }, true},
+		{"typed confirmation off", func(c *Config) { c.TypedConfirmFiles = 0 }, false},
+		{"negative typed confirmation", func(c *Config) { c.TypedConfirmFiles = -1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateInstanceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance string
+		wantErr  bool
+	}{
+		{"simple", "research", false},
+		{"digits and hyphens", "team-2", false},
+		{"empty", "", true},
+		{"uppercase", "Research", true},
+		{"leading hyphen", "-research", true},
+		{"path", "a/b", true},
+		{"default directory", "basic", true},
+		{"backups directory", "basic-backups", true},
+		{"too long", strings.Repeat("a", MaxInstanceNameLen+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInstanceName(tt.instance)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInstanceName(%q) error = %v, wantErr %v", tt.instance, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithInstance(t *testing.T) {
+	useConfig(t, DefaultConfig().WithInstance("research"))
+
+	if got := FrameworkDir(); got != ".strategic-claude-research" {
+		t.Errorf("FrameworkDir() = %q, want .strategic-claude-research", got)
+	}
+	if got := GetRequiredSymlinks()["agents/strategic-research"]; got != "../../.strategic-claude-research/core/agents" {
+		t.Errorf("GetRequiredSymlinks()[agents/strategic-research] = %q, want ../../.strategic-claude-research/core/agents", got)
+	}
+	if _, ok := GetRequiredSymlinks()["agents/strategic"]; ok {
+		t.Error("GetRequiredSymlinks() includes the default installation's link")
+	}
+
+	cfg := DefaultConfig()
+	cfg.Instance = "research"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted an instance outside its own directory")
+	}
+}
+
+func TestFindInstances(t *testing.T) {
+	targetDir := t.TempDir()
+	for _, dir := range []string{
+		StrategicClaudeBasicDir,
+		InstanceDir("research"),
+		InstanceDir("empty"), // No template info
+		InstanceDir("Bad"),
+	} {
+		if err := os.MkdirAll(filepath.Join(targetDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		if dir == InstanceDir("empty") {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(targetDir, dir, TemplateInfoFile), []byte("{}"), 0644); err != nil {
+			t.Fatalf("Failed to write template info: %v", err)
+		}
+	}
+
+	got := FindInstances(targetDir)
+	if len(got) != 1 || got[0] != "research" {
+		t.Errorf("FindInstances() = %v, want [research]", got)
+	}
+}
@@ -0,0 +1,204 @@
+package fsys
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMemory_Files(t *testing.T) {
+	m := NewMemory()
+
+	if err := m.WriteFile("/project/file.txt", []byte("x"), 0644); !os.IsNotExist(err) {
+		t.Errorf("WriteFile() without parent error = %v, want not exist", err)
+	}
+	if err := m.MkdirAll("/project/docs", 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := m.WriteFile("/project/docs/a.md", []byte("alpha"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	w, err := m.Create("/project/docs/b.md", 0600)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := io.WriteString(w, "beta"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := m.ReadFile("/project/docs/b.md")
+	if err != nil || string(data) != "beta" {
+		t.Errorf("ReadFile() = %q, %v, want %q", data, err, "beta")
+	}
+	info, err := m.Stat("/project/docs/b.md")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Mode().Perm() != 0600 || info.Size() != 4 {
+		t.Errorf("Stat() mode = %v size = %d, want 0600 and 4", info.Mode(), info.Size())
+	}
+
+	if err := m.Chmod("/project/docs/b.md", 0644); err != nil {
+		t.Fatalf("Chmod() error = %v", err)
+	}
+	if info, _ := m.Stat("/project/docs/b.md"); info.Mode().Perm() != 0644 {
+		t.Errorf("Chmod() mode = %v, want 0644", info.Mode().Perm())
+	}
+
+	if err := m.Remove("/project/docs"); err == nil {
+		t.Error("Remove() of a non-empty directory succeeded")
+	}
+	if err := m.RemoveAll("/project/docs"); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+	if _, err := m.Stat("/project/docs/a.md"); !os.IsNotExist(err) {
+		t.Errorf("Stat() after RemoveAll() error = %v, want not exist", err)
+	}
+}
+
+func TestMemory_Symlinks(t *testing.T) {
+	m := NewMemory()
+	if err := m.MkdirAll("/project/core/agents", 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := m.WriteFile("/project/core/agents/planner.md", []byte("plan"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := m.MkdirAll("/project/.claude", 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := m.Symlink("../core/agents", "/project/.claude/agents"); err != nil {
+		t.Fatalf("Symlink() error = %v", err)
+	}
+	if err := m.Symlink("elsewhere", "/project/.claude/agents"); !os.IsExist(err) {
+		t.Errorf("Symlink() over an existing path error = %v, want exists", err)
+	}
+
+	info, err := m.Lstat("/project/.claude/agents")
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Lstat() = %v, %v, want a symlink", info, err)
+	}
+	if target, err := m.Readlink("/project/.claude/agents"); err != nil || target != "../core/agents" {
+		t.Errorf("Readlink() = %q, %v, want %q", target, err, "../core/agents")
+	}
+	if info, err := m.Stat("/project/.claude/agents"); err != nil || !info.IsDir() {
+		t.Errorf("Stat() = %v, %v, want the linked directory", info, err)
+	}
+	if data, err := m.ReadFile("/project/.claude/agents/planner.md"); err != nil || string(data) != "plan" {
+		t.Errorf("ReadFile() through symlink = %q, %v, want %q", data, err, "plan")
+	}
+
+	if err := m.Symlink("loop", "/project/loop"); err != nil {
+		t.Fatalf("Symlink() error = %v", err)
+	}
+	if _, err := m.Stat("/project/loop"); err == nil {
+		t.Error("Stat() of a symlink loop succeeded")
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	baseDir := t.TempDir()
+	for path, content := range map[string]string{
+		"keep.txt":       "keep",
+		"change.txt":     "before",
+		"gone/inner.txt": "inner",
+	} {
+		full := filepath.Join(baseDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	overlay := NewOverlay(OS())
+	if err := overlay.WriteFile(filepath.Join(baseDir, "change.txt"), []byte("after"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := overlay.WriteFile(filepath.Join(baseDir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := overlay.RemoveAll(filepath.Join(baseDir, "gone")); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+
+	entries, err := overlay.ReadDir(baseDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"change.txt", "keep.txt", "new.txt"}; !slices.Equal(names, want) {
+		t.Errorf("ReadDir() = %v, want %v", names, want)
+	}
+
+	if data, _ := overlay.ReadFile(filepath.Join(baseDir, "keep.txt")); string(data) != "keep" {
+		t.Errorf("ReadFile() of base file = %q, want %q", data, "keep")
+	}
+	if data, _ := overlay.ReadFile(filepath.Join(baseDir, "change.txt")); string(data) != "after" {
+		t.Errorf("ReadFile() of changed file = %q, want %q", data, "after")
+	}
+
+	// The disk is untouched
+	if data, _ := os.ReadFile(filepath.Join(baseDir, "change.txt")); string(data) != "before" {
+		t.Errorf("base change.txt = %q, want %q", data, "before")
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "gone", "inner.txt")); err != nil {
+		t.Errorf("base gone/inner.txt was removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("new.txt was written to the base: %v", err)
+	}
+
+	// A removed directory recreated in the overlay does not show the base content
+	if err := overlay.MkdirAll(filepath.Join(baseDir, "gone"), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if entries, err := overlay.ReadDir(filepath.Join(baseDir, "gone")); err != nil || len(entries) != 0 {
+		t.Errorf("ReadDir() of recreated directory = %v, %v, want empty", entries, err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	m := NewMemory()
+	for _, dir := range []string{"/root/b", "/root/a/inner"} {
+		if err := m.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+	}
+	if err := m.WriteFile("/root/a/inner/file", nil, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := m.Symlink("/root/a", "/root/link"); err != nil {
+		t.Fatalf("Symlink() error = %v", err)
+	}
+
+	var visited []string
+	err := Walk(m, "/root", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Name() == "b" {
+			return filepath.SkipDir
+		}
+		visited = append(visited, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	want := []string{"/root", "/root/a", "/root/a/inner", "/root/a/inner/file", "/root/link"}
+	if !slices.Equal(visited, want) {
+		t.Errorf("Walk() visited %v, want %v", visited, want)
+	}
+}
@@ -0,0 +1,504 @@
+package fsys
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSymlinks bounds the symlinks followed while resolving one path
+const maxSymlinks = 40
+
+var (
+	errNotDir     = errors.New("not a directory")
+	errIsDir      = errors.New("is a directory")
+	errNotEmpty   = errors.New("directory not empty")
+	errNotSymlink = errors.New("not a symbolic link")
+	errLoop       = errors.New("too many levels of symbolic links")
+)
+
+// node is a file, directory or symlink held in memory
+type node struct {
+	mode    fs.FileMode // Type bits and permissions
+	data    []byte
+	target  string // Symlink target
+	modTime time.Time
+}
+
+// Memory is a file system held in memory. With a base it is an overlay: reads
+// fall through to the base until a path is written or removed, and the base
+// itself is never modified. The root directory always exists.
+type Memory struct {
+	mu     sync.Mutex
+	base   FS
+	nodes  map[string]*node
+	hidden map[string]bool // Base paths removed in the overlay, with everything below them
+}
+
+// NewMemory returns an empty in-memory file system
+func NewMemory() *Memory {
+	return &Memory{
+		nodes:  make(map[string]*node),
+		hidden: make(map[string]bool),
+	}
+}
+
+// NewOverlay returns an in-memory file system over base, so operations can
+// run against real content without changing it, e.g. for dry runs
+func NewOverlay(base FS) *Memory {
+	m := NewMemory()
+	m.base = base
+	return m
+}
+
+func (m *Memory) Lstat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), false, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "lstat", Path: name, Err: err}
+	}
+	_, info, err := m.lookup(path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "lstat", Path: name, Err: fs.ErrNotExist}
+	}
+	return info, nil
+}
+
+func (m *Memory) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	_, info, err := m.lookup(path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	// Like os.Stat, a followed symlink is described under its own name
+	return fileInfo{name: filepath.Base(name), size: info.Size(), mode: info.Mode(), modTime: info.ModTime()}, nil
+}
+
+func (m *Memory) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	n, info, err := m.lookup(path)
+	switch {
+	case err != nil:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case info.IsDir():
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+	case n != nil:
+		return bytes.Clone(n.data), nil
+	default:
+		return m.base.ReadFile(path)
+	}
+}
+
+func (m *Memory) Open(name string) (io.ReadCloser, error) {
+	data, err := m.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *Memory) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	_, info, err := m.lookup(path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: errNotDir}
+	}
+
+	entries := make(map[string]fs.DirEntry)
+	if m.base != nil && !m.isHidden(path) {
+		if baseEntries, err := m.base.ReadDir(path); err == nil {
+			for _, entry := range baseEntries {
+				if !m.hidden[filepath.Join(path, entry.Name())] {
+					entries[entry.Name()] = entry
+				}
+			}
+		}
+	}
+	for childPath, n := range m.nodes {
+		if filepath.Dir(childPath) == path && childPath != path {
+			entries[filepath.Base(childPath)] = fs.FileInfoToDirEntry(n.info(childPath))
+		}
+	}
+
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name() < result[j].Name() })
+	return result, nil
+}
+
+func (m *Memory) Readlink(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), false, 0)
+	if err != nil {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: err}
+	}
+	n, info, err := m.lookup(path)
+	switch {
+	case err != nil:
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrNotExist}
+	case info.Mode()&fs.ModeSymlink == 0:
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: errNotSymlink}
+	case n != nil:
+		return n.target, nil
+	default:
+		return m.base.Readlink(path)
+	}
+}
+
+func (m *Memory) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	// Fail early like os.Create would, then write the content on Close
+	if err := m.WriteFile(name, nil, perm); err != nil {
+		return nil, err
+	}
+	return &memoryWriter{m: m, name: name, perm: perm}, nil
+}
+
+func (m *Memory) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if err := m.requireDir(filepath.Dir(path)); err != nil {
+		return &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	// Like os.WriteFile, an existing file keeps its permissions
+	mode := perm.Perm()
+	if _, info, err := m.lookup(path); err == nil {
+		if info.IsDir() {
+			return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+		}
+		mode = info.Mode().Perm()
+	}
+
+	m.nodes[path] = &node{mode: mode, data: bytes.Clone(data), modTime: time.Now()}
+	return nil
+}
+
+func (m *Memory) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: err}
+	}
+	return m.mkdirAll(path, perm)
+}
+
+// mkdirAll creates path and its missing parents; symlinks in path are resolved
+func (m *Memory) mkdirAll(path string, perm fs.FileMode) error {
+	if _, info, err := m.lookup(path); err == nil {
+		if info.IsDir() {
+			return nil
+		}
+		return &fs.PathError{Op: "mkdir", Path: path, Err: errNotDir}
+	}
+
+	if parent := filepath.Dir(path); parent != path {
+		if err := m.mkdirAll(parent, perm); err != nil {
+			return err
+		}
+	}
+	m.nodes[path] = &node{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+func (m *Memory) Symlink(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(newname), false, 0)
+	if err != nil {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: err}
+	}
+	if _, _, err := m.lookup(path); err == nil {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: fs.ErrExist}
+	}
+	if err := m.requireDir(filepath.Dir(path)); err != nil {
+		return &fs.PathError{Op: "symlink", Path: newname, Err: err}
+	}
+
+	m.nodes[path] = &node{mode: fs.ModeSymlink | 0777, target: oldname, modTime: time.Now()}
+	return nil
+}
+
+func (m *Memory) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), false, 0)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	_, info, err := m.lookup(path)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if info.IsDir() && m.hasChildren(path) {
+		return &fs.PathError{Op: "remove", Path: name, Err: errNotEmpty}
+	}
+
+	m.remove(path)
+	return nil
+}
+
+func (m *Memory) RemoveAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), false, 0)
+	if err != nil {
+		return &fs.PathError{Op: "unlinkat", Path: name, Err: err}
+	}
+	m.remove(path)
+	return nil
+}
+
+func (m *Memory) Chmod(name string, mode fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.resolve(absPath(name), true, 0)
+	if err != nil {
+		return &fs.PathError{Op: "chmod", Path: name, Err: err}
+	}
+	n, err := m.copyUp(path)
+	if err != nil {
+		return &fs.PathError{Op: "chmod", Path: name, Err: err}
+	}
+	n.mode = n.mode.Type() | mode.Perm()
+	return nil
+}
+
+// Lchown only checks that the path exists; ownership is not modeled in memory
+func (m *Memory) Lchown(name string, uid, gid int) error {
+	if _, err := m.Lstat(name); err != nil {
+		return &fs.PathError{Op: "lchown", Path: name, Err: fs.ErrNotExist}
+	}
+	return nil
+}
+
+// lookup returns the node at a resolved path, or its base information when it
+// is only in the base. The node is nil for base entries and the root.
+func (m *Memory) lookup(path string) (*node, fs.FileInfo, error) {
+	if n, ok := m.nodes[path]; ok {
+		return n, n.info(path), nil
+	}
+	if m.base == nil || m.isHidden(path) {
+		if filepath.Dir(path) == path {
+			return nil, fileInfo{name: path, mode: fs.ModeDir | 0755}, nil
+		}
+		return nil, nil, fs.ErrNotExist
+	}
+	info, err := m.base.Lstat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, info, nil
+}
+
+// resolve returns path with the symlinks in its directories resolved, and the
+// last element too when followLast is set. Missing paths resolve to themselves.
+func (m *Memory) resolve(path string, followLast bool, depth int) (string, error) {
+	if depth > maxSymlinks {
+		return "", errLoop
+	}
+
+	if dir := filepath.Dir(path); dir != path {
+		resolvedDir, err := m.resolve(dir, true, depth)
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(resolvedDir, filepath.Base(path))
+	}
+	if !followLast {
+		return path, nil
+	}
+
+	n, info, err := m.lookup(path)
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return path, nil
+	}
+
+	target := ""
+	if n != nil {
+		target = n.target
+	} else if target, err = m.base.Readlink(path); err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return m.resolve(filepath.Clean(target), true, depth+1)
+}
+
+// requireDir checks that a resolved path is an existing directory
+func (m *Memory) requireDir(path string) error {
+	_, info, err := m.lookup(path)
+	if err != nil {
+		return fs.ErrNotExist
+	}
+	if !info.IsDir() {
+		return errNotDir
+	}
+	return nil
+}
+
+// isHidden reports whether path or one of its parents was removed from the base
+func (m *Memory) isHidden(path string) bool {
+	for {
+		if m.hidden[path] {
+			return true
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+}
+
+// hasChildren reports whether a resolved directory has entries
+func (m *Memory) hasChildren(path string) bool {
+	for childPath := range m.nodes {
+		if filepath.Dir(childPath) == path && childPath != path {
+			return true
+		}
+	}
+	if m.base != nil && !m.isHidden(path) {
+		entries, err := m.base.ReadDir(path)
+		if err == nil {
+			for _, entry := range entries {
+				if !m.hidden[filepath.Join(path, entry.Name())] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// remove deletes a resolved path and everything below it
+func (m *Memory) remove(path string) {
+	prefix := path + string(filepath.Separator)
+	for nodePath := range m.nodes {
+		if nodePath == path || strings.HasPrefix(nodePath, prefix) {
+			delete(m.nodes, nodePath)
+		}
+	}
+	if m.base != nil {
+		m.hidden[path] = true
+	}
+}
+
+// copyUp returns the node at a resolved path, copying a base entry into memory first
+func (m *Memory) copyUp(path string) (*node, error) {
+	n, info, err := m.lookup(path)
+	if err != nil {
+		return nil, fs.ErrNotExist
+	}
+	if n != nil {
+		return n, nil
+	}
+	if m.base == nil {
+		// The root of a memory file system; give it a node so it can be modified
+		n = &node{mode: info.Mode(), modTime: time.Now()}
+		m.nodes[path] = n
+		return n, nil
+	}
+
+	n = &node{mode: info.Mode(), modTime: info.ModTime()}
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		if n.target, err = m.base.Readlink(path); err != nil {
+			return nil, err
+		}
+	case info.Mode().IsRegular():
+		if n.data, err = m.base.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	m.nodes[path] = n
+	return n, nil
+}
+
+// info describes the node at path
+func (n *node) info(path string) fs.FileInfo {
+	return fileInfo{name: filepath.Base(path), size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+// fileInfo implements fs.FileInfo for memory nodes
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() fs.FileMode  { return i.mode }
+func (i fileInfo) ModTime() time.Time { return i.modTime }
+func (i fileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i fileInfo) Sys() any           { return nil }
+
+// memoryWriter buffers a file created in memory until it is closed
+type memoryWriter struct {
+	m    *Memory
+	name string
+	perm fs.FileMode
+	buf  bytes.Buffer
+}
+
+func (w *memoryWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *memoryWriter) Close() error {
+	return w.m.WriteFile(w.name, w.buf.Bytes(), w.perm)
+}
+
+// absPath makes name absolute and clean, keeping it unchanged if that fails
+func absPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
@@ -0,0 +1,174 @@
+package i18n
+
+// spanish translates prompts, summaries and other messages of the commands
+var spanish = map[string]string{
+	// Messages
+	"Error":                             "Error",
+	"(y/N)":                             "(s/N)",
+	"Are you sure you want to proceed?": "¿Seguro que quiere continuar?",
+	"This will install Strategic Claude Basic in the above directory.\nAre you sure you want to proceed?": "Se instalará Strategic Claude Basic en el directorio indicado.\n¿Seguro que quiere continuar?",
+	"Installation cancelled by user":                                "Instalación cancelada por el usuario",
+	"Cleanup cancelled by user":                                     "Limpieza cancelada por el usuario",
+	"MCP installation cancelled by user":                            "Instalación de MCP cancelada por el usuario",
+	"Cache clean cancelled by user":                                 "Limpieza de la caché cancelada por el usuario",
+	"Remove all cached templates in %s?":                            "¿Eliminar todas las plantillas en caché de %s?",
+	"Do you want to proceed with MCP server installation?":          "¿Quiere continuar con la instalación de los servidores MCP?",
+	"This will modify your project's .mcp.json configuration file.": "Se modificará el archivo de configuración .mcp.json del proyecto.",
+	"Existing configuration will be backed up before modification.": "Se hará una copia de seguridad de la configuración existente antes de modificarla.",
+
+	// Cleanup
+	"This will remove Strategic Claude Basic from: %s": "Se eliminará Strategic Claude Basic de: %s",
+	"This action will:":       "Esta acción:",
+	"Remove the %s directory": "Eliminará el directorio %s",
+	"Remove Strategic Claude symlinks from .claude directory":                "Eliminará los enlaces simbólicos de Strategic Claude del directorio .claude",
+	"Remove all backups, the project config file and the .gitignore entries": "Eliminará todas las copias de seguridad, el archivo de configuración del proyecto y las entradas de .gitignore",
+	"Preserve any user-created content in .claude":                           "Conservará el contenido creado por el usuario en .claude",
+	"%s holds %d user document(s) (%s) that will be removed with it":         "%s contiene %d documento(s) del usuario (%s) que se eliminarán con él",
+	"Export them with export-user-content first?":                            "¿Exportarlos antes con export-user-content?",
+	"Remove the %d user document(s)?":                                        "¿Eliminar los %d documento(s) del usuario?",
+	"%d files will be replaced or removed.":                                  "Se reemplazarán o eliminarán %d archivos.",
+	"Type %s to confirm":                                                     "Escriba %s para confirmar",
+
+	// Cleanup results
+	"Moved %d path(s) to the trash instead of deleting them":                      "Se movieron %d ruta(s) a la papelera en lugar de eliminarlas",
+	"Removed %d Strategic Claude symlink(s)":                                      "Se eliminaron %d enlace(s) simbólico(s) de Strategic Claude",
+	"Removed %s directory":                                                        "Se eliminó el directorio %s",
+	"Removed %s/%s, which was empty after removing the framework hooks":           "Se eliminó %s/%s, que quedó vacío tras quitar los hooks del framework",
+	"Removed the framework hooks from %s/%s":                                      "Se quitaron los hooks del framework de %s/%s",
+	"Removed %d user document(s) with %s":                                         "Se eliminaron %d documento(s) del usuario con %s",
+	"Removed Cursor rules":                                                        "Se eliminaron las reglas de Cursor",
+	"Removed the Strategic Claude Basic block from .envrc":                        "Se quitó el bloque de Strategic Claude Basic de .envrc",
+	"Removed framework conventions from %s":                                       "Se quitaron las convenciones del framework de %s",
+	"Removed backups":                                                             "Se eliminaron las copias de seguridad",
+	"Next steps:":                                                                 "Próximos pasos:",
+	"Removed %d left-over file(s)":                                                "Se eliminaron %d archivo(s) sobrante(s)",
+	"Cleaned up %d empty director(ies)":                                           "Se eliminaron %d directorio(s) vacío(s)",
+	"Preserved %d user file(s)":                                                   "Se conservaron %d archivo(s) del usuario",
+	"No Strategic Claude Basic installation found to clean":                       "No se encontró ninguna instalación de Strategic Claude Basic que limpiar",
+	"Strategic Claude Basic cleanup completed successfully":                       "La limpieza de Strategic Claude Basic se completó correctamente",
+	"cleanup completed with errors":                                               "la limpieza terminó con errores",
+	"Run 'clean' again to remove what remains":                                    "Vuelva a ejecutar 'clean' para eliminar lo que queda",
+	"Review %s, which keeps your own settings":                                    "Revise %s, que conserva sus propios ajustes",
+	"Review the preserved files; they were not created by Strategic Claude Basic": "Revise los archivos conservados; no los creó Strategic Claude Basic",
+	"Backups are kept in %s; run 'clean --all' to remove them":                    "Las copias de seguridad se conservan en %s; ejecute 'clean --all' para eliminarlas",
+	"%d user document(s) were removed with %s; if you exported them with 'export-user-content', restore them after reinstalling with 'import-user-content'": "Se eliminaron %d documento(s) del usuario con %s; si los exportó con 'export-user-content', restáurelos tras reinstalar con 'import-user-content'",
+
+	// Installation
+	"Installing Strategic Claude Basic in %s...":                               "Instalando Strategic Claude Basic en %s...",
+	"Strategic Claude Basic installation completed successfully!":              "¡La instalación de Strategic Claude Basic se completó correctamente!",
+	"Strategic Claude Basic has been installed!":                               "¡Strategic Claude Basic se ha instalado!",
+	"Use 'strategic-claude-basic-cli status %s' to check installation status.": "Use 'strategic-claude-basic-cli status %s' para comprobar el estado de la instalación.",
+	"Run 'direnv allow' to load the updated .envrc.":                           "Ejecute 'direnv allow' para cargar el .envrc actualizado.",
+
+	// Status
+	"Strategic Claude Basic is not installed":                      "Strategic Claude Basic no está instalado",
+	"Strategic Claude Basic is installed but has %d issue(s)":      "Strategic Claude Basic está instalado pero tiene %d problema(s)",
+	"Strategic Claude Basic is installed and configured correctly": "Strategic Claude Basic está instalado y configurado correctamente",
+	"Directories:":                           "Directorios:",
+	"Strategic Claude Basic: %s":             "Strategic Claude Basic: %s",
+	"Strategic Claude Basic: %s (not found)": "Strategic Claude Basic: %s (no encontrado)",
+	"Dev mode: framework linked from %s":     "Modo de desarrollo: framework enlazado desde %s",
+	"Claude Integration: %s":                 "Integración con Claude: %s",
+	"Claude Integration: %s (not found)":     "Integración con Claude: %s (no encontrada)",
+	"Cursor Rules: %s (%s, %d rules)":        "Reglas de Cursor: %s (%s, %d reglas)",
+	"Cursor Rules: %s (%s, needs attention)": "Reglas de Cursor: %s (%s, requieren atención)",
+	"Instances:":                             "Instancias:",
+	"Checked: %s":                            "Comprobada: %s",
+	"%s (check with --instance %s)":          "%s (compruébela con --instance %s)",
+	"Template Information:":                  "Información de la plantilla:",
+	"Name: %s":                               "Nombre: %s",
+	"ID: %s":                                 "ID: %s",
+	"Description: %s":                        "Descripción: %s",
+	"Branch: %s":                             "Rama: %s",
+	"Commit: %s":                             "Commit: %s",
+	"Installed At: %s":                       "Instalada el: %s",
+	"Language: %s":                           "Lenguaje: %s",
+	"Tags: %v":                               "Etiquetas: %v",
+	"Symlinks:":                              "Enlaces simbólicos:",
+	"%s (not found)":                         "%s (no encontrado)",
+	"Hooks:":                                 "Hooks:",
+	"%d hook(s) ready to run":                "%d hook(s) listo(s) para ejecutarse",
+	"Backups:":                               "Copias de seguridad:",
+	"%d backup(s), %s in %s":                 "%d copia(s) de seguridad, %s en %s",
+	"Newest: %s (%s)":                        "Más reciente: %s (%s)",
+	"Oldest: %s (%s)":                        "Más antigua: %s (%s)",
+	"Consider removing old backups with '%s backup prune'": "Considere eliminar las copias antiguas con '%s backup prune'",
+	"Errors":   "Errores",
+	"Warnings": "Advertencias",
+	"Info":     "Información",
+	"Drift:":   "Cambios locales:",
+	"modified": "modificado",
+	"missing":  "no existe",
+	"Compared all %d framework files with the install manifest":                         "Se compararon los %d archivos del framework con el manifiesto de instalación",
+	"Compared %d of %d framework files with the install manifest (--deep compares all)": "Se compararon %d de %d archivos del framework con el manifiesto de instalación (--deep los compara todos)",
+	"Detailed Information:":                     "Información detallada:",
+	"Target Directory: %s":                      "Directorio de destino: %s",
+	"Valid Symlinks: %d/%d":                     "Enlaces simbólicos válidos: %d/%d",
+	"Installation Date: %s":                     "Fecha de instalación: %s",
+	"Version: %s":                               "Versión: %s",
+	"Commit Hash: %s":                           "Hash del commit: %s",
+	"To install Strategic Claude Basic, run:":   "Para instalar Strategic Claude Basic, ejecute:",
+	"To fix issues, you may need to:":           "Para corregir los problemas, puede que tenga que:",
+	"Run '%s clean' to remove the installation": "Ejecutar '%s clean' para eliminar la instalación",
+	"Then run '%s init' to reinstall":           "Después ejecutar '%s init' para reinstalar",
+	"yes":                                       "sí",
+	"no":                                        "no",
+	"NAME":                                      "NOMBRE",
+	"EXISTS":                                    "EXISTE",
+	"POINTS TO":                                 "APUNTA A",
+	"TARGET EXISTS":                             "DESTINO EXISTE",
+	"EXPECTED":                                  "ESPERADO",
+	"PROJECT\tTEMPLATE\tSTATE\tDETAIL":          "PROYECTO\tPLANTILLA\tESTADO\tDETALLE",
+	"%d project(s) checked":                     "%d proyecto(s) comprobado(s)",
+	"healthy":                                   "correcto",
+	"issues":                                    "problemas",
+	"drift":                                     "cambios locales",
+	"not installed":                             "no instalado",
+	"error":                                     "error",
+
+	// Selectors
+	"Strategic Claude Basic Is Already Installed":                                           "Strategic Claude Basic ya está instalado",
+	"%s already has an installation. Choose how to proceed:":                                "%s ya tiene una instalación. Elija cómo continuar:",
+	"%s already has a Strategic Claude Basic installation. Choose how to proceed:":          "%s ya tiene una instalación de Strategic Claude Basic. Elija cómo continuar:",
+	"Update core files (--force-core)":                                                      "Actualizar los archivos del núcleo (--force-core)",
+	"Replace core, guides and templates; keep your documents and settings":                  "Reemplaza el núcleo, las guías y las plantillas; conserva sus documentos y ajustes",
+	"Overwrite everything (--force)":                                                        "Sobrescribir todo (--force)",
+	"Replace the whole framework directory, user content included; a backup is taken first": "Reemplaza todo el directorio del framework, incluido el contenido del usuario; antes se hace una copia de seguridad",
+	"Cancel":                          "Cancelar",
+	"Leave the installation as it is": "Deja la instalación como está",
+	"Select an option (1-%d)":         "Seleccione una opción (1-%d)",
+	"Invalid selection. Please enter a number between 1 and %d.": "Selección no válida. Introduzca un número entre 1 y %d.",
+	"Invalid selection: %v": "Selección no válida: %v",
+	"Selected: %s":          "Seleccionado: %s",
+	"Selected: %s (%s)":     "Seleccionado: %s (%s)",
+	"Interactive mode failed (%v), falling back to simple mode...":                                "El modo interactivo falló (%v); se usa el modo simple...",
+	"Select User Content to Keep":                                                                 "Seleccione el contenido del usuario que desea conservar",
+	"--force replaces the framework directory of %s; the checked directories are carried over:":   "--force reemplaza el directorio del framework de %s; se conservan los directorios marcados:",
+	"--force replaces the framework directory of %s; the listed directories can be carried over:": "--force reemplaza el directorio del framework de %s; se pueden conservar los directorios indicados:",
+	"%s (%d documents)": "%s (%d documentos)",
+	"Directories to keep by number or name (comma-separated, none for none)": "Directorios que conservar por número o nombre (separados por comas, none para ninguno)",
+	"↑/↓: navigate • enter: select • q: cancel":                              "↑/↓: navegar • intro: seleccionar • q: cancelar",
+	"↑/↓: navigate • enter: select • q: quit":                                "↑/↓: navegar • intro: seleccionar • q: salir",
+	"↑/↓: navigate • space: toggle • enter: confirm • q: quit":               "↑/↓: navegar • espacio: marcar • intro: confirmar • q: salir",
+
+	// Summaries
+	"PROJECT\tTEMPLATE\tRESULT\tDETAIL": "PROYECTO\tPLANTILLA\tRESULTADO\tDETALLE",
+	"%d project(s): %s":                 "%d proyecto(s): %s",
+	"updated":                           "actualizado",
+	"update available":                  "actualización disponible",
+	"up to date":                        "al día",
+	"skipped":                           "omitido",
+	"failed":                            "fallido",
+	"just now":                          "justo ahora",
+	"1 minute ago":                      "hace 1 minuto",
+	"%d minutes ago":                    "hace %d minutos",
+	"1 hour ago":                        "hace 1 hora",
+	"%d hours ago":                      "hace %d horas",
+	"1 day ago":                         "hace 1 día",
+	"%d days ago":                       "hace %d días",
+
+	// Error remediation
+	"To resolve it:":    "Para resolverlo:",
+	"See README.md: %s": "Consulte README.md: %s",
+	"Run 'strategic-claude-basic-cli errors explain %s' for details.": "Ejecute 'strategic-claude-basic-cli errors explain %s' para más detalles.",
+}
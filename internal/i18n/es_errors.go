@@ -0,0 +1,164 @@
+package i18n
+
+// spanishErrors translates the descriptions, messages and remediation steps
+// of the error codes explained by errors explain
+var spanishErrors = map[string]string{
+	"The template repository could not be cloned.":                                                          "No se pudo clonar el repositorio de la plantilla.",
+	"Failed to download the Strategic Claude Basic repository. Please check your internet connection.":      "No se pudo descargar el repositorio de Strategic Claude Basic. Compruebe su conexión a internet.",
+	"Check that the template repository is reachable: git ls-remote <repository-url>":                       "Compruebe que el repositorio de la plantilla es accesible: git ls-remote <repository-url>",
+	"Retry with --verbose to see the git output":                                                            "Vuelva a intentarlo con --verbose para ver la salida de git",
+	"Install offline from a bundle created elsewhere with 'bundle create', using init --from-bundle":        "Instale sin conexión desde un paquete creado en otro equipo con 'bundle create', usando init --from-bundle",
+	"The template's pinned commit could not be checked out.":                                                "No se pudo hacer checkout del commit fijado de la plantilla.",
+	"Failed to checkout the specified commit. The repository may be corrupted or the commit may not exist.": "No se pudo hacer checkout del commit indicado. Puede que el repositorio esté dañado o que el commit no exista.",
+	"Clear the cached clone with 'cache clean' and try again":                                               "Borre el clon en caché con 'cache clean' y vuelva a intentarlo",
+	"Check that the pinned commit still exists in the template repository":                                  "Compruebe que el commit fijado sigue existiendo en el repositorio de la plantilla",
+	"The git executable was not found.":                                                                     "No se encontró el ejecutable de git.",
+	"Git is not installed or not available in PATH. Please install Git and try again.":                      "Git no está instalado o no está disponible en el PATH. Instale Git y vuelva a intentarlo.",
+	"Install git from https://git-scm.com/downloads or your package manager":                                "Instale git desde https://git-scm.com/downloads o con su gestor de paquetes",
+	"Check that 'git --version' works in the same shell":                                                    "Compruebe que 'git --version' funciona en la misma shell",
+	"Install from a bundle with init --from-bundle if git cannot be installed":                              "Instale desde un paquete con init --from-bundle si no puede instalar git",
+	"The git executable was not found in PATH.":                                                             "No se encontró el ejecutable de git en el PATH.",
+	"Add the directory containing git to PATH":                                                              "Añada al PATH el directorio que contiene git",
+	"Cloning the template repository failed.":                                                               "Falló la clonación del repositorio de la plantilla.",
+	"Check your internet connection and proxy settings":                                                     "Compruebe su conexión a internet y la configuración del proxy",
+	"Install offline from a bundle with init --from-bundle":                                                 "Instale sin conexión desde un paquete con init --from-bundle",
+	"Checking out the template's pinned commit failed.":                                                     "Falló el checkout del commit fijado de la plantilla.",
+	"A git command failed.": "Falló un comando de git.",
+	"A git operation failed. Please ensure the repository is valid and try again.":      "Falló una operación de git. Asegúrese de que el repositorio es válido y vuelva a intentarlo.",
+	"Clear the cached clone with 'cache clean' if the failure is in the template cache": "Borre el clon en caché con 'cache clean' si el fallo está en la caché de plantillas",
+	"The template's pinned commit does not exist in the repository.":                    "El commit fijado de la plantilla no existe en el repositorio.",
+	"The specified commit was not found in the repository.":                             "No se encontró el commit indicado en el repositorio.",
+	"Update the CLI; the template registry may pin a commit that was rewritten":         "Actualice la CLI; puede que el registro de plantillas fije un commit que se reescribió",
+	"Clear the cached clone with 'cache clean' so the repository is fetched again":      "Borre el clon en caché con 'cache clean' para que el repositorio se descargue de nuevo",
+	"The template repository rejected the credentials.":                                 "El repositorio de la plantilla rechazó las credenciales.",
+	"Authentication to the template repository failed. Provide a token with --auth-token or SCB_GIT_TOKEN, configure a git credential helper, or load your SSH key into ssh-agent.": "Falló la autenticación en el repositorio de la plantilla. Indique un token con --auth-token o SCB_GIT_TOKEN, configure un credential helper de git o cargue su clave SSH en ssh-agent.",
+	"Pass a token with --auth-token or export SCB_GIT_TOKEN":                                                       "Indique un token con --auth-token o exporte SCB_GIT_TOKEN",
+	"Configure a git credential helper: git config --global credential.helper store":                               "Configure un credential helper de git: git config --global credential.helper store",
+	"Load your SSH key: ssh-add ~/.ssh/id_ed25519":                                                                 "Cargue su clave SSH: ssh-add ~/.ssh/id_ed25519",
+	"init --require-git-repo was used outside a git repository.":                                                   "Se usó init --require-git-repo fuera de un repositorio git.",
+	"The target directory is not inside a git repository. Run 'git init' first or drop --require-git-repo.":        "El directorio de destino no está dentro de un repositorio git. Ejecute antes 'git init' o quite --require-git-repo.",
+	"Run 'git init' in the project directory":                                                                      "Ejecute 'git init' en el directorio del proyecto",
+	"Drop --require-git-repo to install anyway":                                                                    "Quite --require-git-repo para instalar de todos modos",
+	"Reading or writing a file or directory failed.":                                                               "Falló la lectura o escritura de un archivo o directorio.",
+	"Check the path named in the error exists and is writable":                                                     "Compruebe que la ruta indicada en el error existe y admite escritura",
+	"Check that the disk is not full":                                                                              "Compruebe que el disco no está lleno",
+	"Run 'doctor' to report permission problems in the installation":                                               "Ejecute 'doctor' para detectar problemas de permisos en la instalación",
+	"A directory the command needs does not exist.":                                                                "No existe un directorio que el comando necesita.",
+	"The specified directory does not exist.":                                                                      "El directorio indicado no existe.",
+	"Check the --target directory":                                                                                 "Compruebe el directorio de --target",
+	"Create the directory first: mkdir -p <directory>":                                                             "Cree antes el directorio: mkdir -p <directory>",
+	"A directory that must be empty has content.":                                                                  "Un directorio que debe estar vacío tiene contenido.",
+	"Choose an empty or new directory":                                                                             "Elija un directorio vacío o nuevo",
+	"Move the existing content elsewhere and try again":                                                            "Mueva el contenido existente a otro lugar y vuelva a intentarlo",
+	"The current user may not write to the target.":                                                                "El usuario actual no puede escribir en el destino.",
+	"Permission denied. Please check that you have write permissions to the target directory.":                     "Permiso denegado. Compruebe que tiene permisos de escritura en el directorio de destino.",
+	"Check the ownership of the project: ls -la":                                                                   "Compruebe el propietario del proyecto: ls -la",
+	"Run 'doctor --fix-permissions' to reset permissions in the installation":                                      "Ejecute 'doctor --fix-permissions' para restablecer los permisos de la instalación",
+	"If files were created under sudo, re-run init with sudo and --chown-user":                                     "Si los archivos se crearon con sudo, vuelva a ejecutar init con sudo y --chown-user",
+	"A file the command would create already exists.":                                                              "Ya existe un archivo que el comando crearía.",
+	"Pass --force to replace it, where the command supports it":                                                    "Indique --force para reemplazarlo, si el comando lo admite",
+	"Move the existing file elsewhere and try again":                                                               "Mueva el archivo existente a otro lugar y vuelva a intentarlo",
+	"A framework symlink could not be created.":                                                                    "No se pudo crear un enlace simbólico del framework.",
+	"On Windows, enable Developer Mode or run as administrator to allow symlinks":                                  "En Windows, active el Modo de desarrollador o ejecute como administrador para permitir enlaces simbólicos",
+	"Check that nothing else exists at the symlink's path":                                                         "Compruebe que no existe nada más en la ruta del enlace simbólico",
+	"Re-run init --force-core to recreate the symlinks":                                                            "Vuelva a ejecutar init --force-core para recrear los enlaces simbólicos",
+	"A framework symlink is missing or points to the wrong place.":                                                 "Falta un enlace simbólico del framework o apunta a un lugar equivocado.",
+	"Run 'status --verbose' to list the affected symlinks":                                                         "Ejecute 'status --verbose' para ver los enlaces simbólicos afectados",
+	"Re-run init --force-core to recreate them":                                                                    "Vuelva a ejecutar init --force-core para recrearlos",
+	"A directory being copied contains a symlink to itself or one of its parents.":                                 "Un directorio que se está copiando contiene un enlace simbólico a sí mismo o a uno de sus padres.",
+	"A symlink in the copied directory points back to a directory containing it, so following it would never end.": "Un enlace simbólico del directorio copiado apunta a un directorio que lo contiene, así que seguirlo no terminaría nunca.",
+	"Remove or fix the symlink named in the error":                                                                 "Elimine o corrija el enlace simbólico indicado en el error",
+	"Copy symlinks as links instead of following them":                                                             "Copie los enlaces simbólicos como enlaces en lugar de seguirlos",
+	"Another process holds the lock on a file being updated.":                                                      "Otro proceso tiene el bloqueo de un archivo que se está actualizando.",
+	"Another process holds the lock on a settings file. Wait for it to finish and try again; remove the .lock file only if no other installation is running.": "Otro proceso tiene el bloqueo de un archivo de ajustes. Espere a que termine y vuelva a intentarlo; elimine el archivo .lock solo si no hay otra instalación en curso.",
+	"Wait for the other installation to finish and try again":                                                               "Espere a que termine la otra instalación y vuelva a intentarlo",
+	"Remove the .lock file next to the settings file only if no other installation is running":                              "Elimine el archivo .lock junto al archivo de ajustes solo si no hay otra instalación en curso",
+	"A file changed repeatedly while it was being updated.":                                                                 "Un archivo cambió repetidamente mientras se actualizaba.",
+	"A settings file kept changing while it was being updated. Close Claude Code or wait until it is idle, then try again.": "Un archivo de ajustes siguió cambiando mientras se actualizaba. Cierre Claude Code o espere a que esté inactivo y vuelva a intentarlo.",
+	"Close Claude Code or wait until it is idle, then try again":                                                            "Cierre Claude Code o espere a que esté inactivo y vuelva a intentarlo",
+	"The markers around the block the CLI manages in a shared file are unbalanced.":                                         "Los marcadores del bloque que la CLI gestiona en un archivo compartido no están equilibrados.",
+	"A shared file such as .gitignore or .envrc has unbalanced strategic-claude-basic markers, so it was left unchanged.":   "Un archivo compartido como .gitignore o .envrc tiene marcadores de strategic-claude-basic desequilibrados, por lo que no se modificó.",
+	"Fix or remove the lines between the '>>> strategic-claude-basic' and '<<< strategic-claude-basic' markers in the file": "Corrija o elimine las líneas entre los marcadores '>>> strategic-claude-basic' y '<<< strategic-claude-basic' del archivo",
+	"Run the command again; the block is rewritten between the markers":                                                     "Vuelva a ejecutar el comando; el bloque se reescribe entre los marcadores",
+	"The installation could not be completed.":                                                                              "No se pudo completar la instalación.",
+	"Retry with --verbose to see which step failed":                                                                         "Vuelva a intentarlo con --verbose para ver qué paso falló",
+	"Preview the installation with --dry-run":                                                                               "Previsualice la instalación con --dry-run",
+	"Run 'status' to check what was installed and 'backup list' for the backup taken before":                                "Ejecute 'status' para comprobar qué se instaló y 'backup list' para ver la copia de seguridad hecha antes",
+	"The template has more data or files than an installation copies.":                                                      "La plantilla tiene más datos o archivos de los que copia una instalación.",
+	"The template exceeds the size or file count limit of an installation, which usually means it ships large binaries by mistake. The installation was stopped and rolled back.": "La plantilla supera el límite de tamaño o de número de archivos de una instalación, lo que suele indicar que incluye binarios grandes por error. La instalación se detuvo y se revirtió.",
+	"Check the template commit for large files that do not belong in it":                                                                                      "Busque en el commit de la plantilla archivos grandes que no deberían estar en ella",
+	"Re-run init with --allow-large-template if the template is meant to be this large":                                                                       "Vuelva a ejecutar init con --allow-large-template si la plantilla debe ser así de grande",
+	"Raise template_max_bytes or template_max_files in the config, or set them to 0 to turn the limit off":                                                    "Aumente template_max_bytes o template_max_files en la configuración, o póngalos a 0 para quitar el límite",
+	"An installation or cleanup took longer than its --timeout.":                                                                                              "Una instalación o limpieza tardó más que su --timeout.",
+	"The operation did not finish before the deadline set with --timeout. A stopped installation is rolled back; a stopped cleanup lists what remains.":       "La operación no terminó antes del plazo fijado con --timeout. Una instalación detenida se revierte; una limpieza detenida muestra lo que queda.",
+	"Retry with a longer --timeout, or without it":                                                                                                            "Vuelva a intentarlo con un --timeout mayor, o sin él",
+	"Retry with --verbose to see which step was slow, or with --profile":                                                                                      "Vuelva a intentarlo con --verbose para ver qué paso fue lento, o con --profile",
+	"Run 'status' to check the state of the project":                                                                                                          "Ejecute 'status' para comprobar el estado del proyecto",
+	"A plugin run by init exited with an error.":                                                                                                              "Un plugin ejecutado por init terminó con un error.",
+	"One of your organization's plugins failed. Like a failing installation script, it stops the installation unless --on-script-error says otherwise.":       "Falló uno de los plugins de su organización. Como un script de instalación que falla, detiene la instalación salvo que --on-script-error indique otra cosa.",
+	"Read the plugin's output above the error":                                                                                                                "Lea la salida del plugin encima del error",
+	"Run the plugin by hand with the JSON context on stdin to debug it":                                                                                       "Ejecute el plugin a mano con el contexto JSON en stdin para depurarlo",
+	"Pass --no-plugins to install without plugins":                                                                                                            "Indique --no-plugins para instalar sin plugins",
+	"A strategic hook exited with an error when run with a synthetic payload.":                                                                                "Un hook estratégico terminó con un error al ejecutarse con un payload sintético.",
+	"At least one hook would fail when Claude Code runs it, e.g. because a Python dependency is missing or the script has a syntax error.":                    "Al menos un hook fallaría cuando Claude Code lo ejecute, p. ej. porque falta una dependencia de Python o el script tiene un error de sintaxis.",
+	"Read the hook's output shown with the failure":                                                                                                           "Lea la salida del hook que se muestra con el fallo",
+	"Install the hook dependencies with 'init --force-core --install-hook-deps'":                                                                              "Instale las dependencias de los hooks con 'init --force-core --install-hook-deps'",
+	"Check the interpreter with --hook-python or --hook-runner":                                                                                               "Compruebe el intérprete con --hook-python o --hook-runner",
+	"The framework is already installed in the target directory.":                                                                                             "El framework ya está instalado en el directorio de destino.",
+	"Strategic Claude Basic is already installed in this directory. Use --force to reinstall or --force-core to update core files only.":                      "Strategic Claude Basic ya está instalado en este directorio. Use --force para reinstalarlo o --force-core para actualizar solo los archivos del núcleo.",
+	"Update framework files only, keeping user content: init --force-core":                                                                                    "Actualice solo los archivos del framework, conservando el contenido del usuario: init --force-core",
+	"Replace the whole installation: init --force":                                                                                                            "Reemplace toda la instalación: init --force",
+	"The framework is not installed in the target directory.":                                                                                                 "El framework no está instalado en el directorio de destino.",
+	"Strategic Claude Basic is not installed in this directory.":                                                                                              "Strategic Claude Basic no está instalado en este directorio.",
+	"Install the framework: init":                                                                                                                             "Instale el framework: init",
+	"A backup could not be written before changing files.":                                                                                                    "No se pudo escribir una copia de seguridad antes de modificar archivos.",
+	"Check that .strategic-claude-basic/backups is writable and the disk is not full":                                                                         "Compruebe que .strategic-claude-basic/backups admite escritura y que el disco no está lleno",
+	"Remove old backups listed by 'backup list' from .strategic-claude-basic/backups":                                                                         "Elimine de .strategic-claude-basic/backups las copias antiguas que muestra 'backup list'",
+	"Files could not be restored from a backup.":                                                                                                              "No se pudieron restaurar archivos de una copia de seguridad.",
+	"Find the backup with 'backup list' and copy the files back manually":                                                                                     "Busque la copia con 'backup list' y copie los archivos a mano",
+	"Restore single files with 'backup restore --path <path> --backup <name>'":                                                                                "Restaure archivos sueltos con 'backup restore --path <path> --backup <name>'",
+	"Framework directories or symlinks remain after the cleanup.":                                                                                             "Quedan directorios o enlaces simbólicos del framework tras la limpieza.",
+	"Check the permissions of the paths listed and run 'clean' again":                                                                                         "Compruebe los permisos de las rutas indicadas y vuelva a ejecutar 'clean'",
+	"Remove the paths listed manually, then run 'status' to confirm":                                                                                          "Elimine a mano las rutas indicadas y ejecute 'status' para confirmarlo",
+	"A path is invalid or cannot be accessed.":                                                                                                                "Una ruta no es válida o no se puede acceder a ella.",
+	"The specified path is invalid or inaccessible.":                                                                                                          "La ruta indicada no es válida o no es accesible.",
+	"Check the path for typos and that it exists":                                                                                                             "Compruebe que la ruta no tiene erratas y que existe",
+	"Command flags or configuration conflict or are invalid.":                                                                                                 "Las opciones del comando o la configuración son incompatibles o no son válidas.",
+	"Check the flags named in the error; see the command's --help":                                                                                            "Compruebe las opciones indicadas en el error; consulte el --help del comando",
+	"A value failed validation.":                                                                                                                              "Un valor no superó la validación.",
+	"Check the field and value named in the error; see the command's --help":                                                                                  "Compruebe el campo y el valor indicados en el error; consulte el --help del comando",
+	"Fetched template content does not match its expected checksum.":                                                                                          "El contenido descargado de la plantilla no coincide con su checksum esperado.",
+	"The fetched template content failed integrity verification. It may have been tampered with; use --no-verify only if you trust the source.":               "El contenido descargado de la plantilla no superó la verificación de integridad. Puede haber sido manipulado; use --no-verify solo si confía en el origen.",
+	"Pass --no-verify only if you trust the template source":                                                                                                  "Indique --no-verify solo si confía en el origen de la plantilla",
+	"An installation script does not match the hash pinned in script_hashes.":                                                                                 "Un script de instalación no coincide con el hash fijado en script_hashes.",
+	"The template's installation script does not match the SHA-256 pinned in the config, so it was not run. The template commit may have been tampered with.": "El script de instalación de la plantilla no coincide con el SHA-256 fijado en la configuración, así que no se ejecutó. Puede que el commit de la plantilla haya sido manipulado.",
+	"Review the script in the template commit; the install report lists its SHA-256":                                                                          "Revise el script en el commit de la plantilla; el informe de instalación muestra su SHA-256",
+	"Update script_hashes in strategic-claude-basic.json once you trust the new script":                                                                       "Actualice script_hashes en strategic-claude-basic.json cuando confíe en el nuevo script",
+	"Pass --allow-unpinned-scripts to run it this once":                                                                                                       "Indique --allow-unpinned-scripts para ejecutarlo esta vez",
+	"Installation scripts could not be run in a container.":                                                                                                   "No se pudieron ejecutar los scripts de instalación en un contenedor.",
+	"Docker is not installed or not running, so installation scripts ran without --script-isolation=docker.":                                                  "Docker no está instalado o no está en marcha, así que los scripts de instalación se ejecutaron sin --script-isolation=docker.",
+	"Install Docker and start its daemon; 'docker info' must succeed":                                                                                         "Instale Docker e inicie su daemon; 'docker info' debe funcionar",
+	"Check that your user may use Docker without sudo":                                                                                                        "Compruebe que su usuario puede usar Docker sin sudo",
+	"A template bundle is invalid or corrupted.":                                                                                                              "Un paquete de plantilla no es válido o está dañado.",
+	"The template bundle is invalid or corrupted. Recreate it with 'bundle create' on a connected machine.":                                                   "El paquete de plantilla no es válido o está dañado. Vuelva a crearlo con 'bundle create' en un equipo con conexión.",
+	"Recreate the bundle with 'bundle create' on a connected machine":                                                                                         "Vuelva a crear el paquete con 'bundle create' en un equipo con conexión",
+	"Check that the file was copied completely":                                                                                                               "Compruebe que el archivo se copió por completo",
+	"The target is a system or home directory.":                                                                                                               "El destino es un directorio del sistema o el directorio personal.",
+	"Refusing to install into a system or home directory. Choose a project directory, or pass --i-know-what-im-doing to override.":                            "No se instala en un directorio del sistema ni en el directorio personal. Elija un directorio de proyecto o indique --i-know-what-im-doing para forzarlo.",
+	"Run the command from a project directory or pass --target":                                                                                               "Ejecute el comando desde un directorio de proyecto o indique --target",
+	"Pass --i-know-what-im-doing to override":                                                                                                                 "Indique --i-know-what-im-doing para forzarlo",
+	"Contacting the template repository timed out.":                                                                                                           "Se agotó el tiempo de espera al contactar con el repositorio de la plantilla.",
+	"A network error occurred while contacting the template repository. Please check your internet connection and try again.":                                 "Se produjo un error de red al contactar con el repositorio de la plantilla. Compruebe su conexión a internet y vuelva a intentarlo.",
+	"Check your internet connection and proxy settings, then try again":                                                                                       "Compruebe su conexión a internet y la configuración del proxy, y vuelva a intentarlo",
+	"Contacting the template repository failed.":                                                                                                              "Falló el contacto con el repositorio de la plantilla.",
+	"The operation was cancelled at a prompt.":                                                                                                                "La operación se canceló en una pregunta de confirmación.",
+	"Operation cancelled by user.":                                                                                                                            "Operación cancelada por el usuario.",
+	"Pass --yes or --force to skip confirmation prompts":                                                                                                      "Indique --yes o --force para omitir las preguntas de confirmación",
+	"Reading an answer from the terminal failed.":                                                                                                             "Falló la lectura de una respuesta desde el terminal.",
+	"Pass --yes or --force when running without a terminal, e.g. in CI":                                                                                       "Indique --yes o --force cuando se ejecute sin terminal, p. ej. en CI",
+	"An installation that destroys files needs confirmation, but there is no terminal to ask.":                                                                "Una instalación que destruye archivos necesita confirmación, pero no hay un terminal donde preguntar.",
+	"With --confirm=destructive-only, full overwrites and updates that replace files without a backup are only run after confirming at a terminal.":           "Con --confirm=destructive-only, las sobrescrituras completas y las actualizaciones que reemplazan archivos sin copia de seguridad solo se ejecutan tras confirmarlas en un terminal.",
+	"Run the command in a terminal to confirm":                                                                                                                "Ejecute el comando en un terminal para confirmarlo",
+	"Drop --no-backup so the replaced files are backed up":                                                                                                    "Quite --no-backup para que se haga copia de seguridad de los archivos reemplazados",
+	"Pass --confirm=never (or --yes) to approve destructive installations as well":                                                                            "Indique --confirm=never (o --yes) para aprobar también las instalaciones destructivas",
+}
@@ -0,0 +1,139 @@
+// Package i18n translates user-facing messages. Messages are written in English
+// in the code and looked up in the catalog of the selected locale; a message the
+// catalog does not translate is shown in English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported locales
+const (
+	English = "en"
+	Spanish = "es"
+)
+
+// LocaleEnvVars are the environment variables the locale is read from when
+// none is given, the first one set winning as with gettext
+var LocaleEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// catalogs maps a locale to its translations of English messages; English has none
+var catalogs = map[string]map[string]string{
+	Spanish: merge(spanish, spanishErrors),
+}
+
+// affirmatives are the answers to a yes/no prompt that mean yes, besides the English ones
+var affirmatives = map[string][]string{
+	Spanish: {"s", "si", "sí"},
+}
+
+var current = English
+
+// merge returns the translations of all parts in one catalog
+func merge(parts ...map[string]string) map[string]string {
+	catalog := make(map[string]string)
+	for _, part := range parts {
+		for message, translated := range part {
+			catalog[message] = translated
+		}
+	}
+	return catalog
+}
+
+// Locales returns the supported locales, English first
+func Locales() []string {
+	return []string{English, Spanish}
+}
+
+// Normalize returns the supported locale a tag such as "es", "es-MX" or
+// "es_ES.UTF-8" selects, or false when its language is not supported
+func Normalize(tag string) (string, bool) {
+	language := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(language, ".@"); i >= 0 {
+		language = language[:i]
+	}
+	if i := strings.IndexAny(language, "_-"); i >= 0 {
+		language = language[:i]
+	}
+
+	for _, locale := range Locales() {
+		if language == locale {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// FromEnv returns the locale selected by the environment: the first of
+// LC_ALL, LC_MESSAGES and LANG that is set, English when it names an
+// unsupported language such as C or POSIX
+func FromEnv() string {
+	for _, name := range LocaleEnvVars {
+		if value := os.Getenv(name); value != "" {
+			if locale, ok := Normalize(value); ok {
+				return locale
+			}
+			return English
+		}
+	}
+	return English
+}
+
+// Set makes tag the locale messages are translated to, or the locale of the
+// environment when tag is empty. It returns false for an unsupported tag.
+func Set(tag string) bool {
+	if tag == "" {
+		current = FromEnv()
+		return true
+	}
+	locale, ok := Normalize(tag)
+	if !ok {
+		return false
+	}
+	current = locale
+	return true
+}
+
+// Locale returns the locale messages are translated to
+func Locale() string {
+	return current
+}
+
+// T translates message to the current locale and, given args, formats it like
+// fmt.Sprintf. The translation of a format keeps its verbs in the same order.
+func T(message string, args ...any) string {
+	if translated, ok := catalogs[current][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Translated reports whether the catalog of locale translates message; every
+// message is translated for English
+func Translated(locale, message string) bool {
+	if locale == English {
+		return true
+	}
+	_, ok := catalogs[locale][message]
+	return ok
+}
+
+// Affirmative reports whether answer to a yes/no prompt means yes: y or yes,
+// or the current locale's words for it
+func Affirmative(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "y" || answer == "yes" {
+		return true
+	}
+	for _, word := range affirmatives[current] {
+		if answer == word {
+			return true
+		}
+	}
+	return false
+}
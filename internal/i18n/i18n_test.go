@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{tag: "en", want: English, wantOK: true},
+		{tag: "es", want: Spanish, wantOK: true},
+		{tag: "es-MX", want: Spanish, wantOK: true},
+		{tag: "es_ES.UTF-8", want: Spanish, wantOK: true},
+		{tag: "ES_es@euro", want: Spanish, wantOK: true},
+		{tag: "en_US.UTF-8", want: English, wantOK: true},
+		{tag: "C", wantOK: false},
+		{tag: "fr_FR", wantOK: false},
+		{tag: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := Normalize(tt.tag)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "unset", env: map[string]string{}, want: English},
+		{name: "LANG", env: map[string]string{"LANG": "es_ES.UTF-8"}, want: Spanish},
+		{name: "LC_MESSAGES over LANG", env: map[string]string{"LC_MESSAGES": "en_US.UTF-8", "LANG": "es_ES.UTF-8"}, want: English},
+		{name: "LC_ALL over LC_MESSAGES", env: map[string]string{"LC_ALL": "es_MX", "LC_MESSAGES": "en_US"}, want: Spanish},
+		{name: "unsupported", env: map[string]string{"LC_ALL": "C", "LANG": "es_ES"}, want: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range LocaleEnvVars {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := FromEnv(); got != tt.want {
+				t.Errorf("FromEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAndT(t *testing.T) {
+	t.Cleanup(func() { Set(English) })
+	for _, name := range LocaleEnvVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("LANG", "es_ES.UTF-8")
+
+	if Set("fr") {
+		t.Error("Set(fr) = true, want false for an unsupported locale")
+	}
+	if !Set("") || Locale() != Spanish {
+		t.Fatalf("Set(\"\") selected %q, want the environment's %q", Locale(), Spanish)
+	}
+	if got := T("Remove the %d user document(s)?", 3); got != "¿Eliminar los 3 documento(s) del usuario?" {
+		t.Errorf("T() = %q, want the Spanish translation", got)
+	}
+	if got := T("Not in any catalog: %s", "x"); got != "Not in any catalog: x" {
+		t.Errorf("T() of an untranslated message = %q, want it in English", got)
+	}
+
+	Set(English)
+	if got := T("Remove the %d user document(s)?", 3); got != "Remove the 3 user document(s)?" {
+		t.Errorf("T() = %q, want the English message", got)
+	}
+}
+
+func TestAffirmative(t *testing.T) {
+	t.Cleanup(func() { Set(English) })
+
+	Set(English)
+	if !Affirmative(" Yes ") || Affirmative("sí") || Affirmative("") {
+		t.Error("Affirmative() in English accepts the wrong answers")
+	}
+	Set(Spanish)
+	if !Affirmative("sí") || !Affirmative("S") || !Affirmative("y") || Affirmative("n") {
+		t.Error("Affirmative() in Spanish accepts the wrong answers")
+	}
+}
+
+func TestCatalogsKeepVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+	for locale, catalog := range catalogs {
+		for message, translated := range catalog {
+			want := verbs.FindAllString(message, -1)
+			got := verbs.FindAllString(translated, -1)
+			if len(got) != len(want) {
+				t.Errorf("%s: %q translates %q with verbs %v, want %v", locale, translated, message, got, want)
+				continue
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("%s: %q translates %q with verbs %v, want %v", locale, translated, message, got, want)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Components are the parts of an installation that init --only and --skip select
+const (
+	ComponentCore      = "core"      // The core directory: agents, commands and hook scripts
+	ComponentGuides    = "guides"    // The guides directory
+	ComponentTemplates = "templates" // The templates directory
+	ComponentHooks     = "hooks"     // The .claude and .codex symlinks into core and hook dependencies
+	ComponentSettings  = "settings"  // .claude/settings.json and .codex/config.toml
+	ComponentGitignore = "gitignore" // The framework's .gitignore entries and other tools' ignore files
+)
+
+// GetComponents returns all components, in the order they are installed
+func GetComponents() []string {
+	return []string{ComponentCore, ComponentGuides, ComponentTemplates, ComponentHooks, ComponentSettings, ComponentGitignore}
+}
+
+// ParseComponents parses a comma-separated list of components, e.g.
+// "guides,templates", given with the named flag
+func ParseComponents(flag, value string) ([]string, error) {
+	var components []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || slices.Contains(components, name) {
+			continue
+		}
+		if !slices.Contains(GetComponents(), name) {
+			return nil, NewValidationError(flag, value,
+				fmt.Sprintf("unknown component %q, must be one of: %s", name, strings.Join(GetComponents(), ", ")))
+		}
+		components = append(components, name)
+	}
+	if len(components) == 0 {
+		return nil, NewValidationError(flag, value, "must name at least one component")
+	}
+	return components, nil
+}
+
+// SelectComponents returns the components chosen by --only and --skip, at most
+// one of which is set, in installation order. The result is nil when all
+// components are installed.
+func SelectComponents(only, skip string) ([]string, error) {
+	switch {
+	case only != "":
+		components, err := ParseComponents("only", only)
+		if err != nil {
+			return nil, err
+		}
+		if len(components) == len(GetComponents()) {
+			return nil, nil
+		}
+		return slices.DeleteFunc(GetComponents(), func(c string) bool { return !slices.Contains(components, c) }), nil
+	case skip != "":
+		skipped, err := ParseComponents("skip", skip)
+		if err != nil {
+			return nil, err
+		}
+		if len(skipped) == len(GetComponents()) {
+			return nil, NewValidationError("skip", skip, "cannot skip every component")
+		}
+		return slices.DeleteFunc(GetComponents(), func(c string) bool { return slices.Contains(skipped, c) }), nil
+	}
+	return nil, nil
+}
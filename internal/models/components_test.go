@@ -0,0 +1,36 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectComponents(t *testing.T) {
+	tests := []struct {
+		name    string
+		only    string
+		skip    string
+		want    []string
+		wantErr bool
+	}{
+		{"all", "", "", nil, false},
+		{"only", "templates, Guides", "", []string{ComponentGuides, ComponentTemplates}, false},
+		{"only every component", "core,guides,templates,hooks,settings,gitignore", "", nil, false},
+		{"skip", "", "hooks,settings", []string{ComponentCore, ComponentGuides, ComponentTemplates, ComponentGitignore}, false},
+		{"skip every component", "", "core,guides,templates,hooks,settings,gitignore", nil, true},
+		{"unknown", "guides,docs", "", nil, true},
+		{"empty list", ",", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectComponents(tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectComponents(%q, %q) error = %v, wantErr %v", tt.only, tt.skip, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SelectComponents(%q, %q) = %v, want %v", tt.only, tt.skip, got, tt.want)
+			}
+		})
+	}
+}
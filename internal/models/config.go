@@ -1,8 +1,11 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 )
 
@@ -28,6 +31,97 @@ type InstallConfig struct {
 
 	// Timeout for git operations
 	GitTimeout time.Duration
+
+	// Token for cloning private template repositories over HTTPS
+	AuthToken string
+
+	// Limit the template checkout to the framework directory
+	SparseCheckout bool
+
+	// Skip tree hash and signature verification of fetched template content
+	NoVerify bool
+
+	// Install even when a parent directory already has an installation
+	AllowNested bool
+
+	// Give created files to the invoking user when running under sudo
+	ChownUser bool
+
+	// Interpreter for strategic hook commands; detected when empty
+	HookPython string
+
+	// Runner for strategic hook commands: python, uv, poetry or custom:<command>
+	HookRunner string
+
+	// Install hook Python dependencies into .strategic-claude-basic/.venv
+	InstallHookDeps bool
+
+	// Run the strategic hooks with a synthetic payload once installed
+	VerifyHooks bool
+
+	// Write the managed block to .envrc exporting CLAUDE_PROJECT_DIR and adding tools/ to PATH
+	Direnv bool
+
+	// User directories of the installation a full overwrite keeps (--preserve)
+	CarryOver []string
+
+	// Fail when the target is not inside a git work tree
+	RequireGitRepo bool
+
+	// Allow installing into the filesystem root, $HOME or other sensitive directories
+	AllowSensitiveTarget bool
+
+	// Always clone instead of reusing a cached copy of the template commit
+	NoCache bool
+
+	// Install offline from a bundle created with "bundle create" instead of cloning
+	BundlePath string
+
+	// Link the framework directories from this local template checkout instead of copying them (--dev)
+	DevTemplatePath string
+
+	// Tool directories to set up; when empty, the previous installation's
+	// choice or DefaultIntegrations
+	Integrations []string
+
+	// Components to install, in installation order; nil installs all of them.
+	// Installing some of them updates an existing installation.
+	Components []string
+
+	// Named installation to install, empty for the default one
+	Instance string
+
+	// How Cursor rules are installed: symlink or copy; when empty, the previous
+	// installation's choice or symlink
+	CursorMode string
+
+	// How the targets of the .claude and .codex symlinks are written: relative
+	// or absolute; when empty, the previous installation's choice or relative
+	SymlinkStyle string
+
+	// Make the core, guides and templates files read-only after the install;
+	// nil keeps the previous installation's choice
+	ReadOnlyCore *bool
+
+	// What to do when an installation script fails: abort, continue or rollback;
+	// empty means abort
+	ScriptErrorPolicy string
+
+	// How symlinks in the template content are copied: rewrite-relative, skip
+	// or follow; empty means rewrite-relative
+	SymlinkPolicy string
+
+	// Run installation scripts that do not match the hashes pinned in the config
+	AllowUnpinnedScripts bool
+
+	// Where installation scripts run: none or docker; empty means none
+	ScriptIsolation string
+
+	// Install templates above the configured maximum size and file count
+	AllowLargeTemplate bool
+
+	// Leave the template checkout in place after installing, for debugging
+	KeepTemp bool
 }
 
 // CleanConfig holds configuration options for cleanup operations
@@ -57,7 +151,7 @@ func NewInstallConfig(targetDir string) *InstallConfig {
 		Verbose:       false,
 		GitignoreMode: "track",
 		BackupDir:     "",
-		GitTimeout:    30 * time.Second,
+		GitTimeout:    config.DefaultGitTimeout,
 	}
 }
 
@@ -93,13 +187,20 @@ func (c *InstallConfig) Validate() error {
 		return NewAppError(ErrorCodeInvalidPath, "target directory cannot be empty", nil)
 	}
 
-	// Validate template ID
-	if c.TemplateID == "" {
-		return NewAppError(ErrorCodeInvalidConfiguration, "template ID cannot be empty", nil)
+	// Validate template ID; bundles carry their own template definition
+	if c.BundlePath == "" {
+		if c.TemplateID == "" {
+			return NewAppError(ErrorCodeInvalidConfiguration, "template ID cannot be empty", nil)
+		}
+
+		if err := templates.ValidateTemplateID(c.TemplateID); err != nil {
+			return NewAppError(ErrorCodeInvalidConfiguration, "invalid template ID: "+c.TemplateID, err)
+		}
 	}
 
-	if err := templates.ValidateTemplateID(c.TemplateID); err != nil {
-		return NewAppError(ErrorCodeInvalidConfiguration, "invalid template ID: "+c.TemplateID, err)
+	// Dev mode reads the template from a local checkout, not from a bundle
+	if c.DevTemplatePath != "" && c.BundlePath != "" {
+		return NewAppError(ErrorCodeInvalidConfiguration, "cannot specify both --template-path and --from-bundle", nil)
 	}
 
 	// Both force and force-core cannot be true at the same time
@@ -120,6 +221,68 @@ func (c *InstallConfig) Validate() error {
 		return NewAppError(ErrorCodeInvalidConfiguration, "invalid gitignore mode: "+c.GitignoreMode, nil)
 	}
 
+	// Validate hook runner; the interpreter only applies to the python runner
+	if err := ValidateHookRunner(c.HookRunner); err != nil {
+		return err
+	}
+	if c.HookPython != "" && c.HookRunner != "" && c.HookRunner != HookRunnerPython {
+		return NewAppError(ErrorCodeInvalidConfiguration, "--hook-python can only be used with the python hook runner", nil)
+	}
+	if c.InstallHookDeps && c.HookRunner != "" && c.HookRunner != HookRunnerPython {
+		return NewAppError(ErrorCodeInvalidConfiguration, "--install-hook-deps can only be used with the python hook runner", nil)
+	}
+
+	if err := ValidateScriptErrorPolicy(c.ScriptErrorPolicy); err != nil {
+		return err
+	}
+	if err := ValidateSymlinkPolicy(c.SymlinkPolicy); err != nil {
+		return err
+	}
+	if err := ValidateScriptIsolation(c.ScriptIsolation); err != nil {
+		return err
+	}
+
+	// Validate integrations
+	if len(c.Integrations) > 0 {
+		if _, err := ParseIntegrations(strings.Join(c.Integrations, ",")); err != nil {
+			return err
+		}
+	}
+	if err := ValidateCursorMode(c.CursorMode); err != nil {
+		return err
+	}
+	if err := ValidateSymlinkStyle(c.SymlinkStyle); err != nil {
+		return err
+	}
+
+	// Some components are installed into an existing installation, from copies
+	if len(c.Components) > 0 {
+		if _, err := ParseComponents("only", strings.Join(c.Components, ",")); err != nil {
+			return err
+		}
+		if c.Force {
+			return NewAppError(ErrorCodeInvalidConfiguration, "--only and --skip cannot be used with --force", nil)
+		}
+		if c.DevTemplatePath != "" {
+			return NewAppError(ErrorCodeInvalidConfiguration, "--only and --skip cannot be used in dev mode", nil)
+		}
+	}
+
+	// Named installations share Cursor rules, the Aider and OpenCode
+	// configurations and .envrc with the default installation, which manages them
+	if c.Instance != "" {
+		for _, integration := range c.Integrations {
+			if integration != IntegrationClaude && integration != IntegrationCodex {
+				return NewAppError(ErrorCodeInvalidConfiguration,
+					fmt.Sprintf("the %s integration can only be installed by the default installation, not by instance %s", integration, c.Instance), nil)
+			}
+		}
+		if c.Direnv {
+			return NewAppError(ErrorCodeInvalidConfiguration,
+				fmt.Sprintf("--direnv can only be used by the default installation, not by instance %s", c.Instance), nil)
+		}
+	}
+
 	return nil
 }
 
@@ -18,15 +18,21 @@ const (
 	ErrorCodeGitCheckoutError  ErrorCode = "GIT_CHECKOUT_ERROR"
 	ErrorCodeGitError          ErrorCode = "GIT_ERROR"
 	ErrorCodeGitCommitNotFound ErrorCode = "GIT_COMMIT_NOT_FOUND"
+	ErrorCodeGitAuthFailed     ErrorCode = "GIT_AUTH_FAILED"
+	ErrorCodeNotGitRepository  ErrorCode = "NOT_GIT_REPOSITORY"
 
 	// File system errors
-	ErrorCodeFileSystemError       ErrorCode = "FILE_SYSTEM_ERROR"
-	ErrorCodeDirectoryNotFound     ErrorCode = "DIRECTORY_NOT_FOUND"
-	ErrorCodeDirectoryNotEmpty     ErrorCode = "DIRECTORY_NOT_EMPTY"
-	ErrorCodePermissionDenied      ErrorCode = "PERMISSION_DENIED"
-	ErrorCodeFileAlreadyExists     ErrorCode = "FILE_ALREADY_EXISTS"
-	ErrorCodeSymlinkCreationFailed ErrorCode = "SYMLINK_CREATION_FAILED"
-	ErrorCodeSymlinkInvalid        ErrorCode = "SYMLINK_INVALID"
+	ErrorCodeFileSystemError        ErrorCode = "FILE_SYSTEM_ERROR"
+	ErrorCodeDirectoryNotFound      ErrorCode = "DIRECTORY_NOT_FOUND"
+	ErrorCodeDirectoryNotEmpty      ErrorCode = "DIRECTORY_NOT_EMPTY"
+	ErrorCodePermissionDenied       ErrorCode = "PERMISSION_DENIED"
+	ErrorCodeFileAlreadyExists      ErrorCode = "FILE_ALREADY_EXISTS"
+	ErrorCodeSymlinkCreationFailed  ErrorCode = "SYMLINK_CREATION_FAILED"
+	ErrorCodeSymlinkInvalid         ErrorCode = "SYMLINK_INVALID"
+	ErrorCodeSymlinkLoop            ErrorCode = "SYMLINK_LOOP"
+	ErrorCodeFileLocked             ErrorCode = "FILE_LOCKED"
+	ErrorCodeConcurrentModification ErrorCode = "CONCURRENT_MODIFICATION"
+	ErrorCodeManagedBlockConflict   ErrorCode = "MANAGED_BLOCK_CONFLICT"
 
 	// Installation errors
 	ErrorCodeInstallationFailed ErrorCode = "INSTALLATION_FAILED"
@@ -34,19 +40,30 @@ const (
 	ErrorCodeNotInstalled       ErrorCode = "NOT_INSTALLED"
 	ErrorCodeBackupFailed       ErrorCode = "BACKUP_FAILED"
 	ErrorCodeRestoreFailed      ErrorCode = "RESTORE_FAILED"
+	ErrorCodeCleanupIncomplete  ErrorCode = "CLEANUP_INCOMPLETE"
+	ErrorCodeTemplateTooLarge   ErrorCode = "TEMPLATE_TOO_LARGE"
+	ErrorCodeOperationTimeout   ErrorCode = "OPERATION_TIMEOUT"
+	ErrorCodePluginFailed       ErrorCode = "PLUGIN_FAILED"
+	ErrorCodeHookCheckFailed    ErrorCode = "HOOK_CHECK_FAILED"
 
 	// Validation errors
 	ErrorCodeInvalidPath          ErrorCode = "INVALID_PATH"
 	ErrorCodeInvalidConfiguration ErrorCode = "INVALID_CONFIGURATION"
 	ErrorCodeValidationFailed     ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeVerificationFailed   ErrorCode = "VERIFICATION_FAILED"
+	ErrorCodeScriptHashMismatch   ErrorCode = "SCRIPT_HASH_MISMATCH"
+	ErrorCodeDockerUnavailable    ErrorCode = "DOCKER_UNAVAILABLE"
+	ErrorCodeInvalidBundle        ErrorCode = "INVALID_BUNDLE"
+	ErrorCodeSensitiveDirectory   ErrorCode = "SENSITIVE_DIRECTORY"
 
 	// Network errors
 	ErrorCodeNetworkTimeout ErrorCode = "NETWORK_TIMEOUT"
 	ErrorCodeNetworkError   ErrorCode = "NETWORK_ERROR"
 
 	// User interaction errors
-	ErrorCodeUserCancelled ErrorCode = "USER_CANCELLED"
-	ErrorCodeInputError    ErrorCode = "INPUT_ERROR"
+	ErrorCodeUserCancelled        ErrorCode = "USER_CANCELLED"
+	ErrorCodeInputError           ErrorCode = "INPUT_ERROR"
+	ErrorCodeConfirmationRequired ErrorCode = "CONFIRMATION_REQUIRED"
 )
 
 // AppError represents a structured application error
@@ -117,6 +134,12 @@ func NewInstallationError(code ErrorCode, targetDir string, cause error) *AppErr
 		WithContext("target_dir", targetDir)
 }
 
+// NewTimeoutError creates an error for an operation stopped by its deadline
+func NewTimeoutError(operation string, cause error) *AppError {
+	return NewAppError(ErrorCodeOperationTimeout, fmt.Sprintf("The %s did not finish before the deadline set with --timeout", operation), cause).
+		WithContext("operation", operation)
+}
+
 // NewValidationError creates a validation error
 func NewValidationError(field string, value interface{}, message string) *AppError {
 	return NewAppError(ErrorCodeValidationFailed, fmt.Sprintf("Validation failed for %s: %s", field, message), nil).
@@ -140,7 +163,7 @@ func IsGitError(err error) bool {
 		switch appErr.Code {
 		case ErrorCodeGitCloneFailed, ErrorCodeGitCheckoutFailed, ErrorCodeGitNotInstalled,
 			ErrorCodeGitNotFound, ErrorCodeGitCloneError, ErrorCodeGitCheckoutError,
-			ErrorCodeGitError, ErrorCodeGitCommitNotFound:
+			ErrorCodeGitError, ErrorCodeGitCommitNotFound, ErrorCodeGitAuthFailed, ErrorCodeNotGitRepository:
 			return true
 		}
 	}
@@ -167,30 +190,8 @@ func GetUserFriendlyMessage(err error) string {
 		return err.Error()
 	}
 
-	switch appErr.Code {
-	case ErrorCodeGitNotInstalled, ErrorCodeGitNotFound:
-		return "Git is not installed or not available in PATH. Please install Git and try again."
-	case ErrorCodeGitCloneFailed, ErrorCodeGitCloneError:
-		return "Failed to download the Strategic Claude Basic repository. Please check your internet connection."
-	case ErrorCodeGitCheckoutFailed, ErrorCodeGitCheckoutError:
-		return "Failed to checkout the specified commit. The repository may be corrupted or the commit may not exist."
-	case ErrorCodeGitCommitNotFound:
-		return "The specified commit was not found in the repository."
-	case ErrorCodeGitError:
-		return "A git operation failed. Please ensure the repository is valid and try again."
-	case ErrorCodePermissionDenied:
-		return "Permission denied. Please check that you have write permissions to the target directory."
-	case ErrorCodeAlreadyInstalled:
-		return "Strategic Claude Basic is already installed in this directory. Use --force to reinstall or --force-core to update core files only."
-	case ErrorCodeNotInstalled:
-		return "Strategic Claude Basic is not installed in this directory."
-	case ErrorCodeUserCancelled:
-		return "Operation cancelled by user."
-	case ErrorCodeDirectoryNotFound:
-		return "The specified directory does not exist."
-	case ErrorCodeInvalidPath:
-		return "The specified path is invalid or inaccessible."
-	default:
-		return appErr.Message
+	if explanation, ok := ExplainErrorCode(string(appErr.Code)); ok && explanation.Message != "" {
+		return explanation.Message
 	}
+	return appErr.Message
 }
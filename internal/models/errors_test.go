@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 )
 
 func TestNewAppError(t *testing.T) {
@@ -555,3 +557,98 @@ func TestError_Context_Modification(t *testing.T) {
 		t.Error("Expected context value to be overwritten")
 	}
 }
+
+func TestAllErrorCodes_Explained(t *testing.T) {
+	seen := make(map[ErrorCode]bool)
+	for _, code := range AllErrorCodes() {
+		if seen[code] {
+			t.Errorf("AllErrorCodes() lists %s twice", code)
+		}
+		seen[code] = true
+
+		explanation, ok := ExplainErrorCode(string(code))
+		if !ok {
+			t.Errorf("ExplainErrorCode(%s) found = false, want true", code)
+			continue
+		}
+		if explanation.Description == "" {
+			t.Errorf("ExplainErrorCode(%s) has no description", code)
+		}
+		if len(explanation.Remediation) == 0 {
+			t.Errorf("ExplainErrorCode(%s) has no remediation steps", code)
+		}
+	}
+}
+
+func TestExplainErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		wantCode ErrorCode
+		wantOK   bool
+	}{
+		{name: "exact", code: "GIT_AUTH_FAILED", wantCode: ErrorCodeGitAuthFailed, wantOK: true},
+		{name: "lowercase with dashes", code: "permission-denied", wantCode: ErrorCodePermissionDenied, wantOK: true},
+		{name: "surrounding space", code: " not_installed ", wantCode: ErrorCodeNotInstalled, wantOK: true},
+		{name: "unknown", code: "NOT_A_CODE", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation, ok := ExplainErrorCode(tt.code)
+			if ok != tt.wantOK {
+				t.Fatalf("ExplainErrorCode(%q) found = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if explanation.Code != tt.wantCode {
+				t.Errorf("ExplainErrorCode(%q) code = %s, want %s", tt.code, explanation.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetRemediation(t *testing.T) {
+	wrapped := fmt.Errorf("failed to install: %w", NewAppError(ErrorCodeAlreadyInstalled, "exists", nil))
+	explanation, ok := GetRemediation(wrapped)
+	if !ok {
+		t.Fatal("GetRemediation() found = false, want true for a wrapped AppError")
+	}
+	if explanation.Code != ErrorCodeAlreadyInstalled {
+		t.Errorf("GetRemediation() code = %s, want %s", explanation.Code, ErrorCodeAlreadyInstalled)
+	}
+
+	if _, ok := GetRemediation(errors.New("plain")); ok {
+		t.Error("GetRemediation() found = true, want false for a plain error")
+	}
+}
+
+func TestErrorExplanations_Translated(t *testing.T) {
+	for _, locale := range i18n.Locales() {
+		for _, explanation := range errorExplanations {
+			texts := append([]string{explanation.Description, explanation.Message}, explanation.Remediation...)
+			for _, text := range texts {
+				if text != "" && !i18n.Translated(locale, text) {
+					t.Errorf("%s: %q is not translated to %s", explanation.Code, text, locale)
+				}
+			}
+		}
+	}
+}
+
+func TestExplainErrorCode_Locale(t *testing.T) {
+	t.Cleanup(func() { i18n.Set(i18n.English) })
+	i18n.Set(i18n.Spanish)
+
+	explanation, ok := ExplainErrorCode(string(ErrorCodeNotInstalled))
+	if !ok {
+		t.Fatal("ExplainErrorCode() found = false")
+	}
+	if explanation.Message != "Strategic Claude Basic no está instalado en este directorio." {
+		t.Errorf("Message = %q, want the Spanish translation", explanation.Message)
+	}
+	if len(explanation.Docs) == 0 || explanation.Docs[0] != "Quick Start" {
+		t.Errorf("Docs = %v, want the README sections untranslated", explanation.Docs)
+	}
+	if GetUserFriendlyMessage(NewAppError(ErrorCodeNotInstalled, "not installed", nil)) != explanation.Message {
+		t.Error("GetUserFriendlyMessage() is not translated")
+	}
+}
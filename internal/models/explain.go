@@ -0,0 +1,488 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+)
+
+// ErrorExplanation describes an error code and how to resolve it
+type ErrorExplanation struct {
+	Code        ErrorCode `json:"code"`
+	Description string    `json:"description"`           // What the error means
+	Message     string    `json:"message,omitempty"`     // Shown after failures instead of the error's own message; empty keeps it
+	Remediation []string  `json:"remediation,omitempty"` // Steps to take, most likely first
+	Docs        []string  `json:"docs,omitempty"`        // README sections to read
+}
+
+// errorExplanations covers every ErrorCode, in declaration order
+var errorExplanations = []ErrorExplanation{
+	{
+		Code:        ErrorCodeGitCloneFailed,
+		Description: "The template repository could not be cloned.",
+		Message:     "Failed to download the Strategic Claude Basic repository. Please check your internet connection.",
+		Remediation: []string{
+			"Check that the template repository is reachable: git ls-remote <repository-url>",
+			"Retry with --verbose to see the git output",
+			"Install offline from a bundle created elsewhere with 'bundle create', using init --from-bundle",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeGitCheckoutFailed,
+		Description: "The template's pinned commit could not be checked out.",
+		Message:     "Failed to checkout the specified commit. The repository may be corrupted or the commit may not exist.",
+		Remediation: []string{
+			"Clear the cached clone with 'cache clean' and try again",
+			"Check that the pinned commit still exists in the template repository",
+		},
+		Docs: []string{"Template Cache (cache)"},
+	},
+	{
+		Code:        ErrorCodeGitNotInstalled,
+		Description: "The git executable was not found.",
+		Message:     "Git is not installed or not available in PATH. Please install Git and try again.",
+		Remediation: []string{
+			"Install git from https://git-scm.com/downloads or your package manager",
+			"Check that 'git --version' works in the same shell",
+			"Install from a bundle with init --from-bundle if git cannot be installed",
+		},
+		Docs: []string{"Prerequisites"},
+	},
+	{
+		Code:        ErrorCodeGitNotFound,
+		Description: "The git executable was not found in PATH.",
+		Message:     "Git is not installed or not available in PATH. Please install Git and try again.",
+		Remediation: []string{
+			"Install git from https://git-scm.com/downloads or your package manager",
+			"Add the directory containing git to PATH",
+		},
+		Docs: []string{"Prerequisites"},
+	},
+	{
+		Code:        ErrorCodeGitCloneError,
+		Description: "Cloning the template repository failed.",
+		Message:     "Failed to download the Strategic Claude Basic repository. Please check your internet connection.",
+		Remediation: []string{
+			"Check your internet connection and proxy settings",
+			"Retry with --verbose to see the git output",
+			"Install offline from a bundle with init --from-bundle",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeGitCheckoutError,
+		Description: "Checking out the template's pinned commit failed.",
+		Message:     "Failed to checkout the specified commit. The repository may be corrupted or the commit may not exist.",
+		Remediation: []string{
+			"Clear the cached clone with 'cache clean' and try again",
+		},
+		Docs: []string{"Template Cache (cache)"},
+	},
+	{
+		Code:        ErrorCodeGitError,
+		Description: "A git command failed.",
+		Message:     "A git operation failed. Please ensure the repository is valid and try again.",
+		Remediation: []string{
+			"Retry with --verbose to see the git output",
+			"Clear the cached clone with 'cache clean' if the failure is in the template cache",
+		},
+	},
+	{
+		Code:        ErrorCodeGitCommitNotFound,
+		Description: "The template's pinned commit does not exist in the repository.",
+		Message:     "The specified commit was not found in the repository.",
+		Remediation: []string{
+			"Update the CLI; the template registry may pin a commit that was rewritten",
+			"Clear the cached clone with 'cache clean' so the repository is fetched again",
+		},
+		Docs: []string{"Version Management"},
+	},
+	{
+		Code:        ErrorCodeGitAuthFailed,
+		Description: "The template repository rejected the credentials.",
+		Message:     "Authentication to the template repository failed. Provide a token with --auth-token or SCB_GIT_TOKEN, configure a git credential helper, or load your SSH key into ssh-agent.",
+		Remediation: []string{
+			"Pass a token with --auth-token or export SCB_GIT_TOKEN",
+			"Configure a git credential helper: git config --global credential.helper store",
+			"Load your SSH key: ssh-add ~/.ssh/id_ed25519",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeNotGitRepository,
+		Description: "init --require-git-repo was used outside a git repository.",
+		Message:     "The target directory is not inside a git repository. Run 'git init' first or drop --require-git-repo.",
+		Remediation: []string{
+			"Run 'git init' in the project directory",
+			"Drop --require-git-repo to install anyway",
+		},
+	},
+	{
+		Code:        ErrorCodeFileSystemError,
+		Description: "Reading or writing a file or directory failed.",
+		Remediation: []string{
+			"Check the path named in the error exists and is writable",
+			"Check that the disk is not full",
+			"Run 'doctor' to report permission problems in the installation",
+		},
+		Docs: []string{"Diagnose Installation (doctor)"},
+	},
+	{
+		Code:        ErrorCodeDirectoryNotFound,
+		Description: "A directory the command needs does not exist.",
+		Message:     "The specified directory does not exist.",
+		Remediation: []string{
+			"Check the --target directory",
+			"Create the directory first: mkdir -p <directory>",
+		},
+	},
+	{
+		Code:        ErrorCodeDirectoryNotEmpty,
+		Description: "A directory that must be empty has content.",
+		Remediation: []string{
+			"Choose an empty or new directory",
+			"Move the existing content elsewhere and try again",
+		},
+	},
+	{
+		Code:        ErrorCodePermissionDenied,
+		Description: "The current user may not write to the target.",
+		Message:     "Permission denied. Please check that you have write permissions to the target directory.",
+		Remediation: []string{
+			"Check the ownership of the project: ls -la",
+			"Run 'doctor --fix-permissions' to reset permissions in the installation",
+			"If files were created under sudo, re-run init with sudo and --chown-user",
+		},
+		Docs: []string{"Diagnose Installation (doctor)"},
+	},
+	{
+		Code:        ErrorCodeFileAlreadyExists,
+		Description: "A file the command would create already exists.",
+		Remediation: []string{
+			"Pass --force to replace it, where the command supports it",
+			"Move the existing file elsewhere and try again",
+		},
+	},
+	{
+		Code:        ErrorCodeSymlinkCreationFailed,
+		Description: "A framework symlink could not be created.",
+		Remediation: []string{
+			"On Windows, enable Developer Mode or run as administrator to allow symlinks",
+			"Check that nothing else exists at the symlink's path",
+			"Re-run init --force-core to recreate the symlinks",
+		},
+		Docs: []string{"Directory Structure"},
+	},
+	{
+		Code:        ErrorCodeSymlinkInvalid,
+		Description: "A framework symlink is missing or points to the wrong place.",
+		Remediation: []string{
+			"Run 'status --verbose' to list the affected symlinks",
+			"Re-run init --force-core to recreate them",
+		},
+		Docs: []string{"Check Status (status)"},
+	},
+	{
+		Code:        ErrorCodeSymlinkLoop,
+		Description: "A directory being copied contains a symlink to itself or one of its parents.",
+		Message:     "A symlink in the copied directory points back to a directory containing it, so following it would never end.",
+		Remediation: []string{
+			"Remove or fix the symlink named in the error",
+			"Copy symlinks as links instead of following them",
+		},
+	},
+	{
+		Code:        ErrorCodeFileLocked,
+		Description: "Another process holds the lock on a file being updated.",
+		Message:     "Another process holds the lock on a settings file. Wait for it to finish and try again; remove the .lock file only if no other installation is running.",
+		Remediation: []string{
+			"Wait for the other installation to finish and try again",
+			"Remove the .lock file next to the settings file only if no other installation is running",
+		},
+	},
+	{
+		Code:        ErrorCodeConcurrentModification,
+		Description: "A file changed repeatedly while it was being updated.",
+		Message:     "A settings file kept changing while it was being updated. Close Claude Code or wait until it is idle, then try again.",
+		Remediation: []string{
+			"Close Claude Code or wait until it is idle, then try again",
+		},
+	},
+	{
+		Code:        ErrorCodeManagedBlockConflict,
+		Description: "The markers around the block the CLI manages in a shared file are unbalanced.",
+		Message:     "A shared file such as .gitignore or .envrc has unbalanced strategic-claude-basic markers, so it was left unchanged.",
+		Remediation: []string{
+			"Fix or remove the lines between the '>>> strategic-claude-basic' and '<<< strategic-claude-basic' markers in the file",
+			"Run the command again; the block is rewritten between the markers",
+		},
+	},
+	{
+		Code:        ErrorCodeInstallationFailed,
+		Description: "The installation could not be completed.",
+		Remediation: []string{
+			"Retry with --verbose to see which step failed",
+			"Preview the installation with --dry-run",
+			"Run 'status' to check what was installed and 'backup list' for the backup taken before",
+		},
+		Docs: []string{"Initialize Framework (init)", "Backups (backup)"},
+	},
+	{
+		Code:        ErrorCodeTemplateTooLarge,
+		Description: "The template has more data or files than an installation copies.",
+		Message:     "The template exceeds the size or file count limit of an installation, which usually means it ships large binaries by mistake. The installation was stopped and rolled back.",
+		Remediation: []string{
+			"Check the template commit for large files that do not belong in it",
+			"Re-run init with --allow-large-template if the template is meant to be this large",
+			"Raise template_max_bytes or template_max_files in the config, or set them to 0 to turn the limit off",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeOperationTimeout,
+		Description: "An installation or cleanup took longer than its --timeout.",
+		Message:     "The operation did not finish before the deadline set with --timeout. A stopped installation is rolled back; a stopped cleanup lists what remains.",
+		Remediation: []string{
+			"Retry with a longer --timeout, or without it",
+			"Retry with --verbose to see which step was slow, or with --profile",
+			"Run 'status' to check the state of the project",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodePluginFailed,
+		Description: "A plugin run by init exited with an error.",
+		Message:     "One of your organization's plugins failed. Like a failing installation script, it stops the installation unless --on-script-error says otherwise.",
+		Remediation: []string{
+			"Read the plugin's output above the error",
+			"Run the plugin by hand with the JSON context on stdin to debug it",
+			"Pass --no-plugins to install without plugins",
+		},
+		Docs: []string{"Plugins"},
+	},
+	{
+		Code:        ErrorCodeHookCheckFailed,
+		Description: "A strategic hook exited with an error when run with a synthetic payload.",
+		Message:     "At least one hook would fail when Claude Code runs it, e.g. because a Python dependency is missing or the script has a syntax error.",
+		Remediation: []string{
+			"Read the hook's output shown with the failure",
+			"Install the hook dependencies with 'init --force-core --install-hook-deps'",
+			"Check the interpreter with --hook-python or --hook-runner",
+		},
+		Docs: []string{"Verify Hooks (verify-hooks)"},
+	},
+	{
+		Code:        ErrorCodeAlreadyInstalled,
+		Description: "The framework is already installed in the target directory.",
+		Message:     "Strategic Claude Basic is already installed in this directory. Use --force to reinstall or --force-core to update core files only.",
+		Remediation: []string{
+			"Update framework files only, keeping user content: init --force-core",
+			"Replace the whole installation: init --force",
+		},
+		Docs: []string{"Installation Types"},
+	},
+	{
+		Code:        ErrorCodeNotInstalled,
+		Description: "The framework is not installed in the target directory.",
+		Message:     "Strategic Claude Basic is not installed in this directory.",
+		Remediation: []string{
+			"Check the --target directory",
+			"Install the framework: init",
+		},
+		Docs: []string{"Quick Start"},
+	},
+	{
+		Code:        ErrorCodeBackupFailed,
+		Description: "A backup could not be written before changing files.",
+		Remediation: []string{
+			"Check that .strategic-claude-basic/backups is writable and the disk is not full",
+			"Remove old backups listed by 'backup list' from .strategic-claude-basic/backups",
+		},
+		Docs: []string{"Backups (backup)"},
+	},
+	{
+		Code:        ErrorCodeRestoreFailed,
+		Description: "Files could not be restored from a backup.",
+		Remediation: []string{
+			"Find the backup with 'backup list' and copy the files back manually",
+			"Restore single files with 'backup restore --path <path> --backup <name>'",
+		},
+		Docs: []string{"Backups (backup)"},
+	},
+	{
+		Code:        ErrorCodeCleanupIncomplete,
+		Description: "Framework directories or symlinks remain after the cleanup.",
+		Remediation: []string{
+			"Check the permissions of the paths listed and run 'clean' again",
+			"Remove the paths listed manually, then run 'status' to confirm",
+		},
+		Docs: []string{"Clean Installation (clean)"},
+	},
+	{
+		Code:        ErrorCodeInvalidPath,
+		Description: "A path is invalid or cannot be accessed.",
+		Message:     "The specified path is invalid or inaccessible.",
+		Remediation: []string{
+			"Check the path for typos and that it exists",
+		},
+	},
+	{
+		Code:        ErrorCodeInvalidConfiguration,
+		Description: "Command flags or configuration conflict or are invalid.",
+		Remediation: []string{
+			"Check the flags named in the error; see the command's --help",
+		},
+		Docs: []string{"Commands Reference"},
+	},
+	{
+		Code:        ErrorCodeValidationFailed,
+		Description: "A value failed validation.",
+		Remediation: []string{
+			"Check the field and value named in the error; see the command's --help",
+		},
+	},
+	{
+		Code:        ErrorCodeVerificationFailed,
+		Description: "Fetched template content does not match its expected checksum.",
+		Message:     "The fetched template content failed integrity verification. It may have been tampered with; use --no-verify only if you trust the source.",
+		Remediation: []string{
+			"Clear the cached clone with 'cache clean' and try again",
+			"Pass --no-verify only if you trust the template source",
+		},
+		Docs: []string{"Template Cache (cache)"},
+	},
+	{
+		Code:        ErrorCodeScriptHashMismatch,
+		Description: "An installation script does not match the hash pinned in script_hashes.",
+		Message:     "The template's installation script does not match the SHA-256 pinned in the config, so it was not run. The template commit may have been tampered with.",
+		Remediation: []string{
+			"Review the script in the template commit; the install report lists its SHA-256",
+			"Update script_hashes in strategic-claude-basic.json once you trust the new script",
+			"Pass --allow-unpinned-scripts to run it this once",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeDockerUnavailable,
+		Description: "Installation scripts could not be run in a container.",
+		Message:     "Docker is not installed or not running, so installation scripts ran without --script-isolation=docker.",
+		Remediation: []string{
+			"Install Docker and start its daemon; 'docker info' must succeed",
+			"Check that your user may use Docker without sudo",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeInvalidBundle,
+		Description: "A template bundle is invalid or corrupted.",
+		Message:     "The template bundle is invalid or corrupted. Recreate it with 'bundle create' on a connected machine.",
+		Remediation: []string{
+			"Recreate the bundle with 'bundle create' on a connected machine",
+			"Check that the file was copied completely",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+	{
+		Code:        ErrorCodeSensitiveDirectory,
+		Description: "The target is a system or home directory.",
+		Message:     "Refusing to install into a system or home directory. Choose a project directory, or pass --i-know-what-im-doing to override.",
+		Remediation: []string{
+			"Run the command from a project directory or pass --target",
+			"Pass --i-know-what-im-doing to override",
+		},
+	},
+	{
+		Code:        ErrorCodeNetworkTimeout,
+		Description: "Contacting the template repository timed out.",
+		Message:     "A network error occurred while contacting the template repository. Please check your internet connection and try again.",
+		Remediation: []string{
+			"Check your internet connection and proxy settings, then try again",
+			"Install offline from a bundle with init --from-bundle",
+		},
+	},
+	{
+		Code:        ErrorCodeNetworkError,
+		Description: "Contacting the template repository failed.",
+		Message:     "A network error occurred while contacting the template repository. Please check your internet connection and try again.",
+		Remediation: []string{
+			"Check your internet connection and proxy settings, then try again",
+			"Install offline from a bundle with init --from-bundle",
+		},
+	},
+	{
+		Code:        ErrorCodeUserCancelled,
+		Description: "The operation was cancelled at a prompt.",
+		Message:     "Operation cancelled by user.",
+		Remediation: []string{
+			"Pass --yes or --force to skip confirmation prompts",
+		},
+	},
+	{
+		Code:        ErrorCodeInputError,
+		Description: "Reading an answer from the terminal failed.",
+		Remediation: []string{
+			"Pass --yes or --force when running without a terminal, e.g. in CI",
+		},
+	},
+	{
+		Code:        ErrorCodeConfirmationRequired,
+		Description: "An installation that destroys files needs confirmation, but there is no terminal to ask.",
+		Message:     "With --confirm=destructive-only, full overwrites and updates that replace files without a backup are only run after confirming at a terminal.",
+		Remediation: []string{
+			"Run the command in a terminal to confirm",
+			"Drop --no-backup so the replaced files are backed up",
+			"Pass --confirm=never (or --yes) to approve destructive installations as well",
+		},
+		Docs: []string{"Initialize Framework (init)"},
+	},
+}
+
+// AllErrorCodes returns every error code, in declaration order
+func AllErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, len(errorExplanations))
+	for i, explanation := range errorExplanations {
+		codes[i] = explanation.Code
+	}
+	return codes
+}
+
+// ExplainErrorCode returns the explanation of a code in the current locale. The
+// lookup ignores case and accepts dashes for underscores, e.g. "git-auth-failed".
+func ExplainErrorCode(code string) (ErrorExplanation, bool) {
+	normalized := ErrorCode(strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", "_")))
+	for _, explanation := range errorExplanations {
+		if explanation.Code == normalized {
+			return explanation.translated(), true
+		}
+	}
+	return ErrorExplanation{}, false
+}
+
+// translated returns the explanation with its text in the current locale; the
+// README sections it names are not translated
+func (e ErrorExplanation) translated() ErrorExplanation {
+	e.Description = i18n.T(e.Description)
+	if e.Message != "" {
+		e.Message = i18n.T(e.Message)
+	}
+	if e.Remediation != nil {
+		remediation := make([]string, len(e.Remediation))
+		for i, step := range e.Remediation {
+			remediation[i] = i18n.T(step)
+		}
+		e.Remediation = remediation
+	}
+	return e
+}
+
+// GetRemediation returns the explanation of the code of an application error,
+// or false when err is not one
+func GetRemediation(err error) (ErrorExplanation, bool) {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return ErrorExplanation{}, false
+	}
+	return ExplainErrorCode(string(appErr.Code))
+}
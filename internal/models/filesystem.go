@@ -0,0 +1,77 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// FilesystemCapabilities is what the file system holding a directory supports
+type FilesystemCapabilities struct {
+	Dir           string `json:"dir"` // Directory that was probed
+	Symlinks      bool   `json:"symlinks"`
+	Hardlinks     bool   `json:"hardlinks"`
+	CaseSensitive bool   `json:"case_sensitive"`
+	Reflinks      bool   `json:"reflinks"` // Copy-on-write clones of files
+}
+
+// String lists the capabilities, e.g. "symlinks yes, hardlinks yes, case-sensitive no, reflinks no"
+func (c FilesystemCapabilities) String() string {
+	yesNo := func(name string, ok bool) string {
+		if ok {
+			return name + " yes"
+		}
+		return name + " no"
+	}
+	return strings.Join([]string{
+		yesNo("symlinks", c.Symlinks),
+		yesNo("hardlinks", c.Hardlinks),
+		yesNo("case-sensitive", c.CaseSensitive),
+		yesNo("reflinks", c.Reflinks),
+	}, ", ")
+}
+
+// FileHash is the SHA-256 and size of a file's content
+type FileHash struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// CopyTotals is how much an installation copied
+type CopyTotals struct {
+	Bytes int64 `json:"bytes"`
+	Files int   `json:"files"`
+}
+
+// How the installation copies files
+const (
+	CopyStrategyCopy    = "copy"    // Read and write the content
+	CopyStrategyReflink = "reflink" // Clone files where the file system allows, copy otherwise
+)
+
+// How copies treat the symlinks they find
+const (
+	// Recreate links into the copied tree, absolute ones as relative; links
+	// that lead out of it are refused
+	SymlinkPolicyRewriteRelative = "rewrite-relative"
+	// Leave links out of the copy
+	SymlinkPolicySkip = "skip"
+	// Copy what links point to
+	SymlinkPolicyFollow = "follow"
+	// Recreate links unchanged, for backups and the trash, whose copies stand
+	// in for the original; not offered for template content
+	SymlinkPolicyKeep = "keep"
+)
+
+// GetSymlinkPolicies returns the symlink policies for copying template content
+func GetSymlinkPolicies() []string {
+	return []string{SymlinkPolicyRewriteRelative, SymlinkPolicySkip, SymlinkPolicyFollow}
+}
+
+// ValidateSymlinkPolicy checks that a symlink policy for copying template
+// content is known; empty means rewrite-relative
+func ValidateSymlinkPolicy(policy string) error {
+	if policy == "" || slices.Contains(GetSymlinkPolicies(), policy) {
+		return nil
+	}
+	return NewValidationError("symlink-policy", policy, "symlink policy must be rewrite-relative, skip or follow")
+}
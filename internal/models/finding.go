@@ -0,0 +1,74 @@
+package models
+
+// Severity ranks how much a status finding affects an installation
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The framework does not work as installed
+	SeverityWarning Severity = "warning" // The framework works, but something needs attention
+	SeverityInfo    Severity = "info"    // Worth knowing, nothing to fix
+)
+
+// Severities returns all severities, most severe first
+func Severities() []Severity {
+	return []Severity{SeverityError, SeverityWarning, SeverityInfo}
+}
+
+// AtLeast reports whether s is as severe as other or more
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// FindingID identifies a kind of status finding for scripts and tooling
+type FindingID string
+
+// Status finding IDs
+const (
+	FindingIssue                FindingID = "issue" // Findings without a more specific ID
+	FindingFrameworkMissing     FindingID = "framework-missing"
+	FindingFrameworkDirMissing  FindingID = "framework-dir-missing"
+	FindingTemplateInfoInvalid  FindingID = "template-info-invalid"
+	FindingDevMode              FindingID = "dev-mode"
+	FindingDevLinkBroken        FindingID = "dev-link-broken"
+	FindingClaudeMissing        FindingID = "claude-missing"
+	FindingClaudeDirMissing     FindingID = "claude-dir-missing"
+	FindingCodexMissing         FindingID = "codex-missing"
+	FindingCodexDirMissing      FindingID = "codex-dir-missing"
+	FindingSymlinkUnchecked     FindingID = "symlink-unchecked"
+	FindingSymlinksBroken       FindingID = "symlinks-broken"
+	FindingSymlinksMissing      FindingID = "symlinks-missing"
+	FindingSymlinkEscapes       FindingID = "symlink-escapes"
+	FindingNotWritable          FindingID = "not-writable"
+	FindingPartialInstallation  FindingID = "partial-installation"
+	FindingParentInstallation   FindingID = "parent-installation"
+	FindingSettingsInvalid      FindingID = "settings-invalid"
+	FindingHookBroken           FindingID = "hook-broken"
+	FindingCursorRulesMissing   FindingID = "cursor-rules-missing"
+	FindingCursorRulesOutdated  FindingID = "cursor-rules-outdated"
+	FindingConventionsMissing   FindingID = "conventions-missing"
+	FindingToolConfigIncomplete FindingID = "tool-config-incomplete"
+	FindingFastCheck            FindingID = "fast-check"
+	FindingBackupsAccumulated   FindingID = "backups-accumulated"
+	FindingManifestInvalid      FindingID = "manifest-invalid"
+	FindingLegacyLayout         FindingID = "legacy-layout"
+)
+
+// Finding is a single result of a status check
+type Finding struct {
+	ID       FindingID `json:"id"`
+	Severity Severity  `json:"severity"`
+	Message  string    `json:"message"`
+}
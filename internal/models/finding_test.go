@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestStatusInfo_AddFinding(t *testing.T) {
+	status := NewStatusInfo("/project")
+	status.AddFinding(FindingDevMode, SeverityInfo, "Framework is linked from a template checkout")
+
+	if status.HasIssues() {
+		t.Errorf("HasIssues() with only info findings = true, want false (issues: %v)", status.Issues)
+	}
+	if status.HasFindingsAtLeast(SeverityWarning) {
+		t.Error("HasFindingsAtLeast(warning) with only info findings = true, want false")
+	}
+
+	status.AddFinding(FindingParentInstallation, SeverityWarning, "Parent directory also contains an installation")
+	status.AddIssue("Something is broken")
+
+	if len(status.Issues) != 2 {
+		t.Errorf("Issues = %v, want the warning and the error", status.Issues)
+	}
+	if !status.HasFindingsAtLeast(SeverityError) {
+		t.Error("HasFindingsAtLeast(error) = false, want true")
+	}
+	errorFindings := status.FindingsOf(SeverityError)
+	if len(errorFindings) != 1 || errorFindings[0].ID != FindingIssue {
+		t.Errorf("FindingsOf(error) = %v, want one %s finding", errorFindings, FindingIssue)
+	}
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		other    Severity
+		want     bool
+	}{
+		{SeverityError, SeverityWarning, true},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityWarning, SeverityError, false},
+		{SeverityInfo, SeverityInfo, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.AtLeast(tt.other); got != tt.want {
+			t.Errorf("%s.AtLeast(%s) = %v, want %v", tt.severity, tt.other, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+// Integrations are the AI tool directories an installation sets up
+const (
+	IntegrationClaude   = "claude"   // .claude: agents, commands, hooks and settings.json
+	IntegrationCodex    = "codex"    // .codex: prompts, hooks and config.toml
+	IntegrationCursor   = "cursor"   // .cursor: rules
+	IntegrationAider    = "aider"    // .aider.conf.yml: reads the framework conventions
+	IntegrationOpenCode = "opencode" // opencode.json: instructions from the framework conventions
+)
+
+// Ways the cursor integration installs the template's rules
+const (
+	CursorModeSymlink = "symlink" // Link .cursor/rules/strategic to the template's rules
+	CursorModeCopy    = "copy"    // Copy the rules, for setups that do not follow symlinks
+)
+
+// GetIntegrations returns all known integrations
+func GetIntegrations() []string {
+	return []string{IntegrationClaude, IntegrationCodex, IntegrationCursor, IntegrationAider, IntegrationOpenCode}
+}
+
+// DefaultIntegrations returns the integrations set up when none are selected
+func DefaultIntegrations() []string {
+	return []string{IntegrationClaude, IntegrationCodex}
+}
+
+// ParseIntegrations parses a comma-separated list of integrations, e.g.
+// "claude,codex,cursor". The claude integration is required, since the
+// framework's hooks and settings live in .claude.
+func ParseIntegrations(value string) ([]string, error) {
+	var integrations []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || slices.Contains(integrations, name) {
+			continue
+		}
+		if !slices.Contains(GetIntegrations(), name) {
+			return nil, NewValidationError("integrations", value,
+				fmt.Sprintf("unknown integration %q, must be one of: %s", name, strings.Join(GetIntegrations(), ", ")))
+		}
+		integrations = append(integrations, name)
+	}
+
+	if !slices.Contains(integrations, IntegrationClaude) {
+		return nil, NewValidationError("integrations", value, "must include claude")
+	}
+	return integrations, nil
+}
+
+// ValidateSymlinkStyle checks that a symlink style is known; empty selects the default
+func ValidateSymlinkStyle(style string) error {
+	switch style {
+	case "", config.SymlinkStyleRelative, config.SymlinkStyleAbsolute:
+		return nil
+	}
+	return NewValidationError("symlink-style", style, "symlink style must be relative or absolute")
+}
+
+// ValidateCursorMode checks that a cursor mode is known; empty selects the default
+func ValidateCursorMode(mode string) error {
+	switch mode {
+	case "", CursorModeSymlink, CursorModeCopy:
+		return nil
+	}
+	return NewValidationError("cursor-mode", mode, "cursor mode must be symlink or copy")
+}
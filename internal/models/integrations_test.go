@@ -0,0 +1,34 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseIntegrations(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"claude", []string{IntegrationClaude}, false},
+		{"claude,codex,cursor", []string{IntegrationClaude, IntegrationCodex, IntegrationCursor}, false},
+		{" Claude , cursor,claude ", []string{IntegrationClaude, IntegrationCursor}, false},
+		{"codex", nil, true},
+		{"claude,aider,opencode", []string{IntegrationClaude, IntegrationAider, IntegrationOpenCode}, false},
+		{"claude,vim", nil, true},
+		{"", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseIntegrations(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseIntegrations(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseIntegrations(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
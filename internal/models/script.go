@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// ScriptInfo describes an installation script shipped with a template
+type ScriptInfo struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ScriptResult is the outcome of running an installation script
+type ScriptResult struct {
+	ScriptInfo
+	Duration        time.Duration `json:"duration"`
+	ExitCode        int           `json:"exit_code"`        // -1 when the script could not be started
+	Output          string        `json:"output"`           // End of the combined stdout and stderr
+	OutputTruncated bool          `json:"output_truncated"` // Output was longer than what was kept
+	Isolation       string        `json:"isolation"`        // ScriptIsolationNone or ScriptIsolationDocker
+}
+
+// Succeeded returns true if the script exited with code 0
+func (r ScriptResult) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+// HookCheckResult is the outcome of running a hook from settings.json with a
+// synthetic payload, as Claude Code would
+type HookCheckResult struct {
+	Event    string        `json:"event"`
+	Matcher  string        `json:"matcher,omitempty"`
+	Command  string        `json:"command"`
+	Script   string        `json:"script"` // File name of the hook script
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`       // -1 when the hook could not be started or timed out
+	Output   string        `json:"output"`          // End of the combined stdout and stderr
+	Error    string        `json:"error,omitempty"` // Why the hook could not be run to completion
+}
+
+// Succeeded returns true if the hook exited with code 0
+func (r HookCheckResult) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+// What an installation does when a script exits with a non-zero code
+const (
+	ScriptErrorAbort    = "abort"    // Stop the installation
+	ScriptErrorContinue = "continue" // Warn and carry on
+	ScriptErrorRollback = "rollback" // Undo the installation, then stop
+)
+
+// ValidateScriptErrorPolicy checks that a script error policy is known; empty means abort
+func ValidateScriptErrorPolicy(policy string) error {
+	switch policy {
+	case "", ScriptErrorAbort, ScriptErrorContinue, ScriptErrorRollback:
+		return nil
+	}
+	return NewValidationError("on-script-error", policy, "script error policy must be abort, continue or rollback")
+}
+
+// When init asks for confirmation
+const (
+	ConfirmAlways          = "always"           // At every prompt
+	ConfirmDestructiveOnly = "destructive-only" // Only for installations that destroy files; other prompts take their defaults
+	ConfirmNever           = "never"            // Never; every prompt takes its default, like --yes
+)
+
+// ValidateConfirmMode checks that a confirmation mode is known; empty means always
+func ValidateConfirmMode(mode string) error {
+	switch mode {
+	case "", ConfirmAlways, ConfirmDestructiveOnly, ConfirmNever:
+		return nil
+	}
+	return NewValidationError("confirm", mode, "confirmation mode must be never, destructive-only or always")
+}
+
+// Where installation scripts run
+const (
+	ScriptIsolationNone   = "none"   // Directly, with the user's permissions
+	ScriptIsolationDocker = "docker" // In a container with only the project mounted
+)
+
+// ValidateScriptIsolation checks that a script isolation mode is known; empty means none
+func ValidateScriptIsolation(mode string) error {
+	switch mode {
+	case "", ScriptIsolationNone, ScriptIsolationDocker:
+		return nil
+	}
+	return NewValidationError("script-isolation", mode, "script isolation must be none or docker")
+}
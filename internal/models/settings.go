@@ -1,5 +1,13 @@
 package models
 
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
 // ClaudeSettings represents the structure of Claude Code settings.json
 type ClaudeSettings struct {
 	Hooks       *HooksSection       `json:"hooks,omitempty"`
@@ -44,8 +52,37 @@ func GetHookTypesInOrder() []string {
 	}
 }
 
+// Matchers returns the matchers configured for a hook type
+func (h *HooksSection) Matchers(hookType string) []HookMatcher {
+	if h == nil {
+		return nil
+	}
+
+	switch hookType {
+	case "PreToolUse":
+		return h.PreToolUse
+	case "PostToolUse":
+		return h.PostToolUse
+	case "Stop":
+		return h.Stop
+	case "PreCompact":
+		return h.PreCompact
+	case "Notification":
+		return h.Notification
+	}
+	return nil
+}
+
 // IsStrategicHook checks if a hook command is one of our strategic hooks
 func IsStrategicHook(command string) bool {
+	_, ok := StrategicHookScript(command)
+	return ok
+}
+
+// StrategicHookScript returns the strategic script a hook command runs. Every
+// hook runner form is recognized because the script is matched as a separate
+// argument, wherever it appears and however it is quoted.
+func StrategicHookScript(command string) (string, bool) {
 	strategicHooks := []string{
 		"block-skip-hooks.py",
 		"block-config-writes.py",
@@ -54,16 +91,77 @@ func IsStrategicHook(command string) bool {
 		"notification-hook.py",
 	}
 
-	for _, hook := range strategicHooks {
-		if contains(command, hook) {
-			return true
+	for _, arg := range strings.Fields(command) {
+		arg = strings.Trim(arg, `"'`)
+		name := arg[strings.LastIndexAny(arg, `/\`)+1:]
+		if slices.Contains(strategicHooks, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// StrategicHookLink returns the name of the hooks symlink a strategic hook
+// command runs its script through, e.g. strategic-research for a named
+// installation. Commands with the script elsewhere belong to the default
+// installation.
+func StrategicHookLink(command string) string {
+	scriptName, ok := StrategicHookScript(command)
+	if !ok {
+		return ""
+	}
+	for _, arg := range strings.Fields(command) {
+		arg = strings.ReplaceAll(strings.Trim(arg, `"'`), `\`, "/")
+		if path.Base(arg) != scriptName {
+			continue
+		}
+		if link := path.Base(path.Dir(arg)); strings.HasPrefix(link, config.StrategicLink+"-") {
+			return link
+		}
+	}
+	return config.StrategicLink
+}
+
+// Hook runners that strategic hook commands can be written for
+const (
+	HookRunnerPython = "python"
+	HookRunnerUV     = "uv"
+	HookRunnerPoetry = "poetry"
+
+	// HookRunnerCustomPrefix introduces a user supplied runner command, e.g. "custom:pipenv run python"
+	HookRunnerCustomPrefix = "custom:"
+)
+
+// ValidateHookRunner checks that a hook runner is known or a non-empty custom command
+func ValidateHookRunner(runner string) error {
+	switch runner {
+	case "", HookRunnerPython, HookRunnerUV, HookRunnerPoetry:
+		return nil
+	}
+
+	if command, ok := strings.CutPrefix(runner, HookRunnerCustomPrefix); ok {
+		if strings.TrimSpace(command) == "" {
+			return NewValidationError("hook-runner", runner, "custom hook runner requires a command, e.g. custom:pipenv run python")
 		}
+		return nil
 	}
-	return false
+
+	return NewValidationError("hook-runner", runner, "hook runner must be python, uv, poetry or custom:<command>")
 }
 
-// contains is a helper function to check if a string contains a substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && s[len(s)-len(substr):] == substr ||
-		len(s) > len(substr) && s[len(s)-len(substr)-1:len(s)-len(substr)] == "/" && s[len(s)-len(substr):] == substr
+// HookRunnerCommand returns the command placed before a strategic hook script.
+// The python runner uses the given interpreter.
+func HookRunnerCommand(runner, python string) string {
+	switch runner {
+	case HookRunnerUV:
+		return "uv run"
+	case HookRunnerPoetry:
+		return "poetry run python"
+	}
+
+	if command, ok := strings.CutPrefix(runner, HookRunnerCustomPrefix); ok {
+		return strings.TrimSpace(command)
+	}
+
+	return python
 }
@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestValidateHookRunner(t *testing.T) {
+	tests := []struct {
+		runner  string
+		wantErr bool
+	}{
+		{"", false},
+		{HookRunnerPython, false},
+		{HookRunnerUV, false},
+		{HookRunnerPoetry, false},
+		{"custom:pipenv run python", false},
+		{"custom:", true},
+		{"custom:   ", true},
+		{"pipenv", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runner, func(t *testing.T) {
+			err := ValidateHookRunner(tt.runner)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHookRunner(%q) error = %v, wantErr %v", tt.runner, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookRunnerCommand(t *testing.T) {
+	tests := []struct {
+		runner string
+		want   string
+	}{
+		{"", "python3"},
+		{HookRunnerPython, "python3"},
+		{HookRunnerUV, "uv run"},
+		{HookRunnerPoetry, "poetry run python"},
+		{"custom: pipenv run python ", "pipenv run python"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runner, func(t *testing.T) {
+			if got := HookRunnerCommand(tt.runner, "python3"); got != tt.want {
+				t.Errorf("HookRunnerCommand(%q) = %q, want %q", tt.runner, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
+	"slices"
 	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 )
 
@@ -15,6 +17,16 @@ const (
 	InstallationTypeOverwrite InstallationType = "Full Overwrite"
 )
 
+// TemplateSource describes where template content is read from during installation
+type TemplateSource string
+
+const (
+	TemplateSourceRepository TemplateSource = "repository"
+	TemplateSourceBundle     TemplateSource = "bundle"
+	TemplateSourceVendored   TemplateSource = "vendored copy"
+	TemplateSourceDev        TemplateSource = "local checkout (dev mode)"
+)
+
 // StatusInfo represents the overall installation status
 type StatusInfo struct {
 	// Basic installation status
@@ -26,14 +38,44 @@ type StatusInfo struct {
 	// Template information
 	InstalledTemplate *templates.TemplateInfo `json:"installed_template,omitempty"`
 
+	// Tool directories the installation sets up, as recorded in template-info
+	Integrations []string `json:"integrations"`
+
+	// Template checkout the framework directories link to, set for dev mode installs
+	DevTemplatePath string `json:"dev_template_path,omitempty"`
+
 	// Script detection
 	HasPreInstallScript  bool `json:"has_pre_install_script"`
 	HasPostInstallScript bool `json:"has_post_install_script"`
 
+	// Ancestor directories that contain their own installation
+	ParentInstallations []string `json:"parent_installations,omitempty"`
+
 	// Detailed component status
 	Symlinks      []SymlinkStatus `json:"symlinks"`
 	CodexSymlinks []SymlinkStatus `json:"codex_symlinks"`
-	Issues        []string        `json:"issues"`
+	Hooks         []HookStatus    `json:"hooks"`
+	Issues        []string        `json:"issues"` // Messages of the error and warning findings
+
+	// Leveled results of the checks
+	Findings []Finding `json:"findings"`
+
+	// Differences between the installed framework and what this CLI installs
+	Drift []string `json:"drift,omitempty"`
+
+	// Framework files changed since installation, nil without an install manifest
+	DriftCheck *DriftCheck `json:"drift_check,omitempty"`
+
+	// Backups of the project, nil when they were not inventoried
+	Backups *BackupInventory `json:"backups,omitempty"`
+
+	// Named installation that was checked, empty for the default one, and the
+	// named installations found in the project
+	Instance  string   `json:"instance,omitempty"`
+	Instances []string `json:"instances,omitempty"`
+
+	// Cursor rules in .cursor/rules/strategic, nil when they are not installed
+	CursorRules *CursorRulesStatus `json:"cursor_rules,omitempty"`
 
 	// Installation metadata (deprecated - use InstalledTemplate instead)
 	InstallationDate *time.Time `json:"installation_date,omitempty"`
@@ -55,6 +97,131 @@ type SymlinkStatus struct {
 	Target string `json:"target"`          // Target path the symlink points to
 	Exists bool   `json:"exists"`          // Whether the symlink file exists
 	Error  string `json:"error,omitempty"` // Error message if validation failed
+
+	Expected     string `json:"expected,omitempty"`      // Target the symlink should point to
+	TargetExists *bool  `json:"target_exists,omitempty"` // Whether the target resolves, nil when not followed
+	Escapes      bool   `json:"escapes,omitempty"`       // Whether the target resolves outside the framework directory
+}
+
+// Ways a framework file can differ from the install manifest
+const (
+	FileDriftModified = "modified"
+	FileDriftMissing  = "missing"
+)
+
+// FileDrift is a framework file that changed since installation
+type FileDrift struct {
+	Path   string `json:"path"`   // Relative to .strategic-claude-basic
+	Change string `json:"change"` // FileDriftModified or FileDriftMissing
+}
+
+// DriftCheck is the result of comparing the framework files with the install manifest
+type DriftCheck struct {
+	Files   int         `json:"files"`   // Files in the manifest
+	Hashed  int         `json:"hashed"`  // Files whose content was compared
+	Deep    bool        `json:"deep"`    // Whether every file was hashed
+	Changed []FileDrift `json:"changed"` // Modified and missing files
+}
+
+// AddFile records a changed framework file
+func (d *DriftCheck) AddFile(path, change string) {
+	d.Changed = append(d.Changed, FileDrift{Path: path, Change: change})
+}
+
+// BackupInventory summarizes the backups of a project
+type BackupInventory struct {
+	Dir       string     `json:"dir"`              // Backups directory of the project
+	Count     int        `json:"count"`            // Number of backups, including legacy ones
+	Legacy    int        `json:"legacy"`           // Backups older versions left outside Dir
+	TotalSize int64      `json:"total_size"`       // Total size of the backups in bytes
+	Newest    *time.Time `json:"newest,omitempty"` // When the newest backup was created
+	Oldest    *time.Time `json:"oldest,omitempty"` // When the oldest backup was created
+}
+
+// BackupInfo records what a framework backup contains, see config.BackupInfoFile
+type BackupInfo struct {
+	Template    string    `json:"template,omitempty"`     // Template the backed up installation came from
+	Commit      string    `json:"commit,omitempty"`       // Template commit it was installed from
+	InstalledAt string    `json:"installed_at,omitempty"` // When it was installed
+	CreatedAt   time.Time `json:"created_at"`             // When the backup was taken
+}
+
+// Label returns the template and short commit of the backup, e.g. "main@1a2b3c4"
+func (b BackupInfo) Label() string {
+	commit := b.Commit
+	if len(commit) > config.BackupCommitLength {
+		commit = commit[:config.BackupCommitLength]
+	}
+	switch {
+	case b.Template == "":
+		return commit
+	case commit == "":
+		return b.Template
+	}
+	return b.Template + "@" + commit
+}
+
+// NeedsPruning reports whether there are more backups than the CLI keeps, or
+// backups older than it keeps them
+func (b BackupInventory) NeedsPruning() bool {
+	cfg := config.Current()
+	return b.Count > cfg.MaxBackups || (b.Oldest != nil && time.Since(*b.Oldest) > cfg.MaxBackupAge)
+}
+
+// CursorRulesStatus represents the Cursor rules installed from the template
+type CursorRulesStatus struct {
+	Path   string   `json:"path"`             // Full path to .cursor/rules/strategic
+	Mode   string   `json:"mode"`             // CursorModeSymlink or CursorModeCopy
+	Rules  int      `json:"rules"`            // Number of rule files found
+	Issues []string `json:"issues,omitempty"` // Missing or outdated rules
+}
+
+// Valid returns true if the rules match the template
+func (c CursorRulesStatus) Valid() bool {
+	return len(c.Issues) == 0
+}
+
+// HookStatus represents the status of a hook script referenced in settings.json
+type HookStatus struct {
+	Event            string `json:"event"`                 // Hook event (e.g., "PreToolUse")
+	Command          string `json:"command"`               // Command as configured in settings.json
+	Script           string `json:"script"`                // Resolved path to the hook script
+	Interpreter      string `json:"interpreter,omitempty"` // Interpreter that runs the script
+	Exists           bool   `json:"exists"`                // Whether the script resolves to a file
+	Executable       bool   `json:"executable"`            // Whether the script has an execute bit
+	InterpreterFound bool   `json:"interpreter_found"`     // Whether the interpreter can be resolved
+	Error            string `json:"error,omitempty"`       // Reason the hook is broken
+}
+
+// Valid returns true if the hook script can be run
+func (h HookStatus) Valid() bool {
+	return h.Exists && h.Executable && h.InterpreterFound
+}
+
+// What an installation does to a file of the framework directory
+const (
+	PlannedCreate    = "create"    // The file is new
+	PlannedReplace   = "replace"   // The installed file is overwritten with different content
+	PlannedUnchanged = "unchanged" // The installed file is overwritten with the same content
+	PlannedPreserve  = "preserve"  // The installed file is kept
+	PlannedSkip      = "skip"      // The template file is not installed
+	PlannedRemove    = "remove"    // The installed file is not in the template and is removed
+	PlannedLink      = "link"      // The directory is linked to the template checkout (dev mode)
+)
+
+// PlannedFile is what an installation does to one path, relative to the target
+// directory with slashes
+type PlannedFile struct {
+	Path    string `json:"path"`
+	Action  string `json:"action"`
+	Changed bool   `json:"changed"` // The path's content differs after the installation
+}
+
+// PlannedStep is an installation step and what it does
+type PlannedStep struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Skipped     bool   `json:"skipped"`
 }
 
 // InstallationPlan represents what will happen during an installation
@@ -64,11 +231,17 @@ type InstallationPlan struct {
 	InstallationType InstallationType `json:"installation_type"`
 
 	// Template information
-	Template templates.Template `json:"template"`
+	Template       templates.Template `json:"template"`
+	TemplateSource TemplateSource     `json:"template_source"`
 
-	// Script information
-	HasPreInstallScript  bool `json:"has_pre_install_script"`
-	HasPostInstallScript bool `json:"has_post_install_script"`
+	// Script information, known once the template has been fetched
+	HasPreInstallScript  bool         `json:"has_pre_install_script"`
+	HasPostInstallScript bool         `json:"has_post_install_script"`
+	Scripts              []ScriptInfo `json:"scripts,omitempty"`
+
+	// Where installation scripts run: ScriptIsolationDocker only when it was
+	// asked for and Docker is available
+	ScriptIsolation string `json:"script_isolation"`
 
 	// File operations
 	ExistingFiles []string `json:"existing_files"` // Files that already exist
@@ -76,14 +249,54 @@ type InstallationPlan struct {
 	WillPreserve  []string `json:"will_preserve"`  // Files that will be preserved
 	WillCreate    []string `json:"will_create"`    // New files that will be created
 
+	// User directories a full overwrite keeps instead of replacing
+	CarryOver []string `json:"carry_over,omitempty"`
+
+	// What happens to each file of the framework directory, known once the
+	// template has been fetched and compared with the target
+	Files []PlannedFile `json:"files,omitempty"`
+
 	// Directory operations
 	DirectoriesToCreate []string `json:"directories_to_create"`
 	SymlinksToCreate    []string `json:"symlinks_to_create"`
 	SymlinksToUpdate    []string `json:"symlinks_to_update"`
+	SymlinkStyle        string   `json:"symlink_style"` // How symlink targets are written: relative or absolute
+
+	// Whether the core, guides and templates files are made read-only
+	ReadOnlyCore bool `json:"readonly_core,omitempty"`
+
+	// Command written before strategic hook scripts in settings.json
+	HookRunner  string `json:"hook_runner,omitempty"`
+	HookCommand string `json:"hook_command,omitempty"`
+
+	// Whether hook dependencies are installed into a dedicated virtualenv
+	InstallHookDeps bool `json:"install_hook_deps"`
+
+	// Whether the managed block is written to .envrc
+	Direnv bool `json:"direnv"`
+
+	// Components installed, nil for all of them
+	Components []string `json:"components,omitempty"`
+
+	// Tool directories set up by the installation, and how Cursor rules are installed
+	Integrations []string `json:"integrations"`
+	CursorMode   string   `json:"cursor_mode,omitempty"`
+
+	// Git work tree containing the target, empty when it is not version controlled
+	GitRepoRoot string `json:"git_repo_root,omitempty"`
 
-	// Backup information
-	BackupRequired bool   `json:"backup_required"`
-	BackupDir      string `json:"backup_dir,omitempty"`
+	// What the target's file system supports, nil when it could not be probed,
+	// and how files are copied as a result
+	Filesystem   *FilesystemCapabilities `json:"filesystem,omitempty"`
+	CopyStrategy string                  `json:"copy_strategy"`
+
+	// The installation steps in the order they run
+	Steps []PlannedStep `json:"steps,omitempty"`
+
+	// Backup information, with what the backup will contain
+	BackupRequired bool        `json:"backup_required"`
+	BackupDir      string      `json:"backup_dir,omitempty"`
+	BackupInfo     *BackupInfo `json:"backup_info,omitempty"`
 
 	// Validation results
 	HasConflicts bool     `json:"has_conflicts"`
@@ -100,7 +313,10 @@ func NewStatusInfo(targetDir string) *StatusInfo {
 		CodexDir:               false,
 		Symlinks:               make([]SymlinkStatus, 0),
 		CodexSymlinks:          make([]SymlinkStatus, 0),
+		Hooks:                  make([]HookStatus, 0),
 		Issues:                 make([]string, 0),
+		Findings:               make([]Finding, 0),
+		Integrations:           DefaultIntegrations(),
 		TargetDir:              targetDir,
 		StrategicClaudeDirPath: "",
 		ClaudeDirPath:          "",
@@ -114,6 +330,7 @@ func NewInstallationPlan(targetDir string, installType InstallationType, templat
 		TargetDir:           targetDir,
 		InstallationType:    installType,
 		Template:            template,
+		TemplateSource:      TemplateSourceRepository,
 		ExistingFiles:       make([]string, 0),
 		WillReplace:         make([]string, 0),
 		WillPreserve:        make([]string, 0),
@@ -129,9 +346,38 @@ func NewInstallationPlan(targetDir string, installType InstallationType, templat
 	}
 }
 
-// AddIssue adds an issue to the status info
+// AddIssue adds an error without a more specific finding ID to the status info
 func (s *StatusInfo) AddIssue(issue string) {
-	s.Issues = append(s.Issues, issue)
+	s.AddFinding(FindingIssue, SeverityError, issue)
+}
+
+// AddFinding adds a finding to the status info; errors and warnings are also issues
+func (s *StatusInfo) AddFinding(id FindingID, severity Severity, message string) {
+	s.Findings = append(s.Findings, Finding{ID: id, Severity: severity, Message: message})
+	if severity.AtLeast(SeverityWarning) {
+		s.Issues = append(s.Issues, message)
+	}
+}
+
+// FindingsOf returns the findings with the given severity
+func (s *StatusInfo) FindingsOf(severity Severity) []Finding {
+	var findings []Finding
+	for _, finding := range s.Findings {
+		if finding.Severity == severity {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// HasFindingsAtLeast returns true if any finding is as severe as severity or more
+func (s *StatusInfo) HasFindingsAtLeast(severity Severity) bool {
+	for _, finding := range s.Findings {
+		if finding.Severity.AtLeast(severity) {
+			return true
+		}
+	}
+	return false
 }
 
 // AddSymlink adds a symlink status to the status info
@@ -144,6 +390,17 @@ func (s *StatusInfo) AddCodexSymlink(symlink SymlinkStatus) {
 	s.CodexSymlinks = append(s.CodexSymlinks, symlink)
 }
 
+// AddDrift records a difference between the installation and what this CLI installs
+func (s *StatusInfo) AddDrift(drift string) {
+	s.Drift = append(s.Drift, drift)
+}
+
+// HasDrift returns true if the installation differs from what this CLI installs,
+// or framework files changed since installation
+func (s *StatusInfo) HasDrift() bool {
+	return len(s.Drift) > 0 || (s.DriftCheck != nil && len(s.DriftCheck.Changed) > 0)
+}
+
 // HasIssues returns true if there are any issues
 func (s *StatusInfo) HasIssues() bool {
 	return len(s.Issues) > 0
@@ -160,6 +417,22 @@ func (s *StatusInfo) ValidSymlinks() int {
 	return count
 }
 
+// AddHook adds a hook status to the status info
+func (s *StatusInfo) AddHook(hook HookStatus) {
+	s.Hooks = append(s.Hooks, hook)
+}
+
+// BrokenHooks returns the hooks that cannot be run
+func (s *StatusInfo) BrokenHooks() []HookStatus {
+	var broken []HookStatus
+	for _, hook := range s.Hooks {
+		if !hook.Valid() {
+			broken = append(broken, hook)
+		}
+	}
+	return broken
+}
+
 // ValidCodexSymlinks returns the number of valid Codex symlinks
 func (s *StatusInfo) ValidCodexSymlinks() int {
 	count := 0
@@ -171,6 +444,33 @@ func (s *StatusInfo) ValidCodexSymlinks() int {
 	return count
 }
 
+// HasIntegration reports whether the installation sets up the named integration
+func (s *StatusInfo) HasIntegration(name string) bool {
+	return slices.Contains(s.Integrations, name)
+}
+
+// HasIntegration reports whether the plan sets up the named integration
+func (p *InstallationPlan) HasIntegration(name string) bool {
+	return slices.Contains(p.Integrations, name)
+}
+
+// HasComponent reports whether the installation installs the named component
+func (p *InstallationPlan) HasComponent(name string) bool {
+	return len(p.Components) == 0 || slices.Contains(p.Components, name)
+}
+
+// IsPartial reports whether the installation installs only some components
+func (p *InstallationPlan) IsPartial() bool {
+	return len(p.Components) > 0
+}
+
+// HasScript reports whether the template comes with the named installation script
+func (p *InstallationPlan) HasScript(name string) bool {
+	return slices.ContainsFunc(p.Scripts, func(script ScriptInfo) bool {
+		return script.Name == name
+	})
+}
+
 // AddWarning adds a warning to the installation plan
 func (p *InstallationPlan) AddWarning(warning string) {
 	p.Warnings = append(p.Warnings, warning)
@@ -187,6 +487,15 @@ func (p *InstallationPlan) IsValid() bool {
 	return !p.HasConflicts && len(p.Errors) == 0
 }
 
+// IsDestructive reports whether the installation destroys files: a full
+// overwrite, or replacing installed files without taking a backup first
+func (p *InstallationPlan) IsDestructive(noBackup bool) bool {
+	if p.InstallationType == InstallationTypeOverwrite {
+		return true
+	}
+	return len(p.WillReplace) > 0 && (noBackup || !p.BackupRequired)
+}
+
 // RequiresConfirmation returns true if the plan requires user confirmation
 func (p *InstallationPlan) RequiresConfirmation() bool {
 	return len(p.WillReplace) > 0 || p.HasConflicts || len(p.Warnings) > 0
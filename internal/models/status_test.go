@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestInstallationPlan_IsDestructive(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     InstallationPlan
+		noBackup bool
+		want     bool
+	}{
+		{"new installation", InstallationPlan{InstallationType: InstallationTypeNew, WillCreate: []string{"core"}}, false, false},
+		{"update with backup", InstallationPlan{InstallationType: InstallationTypeUpdate, WillReplace: []string{"core"}, BackupRequired: true}, false, false},
+		{"update without backup", InstallationPlan{InstallationType: InstallationTypeUpdate, WillReplace: []string{"core"}, BackupRequired: true}, true, true},
+		{"update replacing nothing", InstallationPlan{InstallationType: InstallationTypeUpdate}, true, false},
+		{"overwrite with backup", InstallationPlan{InstallationType: InstallationTypeOverwrite, BackupRequired: true}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.plan.IsDestructive(tt.noBackup); got != tt.want {
+				t.Errorf("IsDestructive(%v) = %v, want %v", tt.noBackup, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// StepTiming is the time spent in one step of an operation
+type StepTiming struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// StepTimings lists the steps of an operation in the order they ran
+type StepTimings []StepTiming
+
+// Total returns the time spent in all steps
+func (t StepTimings) Total() time.Duration {
+	var total time.Duration
+	for _, step := range t {
+		total += step.Duration
+	}
+	return total
+}
@@ -0,0 +1,16 @@
+package models
+
+// UpdateStatus compares an installation with the template commit this CLI
+// installs for it
+type UpdateStatus struct {
+	TargetDir       string         `json:"target_dir"`
+	Template        string         `json:"template"`
+	Source          TemplateSource `json:"source"`                     // Where the target commit comes from
+	InstalledCommit string         `json:"installed_commit,omitempty"` // Commit the installation was made from
+	TargetCommit    string         `json:"target_commit,omitempty"`    // Commit an update installs
+	UpdateAvailable bool           `json:"update_available"`
+
+	// Why the installation cannot be updated, e.g. because dev mode
+	// installations follow their checkout; empty when it can
+	Unsupported string `json:"unsupported,omitempty"`
+}
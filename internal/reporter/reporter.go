@@ -0,0 +1,159 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Output formats selected with --log-format
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatSilent = "silent"
+)
+
+// Event kinds written by the JSON reporter
+const (
+	EventInfo     = "info"
+	EventWarn     = "warn"
+	EventStep     = "step"
+	EventProgress = "progress"
+)
+
+// Reporter receives what services have to tell the user while they work.
+// Services report through it instead of printing, so commands decide where
+// and how the output appears.
+type Reporter interface {
+	// Info reports something the user should know, such as a file written
+	Info(message string)
+	// Warn reports a problem that did not stop the operation
+	Warn(message string)
+	// Step reports that a stage of a longer operation begins
+	Step(name string)
+	// Progress reports that current of total items of a stage are done
+	Progress(current, total int, message string)
+}
+
+// GetFormats returns the supported output formats
+func GetFormats() []string {
+	return []string{FormatText, FormatJSON, FormatSilent}
+}
+
+// New returns the reporter for a format. Text goes to stdout, alongside the
+// command output; JSON goes to stderr so JSON printed on stdout stays valid.
+// Steps and progress only appear in text when verbose is set.
+func New(format string, verbose bool) (Reporter, error) {
+	switch format {
+	case FormatText, "":
+		return NewText(os.Stdout, verbose), nil
+	case FormatJSON:
+		return NewJSON(os.Stderr), nil
+	case FormatSilent:
+		return NewSilent(), nil
+	default:
+		return nil, models.NewValidationError("log-format", format,
+			fmt.Sprintf("must be one of: %s", strings.Join(GetFormats(), ", ")))
+	}
+}
+
+// Default returns the reporter services use until one is injected
+func Default() Reporter {
+	return NewText(os.Stdout, false)
+}
+
+// textReporter writes messages the way the commands display them
+type textReporter struct {
+	w       io.Writer
+	verbose bool
+}
+
+// NewText returns a reporter writing human-readable lines to w
+func NewText(w io.Writer, verbose bool) Reporter {
+	return &textReporter{w: w, verbose: verbose}
+}
+
+func (r *textReporter) Info(message string) {
+	fmt.Fprintf(r.w, utils.Symbols("ℹ️  %s\n"), message)
+}
+
+func (r *textReporter) Warn(message string) {
+	fmt.Fprintf(r.w, utils.Symbols("⚠️  %s\n"), message)
+}
+
+func (r *textReporter) Step(name string) {
+	if r.verbose {
+		fmt.Fprintf(r.w, utils.Symbols("🔍 %s\n"), name)
+	}
+}
+
+func (r *textReporter) Progress(current, total int, message string) {
+	if r.verbose {
+		fmt.Fprintf(r.w, utils.Symbols("🔍 [%d/%d] %s\n"), current, total, message)
+	}
+}
+
+// Event is one line written by the JSON reporter
+type Event struct {
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"`
+	Message string    `json:"message"`
+	Current int       `json:"current,omitempty"`
+	Total   int       `json:"total,omitempty"`
+}
+
+// jsonReporter writes one JSON event per line
+type jsonReporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSON returns a reporter writing newline-delimited JSON events to w
+func NewJSON(w io.Writer) Reporter {
+	return &jsonReporter{enc: json.NewEncoder(w)}
+}
+
+func (r *jsonReporter) Info(message string) {
+	r.write(Event{Event: EventInfo, Message: message})
+}
+
+func (r *jsonReporter) Warn(message string) {
+	r.write(Event{Event: EventWarn, Message: message})
+}
+
+func (r *jsonReporter) Step(name string) {
+	r.write(Event{Event: EventStep, Message: name})
+}
+
+func (r *jsonReporter) Progress(current, total int, message string) {
+	r.write(Event{Event: EventProgress, Message: message, Current: current, Total: total})
+}
+
+func (r *jsonReporter) write(event Event) {
+	event.Time = time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Reporting must never fail the operation being reported on
+	_ = r.enc.Encode(event)
+}
+
+// silentReporter discards everything
+type silentReporter struct{}
+
+// NewSilent returns a reporter that discards all events
+func NewSilent() Reporter {
+	return silentReporter{}
+}
+
+func (silentReporter) Info(string)               {}
+func (silentReporter) Warn(string)               {}
+func (silentReporter) Step(string)               {}
+func (silentReporter) Progress(int, int, string) {}
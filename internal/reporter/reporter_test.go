@@ -0,0 +1,116 @@
+package reporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: FormatText},
+		{format: FormatJSON},
+		{format: FormatSilent},
+		{format: ""},
+		{format: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			r, err := New(tt.format, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if !tt.wantErr && r == nil {
+				t.Errorf("New(%q) returned nil reporter", tt.format)
+			}
+		})
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	tests := []struct {
+		name    string
+		verbose bool
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "quiet steps",
+			verbose: false,
+			want:    []string{"ℹ️  copied\n", "⚠️  careful\n"},
+			notWant: []string{"installing", "[1/2]"},
+		},
+		{
+			name:    "verbose steps",
+			verbose: true,
+			want:    []string{"ℹ️  copied\n", "⚠️  careful\n", "🔍 installing\n", "🔍 [1/2] symlinks\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := NewText(&buf, tt.verbose)
+			r.Step("installing")
+			r.Info("copied")
+			r.Progress(1, 2, "symlinks")
+			r.Warn("careful")
+
+			output := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(output, want) {
+					t.Errorf("output = %q, want it to contain %q", output, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("output = %q, want it not to contain %q", output, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewJSON(&buf)
+	r.Step("installing")
+	r.Info("copied")
+	r.Warn("careful")
+	r.Progress(1, 2, "symlinks")
+
+	var events []Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %q is not a JSON event: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	want := []Event{
+		{Event: EventStep, Message: "installing"},
+		{Event: EventInfo, Message: "copied"},
+		{Event: EventWarn, Message: "careful"},
+		{Event: EventProgress, Message: "symlinks", Current: 1, Total: 2},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Time.IsZero() {
+			t.Errorf("event %d has no time", i)
+		}
+		event.Time = want[i].Time
+		if event != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+	}
+}
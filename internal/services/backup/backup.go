@@ -0,0 +1,294 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+)
+
+// Kinds of backups the CLI creates
+const (
+	KindFramework = "framework"
+	KindSettings  = "settings"
+	KindCodex     = "codex"
+	KindMCP       = "mcp"
+)
+
+// Entry describes a single backup in a project's backups directory
+type Entry struct {
+	Kind    string
+	Name    string
+	Path    string
+	Created time.Time
+	Size    int64
+	Info    *models.BackupInfo // What a framework backup contains, nil when it was not recorded
+}
+
+// kindPrefixes maps backup name prefixes to their kind
+var kindPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{config.BackupDirPrefix, KindFramework},
+	{config.SettingsBackupPrefix, KindSettings},
+	{config.CodexConfigBackupPrefix, KindCodex},
+	{config.MCPBackupPrefix, KindMCP},
+}
+
+// Service lists, migrates and prunes the backups of a project
+type Service struct {
+	trash *trash.Service // Pruned backups go here when set
+}
+
+// New creates a new backup service instance
+func New() *Service {
+	return &Service{}
+}
+
+// List returns the backups of a project, newest first
+func (s *Service) List(targetDir string) ([]Entry, error) {
+	root := config.GetBackupsRoot(targetDir)
+
+	dirEntries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+	}
+
+	var entries []Entry
+	for _, dirEntry := range dirEntries {
+		kind, ok := backupKind(dirEntry.Name())
+		if !ok {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		entry := Entry{
+			Kind:    kind,
+			Name:    dirEntry.Name(),
+			Path:    filepath.Join(root, dirEntry.Name()),
+			Created: backupTime(dirEntry.Name(), info.ModTime()),
+			Size:    info.Size(),
+		}
+		if dirEntry.IsDir() {
+			entry.Size = directorySize(entry.Path)
+		}
+		if kind == KindFramework {
+			entry.Info, _ = ReadInfo(entry.Path)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Created.Equal(entries[j].Created) {
+			return entries[i].Created.After(entries[j].Created)
+		}
+		return entries[i].Name > entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// WriteInfo records what the framework backup in dir contains. CreatedAt is set to
+// the current time when it is zero.
+func WriteInfo(dir string, info models.BackupInfo) error {
+	if info.CreatedAt.IsZero() {
+		info.CreatedAt = time.Now()
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, config.BackupInfoFile)
+	if err := os.WriteFile(path, append(data, '\n'), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// ReadInfo returns what the framework backup in dir contains, as recorded by WriteInfo
+func ReadInfo(dir string) (*models.BackupInfo, error) {
+	path := filepath.Join(dir, config.BackupInfoFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info models.BackupInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, models.NewAppError(models.ErrorCodeInvalidConfiguration, fmt.Sprintf("Failed to parse %s", path), err)
+	}
+	return &info, nil
+}
+
+// MigrateLegacyBackups moves backups that older versions left in the project root,
+// .claude and .codex into the backups directory. It returns the new paths of the
+// moved backups. Backups whose destination already exists are left in place.
+func (s *Service) MigrateLegacyBackups(targetDir string) ([]string, error) {
+	root := config.GetBackupsRoot(targetDir)
+	var moved []string
+
+	for _, move := range legacyBackupMoves(targetDir) {
+		matches, err := legacyBackups(move.pattern, root)
+		if err != nil {
+			return moved, err
+		}
+
+		for _, oldPath := range matches {
+			newName := move.newPrefix + strings.TrimPrefix(filepath.Base(oldPath), move.oldPrefix)
+			newPath := filepath.Join(root, newName)
+			if _, err := os.Lstat(newPath); err == nil {
+				continue
+			}
+
+			if err := os.MkdirAll(root, config.DirPermissions); err != nil {
+				return moved, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+			}
+			if err := os.Rename(oldPath, newPath); err != nil {
+				return moved, models.NewFileSystemError(models.ErrorCodeFileSystemError, oldPath,
+					fmt.Errorf("failed to move backup to %s: %w", newPath, err))
+			}
+			moved = append(moved, newPath)
+		}
+	}
+
+	return moved, nil
+}
+
+// Inventory summarizes the backups of a project, including the ones older versions
+// left outside the backups directory, without moving anything
+func (s *Service) Inventory(targetDir string) (*models.BackupInventory, error) {
+	entries, err := s.List(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &models.BackupInventory{Dir: config.GetBackupsRoot(targetDir)}
+	add := func(created time.Time, size int64) {
+		inventory.Count++
+		inventory.TotalSize += size
+		if inventory.Newest == nil || created.After(*inventory.Newest) {
+			inventory.Newest = &created
+		}
+		if inventory.Oldest == nil || created.Before(*inventory.Oldest) {
+			inventory.Oldest = &created
+		}
+	}
+
+	for _, entry := range entries {
+		add(entry.Created, entry.Size)
+	}
+
+	for _, move := range legacyBackupMoves(targetDir) {
+		matches, err := legacyBackups(move.pattern, inventory.Dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			info, err := os.Lstat(path)
+			if err != nil {
+				continue
+			}
+			size := info.Size()
+			if info.IsDir() {
+				size = directorySize(path)
+			}
+			add(backupTime(filepath.Base(path), info.ModTime()), size)
+			inventory.Legacy++
+		}
+	}
+
+	return inventory, nil
+}
+
+// legacyBackupMove describes where older versions left a kind of backup, and the
+// prefix it gets in the backups directory
+type legacyBackupMove struct {
+	pattern   string
+	oldPrefix string
+	newPrefix string
+}
+
+// legacyBackupMoves returns the legacy backup locations of a project
+func legacyBackupMoves(targetDir string) []legacyBackupMove {
+	return []legacyBackupMove{
+		{filepath.Join(targetDir, config.BackupDirPrefix+"*"), config.BackupDirPrefix, config.BackupDirPrefix},
+		{filepath.Join(targetDir, config.ClaudeDir, config.SettingsBackupPrefix+"*.json"), config.SettingsBackupPrefix, config.SettingsBackupPrefix},
+		{filepath.Join(targetDir, config.CodexDir, config.LegacyCodexConfigBackupPrefix+"*.toml"), config.LegacyCodexConfigBackupPrefix, config.CodexConfigBackupPrefix},
+		{filepath.Join(targetDir, config.LegacyMCPBackupPrefix+"*.json"), config.LegacyMCPBackupPrefix, config.MCPBackupPrefix},
+	}
+}
+
+// legacyBackups returns the backups matching pattern outside the backups directory root,
+// which may itself be configured inside a legacy location
+func legacyBackups(pattern, root string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, path := range matches {
+		if filepath.Dir(path) != root {
+			backups = append(backups, path)
+		}
+	}
+	return backups, nil
+}
+
+// backupKind returns the kind of backup a name in the backups directory refers to
+func backupKind(name string) (string, bool) {
+	for _, kp := range kindPrefixes {
+		if strings.HasPrefix(name, kp.prefix) {
+			return kp.kind, true
+		}
+	}
+	return "", false
+}
+
+// backupTime parses the timestamp embedded in a backup name, falling back to the
+// modification time for names without one
+func backupTime(name string, modTime time.Time) time.Time {
+	for _, kp := range kindPrefixes {
+		if stamp, ok := strings.CutPrefix(name, kp.prefix); ok {
+			// Names may carry a collision counter after the timestamp
+			layout := config.BackupTimestampLayout
+			if len(stamp) < len(layout) {
+				break
+			}
+			if created, err := time.ParseInLocation(layout, stamp[:len(layout)], time.Local); err == nil {
+				return created
+			}
+		}
+	}
+	return modTime
+}
+
+// directorySize returns the total size of regular files below dir
+func directorySize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,473 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+)
+
+func TestService_MigrateLegacyBackups(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	legacy := map[string]string{
+		filepath.Join(config.BackupDirPrefix+"20240101-120000", "core", "file.md"):          config.BackupDirPrefix + "20240101-120000",
+		filepath.Join(config.ClaudeDir, config.SettingsBackupPrefix+"20240102-120000.json"): config.SettingsBackupPrefix + "20240102-120000.json",
+		filepath.Join(config.CodexDir, "config-backup-20240103-120000.toml"):                config.CodexConfigBackupPrefix + "20240103-120000.toml",
+		".mcp-backup-20240104-120000.json":                                                  config.MCPBackupPrefix + "20240104-120000.json",
+	}
+	for path := range legacy {
+		fullPath := filepath.Join(targetDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte("backup"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := New()
+	moved, err := service.MigrateLegacyBackups(targetDir)
+	if err != nil {
+		t.Fatalf("MigrateLegacyBackups() error = %v", err)
+	}
+	if len(moved) != len(legacy) {
+		t.Errorf("MigrateLegacyBackups() moved %d backups, want %d", len(moved), len(legacy))
+	}
+
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	for oldPath, newName := range legacy {
+		if _, err := os.Stat(filepath.Join(backupsRoot, newName)); err != nil {
+			t.Errorf("Backup %s not moved to %s: %v", oldPath, newName, err)
+		}
+		if _, err := os.Stat(filepath.Join(targetDir, oldPath)); !os.IsNotExist(err) {
+			t.Errorf("Legacy backup %s should no longer exist", oldPath)
+		}
+	}
+
+	entries, err := service.List(targetDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	wantKinds := []string{KindMCP, KindCodex, KindSettings, KindFramework} // Newest first
+	if len(entries) != len(wantKinds) {
+		t.Fatalf("List() returned %d entries, want %d", len(entries), len(wantKinds))
+	}
+	for i, entry := range entries {
+		if entry.Kind != wantKinds[i] {
+			t.Errorf("List()[%d].Kind = %s, want %s", i, entry.Kind, wantKinds[i])
+		}
+		if entry.Size != int64(len("backup")) {
+			t.Errorf("List()[%d].Size = %d, want %d", i, entry.Size, len("backup"))
+		}
+	}
+
+	// Running again finds nothing left to move
+	moved, err = service.MigrateLegacyBackups(targetDir)
+	if err != nil || len(moved) != 0 {
+		t.Errorf("Second MigrateLegacyBackups() = %v, %v, want nothing moved", moved, err)
+	}
+}
+
+func TestService_List_Empty(t *testing.T) {
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	entries, err := New().List(t.TempDir())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("List() = %v, want no entries", entries)
+	}
+}
+
+func TestService_List_Info(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	recorded := filepath.Join(backupsRoot, config.BackupDirPrefix+"20240102-120000-main-1a2b3c4")
+	unrecorded := filepath.Join(backupsRoot, config.BackupDirPrefix+"20240101-120000")
+	for _, dir := range []string{recorded, unrecorded} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info := models.BackupInfo{Template: "main", Commit: "1a2b3c4d5e6f"}
+	if err := WriteInfo(recorded, info); err != nil {
+		t.Fatalf("WriteInfo() error = %v", err)
+	}
+
+	entries, err := New().List(targetDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("List() returned %d entries, want 2", len(entries))
+	}
+
+	// The label after the timestamp does not get in the way of parsing it
+	if want := time.Date(2024, 1, 2, 12, 0, 0, 0, time.Local); !entries[0].Created.Equal(want) {
+		t.Errorf("List()[0].Created = %v, want %v", entries[0].Created, want)
+	}
+	if entries[0].Info == nil || entries[0].Info.Label() != "main@1a2b3c4" || entries[0].Info.CreatedAt.IsZero() {
+		t.Errorf("List()[0].Info = %+v, want main@1a2b3c4 with a creation time", entries[0].Info)
+	}
+	if entries[1].Info != nil {
+		t.Errorf("List()[1].Info = %+v, want nil for a backup without a record", entries[1].Info)
+	}
+}
+
+func TestService_Inventory(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	files := []string{
+		filepath.Join(config.BackupsDir, config.BackupDirPrefix+"20240101-120000", "core", "file.md"),
+		filepath.Join(config.BackupsDir, config.MCPBackupPrefix+"20240301-120000.json"),
+		filepath.Join(config.ClaudeDir, config.SettingsBackupPrefix+"20240201-120000.json"), // Legacy location
+	}
+	for _, path := range files {
+		fullPath := filepath.Join(targetDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte("backup"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inventory, err := New().Inventory(targetDir)
+	if err != nil {
+		t.Fatalf("Inventory() error = %v", err)
+	}
+	if inventory.Count != 3 || inventory.Legacy != 1 {
+		t.Errorf("Inventory() Count = %d, Legacy = %d, want 3 and 1", inventory.Count, inventory.Legacy)
+	}
+	if inventory.TotalSize != int64(3*len("backup")) {
+		t.Errorf("Inventory() TotalSize = %d, want %d", inventory.TotalSize, 3*len("backup"))
+	}
+	if got := inventory.Oldest.Format(config.BackupTimestampLayout); got != "20240101-120000" {
+		t.Errorf("Inventory() Oldest = %s, want 20240101-120000", got)
+	}
+	if got := inventory.Newest.Format(config.BackupTimestampLayout); got != "20240301-120000" {
+		t.Errorf("Inventory() Newest = %s, want 20240301-120000", got)
+	}
+	if !inventory.NeedsPruning() {
+		t.Error("NeedsPruning() with backups older than the maximum age = false, want true")
+	}
+
+	// The inventory moves nothing
+	if _, err := os.Stat(filepath.Join(targetDir, files[2])); err != nil {
+		t.Errorf("Inventory() moved a legacy backup: %v", err)
+	}
+}
+
+func TestService_Prune(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	if err := os.MkdirAll(backupsRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Older than the maximum age, except the newest, which is always kept
+	names := []string{
+		config.SettingsBackupPrefix + "20200103-120000.json",
+		config.SettingsBackupPrefix + "20200102-120000.json",
+		config.SettingsBackupPrefix + "20200101-120000.json",
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(backupsRoot, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := New()
+	pruned, err := service.Prune(targetDir, true)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(pruned) != 2 {
+		t.Fatalf("Prune() dry run returned %d backups, want 2", len(pruned))
+	}
+	if _, err := os.Stat(filepath.Join(backupsRoot, names[2])); err != nil {
+		t.Errorf("dry run removed %s", names[2])
+	}
+
+	graveyard := t.TempDir()
+	trashService := trash.New()
+	trashService.SetDir(graveyard)
+	service.SetTrash(trashService)
+	pruned, err = service.Prune(targetDir, false)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	for _, entry := range pruned {
+		if _, err := os.Stat(entry.TrashPath); err != nil {
+			t.Errorf("%s not moved to the trash: %v", entry.Name, err)
+		}
+	}
+
+	entries, err := service.List(targetDir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != names[0] {
+		t.Errorf("List() after Prune() = %v, want only %s", entries, names[0])
+	}
+}
+
+func TestService_Restore(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	older := filepath.Join(backupsRoot, config.BackupDirPrefix+"20240101-120000")
+	newer := filepath.Join(backupsRoot, config.BackupDirPrefix+"20240102-120000-main-1a2b3c4")
+	files := map[string]string{
+		filepath.Join(older, "core", "commands", "foo.md"):          "older foo",
+		filepath.Join(newer, "core", "commands", "foo.md"):          "backed up foo",
+		filepath.Join(newer, "core", "hooks", "a.py"):               "backed up a",
+		filepath.Join(newer, "core", "hooks", "lib", "b.py"):        "backed up b",
+		filepath.Join(strategicDir, "core", "commands", "foo.md"):   "clobbered foo",
+		filepath.Join(strategicDir, "core", "commands", "other.md"): "kept",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := WriteInfo(newer, models.BackupInfo{Template: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	// A file the installation links elsewhere is replaced, not written through
+	linked := filepath.Join(t.TempDir(), "a.py")
+	if err := os.WriteFile(linked, []byte("checkout"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(strategicDir, "core", "hooks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(linked, filepath.Join(strategicDir, "core", "hooks", "a.py")); err != nil {
+		t.Fatal(err)
+	}
+
+	service := New()
+	entry, err := service.Find(targetDir, "")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if entry.Path != newer {
+		t.Fatalf("Find() = %s, want the newest framework backup %s", entry.Path, newer)
+	}
+
+	// A dry run lists the files without writing them
+	restored, err := service.Restore(targetDir, entry, []string{"core/hooks"}, true)
+	if err != nil {
+		t.Fatalf("Restore() dry run error = %v", err)
+	}
+	if len(restored) != 2 {
+		t.Errorf("Restore() dry run = %v, want the 2 hook files", restored)
+	}
+	if data, _ := os.ReadFile(linked); string(data) != "checkout" {
+		t.Errorf("Restore() dry run changed a file: %q", data)
+	}
+
+	restored, err = service.Restore(targetDir, entry, []string{config.StrategicClaudeBasicDir + "/core/commands/foo.md", "core/hooks"}, false)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(restored) != 3 {
+		t.Errorf("Restore() = %v, want 3 files", restored)
+	}
+	for path, want := range map[string]string{
+		filepath.Join(strategicDir, "core", "commands", "foo.md"):   "backed up foo",
+		filepath.Join(strategicDir, "core", "commands", "other.md"): "kept",
+		filepath.Join(strategicDir, "core", "hooks", "a.py"):        "backed up a",
+		filepath.Join(strategicDir, "core", "hooks", "lib", "b.py"): "backed up b",
+		linked: "checkout",
+	} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Errorf("%s = %q (%v), want %q", path, data, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(strategicDir, config.BackupInfoFile)); !os.IsNotExist(err) {
+		t.Error("Restore() restored the backup record")
+	}
+
+	// Paths the backup does not contain, and paths outside the framework directory, fail
+	if _, err := service.Restore(targetDir, entry, []string{"core/commands/missing.md"}, false); !models.IsErrorCode(err, models.ErrorCodeRestoreFailed) {
+		t.Errorf("Restore() of a missing path error = %v, want %s", err, models.ErrorCodeRestoreFailed)
+	}
+	if _, err := service.Restore(targetDir, entry, []string{"../secrets"}, false); err == nil {
+		t.Error("Restore() of a path outside the framework directory returned no error")
+	}
+
+	if _, err := service.Find(targetDir, "strategic-claude-basic-backup-19990101-000000"); !models.IsErrorCode(err, models.ErrorCodeRestoreFailed) {
+		t.Errorf("Find() of an unknown backup error = %v, want %s", err, models.ErrorCodeRestoreFailed)
+	}
+}
+
+func TestService_Restore_DevMode(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	// Dev mode links core to the template author's checkout
+	checkoutCore := filepath.Join(t.TempDir(), config.StrategicClaudeBasicDir, "core")
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	backupDir := filepath.Join(targetDir, config.BackupsDir, config.BackupDirPrefix+"20240101-120000")
+	files := map[string]string{
+		filepath.Join(checkoutCore, "commands", "example.md"):      "checkout",
+		filepath.Join(backupDir, "core", "commands", "example.md"): "backed up",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(strategicDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(checkoutCore, filepath.Join(strategicDir, "core")); err != nil {
+		t.Fatal(err)
+	}
+	// A backed up link pointing outside the framework directory
+	if err := os.MkdirAll(filepath.Join(backupDir, "guides"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../../etc/passwd", filepath.Join(backupDir, "guides", "escape.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	service := New()
+	entry, err := service.Find(targetDir, "")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	for _, path := range []string{"core/commands/example.md", "core", "guides/escape.md"} {
+		if _, err := service.Restore(targetDir, entry, []string{path}, false); !models.IsErrorCode(err, models.ErrorCodeRestoreFailed) {
+			t.Errorf("Restore(%s) error = %v, want %s", path, err, models.ErrorCodeRestoreFailed)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(checkoutCore, "commands", "example.md")); err != nil || string(data) != "checkout" {
+		t.Errorf("checkout file = %q (%v), want it untouched", data, err)
+	}
+	if _, err := os.Lstat(filepath.Join(strategicDir, "guides", "escape.md")); !os.IsNotExist(err) {
+		t.Error("Restore() wrote a link outside the framework directory")
+	}
+}
+
+func TestService_Restore_SymlinkChain(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	if err := os.MkdirAll(backupsRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := config.BackupDirPrefix + "20240101-120000" + config.BackupArchiveExtension
+	file, err := os.Create(filepath.Join(backupsRoot, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each link stays inside by its text, but the second one is restored
+	// through the first and the file through both
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	headers := []*tar.Header{
+		{Name: "core/a/b/l", Linkname: "../..", Typeflag: tar.TypeSymlink},
+		{Name: "core/a/b/l/l2", Linkname: "../../..", Typeflag: tar.TypeSymlink},
+		{Name: "core/a/b/l/l2/ESCAPED", Mode: 0644, Typeflag: tar.TypeReg},
+	}
+	for _, header := range headers {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	service := New()
+	entry, err := service.Find(targetDir, name)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if _, err := service.Restore(targetDir, entry, []string{"core"}, false); !models.IsErrorCode(err, models.ErrorCodeRestoreFailed) {
+		t.Errorf("Restore() error = %v, want %s", err, models.ErrorCodeRestoreFailed)
+	}
+	for _, dir := range []string{targetDir, filepath.Dir(targetDir)} {
+		if _, err := os.Lstat(filepath.Join(dir, "ESCAPED")); err == nil {
+			t.Errorf("Restore() wrote ESCAPED to %s", dir)
+		}
+	}
+}
+
+func TestService_Restore_Archive(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	backupsRoot := filepath.Join(targetDir, config.BackupsDir)
+	if err := os.MkdirAll(backupsRoot, 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := config.BackupDirPrefix + "20240101-120000" + config.BackupArchiveExtension
+	file, err := os.Create(filepath.Join(backupsRoot, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for entryName, content := range map[string]string{"./core/commands/foo.md": "archived foo", "core/guides/g.md": "archived guide"} {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: entryName, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	service := New()
+	entry, err := service.Find(targetDir, name)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	restored, err := service.Restore(targetDir, entry, []string{"core/commands/foo.md"}, false)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(restored) != 1 || restored[0] != "core/commands/foo.md" {
+		t.Errorf("Restore() = %v, want only core/commands/foo.md", restored)
+	}
+	data, err := os.ReadFile(filepath.Join(targetDir, config.StrategicClaudeBasicDir, "core", "commands", "foo.md"))
+	if err != nil || string(data) != "archived foo" {
+		t.Errorf("Restored file = %q (%v), want %q", data, err, "archived foo")
+	}
+}
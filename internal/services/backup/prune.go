@@ -0,0 +1,64 @@
+package backup
+
+import (
+	"os"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+)
+
+// Pruned is a backup removed by Prune
+type Pruned struct {
+	Entry
+	TrashPath string // Where the backup was moved, empty when it was deleted
+}
+
+// SetTrash makes Prune move backups to t instead of deleting them; nil deletes them
+func (s *Service) SetTrash(t *trash.Service) {
+	s.trash = t
+}
+
+// PruneCandidates returns the backups beyond the configured number to keep or
+// older than the configured age, given entries newest first as List returns
+// them. The newest backup is always kept.
+func PruneCandidates(entries []Entry, now time.Time) []Entry {
+	cfg := config.Current()
+	var candidates []Entry
+	for i, entry := range entries {
+		if i == 0 {
+			continue
+		}
+		if i >= cfg.MaxBackups || now.Sub(entry.Created) > cfg.MaxBackupAge {
+			candidates = append(candidates, entry)
+		}
+	}
+	return candidates
+}
+
+// Prune removes the backups PruneCandidates selects; with dryRun nothing is removed
+func (s *Service) Prune(targetDir string, dryRun bool) ([]Pruned, error) {
+	entries, err := s.List(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []Pruned
+	for _, entry := range PruneCandidates(entries, time.Now()) {
+		result := Pruned{Entry: entry}
+		if !dryRun {
+			if s.trash != nil {
+				dest, err := s.trash.Move(entry.Path)
+				if err != nil {
+					return pruned, err
+				}
+				result.TrashPath = dest
+			} else if err := os.RemoveAll(entry.Path); err != nil {
+				return pruned, models.NewFileSystemError(models.ErrorCodeFileSystemError, entry.Path, err)
+			}
+		}
+		pruned = append(pruned, result)
+	}
+	return pruned, nil
+}
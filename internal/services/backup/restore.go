@@ -0,0 +1,321 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// restoreFile is a file or symlink read from a backup
+type restoreFile struct {
+	path string // Relative to the framework directory, slash separated
+	mode fs.FileMode
+	link string // Target of a symlink, empty for regular files
+	data []byte
+}
+
+// Find returns the framework backup of a project named name, or the newest one
+// when name is empty
+func (s *Service) Find(targetDir, name string) (Entry, error) {
+	entries, err := s.List(targetDir)
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, entry := range entries {
+		if entry.Kind == KindFramework && (name == "" || entry.Name == name) {
+			return entry, nil
+		}
+	}
+
+	message := fmt.Sprintf("No framework backup in %s", config.GetBackupsRoot(targetDir))
+	if name != "" {
+		message = fmt.Sprintf("No framework backup named %s in %s", name, config.GetBackupsRoot(targetDir))
+	}
+	return Entry{}, models.NewAppError(models.ErrorCodeRestoreFailed, message, nil)
+}
+
+// Restore copies paths, relative to the framework directory, from a framework
+// backup, a directory or a tar.gz archive, into the installation in targetDir and
+// returns the files it restored. A directory restores everything below it. Files
+// of the installation are replaced rather than written through when they are
+// symlinks. Nothing is written when a path is not in the backup, when a file would
+// be written through a linked directory or link outside the framework directory,
+// or with dryRun.
+func (s *Service) Restore(targetDir string, entry Entry, paths []string, dryRun bool) ([]string, error) {
+	cleaned := make([]string, 0, len(paths))
+	for _, p := range paths {
+		relPath, err := restorePath(p)
+		if err != nil {
+			return nil, err
+		}
+		cleaned = append(cleaned, relPath)
+	}
+
+	var files []restoreFile
+	var err error
+	if strings.HasSuffix(entry.Name, config.BackupArchiveExtension) {
+		files, err = readArchive(entry.Path, cleaned)
+	} else {
+		files, err = readDirectory(entry.Path, cleaned)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, relPath := range cleaned {
+		if !restores(files, relPath) {
+			return nil, models.NewAppError(
+				models.ErrorCodeRestoreFailed,
+				fmt.Sprintf("%s is not in backup %s", relPath, entry.Name),
+				nil,
+			)
+		}
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	for _, file := range files {
+		if err := checkRestorable(strategicDir, file); err != nil {
+			return nil, err
+		}
+	}
+
+	var restored []string
+	for _, file := range files {
+		if !dryRun {
+			if err := writeRestored(strategicDir, file); err != nil {
+				return restored, err
+			}
+		}
+		restored = append(restored, file.path)
+	}
+	if dryRun {
+		return restored, nil
+	}
+
+	// Links are checked again with every file in place, as a later file may have
+	// turned a path a link goes through into another link
+	for _, file := range files {
+		if file.link == "" {
+			continue
+		}
+		dest := filepath.Join(strategicDir, filepath.FromSlash(file.path))
+		if !utils.LinkResolvesWithin(strategicDir, dest, file.link) {
+			if err := os.Remove(dest); err != nil {
+				return restored, models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+			}
+			return restored, models.NewAppError(
+				models.ErrorCodeRestoreFailed,
+				fmt.Sprintf("Cannot restore %s: it links to %s, outside %s", file.path, file.link, strategicDir),
+				nil,
+			)
+		}
+	}
+	return restored, nil
+}
+
+// restorePath validates a path to restore and returns it cleaned and relative to
+// the framework directory, which it may start with
+func restorePath(p string) (string, error) {
+	cleaned := path.Clean(filepath.ToSlash(strings.TrimSpace(p)))
+	cleaned = strings.TrimPrefix(cleaned, config.FrameworkDir()+"/")
+	if p == "" || cleaned == "." || cleaned == config.FrameworkDir() || path.IsAbs(cleaned) ||
+		cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", models.NewValidationError("path", p, "must be a path inside the framework directory, e.g. core/commands/foo.md")
+	}
+	return cleaned, nil
+}
+
+// restores reports whether files contain relPath or something below it
+func restores(files []restoreFile, relPath string) bool {
+	for _, file := range files {
+		if within(file.path, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// readDirectory reads the files at and below paths from a directory backup
+func readDirectory(backupDir string, paths []string) ([]restoreFile, error) {
+	var files []restoreFile
+	for _, relPath := range paths {
+		root := filepath.Join(backupDir, filepath.FromSlash(relPath))
+		if _, err := os.Lstat(root); err != nil {
+			if os.IsNotExist(err) {
+				continue // Reported by Restore
+			}
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+
+		err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(backupDir, filePath)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if rel == config.BackupInfoFile {
+				return nil
+			}
+
+			switch {
+			case d.Type()&fs.ModeSymlink != 0:
+				target, err := os.Readlink(filePath)
+				if err != nil {
+					return err
+				}
+				files = append(files, restoreFile{path: rel, link: target})
+			case d.Type().IsRegular():
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+				data, err := os.ReadFile(filePath)
+				if err != nil {
+					return err
+				}
+				files = append(files, restoreFile{path: rel, mode: info.Mode().Perm(), data: data})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+	return files, nil
+}
+
+// readArchive reads the files at and below paths from a tar.gz backup, whose
+// entries are relative to the framework directory
+func readArchive(archivePath string, paths []string) ([]restoreFile, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, archivePath, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, models.NewAppError(models.ErrorCodeRestoreFailed, fmt.Sprintf("%s is not a gzip archive", archivePath), err)
+	}
+	defer gzipReader.Close()
+
+	var files []restoreFile
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, models.NewAppError(models.ErrorCodeRestoreFailed, fmt.Sprintf("Failed to read %s", archivePath), err)
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, models.NewAppError(models.ErrorCodeRestoreFailed, fmt.Sprintf("%s contains an unsafe entry: %s", archivePath, header.Name), nil)
+		}
+		if name == config.BackupInfoFile || !matchesAny(name, paths) {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			files = append(files, restoreFile{path: name, link: header.Linkname})
+		case tar.TypeReg:
+			data, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, models.NewAppError(models.ErrorCodeRestoreFailed, fmt.Sprintf("Failed to read %s", archivePath), err)
+			}
+			files = append(files, restoreFile{path: name, mode: fs.FileMode(header.Mode).Perm(), data: data})
+		}
+	}
+}
+
+// matchesAny reports whether name is one of paths or lies below one of them
+func matchesAny(name string, paths []string) bool {
+	for _, relPath := range paths {
+		if within(name, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether name is relPath or lies below it
+func within(name, relPath string) bool {
+	return name == relPath || strings.HasPrefix(name, relPath+"/")
+}
+
+// checkRestorable checks that restoring file writes inside the framework
+// directory: no directory above it may be a symlink, as in dev mode, where core,
+// guides and templates link to the template author's checkout, or as restored
+// from the same backup, and a restored symlink must lead inside the framework
+// directory. Restore checks every file before writing any, and writeRestored
+// again just before the write, as earlier files change what is on disk.
+func checkRestorable(strategicDir string, file restoreFile) error {
+	dest := filepath.Join(strategicDir, filepath.FromSlash(file.path))
+	link, err := utils.SymlinkedParent(strategicDir, dest)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+	}
+	if link != "" {
+		return models.NewAppError(
+			models.ErrorCodeRestoreFailed,
+			fmt.Sprintf("Cannot restore %s: %s is a symlink, e.g. into a dev mode checkout, and would be written through", file.path, link),
+			nil,
+		)
+	}
+
+	if file.link != "" && !utils.LinkResolvesWithin(strategicDir, dest, file.link) {
+		return models.NewAppError(
+			models.ErrorCodeRestoreFailed,
+			fmt.Sprintf("Cannot restore %s: it links to %s, outside %s", file.path, file.link, strategicDir),
+			nil,
+		)
+	}
+	return nil
+}
+
+// writeRestored writes a file read from a backup into the framework directory
+func writeRestored(strategicDir string, file restoreFile) error {
+	if err := checkRestorable(strategicDir, file); err != nil {
+		return err
+	}
+
+	dest := filepath.Join(strategicDir, filepath.FromSlash(file.path))
+	if err := os.MkdirAll(filepath.Dir(dest), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(dest), err)
+	}
+
+	// Replace the file rather than write through a symlink, e.g. into a dev mode checkout
+	if info, err := os.Lstat(dest); err == nil && !info.IsDir() {
+		if err := os.Remove(dest); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+		}
+	}
+
+	if file.link != "" {
+		if err := os.Symlink(file.link, dest); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(dest, file.data, file.mode); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+	}
+	return nil
+}
@@ -0,0 +1,495 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/verify"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Manifest describes the template content packaged in a bundle
+type Manifest struct {
+	FormatVersion int                `json:"format_version"`
+	Template      templates.Template `json:"template"`
+	TreeHash      string             `json:"tree_hash"` // Tree hash of the bundled content directory
+	CreatedAt     string             `json:"created_at"`
+}
+
+// Bundle is an extracted bundle ready to be installed from
+type Bundle struct {
+	Manifest   Manifest
+	RootDir    string // Temporary extraction directory
+	ContentDir string // Template repository content at the pinned commit
+}
+
+// Service creates and opens offline template bundles
+type Service struct {
+	gitService    *git.Service
+	verifyService *verify.Service
+}
+
+// New creates a new bundle service instance
+func New() *Service {
+	return &Service{
+		gitService:    git.New(),
+		verifyService: verify.New(),
+	}
+}
+
+// SetAuthToken sets the token used to fetch private template repositories
+func (s *Service) SetAuthToken(token string) {
+	s.gitService.SetAuthToken(token)
+}
+
+// DefaultBundleName returns the default file name for a template bundle
+func DefaultBundleName(template templates.Template) string {
+	commit := template.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return fmt.Sprintf("strategic-claude-basic-%s-%s%s", template.ID, commit, config.BundleFileExtension)
+}
+
+// Create fetches the template at its pinned commit and writes it to a bundle archive
+func (s *Service) Create(template templates.Template, outputPath string) (*Manifest, error) {
+	tempDir, manifest, err := s.fetch(template)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = s.gitService.CleanupTempDir(tempDir) // Best effort cleanup
+	}()
+
+	if err := s.writeArchive(outputPath, manifest, tempDir); err != nil {
+		_ = os.Remove(outputPath) // Do not leave a partial bundle behind
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// fetch clones the template at its pinned commit without repository metadata
+// and returns the content directory together with its manifest
+func (s *Service) fetch(template templates.Template) (string, *Manifest, error) {
+	tempDir, err := s.gitService.CloneRepositoryWithBranch(template.RepoURL, template.Branch, template.Commit)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch template: %w", err)
+	}
+
+	// Repository metadata is not part of the template content
+	if err := os.RemoveAll(filepath.Join(tempDir, ".git")); err != nil {
+		_ = s.gitService.CleanupTempDir(tempDir)
+		return "", nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, tempDir, err)
+	}
+
+	treeHash, err := s.verifyService.ComputeTreeHash(tempDir)
+	if err != nil {
+		_ = s.gitService.CleanupTempDir(tempDir)
+		return "", nil, fmt.Errorf("failed to hash template content: %w", err)
+	}
+
+	return tempDir, &Manifest{
+		FormatVersion: config.BundleFormatVersion,
+		Template:      template,
+		TreeHash:      treeHash,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// ReadManifest reads the manifest of a bundle without extracting its content
+func (s *Service) ReadManifest(bundlePath string) (*Manifest, error) {
+	var manifest *Manifest
+
+	err := s.walkArchive(bundlePath, func(header *tar.Header, reader io.Reader) (bool, error) {
+		if header.Name != config.BundleManifestFile {
+			return true, nil
+		}
+		parsed, err := decodeManifest(reader)
+		if err != nil {
+			return false, err
+		}
+		manifest = parsed
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, invalidBundle(bundlePath, "manifest not found", nil)
+	}
+
+	return manifest, nil
+}
+
+// RegisteredTemplate returns the registered template of a bundle or vendored
+// copy at bundlePath. Its manifest is not trusted, since whoever altered the
+// content could have rewritten it too: it must name a known template at its
+// pinned commit, whose tree hash and signing key then verify the content.
+func RegisteredTemplate(manifest *Manifest, bundlePath string) (templates.Template, error) {
+	template, err := templates.GetTemplate(manifest.Template.ID)
+	if err != nil {
+		return templates.Template{}, models.NewAppError(
+			models.ErrorCodeInvalidBundle,
+			fmt.Sprintf("%s holds template '%s', which this version does not know", bundlePath, manifest.Template.ID),
+			err,
+		).WithContext("bundle", bundlePath)
+	}
+	if !strings.EqualFold(manifest.Template.Commit, template.Commit) {
+		return templates.Template{}, models.NewAppError(
+			models.ErrorCodeInvalidBundle,
+			fmt.Sprintf("%s holds commit %s of template '%s', which is pinned to %s", bundlePath, manifest.Template.Commit, template.ID, template.Commit),
+			nil,
+		).WithContext("bundle", bundlePath)
+	}
+	return template, nil
+}
+
+// Open extracts a bundle to a temporary directory and verifies its content.
+// The caller is responsible for calling Cleanup on the returned bundle.
+func (s *Service) Open(bundlePath string) (*Bundle, error) {
+	rootDir, err := os.MkdirTemp(config.TempDir(), config.TempDirPrefix)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, config.TempDir(), err)
+	}
+
+	bundle := &Bundle{
+		RootDir:    rootDir,
+		ContentDir: filepath.Join(rootDir, config.BundleContentDir),
+	}
+
+	if err := s.extract(bundlePath, bundle); err != nil {
+		_ = bundle.Cleanup()
+		return nil, err
+	}
+
+	if err := s.verifyService.VerifyTreeHash(bundle.ContentDir, bundle.Manifest.TreeHash); err != nil {
+		_ = bundle.Cleanup()
+		return nil, fmt.Errorf("bundle content does not match its manifest: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// Cleanup removes the extracted bundle
+func (b *Bundle) Cleanup() error {
+	if b.RootDir == "" {
+		return nil
+	}
+	return git.New().CleanupTempDir(b.RootDir)
+}
+
+// extract unpacks the archive into the bundle's root directory
+func (s *Service) extract(bundlePath string, bundle *Bundle) error {
+	foundManifest := false
+	var links []string
+
+	if err := os.MkdirAll(bundle.ContentDir, config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, bundle.ContentDir, err)
+	}
+
+	err := s.walkArchive(bundlePath, func(header *tar.Header, reader io.Reader) (bool, error) {
+		if header.Name == config.BundleManifestFile {
+			manifest, err := decodeManifest(reader)
+			if err != nil {
+				return false, err
+			}
+			bundle.Manifest = *manifest
+			foundManifest = true
+			return true, nil
+		}
+
+		relPath, err := contentPath(header.Name)
+		if err != nil {
+			return false, invalidBundle(bundlePath, err.Error(), nil)
+		}
+		destPath := filepath.Join(bundle.ContentDir, filepath.FromSlash(relPath))
+
+		// A symlink extracted earlier must not be written through
+		if relPath != "" {
+			link, err := utils.SymlinkedParent(bundle.ContentDir, destPath)
+			if err != nil {
+				return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+			}
+			if link != "" {
+				return false, invalidBundle(bundlePath, fmt.Sprintf("%s is below the symlink %s", header.Name, link), nil)
+			}
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(destPath, config.DirPermissions)
+		case tar.TypeReg:
+			err = writeFile(destPath, reader, header.FileInfo().Mode().Perm())
+		case tar.TypeSymlink:
+			if err := validateLinkTarget(relPath, header.Linkname); err != nil {
+				return false, invalidBundle(bundlePath, err.Error(), nil)
+			}
+			if !utils.LinkResolvesWithin(bundle.ContentDir, destPath, header.Linkname) {
+				return false, invalidBundle(bundlePath, fmt.Sprintf("symlink %s points outside the bundle", relPath), nil)
+			}
+			if err = os.MkdirAll(filepath.Dir(destPath), config.DirPermissions); err == nil {
+				err = os.Symlink(header.Linkname, destPath)
+			}
+			links = append(links, relPath)
+		default:
+			return false, invalidBundle(bundlePath, fmt.Sprintf("unsupported entry type for %s", header.Name), nil)
+		}
+		if err != nil {
+			return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !foundManifest {
+		return invalidBundle(bundlePath, "manifest not found", nil)
+	}
+
+	// Links are checked again with the whole content in place, as a later entry
+	// may have turned a path a link goes through into another link
+	for _, link := range links {
+		linkPath := filepath.Join(bundle.ContentDir, filepath.FromSlash(link))
+		target, err := os.Readlink(linkPath)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, linkPath, err)
+		}
+		if !utils.LinkResolvesWithin(bundle.ContentDir, linkPath, target) {
+			return invalidBundle(bundlePath, fmt.Sprintf("symlink %s points outside the bundle", link), nil)
+		}
+	}
+
+	return nil
+}
+
+// walkArchive calls visit for every entry of the bundle until it returns false
+func (s *Service) walkArchive(bundlePath string, visit func(*tar.Header, io.Reader) (bool, error)) error {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, bundlePath, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return invalidBundle(bundlePath, "not a gzip archive", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return invalidBundle(bundlePath, "failed to read archive", err)
+		}
+
+		more, err := visit(header, tarReader)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// writeArchive writes the manifest followed by the content directory to a tar.gz file
+func (s *Service) writeArchive(outputPath string, manifest *Manifest, contentDir string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	// The manifest goes first so it can be read without scanning the whole archive
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    config.BundleManifestFile,
+		Mode:    config.FilePermissions,
+		Size:    int64(len(manifestData)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	if _, err := tarWriter.Write(manifestData); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+
+	err = filepath.WalkDir(contentDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(contentDir, filePath)
+		if err != nil || relPath == "." {
+			return err
+		}
+
+		return addArchiveEntry(tarWriter, filePath, path.Join(config.BundleContentDir, filepath.ToSlash(relPath)), d)
+	})
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+
+	return nil
+}
+
+// addArchiveEntry writes a single file, directory or symlink to the archive
+func addArchiveEntry(tarWriter *tar.Writer, filePath, name string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+
+	linkTarget := ""
+	if info.Mode()&fs.ModeSymlink != 0 {
+		if linkTarget, err = os.Readlink(filePath); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if d.IsDir() {
+		header.Name += "/"
+	}
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// contentPath validates an archive entry name and returns its path relative to the content directory
+func contentPath(name string) (string, error) {
+	if path.IsAbs(name) {
+		return "", fmt.Errorf("unsafe entry path: %s", name)
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == config.BundleContentDir {
+		return "", nil
+	}
+	if !strings.HasPrefix(cleaned, config.BundleContentDir+"/") {
+		return "", fmt.Errorf("unexpected entry outside content directory: %s", name)
+	}
+
+	return strings.TrimPrefix(cleaned, config.BundleContentDir+"/"), nil
+}
+
+// validateLinkTarget ensures a symlink does not point outside the content directory
+func validateLinkTarget(relPath, target string) error {
+	if path.IsAbs(target) {
+		return fmt.Errorf("absolute symlink target for %s", relPath)
+	}
+
+	resolved := path.Join(path.Dir(relPath), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("symlink %s points outside the bundle", relPath)
+	}
+
+	return nil
+}
+
+// writeFile writes a regular file from the archive with the recorded
+// permissions. The file must not exist yet, so an earlier symlink entry of the
+// same name is not written through.
+func writeFile(destPath string, reader io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), config.DirPermissions); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(destPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+// decodeManifest parses and validates a bundle manifest
+func decodeManifest(reader io.Reader) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, models.NewAppError(models.ErrorCodeInvalidBundle, "Failed to parse bundle manifest", err)
+	}
+
+	if manifest.FormatVersion != config.BundleFormatVersion {
+		return nil, models.NewAppError(
+			models.ErrorCodeInvalidBundle,
+			fmt.Sprintf("Unsupported bundle format version: %d", manifest.FormatVersion),
+			nil,
+		)
+	}
+
+	if err := manifest.Template.IsValid(); err != nil {
+		return nil, models.NewAppError(models.ErrorCodeInvalidBundle, "Bundle manifest has an invalid template", err)
+	}
+
+	if manifest.TreeHash == "" {
+		return nil, models.NewAppError(models.ErrorCodeInvalidBundle, "Bundle manifest is missing the content tree hash", nil)
+	}
+
+	return &manifest, nil
+}
+
+// invalidBundle builds an invalid bundle error for the given archive
+func invalidBundle(bundlePath, reason string, cause error) error {
+	return models.NewAppError(
+		models.ErrorCodeInvalidBundle,
+		fmt.Sprintf("Invalid bundle %s: %s", bundlePath, reason),
+		cause,
+	).WithContext("bundle", bundlePath)
+}
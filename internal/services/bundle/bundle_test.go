@@ -0,0 +1,275 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// createTemplateRepo creates a local git repository and a template pinned to its HEAD
+func createTemplateRepo(t *testing.T) templates.Template {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not available, skipping bundle test")
+	}
+
+	repoDir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	files := map[string]string{
+		filepath.Join(config.StrategicClaudeBasicDir, "core", "README.md"): "core",
+		config.PostInstallScript: "#!/bin/bash\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(repoDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0755); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	run("init", "-q")
+	run("add", "-A")
+	run("commit", "-q", "-m", "first")
+
+	return templates.Template{
+		ID:      "local",
+		Name:    "Local Template",
+		RepoURL: "file://" + repoDir,
+		Branch:  "main",
+		Commit:  run("rev-parse", "HEAD"),
+	}
+}
+
+// writeTestArchive writes a bundle archive with the given manifest and raw entries
+func writeTestArchive(t *testing.T, manifest Manifest, entries map[string]string) string {
+	t.Helper()
+
+	bundlePath := filepath.Join(t.TempDir(), "test"+config.BundleFileExtension)
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	data, _ := json.Marshal(manifest)
+	entries[config.BundleManifestFile] = string(data)
+	for name, content := range entries {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write content: %v", err)
+		}
+	}
+
+	_ = tarWriter.Close()
+	_ = gzipWriter.Close()
+	return bundlePath
+}
+
+func TestService_CreateAndOpen(t *testing.T) {
+	template := createTemplateRepo(t)
+	service := New()
+
+	bundlePath := filepath.Join(t.TempDir(), DefaultBundleName(template))
+	manifest, err := service.Create(template, bundlePath)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if manifest.Template.Commit != template.Commit {
+		t.Errorf("Expected manifest commit %s, got %s", template.Commit, manifest.Template.Commit)
+	}
+
+	readManifest, err := service.ReadManifest(bundlePath)
+	if err != nil {
+		t.Fatalf("ReadManifest() error = %v", err)
+	}
+	if readManifest.TreeHash != manifest.TreeHash {
+		t.Errorf("ReadManifest() tree hash = %s, want %s", readManifest.TreeHash, manifest.TreeHash)
+	}
+
+	b, err := service.Open(bundlePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = b.Cleanup() }()
+
+	content, err := os.ReadFile(filepath.Join(b.ContentDir, config.StrategicClaudeBasicDir, "core", "README.md"))
+	if err != nil {
+		t.Fatalf("Expected framework content in bundle: %v", err)
+	}
+	if string(content) != "core" {
+		t.Errorf("Unexpected bundled content: %s", content)
+	}
+
+	if _, err := os.Stat(filepath.Join(b.ContentDir, ".git")); !os.IsNotExist(err) {
+		t.Error("Expected repository metadata to be excluded from the bundle")
+	}
+
+	info, err := os.Stat(filepath.Join(b.ContentDir, config.PostInstallScript))
+	if err != nil {
+		t.Fatalf("Expected install script in bundle: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Error("Expected executable bit to be preserved")
+	}
+
+	if err := b.Cleanup(); err != nil {
+		t.Errorf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(b.RootDir); !os.IsNotExist(err) {
+		t.Error("Expected extracted bundle to be removed")
+	}
+}
+
+func TestService_Open_InvalidBundles(t *testing.T) {
+	template := templates.Template{
+		ID:      "local",
+		Name:    "Local Template",
+		RepoURL: "file:///nonexistent",
+		Branch:  "main",
+		Commit:  "4efe6386d0a949e3e2ddc1b0902ea937986da62f",
+	}
+	manifest := Manifest{FormatVersion: config.BundleFormatVersion, Template: template, TreeHash: "sha256:00"}
+
+	tests := []struct {
+		name     string
+		manifest Manifest
+		entries  map[string]string
+		wantCode models.ErrorCode
+	}{
+		{
+			name:     "path traversal",
+			manifest: manifest,
+			entries:  map[string]string{"content/../../escape.txt": "x"},
+			wantCode: models.ErrorCodeInvalidBundle,
+		},
+		{
+			name:     "entry outside content directory",
+			manifest: manifest,
+			entries:  map[string]string{"other/file.txt": "x"},
+			wantCode: models.ErrorCodeInvalidBundle,
+		},
+		{
+			name:     "unsupported format version",
+			manifest: Manifest{FormatVersion: 99, Template: template, TreeHash: "sha256:00"},
+			entries:  map[string]string{},
+			wantCode: models.ErrorCodeInvalidBundle,
+		},
+		{
+			name:     "tampered content",
+			manifest: manifest,
+			entries:  map[string]string{"content/file.txt": "x"},
+			wantCode: models.ErrorCodeVerificationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundlePath := writeTestArchive(t, tt.manifest, tt.entries)
+
+			_, err := New().Open(bundlePath)
+			if !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("Open() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestService_Open_SymlinkChain(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	// Each link is inside the bundle by its text, but the second one is
+	// created through the first and the file through both
+	bundlePath := filepath.Join(t.TempDir(), "chain"+config.BundleFileExtension)
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	template := templates.Template{
+		ID:      "local",
+		Name:    "Local Template",
+		RepoURL: "file:///nonexistent",
+		Branch:  "main",
+		Commit:  "4efe6386d0a949e3e2ddc1b0902ea937986da62f",
+	}
+	data, _ := json.Marshal(Manifest{FormatVersion: config.BundleFormatVersion, Template: template, TreeHash: "sha256:00"})
+	headers := []*tar.Header{
+		{Name: config.BundleManifestFile, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg},
+		{Name: "content/a/b/l", Linkname: "../..", Typeflag: tar.TypeSymlink},
+		{Name: "content/a/b/l/l2", Linkname: "../..", Typeflag: tar.TypeSymlink},
+		{Name: "content/a/b/l/l2/x", Mode: 0644, Size: 1, Typeflag: tar.TypeReg},
+	}
+	contents := []string{string(data), "", "", "x"}
+	for i, header := range headers {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(contents[i])); err != nil {
+			t.Fatalf("Failed to write content: %v", err)
+		}
+	}
+	_ = tarWriter.Close()
+	_ = gzipWriter.Close()
+	_ = file.Close()
+
+	if _, err := New().Open(bundlePath); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("Open() error = %v, want code %s", err, models.ErrorCodeInvalidBundle)
+	}
+	for _, path := range []string{filepath.Join(tmpDir, "x"), filepath.Join(filepath.Dir(tmpDir), "x")} {
+		if _, err := os.Lstat(path); err == nil {
+			t.Errorf("Open() wrote %s outside the bundle", path)
+		}
+	}
+}
+
+func TestValidateLinkTarget(t *testing.T) {
+	tests := []struct {
+		relPath string
+		target  string
+		wantErr bool
+	}{
+		{"a/link", "../b/file", false},
+		{"a/link", "file", false},
+		{"a/link", "../../outside", true},
+		{"link", "/etc/passwd", true},
+	}
+
+	for _, tt := range tests {
+		err := validateLinkTarget(tt.relPath, tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateLinkTarget(%q, %q) error = %v, wantErr %v", tt.relPath, tt.target, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// VendorPath returns the location of the vendored template copy inside a project
+func VendorPath(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), config.VendorDir)
+}
+
+// IsVendored reports whether the project has a vendored template copy
+func IsVendored(targetDir string) bool {
+	info, err := os.Stat(filepath.Join(VendorPath(targetDir), config.BundleManifestFile))
+	return err == nil && !info.IsDir()
+}
+
+// Vendor fetches the template at its pinned commit and stores it in the vendor
+// directory, replacing any previous copy only once the new one is complete
+func (s *Service) Vendor(template templates.Template, vendorDir string) (*Manifest, error) {
+	tempDir, manifest, err := s.fetch(template)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = s.gitService.CleanupTempDir(tempDir) // Best effort cleanup
+	}()
+
+	parentDir := filepath.Dir(vendorDir)
+	if err := os.MkdirAll(parentDir, config.DirPermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, parentDir, err)
+	}
+
+	// Stage the new copy next to the vendor directory so the swap is a rename
+	stagingDir, err := os.MkdirTemp(parentDir, config.VendorDir+"-")
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, parentDir, err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := filesystem.New().CopyDirectory(tempDir, filepath.Join(stagingDir, config.BundleContentDir)); err != nil {
+		return nil, fmt.Errorf("failed to copy template content: %w", err)
+	}
+	if err := writeManifest(filepath.Join(stagingDir, config.BundleManifestFile), manifest); err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(vendorDir); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, vendorDir, err)
+	}
+	if err := os.Rename(stagingDir, vendorDir); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, vendorDir, err)
+	}
+	if err := os.Chmod(vendorDir, config.DirPermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, vendorDir, err)
+	}
+
+	return manifest, nil
+}
+
+// ReadVendorManifest reads the manifest of a vendored template copy
+func (s *Service) ReadVendorManifest(vendorDir string) (*Manifest, error) {
+	manifestPath := filepath.Join(vendorDir, config.BundleManifestFile)
+
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, models.NewAppError(
+				models.ErrorCodeInvalidBundle,
+				fmt.Sprintf("No vendored template found in %s", vendorDir),
+				err,
+			)
+		}
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, manifestPath, err)
+	}
+	defer file.Close()
+
+	return decodeManifest(file)
+}
+
+// OpenVendor verifies a vendored template copy and stages it in a temporary
+// directory, so installations that replace the framework directory cannot
+// remove their own source. The caller is responsible for calling Cleanup.
+func (s *Service) OpenVendor(vendorDir string) (*Bundle, error) {
+	manifest, err := s.ReadVendorManifest(vendorDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := RegisteredTemplate(manifest, vendorDir); err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyService.VerifyTreeHash(filepath.Join(vendorDir, config.BundleContentDir), manifest.TreeHash); err != nil {
+		return nil, fmt.Errorf("vendored template does not match its manifest: %w", err)
+	}
+
+	rootDir, err := os.MkdirTemp(config.TempDir(), config.TempDirPrefix)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, config.TempDir(), err)
+	}
+
+	bundle := &Bundle{
+		Manifest:   *manifest,
+		RootDir:    rootDir,
+		ContentDir: filepath.Join(rootDir, config.BundleContentDir),
+	}
+
+	if err := filesystem.New().CopyDirectory(vendorDir, rootDir); err != nil {
+		_ = bundle.Cleanup()
+		return nil, fmt.Errorf("failed to stage vendored template: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// writeManifest writes a manifest as indented JSON
+func writeManifest(manifestPath string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	if err := os.WriteFile(manifestPath, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, manifestPath, err)
+	}
+
+	return nil
+}
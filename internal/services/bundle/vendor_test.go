@@ -0,0 +1,154 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/verify"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// registerTemplate adds template to the registry for the duration of the test
+func registerTemplate(t *testing.T, template templates.Template) {
+	t.Helper()
+
+	original, registered := templates.Registry[template.ID]
+	templates.Registry[template.ID] = template
+	t.Cleanup(func() {
+		if registered {
+			templates.Registry[template.ID] = original
+		} else {
+			delete(templates.Registry, template.ID)
+		}
+	})
+}
+
+func TestService_VendorAndOpenVendor(t *testing.T) {
+	template := createTemplateRepo(t)
+	registerTemplate(t, template)
+	service := New()
+	targetDir := t.TempDir()
+	vendorDir := VendorPath(targetDir)
+
+	if IsVendored(targetDir) {
+		t.Fatal("Expected project without vendored copy")
+	}
+
+	manifest, err := service.Vendor(template, vendorDir)
+	if err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+
+	if !IsVendored(targetDir) {
+		t.Error("Expected project to be vendored")
+	}
+
+	readManifest, err := service.ReadVendorManifest(vendorDir)
+	if err != nil {
+		t.Fatalf("ReadVendorManifest() error = %v", err)
+	}
+	if readManifest.TreeHash != manifest.TreeHash {
+		t.Errorf("ReadVendorManifest() tree hash = %s, want %s", readManifest.TreeHash, manifest.TreeHash)
+	}
+
+	b, err := service.OpenVendor(vendorDir)
+	if err != nil {
+		t.Fatalf("OpenVendor() error = %v", err)
+	}
+	defer func() { _ = b.Cleanup() }()
+
+	if _, err := os.Stat(filepath.Join(b.ContentDir, config.StrategicClaudeBasicDir, "core", "README.md")); err != nil {
+		t.Errorf("Expected staged framework content: %v", err)
+	}
+
+	// Removing the vendor directory must not affect the staged copy
+	if err := os.RemoveAll(vendorDir); err != nil {
+		t.Fatalf("Failed to remove vendor directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(b.ContentDir, config.StrategicClaudeBasicDir, "core", "README.md")); err != nil {
+		t.Errorf("Expected staged copy to survive vendor removal: %v", err)
+	}
+}
+
+func TestService_Vendor_Refresh(t *testing.T) {
+	template := createTemplateRepo(t)
+	service := New()
+	vendorDir := VendorPath(t.TempDir())
+
+	if _, err := service.Vendor(template, vendorDir); err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+
+	stale := filepath.Join(vendorDir, config.BundleContentDir, "stale.txt")
+	if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+		t.Fatalf("Failed to write stale file: %v", err)
+	}
+
+	if _, err := service.Vendor(template, vendorDir); err != nil {
+		t.Fatalf("Vendor() refresh error = %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("Expected refresh to replace the previous vendored copy")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(vendorDir))
+	if err != nil {
+		t.Fatalf("Failed to read parent directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected no staging directories to remain, found %d entries", len(entries))
+	}
+}
+
+func TestService_OpenVendor_Tampered(t *testing.T) {
+	template := createTemplateRepo(t)
+	registerTemplate(t, template)
+	service := New()
+	vendorDir := VendorPath(t.TempDir())
+
+	if _, err := service.Vendor(template, vendorDir); err != nil {
+		t.Fatalf("Vendor() error = %v", err)
+	}
+
+	readme := filepath.Join(vendorDir, config.BundleContentDir, config.StrategicClaudeBasicDir, "core", "README.md")
+	if err := os.WriteFile(readme, []byte("modified"), 0644); err != nil {
+		t.Fatalf("Failed to modify vendored file: %v", err)
+	}
+
+	if _, err := service.OpenVendor(vendorDir); !models.IsErrorCode(err, models.ErrorCodeVerificationFailed) {
+		t.Errorf("OpenVendor() error = %v, want code %s", err, models.ErrorCodeVerificationFailed)
+	}
+
+	// Rewriting the manifest to match is caught by the registry
+	manifest, err := service.ReadVendorManifest(vendorDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.TreeHash, err = verify.New().ComputeTreeHash(filepath.Join(vendorDir, config.BundleContentDir)); err != nil {
+		t.Fatal(err)
+	}
+	for _, edit := range []func(*Manifest){
+		func(m *Manifest) { m.Template.Commit = "0123456789abcdef0123456789abcdef01234567" },
+		func(m *Manifest) { m.Template.ID = "unknown" },
+	} {
+		edited := *manifest
+		edit(&edited)
+		if err := writeManifest(filepath.Join(vendorDir, config.BundleManifestFile), &edited); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := service.OpenVendor(vendorDir); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+			t.Errorf("OpenVendor() of %+v error = %v, want code %s", edited.Template, err, models.ErrorCodeInvalidBundle)
+		}
+	}
+}
+
+func TestService_ReadVendorManifest_Missing(t *testing.T) {
+	_, err := New().ReadVendorManifest(VendorPath(t.TempDir()))
+	if !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("ReadVendorManifest() error = %v, want code %s", err, models.ErrorCodeInvalidBundle)
+	}
+}
@@ -0,0 +1,299 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/verify"
+)
+
+// Entry describes a cached template repository at a single commit
+type Entry struct {
+	RepoURL    string    `json:"repo_url"`
+	Commit     string    `json:"commit"`
+	TemplateID string    `json:"template_id,omitempty"`
+	TreeHash   string    `json:"tree_hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsed   time.Time `json:"last_used"`
+
+	// Populated when listing, not persisted
+	Path string `json:"-"`
+	Size int64  `json:"-"`
+}
+
+// CleanOptions selects which cache entries to remove; empty options remove everything
+type CleanOptions struct {
+	OlderThan  time.Duration // Only entries not used within this duration
+	TemplateID string        // Only entries for this template
+}
+
+// Service manages the local cache of fetched template repositories.
+// Entries are keyed by repository URL and commit, so they never go stale.
+type Service struct {
+	root              string
+	filesystemService *filesystem.Service
+	verifyService     *verify.Service
+}
+
+// New creates a cache service rooted at the default cache directory.
+// Caching is disabled when no cache directory can be determined.
+func New() *Service {
+	root, err := Dir()
+	if err != nil {
+		root = ""
+	}
+	return NewWithRoot(root)
+}
+
+// NewWithRoot creates a cache service rooted at the given directory
+func NewWithRoot(root string) *Service {
+	return &Service{
+		root:              root,
+		filesystemService: filesystem.New(),
+		verifyService:     verify.New(),
+	}
+}
+
+// Dir returns the cache directory: $SCB_CACHE_DIR, or below XDG_CACHE_HOME or
+// the platform default
+func Dir() (string, error) {
+	if dir := os.Getenv(config.CacheDirEnvVar); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Clean(dir), nil
+	}
+	if xdgCache := os.Getenv("XDG_CACHE_HOME"); xdgCache != "" && filepath.IsAbs(xdgCache) {
+		return filepath.Join(xdgCache, config.CacheDirName), nil
+	}
+
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return "", models.NewAppError(models.ErrorCodeFileSystemError, "Failed to determine cache directory", err)
+	}
+
+	return filepath.Join(userCache, config.CacheDirName), nil
+}
+
+// Root returns the directory this cache is stored in
+func (s *Service) Root() string {
+	return s.root
+}
+
+// Enabled reports whether the cache has a usable location
+func (s *Service) Enabled() bool {
+	return s.root != ""
+}
+
+// Lookup returns the cached content directory for a repository commit.
+// Entries whose content no longer matches the recorded tree hash are removed.
+func (s *Service) Lookup(repoURL, commit string) (string, bool) {
+	if !s.Enabled() {
+		return "", false
+	}
+
+	entryDir := s.entryDir(repoURL, commit)
+	entry, err := readEntry(entryDir)
+	if err != nil {
+		return "", false
+	}
+
+	contentDir := filepath.Join(entryDir, config.BundleContentDir)
+	if err := s.verifyService.VerifyTreeHash(contentDir, entry.TreeHash); err != nil {
+		_ = os.RemoveAll(entryDir) // Corrupted entry, fetch again
+		return "", false
+	}
+
+	entry.LastUsed = time.Now().UTC()
+	_ = writeEntry(entryDir, entry) // Usage time is best effort
+
+	return contentDir, true
+}
+
+// Store copies fetched repository content into the cache, excluding git metadata
+func (s *Service) Store(repoURL, commit, templateID, sourceDir string) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	entryDir := s.entryDir(repoURL, commit)
+	parentDir := filepath.Dir(entryDir)
+	if err := os.MkdirAll(parentDir, config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, parentDir, err)
+	}
+
+	// Stage next to the final location so concurrent readers never see a partial entry
+	stagingDir, err := os.MkdirTemp(parentDir, ".staging-")
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, parentDir, err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	contentDir := filepath.Join(stagingDir, config.BundleContentDir)
+	if err := s.filesystemService.CopyDirectory(sourceDir, contentDir); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(contentDir, ".git")); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, contentDir, err)
+	}
+
+	treeHash, err := s.verifyService.ComputeTreeHash(contentDir)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	entry := &Entry{
+		RepoURL:    repoURL,
+		Commit:     commit,
+		TemplateID: templateID,
+		TreeHash:   treeHash,
+		CreatedAt:  now,
+		LastUsed:   now,
+	}
+	if err := writeEntry(stagingDir, entry); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(entryDir); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryDir, err)
+	}
+	if err := os.Rename(stagingDir, entryDir); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryDir, err)
+	}
+
+	return os.Chmod(entryDir, config.DirPermissions)
+}
+
+// List returns all cache entries, most recently used first
+func (s *Service) List() ([]Entry, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+
+	repoDirs, err := os.ReadDir(s.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, s.root, err)
+	}
+
+	var entries []Entry
+	for _, repoDir := range repoDirs {
+		if !repoDir.IsDir() {
+			continue
+		}
+
+		commitDirs, err := os.ReadDir(filepath.Join(s.root, repoDir.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, commitDir := range commitDirs {
+			entryDir := filepath.Join(s.root, repoDir.Name(), commitDir.Name())
+			entry, err := readEntry(entryDir)
+			if err != nil {
+				continue // Staging directories and unrelated files
+			}
+
+			entry.Path = entryDir
+			entry.Size = directorySize(entryDir)
+			entries = append(entries, *entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+
+	return entries, nil
+}
+
+// Clean removes the cache entries selected by the options and returns them
+func (s *Service) Clean(opts CleanOptions) ([]Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-opts.OlderThan)
+
+	var removed []Entry
+	for _, entry := range entries {
+		if opts.TemplateID != "" && entry.TemplateID != opts.TemplateID {
+			continue
+		}
+		if opts.OlderThan > 0 && entry.LastUsed.After(cutoff) {
+			continue
+		}
+
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return removed, models.NewFileSystemError(models.ErrorCodeFileSystemError, entry.Path, err)
+		}
+		removed = append(removed, entry)
+
+		// Drop the repository directory once its last commit is gone
+		_ = os.Remove(filepath.Dir(entry.Path))
+	}
+
+	return removed, nil
+}
+
+// entryDir returns the directory for a repository commit
+func (s *Service) entryDir(repoURL, commit string) string {
+	sum := sha256.Sum256([]byte(repoURL))
+	return filepath.Join(s.root, hex.EncodeToString(sum[:8]), commit)
+}
+
+// readEntry loads the metadata of a cache entry
+func readEntry(entryDir string) (*Entry, error) {
+	data, err := os.ReadFile(filepath.Join(entryDir, config.CacheEntryFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cache entry %s: %w", entryDir, err)
+	}
+
+	return &entry, nil
+}
+
+// writeEntry stores the metadata of a cache entry
+func writeEntry(entryDir string, entry *Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	entryPath := filepath.Join(entryDir, config.CacheEntryFile)
+	if err := os.WriteFile(entryPath, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryPath, err)
+	}
+
+	return nil
+}
+
+// directorySize returns the total size of regular files below dir
+func directorySize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
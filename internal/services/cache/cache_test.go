@@ -0,0 +1,213 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+const testRepoURL = "https://example.com/template.git"
+
+// createSourceDir creates a fake cloned repository
+func createSourceDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		filepath.Join(config.StrategicClaudeBasicDir, "core", "README.md"): "core",
+		filepath.Join(".git", "HEAD"):                                      "ref: refs/heads/main",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	return dir
+}
+
+func TestDir(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", xdg)
+
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+	if want := filepath.Join(xdg, config.CacheDirName); dir != want {
+		t.Errorf("Dir() = %v, want %v", dir, want)
+	}
+
+	// Relative XDG paths are ignored per the XDG base directory specification
+	t.Setenv("XDG_CACHE_HOME", "relative/cache")
+	dir, err = Dir()
+	if err == nil && dir == filepath.Join("relative/cache", config.CacheDirName) {
+		t.Error("Expected relative XDG_CACHE_HOME to be ignored")
+	}
+
+	// SCB_CACHE_DIR is used as is
+	override := t.TempDir()
+	t.Setenv(config.CacheDirEnvVar, override)
+	if dir, err := Dir(); err != nil || dir != override {
+		t.Errorf("Dir() = %v, %v, want %v", dir, err, override)
+	}
+}
+
+func TestService_StoreAndLookup(t *testing.T) {
+	service := NewWithRoot(t.TempDir())
+	commit := "0123456789abcdef0123456789abcdef01234567"
+
+	if _, ok := service.Lookup(testRepoURL, commit); ok {
+		t.Fatal("Expected cache miss before storing")
+	}
+
+	if err := service.Store(testRepoURL, commit, "main", createSourceDir(t)); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	contentDir, ok := service.Lookup(testRepoURL, commit)
+	if !ok {
+		t.Fatal("Expected cache hit after storing")
+	}
+	if _, err := os.Stat(filepath.Join(contentDir, config.StrategicClaudeBasicDir, "core", "README.md")); err != nil {
+		t.Errorf("Expected cached content: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(contentDir, ".git")); !os.IsNotExist(err) {
+		t.Error("Expected git metadata to be excluded from the cache")
+	}
+
+	// Corrupted entries are discarded
+	if err := os.WriteFile(filepath.Join(contentDir, "injected"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to corrupt entry: %v", err)
+	}
+	if _, ok := service.Lookup(testRepoURL, commit); ok {
+		t.Error("Expected corrupted entry to be a cache miss")
+	}
+	if entries, _ := service.List(); len(entries) != 0 {
+		t.Errorf("Expected corrupted entry to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestService_Disabled(t *testing.T) {
+	service := NewWithRoot("")
+
+	if err := service.Store(testRepoURL, "abc", "main", createSourceDir(t)); err != nil {
+		t.Errorf("Store() on disabled cache error = %v", err)
+	}
+	if _, ok := service.Lookup(testRepoURL, "abc"); ok {
+		t.Error("Expected disabled cache to always miss")
+	}
+}
+
+func TestService_Clean(t *testing.T) {
+	source := createSourceDir(t)
+
+	tests := []struct {
+		name          string
+		opts          CleanOptions
+		expectRemoved int
+	}{
+		{name: "everything", opts: CleanOptions{}, expectRemoved: 3},
+		{name: "by template", opts: CleanOptions{TemplateID: "ccr"}, expectRemoved: 1},
+		{name: "by age", opts: CleanOptions{OlderThan: 24 * time.Hour}, expectRemoved: 1},
+		{name: "by age and template", opts: CleanOptions{OlderThan: 24 * time.Hour, TemplateID: "ccr"}, expectRemoved: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewWithRoot(t.TempDir())
+
+			entries := []struct{ commit, templateID string }{
+				{"1111111111111111111111111111111111111111", "main"},
+				{"2222222222222222222222222222222222222222", "main"},
+				{"3333333333333333333333333333333333333333", "ccr"},
+			}
+			for _, e := range entries {
+				if err := service.Store(testRepoURL, e.commit, e.templateID, source); err != nil {
+					t.Fatalf("Store() error = %v", err)
+				}
+			}
+
+			// Age the first entry
+			entryDir := service.entryDir(testRepoURL, entries[0].commit)
+			entry, err := readEntry(entryDir)
+			if err != nil {
+				t.Fatalf("readEntry() error = %v", err)
+			}
+			entry.LastUsed = time.Now().Add(-48 * time.Hour)
+			if err := writeEntry(entryDir, entry); err != nil {
+				t.Fatalf("writeEntry() error = %v", err)
+			}
+
+			removed, err := service.Clean(tt.opts)
+			if err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+			if len(removed) != tt.expectRemoved {
+				t.Errorf("Clean() removed %d entries, want %d", len(removed), tt.expectRemoved)
+			}
+
+			remaining, _ := service.List()
+			if len(remaining) != len(entries)-tt.expectRemoved {
+				t.Errorf("List() = %d entries, want %d", len(remaining), len(entries)-tt.expectRemoved)
+			}
+			for _, e := range remaining {
+				if e.Size == 0 {
+					t.Errorf("Expected entry size to be computed for %s", e.Commit)
+				}
+			}
+		})
+	}
+}
+
+func TestCleanTempDirs(t *testing.T) {
+	tempRoot := t.TempDir()
+	previous := config.Current()
+	cfg := config.DefaultConfig()
+	cfg.TempDir = tempRoot
+	if err := config.SetCurrent(cfg); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	t.Cleanup(func() { _ = config.SetCurrent(previous) })
+
+	old := time.Now().Add(-2 * config.StaleTempDirAge)
+	for _, name := range []string{config.TempDirPrefix + "stale", config.TempDirPrefix + "fresh", "other-stale"} {
+		dir := filepath.Join(tempRoot, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file in %s: %v", name, err)
+		}
+		if name != config.TempDirPrefix+"fresh" {
+			if err := os.Chtimes(dir, old, old); err != nil {
+				t.Fatalf("Failed to age %s: %v", name, err)
+			}
+		}
+	}
+
+	// A symlink carrying the prefix is never followed into its target
+	if err := os.Symlink(filepath.Join(tempRoot, "other-stale"), filepath.Join(tempRoot, config.TempDirPrefix+"link")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	removed, err := CleanTempDirs(config.StaleTempDirAge)
+	if err != nil {
+		t.Fatalf("CleanTempDirs() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0].Path != filepath.Join(tempRoot, config.TempDirPrefix+"stale") || removed[0].Size != 7 {
+		t.Fatalf("CleanTempDirs() = %+v, want only the stale checkout", removed)
+	}
+	for _, name := range []string{config.TempDirPrefix + "fresh", "other-stale", config.TempDirPrefix + "link"} {
+		if _, err := os.Lstat(filepath.Join(tempRoot, name)); err != nil {
+			t.Errorf("%s was removed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// TempDir is a template checkout left in the temporary directory
+type TempDir struct {
+	Path     string
+	Modified time.Time
+	Size     int64
+}
+
+// StaleTempDirs returns the template checkouts in config.TempDir() that were
+// not modified within olderThan. Runs that crashed or were killed leave them
+// behind; --keep-temp leaves them on purpose.
+func StaleTempDirs(olderThan time.Duration) ([]TempDir, error) {
+	root := config.TempDir()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var stale []TempDir
+	for _, entry := range entries {
+		// Only real directories carrying the prefix, never symlinks to elsewhere
+		if !strings.HasPrefix(entry.Name(), config.TempDirPrefix) || !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		stale = append(stale, TempDir{Path: filepath.Join(root, entry.Name()), Modified: info.ModTime()})
+	}
+	return stale, nil
+}
+
+// CleanTempDirs removes the template checkouts not modified within olderThan
+// and returns them. Directories that cannot be removed, such as those of other
+// users, are skipped.
+func CleanTempDirs(olderThan time.Duration) ([]TempDir, error) {
+	stale, err := StaleTempDirs(olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []TempDir
+	for _, dir := range stale {
+		dir.Size = directorySize(dir.Path)
+		if err := os.RemoveAll(dir.Path); err != nil {
+			continue
+		}
+		removed = append(removed, dir)
+	}
+	return removed, nil
+}
@@ -0,0 +1,202 @@
+package catalog
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Kinds of catalog entries, named after their directories in core/ and .claude/
+const (
+	KindAgents   = config.AgentsDir
+	KindCommands = config.CommandsDir
+)
+
+// Sources of catalog entries
+const (
+	SourceFramework = "framework" // Shipped by the installed template in core/
+	SourceUser      = "user"      // Added by the user in .claude/
+)
+
+// markdownExt is the extension of agent and command definitions
+const markdownExt = ".md"
+
+// Entry describes an agent or command definition
+type Entry struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Source      string `json:"source"`
+	Path        string `json:"path"`               // Relative to the project directory
+	Disabled    bool   `json:"disabled,omitempty"` // Hidden from Claude Code by Disable
+	Override    string `json:"override,omitempty"` // Copy in overrides/ used instead of Path
+}
+
+// Service lists the agents and commands available in a project
+type Service struct{}
+
+// New creates a new catalog service instance
+func New() *Service {
+	return &Service{}
+}
+
+// List returns the agents or commands of the project in targetDir: the
+// framework's from .strategic-claude-basic/core/<kind> followed by the user's
+// own from .claude/<kind>, each sorted by name. Disabled framework items are
+// included and marked as such; overridden ones are described by their override.
+func (s *Service) List(targetDir, kind string) ([]Entry, error) {
+	if kind != KindAgents && kind != KindCommands {
+		return nil, models.NewValidationError("kind", kind,
+			fmt.Sprintf("must be one of: %s, %s", KindAgents, KindCommands))
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	coreDir := filepath.Join(strategicDir, config.CoreDir, kind)
+	framework, err := scan(targetDir, coreDir, kind, SourceFramework)
+	if err != nil {
+		return nil, err
+	}
+
+	disabled, err := readDisabled(strategicDir)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := readOverrides(strategicDir, kind)
+	if err != nil {
+		return nil, err
+	}
+	for i := range framework {
+		relPath, err := filepath.Rel(coreDir, filepath.Join(targetDir, filepath.FromSlash(framework[i].Path)))
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeInvalidPath, framework[i].Path, err)
+		}
+		relPath = filepath.ToSlash(relPath)
+		framework[i].Disabled = slices.Contains(disabled[kind], relPath)
+		if overrides[relPath] {
+			if err := applyOverride(targetDir, strategicDir, kind, relPath, &framework[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(framework, func(i, j int) bool {
+		return framework[i].Name < framework[j].Name
+	})
+
+	// The "strategic" symlink in .claude/<kind> leads back to core/ and is not
+	// followed, so only the user's own definitions are found here
+	user, err := scan(targetDir, filepath.Join(targetDir, config.ClaudeDir, kind), kind, SourceUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(framework, user...), nil
+}
+
+// scan collects the markdown definitions below root
+func scan(targetDir, root, kind, source string) ([]Entry, error) {
+	var entries []Entry
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || filepath.Ext(d.Name()) != markdownExt || d.Name() == config.ClaudeConfigFile {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		entry, err := newEntry(targetDir, root, path, kind, source, string(content))
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// applyOverride describes entry by its override in overrides/<kind>
+func applyOverride(targetDir, strategicDir, kind, relPath string, entry *Entry) error {
+	overridePath := filepath.Join(strategicDir, config.OverridesDir, kind, filepath.FromSlash(relPath))
+	content, err := os.ReadFile(overridePath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, overridePath, err)
+	}
+
+	relToProject, err := filepath.Rel(targetDir, overridePath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeInvalidPath, overridePath, err)
+	}
+	entry.Override = filepath.ToSlash(relToProject)
+
+	frontMatter := utils.ParseFrontMatter(string(content))
+	if description := frontMatter["description"]; description != "" {
+		entry.Description = description
+	}
+	if name := frontMatter["name"]; kind == KindAgents && name != "" {
+		entry.Name = name
+	}
+	return nil
+}
+
+// newEntry builds a catalog entry from a definition file. Agents are named by
+// their front matter name, commands by their path, since that is how Claude
+// Code invokes them.
+func newEntry(targetDir, root, path, kind, source, content string) (Entry, error) {
+	relToRoot, err := filepath.Rel(root, path)
+	if err != nil {
+		return Entry{}, err
+	}
+	relToProject, err := filepath.Rel(targetDir, path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	frontMatter := utils.ParseFrontMatter(content)
+	entry := Entry{
+		Name:        strings.TrimSuffix(filepath.ToSlash(relToRoot), markdownExt),
+		Description: frontMatter["description"],
+		Source:      source,
+		Path:        filepath.ToSlash(relToProject),
+	}
+	if name := frontMatter["name"]; kind == KindAgents && name != "" {
+		entry.Name = name
+	}
+
+	return entry, nil
+}
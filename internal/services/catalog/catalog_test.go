@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// writeFile writes content to a path relative to targetDir
+func writeFile(t *testing.T, targetDir, relPath, content string) {
+	t.Helper()
+
+	fullPath := filepath.Join(targetDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_List(t *testing.T) {
+	targetDir := t.TempDir()
+	writeFile(t, targetDir, ".strategic-claude-basic/core/agents/planner.md", "---\nname: strategic-planner\ndescription: Plans work\n---\n")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/agents/reviewer.md", "# No front matter\n")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/agents/README.txt", "not an agent")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/commands/research/deep.md", "---\ndescription: \"Deep research\"\n---\n")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/commands/CLAUDE.md", "guide")
+	writeFile(t, targetDir, ".claude/agents/mine.md", "---\nname: my-agent\ndescription: Mine\n---\n")
+	writeFile(t, targetDir, ".claude/commands/deploy.md", "---\nname: ignored\ndescription: Deploy\n---\n")
+
+	// The strategic symlink must not list framework entries twice
+	if err := os.Symlink("../../.strategic-claude-basic/core/agents", filepath.Join(targetDir, ".claude", "agents", "strategic")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kind string
+		want []Entry
+	}{
+		{
+			kind: KindAgents,
+			want: []Entry{
+				{Name: "reviewer", Description: "", Source: SourceFramework, Path: ".strategic-claude-basic/core/agents/reviewer.md"},
+				{Name: "strategic-planner", Description: "Plans work", Source: SourceFramework, Path: ".strategic-claude-basic/core/agents/planner.md"},
+				{Name: "my-agent", Description: "Mine", Source: SourceUser, Path: ".claude/agents/mine.md"},
+			},
+		},
+		{
+			kind: KindCommands,
+			want: []Entry{
+				{Name: "research/deep", Description: "Deep research", Source: SourceFramework, Path: ".strategic-claude-basic/core/commands/research/deep.md"},
+				{Name: "deploy", Description: "Deploy", Source: SourceUser, Path: ".claude/commands/deploy.md"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			got, err := New().List(targetDir, tt.kind)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("List() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("List()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_List_Errors(t *testing.T) {
+	installed := t.TempDir()
+	if err := os.Mkdir(filepath.Join(installed, config.StrategicClaudeBasicDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		targetDir string
+		kind      string
+		wantCode  models.ErrorCode
+	}{
+		{"unknown kind", installed, "hooks", models.ErrorCodeValidationFailed},
+		{"not installed", t.TempDir(), KindAgents, models.ErrorCodeNotInstalled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().List(tt.targetDir, tt.kind); !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("List() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
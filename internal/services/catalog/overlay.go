@@ -0,0 +1,348 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// disabledItems lists the disabled files of each core directory, as paths
+// relative to core/<kind>
+type disabledItems map[string][]string
+
+// Disable hides a framework agent or command from Claude Code. The item stays
+// in core/ but is left out of a generated overlay that .claude/<kind>/strategic
+// points to instead. name is the name shown by List or the file's path below
+// core/<kind> without the extension. Returns the disabled entry.
+func (s *Service) Disable(targetDir, kind, name string) (*Entry, error) {
+	entries, err := s.List(targetDir, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, relPath := findFrameworkEntry(targetDir, kind, entries, name)
+	if entry == nil {
+		return nil, models.NewValidationError("name", name, fmt.Sprintf("no framework %s with this name", strings.TrimSuffix(kind, "s")))
+	}
+	if entry.Disabled {
+		return nil, models.NewValidationError("name", name, "is already disabled")
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	disabled, err := readDisabled(strategicDir)
+	if err != nil {
+		return nil, err
+	}
+	disabled[kind] = append(disabled[kind], relPath)
+	slices.Sort(disabled[kind])
+
+	if err := s.applyDisabled(targetDir, disabled); err != nil {
+		return nil, err
+	}
+
+	entry.Disabled = true
+	return entry, nil
+}
+
+// Enable makes a disabled agent or command visible to Claude Code again. name
+// is matched like in Disable; items whose file no longer exists in core/ can be
+// enabled by path. Returns the path of the enabled item below core/<kind>.
+func (s *Service) Enable(targetDir, kind, name string) (string, error) {
+	entries, err := s.List(targetDir, kind)
+	if err != nil {
+		return "", err
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	disabled, err := readDisabled(strategicDir)
+	if err != nil {
+		return "", err
+	}
+
+	_, relPath := findFrameworkEntry(targetDir, kind, entries, name)
+	if relPath == "" {
+		relPath = name + markdownExt
+	}
+	if !slices.Contains(disabled[kind], relPath) {
+		return "", models.NewValidationError("name", name, "is not disabled")
+	}
+
+	disabled[kind] = slices.DeleteFunc(disabled[kind], func(item string) bool { return item == relPath })
+	if err := s.applyDisabled(targetDir, disabled); err != nil {
+		return "", err
+	}
+
+	return relPath, nil
+}
+
+// Override copies a framework agent or command into
+// .strategic-claude-basic/overrides/<kind> for editing. The copy takes
+// precedence over the core file of the same relative path and is kept across
+// core updates. name is matched like in Disable. Returns the copy's path.
+func (s *Service) Override(targetDir, kind, name string) (string, error) {
+	entries, err := s.List(targetDir, kind)
+	if err != nil {
+		return "", err
+	}
+
+	entry, relPath := findFrameworkEntry(targetDir, kind, entries, name)
+	if entry == nil {
+		return "", models.NewValidationError("name", name, fmt.Sprintf("no framework %s with this name", strings.TrimSuffix(kind, "s")))
+	}
+	if entry.Override != "" {
+		return "", models.NewValidationError("name", name, fmt.Sprintf("is already overridden by %s", entry.Override))
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	sourcePath := filepath.Join(strategicDir, config.CoreDir, kind, filepath.FromSlash(relPath))
+	overridePath := filepath.Join(strategicDir, config.OverridesDir, kind, filepath.FromSlash(relPath))
+
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(overridePath), config.DirPermissions); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(overridePath), err)
+	}
+	if err := os.WriteFile(overridePath, content, config.FilePermissions); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, overridePath, err)
+	}
+
+	if err := s.Apply(targetDir); err != nil {
+		return "", err
+	}
+	return overridePath, nil
+}
+
+// ResetOverride deletes the override of a framework agent or command, so the
+// core file is used again. Returns the deleted copy's path.
+func (s *Service) ResetOverride(targetDir, kind, name string) (string, error) {
+	entries, err := s.List(targetDir, kind)
+	if err != nil {
+		return "", err
+	}
+
+	entry, _ := findFrameworkEntry(targetDir, kind, entries, name)
+	if entry == nil || entry.Override == "" {
+		return "", models.NewValidationError("name", name, "is not overridden")
+	}
+
+	overridePath := filepath.Join(targetDir, filepath.FromSlash(entry.Override))
+	if err := os.Remove(overridePath); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, overridePath, err)
+	}
+
+	if err := s.Apply(targetDir); err != nil {
+		return "", err
+	}
+	return overridePath, nil
+}
+
+// RefreshOverlays regenerates the overlays from the current core/ and
+// overrides/ content, so disabled items stay hidden and overrides stay in
+// effect after core updates. Symlinks are not touched.
+func (s *Service) RefreshOverlays(targetDir string) error {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	disabled, err := readDisabled(strategicDir)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range config.GetOverlayKinds() {
+		overrides, err := readOverrides(strategicDir, kind)
+		if err != nil {
+			return err
+		}
+		if err := buildOverlay(strategicDir, kind, disabled[kind], overrides); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply rebuilds the overlays and points the .claude and .codex symlinks at
+// them, or back at core/ for directories without disabled or overridden items
+func (s *Service) Apply(targetDir string) error {
+	if err := s.RefreshOverlays(targetDir); err != nil {
+		return err
+	}
+
+	symlinkService := symlink.New()
+	if err := symlinkService.CreateSymlinks(targetDir); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.CodexDir)); err == nil {
+		if err := symlinkService.CreateCodexSymlinks(targetDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyDisabled saves the disable list and applies it
+func (s *Service) applyDisabled(targetDir string, disabled disabledItems) error {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if err := writeDisabled(strategicDir, disabled); err != nil {
+		return err
+	}
+	return s.Apply(targetDir)
+}
+
+// findFrameworkEntry returns the framework entry called name and its path below core/<kind>
+func findFrameworkEntry(targetDir, kind string, entries []Entry, name string) (*Entry, string) {
+	coreDir := filepath.ToSlash(filepath.Join(config.FrameworkDir(), config.CoreDir, kind)) + "/"
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Source != SourceFramework {
+			continue
+		}
+
+		relPath := strings.TrimPrefix(entry.Path, coreDir)
+		if entry.Name == name || strings.TrimSuffix(relPath, markdownExt) == name {
+			return entry, relPath
+		}
+	}
+
+	return nil, ""
+}
+
+// buildOverlay mirrors core/<kind> into the overlay with a symlink per file,
+// leaving out the disabled ones and linking overridden ones to their copy in
+// overrides/<kind>. Without disabled or overridden items the overlay is removed.
+func buildOverlay(strategicDir, kind string, disabled []string, overrides map[string]bool) error {
+	overlayDir := filepath.Join(strategicDir, config.OverlayDir, kind)
+	if err := os.RemoveAll(overlayDir); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, overlayDir, err)
+	}
+	if len(disabled) == 0 && len(overrides) == 0 {
+		return nil
+	}
+
+	coreDir := filepath.Join(strategicDir, config.CoreDir, kind)
+	if err := os.MkdirAll(overlayDir, config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, overlayDir, err)
+	}
+
+	err := filepath.WalkDir(coreDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == coreDir {
+				return nil
+			}
+			return err
+		}
+
+		relPath, err := filepath.Rel(coreDir, path)
+		if err != nil || relPath == "." {
+			return err
+		}
+		overlayPath := filepath.Join(overlayDir, relPath)
+
+		if d.IsDir() {
+			return os.MkdirAll(overlayPath, config.DirPermissions)
+		}
+		if slices.Contains(disabled, filepath.ToSlash(relPath)) {
+			return nil
+		}
+		if overrides[filepath.ToSlash(relPath)] {
+			path = filepath.Join(strategicDir, config.OverridesDir, kind, relPath)
+		}
+
+		linkTarget, err := filepath.Rel(filepath.Dir(overlayPath), path)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(linkTarget, overlayPath)
+	})
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, overlayDir, err)
+	}
+
+	return nil
+}
+
+// readOverrides returns the files in overrides/<kind>, as paths relative to it
+func readOverrides(strategicDir, kind string) (map[string]bool, error) {
+	overrides := make(map[string]bool)
+
+	root := filepath.Join(strategicDir, config.OverridesDir, kind)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		overrides[filepath.ToSlash(relPath)] = true
+		return nil
+	})
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+	}
+
+	return overrides, nil
+}
+
+// readDisabled loads the disable list; a missing file means nothing is disabled
+func readDisabled(strategicDir string) (disabledItems, error) {
+	disabled := make(disabledItems)
+
+	path := filepath.Join(strategicDir, config.DisabledItemsFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return disabled, nil
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+
+	if err := json.Unmarshal(data, &disabled); err != nil {
+		return nil, models.NewAppError(
+			models.ErrorCodeInvalidConfiguration,
+			fmt.Sprintf("Failed to parse %s", path),
+			err,
+		)
+	}
+	return disabled, nil
+}
+
+// writeDisabled saves the disable list, removing it when nothing is disabled
+func writeDisabled(strategicDir string, disabled disabledItems) error {
+	path := filepath.Join(strategicDir, config.DisabledItemsFile)
+
+	for kind, items := range disabled {
+		if len(items) == 0 {
+			delete(disabled, kind)
+		}
+	}
+	if len(disabled) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(disabled, "", "  ")
+	if err != nil {
+		return models.NewAppError(models.ErrorCodeFileSystemError, "Failed to encode disabled items", err)
+	}
+	return utils.WriteFileAtomic(path, append(data, '\n'), config.FilePermissions)
+}
@@ -0,0 +1,199 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+)
+
+// createLinkedInstallation creates framework commands and the .claude symlinks to them
+func createLinkedInstallation(t *testing.T) string {
+	t.Helper()
+
+	targetDir := t.TempDir()
+	writeFile(t, targetDir, ".strategic-claude-basic/core/commands/plan.md", "---\ndescription: Plan\n---\n")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/commands/research/deep.md", "---\ndescription: Deep\n---\n")
+	writeFile(t, targetDir, ".strategic-claude-basic/core/agents/helper.md", "---\nname: helper-agent\n---\n")
+	if err := symlink.New().CreateSymlinks(targetDir); err != nil {
+		t.Fatalf("Failed to create symlinks: %v", err)
+	}
+	return targetDir
+}
+
+// visible reports whether a command file can be reached through .claude/commands/strategic
+func visible(targetDir, relPath string) bool {
+	_, err := os.Stat(filepath.Join(targetDir, config.ClaudeDir, config.CommandsDir, "strategic", filepath.FromSlash(relPath)))
+	return err == nil
+}
+
+func TestService_DisableEnable(t *testing.T) {
+	targetDir := createLinkedInstallation(t)
+	service := New()
+
+	entry, err := service.Disable(targetDir, KindCommands, "research/deep")
+	if err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if !entry.Disabled || entry.Name != "research/deep" {
+		t.Errorf("Disable() = %+v, want research/deep disabled", entry)
+	}
+	if visible(targetDir, "research/deep.md") || !visible(targetDir, "plan.md") {
+		t.Error("Disable() should hide research/deep.md and keep plan.md")
+	}
+
+	statuses, err := symlink.New().ValidateSymlinks(targetDir)
+	if err != nil {
+		t.Fatalf("ValidateSymlinks() error = %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Valid {
+			t.Errorf("symlink %s is invalid after Disable(): %s", status.Path, status.Error)
+		}
+	}
+
+	// A core update adds a command; the overlay picks it up and keeps hiding the disabled one
+	writeFile(t, targetDir, ".strategic-claude-basic/core/commands/new.md", "new")
+	if err := service.RefreshOverlays(targetDir); err != nil {
+		t.Fatalf("RefreshOverlays() error = %v", err)
+	}
+	if !visible(targetDir, "new.md") || visible(targetDir, "research/deep.md") {
+		t.Error("RefreshOverlays() should show new.md and keep research/deep.md hidden")
+	}
+
+	entries, err := service.List(targetDir, KindCommands)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Disabled != (entry.Name == "research/deep") {
+			t.Errorf("List() entry %s disabled = %v", entry.Name, entry.Disabled)
+		}
+	}
+
+	if _, err := service.Enable(targetDir, KindCommands, "research/deep"); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !visible(targetDir, "research/deep.md") {
+		t.Error("Enable() should make research/deep.md visible again")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.OverlayDir, KindCommands)); !os.IsNotExist(err) {
+		t.Error("Enable() should remove the overlay once nothing is disabled")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.DisabledItemsFile)); !os.IsNotExist(err) {
+		t.Error("Enable() should remove the disable list once it is empty")
+	}
+}
+
+func TestService_DisableEnable_Errors(t *testing.T) {
+	targetDir := createLinkedInstallation(t)
+	service := New()
+	if _, err := service.Disable(targetDir, KindAgents, "helper-agent"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		enable  bool
+		kind    string
+		argName string
+	}{
+		{"disable unknown", false, KindCommands, "missing"},
+		{"disable twice", false, KindAgents, "helper"},
+		{"enable not disabled", true, KindCommands, "plan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.enable {
+				_, err = service.Enable(targetDir, tt.kind, tt.argName)
+			} else {
+				_, err = service.Disable(targetDir, tt.kind, tt.argName)
+			}
+			if !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+				t.Errorf("error = %v, want code %s", err, models.ErrorCodeValidationFailed)
+			}
+		})
+	}
+}
+
+func TestService_Override(t *testing.T) {
+	targetDir := createLinkedInstallation(t)
+	service := New()
+
+	overridePath, err := service.Override(targetDir, KindCommands, "plan")
+	if err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+	wantPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.OverridesDir, KindCommands, "plan.md")
+	if overridePath != wantPath {
+		t.Errorf("Override() = %s, want %s", overridePath, wantPath)
+	}
+
+	// Edits to the copy are what Claude Code sees
+	writeFile(t, targetDir, ".strategic-claude-basic/overrides/commands/plan.md", "---\ndescription: My plan\n---\n")
+	content, err := os.ReadFile(filepath.Join(targetDir, config.ClaudeDir, config.CommandsDir, "strategic", "plan.md"))
+	if err != nil {
+		t.Fatalf("Failed to read plan.md through the symlink: %v", err)
+	}
+	if string(content) != "---\ndescription: My plan\n---\n" {
+		t.Errorf("plan.md through the symlink = %q, want the override", content)
+	}
+	if !visible(targetDir, "research/deep.md") {
+		t.Error("Override() should keep the other commands visible")
+	}
+
+	entries, err := service.List(targetDir, KindCommands)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name == "plan" && (entry.Override != ".strategic-claude-basic/overrides/commands/plan.md" || entry.Description != "My plan") {
+			t.Errorf("List() plan = %+v, want described by its override", entry)
+		}
+	}
+
+	if _, err := service.Override(targetDir, KindCommands, "plan"); !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Errorf("Override() twice error = %v, want code %s", err, models.ErrorCodeValidationFailed)
+	}
+
+	if _, err := service.ResetOverride(targetDir, KindCommands, "plan"); err != nil {
+		t.Fatalf("ResetOverride() error = %v", err)
+	}
+	if _, err := os.Stat(overridePath); !os.IsNotExist(err) {
+		t.Error("ResetOverride() should delete the override")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.OverlayDir, KindCommands)); !os.IsNotExist(err) {
+		t.Error("ResetOverride() should remove the overlay once nothing is overridden")
+	}
+	if !visible(targetDir, "plan.md") {
+		t.Error("ResetOverride() should link plan.md to core again")
+	}
+
+	if _, err := service.ResetOverride(targetDir, KindCommands, "plan"); !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Errorf("ResetOverride() twice error = %v, want code %s", err, models.ErrorCodeValidationFailed)
+	}
+}
+
+func TestService_RefreshOverlays_Overrides(t *testing.T) {
+	targetDir := createLinkedInstallation(t)
+
+	// Overrides placed by hand take effect once the overlays are refreshed, e.g. on a core update
+	writeFile(t, targetDir, ".strategic-claude-basic/overrides/agents/helper.md", "custom")
+	service := New()
+	if err := service.Apply(targetDir); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(targetDir, config.ClaudeDir, config.AgentsDir, "strategic", "helper.md"))
+	if err != nil {
+		t.Fatalf("Failed to read helper.md through the symlink: %v", err)
+	}
+	if string(content) != "custom" {
+		t.Errorf("helper.md through the symlink = %q, want %q", content, "custom")
+	}
+}
@@ -0,0 +1,120 @@
+package ci
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// CI providers a workflow can be generated for
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// Package is the go install path of the CLI
+const Package = "github.com/Fomo-Driven-Development/strategic-claude-basic-cli/cmd/" + config.BinaryName
+
+// Defaults for Options
+const (
+	DefaultCLIVersion = "latest"
+	DefaultGoVersion  = "1.24"
+)
+
+//go:embed templates/*.yml.tmpl
+var templateFS embed.FS
+
+// Options customize a generated workflow
+type Options struct {
+	Provider         string
+	Branches         []string // Branches whose pushes are checked besides pull requests; none checks every push
+	CLIVersion       string   // Version of the CLI to go install
+	GoVersion        string
+	WorkingDirectory string // Project directory within the repository, when not its root
+}
+
+// Service generates CI workflows that check the framework installation
+type Service struct{}
+
+// New creates a new CI service instance
+func New() *Service {
+	return &Service{}
+}
+
+// GetProviders returns the supported CI providers
+func GetProviders() []string {
+	return []string{ProviderGitHub, ProviderGitLab}
+}
+
+// DefaultPath returns where the workflow of a provider is written, relative to the project
+func DefaultPath(provider string) string {
+	if provider == ProviderGitLab {
+		return filepath.Join(".gitlab", "strategic-claude-basic.gitlab-ci.yml")
+	}
+	return filepath.Join(".github", "workflows", "strategic-claude-basic.yml")
+}
+
+// Render returns the workflow for opts. path is where it will be written,
+// relative to the project, and is referenced by workflows that must be included.
+func (s *Service) Render(opts Options, path string) ([]byte, error) {
+	if opts.Provider != ProviderGitHub && opts.Provider != ProviderGitLab {
+		return nil, models.NewValidationError("provider", opts.Provider,
+			fmt.Sprintf("must be one of: %s", strings.Join(GetProviders(), ", ")))
+	}
+	if opts.CLIVersion == "" {
+		opts.CLIVersion = DefaultCLIVersion
+	}
+	if opts.GoVersion == "" {
+		opts.GoVersion = DefaultGoVersion
+	}
+
+	quoted := make([]string, len(opts.Branches))
+	for i, branch := range opts.Branches {
+		quoted[i] = strconv.Quote(branch)
+	}
+
+	tmpl, err := template.New(opts.Provider).Delims("[[", "]]").ParseFS(templateFS, "templates/"+opts.Provider+".yml.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s workflow template: %w", opts.Provider, err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.ExecuteTemplate(&buf, opts.Provider+".yml.tmpl", map[string]any{
+		"Binary":           config.BinaryName,
+		"Package":          Package,
+		"CLIVersion":       opts.CLIVersion,
+		"GoVersion":        opts.GoVersion,
+		"Branches":         opts.Branches,
+		"BranchList":       "[" + strings.Join(quoted, ", ") + "]",
+		"WorkingDirectory": opts.WorkingDirectory,
+		"Path":             filepath.ToSlash(path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s workflow: %w", opts.Provider, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Write writes a rendered workflow to path, refusing to replace an existing
+// file unless force is set
+func (s *Service) Write(path string, content []byte, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return models.NewFileSystemError(models.ErrorCodeFileAlreadyExists, path, nil)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, content, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
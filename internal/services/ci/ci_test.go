@@ -0,0 +1,84 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestService_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "github defaults",
+			opts: Options{Provider: ProviderGitHub},
+			want: []string{
+				"go install " + Package + "@latest",
+				`go-version: "1.24"`,
+				"run: strategic-claude status --quiet\n",
+			},
+		},
+		{
+			name: "github customized",
+			opts: Options{Provider: ProviderGitHub, Branches: []string{"main", "release/*"}, CLIVersion: "v0.2.0", WorkingDirectory: "services/api"},
+			want: []string{
+				`branches: ["main", "release/*"]`,
+				Package + "@v0.2.0",
+				"status --quiet services/api",
+			},
+		},
+		{
+			name: "gitlab",
+			opts: Options{Provider: ProviderGitLab, Branches: []string{"main"}, GoVersion: "1.23"},
+			want: []string{
+				"image: golang:1.23",
+				`$CI_COMMIT_BRANCH == "main"`,
+				"- local: .gitlab/ci.yml",
+			},
+		},
+		{
+			name:    "unknown provider",
+			opts:    Options{Provider: "jenkins"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := New().Render(tt.opts, ".gitlab/ci.yml")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("Render() = %s\nwant it to contain %q", content, want)
+				}
+			}
+		})
+	}
+}
+
+func TestService_Write(t *testing.T) {
+	service := New()
+	path := filepath.Join(t.TempDir(), DefaultPath(ProviderGitHub))
+
+	if err := service.Write(path, []byte("first"), false); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := service.Write(path, []byte("second"), false); !models.IsErrorCode(err, models.ErrorCodeFileAlreadyExists) {
+		t.Errorf("Write() error = %v, want code %s", err, models.ErrorCodeFileAlreadyExists)
+	}
+	if err := service.Write(path, []byte("second"), true); err != nil {
+		t.Fatalf("Write() with force error = %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "second" {
+		t.Errorf("file content = %q, want %q", content, "second")
+	}
+}
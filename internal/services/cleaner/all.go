@@ -0,0 +1,152 @@
+package cleaner
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+)
+
+// SetAll makes RemoveInstallation also remove what installations leave outside
+// their framework directory: the backups, the project config file, the cached
+// status and the entries added to .claude/.gitignore and the ignore files of
+// other tools
+func (s *Service) SetAll(all bool) {
+	s.all = all
+}
+
+// ignoreEntries returns the entries the installation added to .claude/.gitignore
+// and the ignore files of other tools, by ignore file relative to targetDir. It
+// reads the ignore templates in the framework directory, so it has to run before
+// the directory is removed.
+func (s *Service) ignoreEntries(targetDir string) map[string][]string {
+	var claudeEntries []string
+	for link := range config.GetRequiredSymlinks() {
+		claudeEntries = append(claudeEntries, link)
+	}
+
+	entries := map[string][]string{
+		filepath.Join(config.ClaudeDir, ".gitignore"): append(claudeEntries, templateEntries(targetDir, config.ClaudeIgnoreTemplate)...),
+	}
+	for _, ignoreFile := range config.GetToolIgnoreFiles() {
+		if templateLines := templateEntries(targetDir, ignoreFile.Template); len(templateLines) > 0 {
+			entries[ignoreFile.Target] = templateLines
+		}
+	}
+	return entries
+}
+
+// templateEntries returns the entries of an ignore template in the framework directory
+func templateEntries(targetDir, template string) []string {
+	templatePath := filepath.Join(targetDir, config.FrameworkDir(), config.IgnoreTemplatesDir, template)
+	file, err := os.Open(templatePath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+// remainingInstallations returns the framework directories of the other
+// installations in targetDir, which still use the shared files
+func (s *Service) remainingInstallations(targetDir string) []string {
+	var remaining []string
+	if config.Instance() != "" {
+		if projectConfig, err := config.LoadProjectConfig(targetDir); err == nil {
+			if _, err := os.Stat(filepath.Join(targetDir, projectConfig.FrameworkDir, config.TemplateInfoFile)); err == nil {
+				remaining = append(remaining, projectConfig.FrameworkDir)
+			}
+		}
+	}
+	for _, name := range config.FindInstances(targetDir) {
+		if name != config.Instance() {
+			remaining = append(remaining, config.InstanceDir(name))
+		}
+	}
+	return remaining
+}
+
+// removeLeftovers removes the files --all covers once no other installation uses them
+func (s *Service) removeLeftovers(targetDir string, ignoreEntries map[string][]string, result *CleanupResult) {
+	if remaining := s.remainingInstallations(targetDir); len(remaining) > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Kept backups and shared files still used by %s", strings.Join(remaining, ", ")))
+		return
+	}
+
+	for _, ignoreFile := range slices.Sorted(maps.Keys(ignoreEntries)) {
+		ignorePath := filepath.Join(targetDir, ignoreFile)
+		if removed, err := s.filesystemService.RemoveIgnoreEntries(ignorePath, ignoreEntries[ignoreFile]); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during %s cleanup: %v", ignoreFile, err))
+		} else if removed {
+			result.RemovedFiles = append(result.RemovedFiles, ignoreFile)
+		}
+	}
+
+	// Backups kept outside the project may belong to other projects
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	if _, err := os.Lstat(backupsRoot); err == nil {
+		if rel, err := filepath.Rel(targetDir, backupsRoot); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Kept backups in %s, which is outside the project", backupsRoot))
+		} else if err := s.remove(backupsRoot, result); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove backups: %v", err))
+		} else {
+			result.RemovedBackups = true
+		}
+	}
+
+	// Backups written next to the files before backups had their own directory
+	legacyBackups, _ := filepath.Glob(filepath.Join(targetDir, config.CodexDir, config.LegacyCodexConfigBackupPrefix+"*"))
+	rootBackups, _ := filepath.Glob(filepath.Join(targetDir, config.LegacyMCPBackupPrefix+"*"))
+	for _, path := range append(legacyBackups, rootBackups...) {
+		s.removeLeftoverFile(targetDir, path, result)
+	}
+
+	s.removeLeftoverFile(targetDir, config.ProjectConfigPath(targetDir), result)
+
+	if cacheRoot, err := cache.Dir(); err == nil {
+		if err := status.NewCache(filepath.Join(cacheRoot, config.StatusCacheDir), 0).Remove(targetDir); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during status cache cleanup: %v", err))
+		}
+	}
+}
+
+// removeLeftoverFile removes a single file, recording it relative to targetDir
+func (s *Service) removeLeftoverFile(targetDir, path string, result *CleanupResult) {
+	if !pathExists(path) {
+		return
+	}
+	if err := s.remove(path, result); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove %s: %v", path, err))
+		return
+	}
+	if rel, err := filepath.Rel(targetDir, path); err == nil {
+		path = rel
+	}
+	result.RemovedFiles = append(result.RemovedFiles, path)
+}
+
+// HasLeftovers reports whether targetDir has backups or a project config file
+// for clean --all to remove
+func HasLeftovers(targetDir string) bool {
+	for _, path := range []string{config.GetBackupsRoot(targetDir), config.ProjectConfigPath(targetDir)} {
+		if pathExists(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,47 +1,81 @@
 package cleaner
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/codexconfig"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/direnv"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/settings"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/toolconfig"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
 // Service handles cleanup operations for Strategic Claude Basic installations
 type Service struct {
+	ctx                context.Context
 	filesystemService  *filesystem.Service
 	symlinkService     *symlink.Service
 	statusService      *status.Service
 	settingsService    *settings.Service
 	codexConfigService *codexconfig.Service
+	cursorService      *cursor.Service
+	toolConfigService  *toolconfig.Service
+	direnvService      *direnv.Service
+	reporter           reporter.Reporter
+	all                bool
+	strict             bool           // Remaining framework paths fail the cleanup
+	trash              *trash.Service // Removed directories and files go here when set
 }
 
 // New creates a new cleaner service instance
 func New() *Service {
 	return &Service{
+		ctx:                context.Background(),
 		filesystemService:  filesystem.New(),
 		symlinkService:     symlink.New(),
 		statusService:      status.NewService(),
 		settingsService:    settings.New(),
 		codexConfigService: codexconfig.New(),
+		cursorService:      cursor.New(),
+		toolConfigService:  toolconfig.New(),
+		direnvService:      direnv.New(),
+		reporter:           reporter.Default(),
 	}
 }
 
+// SetReporter sets where cleanup progress is reported. Problems are still
+// collected in the CleanupResult for the caller to display.
+func (s *Service) SetReporter(r reporter.Reporter) {
+	s.reporter = r
+	s.codexConfigService.SetReporter(r)
+}
+
 // CleanupResult represents the result of a cleanup operation
 type CleanupResult struct {
 	// What was removed
-	RemovedDirectory    bool     `json:"removed_directory"`
-	RemovedSymlinks     []string `json:"removed_symlinks"`
+	RemovedDirectory     bool     `json:"removed_directory"`
+	RemovedSymlinks      []string `json:"removed_symlinks"`
 	RemovedCodexSymlinks []string `json:"removed_codex_symlinks"`
-	CleanedSettings     bool     `json:"cleaned_settings"`
-	CleanedCodexConfig  bool     `json:"cleaned_codex_config"`
+	CleanedSettings      bool     `json:"cleaned_settings"`
+	RemovedSettingsFile  bool     `json:"removed_settings_file"` // settings.json was empty after cleanup
+	CleanedCodexConfig   bool     `json:"cleaned_codex_config"`
+	RemovedCursorRules   bool     `json:"removed_cursor_rules"`
+	RemovedToolConfigs   []string `json:"removed_tool_configs"` // Aider and OpenCode files the conventions were removed from
+	CleanedEnvrc         bool     `json:"cleaned_envrc"`
+	RemovedBackups       bool     `json:"removed_backups"`
+	RemovedFiles         []string `json:"removed_files"`        // Left-over files removed with --all
+	RemovedUserContent   []string `json:"removed_user_content"` // User documents removed with the framework directory
 
 	// What was preserved
 	PreservedFiles []string `json:"preserved_files"`
@@ -49,6 +83,15 @@ type CleanupResult struct {
 	// Empty directories cleaned up
 	CleanedDirectories []string `json:"cleaned_directories"`
 
+	// Paths moved to the trash instead of being deleted (--trash), by original path
+	Trashed map[string]string `json:"trashed,omitempty"`
+
+	// Framework paths still present after the cleanup
+	Remaining []string `json:"remaining"`
+
+	// Suggested next steps
+	FollowUps []string `json:"follow_ups"`
+
 	// Issues encountered
 	Warnings []string `json:"warnings"`
 	Errors   []string `json:"errors"`
@@ -57,6 +100,19 @@ type CleanupResult struct {
 	Success bool `json:"success"`
 }
 
+// SymlinkPaths returns the removed .claude and .codex symlinks, relative to
+// the target directory. The summary and the report both list these paths.
+func (r *CleanupResult) SymlinkPaths() []string {
+	paths := make([]string, 0, len(r.RemovedSymlinks)+len(r.RemovedCodexSymlinks))
+	for _, link := range r.RemovedSymlinks {
+		paths = append(paths, filepath.Join(config.ClaudeDir, link))
+	}
+	for _, link := range r.RemovedCodexSymlinks {
+		paths = append(paths, filepath.Join(config.CodexDir, link))
+	}
+	return paths
+}
+
 // RemoveInstallation performs a complete cleanup of Strategic Claude Basic installation
 func (s *Service) RemoveInstallation(targetDir string) (*CleanupResult, error) {
 	if targetDir == "" {
@@ -68,13 +124,13 @@ func (s *Service) RemoveInstallation(targetDir string) (*CleanupResult, error) {
 	}
 
 	result := &CleanupResult{
-		RemovedSymlinks:     make([]string, 0),
+		RemovedSymlinks:      make([]string, 0),
 		RemovedCodexSymlinks: make([]string, 0),
-		PreservedFiles:      make([]string, 0),
-		CleanedDirectories:  make([]string, 0),
-		Warnings:            make([]string, 0),
-		Errors:              make([]string, 0),
-		Success:             false,
+		PreservedFiles:       make([]string, 0),
+		CleanedDirectories:   make([]string, 0),
+		Warnings:             make([]string, 0),
+		Errors:               make([]string, 0),
+		Success:              false,
 	}
 
 	// Get current installation status
@@ -85,25 +141,49 @@ func (s *Service) RemoveInstallation(targetDir string) (*CleanupResult, error) {
 	}
 
 	// If nothing is installed, return early with success
-	if !statusInfo.IsInstalled && !statusInfo.StrategicClaudeDir && !statusInfo.ClaudeDir && !statusInfo.CodexDir {
-		result.Success = true
-		result.Warnings = append(result.Warnings, "No Strategic Claude Basic installation found")
+	if !statusInfo.IsInstalled && !statusInfo.StrategicClaudeDir && !statusInfo.ClaudeDir && !statusInfo.CodexDir && statusInfo.CursorRules == nil {
+		if s.all {
+			s.removeLeftovers(targetDir, s.ignoreEntries(targetDir), result)
+		}
+		result.Success = len(result.Errors) == 0
+		if !result.RemovedBackups && len(result.RemovedFiles) == 0 {
+			result.Warnings = append(result.Warnings, "No Strategic Claude Basic installation found")
+		}
 		return result, nil
 	}
 
-	// Step 1: Remove symlinks
-	if err := s.removeSymlinks(targetDir, result); err != nil {
+	// Step 1: Remove symlinks; .codex is left alone when the installation does not manage it
+	s.reporter.Step("Removing symlinks")
+	if err := s.removeSymlinks(targetDir, statusInfo.HasIntegration(models.IntegrationCodex), result); err != nil {
 		result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove symlinks: %v", err))
 		// Continue with cleanup even if symlinks fail
 	}
 
+	var ignoreEntries map[string][]string
+	if s.all {
+		ignoreEntries = s.ignoreEntries(targetDir)
+	}
+	if err := s.stopAtDeadline(targetDir, result); err != nil {
+		return result, err
+	}
+
 	// Step 2: Remove Strategic Claude Basic directory
+	s.reporter.Step("Removing " + config.FrameworkDir())
+	userDocuments := userContent(targetDir)
 	if err := s.removeStrategicDirectory(targetDir, result); err != nil {
 		result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove Strategic Claude directory: %v", err))
 		return result, err
 	}
+	if result.RemovedDirectory {
+		result.RemovedUserContent = userDocuments
+	}
+
+	if err := s.stopAtDeadline(targetDir, result); err != nil {
+		return result, err
+	}
 
 	// Step 3: Clean settings.json (only if we removed other components)
+	s.reporter.Step("Cleaning settings and integrations")
 	if len(result.RemovedSymlinks) > 0 || result.RemovedDirectory {
 		if err := s.cleanSettings(targetDir, result); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during settings cleanup: %v", err))
@@ -111,15 +191,28 @@ func (s *Service) RemoveInstallation(targetDir string) (*CleanupResult, error) {
 		}
 	}
 
-	// Step 3.5: Clean Codex config.toml (only if we removed other components)
-	if len(result.RemovedCodexSymlinks) > 0 || result.RemovedDirectory {
-		if err := s.cleanCodexConfig(targetDir, result); err != nil {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during codex config cleanup: %v", err))
-			// Non-fatal error, continue
-		}
+	// Steps 3.5 to 3.8 clean files shared by the project, which belong to the
+	// default installation; named installations leave them alone
+	if config.Instance() == "" {
+		s.cleanSharedIntegrations(targetDir, statusInfo, result)
+	}
+
+	if err := s.stopAtDeadline(targetDir, result); err != nil {
+		return result, err
+	}
+
+	// Step 3.9: Remove backups and other left-overs (--all)
+	if s.all {
+		s.reporter.Step("Removing backups and left-over files")
+		s.removeLeftovers(targetDir, ignoreEntries, result)
+	}
+
+	if err := s.stopAtDeadline(targetDir, result); err != nil {
+		return result, err
 	}
 
 	// Step 4: Clean up empty directories (but preserve user content)
+	s.reporter.Step("Removing empty directories")
 	if err := s.cleanupEmptyDirectories(targetDir, result); err != nil {
 		result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during directory cleanup: %v", err))
 		// Non-fatal error, continue
@@ -132,12 +225,59 @@ func (s *Service) RemoveInstallation(targetDir string) (*CleanupResult, error) {
 
 	// Determine overall success
 	result.Success = len(result.Errors) == 0
+	result.FollowUps = followUps(targetDir, s.all, result)
 
 	return result, nil
 }
 
+// cleanSharedIntegrations cleans Codex config.toml, Cursor rules, the Aider and
+// OpenCode configurations and .envrc
+func (s *Service) cleanSharedIntegrations(targetDir string, statusInfo *models.StatusInfo, result *CleanupResult) {
+	// Step 3.5: Clean Codex config.toml (only if we removed other components and manage .codex)
+	if statusInfo.HasIntegration(models.IntegrationCodex) && (len(result.RemovedCodexSymlinks) > 0 || result.RemovedDirectory) {
+		if err := s.cleanCodexConfig(targetDir, result); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during codex config cleanup: %v", err))
+			// Non-fatal error, continue
+		}
+	}
+
+	// Step 3.6: Remove Cursor rules
+	removed, err := s.cursorService.RemoveRules(targetDir)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to remove Cursor rules: %v", err))
+	}
+	result.RemovedCursorRules = removed
+
+	// Step 3.7: Remove the framework conventions from the Aider and OpenCode configurations
+	s.cleanToolConfigs(targetDir, result)
+
+	// Step 3.8: Remove the managed block from .envrc
+	if removed, err := s.direnvService.RemoveBlock(targetDir); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during %s cleanup: %v", config.EnvrcFile, err))
+	} else {
+		result.CleanedEnvrc = removed
+	}
+}
+
+// cleanToolConfigs removes the managed block from .aider.conf.yml and the
+// conventions file from the opencode.json instructions. Both are recognized by
+// what the CLI wrote, so this is safe whether or not the integrations were selected.
+func (s *Service) cleanToolConfigs(targetDir string, result *CleanupResult) {
+	if removed, err := s.toolConfigService.RemoveAider(targetDir); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during %s cleanup: %v", config.AiderConfigFile, err))
+	} else if removed {
+		result.RemovedToolConfigs = append(result.RemovedToolConfigs, config.AiderConfigFile)
+	}
+
+	if removed, err := s.toolConfigService.RemoveOpenCode(targetDir); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Warning during %s cleanup: %v", config.OpenCodeConfigFile, err))
+	} else if removed {
+		result.RemovedToolConfigs = append(result.RemovedToolConfigs, config.OpenCodeConfigFile)
+	}
+}
+
 // removeSymlinks removes Strategic Claude Basic symlinks
-func (s *Service) removeSymlinks(targetDir string, result *CleanupResult) error {
+func (s *Service) removeSymlinks(targetDir string, includeCodex bool, result *CleanupResult) error {
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
 	requiredSymlinks := config.GetRequiredSymlinks()
 
@@ -158,7 +298,7 @@ func (s *Service) removeSymlinks(targetDir string, result *CleanupResult) error
 		}
 
 		// Validate it's a Strategic Claude symlink before removing
-		if isStrategicSymlink, err := s.isStrategicClaudeSymlink(fullSymlinkPath); err != nil {
+		if isStrategicSymlink, err := s.isStrategicClaudeSymlink(targetDir, fullSymlinkPath); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Could not validate symlink %s: %v", fullSymlinkPath, err))
 			continue
 		} else if !isStrategicSymlink {
@@ -177,9 +317,13 @@ func (s *Service) removeSymlinks(targetDir string, result *CleanupResult) error
 		}
 
 		result.RemovedSymlinks = append(result.RemovedSymlinks, symlinkPath)
+		s.reporter.Progress(len(result.RemovedSymlinks), len(requiredSymlinks), symlinkPath)
 	}
 
 	// Also remove Codex symlinks
+	if !includeCodex {
+		return nil
+	}
 	if err := s.removeCodexSymlinks(targetDir, result); err != nil {
 		return fmt.Errorf("failed to remove codex symlinks: %w", err)
 	}
@@ -209,7 +353,7 @@ func (s *Service) removeCodexSymlinks(targetDir string, result *CleanupResult) e
 		}
 
 		// Validate it's a Strategic Claude symlink before removing
-		if isStrategicSymlink, err := s.isStrategicClaudeSymlink(fullSymlinkPath); err != nil {
+		if isStrategicSymlink, err := s.isStrategicClaudeSymlink(targetDir, fullSymlinkPath); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Could not validate codex symlink %s: %v", fullSymlinkPath, err))
 			continue
 		} else if !isStrategicSymlink {
@@ -235,13 +379,21 @@ func (s *Service) removeCodexSymlinks(targetDir string, result *CleanupResult) e
 
 // removeStrategicDirectory removes the .strategic-claude-basic directory
 func (s *Service) removeStrategicDirectory(targetDir string, result *CleanupResult) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Check if directory exists
 	if _, err := os.Stat(strategicDir); os.IsNotExist(err) {
 		return nil // Already doesn't exist
 	}
 
+	if s.trash != nil {
+		if err := s.moveToTrash(strategicDir, result); err != nil {
+			return err
+		}
+		result.RemovedDirectory = true
+		return nil
+	}
+
 	// Use filesystem service for safe removal
 	if err := s.filesystemService.RemoveStrategicClaudeBasic(targetDir); err != nil {
 		return err
@@ -260,7 +412,7 @@ func (s *Service) cleanCodexConfig(targetDir string, result *CleanupResult) erro
 	return nil
 }
 
-// cleanupEmptyDirectories removes empty .claude and .codex subdirectories if they contain no user content
+// cleanupEmptyDirectories removes empty .claude, .codex and .cursor subdirectories if they contain no user content
 func (s *Service) cleanupEmptyDirectories(targetDir string, result *CleanupResult) error {
 	// Clean up .claude directory
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
@@ -298,6 +450,19 @@ func (s *Service) cleanupEmptyDirectories(targetDir string, result *CleanupResul
 		}
 	}
 
+	// Clean up .cursor directory
+	cursorDir := filepath.Join(targetDir, config.CursorDir)
+	if _, err := os.Stat(cursorDir); err == nil {
+		if err := s.cleanupEmptySubdirectory(filepath.Join(cursorDir, config.CursorRulesDir), result); err != nil {
+			return err
+		}
+
+		// Check if .cursor directory itself is now empty
+		if err := s.cleanupEmptySubdirectory(cursorDir, result); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -334,23 +499,16 @@ func (s *Service) cleanupEmptySubdirectory(dirPath string, result *CleanupResult
 	return nil
 }
 
-// validateCleanup verifies that the cleanup was successful
+// validateCleanup verifies that the cleanup was successful; remaining framework
+// paths are warnings, or errors in strict mode
 func (s *Service) validateCleanup(targetDir string, result *CleanupResult) error {
-	// Get post-cleanup status
-	statusInfo, err := s.statusService.CheckInstallation(targetDir)
-	if err != nil {
-		return fmt.Errorf("failed to validate cleanup: %w", err)
-	}
-
-	// Check that Strategic Claude directory is gone
-	if statusInfo.StrategicClaudeDir {
-		result.Warnings = append(result.Warnings, "Strategic Claude directory still exists after cleanup")
-	}
-
-	// Check that our symlinks are gone
-	for _, symlink := range statusInfo.Symlinks {
-		if symlink.Valid && symlink.Exists {
-			result.Warnings = append(result.Warnings, fmt.Sprintf("Strategic Claude symlink still exists: %s", symlink.Path))
+	result.Remaining = remainingPaths(targetDir)
+	for _, path := range result.Remaining {
+		message := fmt.Sprintf("Strategic Claude path still exists after cleanup: %s", path)
+		if s.strict {
+			result.Errors = append(result.Errors, message)
+		} else {
+			result.Warnings = append(result.Warnings, message)
 		}
 	}
 
@@ -375,33 +533,53 @@ func (s *Service) cleanSettings(targetDir string, result *CleanupResult) error {
 
 	// Check if settings file was removed entirely
 	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
-		result.PreservedFiles = append(result.PreservedFiles,
-			"settings.json removed (was empty after cleanup)")
-	} else {
-		result.PreservedFiles = append(result.PreservedFiles,
-			"settings.json (cleaned of strategic hooks)")
+		result.RemovedSettingsFile = true
 	}
 
 	return nil
 }
 
 // isStrategicClaudeSymlink checks if a symlink points to a Strategic Claude target
-func (s *Service) isStrategicClaudeSymlink(symlinkPath string) (bool, error) {
+// that resolves inside the framework directory of targetDir
+func (s *Service) isStrategicClaudeSymlink(targetDir, symlinkPath string) (bool, error) {
 	// Read the symlink target
 	target, err := os.Readlink(symlinkPath)
 	if err != nil {
 		return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, symlinkPath, err)
 	}
 
+	// Absolute targets, written with the absolute symlink style, are compared
+	// as the relative target they stand for
+	if filepath.IsAbs(target) {
+		if linkDir, err := filepath.Abs(filepath.Dir(symlinkPath)); err == nil {
+			if relTarget, err := filepath.Rel(linkDir, target); err == nil {
+				target = filepath.ToSlash(relTarget)
+			}
+		}
+	}
+
 	// Check if target contains strategic-claude-basic path components
+	known := false
 	expectedTargets := config.GetRequiredSymlinks()
 	for _, expectedTarget := range expectedTargets {
 		if target == expectedTarget {
-			return true, nil
+			known = true
 		}
 	}
 
-	return false, nil
+	// Links to the overlays of core directories with disabled or overridden items
+	for _, kind := range config.GetOverlayKinds() {
+		if target == config.GetOverlaySymlinkTarget(kind) {
+			known = true
+		}
+	}
+	if !known {
+		return false, nil
+	}
+
+	// A link a template ships in core/ can take a known target elsewhere
+	_, inside := utils.ResolvesWithin(symlinkPath, filepath.Join(targetDir, config.FrameworkDir()))
+	return inside, nil
 }
 
 // HandlePartialInstallation specifically handles cleanup of broken or incomplete installations
@@ -1,11 +1,15 @@
 package cleaner
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
 )
@@ -113,6 +117,221 @@ func TestRemoveInstallation_CompleteInstallation(t *testing.T) {
 	}
 }
 
+func TestRemoveInstallation_All(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv(config.CacheDirEnvVar, t.TempDir())
+	setupCompleteInstallation(t, tmpDir)
+
+	gitignorePath := filepath.Join(tmpDir, config.ClaudeDir, ".gitignore")
+	gitignore := "# Strategic Claude Basic entries\nagents/strategic\ncommands/strategic\nhooks/strategic\nlocal.json\n"
+	if err := os.WriteFile(gitignorePath, []byte(gitignore), 0644); err != nil {
+		t.Fatalf("Failed to write .gitignore: %v", err)
+	}
+	dockerTemplate := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir, config.DockerIgnoreTemplate)
+	if err := os.MkdirAll(filepath.Dir(dockerTemplate), 0755); err != nil {
+		t.Fatalf("Failed to create ignore templates: %v", err)
+	}
+	if err := os.WriteFile(dockerTemplate, []byte(config.StrategicClaudeBasicDir+"/\n"), 0644); err != nil {
+		t.Fatalf("Failed to write ignore template: %v", err)
+	}
+	dockerignorePath := filepath.Join(tmpDir, ".dockerignore")
+	dockerignore := "# Strategic Claude Basic entries\nnode_modules/\n" + config.StrategicClaudeBasicDir + "/\n"
+	if err := os.WriteFile(dockerignorePath, []byte(dockerignore), 0644); err != nil {
+		t.Fatalf("Failed to write .dockerignore: %v", err)
+	}
+	backupsDir := filepath.Join(tmpDir, config.BackupsDir, config.BackupDirPrefix+"20250101-000000")
+	if err := os.MkdirAll(backupsDir, 0755); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	if err := os.WriteFile(config.ProjectConfigPath(tmpDir), []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+
+	service := New()
+	service.SetAll(true)
+	result, err := service.RemoveInstallation(tmpDir)
+	if err != nil {
+		t.Fatalf("RemoveInstallation() error = %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected successful removal, got errors: %v", result.Errors)
+	}
+	if !result.RemovedBackups {
+		t.Error("Expected backups to be removed")
+	}
+
+	for _, path := range []string{filepath.Join(tmpDir, config.BackupsDir), config.ProjectConfigPath(tmpDir)} {
+		if _, err := os.Lstat(path); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", path)
+		}
+	}
+	data, err := os.ReadFile(gitignorePath)
+	if err != nil {
+		t.Fatalf("Failed to read .gitignore: %v", err)
+	}
+	if string(data) != "local.json\n" {
+		t.Errorf(".gitignore = %q, want only the user entry", data)
+	}
+	data, err = os.ReadFile(dockerignorePath)
+	if err != nil {
+		t.Fatalf("Failed to read .dockerignore: %v", err)
+	}
+	if string(data) != "node_modules/\n" {
+		t.Errorf(".dockerignore = %q, want only the user entry", data)
+	}
+}
+
+func TestRemoveInstallation_CursorRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupCompleteInstallation(t, tmpDir)
+
+	rulePath := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, filepath.FromSlash(config.CursorRulesTemplateDir), "workflow.mdc")
+	if err := os.MkdirAll(filepath.Dir(rulePath), 0755); err != nil {
+		t.Fatalf("Failed to create rules directory: %v", err)
+	}
+	if err := os.WriteFile(rulePath, []byte("rule"), 0644); err != nil {
+		t.Fatalf("Failed to write rule: %v", err)
+	}
+	if _, err := cursor.New().InstallRules(tmpDir, models.CursorModeCopy); err != nil {
+		t.Fatalf("Failed to install cursor rules: %v", err)
+	}
+
+	// Rules the user added next to the framework's are kept
+	userRule := filepath.Join(tmpDir, config.CursorDir, config.CursorRulesDir, "team.mdc")
+	if err := os.WriteFile(userRule, []byte("user rule"), 0644); err != nil {
+		t.Fatalf("Failed to write user rule: %v", err)
+	}
+
+	result, err := New().RemoveInstallation(tmpDir)
+	if err != nil {
+		t.Fatalf("RemoveInstallation() error = %v", err)
+	}
+	if !result.RemovedCursorRules {
+		t.Error("Expected Cursor rules to be removed")
+	}
+	if _, err := os.Lstat(cursor.RulesPath(tmpDir)); !os.IsNotExist(err) {
+		t.Errorf("Cursor rules should be removed, err = %v", err)
+	}
+	if _, err := os.Stat(userRule); err != nil {
+		t.Errorf("User rule should be preserved: %v", err)
+	}
+}
+
+func TestRemoveInstallation_CodexNotSelected(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupCompleteInstallation(t, tmpDir)
+
+	templateInfo := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, config.TemplateInfoFile)
+	if err := os.WriteFile(templateInfo, []byte(`{"id":"main","integrations":["claude"]}`), 0644); err != nil {
+		t.Fatalf("Failed to write template info: %v", err)
+	}
+
+	// Without the codex integration, .codex belongs to the user
+	codexConfig := filepath.Join(tmpDir, config.CodexDir, "config.toml")
+	if err := os.MkdirAll(filepath.Dir(codexConfig), 0755); err != nil {
+		t.Fatalf("Failed to create .codex: %v", err)
+	}
+	if err := os.WriteFile(codexConfig, []byte("model = \"o3\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write codex config: %v", err)
+	}
+
+	result, err := New().RemoveInstallation(tmpDir)
+	if err != nil {
+		t.Fatalf("RemoveInstallation() error = %v", err)
+	}
+	if !result.RemovedDirectory {
+		t.Error("Expected the framework directory to be removed")
+	}
+	if len(result.RemovedCodexSymlinks) > 0 {
+		t.Errorf("RemovedCodexSymlinks = %v, want none", result.RemovedCodexSymlinks)
+	}
+	if _, err := os.Stat(codexConfig); err != nil {
+		t.Errorf("Codex config should be preserved: %v", err)
+	}
+}
+
+func TestRemoveInstallation_Strict(t *testing.T) {
+	tests := []struct {
+		name        string
+		strict      bool
+		wantSuccess bool
+	}{
+		{"warns by default", false, true},
+		{"fails when strict", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupCompleteInstallation(t, tmpDir)
+
+			// Without the codex integration, the cleanup leaves .codex alone,
+			// including a framework link found there
+			templateInfo := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, config.TemplateInfoFile)
+			if err := os.WriteFile(templateInfo, []byte(`{"id":"main","integrations":["claude"]}`), 0644); err != nil {
+				t.Fatalf("Failed to write template info: %v", err)
+			}
+			staleLink := filepath.Join(tmpDir, config.CodexDir, config.HooksDir, config.StrategicLinkName())
+			if err := os.MkdirAll(filepath.Dir(staleLink), 0755); err != nil {
+				t.Fatalf("Failed to create .codex: %v", err)
+			}
+			if err := os.Symlink(config.GetCodexRequiredSymlinks()[config.HooksDir+"/"+config.StrategicLinkName()], staleLink); err != nil {
+				t.Fatalf("Failed to create symlink: %v", err)
+			}
+
+			service := New()
+			service.SetStrict(tt.strict)
+			result, err := service.RemoveInstallation(tmpDir)
+			if err != nil {
+				t.Fatalf("RemoveInstallation() error = %v", err)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v (errors: %v)", result.Success, tt.wantSuccess, result.Errors)
+			}
+			want := filepath.ToSlash(filepath.Join(config.CodexDir, config.HooksDir, config.StrategicLinkName()))
+			if len(result.Remaining) != 1 || result.Remaining[0] != want {
+				t.Errorf("Remaining = %v, want [%s]", result.Remaining, want)
+			}
+
+			err = service.ValidateCleanup(tmpDir)
+			if appErr, ok := err.(*models.AppError); !ok || appErr.Code != models.ErrorCodeCleanupIncomplete {
+				t.Errorf("ValidateCleanup() error = %v, want %s", err, models.ErrorCodeCleanupIncomplete)
+			}
+
+			if err := os.Remove(staleLink); err != nil {
+				t.Fatalf("Failed to remove symlink: %v", err)
+			}
+			if err := service.ValidateCleanup(tmpDir); err != nil {
+				t.Errorf("ValidateCleanup() after removing the link error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoveInstallation_Deadline(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupCompleteInstallation(t, tmpDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service := New()
+	service.SetContext(ctx)
+
+	result, err := service.RemoveInstallation(tmpDir)
+	if !models.IsErrorCode(err, models.ErrorCodeOperationTimeout) {
+		t.Fatalf("RemoveInstallation() error = %v, want %s", err, models.ErrorCodeOperationTimeout)
+	}
+	if result.Success || len(result.Errors) == 0 {
+		t.Errorf("result = %+v, want a failure listing the timeout", result)
+	}
+	if len(result.Remaining) == 0 || result.Remaining[0] != config.StrategicClaudeBasicDir {
+		t.Errorf("Remaining = %v, want the framework directory first", result.Remaining)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, config.StrategicClaudeBasicDir)); err != nil {
+		t.Errorf("framework directory removed after the deadline: %v", err)
+	}
+}
+
 func TestRemoveInstallation_WithUserContent(t *testing.T) {
 	// Create temporary directory for testing
 	tmpDir, err := os.MkdirTemp("", "cleaner-test-*")
@@ -219,14 +438,17 @@ func TestIsStrategicClaudeSymlink(t *testing.T) {
 	service := New()
 
 	// Create a Strategic Claude symlink
-	symlinkPath := filepath.Join(tmpDir, "strategic")
+	symlinkPath := filepath.Join(tmpDir, ".claude", "agents", "strategic")
+	if err := os.MkdirAll(filepath.Dir(symlinkPath), 0755); err != nil {
+		t.Fatalf("Failed to create .claude/agents: %v", err)
+	}
 	target := "../../.strategic-claude-basic/core/agents"
 	err = os.Symlink(target, symlinkPath)
 	if err != nil {
 		t.Fatalf("Failed to create test symlink: %v", err)
 	}
 
-	isStrategic, err := service.isStrategicClaudeSymlink(symlinkPath)
+	isStrategic, err := service.isStrategicClaudeSymlink(tmpDir, symlinkPath)
 	if err != nil {
 		t.Errorf("isStrategicClaudeSymlink() error = %v", err)
 	}
@@ -235,6 +457,24 @@ func TestIsStrategicClaudeSymlink(t *testing.T) {
 		t.Error("Expected symlink to be identified as Strategic Claude symlink")
 	}
 
+	// A known target that a link in core/ takes outside the framework directory
+	coreDir := filepath.Join(tmpDir, ".strategic-claude-basic", "core")
+	if err := os.MkdirAll(coreDir, 0755); err != nil {
+		t.Fatalf("Failed to create core: %v", err)
+	}
+	if err := os.Symlink(t.TempDir(), filepath.Join(coreDir, "agents")); err != nil {
+		t.Fatalf("Failed to create escaping link: %v", err)
+	}
+
+	isStrategic, err = service.isStrategicClaudeSymlink(tmpDir, symlinkPath)
+	if err != nil {
+		t.Errorf("isStrategicClaudeSymlink() error = %v", err)
+	}
+
+	if isStrategic {
+		t.Error("Expected symlink resolving outside the framework directory to not be identified as Strategic Claude symlink")
+	}
+
 	// Create a non-Strategic Claude symlink
 	userSymlinkPath := filepath.Join(tmpDir, "user-symlink")
 	userTarget := "../some-other-path"
@@ -243,7 +483,7 @@ func TestIsStrategicClaudeSymlink(t *testing.T) {
 		t.Fatalf("Failed to create test user symlink: %v", err)
 	}
 
-	isStrategic, err = service.isStrategicClaudeSymlink(userSymlinkPath)
+	isStrategic, err = service.isStrategicClaudeSymlink(tmpDir, userSymlinkPath)
 	if err != nil {
 		t.Errorf("isStrategicClaudeSymlink() error = %v", err)
 	}
@@ -325,3 +565,89 @@ func setupBrokenInstallation(t *testing.T, tmpDir string) {
 
 	// Note: We don't create the strategic-claude-basic directory, making the symlink broken
 }
+
+func TestNewReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupInstallationWithUserContent(t, tmpDir)
+	planFile := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, config.PlanDir, "my-plan.md")
+	if err := os.MkdirAll(filepath.Dir(planFile), 0755); err != nil {
+		t.Fatalf("Failed to create plan directory: %v", err)
+	}
+	if err := os.WriteFile(planFile, []byte("plan"), 0644); err != nil {
+		t.Fatalf("Failed to write plan: %v", err)
+	}
+
+	result, err := New().RemoveInstallation(tmpDir)
+	if err != nil {
+		t.Fatalf("RemoveInstallation() error = %v", err)
+	}
+	report := NewReport(tmpDir, result)
+
+	wantRemoved := map[string]string{
+		config.StrategicClaudeBasicDir:                      EntryDirectory,
+		config.StrategicClaudeBasicDir + "/plan/my-plan.md": EntryUserContent,
+		".claude/agents/strategic":                          EntrySymlink,
+	}
+	for _, entry := range report.Removed {
+		if kind, ok := wantRemoved[entry.Path]; ok {
+			if entry.Kind != kind {
+				t.Errorf("removed %s kind = %q, want %q", entry.Path, entry.Kind, kind)
+			}
+			delete(wantRemoved, entry.Path)
+		}
+	}
+	for path := range wantRemoved {
+		t.Errorf("report does not list %s as removed", path)
+	}
+
+	// The clean summary counts the same symlinks as the report
+	var symlinks []string
+	for _, entry := range report.Removed {
+		if entry.Kind == EntrySymlink {
+			symlinks = append(symlinks, entry.Path)
+		}
+	}
+	if want := result.SymlinkPaths(); !slices.Equal(symlinks, want) {
+		t.Errorf("report symlinks = %v, want %v", symlinks, want)
+	}
+
+	if len(report.Preserved) != 1 || report.Preserved[0].Path != ".claude/agents/user-agent.md" {
+		t.Errorf("Preserved = %+v, want only .claude/agents/user-agent.md", report.Preserved)
+	}
+	if len(report.FollowUps) == 0 {
+		t.Error("FollowUps is empty, want a step for the removed user document")
+	}
+}
+
+func TestScanUserContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	planDir := filepath.Join(tmpDir, config.StrategicClaudeBasicDir, config.PlanDir)
+	if err := os.MkdirAll(planDir, 0755); err != nil {
+		t.Fatalf("Failed to create plan directory: %v", err)
+	}
+	files := map[string]string{
+		"a.md":                  "12345",
+		"b.md":                  "123",
+		config.ClaudeConfigFile: "placeholder",
+		".gitkeep":              "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(planDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	summary := ScanUserContent(tmpDir)
+	if summary.Files != 2 || summary.Bytes != 8 {
+		t.Errorf("ScanUserContent() = %+v, want 2 files of 8 bytes", summary)
+	}
+	if len(summary.Dirs) != 1 || summary.Dirs[config.PlanDir] != 2 {
+		t.Errorf("ScanUserContent().Dirs = %v, want 2 documents in %s", summary.Dirs, config.PlanDir)
+	}
+	if summary.Large() {
+		t.Error("Large() = true for two small documents")
+	}
+	if !(UserContentSummary{Files: config.LargeUserContentFiles + 1}).Large() {
+		t.Error("Large() = false above the file limit")
+	}
+}
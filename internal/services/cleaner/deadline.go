@@ -0,0 +1,28 @@
+package cleaner
+
+import (
+	"context"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// SetContext makes RemoveInstallation stop before its next step once ctx is
+// done, e.g. at the deadline set with clean --timeout
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+	s.filesystemService.SetContext(ctx)
+}
+
+// stopAtDeadline ends a cleanup whose deadline has passed, recording the
+// framework paths that remain so running clean again can finish the job
+func (s *Service) stopAtDeadline(targetDir string, result *CleanupResult) error {
+	if s.ctx.Err() == nil {
+		return nil
+	}
+	err := models.NewTimeoutError("cleanup", s.ctx.Err())
+	result.Errors = append(result.Errors, err.Error())
+	result.Remaining = remainingPaths(targetDir)
+	result.FollowUps = append(followUps(targetDir, s.all, result), i18n.T("Run 'clean' again to remove what remains"))
+	return err
+}
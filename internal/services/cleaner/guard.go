@@ -0,0 +1,40 @@
+package cleaner
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+// UserContentSummary counts the user documents clean would remove with the
+// framework directory
+type UserContentSummary struct {
+	Files int
+	Bytes int64
+
+	// Documents in each user directory that has any
+	Dirs map[string]int
+}
+
+// ScanUserContent counts the user documents in the framework directory of targetDir
+func ScanUserContent(targetDir string) UserContentSummary {
+	summary := UserContentSummary{Dirs: make(map[string]int)}
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	walkUserContent(targetDir, func(path string, d fs.DirEntry) {
+		rel, _ := filepath.Rel(strategicDir, path)
+		dir, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		summary.Dirs[dir]++
+		summary.Files++
+		if info, err := d.Info(); err == nil {
+			summary.Bytes += info.Size()
+		}
+	})
+	return summary
+}
+
+// Large reports whether there is more user content than clean removes without asking
+func (s UserContentSummary) Large() bool {
+	return s.Files > config.LargeUserContentFiles || s.Bytes > config.LargeUserContentBytes
+}
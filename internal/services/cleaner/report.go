@@ -0,0 +1,227 @@
+package cleaner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Kinds of report entries
+const (
+	EntryDirectory      = "directory"
+	EntrySymlink        = "symlink"
+	EntryFile           = "file"
+	EntryUserContent    = "user-content"
+	EntrySettings       = "settings"
+	EntryManagedBlock   = "managed-block"
+	EntryEmptyDirectory = "empty-directory"
+)
+
+// Report is the structured account of a cleanup written by clean --report
+type Report struct {
+	TargetDir    string        `json:"target_dir"`
+	FrameworkDir string        `json:"framework_dir"`
+	Instance     string        `json:"instance,omitempty"`
+	Success      bool          `json:"success"`
+	Removed      []ReportEntry `json:"removed"`
+	Preserved    []ReportEntry `json:"preserved"`
+	Remaining    []string      `json:"remaining"`
+	FollowUps    []string      `json:"follow_ups"`
+	Warnings     []string      `json:"warnings"`
+	Errors       []string      `json:"errors"`
+}
+
+// ReportEntry is a path, relative to the target directory, and what it is
+type ReportEntry struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	Note string `json:"note,omitempty"`
+}
+
+// NewReport builds the report of a cleanup of targetDir
+func NewReport(targetDir string, result *CleanupResult) *Report {
+	report := &Report{
+		TargetDir:    targetDir,
+		FrameworkDir: config.FrameworkDir(),
+		Instance:     config.Instance(),
+		Success:      result.Success,
+		Removed:      make([]ReportEntry, 0),
+		Preserved:    make([]ReportEntry, 0),
+		Remaining:    result.Remaining,
+		FollowUps:    result.FollowUps,
+		Warnings:     result.Warnings,
+		Errors:       result.Errors,
+	}
+	if report.FollowUps == nil {
+		report.FollowUps = make([]string, 0)
+	}
+	if report.Remaining == nil {
+		report.Remaining = make([]string, 0)
+	}
+
+	removed := func(path, kind, note string) {
+		absPath := path
+		if !filepath.IsAbs(absPath) {
+			absPath = filepath.Join(targetDir, path)
+		}
+		if dest, ok := result.Trashed[absPath]; ok {
+			note = strings.TrimPrefix(note+"; moved to "+dest, "; ")
+		}
+		report.Removed = append(report.Removed, ReportEntry{Path: relativePath(targetDir, path), Kind: kind, Note: note})
+	}
+
+	if result.RemovedDirectory {
+		removed(config.FrameworkDir(), EntryDirectory, "")
+	}
+	for _, path := range result.RemovedUserContent {
+		removed(path, EntryUserContent, "removed with "+config.FrameworkDir())
+	}
+	for _, path := range result.SymlinkPaths() {
+		removed(path, EntrySymlink, "")
+	}
+	settingsPath := filepath.Join(config.ClaudeDir, config.ClaudeSettingsFile)
+	if result.RemovedSettingsFile {
+		removed(settingsPath, EntryFile, "empty after removing the framework hooks")
+	} else if result.CleanedSettings {
+		removed(settingsPath, EntrySettings, "framework hooks")
+	}
+	if result.CleanedCodexConfig {
+		removed(filepath.Join(config.CodexDir, config.CodexConfigFile), EntrySettings, "framework configuration")
+	}
+	if result.RemovedCursorRules {
+		removed(filepath.Join(config.CursorDir, config.CursorRulesLink), EntryDirectory, "")
+	}
+	for _, file := range result.RemovedToolConfigs {
+		removed(file, EntryManagedBlock, "framework conventions")
+	}
+	if result.CleanedEnvrc {
+		removed(config.EnvrcFile, EntryManagedBlock, "")
+	}
+	if result.RemovedBackups {
+		removed(config.GetBackupsRoot(targetDir), EntryDirectory, "backups")
+	}
+	for _, file := range result.RemovedFiles {
+		removed(file, EntryFile, "")
+	}
+	for _, dir := range result.CleanedDirectories {
+		removed(dir, EntryEmptyDirectory, "")
+	}
+
+	// A preserved directory is left out when preserved files below it are listed
+	preserved := make(map[string]bool, len(result.PreservedFiles))
+	for _, path := range result.PreservedFiles {
+		preserved[relativePath(targetDir, path)] = true
+	}
+	for _, path := range result.PreservedFiles {
+		rel := relativePath(targetDir, path)
+		if hasPreservedChild(rel, preserved) {
+			continue
+		}
+		kind := EntryFile
+		if info, err := os.Lstat(filepath.Join(targetDir, rel)); err == nil {
+			switch {
+			case info.Mode()&os.ModeSymlink != 0:
+				kind = EntrySymlink
+			case info.IsDir():
+				kind = EntryDirectory
+			}
+		}
+		report.Preserved = append(report.Preserved, ReportEntry{Path: rel, Kind: kind})
+	}
+
+	return report
+}
+
+// WriteFile writes the report as indented JSON
+func (r *Report) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cleanup report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// userContent returns the user documents in the framework directory, relative
+// to targetDir; they are removed with the directory
+func userContent(targetDir string) []string {
+	var files []string
+	walkUserContent(targetDir, func(path string, _ fs.DirEntry) {
+		files = append(files, relativePath(targetDir, path))
+	})
+	return files
+}
+
+// walkUserContent calls visit for every user document in the user directories
+// of the framework directory; placeholders such as CLAUDE.md are skipped
+func walkUserContent(targetDir string, visit func(path string, d fs.DirEntry)) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	for _, dir := range config.GetUserPreservedDirectories() {
+		_ = filepath.WalkDir(filepath.Join(strategicDir, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			name := d.Name()
+			if d.Type().IsRegular() && name != config.ClaudeConfigFile && !strings.HasPrefix(name, ".") {
+				visit(path, d)
+			}
+			return nil
+		})
+	}
+}
+
+// followUps suggests what to do after the cleanup
+func followUps(targetDir string, all bool, result *CleanupResult) []string {
+	var steps []string
+	if n := len(result.RemovedUserContent); n > 0 {
+		steps = append(steps, i18n.T("%d user document(s) were removed with %s; if you exported them with 'export-user-content', restore them after reinstalling with 'import-user-content'", n, config.FrameworkDir()))
+	}
+	if result.CleanedSettings && !result.RemovedSettingsFile {
+		steps = append(steps, i18n.T("Review %s, which keeps your own settings", filepath.Join(config.ClaudeDir, config.ClaudeSettingsFile)))
+	}
+	if len(result.PreservedFiles) > 0 {
+		steps = append(steps, i18n.T("Review the preserved files; they were not created by Strategic Claude Basic"))
+	}
+	if !all {
+		if backupsRoot := config.GetBackupsRoot(targetDir); pathExists(backupsRoot) {
+			steps = append(steps, i18n.T("Backups are kept in %s; run 'clean --all' to remove them", relativePath(targetDir, backupsRoot)))
+		}
+	}
+	return steps
+}
+
+// relativePath returns path relative to targetDir when it is inside it
+func relativePath(targetDir, path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(targetDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// hasPreservedChild reports whether a path below dir is in preserved
+func hasPreservedChild(dir string, preserved map[string]bool) bool {
+	for path := range preserved {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func pathExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
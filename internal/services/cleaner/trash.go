@@ -0,0 +1,34 @@
+package cleaner
+
+import (
+	"os"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/trash"
+)
+
+// SetTrash makes the cleanup move the framework directory, backups and
+// left-over files to t instead of deleting them; nil deletes them
+func (s *Service) SetTrash(t *trash.Service) {
+	s.trash = t
+}
+
+// remove deletes path, or moves it to the trash when one is set
+func (s *Service) remove(path string, result *CleanupResult) error {
+	if s.trash != nil {
+		return s.moveToTrash(path, result)
+	}
+	return os.RemoveAll(path)
+}
+
+// moveToTrash moves path to the trash and records where it went
+func (s *Service) moveToTrash(path string, result *CleanupResult) error {
+	dest, err := s.trash.Move(path)
+	if err != nil {
+		return err
+	}
+	if result.Trashed == nil {
+		result.Trashed = make(map[string]string)
+	}
+	result.Trashed[path] = dest
+	return nil
+}
@@ -0,0 +1,75 @@
+package cleaner
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
+)
+
+// SetStrict makes RemoveInstallation fail when framework directories or
+// symlinks remain after the cleanup, instead of warning about them
+func (s *Service) SetStrict(strict bool) {
+	s.strict = strict
+}
+
+// ValidateCleanup verifies that no framework directory or symlink remains in
+// targetDir, the counterpart of the installer's ValidateInstallation
+func (s *Service) ValidateCleanup(targetDir string) error {
+	remaining := remainingPaths(targetDir)
+	if len(remaining) == 0 {
+		return nil
+	}
+	return models.NewAppError(
+		models.ErrorCodeCleanupIncomplete,
+		fmt.Sprintf("Cleanup incomplete: %s still exist", strings.Join(remaining, ", ")),
+		nil,
+	)
+}
+
+// remainingPaths returns the framework paths left in targetDir, relative to it:
+// the framework directory, symlinks into it and, for the default installation,
+// the Cursor rules
+func remainingPaths(targetDir string) []string {
+	var remaining []string
+	if pathExists(filepath.Join(targetDir, config.FrameworkDir())) {
+		remaining = append(remaining, config.FrameworkDir())
+	}
+
+	links := map[string]map[string]string{
+		config.ClaudeDir: config.GetRequiredSymlinks(),
+		config.CodexDir:  config.GetCodexRequiredSymlinks(),
+	}
+	for _, dir := range []string{config.ClaudeDir, config.CodexDir} {
+		for _, link := range slices.Sorted(maps.Keys(links[dir])) {
+			path := filepath.Join(targetDir, dir, link)
+			if target, err := os.Readlink(path); err == nil && linksIntoFramework(target) {
+				remaining = append(remaining, relativePath(targetDir, path))
+			}
+		}
+	}
+
+	if config.Instance() == "" {
+		if rulesPath := cursor.RulesPath(targetDir); pathExists(rulesPath) {
+			remaining = append(remaining, relativePath(targetDir, rulesPath))
+		}
+	}
+
+	return remaining
+}
+
+// linksIntoFramework reports whether a symlink target goes through the framework directory
+func linksIntoFramework(target string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(target), "/") {
+		if part == config.FrameworkDir() {
+			return true
+		}
+	}
+	return false
+}
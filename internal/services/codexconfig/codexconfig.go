@@ -4,23 +4,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
 )
 
 // Service provides Codex configuration management functionality
-type Service struct{}
+type Service struct {
+	reporter reporter.Reporter
+}
 
 // New creates a new codex config service instance
 func New() *Service {
-	return &Service{}
+	return &Service{reporter: reporter.Default()}
+}
+
+// SetReporter sets where warnings are reported
+func (s *Service) SetReporter(r reporter.Reporter) {
+	s.reporter = r
 }
 
 // ProcessCodexConfig is the main entry point for managing .codex/config.toml
 func (s *Service) ProcessCodexConfig(targetDir string) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 	codexDir := filepath.Join(targetDir, config.CodexDir)
 	configPath := filepath.Join(codexDir, config.CodexConfigFile)
 	templatePath := filepath.Join(strategicDir, config.CodexConfigTemplateFile)
@@ -39,7 +46,7 @@ func (s *Service) ProcessCodexConfig(targetDir string) error {
 	// Handle existing config
 	if _, err := os.Stat(configPath); err == nil {
 		// Backup existing config
-		if err := s.backupExistingConfig(configPath); err != nil {
+		if err := s.backupExistingConfig(targetDir, configPath); err != nil {
 			return fmt.Errorf("failed to backup existing config: %w", err)
 		}
 	}
@@ -52,13 +59,15 @@ func (s *Service) ProcessCodexConfig(targetDir string) error {
 	return nil
 }
 
-// backupExistingConfig creates a timestamped backup of existing config.toml
-func (s *Service) backupExistingConfig(configPath string) error {
-	timestamp := time.Now().Format("20060102-150405")
-	backupPath := filepath.Join(
-		filepath.Dir(configPath),
-		config.CodexConfigBackupPrefix+timestamp+".toml",
-	)
+// backupExistingConfig creates a timestamped backup of existing config.toml in the
+// project's backups directory
+func (s *Service) backupExistingConfig(targetDir, configPath string) error {
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	if err := os.MkdirAll(backupsRoot, config.DirPermissions); err != nil {
+		return err
+	}
+
+	backupPath := filepath.Join(backupsRoot, config.UniqueBackupName(backupsRoot, config.CodexConfigBackupPrefix, ".toml"))
 
 	// Read existing file
 	data, err := os.ReadFile(configPath)
@@ -94,17 +103,23 @@ func (s *Service) RemoveCodexConfig(targetDir string) error {
 		}
 	}
 
-	// Remove backup files
-	pattern := filepath.Join(codexDir, config.CodexConfigBackupPrefix+"*.toml")
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return err
+	// Remove backup files, including any left in .codex by older versions
+	var matches []string
+	for _, pattern := range []string{
+		filepath.Join(config.GetBackupsRoot(targetDir), config.CodexConfigBackupPrefix+"*.toml"),
+		filepath.Join(codexDir, config.LegacyCodexConfigBackupPrefix+"*.toml"),
+	} {
+		found, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		matches = append(matches, found...)
 	}
 
 	for _, backupFile := range matches {
 		if err := os.Remove(backupFile); err != nil {
 			// Log warning but continue
-			fmt.Printf("Warning: Failed to remove backup file %s: %v\n", backupFile, err)
+			s.reporter.Warn(fmt.Sprintf("Failed to remove backup file %s: %v", backupFile, err))
 		}
 	}
 
@@ -0,0 +1,190 @@
+package cursor
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+)
+
+// Service installs the Cursor rules a template ships into .cursor/rules/strategic
+type Service struct {
+	filesystemService *filesystem.Service
+}
+
+// New creates a new cursor service instance
+func New() *Service {
+	return &Service{
+		filesystemService: filesystem.New(),
+	}
+}
+
+// HasRules reports whether the installed framework ships Cursor rules
+func (s *Service) HasRules(targetDir string) bool {
+	info, err := os.Stat(rulesSourceDir(targetDir))
+	return err == nil && info.IsDir()
+}
+
+// InstallRules links .cursor/rules/strategic to the framework's Cursor rules, or
+// copies them in CursorModeCopy. Previously installed rules are replaced.
+// Returns false without changes when the template has no Cursor rules.
+func (s *Service) InstallRules(targetDir, mode string) (bool, error) {
+	if err := models.ValidateCursorMode(mode); err != nil {
+		return false, err
+	}
+	if !s.HasRules(targetDir) {
+		return false, nil
+	}
+
+	rulesPath := RulesPath(targetDir)
+	if err := os.MkdirAll(filepath.Dir(rulesPath), config.DirPermissions); err != nil {
+		return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(rulesPath), err)
+	}
+	if _, err := s.RemoveRules(targetDir); err != nil {
+		return false, err
+	}
+
+	if mode == models.CursorModeCopy {
+		if err := s.filesystemService.CopyDirectory(rulesSourceDir(targetDir), rulesPath); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if err := os.Symlink(config.GetCursorRulesSymlinkTarget(), rulesPath); err != nil {
+		if os.IsPermission(err) {
+			return false, models.NewFileSystemError(models.ErrorCodePermissionDenied, rulesPath, err)
+		}
+		return false, models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, rulesPath, err)
+	}
+	return true, nil
+}
+
+// RemoveRules removes .cursor/rules/strategic, whether linked or copied. The
+// directory belongs to the framework, so copies are removed with their content.
+// Returns false when no rules were installed.
+func (s *Service) RemoveRules(targetDir string) (bool, error) {
+	rulesPath := RulesPath(targetDir)
+	if _, err := os.Lstat(rulesPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	// RemoveAll removes a symlink itself, not its target
+	if err := os.RemoveAll(rulesPath); err != nil {
+		if os.IsPermission(err) {
+			return false, models.NewFileSystemError(models.ErrorCodePermissionDenied, rulesPath, err)
+		}
+		return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, rulesPath, err)
+	}
+	return true, nil
+}
+
+// CheckRules describes the installed Cursor rules, or returns nil when
+// .cursor/rules/strategic does not exist. Links must point to the framework's
+// rules; copies must contain every framework rule with the same content.
+func (s *Service) CheckRules(targetDir string) (*models.CursorRulesStatus, error) {
+	rulesPath := RulesPath(targetDir)
+	info, err := os.Lstat(rulesPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, rulesPath, err)
+	}
+
+	status := &models.CursorRulesStatus{Path: rulesPath, Mode: models.CursorModeCopy}
+	if info.Mode()&os.ModeSymlink != 0 {
+		status.Mode = models.CursorModeSymlink
+		target, err := os.Readlink(rulesPath)
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, rulesPath, err)
+		}
+		if target != config.GetCursorRulesSymlinkTarget() {
+			status.Issues = append(status.Issues, fmt.Sprintf("links to %s instead of the framework's Cursor rules", target))
+			return status, nil
+		}
+		if !s.HasRules(targetDir) {
+			status.Issues = append(status.Issues, fmt.Sprintf("the framework has no Cursor rules in %s", config.CursorRulesTemplateDir))
+			return status, nil
+		}
+	} else if !info.IsDir() {
+		status.Issues = append(status.Issues, "exists but is not a directory or symlink")
+		return status, nil
+	}
+
+	installed, err := listRules(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	status.Rules = len(installed)
+
+	if status.Mode == models.CursorModeCopy && s.HasRules(targetDir) {
+		expected, err := listRules(rulesSourceDir(targetDir))
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range expected {
+			copied, err := os.ReadFile(filepath.Join(rulesPath, rule))
+			if err != nil {
+				status.Issues = append(status.Issues, fmt.Sprintf("rule %s is missing", filepath.ToSlash(rule)))
+				continue
+			}
+			original, err := os.ReadFile(filepath.Join(rulesSourceDir(targetDir), rule))
+			if err != nil {
+				return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, rule, err)
+			}
+			if !bytes.Equal(copied, original) {
+				status.Issues = append(status.Issues, fmt.Sprintf("rule %s differs from the framework's copy", filepath.ToSlash(rule)))
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// RulesPath returns the path of the installed Cursor rules in a project
+func RulesPath(targetDir string) string {
+	return filepath.Join(targetDir, config.CursorDir, filepath.FromSlash(config.CursorRulesLink))
+}
+
+// rulesSourceDir returns the framework directory holding the template's Cursor rules
+func rulesSourceDir(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), filepath.FromSlash(config.CursorRulesTemplateDir))
+}
+
+// listRules returns the files below dir relative to it, sorted. A symlinked
+// dir is followed.
+func listRules(dir string) ([]string, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+	}
+
+	var rules []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, relPath)
+		return nil
+	})
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+	}
+
+	sort.Strings(rules)
+	return rules, nil
+}
@@ -0,0 +1,143 @@
+package cursor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// writeTemplateRules creates framework Cursor rules in targetDir
+func writeTemplateRules(t *testing.T, targetDir string) string {
+	t.Helper()
+
+	rulesDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(config.CursorRulesTemplateDir))
+	files := map[string]string{
+		"workflow.mdc":          "---\ndescription: Framework workflow\n---\n",
+		"research/research.mdc": "---\ndescription: Research\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rulesDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create rules directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write rule %s: %v", name, err)
+		}
+	}
+	return rulesDir
+}
+
+func TestService_InstallRules(t *testing.T) {
+	for _, mode := range []string{models.CursorModeSymlink, models.CursorModeCopy} {
+		t.Run(mode, func(t *testing.T) {
+			service := New()
+			targetDir := t.TempDir()
+			writeTemplateRules(t, targetDir)
+
+			installed, err := service.InstallRules(targetDir, mode)
+			if err != nil {
+				t.Fatalf("InstallRules() error = %v", err)
+			}
+			if !installed {
+				t.Fatal("InstallRules() = false, want true")
+			}
+
+			// Installing again replaces the previous rules
+			if _, err := service.InstallRules(targetDir, mode); err != nil {
+				t.Fatalf("InstallRules() second run error = %v", err)
+			}
+
+			status, err := service.CheckRules(targetDir)
+			if err != nil {
+				t.Fatalf("CheckRules() error = %v", err)
+			}
+			if status == nil {
+				t.Fatal("CheckRules() = nil, want installed rules")
+			}
+			if status.Mode != mode {
+				t.Errorf("CheckRules() mode = %v, want %v", status.Mode, mode)
+			}
+			if status.Rules != 2 {
+				t.Errorf("CheckRules() rules = %d, want 2", status.Rules)
+			}
+			if !status.Valid() {
+				t.Errorf("CheckRules() issues = %v, want none", status.Issues)
+			}
+
+			removed, err := service.RemoveRules(targetDir)
+			if err != nil {
+				t.Fatalf("RemoveRules() error = %v", err)
+			}
+			if !removed {
+				t.Error("RemoveRules() = false, want true")
+			}
+			if _, err := os.Lstat(RulesPath(targetDir)); !os.IsNotExist(err) {
+				t.Errorf("rules still exist after RemoveRules(), err = %v", err)
+			}
+			if !service.HasRules(targetDir) {
+				t.Error("RemoveRules() removed the framework's rules")
+			}
+		})
+	}
+}
+
+func TestService_InstallRules_NoTemplateRules(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+
+	installed, err := service.InstallRules(targetDir, models.CursorModeSymlink)
+	if err != nil {
+		t.Fatalf("InstallRules() error = %v", err)
+	}
+	if installed {
+		t.Error("InstallRules() = true, want false without template rules")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.CursorDir)); !os.IsNotExist(err) {
+		t.Errorf(".cursor should not be created, err = %v", err)
+	}
+}
+
+func TestService_CheckRules_OutdatedCopy(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+	rulesDir := writeTemplateRules(t, targetDir)
+
+	if _, err := service.InstallRules(targetDir, models.CursorModeCopy); err != nil {
+		t.Fatalf("InstallRules() error = %v", err)
+	}
+
+	// The framework changes after the rules were copied
+	if err := os.WriteFile(filepath.Join(rulesDir, "workflow.mdc"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("Failed to update rule: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "new.mdc"), []byte("new"), 0644); err != nil {
+		t.Fatalf("Failed to add rule: %v", err)
+	}
+
+	status, err := service.CheckRules(targetDir)
+	if err != nil {
+		t.Fatalf("CheckRules() error = %v", err)
+	}
+	want := []string{"rule new.mdc is missing", "rule workflow.mdc differs from the framework's copy"}
+	if len(status.Issues) != len(want) {
+		t.Fatalf("CheckRules() issues = %v, want %v", status.Issues, want)
+	}
+	for i := range want {
+		if status.Issues[i] != want[i] {
+			t.Errorf("CheckRules() issue %d = %q, want %q", i, status.Issues[i], want[i])
+		}
+	}
+}
+
+func TestService_CheckRules_NotInstalled(t *testing.T) {
+	status, err := New().CheckRules(t.TempDir())
+	if err != nil {
+		t.Fatalf("CheckRules() error = %v", err)
+	}
+	if status != nil {
+		t.Errorf("CheckRules() = %+v, want nil", status)
+	}
+}
@@ -0,0 +1,235 @@
+package devcontainer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// DefaultCommand installs the framework on first start and updates the core on rebuilds
+const DefaultCommand = config.BinaryName + " init --force-core --yes"
+
+// commandName keys the framework command in an object-form postCreateCommand
+const commandName = "strategic-claude-basic"
+
+// projectCommandName keys the project's own command when a string or array
+// postCreateCommand is turned into the object form
+const projectCommandName = "project"
+
+// Result describes the changes made to devcontainer.json
+type Result struct {
+	Path       string   // devcontainer.json
+	Created    bool     // The file did not exist
+	Changes    []string // What was added or updated, empty when already configured
+	BackupPath string   // Copy of the previous file, when it was changed
+}
+
+// Service adds the framework to a project's dev container configuration
+type Service struct{}
+
+// New creates a new devcontainer service instance
+func New() *Service {
+	return &Service{}
+}
+
+// Path returns the path of devcontainer.json in a project
+func Path(targetDir string) string {
+	return filepath.Join(targetDir, config.DevcontainerDir, config.DevcontainerFile)
+}
+
+// Apply merges the framework setup into .devcontainer/devcontainer.json:
+// command runs as a postCreateCommand, and a volume keeps the clone cache across
+// rebuilds. Everything else in the file is kept; comments are not, so the
+// previous file is backed up like settings.json is.
+func (s *Service) Apply(targetDir, command string) (*Result, error) {
+	if strings.TrimSpace(command) == "" {
+		command = DefaultCommand
+	}
+
+	result := &Result{Path: Path(targetDir)}
+	original, err := os.ReadFile(result.Path)
+	if os.IsNotExist(err) {
+		result.Created = true
+	} else if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, result.Path, err)
+	}
+
+	devcontainer := map[string]json.RawMessage{}
+	if !result.Created {
+		if err := json.Unmarshal(utils.StripJSONComments(original), &devcontainer); err != nil {
+			return nil, models.NewAppError(
+				models.ErrorCodeValidationFailed,
+				fmt.Sprintf("failed to parse %s", result.Path),
+				err,
+			)
+		}
+	} else {
+		devcontainer["name"] = mustMarshal(filepath.Base(targetDir))
+		devcontainer["image"] = mustMarshal("mcr.microsoft.com/devcontainers/base:ubuntu")
+	}
+
+	for _, merge := range []func(map[string]json.RawMessage, string) (string, error){
+		mergePostCreateCommand,
+		mergeMounts,
+		mergeContainerEnv,
+	} {
+		change, err := merge(devcontainer, command)
+		if err != nil {
+			return nil, models.NewAppError(
+				models.ErrorCodeValidationFailed,
+				fmt.Sprintf("failed to update %s", result.Path),
+				err,
+			)
+		}
+		if change != "" {
+			result.Changes = append(result.Changes, change)
+		}
+	}
+
+	if len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	data, err := json.MarshalIndent(devcontainer, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", config.DevcontainerFile, err)
+	}
+	data = append(data, '\n')
+
+	if !result.Created {
+		backupPath, err := s.backupExisting(targetDir, original)
+		if err != nil {
+			return nil, fmt.Errorf("failed to backup existing %s: %w", config.DevcontainerFile, err)
+		}
+		result.BackupPath = backupPath
+	}
+
+	if err := os.MkdirAll(filepath.Dir(result.Path), config.DirPermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(result.Path), err)
+	}
+	if err := utils.WriteFileAtomic(result.Path, data, config.FilePermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, result.Path, err)
+	}
+	return result, nil
+}
+
+// mergePostCreateCommand runs command after the container is created. A
+// project command is kept by switching to the object form, whose commands
+// run in parallel.
+func mergePostCreateCommand(devcontainer map[string]json.RawMessage, command string) (string, error) {
+	existing, found := devcontainer["postCreateCommand"]
+	commands := map[string]json.RawMessage{}
+
+	if found {
+		trimmed := bytes.TrimSpace(existing)
+		switch {
+		case len(trimmed) > 0 && trimmed[0] == '{':
+			if err := json.Unmarshal(trimmed, &commands); err != nil {
+				return "", fmt.Errorf("postCreateCommand: %w", err)
+			}
+		case bytes.Equal(trimmed, mustMarshal(command)):
+			return "", nil
+		case !bytes.Equal(trimmed, []byte("null")):
+			commands[projectCommandName] = existing
+		}
+	}
+
+	if current, ok := commands[commandName]; ok && bytes.Equal(bytes.TrimSpace(current), mustMarshal(command)) {
+		return "", nil
+	}
+	commands[commandName] = mustMarshal(command)
+
+	if len(commands) == 1 {
+		devcontainer["postCreateCommand"] = mustMarshal(command)
+	} else {
+		devcontainer["postCreateCommand"] = mustMarshal(commands)
+	}
+	return fmt.Sprintf("postCreateCommand runs %q", command), nil
+}
+
+// mergeMounts mounts the cache volume unless something is already mounted there
+func mergeMounts(devcontainer map[string]json.RawMessage, _ string) (string, error) {
+	var mounts []json.RawMessage
+	if existing, found := devcontainer["mounts"]; found {
+		if err := json.Unmarshal(existing, &mounts); err != nil {
+			return "", fmt.Errorf("mounts: %w", err)
+		}
+	}
+
+	for _, mount := range mounts {
+		if mountTarget(mount) == config.DevcontainerCacheMount {
+			return "", nil
+		}
+	}
+
+	mount := fmt.Sprintf("source=%s,target=%s,type=volume", config.DevcontainerCacheVolume, config.DevcontainerCacheMount)
+	mounts = append(mounts, mustMarshal(mount))
+	devcontainer["mounts"] = mustMarshal(mounts)
+	return fmt.Sprintf("volume %s is mounted at %s", config.DevcontainerCacheVolume, config.DevcontainerCacheMount), nil
+}
+
+// mountTarget returns the target of a mount given as a string or an object
+func mountTarget(mount json.RawMessage) string {
+	var spec string
+	if err := json.Unmarshal(mount, &spec); err == nil {
+		for _, option := range strings.Split(spec, ",") {
+			key, value, _ := strings.Cut(option, "=")
+			if key == "target" || key == "destination" || key == "dst" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	var object struct {
+		Target string `json:"target"`
+	}
+	if err := json.Unmarshal(mount, &object); err == nil {
+		return object.Target
+	}
+	return ""
+}
+
+// mergeContainerEnv points the clone cache at the mounted volume
+func mergeContainerEnv(devcontainer map[string]json.RawMessage, _ string) (string, error) {
+	env := map[string]json.RawMessage{}
+	if existing, found := devcontainer["containerEnv"]; found {
+		if err := json.Unmarshal(existing, &env); err != nil {
+			return "", fmt.Errorf("containerEnv: %w", err)
+		}
+	}
+
+	if _, found := env[config.CacheDirEnvVar]; found {
+		return "", nil
+	}
+	env[config.CacheDirEnvVar] = mustMarshal(config.DevcontainerCacheMount)
+	devcontainer["containerEnv"] = mustMarshal(env)
+	return fmt.Sprintf("containerEnv sets %s", config.CacheDirEnvVar), nil
+}
+
+// backupExisting writes the previous devcontainer.json to the project's backups directory
+func (s *Service) backupExisting(targetDir string, data []byte) (string, error) {
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	if err := os.MkdirAll(backupsRoot, config.DirPermissions); err != nil {
+		return "", err
+	}
+
+	backupPath := filepath.Join(backupsRoot, config.UniqueBackupName(backupsRoot, config.DevcontainerBackupPrefix, ".json"))
+	return backupPath, os.WriteFile(backupPath, data, config.FilePermissions)
+}
+
+// mustMarshal encodes values that always marshal: strings, maps and slices of raw JSON
+func mustMarshal(value any) json.RawMessage {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,117 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+func TestService_Apply_NewFile(t *testing.T) {
+	targetDir := t.TempDir()
+
+	result, err := New().Apply(targetDir, "")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !result.Created || len(result.Changes) != 3 || result.BackupPath != "" {
+		t.Errorf("Apply() = %+v, want a created file with 3 changes and no backup", result)
+	}
+
+	devcontainer := readDevcontainer(t, targetDir)
+	if devcontainer["postCreateCommand"] != DefaultCommand {
+		t.Errorf("postCreateCommand = %v, want %q", devcontainer["postCreateCommand"], DefaultCommand)
+	}
+	env := devcontainer["containerEnv"].(map[string]any)
+	if env[config.CacheDirEnvVar] != config.DevcontainerCacheMount {
+		t.Errorf("containerEnv = %v, want %s=%s", env, config.CacheDirEnvVar, config.DevcontainerCacheMount)
+	}
+
+	// Applying again changes nothing
+	result, err = New().Apply(targetDir, "")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(result.Changes) != 0 {
+		t.Errorf("second Apply() changes = %v, want none", result.Changes)
+	}
+}
+
+func TestService_Apply_ExistingFile(t *testing.T) {
+	targetDir := t.TempDir()
+	path := Path(targetDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create .devcontainer: %v", err)
+	}
+	existing := `{
+  // Project container
+  "image": "mcr.microsoft.com/devcontainers/go:1",
+  "postCreateCommand": "go mod download",
+  "mounts": [
+    {"source": "cache", "target": "/var/cache/strategic-claude-basic", "type": "volume"},
+  ],
+  "containerEnv": {"GOFLAGS": "-mod=mod"},
+}`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write devcontainer.json: %v", err)
+	}
+
+	result, err := New().Apply(targetDir, "scb-install")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if result.Created || result.BackupPath == "" {
+		t.Errorf("Apply() = %+v, want an updated file with a backup", result)
+	}
+	if backup, err := os.ReadFile(result.BackupPath); err != nil || string(backup) != existing {
+		t.Errorf("backup = %q, %v, want the previous file", backup, err)
+	}
+
+	devcontainer := readDevcontainer(t, targetDir)
+	if devcontainer["image"] != "mcr.microsoft.com/devcontainers/go:1" {
+		t.Errorf("image = %v, want it kept", devcontainer["image"])
+	}
+	commands, ok := devcontainer["postCreateCommand"].(map[string]any)
+	if !ok || commands[projectCommandName] != "go mod download" || commands[commandName] != "scb-install" {
+		t.Errorf("postCreateCommand = %v, want the project and framework commands", devcontainer["postCreateCommand"])
+	}
+	// The existing mount at the cache path is kept instead of adding another
+	if mounts := devcontainer["mounts"].([]any); len(mounts) != 1 {
+		t.Errorf("mounts = %v, want the existing mount only", mounts)
+	}
+	env := devcontainer["containerEnv"].(map[string]any)
+	if env["GOFLAGS"] != "-mod=mod" || env[config.CacheDirEnvVar] != config.DevcontainerCacheMount {
+		t.Errorf("containerEnv = %v, want GOFLAGS kept and %s added", env, config.CacheDirEnvVar)
+	}
+}
+
+func TestService_Apply_InvalidFile(t *testing.T) {
+	targetDir := t.TempDir()
+	path := Path(targetDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create .devcontainer: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"image": `), 0644); err != nil {
+		t.Fatalf("Failed to write devcontainer.json: %v", err)
+	}
+
+	if _, err := New().Apply(targetDir, ""); err == nil {
+		t.Error("Apply() error = nil, want a parse error")
+	}
+}
+
+func readDevcontainer(t *testing.T, targetDir string) map[string]any {
+	t.Helper()
+
+	data, err := os.ReadFile(Path(targetDir))
+	if err != nil {
+		t.Fatalf("Failed to read devcontainer.json: %v", err)
+	}
+	var devcontainer map[string]any
+	if err := json.Unmarshal(data, &devcontainer); err != nil {
+		t.Fatalf("Failed to parse devcontainer.json: %v", err)
+	}
+	return devcontainer
+}
@@ -0,0 +1,57 @@
+package direnv
+
+import (
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+)
+
+// managedBlock marks the lines managed in .envrc
+var managedBlock = filesystem.HashCommentBlock
+
+// Service manages the block init --direnv writes to .envrc, which exports the
+// environment hooks expect outside Claude Code and puts the project tools on PATH
+type Service struct {
+	filesystemService *filesystem.Service
+}
+
+// New creates a new direnv service instance
+func New() *Service {
+	return &Service{filesystemService: filesystem.New()}
+}
+
+// Block returns the managed block. direnv evaluates .envrc in its directory,
+// so $PWD and relative PATH_add entries resolve to the project.
+func Block() string {
+	return managedBlock.Render(blockBody())
+}
+
+// blockBody returns the lines between the markers of the managed block
+func blockBody() string {
+	return "export CLAUDE_PROJECT_DIR=\"$PWD\"\n" +
+		"PATH_add " + config.FrameworkDir() + "/" + config.ToolsDir + "\n"
+}
+
+// EnvrcPath returns the path of .envrc in a project
+func EnvrcPath(targetDir string) string {
+	return filepath.Join(targetDir, config.EnvrcFile)
+}
+
+// InstallBlock writes the managed block to .envrc, replacing a previous one and
+// keeping everything else. Returns false when the block was already current.
+func (s *Service) InstallBlock(targetDir string) (bool, error) {
+	return s.filesystemService.ApplyManagedBlock(EnvrcPath(targetDir), managedBlock, blockBody())
+}
+
+// HasBlock reports whether .envrc contains the managed block
+func (s *Service) HasBlock(targetDir string) bool {
+	_, found, _ := s.filesystemService.ReadManagedBlock(EnvrcPath(targetDir), managedBlock)
+	return found
+}
+
+// RemoveBlock removes the managed block from .envrc, and the file itself when
+// nothing else is left. Returns false when there was no block.
+func (s *Service) RemoveBlock(targetDir string) (bool, error) {
+	return s.filesystemService.RemoveManagedBlock(EnvrcPath(targetDir), managedBlock)
+}
@@ -0,0 +1,157 @@
+package direnv
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestService_InstallBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *string
+		want     string
+	}{
+		{
+			name: "no envrc",
+			want: Block(),
+		},
+		{
+			name:     "user content kept",
+			existing: stringPtr("use flake\n"),
+			want:     "use flake\n\n" + Block(),
+		},
+		{
+			name:     "outdated block replaced",
+			existing: stringPtr("use flake\n\n" + managedBlock.Render("export OLD=1\n") + "\ndotenv\n"),
+			want:     "use flake\n\ndotenv\n\n" + Block(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			if tt.existing != nil {
+				if err := os.WriteFile(EnvrcPath(targetDir), []byte(*tt.existing), 0644); err != nil {
+					t.Fatalf("Failed to write .envrc: %v", err)
+				}
+			}
+
+			service := New()
+			changed, err := service.InstallBlock(targetDir)
+			if err != nil {
+				t.Fatalf("InstallBlock() error = %v", err)
+			}
+			if !changed {
+				t.Errorf("InstallBlock() changed = false, want true")
+			}
+
+			content, err := os.ReadFile(EnvrcPath(targetDir))
+			if err != nil {
+				t.Fatalf("Failed to read .envrc: %v", err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("InstallBlock() content = %q, want %q", content, tt.want)
+			}
+			if !service.HasBlock(targetDir) {
+				t.Errorf("HasBlock() = false, want true")
+			}
+
+			changed, err = service.InstallBlock(targetDir)
+			if err != nil {
+				t.Fatalf("second InstallBlock() error = %v", err)
+			}
+			if changed {
+				t.Errorf("second InstallBlock() changed = true, want false")
+			}
+		})
+	}
+}
+
+func TestBlock(t *testing.T) {
+	block := Block()
+	for _, want := range []string{
+		`export CLAUDE_PROJECT_DIR="$PWD"`,
+		"PATH_add .strategic-claude-basic/tools",
+	} {
+		if !strings.Contains(block, want) {
+			t.Errorf("Block() = %q, want it to contain %q", block, want)
+		}
+	}
+}
+
+func TestService_RemoveBlock(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    *string
+		wantRemoved bool
+		wantFile    bool
+		wantContent string
+	}{
+		{
+			name:        "no envrc",
+			wantRemoved: false,
+			wantFile:    false,
+		},
+		{
+			name:        "no block",
+			existing:    stringPtr("use flake\n"),
+			wantRemoved: false,
+			wantFile:    true,
+			wantContent: "use flake\n",
+		},
+		{
+			name:        "only block deletes file",
+			existing:    stringPtr(Block()),
+			wantRemoved: true,
+			wantFile:    false,
+		},
+		{
+			name:        "user content kept",
+			existing:    stringPtr("use flake\n\n" + Block()),
+			wantRemoved: true,
+			wantFile:    true,
+			wantContent: "use flake\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			if tt.existing != nil {
+				if err := os.WriteFile(EnvrcPath(targetDir), []byte(*tt.existing), 0644); err != nil {
+					t.Fatalf("Failed to write .envrc: %v", err)
+				}
+			}
+
+			removed, err := New().RemoveBlock(targetDir)
+			if err != nil {
+				t.Fatalf("RemoveBlock() error = %v", err)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("RemoveBlock() = %v, want %v", removed, tt.wantRemoved)
+			}
+
+			content, err := os.ReadFile(EnvrcPath(targetDir))
+			if os.IsNotExist(err) {
+				if tt.wantFile {
+					t.Errorf("RemoveBlock() deleted .envrc, want it kept")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to read .envrc: %v", err)
+			}
+			if !tt.wantFile {
+				t.Errorf("RemoveBlock() kept .envrc = %q, want it deleted", content)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("RemoveBlock() content = %q, want %q", content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,170 @@
+package document
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// archiveIndexHeader starts a new archive index
+const archiveIndexHeader = "# Archive Index\n\nDocuments retired with `strategic-claude-basic-cli archive`.\n\n"
+
+// ArchivedDocument describes a document that was moved into archives/.
+// Paths are relative to .strategic-claude-basic.
+type ArchivedDocument struct {
+	Kind  string
+	Title string
+	From  string
+	To    string
+}
+
+// Archive moves a plan, research or summary document into a dated folder below
+// archives/ and records it in the archive index. docPath may be absolute,
+// relative to the working directory, or relative to .strategic-claude-basic.
+func (s *Service) Archive(targetDir, docPath string) (*ArchivedDocument, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	sourcePath, err := resolveDocument(strategicDir, docPath)
+	if err != nil {
+		return nil, err
+	}
+
+	relPath, err := filepath.Rel(strategicDir, sourcePath)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeInvalidPath, sourcePath, err)
+	}
+	relPath = filepath.ToSlash(relPath)
+	kind, _, _ := strings.Cut(relPath, "/")
+	if !slices.Contains(GetKinds(), kind) || kind == relPath {
+		return nil, models.NewValidationError("document", docPath,
+			fmt.Sprintf("must be a document in one of: %s", strings.Join(GetKinds(), ", ")))
+	}
+
+	info, err := os.Lstat(sourcePath)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, models.NewValidationError("document", docPath, "must be a regular file")
+	}
+	// The directory guides belong to the framework, not to the user
+	if info.Name() == config.ClaudeConfigFile {
+		return nil, models.NewValidationError("document", docPath, "framework files cannot be archived")
+	}
+
+	date := s.now().Format(time.DateOnly)
+	archivedRel := filepath.ToSlash(filepath.Join(date, relPath))
+	destPath := filepath.Join(strategicDir, config.ArchivesDir, filepath.FromSlash(archivedRel))
+	if _, err := os.Lstat(destPath); err == nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileAlreadyExists, destPath, os.ErrExist)
+	}
+
+	doc := &ArchivedDocument{
+		Kind:  kind,
+		Title: documentTitle(sourcePath),
+		From:  relPath,
+		To:    config.ArchivesDir + "/" + archivedRel,
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), config.DirPermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(destPath), err)
+	}
+	if err := os.Rename(sourcePath, destPath); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
+	}
+
+	entry := fmt.Sprintf("- %s: [%s](%s) (from %s)\n", date, doc.Title, archivedRel, relPath)
+	if err := appendArchiveIndex(filepath.Join(strategicDir, config.ArchivesDir, config.ArchiveIndexFile), entry); err != nil {
+		return doc, err
+	}
+
+	return doc, nil
+}
+
+// resolveDocument finds the file docPath refers to
+func resolveDocument(strategicDir, docPath string) (string, error) {
+	candidates := []string{filepath.Join(strategicDir, docPath)}
+	if filepath.IsAbs(docPath) {
+		candidates = []string{docPath}
+	} else if absPath, err := filepath.Abs(docPath); err == nil {
+		candidates = append([]string{absPath}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := os.Lstat(candidate); err == nil {
+			return filepath.Clean(candidate), nil
+		}
+	}
+
+	return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, docPath, os.ErrNotExist)
+}
+
+// documentTitle returns the front matter title or first heading of a document,
+// falling back to its file name
+func documentTitle(path string) string {
+	fallback := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fallback
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	inFrontMatter := false
+	for lineNumber := 0; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "---" && lineNumber == 0:
+			inFrontMatter = true
+		case line == "---" && inFrontMatter:
+			inFrontMatter = false
+		case inFrontMatter:
+			if value, found := strings.CutPrefix(line, "title:"); found {
+				if title := strings.Trim(strings.TrimSpace(value), `"'`); title != "" {
+					return title
+				}
+			}
+		case strings.HasPrefix(line, "# "):
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+
+	return fallback
+}
+
+// appendArchiveIndex adds an entry to the archive index, creating it when needed
+func appendArchiveIndex(indexPath, entry string) error {
+	_, err := os.Stat(indexPath)
+	created := errors.Is(err, os.ErrNotExist)
+
+	file, err := os.OpenFile(indexPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, config.FilePermissions)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, indexPath, err)
+	}
+
+	if created {
+		entry = archiveIndexHeader + entry
+	}
+	_, writeErr := file.WriteString(entry)
+	if err := errors.Join(writeErr, file.Close()); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, indexPath, err)
+	}
+
+	return nil
+}
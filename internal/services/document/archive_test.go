@@ -0,0 +1,102 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// writeDocument writes a file below .strategic-claude-basic and returns its path
+func writeDocument(t *testing.T, targetDir, relPath, content string) string {
+	t.Helper()
+
+	fullPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fullPath
+}
+
+func TestService_Archive(t *testing.T) {
+	targetDir := createInstallation(t, nil)
+	planPath := writeDocument(t, targetDir, "plan/auth.md", "---\ntitle: \"User auth\"\n---\n# Heading\n")
+	writeDocument(t, targetDir, "research/notes.md", "# Caching notes\n")
+	writeDocument(t, targetDir, "summary/untitled.md", "no title\n")
+
+	tests := []struct {
+		docPath   string
+		wantTo    string
+		wantTitle string
+	}{
+		{planPath, "archives/2025-03-14/plan/auth.md", "User auth"},
+		{"research/notes.md", "archives/2025-03-14/research/notes.md", "Caching notes"},
+		{"summary/untitled.md", "archives/2025-03-14/summary/untitled.md", "untitled"},
+	}
+
+	for _, tt := range tests {
+		doc, err := newTestService().Archive(targetDir, tt.docPath)
+		if err != nil {
+			t.Fatalf("Archive(%s) error = %v", tt.docPath, err)
+		}
+		if doc.To != tt.wantTo || doc.Title != tt.wantTitle {
+			t.Errorf("Archive(%s) = %+v, want to %s with title %q", tt.docPath, doc, tt.wantTo, tt.wantTitle)
+		}
+
+		strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+		if _, err := os.Stat(filepath.Join(strategicDir, filepath.FromSlash(doc.From))); !os.IsNotExist(err) {
+			t.Errorf("Archive(%s) left the original in place", tt.docPath)
+		}
+		if _, err := os.Stat(filepath.Join(strategicDir, filepath.FromSlash(doc.To))); err != nil {
+			t.Errorf("Archive(%s) did not create %s: %v", tt.docPath, doc.To, err)
+		}
+	}
+
+	index, err := os.ReadFile(filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.ArchivesDir, config.ArchiveIndexFile))
+	if err != nil {
+		t.Fatalf("Failed to read archive index: %v", err)
+	}
+	wantEntries := []string{
+		"- 2025-03-14: [User auth](2025-03-14/plan/auth.md) (from plan/auth.md)\n",
+		"- 2025-03-14: [Caching notes](2025-03-14/research/notes.md) (from research/notes.md)\n",
+		"- 2025-03-14: [untitled](2025-03-14/summary/untitled.md) (from summary/untitled.md)\n",
+	}
+	if !strings.HasPrefix(string(index), archiveIndexHeader) || !strings.HasSuffix(string(index), strings.Join(wantEntries, "")) {
+		t.Errorf("archive index = %q, want header followed by %q", index, wantEntries)
+	}
+}
+
+func TestService_Archive_Errors(t *testing.T) {
+	targetDir := createInstallation(t, nil)
+	writeDocument(t, targetDir, "plan/CLAUDE.md", "framework guide")
+	writeDocument(t, targetDir, "issues/bug.md", "issue")
+	writeDocument(t, targetDir, "plan/dup.md", "second copy")
+	writeDocument(t, targetDir, "archives/2025-03-14/plan/dup.md", "first copy")
+
+	tests := []struct {
+		name     string
+		docPath  string
+		wantCode models.ErrorCode
+	}{
+		{"missing", "plan/missing.md", models.ErrorCodeFileSystemError},
+		{"outside document directories", "issues/bug.md", models.ErrorCodeValidationFailed},
+		{"directory", "plan", models.ErrorCodeValidationFailed},
+		{"framework file", "plan/CLAUDE.md", models.ErrorCodeValidationFailed},
+		{"already archived", "plan/dup.md", models.ErrorCodeFileAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestService().Archive(targetDir, tt.docPath)
+			if !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("Archive(%s) error = %v, want code %s", tt.docPath, err, tt.wantCode)
+			}
+		})
+	}
+}
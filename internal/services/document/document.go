@@ -0,0 +1,187 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Document kinds that can be scaffolded, each stored in the user directory of the same name
+const (
+	KindPlan     = config.PlanDir
+	KindResearch = config.ResearchDir
+	KindSummary  = config.SummaryDir
+)
+
+// defaultTemplate is used when the installed framework ships no template for a kind
+const defaultTemplate = `---
+title:
+date:
+type: {{kind}}
+---
+
+# {{title}}
+`
+
+// frontMatterKeys are filled in when a template leaves them empty
+var frontMatterKeys = []string{"title", "name", "date"}
+
+// nonSlugChars matches runs of characters that are not allowed in file names
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Document describes a newly created document
+type Document struct {
+	Kind         string
+	Title        string
+	Path         string // Absolute path of the created file
+	TemplatePath string // Framework template used, empty for the built-in default
+}
+
+// Service scaffolds user documents from the installed framework templates and
+// retires them into archives/
+type Service struct {
+	now func() time.Time
+}
+
+// New creates a new document service instance
+func New() *Service {
+	return &Service{
+		now: time.Now,
+	}
+}
+
+// GetKinds returns the document kinds that can be created
+func GetKinds() []string {
+	return []string{KindPlan, KindResearch, KindSummary}
+}
+
+// ValidateKind checks that kind is a supported document kind
+func ValidateKind(kind string) error {
+	if !slices.Contains(GetKinds(), kind) {
+		return models.NewValidationError("kind", kind,
+			fmt.Sprintf("must be one of: %s", strings.Join(GetKinds(), ", ")))
+	}
+	return nil
+}
+
+// Slugify turns a document name into a file name component
+func Slugify(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// TemplatePath returns where the framework keeps the template for kind
+func TemplatePath(targetDir, kind string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(),
+		filepath.FromSlash(config.DocumentTemplatesDir), kind+config.DocumentTemplateSuffix)
+}
+
+// Create writes a new document of the given kind named name into its user
+// directory. The file is named <date>-<slug>.md and is never overwritten.
+func (s *Service) Create(targetDir, kind, name string) (*Document, error) {
+	if err := ValidateKind(kind); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(name)
+	slug := Slugify(title)
+	if slug == "" {
+		return nil, models.NewValidationError("name", name, "must contain at least one letter or digit")
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	doc := &Document{Kind: kind, Title: title}
+	content := defaultTemplate
+	templatePath := TemplatePath(targetDir, kind)
+	data, err := os.ReadFile(templatePath)
+	switch {
+	case err == nil:
+		content = string(data)
+		doc.TemplatePath = templatePath
+	case !os.IsNotExist(err):
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, templatePath, err)
+	}
+
+	now := s.now()
+	date := now.Format(time.DateOnly)
+	content = fillFrontMatter(content, map[string]string{"title": title, "name": title, "date": date})
+	content = strings.NewReplacer(
+		"{{title}}", title,
+		"{{name}}", title,
+		"{{slug}}", slug,
+		"{{kind}}", kind,
+		"{{date}}", date,
+		"{{datetime}}", now.Format(time.RFC3339),
+	).Replace(content)
+
+	dir := filepath.Join(strategicDir, kind)
+	if err := os.MkdirAll(dir, config.DirPermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+	}
+
+	doc.Path = filepath.Join(dir, date+"-"+slug+".md")
+	file, err := os.OpenFile(doc.Path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, config.FilePermissions)
+	if os.IsExist(err) {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileAlreadyExists, doc.Path, err)
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, doc.Path, err)
+	}
+
+	_, writeErr := file.WriteString(content)
+	if err := errors.Join(writeErr, file.Close()); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, doc.Path, err)
+	}
+
+	return doc, nil
+}
+
+// fillFrontMatter sets front matter keys that a template leaves empty, e.g.
+// "date:" or `title: ""`. Content without front matter is returned unchanged.
+func fillFrontMatter(content string, values map[string]string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return content
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			break
+		}
+
+		key, value, found := strings.Cut(lines[i], ":")
+		if !found || !slices.Contains(frontMatterKeys, strings.TrimSpace(key)) {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value != "" && value != `""` && value != "''" {
+			continue
+		}
+		lines[i] = fmt.Sprintf("%s: %s", key, quoteYAML(values[strings.TrimSpace(key)]))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// quoteYAML quotes a front matter value when it would not parse as a plain string
+func quoteYAML(value string) string {
+	if strings.ContainsAny(value, ":#\"'{}[],&*!|>%@`") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
@@ -0,0 +1,151 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// newTestService returns a service whose clock is fixed
+func newTestService() *Service {
+	return &Service{
+		now: func() time.Time { return time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC) },
+	}
+}
+
+// createInstallation creates a project with the given document templates
+func createInstallation(t *testing.T, templates map[string]string) string {
+	t.Helper()
+
+	targetDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(targetDir, config.StrategicClaudeBasicDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for kind, content := range templates {
+		templatePath := TemplatePath(targetDir, kind)
+		if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(templatePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return targetDir
+}
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		templates    map[string]string
+		kind         string
+		docName      string
+		wantFile     string
+		wantContent  string
+		wantTemplate bool
+	}{
+		{
+			name:         "placeholders",
+			templates:    map[string]string{KindPlan: "# {{title}}\n\nCreated {{date}} ({{kind}}, {{slug}})\n"},
+			kind:         KindPlan,
+			docName:      "User Authentication",
+			wantFile:     "plan/2025-03-14-user-authentication.md",
+			wantContent:  "# User Authentication\n\nCreated 2025-03-14 (plan, user-authentication)\n",
+			wantTemplate: true,
+		},
+		{
+			name:         "empty front matter fields",
+			templates:    map[string]string{KindResearch: "---\ntitle: \"\"\ndate:\nstatus: draft\n---\nBody\n"},
+			kind:         KindResearch,
+			docName:      "Caching: options",
+			wantFile:     "research/2025-03-14-caching-options.md",
+			wantContent:  "---\ntitle: \"Caching: options\"\ndate: 2025-03-14\nstatus: draft\n---\nBody\n",
+			wantTemplate: true,
+		},
+		{
+			name:        "built-in default",
+			kind:        KindSummary,
+			docName:     "Sprint 12",
+			wantFile:    "summary/2025-03-14-sprint-12.md",
+			wantContent: "---\ntitle: Sprint 12\ndate: 2025-03-14\ntype: summary\n---\n\n# Sprint 12\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := createInstallation(t, tt.templates)
+
+			doc, err := newTestService().Create(targetDir, tt.kind, tt.docName)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			wantPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(tt.wantFile))
+			if doc.Path != wantPath {
+				t.Errorf("Create() path = %v, want %v", doc.Path, wantPath)
+			}
+			if (doc.TemplatePath != "") != tt.wantTemplate {
+				t.Errorf("Create() template = %q, want template used %v", doc.TemplatePath, tt.wantTemplate)
+			}
+
+			content, err := os.ReadFile(wantPath)
+			if err != nil {
+				t.Fatalf("Failed to read created document: %v", err)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("Create() content = %q, want %q", content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestService_Create_Errors(t *testing.T) {
+	installed := createInstallation(t, nil)
+	if _, err := newTestService().Create(installed, KindPlan, "Existing"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		targetDir string
+		kind      string
+		docName   string
+		wantCode  models.ErrorCode
+	}{
+		{"unknown kind", installed, "decision", "Name", models.ErrorCodeValidationFailed},
+		{"empty name", installed, KindPlan, " !? ", models.ErrorCodeValidationFailed},
+		{"not installed", t.TempDir(), KindPlan, "Name", models.ErrorCodeNotInstalled},
+		{"already exists", installed, KindPlan, "existing", models.ErrorCodeFileAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestService().Create(tt.targetDir, tt.kind, tt.docName)
+			if !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("Create() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"User Authentication", "user-authentication"},
+		{"  API v2: rollout!  ", "api-v2-rollout"},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.name); got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
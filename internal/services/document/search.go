@@ -0,0 +1,179 @@
+package document
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// binarySniffLen is how much of a file is checked for NUL bytes to skip binaries
+const binarySniffLen = 8000
+
+// leadingDate matches the date prefix of document file names created by 'new'
+var leadingDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+// SearchQuery selects the documents and lines reported by Search
+type SearchQuery struct {
+	Pattern       string
+	Regexp        bool              // Pattern is a regular expression instead of plain text
+	CaseSensitive bool              // Match case exactly
+	Dirs          []string          // User directories to search, default GetSearchDirectories()
+	Fields        map[string]string // Front matter fields that must have these values
+	Since         time.Time         // Only documents dated on or after this day
+	Until         time.Time         // Only documents dated on or before this day
+}
+
+// Match is a matching line. Path is relative to .strategic-claude-basic.
+type Match struct {
+	Path string
+	Line int
+	Text string
+}
+
+// GetSearchDirectories returns the user directories searched by default
+func GetSearchDirectories() []string {
+	return []string{config.PlanDir, config.ResearchDir, config.SummaryDir, config.IssuesDir}
+}
+
+// Search returns the lines of user documents in targetDir that match query,
+// ordered by directory as searched, then by path and line number
+func (s *Service) Search(targetDir string, query SearchQuery) ([]Match, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	matcher, err := compileQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := query.Dirs
+	if len(dirs) == 0 {
+		dirs = GetSearchDirectories()
+	}
+	for _, dir := range dirs {
+		if !slices.Contains(config.GetUserPreservedDirectories(), dir) {
+			return nil, models.NewValidationError("in", dir,
+				fmt.Sprintf("must be one of: %s", strings.Join(config.GetUserPreservedDirectories(), ", ")))
+		}
+	}
+
+	var matches []Match
+	for _, dir := range dirs {
+		root := filepath.Join(strategicDir, dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				return err
+			}
+			if !d.Type().IsRegular() || d.Name() == config.ClaudeConfigFile {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if bytes.IndexByte(content[:min(len(content), binarySniffLen)], 0) >= 0 {
+				return nil
+			}
+			if !matchesFilters(d.Name(), utils.ParseFrontMatter(string(content)), query) {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(strategicDir, path)
+			if err != nil {
+				return err
+			}
+			matches = append(matches, matchLines(filepath.ToSlash(relPath), content, matcher)...)
+			return nil
+		})
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+
+	return matches, nil
+}
+
+// compileQuery turns the query pattern into a line matcher
+func compileQuery(query SearchQuery) (*regexp.Regexp, error) {
+	if query.Pattern == "" {
+		return nil, models.NewValidationError("query", query.Pattern, "must not be empty")
+	}
+
+	pattern := query.Pattern
+	if !query.Regexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !query.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, models.NewValidationError("query", query.Pattern, fmt.Sprintf("invalid regular expression: %v", err))
+	}
+	return matcher, nil
+}
+
+// matchesFilters reports whether a document passes the front matter and date filters
+func matchesFilters(name string, frontMatter map[string]string, query SearchQuery) bool {
+	for key, want := range query.Fields {
+		if !strings.EqualFold(frontMatter[strings.ToLower(key)], want) {
+			return false
+		}
+	}
+
+	if query.Since.IsZero() && query.Until.IsZero() {
+		return true
+	}
+
+	dateText := frontMatter["date"]
+	if dateText == "" {
+		dateText = leadingDate.FindString(name)
+	}
+	if len(dateText) < len(time.DateOnly) {
+		return false
+	}
+	date, err := time.Parse(time.DateOnly, dateText[:len(time.DateOnly)])
+	if err != nil {
+		return false
+	}
+
+	return (query.Since.IsZero() || !date.Before(query.Since)) &&
+		(query.Until.IsZero() || !date.After(query.Until))
+}
+
+// matchLines returns the lines of content that match
+func matchLines(relPath string, content []byte, matcher *regexp.Regexp) []Match {
+	var matches []Match
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if line := scanner.Text(); matcher.MatchString(line) {
+			matches = append(matches, Match{Path: relPath, Line: lineNumber, Text: strings.TrimSpace(line)})
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,103 @@
+package document
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestService_Search(t *testing.T) {
+	targetDir := createInstallation(t, nil)
+	writeDocument(t, targetDir, "plan/2025-01-10-auth.md", "---\nstatus: draft\n---\n# Auth plan\nAdd OAuth login\n")
+	writeDocument(t, targetDir, "plan/2025-02-20-cache.md", "---\nstatus: done\ndate: 2025-02-20\n---\nCache the OAuth tokens\n")
+	writeDocument(t, targetDir, "plan/CLAUDE.md", "How to write oauth plans\n")
+	writeDocument(t, targetDir, "research/oauth.md", "oauth providers compared\n")
+	writeDocument(t, targetDir, "issues/bug.md", "Login fails after OAuth refresh\n")
+	writeDocument(t, targetDir, "product/vision.md", "OAuth everywhere\n")
+
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  []Match
+	}{
+		{
+			name:  "default directories, case-insensitive",
+			query: SearchQuery{Pattern: "oauth"},
+			want: []Match{
+				{"plan/2025-01-10-auth.md", 5, "Add OAuth login"},
+				{"plan/2025-02-20-cache.md", 5, "Cache the OAuth tokens"},
+				{"research/oauth.md", 1, "oauth providers compared"},
+				{"issues/bug.md", 1, "Login fails after OAuth refresh"},
+			},
+		},
+		{
+			name:  "case-sensitive in selected directories",
+			query: SearchQuery{Pattern: "oauth", CaseSensitive: true, Dirs: []string{"research", "product"}},
+			want:  []Match{{"research/oauth.md", 1, "oauth providers compared"}},
+		},
+		{
+			name:  "regular expression",
+			query: SearchQuery{Pattern: `^(add|cache) `, Regexp: true},
+			want: []Match{
+				{"plan/2025-01-10-auth.md", 5, "Add OAuth login"},
+				{"plan/2025-02-20-cache.md", 5, "Cache the OAuth tokens"},
+			},
+		},
+		{
+			name:  "front matter field",
+			query: SearchQuery{Pattern: "oauth", Fields: map[string]string{"Status": "DRAFT"}},
+			want:  []Match{{"plan/2025-01-10-auth.md", 5, "Add OAuth login"}},
+		},
+		{
+			name: "date range",
+			query: SearchQuery{
+				Pattern: "oauth",
+				Since:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+			want: []Match{{"plan/2025-01-10-auth.md", 5, "Add OAuth login"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().Search(targetDir, tt.query)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestService_Search_Errors(t *testing.T) {
+	installed := createInstallation(t, nil)
+
+	tests := []struct {
+		name      string
+		targetDir string
+		query     SearchQuery
+		wantCode  models.ErrorCode
+	}{
+		{"empty query", installed, SearchQuery{}, models.ErrorCodeValidationFailed},
+		{"invalid regexp", installed, SearchQuery{Pattern: "(", Regexp: true}, models.ErrorCodeValidationFailed},
+		{"framework directory", installed, SearchQuery{Pattern: "x", Dirs: []string{"core"}}, models.ErrorCodeValidationFailed},
+		{"not installed", t.TempDir(), SearchQuery{Pattern: "x"}, models.ErrorCodeNotInstalled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().Search(tt.targetDir, tt.query); !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("Search() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
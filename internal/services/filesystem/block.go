@@ -0,0 +1,190 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/fsys"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// ManagedBlock identifies the lines the CLI manages in a text file it shares
+// with the user, such as .gitignore or .envrc, by the marker lines around them.
+// Everything outside the markers belongs to the user and is kept.
+type ManagedBlock struct {
+	Start string // Line opening the block
+	End   string // Line closing the block
+}
+
+// NewManagedBlock returns the block delimited by the CLI's markers, written as
+// comments between prefix and suffix: "# " and "" for shell, YAML and ignore
+// files, "<!-- " and " -->" for Markdown
+func NewManagedBlock(prefix, suffix string) ManagedBlock {
+	return ManagedBlock{
+		Start: prefix + ">>> strategic-claude-basic (managed by strategic-claude-basic-cli, do not edit) >>>" + suffix,
+		End:   prefix + "<<< strategic-claude-basic <<<" + suffix,
+	}
+}
+
+// HashCommentBlock is the managed block of files with # comments
+var HashCommentBlock = NewManagedBlock("# ", "")
+
+// Render returns the block holding body
+func (b ManagedBlock) Render(body string) string {
+	if body != "" && !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+	return b.Start + "\n" + body + b.End + "\n"
+}
+
+// SplitManagedBlock returns content without the block, the body of the block
+// and whether a marker was found. The content before and after the block is
+// joined with a blank line. Markers are matched as whole lines.
+//
+// Unbalanced markers, i.e. a start marker without end marker, an end marker
+// without start marker or more than one block, are a conflict: the content is
+// returned unchanged with an error, since guessing where the block ends could
+// discard the user's lines. Callers report it with ErrorCodeManagedBlockConflict.
+func SplitManagedBlock(content string, block ManagedBlock) (string, string, bool, error) {
+	lines := strings.SplitAfter(content, "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case block.Start:
+			if start >= 0 {
+				return content, "", true, errors.New("more than one managed block")
+			}
+			start = i
+		case block.End:
+			if start < 0 || end >= 0 {
+				return content, "", true, errors.New("end marker without start marker")
+			}
+			end = i
+		}
+	}
+	if start < 0 {
+		return content, "", false, nil
+	}
+	if end < 0 {
+		return content, "", true, errors.New("start marker without end marker")
+	}
+
+	body := strings.Join(lines[start+1:end], "")
+	before := strings.TrimRight(strings.Join(lines[:start], ""), "\n")
+	after := strings.TrimLeft(strings.Join(lines[end+1:], ""), "\n")
+	switch {
+	case before == "":
+		return after, body, true, nil
+	case after == "":
+		return before + "\n", body, true, nil
+	default:
+		return before + "\n\n" + after, body, true, nil
+	}
+}
+
+// JoinManagedBlock returns rest, content without the block, with the block
+// holding body appended after a blank line
+func JoinManagedBlock(rest string, block ManagedBlock, body string) string {
+	if strings.TrimSpace(rest) == "" {
+		return block.Render(body)
+	}
+	return strings.TrimRight(rest, "\n") + "\n\n" + block.Render(body)
+}
+
+// ReadManagedBlock returns the body of the block in the file at path, and
+// whether the block was found. A missing file has no block.
+func (s *Service) ReadManagedBlock(path string, block ManagedBlock) (string, bool, error) {
+	existing, err := s.readIfExists(path)
+	if err != nil {
+		return "", false, err
+	}
+	_, body, found, err := SplitManagedBlock(string(existing), block)
+	if err != nil {
+		return "", found, models.NewFileSystemError(models.ErrorCodeManagedBlockConflict, path, err)
+	}
+	return body, found, nil
+}
+
+// ApplyManagedBlock writes body into the block in the file at path, replacing
+// a previous block at the end of the file and keeping everything else. The file
+// is created when missing. Returns false without writing when the block was
+// already current, so applying it again changes nothing.
+func (s *Service) ApplyManagedBlock(path string, block ManagedBlock, body string) (bool, error) {
+	existing, err := s.readIfExists(path)
+	if err != nil {
+		return false, err
+	}
+	rest, _, _, err := SplitManagedBlock(string(existing), block)
+	if err != nil {
+		return false, models.NewFileSystemError(models.ErrorCodeManagedBlockConflict, path, err)
+	}
+
+	content := JoinManagedBlock(rest, block, body)
+	if content == string(existing) {
+		return false, nil
+	}
+
+	if err := s.CreateDirectory(filepath.Dir(path)); err != nil {
+		return false, err
+	}
+	if err := s.writeSharedFile(path, content); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveManagedBlock removes the block from the file at path, and the file
+// itself when nothing else is left. Returns false when there was no block.
+func (s *Service) RemoveManagedBlock(path string, block ManagedBlock) (bool, error) {
+	existing, err := s.readIfExists(path)
+	if err != nil {
+		return false, err
+	}
+	rest, _, found, err := SplitManagedBlock(string(existing), block)
+	if err != nil {
+		return false, models.NewFileSystemError(models.ErrorCodeManagedBlockConflict, path, err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	if strings.TrimSpace(rest) == "" {
+		if err := s.fs.Remove(path); err != nil {
+			return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+		}
+		return true, nil
+	}
+
+	if err := s.writeSharedFile(path, strings.TrimRight(rest, "\n")+"\n"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// writeSharedFile writes a file shared with the user. On disk it is replaced
+// atomically, so an interrupted write cannot truncate the user's content.
+func (s *Service) writeSharedFile(path, content string) error {
+	if s.fs == fsys.OS() {
+		return utils.WriteFileAtomic(path, []byte(content), config.FilePermissions)
+	}
+	if err := s.fs.WriteFile(path, []byte(content), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// readIfExists reads a file, returning nil when it does not exist
+func (s *Service) readIfExists(path string) ([]byte, error) {
+	data, err := s.fs.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return data, nil
+}
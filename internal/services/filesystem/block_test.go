@@ -0,0 +1,192 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestSplitManagedBlock(t *testing.T) {
+	block := HashCommentBlock
+
+	tests := []struct {
+		name      string
+		content   string
+		wantRest  string
+		wantBody  string
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name:     "no block",
+			content:  "user\n",
+			wantRest: "user\n",
+		},
+		{
+			name:      "block between user lines",
+			content:   "before\n\n" + block.Render("managed\n") + "\nafter\n",
+			wantRest:  "before\n\nafter\n",
+			wantBody:  "managed\n",
+			wantFound: true,
+		},
+		{
+			name:      "block only",
+			content:   block.Render("managed\n"),
+			wantBody:  "managed\n",
+			wantFound: true,
+		},
+		{
+			name:      "start marker without end marker",
+			content:   "user\n" + block.Start + "\nmanaged\nmore user\n",
+			wantRest:  "user\n" + block.Start + "\nmanaged\nmore user\n",
+			wantFound: true,
+			wantErr:   true,
+		},
+		{
+			name:      "end marker without start marker",
+			content:   "user\n" + block.End + "\n",
+			wantRest:  "user\n" + block.End + "\n",
+			wantFound: true,
+			wantErr:   true,
+		},
+		{
+			name:      "two blocks",
+			content:   block.Render("one\n") + block.Render("two\n"),
+			wantRest:  block.Render("one\n") + block.Render("two\n"),
+			wantFound: true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, body, found, err := SplitManagedBlock(tt.content, block)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitManagedBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rest != tt.wantRest || body != tt.wantBody || found != tt.wantFound {
+				t.Errorf("SplitManagedBlock() = %q, %q, %v, want %q, %q, %v", rest, body, found, tt.wantRest, tt.wantBody, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestService_ApplyManagedBlock(t *testing.T) {
+	service := New()
+	block := NewManagedBlock("<!-- ", " -->")
+	path := filepath.Join(t.TempDir(), "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Agents\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := service.ApplyManagedBlock(path, block, "first")
+	if err != nil || !changed {
+		t.Fatalf("ApplyManagedBlock() = %v, %v, want true", changed, err)
+	}
+	changed, err = service.ApplyManagedBlock(path, block, "second")
+	if err != nil || !changed {
+		t.Fatalf("ApplyManagedBlock() replacing = %v, %v, want true", changed, err)
+	}
+	changed, err = service.ApplyManagedBlock(path, block, "second")
+	if err != nil || changed {
+		t.Errorf("ApplyManagedBlock() again = %v, %v, want false", changed, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Agents\n\n" + block.Render("second\n"); string(data) != want {
+		t.Errorf("content = %q, want %q", data, want)
+	}
+	if body, found, err := service.ReadManagedBlock(path, block); err != nil || !found || body != "second\n" {
+		t.Errorf("ReadManagedBlock() = %q, %v, %v", body, found, err)
+	}
+
+	// The user's lines are kept and the file with them
+	removed, err := service.RemoveManagedBlock(path, block)
+	if err != nil || !removed {
+		t.Fatalf("RemoveManagedBlock() = %v, %v, want true", removed, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# Agents\n" {
+		t.Errorf("content after removal = %q, want the user's lines", data)
+	}
+	if removed, err := service.RemoveManagedBlock(path, block); err != nil || removed {
+		t.Errorf("RemoveManagedBlock() again = %v, %v, want false", removed, err)
+	}
+}
+
+func TestService_ApplyManagedBlock_Conflict(t *testing.T) {
+	service := New()
+	path := filepath.Join(t.TempDir(), ".envrc")
+	content := "use flake\n" + HashCommentBlock.Start + "\nexport A=1\ndotenv\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.ApplyManagedBlock(path, HashCommentBlock, "export B=2\n"); !models.IsErrorCode(err, models.ErrorCodeManagedBlockConflict) {
+		t.Errorf("ApplyManagedBlock() error = %v, want %s", err, models.ErrorCodeManagedBlockConflict)
+	}
+	if _, err := service.RemoveManagedBlock(path, HashCommentBlock); !models.IsErrorCode(err, models.ErrorCodeManagedBlockConflict) {
+		t.Errorf("RemoveManagedBlock() error = %v, want %s", err, models.ErrorCodeManagedBlockConflict)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("content = %q, want it unchanged", data)
+	}
+}
+
+func TestService_ApplyIgnoreTemplate(t *testing.T) {
+	service := New()
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "ignore.template")
+	if err := os.WriteFile(templatePath, []byte("agents/strategic\n\nlocal.json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries merged by older versions move into the block, the user's stay
+	targetPath := filepath.Join(dir, ".gitignore")
+	legacy := "# Strategic Claude Basic entries\nnode_modules/\nlocal.json\nagents/strategic\n"
+	if err := os.WriteFile(targetPath, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(targetPath+".backup", []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := service.ApplyIgnoreTemplate(templatePath, targetPath); err != nil {
+			t.Fatalf("ApplyIgnoreTemplate() error = %v", err)
+		}
+	}
+	data, err := os.ReadFile(targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "node_modules/\n\n" + HashCommentBlock.Render("agents/strategic\nlocal.json\n"); string(data) != want {
+		t.Errorf(".gitignore = %q, want %q", data, want)
+	}
+	if _, err := os.Stat(targetPath + ".backup"); !os.IsNotExist(err) {
+		t.Error("legacy backup was not removed")
+	}
+
+	// Entries the user lists are not repeated
+	if err := os.WriteFile(targetPath, []byte("local.json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.ApplyIgnoreTemplate(templatePath, targetPath); err != nil {
+		t.Fatalf("ApplyIgnoreTemplate() error = %v", err)
+	}
+	if data, _ := os.ReadFile(targetPath); string(data) != "local.json\n\n"+HashCommentBlock.Render("agents/strategic\n") {
+		t.Errorf(".gitignore = %q, want only the missing entry in the block", data)
+	}
+
+	removed, err := service.RemoveIgnoreEntries(targetPath, []string{"agents/strategic", "local.json"})
+	if err != nil || !removed {
+		t.Fatalf("RemoveIgnoreEntries() = %v, %v, want true", removed, err)
+	}
+	if data, _ := os.ReadFile(targetPath); string(data) != "local.json\n" {
+		t.Errorf(".gitignore = %q, want the user's entry", data)
+	}
+}
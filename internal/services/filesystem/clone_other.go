@@ -0,0 +1,13 @@
+//go:build !linux
+
+package filesystem
+
+import (
+	"errors"
+	"os"
+)
+
+// cloneFile is not supported on this platform; files are copied instead
+func cloneFile(dst, src *os.File) error {
+	return errors.ErrUnsupported
+}
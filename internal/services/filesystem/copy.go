@@ -0,0 +1,181 @@
+package filesystem
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// How CopyDirectory copies the symlinks it finds
+const (
+	// Recreate the link; an absolute link into the copied tree becomes relative
+	// so the copy points into itself rather than back into the source. Links
+	// that lead out of the copied tree are refused.
+	SymlinkRewriteRelative = models.SymlinkPolicyRewriteRelative
+	// Leave the link out of the copy
+	SymlinkSkip = models.SymlinkPolicySkip
+	// Copy what the link points to; links back into a directory being copied
+	// are refused
+	SymlinkFollow = models.SymlinkPolicyFollow
+	// Recreate the link unchanged, wherever it points; backups use it
+	SymlinkKeep = models.SymlinkPolicyKeep
+)
+
+// SetSymlinkPolicy selects how CopyDirectory copies symlinks; empty selects
+// SymlinkRewriteRelative
+func (s *Service) SetSymlinkPolicy(policy string) error {
+	if policy != SymlinkKeep {
+		if err := models.ValidateSymlinkPolicy(policy); err != nil {
+			return err
+		}
+	}
+	s.symlinkPolicy = policy
+	return nil
+}
+
+// copyState is what copyTree needs to know about the directories above
+type copyState struct {
+	root      string        // Directory links must stay inside, see SymlinkRewriteRelative
+	policy    string        // How symlinks are copied
+	ancestors []fs.FileInfo // Directories being copied, to detect symlink loops
+	followed  int           // Symlinks followed to get here
+}
+
+// copyTree copies the contents of sourcePath into destPath, which exists. Like
+// filepath.WalkDir, it goes by the type of each directory entry, so only
+// directories are stat'ed, and files are copied into the directory created for
+// them without checking it again.
+func (s *Service) copyTree(sourcePath, destPath string, state copyState) error {
+	entries, err := s.fs.ReadDir(sourcePath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+
+	for _, entry := range entries {
+		path := filepath.Join(sourcePath, entry.Name())
+		destItemPath := filepath.Join(destPath, entry.Name())
+
+		switch {
+		case entry.IsDir():
+			info, err := entry.Info()
+			if err != nil {
+				return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+			}
+			if err := s.copySubdirectory(path, destItemPath, info, state); err != nil {
+				return err
+			}
+		case entry.Type()&fs.ModeSymlink != 0:
+			if err := s.copySymlink(path, destItemPath, state); err != nil {
+				return err
+			}
+		default:
+			// Copy regular file
+			if err := s.copyFile(path, destItemPath, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// copySubdirectory creates destPath and copies the directory at path, described by info, into it
+func (s *Service) copySubdirectory(path, destPath string, info fs.FileInfo, state copyState) error {
+	if err := s.fs.MkdirAll(destPath, info.Mode().Perm()); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+	}
+	if err := s.applyOwner(destPath); err != nil {
+		return err
+	}
+	state.ancestors = append(slices.Clip(state.ancestors), info)
+	return s.copyTree(path, destPath, state)
+}
+
+// copySymlink copies the symlink at path to destPath according to the symlink policy
+func (s *Service) copySymlink(path, destPath string, state copyState) error {
+	switch state.policy {
+	case SymlinkSkip:
+		return nil
+
+	case SymlinkFollow:
+		info, err := s.fs.Stat(path)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeSymlinkInvalid, path, err)
+		}
+		if !info.IsDir() {
+			return s.copyFile(path, destPath, false)
+		}
+		if slices.ContainsFunc(state.ancestors, func(ancestor fs.FileInfo) bool { return os.SameFile(ancestor, info) }) ||
+			state.followed >= config.MaxFollowedSymlinks {
+			return models.NewAppError(
+				models.ErrorCodeSymlinkLoop,
+				fmt.Sprintf("Symlink loop: %s points to a directory containing it", path),
+				nil,
+			)
+		}
+		state.followed++
+		return s.copySubdirectory(path, destPath, info, state)
+	}
+
+	linkTarget, err := s.fs.Readlink(path)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	if state.policy != SymlinkKeep {
+		if !utils.LinkResolvesWithinFS(s.fs, state.root, path, linkTarget) {
+			return models.NewAppError(
+				models.ErrorCodeSymlinkInvalid,
+				fmt.Sprintf("Symlink %s points to %s, outside %s; use the skip or follow symlink policy to copy it", path, linkTarget, state.root),
+				nil,
+			)
+		}
+		linkTarget = s.rewriteLinkTarget(path, linkTarget, state.root)
+	}
+	if err := s.fs.Symlink(linkTarget, destPath); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, destPath, err)
+	}
+	return s.applyOwner(destPath)
+}
+
+// rewriteLinkTarget returns the target a copy of the symlink at path, pointing
+// to target inside root, gets: an absolute target becomes relative, relative
+// targets are kept
+func (s *Service) rewriteLinkTarget(path, target, root string) string {
+	if !filepath.IsAbs(target) {
+		return target
+	}
+	if inside, err := s.IsSubPath(root, target); err == nil && inside {
+		if rel, err := s.GetRelativePath(filepath.Dir(path), target); err == nil {
+			return rel
+		}
+	}
+	return target
+}
+
+// copyBuffers are reused for file contents instead of allocating a buffer per file
+var copyBuffers = sync.Pool{
+	New: func() any {
+		buf := make([]byte, config.CopyBufferSize)
+		return &buf
+	},
+}
+
+// copyContent copies src to dst through a pooled buffer. The wrappers hide
+// ReadFrom and WriteTo, whose fallbacks allocate a new buffer for every file.
+func copyContent(dst io.Writer, src io.Reader) error {
+	buf := copyBuffers.Get().(*[]byte)
+	defer copyBuffers.Put(buf)
+
+	_, err := io.CopyBuffer(struct{ io.Writer }{dst}, struct{ io.Reader }{src}, *buf)
+	return err
+}
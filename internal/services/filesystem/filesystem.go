@@ -2,14 +2,17 @@ package filesystem
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
-	"time"
+	"sync"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/fsys"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
@@ -17,13 +20,99 @@ import (
 // Service handles file system operations for the Strategic Claude Basic CLI
 type Service struct {
 	pathValidator *utils.PathValidator
+
+	// Copies stop between files once it is done
+	ctx context.Context
+
+	// File system the operations apply to: the disk, memory, or memory over the disk
+	fs fsys.FS
+
+	// Ownership applied to created files and directories; nil keeps the process owner
+	owner *fileOwner
+
+	// How CopyDirectory copies symlinks; empty means SymlinkRewriteRelative
+	symlinkPolicy string
+
+	// Whether CopyFile tries to clone files, see SetCopyStrategy
+	reflink bool
+
+	// Hashes of the files copied since RecordHashes, by destination path; nil
+	// when they are not recorded
+	hashesMu sync.Mutex
+	hashes   map[string]models.FileHash
+
+	// What CopyFile copied since SetCopyLimit, and the most it copies
+	totalsMu sync.Mutex
+	totals   models.CopyTotals
+	limit    copyLimit
 }
 
-// New creates a new filesystem service instance
+// fileOwner holds the numeric owner applied to created paths
+type fileOwner struct {
+	uid int
+	gid int
+}
+
+// New creates a new filesystem service instance operating on the disk
 func New() *Service {
+	return NewWithFS(fsys.OS())
+}
+
+// NewWithFS creates a filesystem service operating on fs, e.g. an in-memory
+// file system for tests or an overlay for dry runs
+func NewWithFS(fs fsys.FS) *Service {
 	return &Service{
 		pathValidator: utils.NewPathValidator(),
+		ctx:           context.Background(),
+		fs:            fs,
+	}
+}
+
+// SetContext makes CopyFile and CopyDirectory stop before the next file once
+// ctx is done, e.g. at the deadline of the installation
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// SetOwner makes the service hand ownership of files and directories it creates to uid/gid.
+// A gid of -1 leaves the group unchanged.
+func (s *Service) SetOwner(uid, gid int) {
+	s.owner = &fileOwner{uid: uid, gid: gid}
+}
+
+// ChownTree applies the configured owner to path and everything below it.
+// It is a no-op when no owner is configured or the path does not exist.
+func (s *Service) ChownTree(path string) error {
+	if s.owner == nil {
+		return nil
+	}
+
+	if _, err := s.fs.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	return fsys.Walk(s.fs, path, func(walkPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return s.applyOwner(walkPath)
+	})
+}
+
+// applyOwner sets the configured owner on a single path without following symlinks
+func (s *Service) applyOwner(path string) error {
+	if s.owner == nil {
+		return nil
+	}
+
+	if err := s.fs.Lchown(path, s.owner.uid, s.owner.gid); err != nil {
+		if os.IsPermission(err) {
+			return models.NewFileSystemError(models.ErrorCodePermissionDenied, path, err)
+		}
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
 	}
+
+	return nil
 }
 
 // DirectoryOperations provides directory manipulation functions
@@ -45,7 +134,7 @@ func (s *Service) CreateDirectory(path string) error {
 	}
 
 	// Check if directory already exists
-	if info, err := os.Stat(absPath); err == nil {
+	if info, err := s.fs.Stat(absPath); err == nil {
 		if info.IsDir() {
 			return nil // Already exists and is a directory
 		}
@@ -57,7 +146,7 @@ func (s *Service) CreateDirectory(path string) error {
 	}
 
 	// Create directory with proper permissions
-	err = os.MkdirAll(absPath, config.DirPermissions)
+	err = s.fs.MkdirAll(absPath, config.DirPermissions)
 	if err != nil {
 		if os.IsPermission(err) {
 			return models.NewFileSystemError(models.ErrorCodePermissionDenied, absPath, err)
@@ -65,7 +154,7 @@ func (s *Service) CreateDirectory(path string) error {
 		return models.NewFileSystemError(models.ErrorCodeFileSystemError, absPath, err)
 	}
 
-	return nil
+	return s.applyOwner(absPath)
 }
 
 // RemoveStrategicClaudeBasic removes only the .strategic-claude-basic directory
@@ -79,7 +168,7 @@ func (s *Service) RemoveStrategicClaudeBasic(targetDir string) error {
 	}
 
 	// Build the exact path to .strategic-claude-basic
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Resolve to absolute path for validation
 	absPath, err := filepath.Abs(strategicDir)
@@ -88,7 +177,7 @@ func (s *Service) RemoveStrategicClaudeBasic(targetDir string) error {
 	}
 
 	// Validate that we're removing what we expect
-	if !strings.HasSuffix(absPath, config.StrategicClaudeBasicDir) {
+	if !strings.HasSuffix(absPath, config.FrameworkDir()) {
 		return models.NewAppError(
 			models.ErrorCodeValidationFailed,
 			fmt.Sprintf("Path does not end with expected directory name: %s", absPath),
@@ -97,12 +186,12 @@ func (s *Service) RemoveStrategicClaudeBasic(targetDir string) error {
 	}
 
 	// Check if directory exists
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+	if _, err := s.fs.Stat(absPath); os.IsNotExist(err) {
 		return nil // Already doesn't exist
 	}
 
 	// Remove the strategic-claude-basic directory
-	err = os.RemoveAll(absPath)
+	err = s.fs.RemoveAll(absPath)
 	if err != nil {
 		if os.IsPermission(err) {
 			return models.NewFileSystemError(models.ErrorCodePermissionDenied, absPath, err)
@@ -113,6 +202,45 @@ func (s *Service) RemoveStrategicClaudeBasic(targetDir string) error {
 	return nil
 }
 
+// RemoveStrategicClaudeBasicExcept removes the .strategic-claude-basic directory
+// but the entries of it named in keep, which a full overwrite carries over
+func (s *Service) RemoveStrategicClaudeBasicExcept(targetDir string, keep []string) error {
+	if len(keep) == 0 {
+		return s.RemoveStrategicClaudeBasic(targetDir)
+	}
+	if targetDir == "" {
+		return models.NewAppError(
+			models.ErrorCodeValidationFailed,
+			"Target directory cannot be empty",
+			nil,
+		)
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	entries, err := s.fs.ReadDir(strategicDir)
+	if os.IsNotExist(err) {
+		return nil // Already doesn't exist
+	}
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, strategicDir, err)
+	}
+
+	for _, entry := range entries {
+		if slices.Contains(keep, entry.Name()) {
+			continue
+		}
+		entryPath := filepath.Join(strategicDir, entry.Name())
+		if err := s.fs.RemoveAll(entryPath); err != nil {
+			if os.IsPermission(err) {
+				return models.NewFileSystemError(models.ErrorCodePermissionDenied, entryPath, err)
+			}
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryPath, err)
+		}
+	}
+
+	return nil
+}
+
 // RemoveSymlinks removes only the known Strategic Claude Basic symlinks
 func (s *Service) RemoveSymlinks(targetDir string) error {
 	if targetDir == "" {
@@ -130,12 +258,12 @@ func (s *Service) RemoveSymlinks(targetDir string) error {
 		fullSymlinkPath := filepath.Join(claudeDir, symlinkPath)
 
 		// Check if symlink exists
-		if _, err := os.Lstat(fullSymlinkPath); os.IsNotExist(err) {
+		if _, err := s.fs.Lstat(fullSymlinkPath); os.IsNotExist(err) {
 			continue // Skip if doesn't exist
 		}
 
 		// Remove the symlink
-		err := os.Remove(fullSymlinkPath)
+		err := s.fs.Remove(fullSymlinkPath)
 		if err != nil {
 			if os.IsPermission(err) {
 				return models.NewFileSystemError(models.ErrorCodePermissionDenied, fullSymlinkPath, err)
@@ -167,7 +295,8 @@ func (s *Service) RemoveBackup(targetDir, backupName string) error {
 	}
 
 	// Build path to backup directory
-	backupPath := filepath.Join(targetDir, backupName)
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	backupPath := filepath.Join(backupsRoot, backupName)
 
 	// Resolve to absolute path for validation
 	absPath, err := filepath.Abs(backupPath)
@@ -175,10 +304,10 @@ func (s *Service) RemoveBackup(targetDir, backupName string) error {
 		return models.NewFileSystemError(models.ErrorCodeInvalidPath, backupPath, err)
 	}
 
-	// Additional safety check - ensure we're in the expected target directory
-	expectedParent, err := filepath.Abs(targetDir)
+	// Additional safety check - ensure we're in the backups directory
+	expectedParent, err := filepath.Abs(backupsRoot)
 	if err != nil {
-		return models.NewFileSystemError(models.ErrorCodeInvalidPath, targetDir, err)
+		return models.NewFileSystemError(models.ErrorCodeInvalidPath, backupsRoot, err)
 	}
 
 	if filepath.Dir(absPath) != expectedParent {
@@ -190,12 +319,12 @@ func (s *Service) RemoveBackup(targetDir, backupName string) error {
 	}
 
 	// Check if directory exists
-	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+	if _, err := s.fs.Stat(absPath); os.IsNotExist(err) {
 		return nil // Already doesn't exist
 	}
 
 	// Remove the backup directory
-	err = os.RemoveAll(absPath)
+	err = s.fs.RemoveAll(absPath)
 	if err != nil {
 		if os.IsPermission(err) {
 			return models.NewFileSystemError(models.ErrorCodePermissionDenied, absPath, err)
@@ -228,7 +357,7 @@ func (s *Service) BackupDirectory(sourcePath, backupPath string) error {
 	}
 
 	// Check if source exists and is directory
-	sourceInfo, err := os.Stat(sourceAbs)
+	sourceInfo, err := s.fs.Stat(sourceAbs)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return models.NewFileSystemError(models.ErrorCodeDirectoryNotFound, sourceAbs, err)
@@ -245,7 +374,7 @@ func (s *Service) BackupDirectory(sourcePath, backupPath string) error {
 	}
 
 	// Check if backup path already exists
-	if _, err := os.Stat(backupAbs); err == nil {
+	if _, err := s.fs.Stat(backupAbs); err == nil {
 		return models.NewFileSystemError(
 			models.ErrorCodeFileAlreadyExists,
 			backupAbs,
@@ -253,13 +382,14 @@ func (s *Service) BackupDirectory(sourcePath, backupPath string) error {
 		)
 	}
 
-	// Copy directory to backup location
-	return s.CopyDirectory(sourceAbs, backupAbs)
+	// Copy directory to backup location; links are kept as they are, e.g. the
+	// links of a dev mode installation into the checkout
+	return s.copyDirectory(sourceAbs, backupAbs, sourceAbs, SymlinkKeep)
 }
 
 // EnsureDirectoryStructure creates the Strategic Claude Basic directory structure
 func (s *Service) EnsureDirectoryStructure(targetDir string) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Create main directory
 	if err := s.CreateDirectory(strategicDir); err != nil {
@@ -309,8 +439,18 @@ func (s *Service) CopyFile(sourcePath, destPath string) error {
 		)
 	}
 
+	return s.copyFile(sourcePath, destPath, true)
+}
+
+// copyFile copies a file whose path has been validated; createParent creates
+// the destination directory, which copyTree has already done
+func (s *Service) copyFile(sourcePath, destPath string, createParent bool) error {
+	if err := s.ctx.Err(); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
+	}
+
 	// Open source file
-	sourceFile, err := os.Open(sourcePath)
+	sourceFile, err := s.fs.Open(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return models.NewFileSystemError(models.ErrorCodeDirectoryNotFound, sourcePath, err)
@@ -322,45 +462,82 @@ func (s *Service) CopyFile(sourcePath, destPath string) error {
 	}
 	defer sourceFile.Close()
 
-	// Get source file info for permissions
-	sourceInfo, err := sourceFile.Stat()
+	// Get source file info for permissions, from the open file when possible
+	var sourceInfo os.FileInfo
+	if file, ok := sourceFile.(interface{ Stat() (os.FileInfo, error) }); ok {
+		sourceInfo, err = file.Stat()
+	} else {
+		sourceInfo, err = s.fs.Stat(sourcePath)
+	}
 	if err != nil {
 		return models.NewFileSystemError(models.ErrorCodeFileSystemError, sourcePath, err)
 	}
+	if err := s.countCopy(sourcePath, sourceInfo.Size()); err != nil {
+		return err
+	}
 
 	// Create destination directory if it doesn't exist
-	destDir := filepath.Dir(destPath)
-	if err := s.CreateDirectory(destDir); err != nil {
-		return err
+	if createParent {
+		if err := s.CreateDirectory(filepath.Dir(destPath)); err != nil {
+			return err
+		}
+	}
+
+	// Files made read-only by --readonly-core are replaced rather than written to
+	if info, err := s.fs.Lstat(destPath); err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0200 == 0 {
+		if err := s.fs.Remove(destPath); err != nil {
+			return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
+		}
 	}
 
 	// Create destination file
-	destFile, err := os.Create(destPath)
+	destFile, err := s.fs.Create(destPath, config.FilePermissions)
 	if err != nil {
 		if os.IsPermission(err) {
 			return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
 		}
 		return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
 	}
-	defer destFile.Close()
 
-	// Copy file contents
-	_, err = io.Copy(destFile, sourceFile)
+	// Copy file contents, cloning them when reflinks are selected and the file
+	// system supports them; closing completes the write. When hashes are
+	// recorded, the content is hashed as it is copied.
+	hash := s.newHash()
+	if !s.reflink || !cloneOSFile(destFile, sourceFile) {
+		var source io.Reader = sourceFile
+		if hash != nil {
+			source = io.TeeReader(sourceFile, hash)
+		}
+		err = copyContent(destFile, source)
+	} else if hash != nil {
+		err = s.hashFile(sourcePath, hash)
+	}
+	if closeErr := destFile.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
 	}
+	s.recordHash(destPath, hash)
 
 	// Set permissions to match source
-	err = os.Chmod(destPath, sourceInfo.Mode())
+	err = s.fs.Chmod(destPath, sourceInfo.Mode())
 	if err != nil {
 		return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
 	}
 
-	return nil
+	return s.applyOwner(destPath)
 }
 
-// CopyDirectory copies an entire directory tree
+// CopyDirectory copies an entire directory tree; symlinks are copied
+// according to the symlink policy, see SetSymlinkPolicy
 func (s *Service) CopyDirectory(sourcePath, destPath string) error {
+	return s.copyDirectory(sourcePath, destPath, sourcePath, s.symlinkPolicy)
+}
+
+// copyDirectory copies a directory tree with the symlink policy, which keeps
+// links inside root
+func (s *Service) copyDirectory(sourcePath, destPath, root, policy string) error {
 	if sourcePath == "" || destPath == "" {
 		return models.NewAppError(
 			models.ErrorCodeValidationFailed,
@@ -370,7 +547,7 @@ func (s *Service) CopyDirectory(sourcePath, destPath string) error {
 	}
 
 	// Get source directory info
-	sourceInfo, err := os.Stat(sourcePath)
+	sourceInfo, err := s.fs.Stat(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return models.NewFileSystemError(models.ErrorCodeDirectoryNotFound, sourcePath, err)
@@ -392,73 +569,47 @@ func (s *Service) CopyDirectory(sourcePath, destPath string) error {
 	}
 
 	// Set permissions to match source
-	err = os.Chmod(destPath, sourceInfo.Mode())
+	err = s.fs.Chmod(destPath, sourceInfo.Mode())
 	if err != nil {
 		return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
 	}
 
-	// Walk through source directory
-	return filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip root directory (already created)
-		if path == sourcePath {
-			return nil
-		}
-
-		// Calculate relative path
-		relPath, err := filepath.Rel(sourcePath, path)
-		if err != nil {
-			return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
-		}
-
-		destItemPath := filepath.Join(destPath, relPath)
-
-		switch {
-		case info.IsDir():
-			// Create directory
-			err = os.MkdirAll(destItemPath, info.Mode())
-			if err != nil {
-				return models.NewFileSystemError(models.ErrorCodeFileSystemError, destItemPath, err)
-			}
-		case info.Mode()&os.ModeSymlink != 0:
-			// Handle symlinks
-			linkTarget, err := os.Readlink(path)
-			if err != nil {
-				return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
-			}
-			err = os.Symlink(linkTarget, destItemPath)
-			if err != nil {
-				return models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, destItemPath, err)
-			}
-		default:
-			// Copy regular file
-			if err := s.CopyFile(path, destItemPath); err != nil {
-				return err
-			}
-		}
-
-		return nil
+	// Copy the contents, following the symlink policy
+	return s.copyTree(sourcePath, destPath, copyState{
+		root:      root,
+		policy:    policy,
+		ancestors: []os.FileInfo{sourceInfo},
 	})
 }
 
 // CopyFrameworkFiles copies only the framework directories (core, guides, templates)
 func (s *Service) CopyFrameworkFiles(sourceDir, destDir string) error {
+	return s.CopyFrameworkDirectories(sourceDir, destDir, config.GetCoreDirectories())
+}
+
+// CopyFrameworkDirectories copies the named framework directories, replacing
+// the ones in destDir; other directories are refused
+func (s *Service) CopyFrameworkDirectories(sourceDir, destDir string, dirs []string) error {
 	frameworkDirs := config.GetCoreDirectories()
 
-	for _, dir := range frameworkDirs {
-		sourcePath := filepath.Join(sourceDir, dir)
-		destPath := filepath.Join(destDir, dir)
+	for _, dir := range dirs {
+		sourcePath, err := s.pathValidator.SafeJoin(sourceDir, dir)
+		if err != nil {
+			return err
+		}
+		destPath, err := s.pathValidator.SafeJoin(destDir, dir)
+		if err != nil {
+			return err
+		}
 
 		// Check if source directory exists
-		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+		if _, err := s.fs.Stat(sourcePath); os.IsNotExist(err) {
 			continue // Skip if source doesn't have this directory
 		}
 
-		// Remove existing framework directory if it exists
-		if _, err := os.Stat(destPath); err == nil {
+		// Remove existing framework directory if it exists; a dev mode link is
+		// removed without touching the checkout it points to
+		if _, err := s.fs.Lstat(destPath); err == nil {
 			// Only remove if it's one of the expected framework directories
 			expectedFrameworkDir := filepath.Base(destPath)
 			isFrameworkDir := false
@@ -478,7 +629,7 @@ func (s *Service) CopyFrameworkFiles(sourceDir, destDir string) error {
 			}
 
 			// Safe to remove framework directory
-			err = os.RemoveAll(destPath)
+			err = s.fs.RemoveAll(destPath)
 			if err != nil {
 				if os.IsPermission(err) {
 					return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
@@ -487,8 +638,50 @@ func (s *Service) CopyFrameworkFiles(sourceDir, destDir string) error {
 			}
 		}
 
-		// Copy the directory
-		if err := s.CopyDirectory(sourcePath, destPath); err != nil {
+		// Copy the directory; links may point to the other framework directories
+		if err := s.copyDirectory(sourcePath, destPath, sourceDir, s.symlinkPolicy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinkFrameworkFiles replaces the framework directories (core, guides, templates)
+// in destDir with symlinks to the ones in sourceDir, for dev mode installs
+func (s *Service) LinkFrameworkFiles(sourceDir, destDir string) error {
+	for _, dir := range config.GetCoreDirectories() {
+		sourcePath, err := s.pathValidator.SafeJoin(sourceDir, dir)
+		if err != nil {
+			return err
+		}
+		destPath, err := s.pathValidator.SafeJoin(destDir, dir)
+		if err != nil {
+			return err
+		}
+
+		// Skip if source doesn't have this directory
+		if info, err := s.fs.Stat(sourcePath); err != nil || !info.IsDir() {
+			continue
+		}
+
+		absSource, err := filepath.Abs(sourcePath)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeInvalidPath, sourcePath, err)
+		}
+
+		if err := s.fs.RemoveAll(destPath); err != nil {
+			if os.IsPermission(err) {
+				return models.NewFileSystemError(models.ErrorCodePermissionDenied, destPath, err)
+			}
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+		}
+
+		// The checkout lives outside the project, so the link is absolute
+		if err := s.fs.Symlink(absSource, destPath); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, destPath, err)
+		}
+		if err := s.applyOwner(destPath); err != nil {
 			return err
 		}
 	}
@@ -499,13 +692,13 @@ func (s *Service) CopyFrameworkFiles(sourceDir, destDir string) error {
 // PreserveUserContent ensures user directories are not overwritten
 func (s *Service) PreserveUserContent(targetDir string) error {
 	userDirs := config.GetUserPreservedDirectories()
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	for _, dir := range userDirs {
 		dirPath := filepath.Join(strategicDir, dir)
 
 		// Create directory if it doesn't exist (but don't overwrite)
-		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+		if _, err := s.fs.Stat(dirPath); os.IsNotExist(err) {
 			if err := s.CreateDirectory(dirPath); err != nil {
 				return err
 			}
@@ -561,12 +754,107 @@ func (s *Service) GetRelativePath(basePath, targetPath string) (string, error) {
 
 // SetFilePermissions sets proper file permissions
 func (s *Service) SetFilePermissions(path string) error {
-	return os.Chmod(path, config.FilePermissions)
+	return s.fs.Chmod(path, config.FilePermissions)
 }
 
 // SetDirectoryPermissions sets proper directory permissions
 func (s *Service) SetDirectoryPermissions(path string) error {
-	return os.Chmod(path, config.DirPermissions)
+	return s.fs.Chmod(path, config.DirPermissions)
+}
+
+// PermissionChange records a mode change made (or proposed) by NormalizePermissions
+type PermissionChange struct {
+	Path    string
+	OldMode os.FileMode
+	NewMode os.FileMode
+}
+
+// NormalizePermissions walks root and applies config.DirPermissions to directories and
+// config.FilePermissions to files, or config.ReadOnlyFilePermissions to files below
+// one of the readOnly directories. Executables and scripts in hook directories keep
+// their execute bits. Symlinks are not followed. When apply is false the changes are
+// only reported.
+func (s *Service) NormalizePermissions(root string, apply bool, readOnly ...string) ([]PermissionChange, error) {
+	var changes []PermissionChange
+
+	if _, err := s.fs.Lstat(root); os.IsNotExist(err) {
+		return changes, nil
+	}
+
+	err := fsys.Walk(s.fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 || (!info.IsDir() && !info.Mode().IsRegular()) {
+			return nil
+		}
+
+		current := info.Mode().Perm()
+		wanted := expectedMode(path, info)
+		if !info.IsDir() && withinAny(path, readOnly) {
+			wanted &^= 0222
+		}
+		if current == wanted {
+			return nil
+		}
+
+		if apply {
+			if err := s.fs.Chmod(path, wanted); err != nil {
+				if os.IsPermission(err) {
+					return models.NewFileSystemError(models.ErrorCodePermissionDenied, path, err)
+				}
+				return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+			}
+		}
+
+		changes = append(changes, PermissionChange{Path: path, OldMode: current, NewMode: wanted})
+		return nil
+	})
+	if err != nil {
+		return changes, err
+	}
+
+	return changes, nil
+}
+
+// expectedMode returns the normalized permission bits for a directory or regular file
+func expectedMode(path string, info os.FileInfo) os.FileMode {
+	if info.IsDir() {
+		return config.DirPermissions
+	}
+
+	if info.Mode().Perm()&0111 != 0 || isHookScript(path) {
+		return config.FilePermissions | 0111
+	}
+
+	return config.FilePermissions
+}
+
+// MakeReadOnly normalizes the permissions below root and removes the write bits of
+// its files, so editors refuse to change them. Directories stay writable, so updates
+// can still replace them; a dev mode link is not followed.
+func (s *Service) MakeReadOnly(root string) error {
+	_, err := s.NormalizePermissions(root, true, root)
+	return err
+}
+
+// withinAny reports whether path is one of dirs or lies below one of them
+func withinAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHookScript reports whether path is a script inside a hooks directory
+func isHookScript(path string) bool {
+	if filepath.Base(filepath.Dir(path)) != config.HooksDir && !strings.Contains(filepath.ToSlash(path), "/"+config.HooksDir+"/") {
+		return false
+	}
+
+	return slices.Contains(config.GetHookScriptExtensions(), filepath.Ext(path))
 }
 
 // CheckWritePermission checks if we have write permission to a directory
@@ -576,15 +864,25 @@ func (s *Service) CheckWritePermission(path string) error {
 
 // Helper functions
 
-// GetBackupPath generates a backup path with timestamp
-func (s *Service) GetBackupPath(targetDir string) string {
-	timestamp := time.Now().Format("20060102-150405")
-	backupName := fmt.Sprintf("%s%s", config.BackupDirPrefix, timestamp)
-	return filepath.Join(targetDir, backupName)
+// GetBackupPath generates a timestamped backup path in the project's backups directory
+// that does not collide with an existing backup. The label, see config.BackupLabel,
+// follows the timestamp.
+func (s *Service) GetBackupPath(targetDir, label string) string {
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	return filepath.Join(backupsRoot, config.UniqueBackupName(backupsRoot, config.BackupDirPrefix, label))
 }
 
-// ApplyGitignoreTemplate applies a gitignore template to a target location
-func (s *Service) ApplyGitignoreTemplate(templatePath, targetPath string) error {
+// legacyIgnoreHeader starts the ignore files older versions merged entries into,
+// above the user's lines and the entries
+const legacyIgnoreHeader = "# Strategic Claude Basic"
+
+// ApplyIgnoreTemplate merges the entries of an ignore template into the ignore
+// file at targetPath: a .gitignore, or the ignore file of another tool such as
+// .dockerignore, which use the same one-pattern-per-line format. The entries are
+// kept in a managed block, see ApplyManagedBlock, and entries the user already
+// lists outside it are not repeated. Entries merged by older versions, below
+// the "# Strategic Claude Basic entries" header, move into the block.
+func (s *Service) ApplyIgnoreTemplate(templatePath, targetPath string) error {
 	if templatePath == "" || targetPath == "" {
 		return models.NewAppError(
 			models.ErrorCodeValidationFailed,
@@ -594,30 +892,43 @@ func (s *Service) ApplyGitignoreTemplate(templatePath, targetPath string) error
 	}
 
 	// Check if template exists
-	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-		utils.DisplayWarning(fmt.Sprintf("Gitignore template %s not found, skipping", templatePath))
+	if _, err := s.fs.Stat(templatePath); os.IsNotExist(err) {
+		utils.DisplayWarning(fmt.Sprintf("Ignore template %s not found, skipping", templatePath))
 		return nil
 	}
 
 	// Read template content
 	templateContent, err := s.readFileLines(templatePath)
 	if err != nil {
-		return fmt.Errorf("failed to read gitignore template: %w", err)
+		return fmt.Errorf("failed to read ignore template: %w", err)
 	}
 
-	// Check if target .gitignore exists
-	if _, err := os.Stat(targetPath); err == nil {
-		// File exists, merge content
-		return s.mergeGitignoreContent(targetPath, templateContent)
+	if _, err := s.removeLegacyIgnoreEntries(targetPath, templateContent); err != nil {
+		return err
 	}
 
-	// File doesn't exist, create new one
-	return s.writeGitignoreContent(targetPath, templateContent)
+	// The user's entries are the lines outside the block
+	existing, err := s.readIfExists(targetPath)
+	if err != nil {
+		return err
+	}
+	userContent, _, _, err := SplitManagedBlock(string(existing), HashCommentBlock)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeManagedBlockConflict, targetPath, err)
+	}
+
+	entries := deduplicateIgnoreLines(strings.Split(userContent, "\n"), templateContent)
+	if len(entries) == 0 {
+		_, err = s.RemoveManagedBlock(targetPath, HashCommentBlock)
+		return err
+	}
+	_, err = s.ApplyManagedBlock(targetPath, HashCommentBlock, strings.Join(entries, "\n"))
+	return err
 }
 
 // readFileLines reads a file and returns its lines
 func (s *Service) readFileLines(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
+	file, err := s.fs.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -636,78 +947,92 @@ func (s *Service) readFileLines(filePath string) ([]string, error) {
 	return lines, nil
 }
 
-// mergeGitignoreContent merges template content with existing .gitignore
-func (s *Service) mergeGitignoreContent(targetPath string, templateLines []string) error {
-	// Read existing content
-	existingLines, err := s.readFileLines(targetPath)
+// RemoveIgnoreEntries removes the managed block from the ignore file at
+// targetPath. In files written by older versions, which have no block, the
+// header and the given entries are removed instead, along with the backup taken
+// when entries were merged into them. The file is deleted when nothing else is
+// left. It reports whether the file was changed.
+func (s *Service) RemoveIgnoreEntries(targetPath string, entries []string) (bool, error) {
+	removed, err := s.RemoveManagedBlock(targetPath, HashCommentBlock)
 	if err != nil {
-		return fmt.Errorf("failed to read existing .gitignore: %w", err)
+		return false, err
 	}
-
-	// Create backup of existing .gitignore
-	backupPath := targetPath + ".backup"
-	if err := s.CopyFile(targetPath, backupPath); err != nil {
-		utils.DisplayWarning(fmt.Sprintf("Failed to create backup of .gitignore: %v", err))
-	}
-
-	// Merge content with deduplication
-	mergedLines := s.deduplicateGitignoreLines(existingLines, templateLines)
-
-	// Write merged content
-	return s.writeGitignoreContent(targetPath, mergedLines)
+	legacyRemoved, err := s.removeLegacyIgnoreEntries(targetPath, entries)
+	return removed || legacyRemoved, err
 }
 
-// writeGitignoreContent writes gitignore content to target file
-func (s *Service) writeGitignoreContent(targetPath string, lines []string) error {
-	// Ensure target directory exists
-	targetDir := filepath.Dir(targetPath)
-	if err := s.CreateDirectory(targetDir); err != nil {
-		return fmt.Errorf("failed to create target directory: %w", err)
+// removeLegacyIgnoreEntries removes the header of older versions and entries
+// from the ignore file at targetPath, along with its backup, when the file has
+// the header. The file is deleted when nothing else is left. It reports whether
+// the file was changed.
+func (s *Service) removeLegacyIgnoreEntries(targetPath string, entries []string) (bool, error) {
+	name := filepath.Base(targetPath)
+	lines, err := s.readFileLines(targetPath)
+	if os.IsNotExist(err) {
+		return false, nil
 	}
-
-	file, err := os.Create(targetPath)
 	if err != nil {
-		return fmt.Errorf("failed to create .gitignore file: %w", err)
+		return false, fmt.Errorf("failed to read existing %s: %w", name, err)
+	}
+	if !slices.ContainsFunc(lines, func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), legacyIgnoreHeader)
+	}) {
+		return false, nil
 	}
-	defer file.Close()
 
-	// Write Strategic Claude Basic header
-	if _, err := file.WriteString("# Strategic Claude Basic entries\n"); err != nil {
-		return fmt.Errorf("failed to write header to .gitignore: %w", err)
+	remove := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		remove[strings.TrimSpace(entry)] = true
 	}
 
-	// Write content
+	var kept []string
+	hasContent := false
 	for _, line := range lines {
-		if _, err := file.WriteString(line + "\n"); err != nil {
-			return fmt.Errorf("failed to write line to .gitignore: %w", err)
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, legacyIgnoreHeader) || (trimmed != "" && remove[trimmed]) {
+			continue
+		}
+		kept = append(kept, line)
+		if trimmed != "" {
+			hasContent = true
 		}
 	}
 
-	return nil
+	if err := s.fs.Remove(targetPath + ".backup"); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to remove %s backup: %w", name, err)
+	}
+	if !hasContent {
+		if err := s.fs.Remove(targetPath); err != nil {
+			return false, fmt.Errorf("failed to remove %s file: %w", name, err)
+		}
+		return true, nil
+	}
+
+	var content strings.Builder
+	for _, line := range kept {
+		content.WriteString(line + "\n")
+	}
+	if err := s.fs.WriteFile(targetPath, []byte(content.String()), config.FilePermissions); err != nil {
+		return false, fmt.Errorf("failed to write %s file: %w", name, err)
+	}
+	return true, nil
 }
 
-// deduplicateGitignoreLines merges and deduplicates gitignore lines
-func (s *Service) deduplicateGitignoreLines(existing, template []string) []string {
+// deduplicateIgnoreLines returns the template lines that are not blank and not
+// already among the existing lines, each once
+func deduplicateIgnoreLines(existing, template []string) []string {
 	seen := make(map[string]bool)
-	var result []string
-
-	// Add existing lines first (preserve user content order)
 	for _, line := range existing {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" && !seen[trimmed] && !strings.HasPrefix(trimmed, "# Strategic Claude Basic") {
-			result = append(result, line)
-			seen[trimmed] = true
-		}
+		seen[strings.TrimSpace(line)] = true
 	}
 
-	// Add template lines that aren't duplicates
+	var result []string
 	for _, line := range template {
 		trimmed := strings.TrimSpace(line)
 		if trimmed != "" && !seen[trimmed] {
-			result = append(result, line)
+			result = append(result, trimmed)
 			seen[trimmed] = true
 		}
 	}
-
 	return result
 }
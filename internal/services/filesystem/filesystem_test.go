@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/fsys"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 )
 
@@ -92,6 +93,40 @@ func TestService_CreateDirectory_ExistingFile(t *testing.T) {
 	}
 }
 
+func TestService_RemoveStrategicClaudeBasicExcept(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	for _, dir := range []string{config.CoreDir, config.PlanDir, config.ResearchDir} {
+		if err := os.MkdirAll(filepath.Join(strategicDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(strategicDir, dir, "file.md"), []byte("test"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	if err := service.RemoveStrategicClaudeBasicExcept(targetDir, []string{config.PlanDir}); err != nil {
+		t.Fatalf("RemoveStrategicClaudeBasicExcept() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(strategicDir, config.PlanDir, "file.md")); err != nil {
+		t.Errorf("kept directory was removed: %v", err)
+	}
+	for _, dir := range []string{config.CoreDir, config.ResearchDir} {
+		if _, err := os.Stat(filepath.Join(strategicDir, dir)); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed, err = %v", dir, err)
+		}
+	}
+
+	// Nothing to keep removes the whole directory
+	if err := service.RemoveStrategicClaudeBasicExcept(targetDir, nil); err != nil {
+		t.Fatalf("RemoveStrategicClaudeBasicExcept() error = %v", err)
+	}
+	if _, err := os.Stat(strategicDir); !os.IsNotExist(err) {
+		t.Errorf("framework directory was not removed, err = %v", err)
+	}
+}
+
 func TestService_RemoveStrategicClaudeBasic(t *testing.T) {
 	service := New()
 	tempDir := t.TempDir()
@@ -225,7 +260,7 @@ func TestService_RemoveBackup(t *testing.T) {
 			name: "valid backup removal",
 			setup: func() (string, string) {
 				backupName := config.BackupDirPrefix + "20240101-120000"
-				backupPath := filepath.Join(tempDir, backupName)
+				backupPath := filepath.Join(config.GetBackupsRoot(tempDir), backupName)
 				_ = os.MkdirAll(backupPath, 0755)
 				_ = os.WriteFile(filepath.Join(backupPath, "test.txt"), []byte("backup content"), 0644)
 				return tempDir, backupName
@@ -454,6 +489,160 @@ func TestService_CopyDirectory(t *testing.T) {
 	}
 }
 
+func TestService_CopyDirectory_Symlinks(t *testing.T) {
+	tempDir := t.TempDir()
+	outside := filepath.Join(tempDir, "outside.txt")
+	sourceDir := filepath.Join(tempDir, "source")
+	if err := os.MkdirAll(filepath.Join(sourceDir, "subdir"), 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	for _, file := range []string{outside, filepath.Join(sourceDir, "subdir", "file.txt")} {
+		if err := os.WriteFile(file, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", file, err)
+		}
+	}
+	links := map[string]string{
+		"absolute-inside":  filepath.Join(sourceDir, "subdir", "file.txt"),
+		"relative-inside":  "subdir/file.txt",
+		"absolute-outside": outside,
+		"escaping":         "../outside.txt",
+		"directory":        "subdir",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(sourceDir, name)); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+	}
+
+	t.Run("rewrite-relative", func(t *testing.T) {
+		// Links leading out of the copied tree are refused
+		err := New().CopyDirectory(sourceDir, filepath.Join(t.TempDir(), "dest"))
+		if !models.IsErrorCode(err, models.ErrorCodeSymlinkInvalid) {
+			t.Errorf("CopyDirectory() error = %v, want %s", err, models.ErrorCodeSymlinkInvalid)
+		}
+
+		insideDir := filepath.Join(t.TempDir(), "inside")
+		if err := New().CopyDirectory(filepath.Join(sourceDir, "subdir"), filepath.Join(insideDir, "subdir")); err != nil {
+			t.Fatalf("CopyDirectory failed: %v", err)
+		}
+		for name, target := range map[string]string{
+			"absolute-inside": filepath.Join(insideDir, "subdir", "file.txt"),
+			"relative-inside": "subdir/file.txt",
+			"directory":       "subdir",
+		} {
+			if err := os.Symlink(target, filepath.Join(insideDir, name)); err != nil {
+				t.Fatalf("Failed to create symlink: %v", err)
+			}
+		}
+		destDir := filepath.Join(t.TempDir(), "dest")
+		if err := New().CopyDirectory(insideDir, destDir); err != nil {
+			t.Fatalf("CopyDirectory failed: %v", err)
+		}
+		want := map[string]string{
+			"absolute-inside": filepath.Join("subdir", "file.txt"),
+			"relative-inside": "subdir/file.txt",
+			"directory":       "subdir",
+		}
+		for name, wantTarget := range want {
+			if target, err := os.Readlink(filepath.Join(destDir, name)); err != nil || target != wantTarget {
+				t.Errorf("%s links to %q (%v), want %q", name, target, err, wantTarget)
+			}
+		}
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		service := New()
+		if err := service.SetSymlinkPolicy(SymlinkKeep); err != nil {
+			t.Fatalf("SetSymlinkPolicy failed: %v", err)
+		}
+		destDir := filepath.Join(t.TempDir(), "dest")
+		if err := service.CopyDirectory(sourceDir, destDir); err != nil {
+			t.Fatalf("CopyDirectory failed: %v", err)
+		}
+		for name, wantTarget := range links {
+			if target, err := os.Readlink(filepath.Join(destDir, name)); err != nil || target != wantTarget {
+				t.Errorf("%s links to %q (%v), want %q", name, target, err, wantTarget)
+			}
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		service := New()
+		if err := service.SetSymlinkPolicy(SymlinkSkip); err != nil {
+			t.Fatalf("SetSymlinkPolicy failed: %v", err)
+		}
+		destDir := filepath.Join(t.TempDir(), "dest")
+		if err := service.CopyDirectory(sourceDir, destDir); err != nil {
+			t.Fatalf("CopyDirectory failed: %v", err)
+		}
+		for name := range links {
+			if _, err := os.Lstat(filepath.Join(destDir, name)); !os.IsNotExist(err) {
+				t.Errorf("%s was copied", name)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(destDir, "subdir", "file.txt")); err != nil {
+			t.Errorf("Regular file was not copied: %v", err)
+		}
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		service := New()
+		if err := service.SetSymlinkPolicy(SymlinkFollow); err != nil {
+			t.Fatalf("SetSymlinkPolicy failed: %v", err)
+		}
+		destDir := filepath.Join(t.TempDir(), "dest")
+		if err := service.CopyDirectory(sourceDir, destDir); err != nil {
+			t.Fatalf("CopyDirectory failed: %v", err)
+		}
+		for _, path := range []string{"escaping", "absolute-inside", filepath.Join("directory", "file.txt")} {
+			info, err := os.Lstat(filepath.Join(destDir, path))
+			if err != nil || !info.Mode().IsRegular() {
+				t.Errorf("%s is not a copied file: %v", path, err)
+			}
+		}
+	})
+
+	if err := New().SetSymlinkPolicy("hardlink"); err == nil {
+		t.Error("SetSymlinkPolicy accepted an unknown policy")
+	}
+}
+
+func TestService_CopyDirectory_SymlinkLoop(t *testing.T) {
+	sourceDir := filepath.Join(t.TempDir(), "source")
+	if err := os.MkdirAll(filepath.Join(sourceDir, "a", "b"), 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	if err := os.Symlink("..", filepath.Join(sourceDir, "a", "b", "loop")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	service := New()
+	if err := service.SetSymlinkPolicy(SymlinkFollow); err != nil {
+		t.Fatalf("SetSymlinkPolicy failed: %v", err)
+	}
+	err := service.CopyDirectory(sourceDir, filepath.Join(t.TempDir(), "dest"))
+	if !models.IsErrorCode(err, models.ErrorCodeSymlinkLoop) {
+		t.Errorf("CopyDirectory() error = %v, want %s", err, models.ErrorCodeSymlinkLoop)
+	}
+
+	// Without inode information, the number of followed links stops the copy
+	memFS := fsys.NewMemory()
+	if err := memFS.MkdirAll("/src/a", 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	if err := memFS.Symlink("..", "/src/a/loop"); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	service = NewWithFS(memFS)
+	if err := service.SetSymlinkPolicy(SymlinkFollow); err != nil {
+		t.Fatalf("SetSymlinkPolicy failed: %v", err)
+	}
+	err = service.CopyDirectory("/src", "/dest")
+	if !models.IsErrorCode(err, models.ErrorCodeSymlinkLoop) {
+		t.Errorf("CopyDirectory() on memory error = %v, want %s", err, models.ErrorCodeSymlinkLoop)
+	}
+}
+
 func TestService_CopyFrameworkFiles(t *testing.T) {
 	service := New()
 	tempDir := t.TempDir()
@@ -501,6 +690,97 @@ func TestService_CopyFrameworkFiles(t *testing.T) {
 	}
 }
 
+func TestService_CopyFrameworkFiles_Overlay(t *testing.T) {
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+	for _, dir := range config.GetCoreDirectories() {
+		if err := os.MkdirAll(filepath.Join(sourceDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create framework directory %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(sourceDir, dir, "test.txt"), []byte(dir+" content"), 0644); err != nil {
+			t.Fatalf("Failed to create test file in %s: %v", dir, err)
+		}
+	}
+	// An existing framework file is replaced in the overlay only
+	if err := os.MkdirAll(filepath.Join(destDir, config.CoreDir), 0755); err != nil {
+		t.Fatalf("Failed to create dest directory: %v", err)
+	}
+	oldFile := filepath.Join(destDir, config.CoreDir, "old.txt")
+	if err := os.WriteFile(oldFile, []byte("old"), 0644); err != nil {
+		t.Fatalf("Failed to create old file: %v", err)
+	}
+
+	overlay := fsys.NewOverlay(fsys.OS())
+	service := NewWithFS(overlay)
+	if err := service.CopyFrameworkFiles(sourceDir, destDir); err != nil {
+		t.Fatalf("CopyFrameworkFiles() error = %v", err)
+	}
+
+	for _, dir := range config.GetCoreDirectories() {
+		data, err := overlay.ReadFile(filepath.Join(destDir, dir, "test.txt"))
+		if err != nil || string(data) != dir+" content" {
+			t.Errorf("overlay %s/test.txt = %q, %v, want %q", dir, data, err, dir+" content")
+		}
+		if _, err := os.Stat(filepath.Join(destDir, dir, "test.txt")); !os.IsNotExist(err) {
+			t.Errorf("%s/test.txt was written to disk: %v", dir, err)
+		}
+	}
+	if _, err := overlay.Stat(oldFile); !os.IsNotExist(err) {
+		t.Errorf("overlay still has the replaced framework file: %v", err)
+	}
+	if _, err := os.Stat(oldFile); err != nil {
+		t.Errorf("replaced framework file was removed from disk: %v", err)
+	}
+}
+
+func TestService_LinkFrameworkFiles(t *testing.T) {
+	service := New()
+	tempDir := t.TempDir()
+
+	sourceDir := filepath.Join(tempDir, "checkout")
+	destDir := filepath.Join(tempDir, "dest")
+	for _, dir := range config.GetCoreDirectories() {
+		if err := os.MkdirAll(filepath.Join(sourceDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create framework directory %s: %v", dir, err)
+		}
+		// Existing copies are replaced by links
+		if err := os.MkdirAll(filepath.Join(destDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create existing directory %s: %v", dir, err)
+		}
+	}
+
+	if err := service.LinkFrameworkFiles(sourceDir, destDir); err != nil {
+		t.Fatalf("LinkFrameworkFiles failed: %v", err)
+	}
+
+	for _, dir := range config.GetCoreDirectories() {
+		target, err := os.Readlink(filepath.Join(destDir, dir))
+		if err != nil {
+			t.Errorf("%s is not a symlink: %v", dir, err)
+			continue
+		}
+		if target != filepath.Join(sourceDir, dir) {
+			t.Errorf("%s links to %s, want %s", dir, target, filepath.Join(sourceDir, dir))
+		}
+	}
+
+	// Copying over the links must replace them without writing into the checkout
+	if err := os.WriteFile(filepath.Join(sourceDir, config.CoreDir, "edit.md"), []byte("edit"), 0644); err != nil {
+		t.Fatalf("Failed to write checkout file: %v", err)
+	}
+	if err := service.CopyFrameworkFiles(sourceDir, destDir); err != nil {
+		t.Fatalf("CopyFrameworkFiles failed: %v", err)
+	}
+	info, err := os.Lstat(filepath.Join(destDir, config.CoreDir))
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("core should be a copied directory after CopyFrameworkFiles, err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sourceDir, config.CoreDir, "edit.md")); err != nil {
+		t.Errorf("checkout file was removed: %v", err)
+	}
+}
+
 func TestService_IsSubPath(t *testing.T) {
 	service := New()
 
@@ -619,11 +899,11 @@ func TestService_GetBackupPath(t *testing.T) {
 	service := New()
 	targetDir := "/test/target"
 
-	backupPath := service.GetBackupPath(targetDir)
+	backupPath := service.GetBackupPath(targetDir, "")
 
-	// Should be under target directory
-	if !strings.HasPrefix(backupPath, targetDir) {
-		t.Errorf("Backup path %s should be under target directory %s", backupPath, targetDir)
+	// Should be in the backups directory of the target
+	if filepath.Dir(backupPath) != filepath.Join(targetDir, config.BackupsDir) {
+		t.Errorf("Backup path %s should be in %s", backupPath, filepath.Join(targetDir, config.BackupsDir))
 	}
 
 	// Should contain backup prefix
@@ -638,6 +918,144 @@ func TestService_GetBackupPath(t *testing.T) {
 	}
 }
 
+func TestService_GetBackupPath_Collision(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+	sourceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sourceDir, "file.md"), []byte("content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two backups within the same second must both succeed
+	first := service.GetBackupPath(targetDir, "")
+	if err := service.BackupDirectory(sourceDir, first); err != nil {
+		t.Fatalf("BackupDirectory() error = %v", err)
+	}
+
+	second := service.GetBackupPath(targetDir, "")
+	if second == first {
+		t.Fatalf("GetBackupPath() = %s, want a path not taken by an existing backup", second)
+	}
+	if err := service.BackupDirectory(sourceDir, second); err != nil {
+		t.Errorf("BackupDirectory() error = %v", err)
+	}
+}
+
+func TestProbeCapabilities(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// A directory that does not exist yet is probed through its parent
+	capabilities, err := ProbeCapabilities(filepath.Join(tempDir, "new", "project"))
+	if err != nil {
+		t.Fatalf("ProbeCapabilities failed: %v", err)
+	}
+	if capabilities.Dir != tempDir {
+		t.Errorf("Dir = %s, want %s", capabilities.Dir, tempDir)
+	}
+	if !capabilities.Symlinks || !capabilities.Hardlinks {
+		t.Errorf("Capabilities = %s, want symlinks and hardlinks on the test file system", capabilities)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Probe left %d entries behind (%v)", len(entries), err)
+	}
+}
+
+func TestService_CopyFile_Reflink(t *testing.T) {
+	tempDir := t.TempDir()
+	source := filepath.Join(tempDir, "source.txt")
+	if err := os.WriteFile(source, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	// Copies where the file system cannot clone
+	service := New()
+	service.SetCopyStrategy(models.CopyStrategyReflink)
+	dest := filepath.Join(tempDir, "dest.txt")
+	if err := service.CopyFile(source, dest); err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	if content, err := os.ReadFile(dest); err != nil || string(content) != "content" {
+		t.Errorf("Copied content = %q, %v", content, err)
+	}
+}
+
+func TestService_RecordHashes(t *testing.T) {
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	if err := os.MkdirAll(filepath.Join(sourceDir, "subdir"), 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceDir, "subdir", "file.txt"), []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create source file: %v", err)
+	}
+
+	service := New()
+	if err := service.CopyDirectory(sourceDir, filepath.Join(tempDir, "before")); err != nil {
+		t.Fatalf("CopyDirectory failed: %v", err)
+	}
+	if hashes := service.Hashes(); len(hashes) != 0 {
+		t.Errorf("Hashes() = %v before RecordHashes", hashes)
+	}
+
+	service.RecordHashes()
+	destDir := filepath.Join(tempDir, "dest")
+	if err := service.CopyDirectory(sourceDir, destDir); err != nil {
+		t.Fatalf("CopyDirectory failed: %v", err)
+	}
+	want := models.FileHash{SHA256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", Size: 7}
+	hashes := service.Hashes()
+	if got := hashes[filepath.Join(destDir, "subdir", "file.txt")]; got != want || len(hashes) != 1 {
+		t.Errorf("Hashes() = %v, want %v for the copied file", hashes, want)
+	}
+}
+
+func TestService_SetCopyLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(sourceDir, name), []byte("0123456789"), 0644); err != nil {
+			t.Fatalf("Failed to create source file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		maxFiles int
+		wantErr  bool
+	}{
+		{"no limit", 0, 0, false},
+		{"within limits", 30, 3, false},
+		{"too many bytes", 25, 0, true},
+		{"too many files", 0, 2, true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New()
+			service.SetCopyLimit(tt.maxBytes, tt.maxFiles)
+			err := service.CopyDirectory(sourceDir, filepath.Join(tempDir, fmt.Sprintf("dest%d", i)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CopyDirectory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !models.IsErrorCode(err, models.ErrorCodeTemplateTooLarge) {
+					t.Errorf("CopyDirectory() error = %v, want %s", err, models.ErrorCodeTemplateTooLarge)
+				}
+				return
+			}
+			if totals := service.CopyTotals(); totals != (models.CopyTotals{Bytes: 30, Files: 3}) {
+				t.Errorf("CopyTotals() = %+v, want 30 bytes in 3 files", totals)
+			}
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkService_CreateDirectory(b *testing.B) {
 	service := New()
@@ -673,3 +1091,30 @@ func BenchmarkService_CopyFile(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkService_CopyDirectory(b *testing.B) {
+	service := New()
+	tempDir := b.TempDir()
+
+	// Create a source tree of 10 directories with 20 files each
+	sourceDir := filepath.Join(tempDir, "source")
+	content := []byte(strings.Repeat("test content ", 1000))
+	for d := 0; d < 10; d++ {
+		dir := filepath.Join(sourceDir, fmt.Sprintf("dir%d", d))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatalf("Failed to create source directory: %v", err)
+		}
+		for f := 0; f < 20; f++ {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.txt", f)), content, 0644); err != nil {
+				b.Fatalf("Failed to create source file: %v", err)
+			}
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := service.CopyDirectory(sourceDir, filepath.Join(tempDir, fmt.Sprintf("dest%d", i))); err != nil {
+			b.Fatalf("CopyDirectory failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package filesystem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"maps"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// countingHash is a SHA-256 that also counts the bytes written to it
+type countingHash struct {
+	hash.Hash
+	size int64
+}
+
+func (h *countingHash) Write(p []byte) (int, error) {
+	n, err := h.Hash.Write(p)
+	h.size += int64(n)
+	return n, err
+}
+
+// RecordHashes makes CopyFile, and so CopyDirectory, record the SHA-256 of
+// every file it writes from now on, computed while the content is copied, so
+// the install manifest does not read the files again
+func (s *Service) RecordHashes() {
+	s.hashesMu.Lock()
+	defer s.hashesMu.Unlock()
+	s.hashes = make(map[string]models.FileHash)
+}
+
+// Hashes returns the hashes recorded since RecordHashes by destination path
+func (s *Service) Hashes() map[string]models.FileHash {
+	s.hashesMu.Lock()
+	defer s.hashesMu.Unlock()
+	return maps.Clone(s.hashes)
+}
+
+// newHash returns the hash to feed a copied file's content, or nil when hashes are not recorded
+func (s *Service) newHash() *countingHash {
+	s.hashesMu.Lock()
+	defer s.hashesMu.Unlock()
+	if s.hashes == nil {
+		return nil
+	}
+	return &countingHash{Hash: sha256.New()}
+}
+
+// recordHash stores the hash of a file copied to path
+func (s *Service) recordHash(path string, h *countingHash) {
+	if h == nil {
+		return
+	}
+	s.hashesMu.Lock()
+	defer s.hashesMu.Unlock()
+	if s.hashes != nil {
+		s.hashes[path] = models.FileHash{SHA256: hex.EncodeToString(h.Sum(nil)), Size: h.size}
+	}
+}
+
+// hashFile feeds the content of path to h, for files cloned instead of copied
+func (s *Service) hashFile(path string, h *countingHash) error {
+	file, err := s.fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return copyContent(h, file)
+}
@@ -0,0 +1,55 @@
+package filesystem
+
+import (
+	"fmt"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// copyLimit is the most CopyFile copies before it fails; 0 leaves a limit off
+type copyLimit struct {
+	bytes int64
+	files int
+}
+
+// SetCopyLimit resets the copy totals and makes CopyFile, and so
+// CopyDirectory, fail with ErrorCodeTemplateTooLarge before a file would take
+// them above maxBytes or maxFiles; 0 leaves a limit off
+func (s *Service) SetCopyLimit(maxBytes int64, maxFiles int) {
+	s.totalsMu.Lock()
+	defer s.totalsMu.Unlock()
+	s.limit = copyLimit{bytes: maxBytes, files: maxFiles}
+	s.totals = models.CopyTotals{}
+}
+
+// CopyTotals returns the bytes and files copied since SetCopyLimit
+func (s *Service) CopyTotals() models.CopyTotals {
+	s.totalsMu.Lock()
+	defer s.totalsMu.Unlock()
+	return s.totals
+}
+
+// countCopy adds a file of size bytes to the copy totals, or returns an error
+// when that would exceed the copy limit
+func (s *Service) countCopy(sourcePath string, size int64) error {
+	s.totalsMu.Lock()
+	defer s.totalsMu.Unlock()
+	totals := models.CopyTotals{Bytes: s.totals.Bytes + size, Files: s.totals.Files + 1}
+	if s.limit.bytes > 0 && totals.Bytes > s.limit.bytes {
+		return models.NewAppError(
+			models.ErrorCodeTemplateTooLarge,
+			fmt.Sprintf("Copying %s would exceed the limit of %s", sourcePath, utils.FormatSize(s.limit.bytes)),
+			nil,
+		).WithContext("limit_bytes", s.limit.bytes)
+	}
+	if s.limit.files > 0 && totals.Files > s.limit.files {
+		return models.NewAppError(
+			models.ErrorCodeTemplateTooLarge,
+			fmt.Sprintf("Copying %s would exceed the limit of %d files", sourcePath, s.limit.files),
+			nil,
+		).WithContext("limit_files", s.limit.files)
+	}
+	s.totals = totals
+	return nil
+}
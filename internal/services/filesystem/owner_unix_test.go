@@ -0,0 +1,66 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestService_SetOwner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Changing ownership requires root, skipping")
+	}
+
+	const uid, gid = 4242, 4343
+	service := New()
+	service.SetOwner(uid, gid)
+
+	sourceDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sourceDir, "core", "agents"), 0755); err != nil {
+		t.Fatalf("Failed to create source tree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceDir, "core", "agents", "agent.md"), []byte("agent"), 0644); err != nil {
+		t.Fatalf("Failed to write source file: %v", err)
+	}
+
+	destDir := filepath.Join(t.TempDir(), "dest")
+	if err := service.CopyDirectory(sourceDir, destDir); err != nil {
+		t.Fatalf("CopyDirectory() error = %v", err)
+	}
+
+	// Files written outside the service are fixed up by ChownTree
+	external := filepath.Join(destDir, "external.txt")
+	if err := os.WriteFile(external, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write external file: %v", err)
+	}
+	if err := service.ChownTree(destDir); err != nil {
+		t.Fatalf("ChownTree() error = %v", err)
+	}
+
+	for _, path := range []string{
+		destDir,
+		filepath.Join(destDir, "core", "agents"),
+		filepath.Join(destDir, "core", "agents", "agent.md"),
+		external,
+	} {
+		info, err := os.Lstat(path)
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		stat := info.Sys().(*syscall.Stat_t)
+		if stat.Uid != uid || stat.Gid != gid {
+			t.Errorf("%s owned by %d:%d, want %d:%d", path, stat.Uid, stat.Gid, uid, gid)
+		}
+	}
+}
+
+func TestService_ChownTree_NoOwner(t *testing.T) {
+	service := New()
+
+	if err := service.ChownTree(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("ChownTree() without owner error = %v", err)
+	}
+}
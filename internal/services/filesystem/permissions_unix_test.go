@@ -0,0 +1,181 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestService_NormalizePermissions(t *testing.T) {
+	root := t.TempDir()
+
+	paths := map[string]os.FileMode{
+		"core":                     0700,
+		"core/hooks":               0755,
+		"core/hooks/check.py":      0600,
+		"core/hooks/notes.md":      0666,
+		"core/agents":              0755,
+		"core/agents/agent.md":     0644,
+		"core/agents/run.sh":       0700,
+		"core/commands":            0775,
+		"core/commands/command.md": 0600,
+		"core/commands/readme.txt": 0644,
+	}
+
+	for _, dir := range []string{"core", "core/hooks", "core/agents", "core/commands"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+	for path := range paths {
+		fullPath := filepath.Join(root, path)
+		if filepath.Ext(path) != "" {
+			if err := os.WriteFile(fullPath, []byte("content"), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+		}
+	}
+	for path, mode := range paths {
+		if err := os.Chmod(filepath.Join(root, path), mode); err != nil {
+			t.Fatalf("Failed to chmod %s: %v", path, err)
+		}
+	}
+	if err := os.Symlink("agents/agent.md", filepath.Join(root, "core", "link.md")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	want := map[string]os.FileMode{
+		"core":                     0755,
+		"core/hooks/check.py":      0755,
+		"core/hooks/notes.md":      0644,
+		"core/agents/run.sh":       0755,
+		"core/commands":            0755,
+		"core/commands/command.md": 0644,
+	}
+
+	service := New()
+
+	// Report only
+	changes, err := service.NormalizePermissions(root, false)
+	if err != nil {
+		t.Fatalf("NormalizePermissions() error = %v", err)
+	}
+	if len(changes) != len(want) {
+		t.Errorf("NormalizePermissions() reported %d changes, want %d: %v", len(changes), len(want), changes)
+	}
+	for _, change := range changes {
+		relPath, _ := filepath.Rel(root, change.Path)
+		if change.NewMode != want[relPath] {
+			t.Errorf("NormalizePermissions() %s NewMode = %04o, want %04o", relPath, change.NewMode, want[relPath])
+		}
+		if change.OldMode != paths[relPath] {
+			t.Errorf("NormalizePermissions() %s OldMode = %04o, want %04o", relPath, change.OldMode, paths[relPath])
+		}
+	}
+	info, err := os.Stat(filepath.Join(root, "core", "hooks", "check.py"))
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("NormalizePermissions() without apply changed mode to %04o", info.Mode().Perm())
+	}
+
+	// Apply
+	if _, err := service.NormalizePermissions(root, true); err != nil {
+		t.Fatalf("NormalizePermissions() error = %v", err)
+	}
+	for path, mode := range want {
+		info, err := os.Stat(filepath.Join(root, path))
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("NormalizePermissions() %s mode = %04o, want %04o", path, info.Mode().Perm(), mode)
+		}
+	}
+
+	// A second pass finds nothing left to change
+	changes, err = service.NormalizePermissions(root, true)
+	if err != nil {
+		t.Fatalf("NormalizePermissions() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("NormalizePermissions() second pass reported %d changes, want 0", len(changes))
+	}
+}
+
+func TestService_NormalizePermissions_MissingRoot(t *testing.T) {
+	changes, err := New().NormalizePermissions(filepath.Join(t.TempDir(), "missing"), true)
+	if err != nil {
+		t.Errorf("NormalizePermissions() error = %v, want nil", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("NormalizePermissions() reported %d changes, want 0", len(changes))
+	}
+}
+
+func TestService_MakeReadOnly(t *testing.T) {
+	root := t.TempDir()
+	hooksDir := filepath.Join(root, "core", "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	files := map[string]os.FileMode{
+		"core/guide.md":       0444,
+		"core/hooks/check.py": 0555,
+	}
+	for path := range files {
+		if err := os.WriteFile(filepath.Join(root, path), []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	service := New()
+	if err := service.MakeReadOnly(filepath.Join(root, "core")); err != nil {
+		t.Fatalf("MakeReadOnly() error = %v", err)
+	}
+	for path, mode := range files {
+		info, err := os.Stat(filepath.Join(root, path))
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("MakeReadOnly() %s mode = %04o, want %04o", path, info.Mode().Perm(), mode)
+		}
+	}
+	if info, err := os.Stat(hooksDir); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("MakeReadOnly() should keep directories writable: %v", err)
+	}
+
+	// Reported against the read-only directories, nothing is left to change,
+	// while a plain normalization makes the files writable again
+	changes, err := service.NormalizePermissions(root, false, filepath.Join(root, "core"))
+	if err != nil || len(changes) != 0 {
+		t.Errorf("NormalizePermissions() read-only = %v, %v, want no changes", changes, err)
+	}
+	changes, err = service.NormalizePermissions(root, false)
+	if err != nil || len(changes) != len(files) {
+		t.Errorf("NormalizePermissions() = %v, %v, want %d changes", changes, err, len(files))
+	}
+}
+
+func TestService_CopyFile_ReplacesReadOnly(t *testing.T) {
+	root := t.TempDir()
+	sourcePath := filepath.Join(root, "source.md")
+	destPath := filepath.Join(root, "dest.md")
+	if err := os.WriteFile(sourcePath, []byte("new"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(destPath, []byte("old"), 0444); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := New().CopyFile(sourcePath, destPath); err != nil {
+		t.Fatalf("CopyFile() error = %v", err)
+	}
+	if data, err := os.ReadFile(destPath); err != nil || string(data) != "new" {
+		t.Errorf("CopyFile() content = %q, %v, want %q", data, err, "new")
+	}
+}
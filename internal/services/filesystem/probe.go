@@ -0,0 +1,87 @@
+package filesystem
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// ProbeCapabilities tries symlinks, hardlinks, case sensitivity and reflinks
+// in a scratch directory on the disk holding dir. When dir does not exist yet,
+// its closest existing parent is probed.
+func ProbeCapabilities(dir string) (*models.FilesystemCapabilities, error) {
+	probeDir := filepath.Clean(dir)
+	for {
+		if info, err := os.Stat(probeDir); err == nil && info.IsDir() {
+			break
+		}
+		parent := filepath.Dir(probeDir)
+		if parent == probeDir {
+			return nil, models.NewFileSystemError(models.ErrorCodeDirectoryNotFound, dir, nil)
+		}
+		probeDir = parent
+	}
+
+	scratch, err := os.MkdirTemp(probeDir, config.ProbeDirPrefix)
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil, models.NewFileSystemError(models.ErrorCodePermissionDenied, probeDir, err)
+		}
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, probeDir, err)
+	}
+	defer os.RemoveAll(scratch)
+
+	file := filepath.Join(scratch, "probe")
+	if err := os.WriteFile(file, []byte("probe\n"), config.FilePermissions); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, file, err)
+	}
+
+	capabilities := &models.FilesystemCapabilities{Dir: probeDir}
+	capabilities.Symlinks = os.Symlink("probe", filepath.Join(scratch, "symlink")) == nil
+	capabilities.Hardlinks = os.Link(file, filepath.Join(scratch, "hardlink")) == nil
+	_, err = os.Lstat(filepath.Join(scratch, "PROBE"))
+	capabilities.CaseSensitive = os.IsNotExist(err)
+	capabilities.Reflinks = probeReflink(file, filepath.Join(scratch, "clone")) == nil
+
+	return capabilities, nil
+}
+
+// probeReflink clones source to a new file at dest
+func probeReflink(source, dest string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	return cloneFile(dst, src)
+}
+
+// cloneOSFile clones src into dst when both are files on the disk and reports
+// whether it succeeded
+func cloneOSFile(dst io.Writer, src io.Reader) bool {
+	dstFile, ok := dst.(*os.File)
+	if !ok {
+		return false
+	}
+	srcFile, ok := src.(*os.File)
+	if !ok {
+		return false
+	}
+	return cloneFile(dstFile, srcFile) == nil
+}
+
+// SetCopyStrategy selects how CopyFile copies: models.CopyStrategyReflink
+// clones files when source and destination allow it and copies them otherwise
+func (s *Service) SetCopyStrategy(strategy string) {
+	s.reflink = strategy == models.CopyStrategyReflink
+}
@@ -1,9 +1,15 @@
 package git
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"fmt"
+	neturl "net/url"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,16 +19,53 @@ import (
 
 // Service handles git operations for the Strategic Claude Basic CLI
 type Service struct {
-	timeout time.Duration
+	ctx         context.Context
+	timeout     time.Duration
+	authToken   string
+	sparsePaths []string
 }
 
 // New creates a new git service instance
 func New() *Service {
 	return &Service{
+		ctx:     context.Background(),
 		timeout: config.DefaultGitTimeout,
 	}
 }
 
+// SetContext makes git commands stop when ctx is done, e.g. at the deadline of
+// the operation they are part of
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// SetAuthToken sets the token used for HTTPS authentication. When empty, the
+// SCB_GIT_TOKEN environment variable is used instead, and when neither is set
+// git falls back to the user's configured credential helpers.
+func (s *Service) SetAuthToken(token string) {
+	s.authToken = token
+}
+
+// SetTimeout limits how long each git command may run
+func (s *Service) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// SetSparsePaths limits the checked out working tree to the given directories
+// (cone mode). Top-level files such as install scripts are always included.
+// Passing no paths disables sparse checkout.
+func (s *Service) SetSparsePaths(paths ...string) {
+	s.sparsePaths = paths
+}
+
+// resolveAuthToken returns the explicit token if set, otherwise the environment token
+func (s *Service) resolveAuthToken() string {
+	if s.authToken != "" {
+		return s.authToken
+	}
+	return strings.TrimSpace(os.Getenv(config.GitTokenEnvVar))
+}
+
 // ValidateGitInstalled checks if git is available in the system
 func (s *Service) ValidateGitInstalled() error {
 	_, err := exec.LookPath("git")
@@ -41,7 +84,10 @@ func (s *Service) CloneRepository(url, commit string) (string, error) {
 	return s.CloneRepositoryWithBranch(url, "", commit)
 }
 
-// CloneRepositoryWithBranch clones a git repository with optional branch specification and checks out a specific commit
+// CloneRepositoryWithBranch clones a git repository with optional branch specification and checks out a specific commit.
+// It first attempts a depth-1 fetch of just the pinned commit and falls back to a full clone
+// when the server refuses to serve the commit directly. An empty commit clones the head
+// of branch, or of the default branch when branch is empty too.
 func (s *Service) CloneRepositoryWithBranch(url, branch, commit string) (string, error) {
 	if err := s.ValidateGitInstalled(); err != nil {
 		return "", err
@@ -56,6 +102,29 @@ func (s *Service) CloneRepositoryWithBranch(url, branch, commit string) (string,
 		)
 	}
 
+	// Fast path: fetch only the pinned commit without history
+	if commit != "" {
+		shallowErr := s.shallowFetchCommit(url, commit, tempDir)
+		if shallowErr == nil {
+			return tempDir, nil
+		}
+
+		_ = s.CleanupTempDir(tempDir) // Best effort cleanup
+		if models.IsErrorCode(shallowErr, models.ErrorCodeGitAuthFailed) || s.ctx.Err() != nil {
+			return "", shallowErr
+		}
+
+		// Start over with a fresh directory for the full clone
+		tempDir, err = s.createTempDir()
+		if err != nil {
+			return "", models.NewAppError(
+				models.ErrorCodeFileSystemError,
+				"Failed to create temporary directory",
+				err,
+			)
+		}
+	}
+
 	// Attempt clone with retries for network issues
 	var cloneErr error
 	for attempt := 1; attempt <= 3; attempt++ {
@@ -64,6 +133,12 @@ func (s *Service) CloneRepositoryWithBranch(url, branch, commit string) (string,
 			break
 		}
 
+		// Authentication failures will not resolve themselves on retry, and
+		// nothing is retried after the deadline
+		if models.IsErrorCode(cloneErr, models.ErrorCodeGitAuthFailed) || s.ctx.Err() != nil {
+			break
+		}
+
 		if attempt < 3 {
 			time.Sleep(time.Second * time.Duration(attempt))
 		}
@@ -74,8 +149,16 @@ func (s *Service) CloneRepositoryWithBranch(url, branch, commit string) (string,
 		return "", cloneErr
 	}
 
-	// Checkout specific commit
-	if err := s.checkoutCommit(tempDir, commit); err != nil {
+	// Checkout specific commit; without one the branch head is kept
+	if commit != "" {
+		if err := s.checkoutCommit(tempDir, commit); err != nil {
+			_ = s.CleanupTempDir(tempDir) // Best effort cleanup
+			return "", err
+		}
+	}
+
+	// Narrow the working tree if sparse checkout was requested
+	if err := s.applySparseCheckout(tempDir); err != nil {
 		_ = s.CleanupTempDir(tempDir) // Best effort cleanup
 		return "", err
 	}
@@ -112,7 +195,7 @@ func (s *Service) CleanupTempDir(path string) error {
 
 // createTempDir creates a temporary directory for git operations
 func (s *Service) createTempDir() (string, error) {
-	tempDir, err := os.MkdirTemp("", config.TempDirPrefix)
+	tempDir, err := os.MkdirTemp(config.TempDir(), config.TempDirPrefix)
 	if err != nil {
 		return "", err
 	}
@@ -125,24 +208,41 @@ func (s *Service) cloneWithRetry(url, branch, tempDir string, attempt int) error
 
 	if branch != "" {
 		// Clone specific branch
-		cmd = exec.Command("git", "clone", "-b", branch, url, tempDir)
+		cmd = exec.CommandContext(s.ctx, "git", "clone", "-b", branch, url, tempDir)
 	} else {
 		// Clone default branch
-		cmd = exec.Command("git", "clone", url, tempDir)
+		cmd = exec.CommandContext(s.ctx, "git", "clone", url, tempDir)
 	}
 
+	var stderr bytes.Buffer
 	cmd.Stdout = nil // Suppress output
-	cmd.Stderr = nil
+	cmd.Stderr = &stderr
+	cmd.Env = s.buildGitEnv(url)
 
 	err := cmd.Run()
 	if err != nil {
+		branchInfo := ""
+		if branch != "" {
+			branchInfo = fmt.Sprintf(" (branch: %s)", branch)
+		}
+
+		// Authentication failures are reported immediately, they are not retried
+		output := stderr.String()
+		if isAuthFailure(output) {
+			return models.NewAppError(
+				models.ErrorCodeGitAuthFailed,
+				fmt.Sprintf("Authentication failed while cloning %s%s", url, branchInfo),
+				fmt.Errorf("%w: %s", err, s.redact(lastLine(output))),
+			).WithContext("url", url)
+		}
+
 		if attempt == 3 { // Last attempt, return detailed error
-			branchInfo := ""
-			if branch != "" {
-				branchInfo = fmt.Sprintf(" (branch: %s)", branch)
+			code := models.ErrorCodeGitCloneError
+			if isNetworkFailure(output) {
+				code = models.ErrorCodeNetworkError
 			}
 			return models.NewAppError(
-				models.ErrorCodeGitCloneError,
+				code,
 				fmt.Sprintf("Failed to clone repository %s%s after %d attempts", url, branchInfo, attempt),
 				err,
 			)
@@ -153,9 +253,244 @@ func (s *Service) cloneWithRetry(url, branch, tempDir string, attempt int) error
 	return nil
 }
 
+// shallowFetchCommit initializes an empty repository and fetches only the given commit at depth 1
+func (s *Service) shallowFetchCommit(url, commit, repoPath string) error {
+	if output, err := s.runGit(repoPath, "", "init", "-q"); err != nil {
+		return models.NewGitError(models.ErrorCodeGitError, "init: "+lastLine(output), err)
+	}
+
+	if output, err := s.runGit(repoPath, "", "remote", "add", "origin", url); err != nil {
+		return models.NewGitError(models.ErrorCodeGitError, "remote add: "+lastLine(output), err)
+	}
+
+	if output, err := s.runGit(repoPath, url, "fetch", "-q", "--depth", "1", "origin", commit); err != nil {
+		if isAuthFailure(output) {
+			return models.NewAppError(
+				models.ErrorCodeGitAuthFailed,
+				fmt.Sprintf("Authentication failed while fetching %s", url),
+				fmt.Errorf("%w: %s", err, s.redact(lastLine(output))),
+			).WithContext("url", url)
+		}
+		return models.NewGitError(models.ErrorCodeGitCloneError, "shallow fetch: "+s.redact(lastLine(output)), err)
+	}
+
+	if err := s.applySparseCheckout(repoPath); err != nil {
+		return err
+	}
+
+	if output, err := s.runGit(repoPath, "", "checkout", "-q", "FETCH_HEAD"); err != nil {
+		return models.NewAppError(
+			models.ErrorCodeGitCheckoutError,
+			fmt.Sprintf("Failed to checkout commit %s: %s", commit, lastLine(output)),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// applySparseCheckout restricts the working tree to the configured sparse paths
+func (s *Service) applySparseCheckout(repoPath string) error {
+	if len(s.sparsePaths) == 0 {
+		return nil
+	}
+
+	args := append([]string{"sparse-checkout", "set", "--cone"}, s.sparsePaths...)
+	if output, err := s.runGit(repoPath, "", args...); err != nil {
+		return models.NewGitError(models.ErrorCodeGitError, "sparse-checkout: "+lastLine(output), err)
+	}
+
+	return nil
+}
+
+// runGit runs a git command in repoPath and returns its combined stderr output.
+// When url is non-empty the command is treated as a network operation and
+// receives the authentication environment for that URL.
+func (s *Service) runGit(repoPath, url string, args ...string) (string, error) {
+	cmd := exec.CommandContext(s.ctx, "git", args...)
+	cmd.Dir = repoPath
+
+	var stderr bytes.Buffer
+	cmd.Stdout = nil
+	cmd.Stderr = &stderr
+	if url != "" {
+		cmd.Env = s.buildGitEnv(url)
+	}
+
+	err := cmd.Run()
+	return stderr.String(), err
+}
+
+// Run runs a git command in dir and returns its trimmed standard output. When url
+// is non-empty the command is treated as a network operation and receives the
+// authentication environment for that URL. Failures carry the last line of git's
+// error output, with authentication and network problems reported as such.
+func (s *Service) Run(dir, url string, args ...string) (string, error) {
+	cmd := exec.CommandContext(s.ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if url != "" {
+		cmd.Env = s.buildGitEnv(url)
+	}
+
+	err := cmd.Run()
+	output := strings.TrimSpace(stdout.String())
+	if err == nil {
+		return output, nil
+	}
+
+	errOutput := stderr.String()
+	code := models.ErrorCodeGitError
+	switch {
+	case url != "" && isAuthFailure(errOutput):
+		code = models.ErrorCodeGitAuthFailed
+	case url != "" && isNetworkFailure(errOutput):
+		code = models.ErrorCodeNetworkError
+	}
+
+	operation := args[0]
+	if line := lastLine(withoutHints(errOutput)); line != "" {
+		operation += ": " + s.redact(line)
+	}
+	return output, models.NewGitError(code, operation, err)
+}
+
+// withoutHints removes the "hint:" lines git adds to error output
+func withoutHints(output string) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "hint:") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildGitEnv returns the environment for git network commands. Interactive
+// prompts are disabled so missing credentials fail fast instead of hanging,
+// an HTTPS token is injected through GIT_CONFIG_* variables (keeping it out of
+// the process list and the cloned repository's config), and SSH runs in batch
+// mode so only agent or key-file authentication is attempted. The token is
+// only sent to the host of url, not to submodules or redirects elsewhere, and
+// is added after the GIT_CONFIG_* entries the user already set.
+func (s *Service) buildGitEnv(url string) []string {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if IsSSHURL(url) {
+		if os.Getenv("GIT_SSH_COMMAND") == "" {
+			env = append(env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+		}
+		return env
+	}
+
+	token := s.resolveAuthToken()
+	if token == "" || !strings.HasPrefix(url, "https://") {
+		return env
+	}
+
+	parsed, err := neturl.Parse(url)
+	if err != nil || parsed.Host == "" {
+		return env
+	}
+
+	count := 0
+	if value := os.Getenv("GIT_CONFIG_COUNT"); value != "" {
+		if count, err = strconv.Atoi(value); err != nil || count < 0 {
+			return env // Git rejects the user's entries, adding one would not help
+		}
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+	env = slices.DeleteFunc(env, func(entry string) bool {
+		return strings.HasPrefix(entry, "GIT_CONFIG_COUNT=")
+	})
+	return append(env,
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.https://%s/.extraHeader", count, parsed.Host),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", count, credentials),
+	)
+}
+
+// redact removes the auth token from text that may be shown to the user
+func (s *Service) redact(text string) string {
+	if token := s.resolveAuthToken(); token != "" {
+		text = strings.ReplaceAll(text, token, "****")
+	}
+	return text
+}
+
+// IsSSHURL reports whether a repository URL uses the SSH transport
+func IsSSHURL(url string) bool {
+	if strings.HasPrefix(url, "ssh://") || strings.HasPrefix(url, "git+ssh://") {
+		return true
+	}
+	// scp-like syntax: user@host:path
+	if strings.Contains(url, "://") {
+		return false
+	}
+	at := strings.Index(url, "@")
+	colon := strings.Index(url, ":")
+	return at > 0 && colon > at
+}
+
+// authFailurePatterns are git/ssh stderr fragments that indicate rejected or missing credentials
+var authFailurePatterns = []string{
+	"authentication failed",
+	"could not read username",
+	"could not read password",
+	"terminal prompts disabled",
+	"permission denied (publickey",
+	"invalid username or password",
+	"the requested url returned error: 401",
+	"the requested url returned error: 403",
+	"repository not found",
+	"host key verification failed",
+}
+
+// networkFailurePatterns are git stderr fragments that indicate connectivity problems
+var networkFailurePatterns = []string{
+	"could not resolve host",
+	"could not resolve hostname",
+	"connection timed out",
+	"connection refused",
+	"network is unreachable",
+	"failed to connect",
+	"operation timed out",
+	"temporary failure in name resolution",
+}
+
+// isAuthFailure reports whether git output indicates an authentication problem
+func isAuthFailure(output string) bool {
+	return containsAny(strings.ToLower(output), authFailurePatterns)
+}
+
+// isNetworkFailure reports whether git output indicates a network problem
+func isNetworkFailure(output string) bool {
+	return containsAny(strings.ToLower(output), networkFailurePatterns)
+}
+
+// containsAny reports whether s contains any of the given substrings
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastLine returns the last non-empty line of command output
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
 // checkoutCommit checks out a specific commit in the cloned repository
 func (s *Service) checkoutCommit(repoPath, commit string) error {
-	cmd := exec.Command("git", "checkout", commit)
+	cmd := exec.CommandContext(s.ctx, "git", "checkout", commit)
 	cmd.Dir = repoPath
 	cmd.Stdout = nil
 	cmd.Stderr = nil
@@ -172,12 +507,28 @@ func (s *Service) checkoutCommit(repoPath, commit string) error {
 	return nil
 }
 
+// FindRepoRoot returns the root of the git work tree containing dir
+func (s *Service) FindRepoRoot(dir string) (string, error) {
+	cmd := exec.CommandContext(s.ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", models.NewAppError(
+			models.ErrorCodeNotGitRepository,
+			fmt.Sprintf("Directory is not inside a git work tree: %s", dir),
+			err,
+		)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 // GetRepoInfo returns information about the repository state
 func (s *Service) GetRepoInfo(repoPath string) (map[string]string, error) {
 	info := make(map[string]string)
 
 	// Get current commit hash
-	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd := exec.CommandContext(s.ctx, "git", "rev-parse", "HEAD")
 	cmd.Dir = repoPath
 	output, err := cmd.Output()
 	if err != nil {
@@ -190,7 +541,7 @@ func (s *Service) GetRepoInfo(repoPath string) (map[string]string, error) {
 	info["commit"] = strings.TrimSpace(string(output))
 
 	// Get remote URL
-	cmd = exec.Command("git", "config", "--get", "remote.origin.url")
+	cmd = exec.CommandContext(s.ctx, "git", "config", "--get", "remote.origin.url")
 	cmd.Dir = repoPath
 	output, err = cmd.Output()
 	if err != nil {
@@ -207,7 +558,7 @@ func (s *Service) GetRepoInfo(repoPath string) (map[string]string, error) {
 
 // IsValidCommit checks if a commit hash exists in the repository
 func (s *Service) IsValidCommit(repoPath, commit string) error {
-	cmd := exec.Command("git", "cat-file", "-e", commit)
+	cmd := exec.CommandContext(s.ctx, "git", "cat-file", "-e", commit)
 	cmd.Dir = repoPath
 
 	err := cmd.Run()
@@ -342,3 +342,279 @@ func TestService_FileSystemErrors(t *testing.T) {
 		_ = err
 	}
 }
+
+func TestIsSSHURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"git@github.com:org/repo.git", true},
+		{"ssh://git@github.com/org/repo.git", true},
+		{"git+ssh://git@github.com/org/repo.git", true},
+		{"https://github.com/org/repo.git", false},
+		{"https://user@github.com/org/repo.git", false},
+		{"/local/path/repo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := IsSSHURL(tt.url); got != tt.expected {
+				t.Errorf("IsSSHURL(%q) = %v, want %v", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCloneFailureClassification(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		auth    bool
+		network bool
+	}{
+		{
+			name:   "https credentials missing",
+			output: "fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			auth:   true,
+		},
+		{
+			name:   "ssh key rejected",
+			output: "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.",
+			auth:   true,
+		},
+		{
+			name:    "dns failure",
+			output:  "fatal: unable to access 'https://github.com/org/repo.git/': Could not resolve host: github.com",
+			network: true,
+		},
+		{
+			name:   "unrelated failure",
+			output: "fatal: destination path already exists and is not an empty directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAuthFailure(tt.output); got != tt.auth {
+				t.Errorf("isAuthFailure() = %v, want %v", got, tt.auth)
+			}
+			if got := isNetworkFailure(tt.output); got != tt.network {
+				t.Errorf("isNetworkFailure() = %v, want %v", got, tt.network)
+			}
+		})
+	}
+}
+
+func TestService_buildGitEnv(t *testing.T) {
+	t.Setenv(config.GitTokenEnvVar, "")
+	t.Setenv("GIT_CONFIG_COUNT", "") // Restored afterwards
+	os.Unsetenv("GIT_CONFIG_COUNT")
+
+	service := New()
+
+	hasEntry := func(env []string, prefix string) bool {
+		for _, entry := range env {
+			if strings.HasPrefix(entry, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	env := service.buildGitEnv("https://github.com/org/repo.git")
+	if !hasEntry(env, "GIT_TERMINAL_PROMPT=0") {
+		t.Error("Expected interactive prompts to be disabled")
+	}
+	if hasEntry(env, "GIT_CONFIG_COUNT=") {
+		t.Error("Expected no auth header without a token")
+	}
+
+	service.SetAuthToken("secret-token")
+	env = service.buildGitEnv("https://github.com/org/repo.git")
+	if !hasEntry(env, "GIT_CONFIG_VALUE_0=Authorization: Basic ") {
+		t.Error("Expected auth header to be injected for HTTPS URL")
+	}
+	if !hasEntry(env, "GIT_CONFIG_KEY_0=http.https://github.com/.extraHeader") {
+		t.Error("Expected auth header to be scoped to the repository host")
+	}
+
+	// The user's own GIT_CONFIG_* entries are kept, the header comes after them
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	env = service.buildGitEnv("https://git.example.com:8443/org/repo.git")
+	var counts []string
+	for _, entry := range env {
+		if strings.HasPrefix(entry, "GIT_CONFIG_COUNT=") {
+			counts = append(counts, entry)
+		}
+	}
+	if len(counts) != 1 || counts[0] != "GIT_CONFIG_COUNT=3" {
+		t.Errorf("GIT_CONFIG_COUNT entries = %v, want only GIT_CONFIG_COUNT=3", counts)
+	}
+	if !hasEntry(env, "GIT_CONFIG_KEY_2=http.https://git.example.com:8443/.extraHeader") || !hasEntry(env, "GIT_CONFIG_VALUE_2=Authorization: Basic ") {
+		t.Error("Expected auth header to be appended after the user's entries")
+	}
+	os.Unsetenv("GIT_CONFIG_COUNT")
+
+	env = service.buildGitEnv("git@github.com:org/repo.git")
+	if hasEntry(env, "GIT_CONFIG_COUNT=") {
+		t.Error("Expected token not to be sent for SSH URL")
+	}
+
+	if got := service.redact("token secret-token leaked"); strings.Contains(got, "secret-token") {
+		t.Errorf("Expected token to be redacted, got %q", got)
+	}
+}
+
+func TestService_resolveAuthToken(t *testing.T) {
+	t.Setenv(config.GitTokenEnvVar, "env-token")
+
+	service := New()
+	if got := service.resolveAuthToken(); got != "env-token" {
+		t.Errorf("Expected environment token, got %q", got)
+	}
+
+	service.SetAuthToken("flag-token")
+	if got := service.resolveAuthToken(); got != "flag-token" {
+		t.Errorf("Expected explicit token to take precedence, got %q", got)
+	}
+}
+
+// createLocalRepo creates a repository with two commits and returns its path and the first commit hash
+func createLocalRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	files := map[string]string{
+		filepath.Join(config.StrategicClaudeBasicDir, "core", "README.md"): "core",
+		filepath.Join("docs", "index.md"):                                  "docs",
+		config.PostInstallScript:                                           "#!/bin/bash\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(repoDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	run("init", "-q")
+	run("add", "-A")
+	run("commit", "-q", "-m", "first")
+	first := run("rev-parse", "HEAD")
+
+	if err := os.WriteFile(filepath.Join(repoDir, "docs", "index.md"), []byte("updated"), 0644); err != nil {
+		t.Fatalf("Failed to update file: %v", err)
+	}
+	run("commit", "-q", "-am", "second")
+
+	return repoDir, first
+}
+
+func TestService_CloneRepository_ShallowPinnedCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not available, skipping clone test")
+	}
+
+	repoDir, commit := createLocalRepo(t)
+	service := New()
+
+	tempDir, err := service.CloneRepositoryWithBranch("file://"+repoDir, "", commit)
+	if err != nil {
+		t.Fatalf("CloneRepositoryWithBranch() error = %v", err)
+	}
+	defer func() { _ = service.CleanupTempDir(tempDir) }()
+
+	info, err := service.GetRepoInfo(tempDir)
+	if err != nil {
+		t.Fatalf("GetRepoInfo() error = %v", err)
+	}
+	if info["commit"] != commit {
+		t.Errorf("Expected pinned commit %s, got %s", commit, info["commit"])
+	}
+
+	// Only the pinned commit should have been fetched
+	cmd := exec.Command("git", "rev-list", "--count", "HEAD")
+	cmd.Dir = tempDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git rev-list failed: %v", err)
+	}
+	if count := strings.TrimSpace(string(output)); count != "1" {
+		t.Errorf("Expected a depth-1 history, got %s commits", count)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "docs", "index.md"))
+	if err != nil {
+		t.Fatalf("Failed to read checked out file: %v", err)
+	}
+	if string(content) != "docs" {
+		t.Errorf("Expected content from pinned commit, got %q", string(content))
+	}
+}
+
+func TestService_CloneRepository_SparseCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not available, skipping clone test")
+	}
+
+	repoDir, commit := createLocalRepo(t)
+	service := New()
+	service.SetSparsePaths(config.StrategicClaudeBasicDir)
+
+	tempDir, err := service.CloneRepositoryWithBranch("file://"+repoDir, "", commit)
+	if err != nil {
+		t.Fatalf("CloneRepositoryWithBranch() error = %v", err)
+	}
+	defer func() { _ = service.CleanupTempDir(tempDir) }()
+
+	if _, err := os.Stat(filepath.Join(tempDir, config.StrategicClaudeBasicDir, "core", "README.md")); err != nil {
+		t.Errorf("Expected framework directory to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, config.PostInstallScript)); err != nil {
+		t.Errorf("Expected top-level scripts to be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "docs")); !os.IsNotExist(err) {
+		t.Error("Expected directories outside the sparse paths to be excluded")
+	}
+}
+
+func TestService_FindRepoRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not available, skipping repository detection test")
+	}
+
+	service := New()
+	repoDir, _ := createLocalRepo(t)
+	expectedRoot, err := filepath.EvalSymlinks(repoDir)
+	if err != nil {
+		t.Fatalf("Failed to resolve repo dir: %v", err)
+	}
+
+	root, err := service.FindRepoRoot(filepath.Join(repoDir, "docs"))
+	if err != nil {
+		t.Fatalf("FindRepoRoot() error = %v", err)
+	}
+	if resolved, _ := filepath.EvalSymlinks(root); resolved != expectedRoot {
+		t.Errorf("FindRepoRoot() = %v, want %v", root, expectedRoot)
+	}
+
+	_, err = service.FindRepoRoot(t.TempDir())
+	if !models.IsErrorCode(err, models.ErrorCodeNotGitRepository) {
+		t.Errorf("FindRepoRoot() error = %v, want code %s", err, models.ErrorCodeNotGitRepository)
+	}
+}
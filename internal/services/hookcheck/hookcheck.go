@@ -0,0 +1,197 @@
+// Package hookcheck runs the strategic hooks registered in .claude/settings.json
+// with a synthetic Claude Code payload, to find hooks that would fail during a
+// session, e.g. because of a missing Python dependency or a syntax error.
+package hookcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Service runs hooks
+type Service struct {
+	ctx     context.Context
+	timeout time.Duration
+}
+
+// New creates a new hook check service instance
+func New() *Service {
+	return &Service{ctx: context.Background(), timeout: config.HookCheckTimeout}
+}
+
+// SetContext makes running hooks stop when ctx is done
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Check runs every strategic hook in the settings of the installation in
+// targetDir, in the order of the events, and returns how each one went
+func (s *Service) Check(targetDir string) ([]models.HookCheckResult, error) {
+	settingsPath := filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile)
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, settingsPath, err)
+	}
+	var settings models.ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, models.NewAppError(
+			models.ErrorCodeInvalidConfiguration,
+			fmt.Sprintf("Failed to parse %s", settingsPath),
+			err,
+		)
+	}
+
+	// Stop hooks may read the transcript, so they get an empty one
+	transcript, err := os.CreateTemp(config.TempDir(), "hook-check-*.jsonl")
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, config.TempDir(), err)
+	}
+	transcript.Close()
+	defer os.Remove(transcript.Name())
+
+	var results []models.HookCheckResult
+	for _, event := range models.GetHookTypesInOrder() {
+		for _, matcher := range settings.Hooks.Matchers(event) {
+			for _, hook := range matcher.Hooks {
+				script, ok := models.StrategicHookScript(hook.Command)
+				if !ok {
+					continue
+				}
+				if err := s.ctx.Err(); err != nil {
+					return results, err
+				}
+				result := s.run(targetDir, hook.Command, payload(targetDir, event, matcher.Matcher, transcript.Name()))
+				result.Event = event
+				result.Matcher = matcher.Matcher
+				result.Script = script
+				results = append(results, result)
+			}
+		}
+	}
+	return results, nil
+}
+
+// Err returns an error listing the hooks that failed, or nil when all succeeded
+func Err(results []models.HookCheckResult) error {
+	var failed []string
+	for _, result := range results {
+		if !result.Succeeded() {
+			failed = append(failed, fmt.Sprintf("%s (%s)", result.Script, result.Event))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return models.NewAppError(
+		models.ErrorCodeHookCheckFailed,
+		fmt.Sprintf("%d of %d hooks failed: %s", len(failed), len(results), strings.Join(failed, ", ")),
+		nil,
+	)
+}
+
+// run runs a hook command through the shell in the project directory, like
+// Claude Code does, with the payload on stdin
+func (s *Service) run(targetDir, command string, input []byte) models.HookCheckResult {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = targetDir
+	cmd.Env = append(os.Environ(), "CLAUDE_PROJECT_DIR="+targetDir, config.HookCheckEnvVar+"=1")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = config.ScriptStopTimeout
+
+	start := time.Now()
+	err := cmd.Run()
+	result := models.HookCheckResult{
+		Command:  command,
+		Duration: time.Since(start),
+		Output:   tail(output.Bytes(), config.MaxScriptOutputBytes),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.ExitCode = -1
+		result.Error = fmt.Sprintf("timed out after %s", s.timeout)
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		result.ExitCode = -1
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// payload returns the JSON Claude Code sends a hook for the event. Tool events
+// use the first tool the matcher names, with input that hooks let through.
+func payload(targetDir, event, matcher, transcriptPath string) []byte {
+	input := map[string]any{
+		"session_id":      "strategic-claude-hook-check",
+		"transcript_path": transcriptPath,
+		"cwd":             targetDir,
+		"hook_event_name": event,
+	}
+
+	switch event {
+	case "PreToolUse", "PostToolUse":
+		tool, _, _ := strings.Cut(matcher, "|")
+		if tool == "" || tool == "*" || strings.ContainsAny(tool, ".*+?()[]") {
+			tool = "Bash"
+		}
+		input["tool_name"] = tool
+		input["tool_input"] = toolInput(targetDir, tool)
+		if event == "PostToolUse" {
+			input["tool_response"] = map[string]any{"success": true}
+		}
+	case "Stop":
+		input["stop_hook_active"] = false
+	case "PreCompact":
+		input["trigger"] = "manual"
+		input["custom_instructions"] = ""
+	case "Notification":
+		input["message"] = "Hook check"
+	}
+
+	data, _ := json.Marshal(input)
+	return data
+}
+
+// toolInput returns harmless input for a tool: a read-only command, or a
+// file that is not part of any configuration
+func toolInput(targetDir, tool string) map[string]any {
+	switch tool {
+	case "Bash":
+		return map[string]any{"command": "ls", "description": "List files"}
+	case "Write", "Edit", "MultiEdit", "Read":
+		return map[string]any{
+			"file_path":  filepath.Join(targetDir, "hook-check.txt"),
+			"content":    "",
+			"old_string": "",
+			"new_string": "",
+		}
+	}
+	return map[string]any{}
+}
+
+// tail returns the last limit bytes of data
+func tail(data []byte, limit int) string {
+	if len(data) > limit {
+		data = data[len(data)-limit:]
+	}
+	return string(data)
+}
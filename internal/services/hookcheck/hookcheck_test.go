@@ -0,0 +1,91 @@
+package hookcheck
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are run through sh")
+	}
+
+	targetDir := t.TempDir()
+	// The hooks are shell scripts named like the strategic ones
+	writeFile(t, filepath.Join(targetDir, "hooks", "block-skip-hooks.py"),
+		"cat > \"$CLAUDE_PROJECT_DIR/payload.json\"\necho \"check=$"+config.HookCheckEnvVar+"\"\n")
+	writeFile(t, filepath.Join(targetDir, "hooks", "stop-session-notify.py"), "echo 'No module named requests' >&2\nexit 1\n")
+	writeFile(t, filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile), `{
+  "hooks": {
+    "PreToolUse": [{"matcher": "Bash", "hooks": [
+      {"type": "command", "command": "sh $CLAUDE_PROJECT_DIR/hooks/block-skip-hooks.py"},
+      {"type": "command", "command": "exit 1"}
+    ]}],
+    "Stop": [{"matcher": "", "hooks": [
+      {"type": "command", "command": "sh $CLAUDE_PROJECT_DIR/hooks/stop-session-notify.py"}
+    ]}]
+  }
+}`)
+
+	results, err := New().Check(targetDir)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	// The user's hook is not run
+	if len(results) != 2 {
+		t.Fatalf("Check() returned %d results, want 2: %+v", len(results), results)
+	}
+
+	pre := results[0]
+	if pre.Event != "PreToolUse" || pre.Script != "block-skip-hooks.py" || !pre.Succeeded() {
+		t.Errorf("results[0] = %+v, want a successful PreToolUse hook", pre)
+	}
+	if pre.Output != "check=1\n" {
+		t.Errorf("results[0].Output = %q, want the hook to see %s", pre.Output, config.HookCheckEnvVar)
+	}
+	var payload map[string]any
+	data, err := os.ReadFile(filepath.Join(targetDir, "payload.json"))
+	if err != nil {
+		t.Fatalf("Hook did not write its payload: %v", err)
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("Payload is not JSON: %v", err)
+	}
+	if payload["hook_event_name"] != "PreToolUse" || payload["tool_name"] != "Bash" || payload["cwd"] != targetDir {
+		t.Errorf("payload = %v", payload)
+	}
+
+	stop := results[1]
+	if stop.Event != "Stop" || stop.Succeeded() || stop.ExitCode != 1 || stop.Output != "No module named requests\n" {
+		t.Errorf("results[1] = %+v, want a Stop hook failing with its output", stop)
+	}
+
+	if err := Err(results); !models.IsErrorCode(err, models.ErrorCodeHookCheckFailed) {
+		t.Errorf("Err() = %v, want %s", err, models.ErrorCodeHookCheckFailed)
+	}
+	if err := Err(results[:1]); err != nil {
+		t.Errorf("Err() of successful hooks = %v, want nil", err)
+	}
+}
+
+func TestCheck_NoSettings(t *testing.T) {
+	if _, err := New().Check(t.TempDir()); err == nil {
+		t.Error("Check() without settings.json returned no error")
+	}
+}
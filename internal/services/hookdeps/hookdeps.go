@@ -0,0 +1,112 @@
+package hookdeps
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/settings"
+)
+
+// Service installs the Python dependencies of template hooks into a dedicated
+// virtualenv under .strategic-claude-basic/.venv
+type Service struct {
+	ctx context.Context
+}
+
+// New creates a new hook dependency service instance
+func New() *Service {
+	return &Service{ctx: context.Background()}
+}
+
+// SetContext makes Python and pip stop when ctx is done, e.g. at the deadline
+// of the installation
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// FindDependencyFile returns the requirements.txt or pyproject.toml in a hooks directory,
+// preferring requirements.txt
+func FindDependencyFile(hooksDir string) (string, bool) {
+	for _, name := range []string{config.HookRequirementsFile, config.HookPyprojectFile} {
+		path := filepath.Join(hooksDir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// HooksDir returns the installed core hooks directory of a project
+func HooksDir(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), config.CoreDir, config.HooksDir)
+}
+
+// Install creates the hook virtualenv with the given Python command, unless it
+// already exists, and installs the dependencies declared in depFile. It returns
+// the path of the virtualenv's interpreter.
+func (s *Service) Install(targetDir, python, depFile string) (string, error) {
+	venvDir := settings.HookVenvPath(targetDir)
+
+	venvPython := settings.VenvPython(venvDir)
+	if venvPython == "" {
+		args := settings.CommandArgs(targetDir, python)
+		if len(args) == 0 {
+			return "", models.NewValidationError("hook-python", python, "Python command cannot be empty")
+		}
+
+		args = append(args, "-m", "venv", venvDir)
+		if err := s.run(targetDir, args); err != nil {
+			return "", models.NewAppError(
+				models.ErrorCodeInstallationFailed,
+				fmt.Sprintf("Failed to create hook virtualenv in %s", venvDir),
+				err,
+			)
+		}
+
+		venvPython = settings.VenvPython(venvDir)
+		if venvPython == "" {
+			return "", models.NewAppError(
+				models.ErrorCodeInstallationFailed,
+				fmt.Sprintf("Hook virtualenv in %s has no Python interpreter", venvDir),
+				nil,
+			)
+		}
+	}
+
+	// Keep the virtualenv out of version control whatever the gitignore mode
+	gitignorePath := filepath.Join(venvDir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte("*\n"), config.FilePermissions); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, gitignorePath, err)
+	}
+
+	args := []string{venvPython, "-m", "pip", "install", "--disable-pip-version-check"}
+	if filepath.Base(depFile) == config.HookRequirementsFile {
+		args = append(args, "-r", depFile)
+	} else {
+		args = append(args, filepath.Dir(depFile))
+	}
+
+	if err := s.run(targetDir, args); err != nil {
+		return "", models.NewAppError(
+			models.ErrorCodeInstallationFailed,
+			fmt.Sprintf("Failed to install hook dependencies from %s", depFile),
+			err,
+		)
+	}
+
+	return venvPython, nil
+}
+
+// run executes a command in the target directory, streaming its output
+func (s *Service) run(targetDir string, args []string) error {
+	cmd := exec.CommandContext(s.ctx, args[0], args[1:]...)
+	cmd.Dir = targetDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
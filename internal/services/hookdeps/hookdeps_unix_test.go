@@ -0,0 +1,109 @@
+//go:build unix
+
+package hookdeps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/settings"
+)
+
+// fakePython stands in for python3: "-m venv DIR" creates DIR/bin/python3 as a copy of
+// itself, and "-m pip ..." records its arguments next to the interpreter
+const fakePython = `#!/bin/sh
+if [ "$1 $2" = "-m venv" ]; then
+	mkdir -p "$3/bin" && cp "$0" "$3/bin/python3" && exit 0
+fi
+echo "$@" > "$(dirname "$0")/pip.log"
+`
+
+func TestFindDependencyFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []string
+		want   string
+		wantOK bool
+	}{
+		{"requirements", []string{config.HookRequirementsFile}, config.HookRequirementsFile, true},
+		{"pyproject", []string{config.HookPyprojectFile}, config.HookPyprojectFile, true},
+		{"both prefer requirements", []string{config.HookPyprojectFile, config.HookRequirementsFile}, config.HookRequirementsFile, true},
+		{"none", []string{"hook.py"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooksDir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(""), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			got, ok := FindDependencyFile(hooksDir)
+			if ok != tt.wantOK {
+				t.Fatalf("FindDependencyFile() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && filepath.Base(got) != tt.want {
+				t.Errorf("FindDependencyFile() = %s, want %s", filepath.Base(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestService_Install(t *testing.T) {
+	targetDir := t.TempDir()
+	hooksDir := HooksDir(targetDir)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Failed to create hooks directory: %v", err)
+	}
+	depFile := filepath.Join(hooksDir, config.HookRequirementsFile)
+	if err := os.WriteFile(depFile, []byte("requests\n"), 0644); err != nil {
+		t.Fatalf("Failed to write requirements: %v", err)
+	}
+
+	python := filepath.Join(t.TempDir(), "python3")
+	if err := os.WriteFile(python, []byte(fakePython), 0755); err != nil {
+		t.Fatalf("Failed to write fake python: %v", err)
+	}
+
+	venvPython, err := New().Install(targetDir, python, depFile)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	venvDir := settings.HookVenvPath(targetDir)
+	if want := filepath.Join(venvDir, "bin", "python3"); venvPython != want {
+		t.Errorf("Install() = %s, want %s", venvPython, want)
+	}
+
+	log, err := os.ReadFile(filepath.Join(venvDir, "bin", "pip.log"))
+	if err != nil {
+		t.Fatalf("pip was not run with the virtualenv interpreter: %v", err)
+	}
+	if want := "-m pip install --disable-pip-version-check -r " + depFile; strings.TrimSpace(string(log)) != want {
+		t.Errorf("pip arguments = %q, want %q", strings.TrimSpace(string(log)), want)
+	}
+
+	gitignore, err := os.ReadFile(filepath.Join(venvDir, ".gitignore"))
+	if err != nil || string(gitignore) != "*\n" {
+		t.Errorf("virtualenv .gitignore = %q, %v, want \"*\\n\"", gitignore, err)
+	}
+
+	// Hook commands now resolve to the virtualenv
+	if got, want := settings.DetectPython(targetDir), "$CLAUDE_PROJECT_DIR/.strategic-claude-basic/.venv/bin/python3"; got != want {
+		t.Errorf("DetectPython() = %s, want %s", got, want)
+	}
+}
+
+func TestService_Install_FailingPython(t *testing.T) {
+	targetDir := t.TempDir()
+	depFile := filepath.Join(t.TempDir(), config.HookRequirementsFile)
+
+	if _, err := New().Install(targetDir, "false", depFile); err == nil {
+		t.Errorf("Install() error = nil, want error when the virtualenv cannot be created")
+	}
+}
@@ -0,0 +1,47 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// SetContext makes Install stop once ctx is done, e.g. at the deadline set
+// with init --timeout: git commands, installation scripts and the hook
+// dependency install are stopped, copies stop before the next file, and what
+// was installed so far is rolled back
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+	s.gitService.SetContext(ctx)
+	s.filesystemService.SetContext(ctx)
+	s.scriptService.SetContext(ctx)
+	s.hookDepsService.SetContext(ctx)
+	s.pluginService.SetContext(ctx)
+	s.hookCheckService.SetContext(ctx)
+}
+
+// handleDeadline turns the error of an installation stopped by its deadline
+// into a timeout error. Framework files being changed are rolled back like
+// after a failed script, so the project is not left half installed.
+func (s *Service) handleDeadline(plan *models.InstallationPlan, installErr error) error {
+	if installErr == nil || s.ctx.Err() == nil {
+		return installErr
+	}
+	timeoutErr := models.NewTimeoutError("installation", installErr)
+	if !s.changing || s.rolledBack {
+		return timeoutErr
+	}
+
+	// The rollback copies files too, which the expired context would stop
+	ctx := s.ctx
+	s.SetContext(context.Background())
+	defer s.SetContext(ctx)
+
+	s.reporter.Step("Rolling back the installation")
+	if err := s.rollback(plan); err != nil {
+		return fmt.Errorf("%w; rollback failed: %v", timeoutErr, err)
+	}
+	s.rolledBack = true
+	return fmt.Errorf("%w; the installation was rolled back", timeoutErr)
+}
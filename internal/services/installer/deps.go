@@ -0,0 +1,142 @@
+package installer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/script"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/settings"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+)
+
+// GitClient fetches template repositories. The git service shells out to git;
+// other backends can fetch the same content another way.
+type GitClient interface {
+	// SetContext makes git commands stop when ctx is done
+	SetContext(ctx context.Context)
+	SetAuthToken(token string)
+	SetTimeout(timeout time.Duration)
+	SetSparsePaths(paths ...string)
+	// CloneRepositoryWithBranch checks out commit into a new temporary directory and returns it
+	CloneRepositoryWithBranch(url, branch, commit string) (string, error)
+	// CleanupTempDir removes a directory returned by CloneRepositoryWithBranch
+	CleanupTempDir(path string) error
+	Run(dir, url string, args ...string) (string, error)
+	FindRepoRoot(dir string) (string, error)
+}
+
+// FS performs the file operations of an installation
+type FS interface {
+	// SetContext makes copies stop before the next file when ctx is done
+	SetContext(ctx context.Context)
+	SetOwner(uid, gid int)
+	SetCopyStrategy(strategy string)
+	// SetSymlinkPolicy selects how CopyDirectory copies symlinks
+	SetSymlinkPolicy(policy string) error
+	// RecordHashes makes copies record the hashes of the files they write, see Hashes
+	RecordHashes()
+	Hashes() map[string]models.FileHash
+	// SetCopyLimit resets the copy totals and makes copies fail above the limits; 0 leaves a limit off
+	SetCopyLimit(maxBytes int64, maxFiles int)
+	CopyTotals() models.CopyTotals
+	ChownTree(path string) error
+	// MakeReadOnly removes the write bits of the files below root
+	MakeReadOnly(root string) error
+	CreateDirectory(path string) error
+	CopyFile(sourcePath, destPath string) error
+	CopyDirectory(sourcePath, destPath string) error
+	CopyFrameworkFiles(sourceDir, destDir string) error
+	CopyFrameworkDirectories(sourceDir, destDir string, dirs []string) error
+	LinkFrameworkFiles(sourceDir, destDir string) error
+	PreserveUserContent(targetDir string) error
+	RemoveStrategicClaudeBasic(targetDir string) error
+	RemoveStrategicClaudeBasicExcept(targetDir string, keep []string) error
+	BackupDirectory(sourcePath, backupPath string) error
+	GetBackupPath(targetDir, label string) string
+	ApplyIgnoreTemplate(templatePath, targetPath string) error
+}
+
+// SymlinkManager links the framework into the tool directories
+type SymlinkManager interface {
+	// SetStyle makes the symlinks be written in style instead of the recorded one
+	SetStyle(style string)
+	CreateSymlinks(targetDir string) error
+	CreateCodexSymlinks(targetDir string) error
+	RemoveCodexSymlinks(targetDir string) error
+}
+
+// SettingsManager merges the template settings into .claude/settings.json
+type SettingsManager interface {
+	SetHookPython(command string)
+	SetHookRunner(runner string) error
+	ProcessSettings(targetDir string) error
+	PreviewSettings(targetDir, templatePath, runner string) (current, merged []byte, err error)
+}
+
+// ScriptRunner runs the template's pre- and post-install scripts
+type ScriptRunner interface {
+	// SetContext makes running scripts stop when ctx is done
+	SetContext(ctx context.Context)
+	ScriptExists(sourceDir, scriptName string) bool
+	InspectScript(dir, scriptName string) (*models.ScriptInfo, error)
+	// SetPins makes ExecuteScript refuse scripts that do not match the pinned hashes
+	SetPins(pins map[string]string, allowUnpinned bool)
+	CheckPin(info models.ScriptInfo) error
+	// SetIsolation returns an error, and keeps running scripts directly, when
+	// the isolation mode is not available
+	SetIsolation(mode string) error
+	CopyScript(sourceDir, targetDir, scriptName string) error
+	// ExecuteScript returns the result whenever the script ran, also when it failed
+	ExecuteScript(targetDir, scriptName string) (*models.ScriptResult, error)
+	RemoveScript(targetDir, scriptName string) error
+}
+
+// The default implementations
+var (
+	_ GitClient       = (*git.Service)(nil)
+	_ FS              = (*filesystem.Service)(nil)
+	_ SymlinkManager  = (*symlink.Service)(nil)
+	_ SettingsManager = (*settings.Service)(nil)
+	_ ScriptRunner    = (*script.Service)(nil)
+)
+
+// Option replaces a dependency of the installer
+type Option func(*Service)
+
+// WithGitClient fetches template repositories with client
+func WithGitClient(client GitClient) Option {
+	return func(s *Service) {
+		s.gitService = client
+	}
+}
+
+// WithFS performs file operations with fs
+func WithFS(fs FS) Option {
+	return func(s *Service) {
+		s.filesystemService = fs
+	}
+}
+
+// WithSymlinkManager creates symlinks with manager
+func WithSymlinkManager(manager SymlinkManager) Option {
+	return func(s *Service) {
+		s.symlinkService = manager
+	}
+}
+
+// WithSettingsManager merges settings with manager
+func WithSettingsManager(manager SettingsManager) Option {
+	return func(s *Service) {
+		s.settingsService = manager
+	}
+}
+
+// WithScriptRunner runs installation scripts with runner
+func WithScriptRunner(runner ScriptRunner) Option {
+	return func(s *Service) {
+		s.scriptService = runner
+	}
+}
@@ -1,46 +1,124 @@
 package installer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/backup"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/catalog"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/codexconfig"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/direnv"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/hookcheck"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/hookdeps"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/manifest"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/plugin"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/script"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/settings"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/toolconfig"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/verify"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
 // Service provides installation functionality for the Strategic Claude Basic framework
 type Service struct {
-	gitService        *git.Service
-	filesystemService *filesystem.Service
-	statusService     *status.Service
-	symlinkService    *symlink.Service
-	settingsService   *settings.Service
+	gitService         GitClient
+	filesystemService  FS
+	statusService      *status.Service
+	symlinkService     SymlinkManager
+	settingsService    SettingsManager
 	codexConfigService *codexconfig.Service
-	scriptService     *script.Service
+	cursorService      *cursor.Service
+	toolConfigService  *toolconfig.Service
+	direnvService      *direnv.Service
+	scriptService      ScriptRunner
+	verifyService      *verify.Service
+	bundleService      *bundle.Service
+	cacheService       *cache.Service
+	hookDepsService    *hookdeps.Service
+	backupService      *backup.Service
+	catalogService     *catalog.Service
+	manifestService    *manifest.Service
+	pluginService      *plugin.Service
+	hookCheckService   *hookcheck.Service
+	reporter           reporter.Reporter
+	timings            models.StepTimings
+	scriptResults      []models.ScriptResult
+	scriptErrorPolicy  string
+	rolledBack         bool
+	copied             models.CopyTotals
+	changing           bool // The framework files are being changed
+	ctx                context.Context
+	steps              []Step
+	pathValidator      *utils.PathValidator
 }
 
-// New creates a new installer service instance
-func New() *Service {
-	return &Service{
-		gitService:        git.New(),
-		filesystemService: filesystem.New(),
-		statusService:     status.NewService(),
-		symlinkService:    symlink.New(),
-		settingsService:   settings.New(),
+// New creates a new installer service instance. Options replace the default
+// git, filesystem, symlink, settings and script services.
+func New(opts ...Option) *Service {
+	s := &Service{
+		gitService:         git.New(),
+		filesystemService:  filesystem.New(),
+		statusService:      status.NewService(),
+		symlinkService:     symlink.New(),
+		settingsService:    settings.New(),
 		codexConfigService: codexconfig.New(),
-		scriptService:     script.New(),
+		cursorService:      cursor.New(),
+		toolConfigService:  toolconfig.New(),
+		direnvService:      direnv.New(),
+		scriptService:      script.New(),
+		pathValidator:      utils.NewPathValidator(),
+		verifyService:      verify.New(),
+		bundleService:      bundle.New(),
+		cacheService:       cache.New(),
+		hookDepsService:    hookdeps.New(),
+		backupService:      backup.New(),
+		catalogService:     catalog.New(),
+		manifestService:    manifest.New(),
+		pluginService:      plugin.New(),
+		hookCheckService:   hookcheck.New(),
+		reporter:           reporter.Default(),
+		ctx:                context.Background(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.steps = s.defaultSteps()
+	return s
+}
+
+// SetReporter sets where progress and warnings are reported during installation
+func (s *Service) SetReporter(r reporter.Reporter) {
+	s.reporter = r
+	s.codexConfigService.SetReporter(r)
+}
+
+// Timings returns how long each step of the last installation took
+func (s *Service) Timings() models.StepTimings {
+	return slices.Clone(s.timings)
+}
+
+// ScriptResults returns the results of the scripts run by the last installation,
+// including a script that failed
+func (s *Service) ScriptResults() []models.ScriptResult {
+	return slices.Clone(s.scriptResults)
 }
 
 // AnalyzeInstallation examines the target directory and determines what type of installation is needed
@@ -63,6 +141,13 @@ func (s *Service) AnalyzeInstallation(installConfig models.InstallConfig) (*mode
 		)
 	}
 
+	// Refuse system and home directories unless explicitly overridden
+	if !installConfig.AllowSensitiveTarget {
+		if err := s.pathValidator.ValidateNotSensitive(absTarget); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check current installation status
 	currentStatus, err := s.statusService.CheckInstallation(absTarget)
 	if err != nil {
@@ -70,22 +155,50 @@ func (s *Service) AnalyzeInstallation(installConfig models.InstallConfig) (*mode
 	}
 
 	// Get template configuration
-	template, err := installConfig.GetTemplate()
+	template, source, err := s.resolveTemplate(installConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template configuration: %w", err)
 	}
 
 	// Determine installation type
-	installType := s.determineInstallationType(currentStatus, installConfig)
+	installType, err := s.determineInstallationType(currentStatus, installConfig)
+	if err != nil {
+		return nil, err
+	}
 	plan := models.NewInstallationPlan(absTarget, installType, template)
+	plan.TemplateSource = source
+	s.analyzeComponents(plan, currentStatus, installConfig)
+	s.analyzeCarryOver(plan, currentStatus, installConfig)
+	s.analyzeIntegrations(plan, currentStatus, installConfig)
+	s.analyzeFilesystem(plan)
+	plan.Direnv = installConfig.Direnv
 
 	// Analyze what will be done based on installation type
 	s.analyzeFileOperations(plan, currentStatus)
 
+	// Check for installations in parent directories
+	s.analyzeParentInstallations(plan, currentStatus, installConfig.AllowNested)
+
+	// Check that the target is version controlled
+	s.analyzeGitRepository(plan, installConfig.RequireGitRepo)
+
+	// Point out dev mode links that a regular install replaces
+	s.analyzeDevMode(plan, currentStatus)
+
+	// Choose the command that runs strategic hooks
+	s.analyzeHookRunner(plan, installConfig)
+
 	// Determine if backup is needed
 	plan.BackupRequired = s.needsBackup(plan, installConfig)
 	if plan.BackupRequired && !installConfig.NoBackup {
-		plan.BackupDir = s.filesystemService.GetBackupPath(absTarget)
+		// Name the backup after the installation it holds
+		plan.BackupInfo = &models.BackupInfo{}
+		if installed := currentStatus.InstalledTemplate; installed != nil {
+			plan.BackupInfo.Template = installed.Template.ID
+			plan.BackupInfo.Commit = installed.InstalledCommit
+			plan.BackupInfo.InstalledAt = installed.InstalledAt
+		}
+		plan.BackupDir = s.filesystemService.GetBackupPath(absTarget, config.BackupLabel(plan.BackupInfo.Template, plan.BackupInfo.Commit))
 	}
 
 	// Set up directory operations
@@ -94,19 +207,38 @@ func (s *Service) AnalyzeInstallation(installConfig models.InstallConfig) (*mode
 	// Set up symlink operations
 	s.analyzeSymlinkOperations(plan, currentStatus)
 
-	// Check for installation scripts
-	s.analyzeScriptOperations(plan)
+	// Check for installation scripts and where they run
+	s.analyzeScriptOperations(plan, installConfig)
+
+	plan.Steps = s.describeSteps(&Run{Config: installConfig, Plan: plan})
 
 	return plan, nil
 }
 
-// Install performs the complete installation process
-func (s *Service) Install(installConfig models.InstallConfig) error {
+// Install performs the complete installation process: it analyzes the target
+// and runs the installation steps in order, see Steps
+func (s *Service) Install(installConfig models.InstallConfig) (err error) {
+	timer := newStepTimer(s.reporter, installConfig.Verbose)
+	defer func() {
+		s.timings = timer.timings
+	}()
+	s.scriptResults = nil
+	s.scriptErrorPolicy = installConfig.ScriptErrorPolicy
+	if s.scriptErrorPolicy == "" {
+		s.scriptErrorPolicy = models.ScriptErrorAbort
+	}
+	s.rolledBack = false
+	s.copied = models.CopyTotals{}
+	s.changing = false
+
 	// Analyze what needs to be done
 	plan, err := s.AnalyzeInstallation(installConfig)
 	if err != nil {
 		return fmt.Errorf("installation analysis failed: %w", err)
 	}
+	defer func() {
+		err = s.handleDeadline(plan, err)
+	}()
 
 	// Validate the plan
 	if !plan.IsValid() {
@@ -117,114 +249,94 @@ func (s *Service) Install(installConfig models.InstallConfig) error {
 		)
 	}
 
-	// Create backup if needed
-	if plan.BackupRequired && !installConfig.NoBackup {
-		if err := s.CreateBackup(plan.TargetDir, plan.BackupDir); err != nil {
-			return fmt.Errorf("backup creation failed: %w", err)
-		}
-	}
-
-	// Get template configuration for cloning
-	template, err := installConfig.GetTemplate()
-	if err != nil {
-		return fmt.Errorf("failed to get template configuration: %w", err)
-	}
-
-	// Clone repository to temporary location using template configuration
-	tempDir, err := s.gitService.CloneRepositoryWithBranch(template.RepoURL, template.Branch, template.Commit)
-	if err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+	// Write strategic hook commands as analyzed
+	s.settingsService.SetHookPython(installConfig.HookPython)
+	if err := s.settingsService.SetHookRunner(installConfig.HookRunner); err != nil {
+		return err
 	}
-	defer func() {
-		if cleanupErr := s.gitService.CleanupTempDir(tempDir); cleanupErr != nil {
-			fmt.Printf("Warning: Failed to cleanup temporary directory: %v\n", cleanupErr)
-		}
-	}()
-
-	// Update plan with actual script detection
-	plan.HasPreInstallScript = s.scriptService.ScriptExists(tempDir, config.PreInstallScript)
-	plan.HasPostInstallScript = s.scriptService.ScriptExists(tempDir, config.PostInstallScript)
 
-	// Execute pre-install script if it exists
-	if plan.HasPreInstallScript {
-		if err := s.executePreInstallScript(tempDir, plan.TargetDir); err != nil {
-			return fmt.Errorf("pre-install script failed: %w", err)
+	// Hand created files to the user who invoked sudo instead of root
+	if installConfig.ChownUser {
+		if sudo, ok := utils.DetectSudo(); ok {
+			s.filesystemService.SetOwner(sudo.UID, sudo.GID)
 		}
 	}
-
-	// Perform the installation based on type
-	switch plan.InstallationType {
-	case models.InstallationTypeNew:
-		err = s.installNew(tempDir, plan.TargetDir)
-	case models.InstallationTypeUpdate:
-		err = s.InstallCore(tempDir, plan.TargetDir)
-	case models.InstallationTypeOverwrite:
-		err = s.installOverwrite(tempDir, plan.TargetDir)
-	default:
-		err = models.NewAppError(
-			models.ErrorCodeInstallationFailed,
-			fmt.Sprintf("Unknown installation type: %s", plan.InstallationType),
-			nil,
-		)
-	}
-
-	if err != nil {
-		return fmt.Errorf("installation failed: %w", err)
-	}
-
-	// Create .claude directory structure if needed
-	if err := s.ensureClaudeDirectory(plan.TargetDir); err != nil {
-		return fmt.Errorf("failed to create .claude directory structure: %w", err)
+	s.filesystemService.SetCopyStrategy(plan.CopyStrategy)
+	if err := s.filesystemService.SetSymlinkPolicy(installConfig.SymlinkPolicy); err != nil {
+		return err
 	}
+	s.filesystemService.RecordHashes()
 
-	// Create symlinks
-	if err := s.symlinkService.CreateSymlinks(plan.TargetDir); err != nil {
-		return fmt.Errorf("failed to create symlinks: %w", err)
+	// Collect backups left around the project by older versions
+	if moved, err := s.backupService.MigrateLegacyBackups(plan.TargetDir); err != nil {
+		s.reporter.Warn(fmt.Sprintf("Failed to migrate old backups: %v", err))
+	} else if len(moved) > 0 {
+		s.reporter.Info(fmt.Sprintf("Moved %d old backup(s) to %s", len(moved), config.GetBackupsRoot(plan.TargetDir)))
 	}
 
-	// Create Codex symlinks
-	if err := s.symlinkService.CreateCodexSymlinks(plan.TargetDir); err != nil {
-		return fmt.Errorf("failed to create codex symlinks: %w", err)
-	}
+	return s.runSteps(&Run{Config: installConfig, Plan: plan}, timer)
+}
 
-	// Process settings.json (merge template with existing user settings)
-	if err := s.settingsService.ProcessSettings(plan.TargetDir); err != nil {
-		return fmt.Errorf("failed to process settings: %w", err)
-	}
+// PreviewSettingsDiff fetches the template and returns a unified diff between the
+// project's .claude/settings.json and the result of merging the template settings
+// into it. The diff is empty when settings.json would not change.
+func (s *Service) PreviewSettingsDiff(installConfig models.InstallConfig, plan *models.InstallationPlan) (string, error) {
+	var diff string
+	err := s.withTemplate(installConfig, func(tempDir string) error {
+		templatePath := filepath.Join(tempDir, config.StrategicClaudeBasicDir, config.SettingsTemplateFile)
+		current, merged, err := s.settingsService.PreviewSettings(plan.TargetDir, templatePath, plan.HookCommand)
+		if err != nil {
+			return err
+		}
 
-	// Process Codex config.toml (copy template if it exists)
-	if err := s.codexConfigService.ProcessCodexConfig(plan.TargetDir); err != nil {
-		return fmt.Errorf("failed to process codex config: %w", err)
-	}
+		settingsFile := filepath.ToSlash(filepath.Join(config.ClaudeDir, config.ClaudeSettingsFile))
+		diff = utils.UnifiedDiff("a/"+settingsFile, "b/"+settingsFile, current, merged)
+		return nil
+	})
+	return diff, err
+}
 
-	// Execute post-install script if it exists
-	if plan.HasPostInstallScript {
-		if err := s.executePostInstallScript(tempDir, plan.TargetDir); err != nil {
-			return fmt.Errorf("post-install script failed: %w", err)
+// PreviewScripts fetches the template and records its installation scripts in
+// the plan, so they can be shown before anything is installed
+func (s *Service) PreviewScripts(installConfig models.InstallConfig, plan *models.InstallationPlan) error {
+	s.scriptService.SetPins(config.Current().ScriptHashes, installConfig.AllowUnpinnedScripts)
+	return s.withTemplate(installConfig, func(tempDir string) error {
+		if err := s.inspectScripts(tempDir, plan); err != nil {
+			return err
 		}
-	}
+		plan.Steps = s.describeSteps(&Run{Config: installConfig, Plan: plan})
+		return nil
+	})
+}
 
-	// Apply gitignore templates based on mode
-	if err := s.applyGitignoreTemplates(tempDir, plan.TargetDir, installConfig.GitignoreMode); err != nil {
-		return fmt.Errorf("failed to apply gitignore templates: %w", err)
+// withTemplate fetches and verifies the template content and calls fn with the
+// directory it was fetched to, which is removed afterwards
+func (s *Service) withTemplate(installConfig models.InstallConfig, fn func(tempDir string) error) error {
+	template, source, err := s.resolveTemplate(installConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get template configuration: %w", err)
 	}
 
-	// Save template metadata
-	if err := s.saveTemplateInfo(plan.TargetDir, template); err != nil {
-		return fmt.Errorf("failed to save template metadata: %w", err)
+	tempDir, cleanup, err := s.fetchTemplate(installConfig, template, source)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		if cleanupErr := cleanup(); cleanupErr != nil {
+			s.reporter.Warn(fmt.Sprintf("Failed to cleanup temporary directory: %v", cleanupErr))
+		}
+	}()
 
-	// Validate installation
-	if err := s.ValidateInstallation(plan.TargetDir); err != nil {
-		return fmt.Errorf("installation validation failed: %w", err)
+	if err := s.verifyTemplateContent(tempDir, template, installConfig.NoVerify); err != nil {
+		return fmt.Errorf("template verification failed: %w", err)
 	}
 
-	return nil
+	return fn(tempDir)
 }
 
 // InstallCore performs selective core updates (--force-core flag)
 func (s *Service) InstallCore(sourceDir, targetDir string) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Ensure target directory exists
 	if err := s.filesystemService.CreateDirectory(strategicDir); err != nil {
@@ -247,17 +359,64 @@ func (s *Service) InstallCore(sourceDir, targetDir string) error {
 		return fmt.Errorf("failed to process settings during core update: %w", err)
 	}
 
-	// Process Codex config.toml (update template if it exists)
-	if err := s.codexConfigService.ProcessCodexConfig(targetDir); err != nil {
-		return fmt.Errorf("failed to process codex config during core update: %w", err)
+	// Codex config.toml is processed by Install, which knows whether codex is selected
+
+	return nil
+}
+
+// installSharedIntegrations installs the template's Cursor rules, or removes them
+// when cursor is no longer selected, shares the framework conventions with Aider
+// and OpenCode and writes the .envrc block
+func (s *Service) installSharedIntegrations(plan *models.InstallationPlan) error {
+	if plan.HasIntegration(models.IntegrationCursor) {
+		if _, err := s.cursorService.InstallRules(plan.TargetDir, plan.CursorMode); err != nil {
+			return fmt.Errorf("failed to install cursor rules: %w", err)
+		}
+	} else if _, err := s.cursorService.RemoveRules(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to remove cursor rules: %w", err)
+	}
+
+	if err := s.installToolConfigs(plan); err != nil {
+		return fmt.Errorf("failed to configure aider and opencode: %w", err)
 	}
 
+	// Without --direnv an existing block is left as it is
+	if plan.Direnv {
+		if _, err := s.direnvService.InstallBlock(plan.TargetDir); err != nil {
+			return fmt.Errorf("failed to update %s: %w", config.EnvrcFile, err)
+		}
+	}
 	return nil
 }
 
+// installToolConfigs generates the conventions file and references it from the
+// Aider and OpenCode configurations of the selected integrations. Deselected
+// integrations lose their reference.
+func (s *Service) installToolConfigs(plan *models.InstallationPlan) error {
+	if plan.HasIntegration(models.IntegrationAider) || plan.HasIntegration(models.IntegrationOpenCode) {
+		if err := s.toolConfigService.GenerateConventions(plan.TargetDir); err != nil {
+			return err
+		}
+	}
+
+	if plan.HasIntegration(models.IntegrationAider) {
+		if _, err := s.toolConfigService.InstallAider(plan.TargetDir); err != nil {
+			return err
+		}
+	} else if _, err := s.toolConfigService.RemoveAider(plan.TargetDir); err != nil {
+		return err
+	}
+
+	if plan.HasIntegration(models.IntegrationOpenCode) {
+		return s.toolConfigService.InstallOpenCode(plan.TargetDir)
+	}
+	_, err := s.toolConfigService.RemoveOpenCode(plan.TargetDir)
+	return err
+}
+
 // CreateBackup creates a backup of the existing installation
-func (s *Service) CreateBackup(targetDir, backupPath string) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+func (s *Service) CreateBackup(targetDir, backupPath string, info *models.BackupInfo) error {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Check if strategic-claude-basic directory exists
 	if _, err := os.Stat(strategicDir); os.IsNotExist(err) {
@@ -269,6 +428,13 @@ func (s *Service) CreateBackup(targetDir, backupPath string) error {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
+	// Record what the backup contains
+	if info != nil {
+		if err := backup.WriteInfo(backupPath, *info); err != nil {
+			return fmt.Errorf("failed to record backup contents: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -302,54 +468,247 @@ func (s *Service) ValidateInstallation(targetDir string) error {
 
 // Helper methods
 
-func (s *Service) determineInstallationType(status *models.StatusInfo, installConfig models.InstallConfig) models.InstallationType {
+// determineInstallationType picks the installation type from the flags. An
+// existing installation is only replaced or updated when a flag says how; the
+// caller can ask the user and set Force or ForceCore.
+func (s *Service) determineInstallationType(status *models.StatusInfo, installConfig models.InstallConfig) (models.InstallationType, error) {
 	// If force is set, always do full overwrite
 	if installConfig.Force {
-		return models.InstallationTypeOverwrite
+		return models.InstallationTypeOverwrite, nil
 	}
 
-	// If force-core is set, do selective update
-	if installConfig.ForceCore {
-		return models.InstallationTypeUpdate
+	// If force-core is set, or only some components are installed, do selective update
+	if installConfig.ForceCore || (len(installConfig.Components) > 0 && status.IsInstalled) {
+		return models.InstallationTypeUpdate, nil
 	}
 
 	// If not installed, do new installation
 	if !status.IsInstalled {
-		return models.InstallationTypeNew
+		return models.InstallationTypeNew, nil
+	}
+
+	return "", models.NewAppError(
+		models.ErrorCodeAlreadyInstalled,
+		fmt.Sprintf("Strategic Claude Basic is already installed in %s; use --force-core to update core files or --force to reinstall", status.TargetDir),
+		nil,
+	)
+}
+
+// analyzeCarryOver records the user directories a full overwrite keeps; other
+// installation types keep all of them anyway
+func (s *Service) analyzeCarryOver(plan *models.InstallationPlan, status *models.StatusInfo, installConfig models.InstallConfig) {
+	if plan.InstallationType != models.InstallationTypeOverwrite {
+		return
+	}
+	userDirs := config.GetUserPreservedDirectories()
+	for _, dir := range installConfig.CarryOver {
+		if !slices.Contains(userDirs, dir) {
+			plan.AddError(fmt.Sprintf("--preserve takes user directories (%s), got %q", strings.Join(userDirs, ", "), dir))
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(status.TargetDir, config.FrameworkDir(), dir)); err == nil && !slices.Contains(plan.CarryOver, dir) {
+			plan.CarryOver = append(plan.CarryOver, dir)
+		}
+	}
+}
+
+// analyzeComponents records the components to install; installing only some of
+// them needs an installation to update
+func (s *Service) analyzeComponents(plan *models.InstallationPlan, status *models.StatusInfo, installConfig models.InstallConfig) {
+	plan.Components = installConfig.Components
+	if plan.IsPartial() && !status.IsInstalled {
+		plan.AddError(fmt.Sprintf("--only and --skip update an existing installation, but %s has none; install all components first", plan.TargetDir))
 	}
+}
 
-	// If installed and no force flags, this would be an error case
-	// The caller should handle this scenario
-	return models.InstallationTypeOverwrite
+// analyzeParentInstallations rejects installing below an existing installation unless nesting is allowed
+func (s *Service) analyzeParentInstallations(plan *models.InstallationPlan, status *models.StatusInfo, allowNested bool) {
+	for _, parent := range status.ParentInstallations {
+		message := fmt.Sprintf("Parent directory %s already contains a Strategic Claude Basic installation; hooks would be wired twice", parent)
+		if allowNested {
+			plan.AddWarning(message)
+		} else {
+			plan.AddError(message + " (use --allow-nested to install anyway)")
+		}
+	}
+}
+
+// analyzeGitRepository records the git work tree of the target and flags unversioned targets
+func (s *Service) analyzeGitRepository(plan *models.InstallationPlan, requireGitRepo bool) {
+	root, err := s.gitService.FindRepoRoot(plan.TargetDir)
+	if err == nil {
+		plan.GitRepoRoot = root
+		return
+	}
+
+	if requireGitRepo {
+		plan.AddError(fmt.Sprintf("Target directory %s is not inside a git repository (required by --require-git-repo)", plan.TargetDir))
+	} else {
+		plan.AddWarning("Target directory is not inside a git repository; framework files will not be version controlled")
+	}
+}
+
+// analyzeIntegrations selects the tool directories to set up: the requested
+// ones, otherwise those of the existing installation. The Cursor mode and the
+// symlink style follow the same rule and default to symlinks and relative targets.
+func (s *Service) analyzeIntegrations(plan *models.InstallationPlan, currentStatus *models.StatusInfo, installConfig models.InstallConfig) {
+	plan.Integrations = installConfig.Integrations
+	if len(plan.Integrations) == 0 {
+		plan.Integrations = currentStatus.Integrations
+	}
+
+	plan.CursorMode = installConfig.CursorMode
+	if plan.CursorMode == "" && currentStatus.InstalledTemplate != nil {
+		plan.CursorMode = currentStatus.InstalledTemplate.CursorMode
+	}
+	if plan.CursorMode == "" {
+		plan.CursorMode = models.CursorModeSymlink
+	}
+
+	plan.SymlinkStyle = installConfig.SymlinkStyle
+	if plan.SymlinkStyle == "" && currentStatus.InstalledTemplate != nil {
+		plan.SymlinkStyle = currentStatus.InstalledTemplate.SymlinkStyle
+	}
+	if plan.SymlinkStyle == "" {
+		plan.SymlinkStyle = config.SymlinkStyleRelative
+	}
+
+	if installConfig.ReadOnlyCore != nil {
+		plan.ReadOnlyCore = *installConfig.ReadOnlyCore
+	} else if currentStatus.InstalledTemplate != nil {
+		plan.ReadOnlyCore = currentStatus.InstalledTemplate.ReadOnlyCore
+	}
+
+	if currentStatus.HasIntegration(models.IntegrationCodex) && !plan.HasIntegration(models.IntegrationCodex) && currentStatus.CodexDir {
+		plan.AddWarning("Codex is no longer selected; the .codex/prompts/strategic and .codex/hooks/strategic symlinks will be removed")
+	}
+
+	if plan.HasIntegration(models.IntegrationAider) && s.toolConfigService.AiderReadConflict(plan.TargetDir) {
+		plan.AddWarning(fmt.Sprintf("%s already sets read; add %s/%s to it to share the framework conventions with Aider",
+			config.AiderConfigFile, config.FrameworkDir(), config.ConventionsFile))
+	}
+}
+
+// analyzeFilesystem probes the file system of the target and picks the
+// strategies it supports: files are cloned where reflinks are available, and
+// a file system without symlinks, which the framework links need, is refused
+func (s *Service) analyzeFilesystem(plan *models.InstallationPlan) {
+	plan.CopyStrategy = models.CopyStrategyCopy
+
+	capabilities, err := filesystem.ProbeCapabilities(plan.TargetDir)
+	if err != nil {
+		plan.AddWarning(fmt.Sprintf("Could not probe the file system of the target directory: %v", err))
+		return
+	}
+	plan.Filesystem = capabilities
+
+	if capabilities.Reflinks {
+		plan.CopyStrategy = models.CopyStrategyReflink
+	}
+	if !capabilities.Symlinks {
+		plan.AddError(fmt.Sprintf("The file system of %s does not support symlinks, which link %s into the tool directories", capabilities.Dir, config.FrameworkDir()))
+	}
+}
+
+// analyzeDevMode warns when an installation made with init --dev is replaced by
+// copies of the template
+func (s *Service) analyzeDevMode(plan *models.InstallationPlan, currentStatus *models.StatusInfo) {
+	if currentStatus.DevTemplatePath == "" || plan.TemplateSource == models.TemplateSourceDev {
+		return
+	}
+	if plan.InstallationType == models.InstallationTypeNew {
+		return
+	}
+
+	plan.AddWarning(fmt.Sprintf("Framework directories link to the template checkout %s (dev mode); they will be replaced with copies. Use --dev --template-path to keep the links",
+		currentStatus.DevTemplatePath))
+}
+
+// analyzeHookRunner records the command for strategic hooks and warns when it cannot be found
+func (s *Service) analyzeHookRunner(plan *models.InstallationPlan, installConfig models.InstallConfig) {
+	plan.HookRunner = installConfig.HookRunner
+	if plan.HookRunner == "" {
+		plan.HookRunner = models.HookRunnerPython
+	}
+
+	python := strings.TrimSpace(installConfig.HookPython)
+	if python == "" && plan.HookRunner == models.HookRunnerPython {
+		python = settings.DetectPython(plan.TargetDir)
+	}
+	plan.HookCommand = models.HookRunnerCommand(plan.HookRunner, python)
+
+	if !settings.CommandAvailable(plan.TargetDir, plan.HookCommand) {
+		plan.AddWarning(fmt.Sprintf("Hook command %s was not found; strategic hooks will fail until it is installed (see --hook-runner and --hook-python)", plan.HookCommand))
+	}
+
+	// Hooks run from the dependency virtualenv, created with the interpreter checked above
+	if installConfig.InstallHookDeps {
+		plan.InstallHookDeps = true
+		plan.HookCommand = settings.HookVenvCommand(plan.TargetDir)
+	}
+}
+
+// installHookDependencies installs the dependencies declared by the template hooks
+// into the hook virtualenv and points strategic hook commands at it
+func (s *Service) installHookDependencies(targetDir, hookPython string) error {
+	depFile, ok := hookdeps.FindDependencyFile(hookdeps.HooksDir(targetDir))
+	if !ok {
+		s.reporter.Warn("--install-hook-deps was given but the template hooks declare no dependencies")
+		return nil
+	}
+
+	if hookPython == "" {
+		hookPython = settings.DetectPython(targetDir)
+	}
+
+	if _, err := s.hookDepsService.Install(targetDir, hookPython, depFile); err != nil {
+		return err
+	}
+
+	s.settingsService.SetHookPython(settings.HookVenvCommand(targetDir))
+	return nil
 }
 
 func (s *Service) analyzeFileOperations(plan *models.InstallationPlan, status *models.StatusInfo) {
-	strategicDir := filepath.Join(plan.TargetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(plan.TargetDir, config.FrameworkDir())
 
 	switch plan.InstallationType {
 	case models.InstallationTypeNew:
-		plan.WillCreate = append(plan.WillCreate, config.StrategicClaudeBasicDir)
+		plan.WillCreate = append(plan.WillCreate, config.FrameworkDir())
 	case models.InstallationTypeUpdate:
 		// Will replace only framework directories
 		frameworkDirs := config.GetCoreDirectories()
 		for _, dir := range frameworkDirs {
 			dirPath := filepath.Join(strategicDir, dir)
-			if _, err := os.Stat(dirPath); err == nil {
-				plan.WillReplace = append(plan.WillReplace, filepath.Join(config.StrategicClaudeBasicDir, dir))
+			if !plan.HasComponent(dir) {
+				plan.WillPreserve = append(plan.WillPreserve, filepath.Join(config.FrameworkDir(), dir))
+			} else if _, err := os.Stat(dirPath); err == nil {
+				plan.WillReplace = append(plan.WillReplace, filepath.Join(config.FrameworkDir(), dir))
 			} else {
-				plan.WillCreate = append(plan.WillCreate, filepath.Join(config.StrategicClaudeBasicDir, dir))
+				plan.WillCreate = append(plan.WillCreate, filepath.Join(config.FrameworkDir(), dir))
 			}
 		}
 		// Will preserve user directories
 		userDirs := config.GetUserPreservedDirectories()
 		for _, dir := range userDirs {
-			plan.WillPreserve = append(plan.WillPreserve, filepath.Join(config.StrategicClaudeBasicDir, dir))
+			plan.WillPreserve = append(plan.WillPreserve, filepath.Join(config.FrameworkDir(), dir))
 		}
 	case models.InstallationTypeOverwrite:
 		if status.StrategicClaudeDir {
-			plan.WillReplace = append(plan.WillReplace, config.StrategicClaudeBasicDir)
+			plan.WillReplace = append(plan.WillReplace, config.FrameworkDir())
+		} else {
+			plan.WillCreate = append(plan.WillCreate, config.FrameworkDir())
+		}
+		for _, dir := range plan.CarryOver {
+			plan.WillPreserve = append(plan.WillPreserve, filepath.Join(config.FrameworkDir(), dir))
+		}
+	}
+
+	if projectConfig, err := config.LoadProjectConfig(plan.TargetDir); err == nil && config.Instance() == "" && projectConfig.FrameworkDir != config.FrameworkDir() {
+		if _, err := os.Stat(config.ProjectConfigPath(plan.TargetDir)); err == nil {
+			plan.WillReplace = append(plan.WillReplace, config.ConfigFileName)
 		} else {
-			plan.WillCreate = append(plan.WillCreate, config.StrategicClaudeBasicDir)
+			plan.WillCreate = append(plan.WillCreate, config.ConfigFileName)
 		}
 	}
 }
@@ -365,8 +724,8 @@ func (s *Service) needsBackup(plan *models.InstallationPlan, installConfig model
 }
 
 func (s *Service) analyzeDirectoryOperations(plan *models.InstallationPlan, status *models.StatusInfo) {
-	// Always ensure .claude directory structure
-	if !status.ClaudeDir {
+	// Always ensure .claude directory structure, unless the hooks are left alone
+	if !status.ClaudeDir && plan.HasComponent(models.ComponentHooks) {
 		plan.DirectoriesToCreate = append(plan.DirectoriesToCreate, config.ClaudeDir)
 		plan.DirectoriesToCreate = append(plan.DirectoriesToCreate,
 			filepath.Join(config.ClaudeDir, config.AgentsDir))
@@ -378,6 +737,9 @@ func (s *Service) analyzeDirectoryOperations(plan *models.InstallationPlan, stat
 }
 
 func (s *Service) analyzeSymlinkOperations(plan *models.InstallationPlan, status *models.StatusInfo) {
+	if !plan.HasComponent(models.ComponentHooks) {
+		return
+	}
 	requiredSymlinks := config.GetRequiredSymlinks()
 
 	for symlinkPath := range requiredSymlinks {
@@ -393,20 +755,105 @@ func (s *Service) analyzeSymlinkOperations(plan *models.InstallationPlan, status
 
 func (s *Service) installNew(sourceDir, targetDir string) error {
 	sourceStrategicDir := filepath.Join(sourceDir, config.StrategicClaudeBasicDir)
-	targetStrategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	targetStrategicDir := filepath.Join(targetDir, config.FrameworkDir())
 
 	// Copy entire .strategic-claude-basic directory
 	return s.filesystemService.CopyDirectory(sourceStrategicDir, targetStrategicDir)
 }
 
-func (s *Service) installOverwrite(sourceDir, targetDir string) error {
+// installComponents copies the selected framework directories over an existing
+// installation, leaving the other ones and user content as they are
+func (s *Service) installComponents(sourceDir, targetDir string, components []string) error {
+	var dirs []string
+	for _, dir := range config.GetCoreDirectories() {
+		if slices.Contains(components, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	sourceStrategicDir := filepath.Join(sourceDir, config.StrategicClaudeBasicDir)
+	targetStrategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if err := s.filesystemService.CopyFrameworkDirectories(sourceStrategicDir, targetStrategicDir, dirs); err != nil {
+		return fmt.Errorf("failed to copy framework files: %w", err)
+	}
+	return s.filesystemService.PreserveUserContent(targetDir)
+}
+
+// installOverwrite replaces the installation with a fresh copy; the user
+// directories in carryOver are kept, and the template's are not copied over them
+func (s *Service) installOverwrite(sourceDir, targetDir string, carryOver []string) error {
 	// Remove existing installation
-	if err := s.filesystemService.RemoveStrategicClaudeBasic(targetDir); err != nil {
+	if err := s.filesystemService.RemoveStrategicClaudeBasicExcept(targetDir, carryOver); err != nil {
 		return err
 	}
 
 	// Install fresh copy
-	return s.installNew(sourceDir, targetDir)
+	if len(carryOver) == 0 {
+		return s.installNew(sourceDir, targetDir)
+	}
+	sourceStrategicDir := filepath.Join(sourceDir, config.StrategicClaudeBasicDir)
+	targetStrategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if err := s.copyMissing(sourceStrategicDir, targetStrategicDir, nil); err != nil {
+		return err
+	}
+	return s.filesystemService.PreserveUserContent(targetDir)
+}
+
+// installDev links the framework directories of a local template checkout into
+// the target instead of copying them, so edits in the checkout apply immediately.
+// The rest of the template's framework directory is copied where the target
+// does not have it yet, which keeps existing user content.
+func (s *Service) installDev(sourceDir, targetDir string, installType models.InstallationType, carryOver []string) error {
+	if installType == models.InstallationTypeOverwrite {
+		if err := s.filesystemService.RemoveStrategicClaudeBasicExcept(targetDir, carryOver); err != nil {
+			return err
+		}
+	}
+
+	sourceStrategicDir := filepath.Join(sourceDir, config.StrategicClaudeBasicDir)
+	targetStrategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if err := s.filesystemService.CreateDirectory(targetStrategicDir); err != nil {
+		return err
+	}
+
+	if err := s.filesystemService.LinkFrameworkFiles(sourceStrategicDir, targetStrategicDir); err != nil {
+		return fmt.Errorf("failed to link framework files: %w", err)
+	}
+	if err := s.copyMissing(sourceStrategicDir, targetStrategicDir, config.GetCoreDirectories()); err != nil {
+		return err
+	}
+
+	return s.filesystemService.PreserveUserContent(targetDir)
+}
+
+// copyMissing copies the entries of the template's framework directory that
+// the target's does not have yet, except the ones named in skip
+func (s *Service) copyMissing(sourceStrategicDir, targetStrategicDir string, skip []string) error {
+	entries, err := os.ReadDir(sourceStrategicDir)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, sourceStrategicDir, err)
+	}
+	for _, entry := range entries {
+		if slices.Contains(skip, entry.Name()) {
+			continue
+		}
+
+		targetPath := filepath.Join(targetStrategicDir, entry.Name())
+		if _, err := os.Lstat(targetPath); err == nil {
+			continue
+		}
+
+		sourcePath := filepath.Join(sourceStrategicDir, entry.Name())
+		if entry.IsDir() {
+			err = s.filesystemService.CopyDirectory(sourcePath, targetPath)
+		} else {
+			err = s.filesystemService.CopyFile(sourcePath, targetPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *Service) ensureClaudeDirectory(targetDir string) error {
@@ -430,8 +877,8 @@ func (s *Service) ensureClaudeDirectory(targetDir string) error {
 }
 
 // saveTemplateInfo saves template metadata to the installation directory
-func (s *Service) saveTemplateInfo(targetDir string, template templates.Template) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+func (s *Service) saveTemplateInfo(targetDir string, template templates.Template, plan *models.InstallationPlan) error {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 	templateInfoPath := filepath.Join(strategicDir, config.TemplateInfoFile)
 
 	// Create template info
@@ -439,8 +886,16 @@ func (s *Service) saveTemplateInfo(targetDir string, template templates.Template
 		Template:        template,
 		InstalledAt:     time.Now().Format(time.RFC3339),
 		InstalledCommit: template.Commit,
+		Integrations:    plan.Integrations,
+		SymlinkStyle:    plan.SymlinkStyle,
+		ReadOnlyCore:    plan.ReadOnlyCore,
+		FrameworkDir:    config.FrameworkDir(),
+		Instance:        config.Instance(),
 		Metadata:        make(map[string]string),
 	}
+	if plan.HasIntegration(models.IntegrationCursor) {
+		templateInfo.CursorMode = plan.CursorMode
+	}
 
 	// Add additional metadata
 	templateInfo.Metadata["cli_version"] = "0.1.0" // TODO: Get from build info
@@ -468,12 +923,297 @@ func (s *Service) saveTemplateInfo(targetDir string, template templates.Template
 	return nil
 }
 
+// saveProjectConfig writes the framework directory name to the project config
+// file unless the file already has it; a default installation writes no file,
+// and neither do named installations, which have their own directory names
+func (s *Service) saveProjectConfig(targetDir string) error {
+	if config.Instance() != "" {
+		return nil
+	}
+	projectConfig, err := config.LoadProjectConfig(targetDir)
+	if err != nil {
+		return err
+	}
+	if projectConfig.FrameworkDir == config.FrameworkDir() {
+		return nil
+	}
+	return config.SaveProjectFrameworkDir(targetDir, config.FrameworkDir())
+}
+
+// saveManifest records the installed framework files in the install manifest,
+// with the hashes computed while copying them. Files are read again only when
+// a post-install script ran, as it may have changed them.
+func (s *Service) saveManifest(plan *models.InstallationPlan) error {
+	var known map[string]models.FileHash
+	if !plan.HasPostInstallScript {
+		known = s.filesystemService.Hashes()
+	}
+	installed, err := s.manifestService.BuildWithHashes(plan.TargetDir, known)
+	if err != nil {
+		return err
+	}
+	return s.manifestService.Write(plan.TargetDir, installed)
+}
+
+// resolveDevTemplate describes the local checkout used in dev mode as the
+// selected template, with the checkout's current branch and commit when it is a
+// git repository. Verification does not apply to work in progress.
+func (s *Service) resolveDevTemplate(installConfig models.InstallConfig) (templates.Template, models.TemplateSource, error) {
+	checkout, err := filepath.Abs(installConfig.DevTemplatePath)
+	if err != nil {
+		return templates.Template{}, "", models.NewFileSystemError(models.ErrorCodeInvalidPath, installConfig.DevTemplatePath, err)
+	}
+	if info, err := os.Stat(filepath.Join(checkout, config.StrategicClaudeBasicDir)); err != nil || !info.IsDir() {
+		return templates.Template{}, "", models.NewValidationError("template-path", installConfig.DevTemplatePath,
+			fmt.Sprintf("must be a template checkout containing %s/", config.StrategicClaudeBasicDir))
+	}
+
+	template, err := installConfig.GetTemplate()
+	if err != nil {
+		return templates.Template{}, "", err
+	}
+	template.RepoURL = checkout
+	template.Branch = ""
+	template.Commit = ""
+	if commit, err := s.gitService.Run(checkout, "", "rev-parse", "HEAD"); err == nil {
+		template.Commit = commit
+		template.Branch, _ = s.gitService.Run(checkout, "", "rev-parse", "--abbrev-ref", "HEAD")
+	}
+	template.TreeHash = ""
+	template.PublicKey = ""
+
+	return template, models.TemplateSourceDev, nil
+}
+
+// resolveBundleTemplate returns the registered template a bundle was created
+// from. The template in the bundle's manifest is not trusted, since whoever
+// altered the content could have rewritten it too: the bundle must hold a known
+// template at its pinned commit, and the content is verified against the tree
+// hash and signing key of the registry.
+func (s *Service) resolveBundleTemplate(bundlePath string) (templates.Template, error) {
+	manifest, err := s.bundleService.ReadManifest(bundlePath)
+	if err != nil {
+		return templates.Template{}, err
+	}
+	return bundle.RegisteredTemplate(manifest, bundlePath)
+}
+
+// resolveTemplate returns the template to install and where its content comes from.
+// A dev mode checkout takes precedence, then bundles, then a vendored copy of the
+// requested template.
+func (s *Service) resolveTemplate(installConfig models.InstallConfig) (templates.Template, models.TemplateSource, error) {
+	if installConfig.DevTemplatePath != "" {
+		return s.resolveDevTemplate(installConfig)
+	}
+
+	if installConfig.BundlePath != "" {
+		template, err := s.resolveBundleTemplate(installConfig.BundlePath)
+		return template, models.TemplateSourceBundle, err
+	}
+
+	if bundle.IsVendored(installConfig.TargetDir) {
+		manifest, err := s.bundleService.ReadVendorManifest(bundle.VendorPath(installConfig.TargetDir))
+		if err != nil {
+			return templates.Template{}, "", err
+		}
+		if manifest.Template.ID == installConfig.TemplateID {
+			// Like bundles, the copy is checked against the registry rather than its manifest
+			template, err := bundle.RegisteredTemplate(manifest, bundle.VendorPath(installConfig.TargetDir))
+			return template, models.TemplateSourceVendored, err
+		}
+	}
+
+	template, err := installConfig.GetTemplate()
+	return template, models.TemplateSourceRepository, err
+}
+
+// fetchTemplate makes the template content available in a temporary directory
+// and returns a function that removes it again
+func (s *Service) fetchTemplate(installConfig models.InstallConfig, template templates.Template, source models.TemplateSource) (string, func() error, error) {
+	switch source {
+	case models.TemplateSourceDev:
+		// The checkout is used in place and must not be removed afterwards
+		return template.RepoURL, func() error { return nil }, nil
+	case models.TemplateSourceBundle:
+		b, err := s.bundleService.Open(installConfig.BundlePath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to open bundle: %w", err)
+		}
+		if !template.RequiresVerification() {
+			s.reporter.Warn(fmt.Sprintf("Template '%s' declares no tree hash or signing key, so bundle %s is only checked against its own manifest", template.ID, installConfig.BundlePath))
+		}
+		return b.ContentDir, b.Cleanup, nil
+	case models.TemplateSourceVendored:
+		b, err := s.bundleService.OpenVendor(bundle.VendorPath(installConfig.TargetDir))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to open vendored template: %w", err)
+		}
+		if !template.RequiresVerification() {
+			s.reporter.Warn(fmt.Sprintf("Template '%s' declares no tree hash or signing key, so the vendored copy is only checked against its own manifest", template.ID))
+		}
+		return b.ContentDir, b.Cleanup, nil
+	}
+
+	// Sparse checkouts are partial, so they neither read from nor populate the cache
+	useCache := !installConfig.NoCache && !installConfig.SparseCheckout
+	if useCache {
+		if tempDir, ok := s.checkoutFromCache(template); ok {
+			return tempDir, func() error { return s.gitService.CleanupTempDir(tempDir) }, nil
+		}
+	}
+
+	s.gitService.SetAuthToken(installConfig.AuthToken)
+	if installConfig.GitTimeout > 0 {
+		s.gitService.SetTimeout(installConfig.GitTimeout)
+	}
+	if installConfig.SparseCheckout {
+		s.gitService.SetSparsePaths(config.StrategicClaudeBasicDir)
+	}
+	tempDir, err := s.gitService.CloneRepositoryWithBranch(template.RepoURL, template.Branch, template.Commit)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	if useCache {
+		if err := s.cacheService.Store(template.RepoURL, template.Commit, template.ID, tempDir); err != nil {
+			s.reporter.Warn(fmt.Sprintf("Failed to cache template repository: %v", err))
+		}
+	}
+
+	return tempDir, func() error { return s.gitService.CleanupTempDir(tempDir) }, nil
+}
+
+// checkoutFromCache copies a cached template commit into a fresh temporary directory
+func (s *Service) checkoutFromCache(template templates.Template) (string, bool) {
+	cachedDir, ok := s.cacheService.Lookup(template.RepoURL, template.Commit)
+	if !ok {
+		return "", false
+	}
+
+	tempDir, err := os.MkdirTemp(config.TempDir(), config.TempDirPrefix)
+	if err != nil {
+		return "", false
+	}
+
+	if err := s.filesystemService.CopyDirectory(cachedDir, tempDir); err != nil {
+		_ = s.gitService.CleanupTempDir(tempDir)
+		return "", false
+	}
+
+	return tempDir, true
+}
+
+// applyOwnership hands every path the installation touches to the configured owner
+func (s *Service) applyOwnership(plan *models.InstallationPlan) error {
+	var paths []string
+	for _, path := range config.GetInstalledPaths() {
+		paths = append(paths, filepath.Join(plan.TargetDir, path))
+	}
+	paths = append(paths, config.GetBackupsRoot(plan.TargetDir))
+
+	for _, path := range paths {
+		if err := s.filesystemService.ChownTree(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreVendoredCopy puts the staged vendored template back if the installation removed it
+func (s *Service) restoreVendoredCopy(contentDir, targetDir string) error {
+	vendorDir := bundle.VendorPath(targetDir)
+	if _, err := os.Stat(vendorDir); !os.IsNotExist(err) {
+		return nil
+	}
+
+	// The staged copy mirrors the vendor directory: manifest plus content
+	return s.filesystemService.CopyDirectory(filepath.Dir(contentDir), vendorDir)
+}
+
+// verifyTemplateContent checks the fetched template content the installer copies,
+// reads or executes, i.e. the framework directory and the installation scripts,
+// against the tree hash and detached signature declared by the template
+func (s *Service) verifyTemplateContent(sourceDir string, template templates.Template, noVerify bool) error {
+	if !template.RequiresVerification() {
+		return nil
+	}
+
+	if noVerify {
+		s.reporter.Warn(fmt.Sprintf("Skipping verification of template '%s' (--no-verify)", template.ID))
+		return nil
+	}
+
+	paths := config.GetVerifiedTemplatePaths()
+
+	if template.TreeHash != "" {
+		if err := s.verifyService.VerifyTreeHash(sourceDir, template.TreeHash, paths...); err != nil {
+			return err
+		}
+	}
+
+	if template.PublicKey != "" {
+		signaturePath := filepath.Join(sourceDir, config.TemplateSignatureFile)
+		if err := s.verifyService.VerifySignature(sourceDir, signaturePath, template.PublicKey, paths...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // analyzeScriptOperations checks if installation scripts exist in the template
-func (s *Service) analyzeScriptOperations(plan *models.InstallationPlan) {
+// and selects where they run; when Docker isolation was asked for but Docker
+// is not available, the plan warns that they run without isolation
+func (s *Service) analyzeScriptOperations(plan *models.InstallationPlan, installConfig models.InstallConfig) {
 	// This will be set after the repository is cloned, but we can initialize it here
 	// The actual check will happen in the Install method once we have the temporary directory
 	plan.HasPreInstallScript = false
 	plan.HasPostInstallScript = false
+
+	plan.ScriptIsolation = models.ScriptIsolationNone
+	if err := s.scriptService.SetIsolation(installConfig.ScriptIsolation); err != nil {
+		if !plan.IsPartial() {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("%v; installation scripts will run without isolation", err))
+		}
+		return
+	}
+	if installConfig.ScriptIsolation == models.ScriptIsolationDocker {
+		plan.ScriptIsolation = models.ScriptIsolationDocker
+	}
+}
+
+// inspectScripts records the installation scripts of the template in sourceDir
+// in the plan; installing only some components runs no scripts
+func (s *Service) inspectScripts(sourceDir string, plan *models.InstallationPlan) error {
+	plan.Scripts = nil
+	if plan.IsPartial() {
+		return nil
+	}
+	for _, name := range []string{config.PreInstallScript, config.PostInstallScript} {
+		info, err := s.scriptService.InspectScript(sourceDir, name)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", name, err)
+		}
+		if info != nil {
+			plan.Scripts = append(plan.Scripts, *info)
+			if err := s.scriptService.CheckPin(*info); err != nil {
+				plan.AddError(fmt.Sprintf("%v; pin its hash in script_hashes or pass --allow-unpinned-scripts", err))
+			}
+		}
+	}
+	plan.HasPreInstallScript = plan.HasScript(config.PreInstallScript)
+	plan.HasPostInstallScript = plan.HasScript(config.PostInstallScript)
+	return nil
+}
+
+// executeScript runs a script copied to targetDir and records its result
+func (s *Service) executeScript(targetDir, scriptName string) error {
+	result, err := s.scriptService.ExecuteScript(targetDir, scriptName)
+	if result != nil {
+		s.scriptResults = append(s.scriptResults, *result)
+	}
+	return err
 }
 
 // executePreInstallScript copies and executes the pre-install script
@@ -484,16 +1224,17 @@ func (s *Service) executePreInstallScript(sourceDir, targetDir string) error {
 	}
 
 	// Execute the script
-	if err := s.scriptService.ExecuteScript(targetDir, config.PreInstallScript); err != nil {
-		return fmt.Errorf("failed to execute pre-install script: %w", err)
-	}
+	execErr := s.executeScript(targetDir, config.PreInstallScript)
 
-	// Clean up script after execution
+	// Clean up script after execution, also when it failed
 	if err := s.scriptService.RemoveScript(targetDir, config.PreInstallScript); err != nil {
 		// Log warning but don't fail installation
-		fmt.Printf("Warning: Failed to remove pre-install script: %v\n", err)
+		s.reporter.Warn(fmt.Sprintf("Failed to remove pre-install script: %v", err))
 	}
 
+	if execErr != nil {
+		return fmt.Errorf("failed to execute pre-install script: %w", execErr)
+	}
 	return nil
 }
 
@@ -505,16 +1246,17 @@ func (s *Service) executePostInstallScript(sourceDir, targetDir string) error {
 	}
 
 	// Execute the script
-	if err := s.scriptService.ExecuteScript(targetDir, config.PostInstallScript); err != nil {
-		return fmt.Errorf("failed to execute post-install script: %w", err)
-	}
+	execErr := s.executeScript(targetDir, config.PostInstallScript)
 
-	// Clean up script after execution
+	// Clean up script after execution, also when it failed
 	if err := s.scriptService.RemoveScript(targetDir, config.PostInstallScript); err != nil {
 		// Log warning but don't fail installation
-		fmt.Printf("Warning: Failed to remove post-install script: %v\n", err)
+		s.reporter.Warn(fmt.Sprintf("Failed to remove post-install script: %v", err))
 	}
 
+	if execErr != nil {
+		return fmt.Errorf("failed to execute post-install script: %w", execErr)
+	}
 	return nil
 }
 
@@ -531,28 +1273,64 @@ func (s *Service) applyGitignoreTemplates(sourceDir, targetDir, gitignoreMode st
 	switch gitignoreMode {
 	case "all":
 		templateMappings = map[string]string{
-			"dot_claude-strategic-ignore.template":           ".claude/.gitignore",
-			"dot_strategic-claude-basic-ignore-all.template": ".strategic-claude-basic/.gitignore",
+			config.ClaudeIgnoreTemplate:       ".claude/.gitignore",
+			config.StrategicIgnoreAllTemplate: config.FrameworkDir() + "/.gitignore",
 		}
 	case "non-user":
 		templateMappings = map[string]string{
-			"dot_claude-strategic-ignore.template":                     ".claude/.gitignore",
-			"dot_strategic-claude-basic-ignore-non-user-dirs.template": ".strategic-claude-basic/.gitignore",
+			config.ClaudeIgnoreTemplate:           ".claude/.gitignore",
+			config.StrategicIgnoreNonUserTemplate: config.FrameworkDir() + "/.gitignore",
 		}
 	default:
 		return fmt.Errorf("unsupported gitignore mode: %s", gitignoreMode)
 	}
 
-	// Apply each template
-	for templateFile, targetFile := range templateMappings {
-		templatePath := filepath.Join(sourceDir, config.StrategicClaudeBasicDir, "templates", "ignore", templateFile)
-		targetPath := filepath.Join(targetDir, targetFile)
+	return s.applyIgnoreTemplates(sourceDir, targetDir, templateMappings)
+}
+
+// applyToolIgnoreTemplates adds the entries of the template's ignore snippets for
+// other tools, such as .dockerignore, to the project. Templates without a snippet
+// are skipped, and an ignore file is only created for a tool the project uses.
+func (s *Service) applyToolIgnoreTemplates(sourceDir, targetDir string) error {
+	exists := func(name string) bool {
+		_, err := os.Lstat(filepath.Join(targetDir, name))
+		return err == nil
+	}
+
+	templateMappings := make(map[string]string)
+	for _, ignoreFile := range config.GetToolIgnoreFiles() {
+		templatePath := filepath.Join(sourceDir, config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir, ignoreFile.Template)
+		if _, err := os.Stat(templatePath); err != nil {
+			continue
+		}
+		if !exists(ignoreFile.Target) && !slices.ContainsFunc(ignoreFile.Markers, exists) {
+			continue
+		}
+		templateMappings[ignoreFile.Template] = ignoreFile.Target
+	}
+
+	return s.applyIgnoreTemplates(sourceDir, targetDir, templateMappings)
+}
+
+// applyIgnoreTemplates merges each ignore template, named within templates/ignore
+// of the source, into its target file relative to the project
+func (s *Service) applyIgnoreTemplates(sourceDir, targetDir string, templateMappings map[string]string) error {
+	for _, templateFile := range slices.Sorted(maps.Keys(templateMappings)) {
+		targetFile := templateMappings[templateFile]
+		templatePath, err := s.pathValidator.SafeJoin(sourceDir, config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir, templateFile)
+		if err != nil {
+			return err
+		}
+		targetPath, err := s.pathValidator.SafeJoin(targetDir, targetFile)
+		if err != nil {
+			return err
+		}
 
-		if err := s.filesystemService.ApplyGitignoreTemplate(templatePath, targetPath); err != nil {
+		if err := s.filesystemService.ApplyIgnoreTemplate(templatePath, targetPath); err != nil {
 			return fmt.Errorf("failed to apply template %s: %w", templateFile, err)
 		}
 
-		fmt.Printf("Applied gitignore template: %s -> %s\n", templateFile, targetFile)
+		s.reporter.Info(fmt.Sprintf("Applied ignore template: %s -> %s", templateFile, targetFile))
 	}
 
 	return nil
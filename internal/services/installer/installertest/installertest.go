@@ -0,0 +1,208 @@
+// Package installertest provides fakes for the installer's dependencies, so
+// installations can be tested without git, a network or running scripts.
+package installertest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+)
+
+// LocalGit is a GitClient that clones by copying a local template checkout
+type LocalGit struct {
+	// SourceDir is copied for every clone, whatever the URL
+	SourceDir string
+	// RepoRoot is returned by FindRepoRoot; empty reports no repository
+	RepoRoot string
+
+	mu          sync.Mutex
+	ctx         context.Context
+	clones      []string
+	authToken   string
+	sparsePaths []string
+}
+
+// NewLocalGit returns a LocalGit copying sourceDir
+func NewLocalGit(sourceDir string) *LocalGit {
+	return &LocalGit{SourceDir: sourceDir}
+}
+
+func (g *LocalGit) SetAuthToken(token string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.authToken = token
+}
+
+// SetContext makes clones fail once ctx is done
+func (g *LocalGit) SetContext(ctx context.Context) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ctx = ctx
+}
+
+// SetTimeout is ignored; copies are not limited in time
+func (g *LocalGit) SetTimeout(timeout time.Duration) {}
+
+func (g *LocalGit) SetSparsePaths(paths ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sparsePaths = paths
+}
+
+func (g *LocalGit) CloneRepositoryWithBranch(url, branch, commit string) (string, error) {
+	g.mu.Lock()
+	g.clones = append(g.clones, url)
+	ctx := g.ctx
+	g.mu.Unlock()
+	if ctx != nil && ctx.Err() != nil {
+		return "", models.NewGitError(models.ErrorCodeGitCloneFailed, "clone "+url, ctx.Err())
+	}
+
+	tempDir, err := os.MkdirTemp(config.TempDir(), config.TempDirPrefix)
+	if err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, config.TempDir(), err)
+	}
+	// Like a clone, the copy keeps the links of the checkout as they are
+	fsService := filesystem.New()
+	_ = fsService.SetSymlinkPolicy(filesystem.SymlinkKeep)
+	if err := fsService.CopyDirectory(g.SourceDir, tempDir); err != nil {
+		_ = os.RemoveAll(tempDir)
+		return "", models.NewGitError(models.ErrorCodeGitCloneFailed, "clone "+url, err)
+	}
+	return tempDir, nil
+}
+
+func (g *LocalGit) CleanupTempDir(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !strings.Contains(filepath.Base(path), config.TempDirPrefix) {
+		return fmt.Errorf("refusing to remove %s, which is not a temporary clone", path)
+	}
+	return os.RemoveAll(path)
+}
+
+func (g *LocalGit) Run(dir, url string, args ...string) (string, error) {
+	return "", models.NewGitError(models.ErrorCodeGitError, strings.Join(args, " "), fmt.Errorf("not supported by LocalGit"))
+}
+
+func (g *LocalGit) FindRepoRoot(dir string) (string, error) {
+	if g.RepoRoot == "" {
+		return "", models.NewAppError(models.ErrorCodeNotGitRepository, "Directory is not inside a git work tree: "+dir, nil)
+	}
+	return g.RepoRoot, nil
+}
+
+// Clones returns the URLs cloned so far, in order
+func (g *LocalGit) Clones() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.clones...)
+}
+
+// AuthToken returns the last token set
+func (g *LocalGit) AuthToken() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.authToken
+}
+
+// SparsePaths returns the last sparse paths set
+func (g *LocalGit) SparsePaths() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.sparsePaths...)
+}
+
+// RecordingScripts is a ScriptRunner that records scripts instead of running them
+type RecordingScripts struct {
+	// Fail makes ExecuteScript fail for these script names
+	Fail map[string]error
+	// Hang makes ExecuteScript run these scripts until the context is done
+	Hang map[string]bool
+	// IsolationErr is returned by SetIsolation for isolation other than none
+	IsolationErr error
+
+	mu       sync.Mutex
+	ctx      context.Context
+	executed []string
+}
+
+// NewRecordingScripts returns a RecordingScripts running nothing
+func NewRecordingScripts() *RecordingScripts {
+	return &RecordingScripts{}
+}
+
+func (r *RecordingScripts) ScriptExists(sourceDir, scriptName string) bool {
+	_, err := os.Stat(filepath.Join(sourceDir, scriptName))
+	return err == nil
+}
+
+func (r *RecordingScripts) InspectScript(dir, scriptName string) (*models.ScriptInfo, error) {
+	info, err := os.Stat(filepath.Join(dir, scriptName))
+	if err != nil {
+		return nil, nil
+	}
+	return &models.ScriptInfo{Name: scriptName, Size: info.Size()}, nil
+}
+
+func (r *RecordingScripts) SetPins(pins map[string]string, allowUnpinned bool) {}
+
+func (r *RecordingScripts) CheckPin(info models.ScriptInfo) error {
+	return nil
+}
+
+func (r *RecordingScripts) SetContext(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctx = ctx
+}
+
+func (r *RecordingScripts) SetIsolation(mode string) error {
+	if mode == "" || mode == models.ScriptIsolationNone {
+		return nil
+	}
+	return r.IsolationErr
+}
+
+func (r *RecordingScripts) CopyScript(sourceDir, targetDir, scriptName string) error {
+	return nil
+}
+
+func (r *RecordingScripts) ExecuteScript(targetDir, scriptName string) (*models.ScriptResult, error) {
+	r.mu.Lock()
+	r.executed = append(r.executed, scriptName)
+	ctx := r.ctx
+	r.mu.Unlock()
+
+	result := &models.ScriptResult{ScriptInfo: models.ScriptInfo{Name: scriptName}}
+	if r.Hang[scriptName] && ctx != nil {
+		<-ctx.Done()
+		result.ExitCode = -1
+		return result, models.NewAppError(models.ErrorCodeInstallationFailed, "Script execution failed: "+scriptName, ctx.Err())
+	}
+	if err := r.Fail[scriptName]; err != nil {
+		result.ExitCode = 1
+		return result, err
+	}
+	return result, nil
+}
+
+func (r *RecordingScripts) RemoveScript(targetDir, scriptName string) error {
+	return nil
+}
+
+// Executed returns the scripts executed so far, in order
+func (r *RecordingScripts) Executed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.executed...)
+}
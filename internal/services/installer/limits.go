@@ -0,0 +1,48 @@
+package installer
+
+import (
+	"fmt"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// limitCopy makes the framework files copied next stop at the configured
+// template maximums, unless large templates are allowed
+func (s *Service) limitCopy(allowLarge bool) {
+	cfg := config.Current()
+	if allowLarge {
+		s.filesystemService.SetCopyLimit(0, 0)
+		return
+	}
+	s.filesystemService.SetCopyLimit(cfg.TemplateMaxBytes, cfg.TemplateMaxFiles)
+}
+
+// checkCopied records how much the installation copied and lifts the copy
+// limit again. A copy stopped by the limit is rolled back; a completed one
+// above the warning limits gets a warning.
+func (s *Service) checkCopied(plan *models.InstallationPlan, copyErr error) error {
+	s.copied = s.filesystemService.CopyTotals()
+	s.filesystemService.SetCopyLimit(0, 0)
+
+	if models.IsErrorCode(copyErr, models.ErrorCodeTemplateTooLarge) {
+		s.reporter.Step("Rolling back the installation")
+		if err := s.rollback(plan); err != nil {
+			return fmt.Errorf("%w; rollback failed: %v", copyErr, err)
+		}
+		s.rolledBack = true
+		return fmt.Errorf("%w; the installation was rolled back, re-run with --allow-large-template to install it anyway", copyErr)
+	}
+	if copyErr != nil {
+		return copyErr
+	}
+
+	cfg := config.Current()
+	if (cfg.TemplateWarnBytes > 0 && s.copied.Bytes > cfg.TemplateWarnBytes) ||
+		(cfg.TemplateWarnFiles > 0 && s.copied.Files > cfg.TemplateWarnFiles) {
+		s.reporter.Warn(fmt.Sprintf("The template is large: %s in %d files were copied; check it for files that do not belong in it",
+			utils.FormatSize(s.copied.Bytes), s.copied.Files))
+	}
+	return nil
+}
@@ -0,0 +1,459 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/hookcheck"
+)
+
+// Step is a named part of an installation. Install runs the steps in order,
+// reports and times each one, and stops at the first error.
+type Step struct {
+	Name    string
+	Message string // Reported when the step starts and listed by dry runs
+
+	// Describe replaces Message with one that depends on the installation; optional
+	Describe func(run *Run) string
+	// Skip reports whether the installation leaves the step out; nil runs it always
+	Skip func(run *Run) bool
+	Run  func(run *Run) error
+}
+
+// Run is the installation the steps work on
+type Run struct {
+	Config models.InstallConfig
+	Plan   *models.InstallationPlan
+
+	// SourceDir is the fetched template, set by the clone step
+	SourceDir string
+
+	cleanups []func()
+}
+
+// OnCleanup registers fn to be called once all steps have run or one failed
+func (r *Run) OnCleanup(fn func()) {
+	r.cleanups = append(r.cleanups, fn)
+}
+
+func (r *Run) cleanup() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+	r.cleanups = nil
+}
+
+// Steps returns the names of the installation steps, in the order they run
+func (s *Service) Steps() []string {
+	names := make([]string, 0, len(s.steps))
+	for _, step := range s.steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+// AddStep adds a step to installations, right after the step named after
+func (s *Service) AddStep(after string, step Step) error {
+	if step.Name == "" || step.Run == nil {
+		return models.NewValidationError("step", step.Name, "a step needs a name and a Run function")
+	}
+	if slices.Contains(s.Steps(), step.Name) {
+		return models.NewValidationError("step", step.Name, "a step with this name already exists")
+	}
+	i := slices.Index(s.Steps(), after)
+	if i < 0 {
+		return models.NewValidationError("step", after, "no installation step has this name")
+	}
+	s.steps = slices.Insert(s.steps, i+1, step)
+	return nil
+}
+
+// runSteps runs the installation's steps in order, except the skipped ones.
+// Every step first checks the deadline set with SetContext.
+func (s *Service) runSteps(run *Run, timer *stepTimer) error {
+	defer run.cleanup()
+
+	skip := componentSkips(run.Plan)
+	for _, step := range s.steps {
+		if skip[step.Name] || (step.Skip != nil && step.Skip(run)) {
+			continue
+		}
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		done := timer.start(step.Name, step.message(run))
+		if err := step.Run(run); err != nil {
+			return err
+		}
+		done()
+	}
+	return nil
+}
+
+// describeSteps lists what each step of an installation does and whether it is skipped, for dry runs
+func (s *Service) describeSteps(run *Run) []models.PlannedStep {
+	skip := componentSkips(run.Plan)
+	steps := make([]models.PlannedStep, 0, len(s.steps))
+	for _, step := range s.steps {
+		steps = append(steps, models.PlannedStep{
+			Name:        step.Name,
+			Description: step.message(run),
+			Skipped:     skip[step.Name] || (step.Skip != nil && step.Skip(run)),
+		})
+	}
+	return steps
+}
+
+func (step Step) message(run *Run) string {
+	if step.Describe != nil {
+		return step.Describe(run)
+	}
+	return step.Message
+}
+
+// componentSkips returns the steps left out when only some components are
+// installed; those installations run no scripts and leave integrations alone
+func componentSkips(plan *models.InstallationPlan) map[string]bool {
+	skip := make(map[string]bool)
+	if !plan.IsPartial() {
+		return skip
+	}
+	skip[stepPreInstall] = true
+	skip[stepPostInstall] = true
+	skip[stepIntegrations] = true
+	if !plan.HasComponent(models.ComponentHooks) {
+		skip[stepHookDeps] = true
+		skip[stepSymlinks] = true
+	}
+	if !plan.HasComponent(models.ComponentSettings) {
+		skip[stepSettings] = true
+		skip[stepCodex] = true
+	}
+	if !plan.HasComponent(models.ComponentGitignore) {
+		skip[stepGitignore] = true
+	}
+	return skip
+}
+
+// defaultSteps returns the steps of an installation
+func (s *Service) defaultSteps() []Step {
+	return []Step{
+		{
+			Name: stepClone,
+			Describe: func(run *Run) string {
+				return fmt.Sprintf("Fetching template '%s'", run.Plan.Template.ID)
+			},
+			Run: s.stepFetch,
+		},
+		{Name: stepVerify, Message: "Verifying template content", Run: s.stepVerify},
+		{
+			Name:    stepBackup,
+			Message: "Backing up the existing installation",
+			Skip: func(run *Run) bool {
+				return !run.Plan.BackupRequired || run.Config.NoBackup
+			},
+			Run: func(run *Run) error {
+				if err := s.CreateBackup(run.Plan.TargetDir, run.Plan.BackupDir, run.Plan.BackupInfo); err != nil {
+					return fmt.Errorf("backup creation failed: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    stepPreInstall,
+			Message: "Running the pre-install script",
+			Skip: func(run *Run) bool {
+				return !run.Plan.HasPreInstallScript
+			},
+			Run: func(run *Run) error {
+				if err := s.executePreInstallScript(run.SourceDir, run.Plan.TargetDir); err != nil {
+					return s.handleScriptError(run.Plan, fmt.Errorf("pre-install script failed: %w", err), false)
+				}
+				return nil
+			},
+		},
+		{Name: stepCopy, Message: "Installing framework files", Run: s.stepCopy},
+		{
+			// Hook dependencies come before settings.json is written, so hooks use the virtualenv
+			Name:    stepHookDeps,
+			Message: "Installing hook dependencies",
+			Skip: func(run *Run) bool {
+				return !run.Config.InstallHookDeps
+			},
+			Run: func(run *Run) error {
+				if err := s.installHookDependencies(run.Plan.TargetDir, run.Config.HookPython); err != nil {
+					return fmt.Errorf("failed to install hook dependencies: %w", err)
+				}
+				return nil
+			},
+		},
+		{Name: stepSymlinks, Message: "Creating symlinks", Run: s.stepSymlinks},
+		{
+			// Cursor rules, the Aider and OpenCode configurations and .envrc are shared
+			// by the project and belong to the default installation
+			Name:    stepIntegrations,
+			Message: "Configuring integrations",
+			Skip: func(run *Run) bool {
+				return config.Instance() != ""
+			},
+			Run: func(run *Run) error {
+				return s.installSharedIntegrations(run.Plan)
+			},
+		},
+		{
+			// Merge the template into the existing user settings
+			Name:    stepSettings,
+			Message: "Merging settings",
+			Run: func(run *Run) error {
+				if err := s.settingsService.ProcessSettings(run.Plan.TargetDir); err != nil {
+					return fmt.Errorf("failed to process settings: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			// Like the other shared files, config.toml belongs to the default installation
+			Name:    stepCodex,
+			Message: "Configuring Codex",
+			Skip: func(run *Run) bool {
+				return !run.Plan.HasIntegration(models.IntegrationCodex) || config.Instance() != ""
+			},
+			Run: func(run *Run) error {
+				if err := s.codexConfigService.ProcessCodexConfig(run.Plan.TargetDir); err != nil {
+					return fmt.Errorf("failed to process codex config: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    stepPostInstall,
+			Message: "Running the post-install script",
+			Skip: func(run *Run) bool {
+				return !run.Plan.HasPostInstallScript
+			},
+			Run: func(run *Run) error {
+				if err := s.executePostInstallScript(run.SourceDir, run.Plan.TargetDir); err != nil {
+					return s.handleScriptError(run.Plan, fmt.Errorf("post-install script failed: %w", err), true)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    stepGitignore,
+			Message: "Applying ignore templates",
+			Run: func(run *Run) error {
+				if err := s.applyGitignoreTemplates(run.SourceDir, run.Plan.TargetDir, run.Config.GitignoreMode); err != nil {
+					return fmt.Errorf("failed to apply gitignore templates: %w", err)
+				}
+				if err := s.applyToolIgnoreTemplates(run.SourceDir, run.Plan.TargetDir); err != nil {
+					return fmt.Errorf("failed to apply ignore templates: %w", err)
+				}
+				return nil
+			},
+		},
+		{Name: stepMetadata, Message: "Recording the installation", Run: s.stepMetadata},
+		{
+			Name:    stepReadOnly,
+			Message: "Making the framework files read-only",
+			Skip: func(run *Run) bool {
+				return !run.Plan.ReadOnlyCore
+			},
+			Run: s.stepReadOnly,
+		},
+		{
+			Name:    stepValidate,
+			Message: "Validating the installation",
+			Run: func(run *Run) error {
+				if err := s.ValidateInstallation(run.Plan.TargetDir); err != nil {
+					return fmt.Errorf("installation validation failed: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    stepVerifyHooks,
+			Message: "Running the hooks with a test payload",
+			Skip: func(run *Run) bool {
+				return !run.Config.VerifyHooks
+			},
+			Run: s.stepVerifyHooks,
+		},
+	}
+}
+
+// stepFetch fetches the template content from the repository, an offline
+// bundle or the vendored copy; the checkout is removed after the last step
+// unless it is kept for debugging
+func (s *Service) stepFetch(run *Run) error {
+	template, source := run.Plan.Template, run.Plan.TemplateSource
+	tempDir, cleanup, err := s.fetchTemplate(run.Config, template, source)
+	if err != nil {
+		return err
+	}
+	run.SourceDir = tempDir
+	run.OnCleanup(func() {
+		if run.Config.KeepTemp && source != models.TemplateSourceDev {
+			s.reporter.Info(fmt.Sprintf("Kept the template checkout in %s", tempDir))
+			return
+		}
+		if cleanupErr := cleanup(); cleanupErr != nil {
+			s.reporter.Warn(fmt.Sprintf("Failed to cleanup temporary directory: %v", cleanupErr))
+		}
+	})
+	return nil
+}
+
+// stepVerify verifies the fetched content before anything is copied or
+// executed and records its scripts in the plan; scripts that do not match the
+// pinned hashes stop the installation before anything is changed
+func (s *Service) stepVerify(run *Run) error {
+	if err := s.verifyTemplateContent(run.SourceDir, run.Plan.Template, run.Config.NoVerify); err != nil {
+		return fmt.Errorf("template verification failed: %w", err)
+	}
+
+	s.scriptService.SetPins(config.Current().ScriptHashes, run.Config.AllowUnpinnedScripts)
+	if err := s.inspectScripts(run.SourceDir, run.Plan); err != nil {
+		return err
+	}
+	for _, script := range run.Plan.Scripts {
+		if err := s.scriptService.CheckPin(script); err != nil {
+			return err
+		}
+	}
+	if len(run.Plan.Scripts) > 0 && run.Config.ScriptIsolation == models.ScriptIsolationDocker &&
+		run.Plan.ScriptIsolation != models.ScriptIsolationDocker {
+		s.reporter.Warn("Docker is not available; running installation scripts without isolation")
+	}
+	return nil
+}
+
+// stepCopy installs the framework files based on the installation type
+func (s *Service) stepCopy(run *Run) error {
+	plan, tempDir := run.Plan, run.SourceDir
+	s.changing = true
+	s.limitCopy(run.Config.AllowLargeTemplate)
+
+	var err error
+	switch {
+	case plan.TemplateSource == models.TemplateSourceDev:
+		err = s.installDev(tempDir, plan.TargetDir, plan.InstallationType, plan.CarryOver)
+	case plan.InstallationType == models.InstallationTypeNew:
+		err = s.installNew(tempDir, plan.TargetDir)
+	case plan.IsPartial():
+		err = s.installComponents(tempDir, plan.TargetDir, plan.Components)
+	case plan.InstallationType == models.InstallationTypeUpdate:
+		err = s.InstallCore(tempDir, plan.TargetDir)
+	case plan.InstallationType == models.InstallationTypeOverwrite:
+		err = s.installOverwrite(tempDir, plan.TargetDir, plan.CarryOver)
+	default:
+		err = models.NewAppError(
+			models.ErrorCodeInstallationFailed,
+			fmt.Sprintf("Unknown installation type: %s", plan.InstallationType),
+			nil,
+		)
+	}
+	if err := s.checkCopied(plan, err); err != nil {
+		return fmt.Errorf("installation failed: %w", err)
+	}
+
+	// A full overwrite removes the framework directory, vendored copy included
+	if plan.TemplateSource == models.TemplateSourceVendored {
+		if err := s.restoreVendoredCopy(tempDir, plan.TargetDir); err != nil {
+			return fmt.Errorf("failed to restore vendored template: %w", err)
+		}
+	}
+
+	// Regenerate overlays from the new core so disabled agents and commands stay hidden
+	if err := s.catalogService.RefreshOverlays(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to refresh disabled item overlays: %w", err)
+	}
+	return nil
+}
+
+// stepSymlinks links the framework into .claude and, when codex is selected,
+// .codex; the Codex links are removed when it is no longer selected
+func (s *Service) stepSymlinks(run *Run) error {
+	plan := run.Plan
+	if err := s.ensureClaudeDirectory(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to create .claude directory structure: %w", err)
+	}
+
+	s.symlinkService.SetStyle(plan.SymlinkStyle)
+	if err := s.symlinkService.CreateSymlinks(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to create symlinks: %w", err)
+	}
+
+	if plan.HasIntegration(models.IntegrationCodex) {
+		if err := s.symlinkService.CreateCodexSymlinks(plan.TargetDir); err != nil {
+			return fmt.Errorf("failed to create codex symlinks: %w", err)
+		}
+	} else if err := s.symlinkService.RemoveCodexSymlinks(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to remove codex symlinks: %w", err)
+	}
+	return nil
+}
+
+// stepVerifyHooks runs every strategic hook the way Claude Code would; the
+// installation is kept when one fails, but the failure is reported as its error
+func (s *Service) stepVerifyHooks(run *Run) error {
+	results, err := s.hookCheckService.Check(run.Plan.TargetDir)
+	if err != nil {
+		return fmt.Errorf("failed to run hooks: %w", err)
+	}
+	for _, result := range results {
+		if result.Succeeded() {
+			continue
+		}
+		message := fmt.Sprintf("Hook %s (%s) exited with code %d", result.Script, result.Event, result.ExitCode)
+		if result.Error != "" {
+			message = fmt.Sprintf("Hook %s (%s) %s", result.Script, result.Event, result.Error)
+		}
+		if output := strings.TrimSpace(result.Output); output != "" {
+			message += ":\n" + output
+		}
+		s.reporter.Warn(message)
+	}
+	return hookcheck.Err(results)
+}
+
+// stepReadOnly removes the write bits of the core, guides and templates files,
+// so edits that an update would discard fail in editors. Directories linked to a
+// dev mode checkout are not followed.
+func (s *Service) stepReadOnly(run *Run) error {
+	strategicDir := filepath.Join(run.Plan.TargetDir, config.FrameworkDir())
+	for _, dir := range config.GetCoreDirectories() {
+		if err := s.filesystemService.MakeReadOnly(filepath.Join(strategicDir, dir)); err != nil {
+			return fmt.Errorf("failed to make %s read-only: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// stepMetadata records the template, a configured framework directory name and
+// the installed files, and hands the files written by services other than the
+// filesystem service to the user who invoked sudo
+func (s *Service) stepMetadata(run *Run) error {
+	plan := run.Plan
+	if err := s.saveTemplateInfo(plan.TargetDir, plan.Template, plan); err != nil {
+		return fmt.Errorf("failed to save template metadata: %w", err)
+	}
+
+	// Later commands find the installation through the project config
+	if err := s.saveProjectConfig(plan.TargetDir); err != nil {
+		return fmt.Errorf("failed to save project config: %w", err)
+	}
+
+	// Status lists the framework files changed since from the manifest
+	if err := s.saveManifest(plan); err != nil {
+		return fmt.Errorf("failed to save install manifest: %w", err)
+	}
+
+	if err := s.applyOwnership(plan); err != nil {
+		return fmt.Errorf("failed to change ownership of installed files: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package installer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// PreviewFiles fetches the template and records in the plan what the
+// installation does to each file of the framework directory. Files that would
+// be overwritten are hashed on both sides, so the ones with the same content
+// are listed as unchanged rather than replaced.
+func (s *Service) PreviewFiles(installConfig models.InstallConfig, plan *models.InstallationPlan) error {
+	return s.withTemplate(installConfig, func(tempDir string) error {
+		files, err := planFiles(filepath.Join(tempDir, config.StrategicClaudeBasicDir), plan)
+		if err != nil {
+			return err
+		}
+		plan.Files = files
+		return nil
+	})
+}
+
+// planFiles compares the template's framework directory in sourceDir with the
+// one in the plan's target, following what the installation type copies:
+// everything for new installs and overwrites, but the user directories an
+// overwrite carries over, only the core directories for
+// updates, or the selected ones, and links instead of the core directories in dev mode
+func planFiles(sourceDir string, plan *models.InstallationPlan) ([]models.PlannedFile, error) {
+	targetDir := filepath.Join(plan.TargetDir, config.FrameworkDir())
+	devMode := plan.TemplateSource == models.TemplateSourceDev
+	isCopied := func(rel string) bool {
+		top, _, _ := strings.Cut(rel, "/")
+		return slices.Contains(config.GetCoreDirectories(), top) && plan.HasComponent(top)
+	}
+	isCarried := func(rel string) bool {
+		top, _, _ := strings.Cut(rel, "/")
+		return slices.Contains(plan.CarryOver, top)
+	}
+	entry := func(rel, action string) models.PlannedFile {
+		changed := action == models.PlannedCreate || action == models.PlannedReplace || action == models.PlannedRemove
+		return models.PlannedFile{Path: filepath.ToSlash(filepath.Join(config.FrameworkDir(), rel)), Action: action, Changed: changed}
+	}
+
+	var files []models.PlannedFile
+	sourceFiles := make(map[string]bool)
+	err := filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(sourceDir, path)
+		rel = filepath.ToSlash(rel)
+		if devMode && d.IsDir() && slices.Contains(config.GetCoreDirectories(), rel) {
+			files = append(files, entry(rel, models.PlannedLink))
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		sourceFiles[rel] = true
+
+		targetPath := filepath.Join(targetDir, filepath.FromSlash(rel))
+		_, statErr := os.Lstat(targetPath)
+		exists := statErr == nil
+		switch {
+		case plan.InstallationType == models.InstallationTypeNew:
+			files = append(files, entry(rel, models.PlannedCreate))
+		case isCarried(rel):
+			files = append(files, entry(rel, keepAction(exists)))
+		case devMode:
+			// The rest of the checkout is copied where the target does not have it yet
+			top, _, _ := strings.Cut(rel, "/")
+			if _, err := os.Lstat(filepath.Join(targetDir, top)); err == nil && plan.InstallationType == models.InstallationTypeUpdate {
+				files = append(files, entry(rel, keepAction(exists)))
+			} else {
+				files = append(files, entry(rel, models.PlannedCreate))
+			}
+		case plan.InstallationType == models.InstallationTypeUpdate && !isCopied(rel):
+			files = append(files, entry(rel, keepAction(exists)))
+		case !exists:
+			files = append(files, entry(rel, models.PlannedCreate))
+		default:
+			same, err := sameContent(path, targetPath)
+			if err != nil {
+				return err
+			}
+			if same {
+				files = append(files, entry(rel, models.PlannedUnchanged))
+			} else {
+				files = append(files, entry(rel, models.PlannedReplace))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, sourceDir, err)
+	}
+
+	// Installed files that are not in the template go with the directories they
+	// are in, except for the ones the installation writes again
+	generated := []string{config.TemplateInfoFile, config.InstallManifestFile, config.ConventionsFile}
+	var removed []string
+	switch {
+	case plan.InstallationType == models.InstallationTypeOverwrite:
+		removed = []string{"."}
+	case plan.InstallationType == models.InstallationTypeUpdate && !devMode:
+		for _, dir := range config.GetCoreDirectories() {
+			if _, err := os.Stat(filepath.Join(sourceDir, dir)); err == nil && plan.HasComponent(dir) {
+				removed = append(removed, dir)
+			}
+		}
+	}
+	for _, dir := range removed {
+		_ = filepath.WalkDir(filepath.Join(targetDir, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(targetDir, path)
+			if !sourceFiles[filepath.ToSlash(rel)] && !slices.Contains(generated, rel) && !isCarried(filepath.ToSlash(rel)) {
+				files = append(files, entry(filepath.ToSlash(rel), models.PlannedRemove))
+			}
+			return nil
+		})
+	}
+
+	slices.SortFunc(files, func(a, b models.PlannedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files, nil
+}
+
+// keepAction is the action for a template file an installation does not copy
+func keepAction(installed bool) string {
+	if installed {
+		return models.PlannedPreserve
+	}
+	return models.PlannedSkip
+}
+
+// sameContent reports whether two files have the same SHA-256
+func sameContent(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if err != nil || !infoB.Mode().IsRegular() {
+		return false, nil
+	}
+	if infoA.Size() != infoB.Size() {
+		return false, nil
+	}
+
+	hashA, err := fileSHA256(a)
+	if err != nil {
+		return false, err
+	}
+	hashB, err := fileSHA256(b)
+	if err != nil {
+		return false, nil
+	}
+	return bytes.Equal(hashA, hashB), nil
+}
+
+func fileSHA256(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
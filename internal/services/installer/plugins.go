@@ -0,0 +1,71 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/plugin"
+)
+
+// pluginSteps are the steps plugins run after, by point
+var pluginSteps = map[string]string{
+	config.PluginPointPreInstall:  stepPreInstall,
+	config.PluginPointPostCopy:    stepCopy,
+	config.PluginPointPostInstall: stepValidate,
+}
+
+// SetPlugins adds a step to installations for each plugin, after the step of
+// its point; plugins at the same point run in the order given. A failing
+// plugin is handled like a failing installation script.
+func (s *Service) SetPlugins(plugins []plugin.Plugin) error {
+	last := make(map[string]string)
+	for _, p := range plugins {
+		after, ok := pluginSteps[p.Point]
+		if !ok {
+			return models.NewValidationError("plugin", p.Point, "unknown plugin point")
+		}
+		if name, ok := last[p.Point]; ok {
+			after = name
+		}
+
+		step := Step{
+			Name:    fmt.Sprintf("plugin %s/%s", p.Point, p.Name),
+			Message: fmt.Sprintf("Running plugin %s", p.Name),
+			Run: func(run *Run) error {
+				if err := s.pluginService.Run(p, pluginContext(p.Point, run)); err != nil {
+					return s.handleScriptError(run.Plan, err, p.Point != config.PluginPointPreInstall)
+				}
+				return nil
+			},
+		}
+		if err := s.AddStep(after, step); err != nil {
+			return err
+		}
+		last[p.Point] = step.Name
+	}
+	return nil
+}
+
+// pluginContext describes the installation to a plugin
+func pluginContext(point string, run *Run) plugin.Context {
+	plan := run.Plan
+	integrations := plan.Integrations
+	if integrations == nil {
+		integrations = []string{}
+	}
+	return plugin.Context{
+		Point:            point,
+		TargetDir:        plan.TargetDir,
+		FrameworkDir:     filepath.Join(plan.TargetDir, config.FrameworkDir()),
+		Instance:         config.Instance(),
+		Template:         plan.Template.ID,
+		TemplateRepo:     plan.Template.RepoURL,
+		TemplateCommit:   plan.Template.Commit,
+		InstallationType: string(plan.InstallationType),
+		Integrations:     integrations,
+		Components:       plan.Components,
+		SourceDir:        run.SourceDir,
+	}
+}
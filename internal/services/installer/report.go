@@ -0,0 +1,70 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Report is the structured account of an installation written by init --report
+type Report struct {
+	TargetDir         string                         `json:"target_dir"`
+	FrameworkDir      string                         `json:"framework_dir"`
+	Instance          string                         `json:"instance,omitempty"`
+	Template          string                         `json:"template"`
+	InstallationType  models.InstallationType        `json:"installation_type"`
+	Success           bool                           `json:"success"`
+	Error             string                         `json:"error,omitempty"`
+	Steps             models.StepTimings             `json:"steps"`
+	Scripts           []models.ScriptResult          `json:"scripts"`
+	ScriptErrorPolicy string                         `json:"script_error_policy"`
+	RolledBack        bool                           `json:"rolled_back"`
+	Filesystem        *models.FilesystemCapabilities `json:"filesystem,omitempty"`
+	CopyStrategy      string                         `json:"copy_strategy"`
+	Copied            models.CopyTotals              `json:"copied"`
+}
+
+// Report builds the report of the last installation, planned as plan, which
+// failed with installErr when it is not nil
+func (s *Service) Report(plan *models.InstallationPlan, installErr error) *Report {
+	report := &Report{
+		TargetDir:         plan.TargetDir,
+		FrameworkDir:      config.FrameworkDir(),
+		Instance:          config.Instance(),
+		Template:          plan.Template.ID,
+		InstallationType:  plan.InstallationType,
+		Success:           installErr == nil,
+		Steps:             s.Timings(),
+		Scripts:           s.ScriptResults(),
+		ScriptErrorPolicy: s.scriptErrorPolicy,
+		RolledBack:        s.rolledBack,
+		Filesystem:        plan.Filesystem,
+		CopyStrategy:      plan.CopyStrategy,
+		Copied:            s.copied,
+	}
+	if installErr != nil {
+		report.Error = installErr.Error()
+	}
+	if report.Steps == nil {
+		report.Steps = make(models.StepTimings, 0)
+	}
+	if report.Scripts == nil {
+		report.Scripts = make([]models.ScriptResult, 0)
+	}
+	return report
+}
+
+// WriteFile writes the report as indented JSON
+func (r *Report) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode installation report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cleaner"
+)
+
+// handleScriptError applies the script error policy to a failed script and
+// returns the error the installation stops with, or nil to carry on. installed
+// tells whether the framework files were installed when the script ran.
+func (s *Service) handleScriptError(plan *models.InstallationPlan, scriptErr error, installed bool) error {
+	switch s.scriptErrorPolicy {
+	case models.ScriptErrorContinue:
+		s.reporter.Warn(fmt.Sprintf("%v; continuing as the script error policy is %s", scriptErr, models.ScriptErrorContinue))
+		return nil
+	case models.ScriptErrorRollback:
+		if !installed {
+			return scriptErr // Nothing was installed yet
+		}
+		s.reporter.Step("Rolling back the installation")
+		if err := s.rollback(plan); err != nil {
+			return fmt.Errorf("%w; rollback failed: %v", scriptErr, err)
+		}
+		s.rolledBack = true
+		return fmt.Errorf("%w; the installation was rolled back", scriptErr)
+	default:
+		return scriptErr
+	}
+}
+
+// rollback undoes an installation. A new installation is removed again; an
+// update or overwrite gets back the framework directory from the backup taken
+// before it, keeping the symlinks and settings, which point to the same files.
+func (s *Service) rollback(plan *models.InstallationPlan) error {
+	if plan.InstallationType == models.InstallationTypeNew {
+		cleanerService := cleaner.New()
+		cleanerService.SetReporter(s.reporter)
+		result, err := cleanerService.RemoveInstallation(plan.TargetDir)
+		if err != nil {
+			return err
+		}
+		if !result.Success {
+			return fmt.Errorf("%s", strings.Join(result.Errors, "; "))
+		}
+		return nil
+	}
+
+	if plan.BackupDir == "" {
+		return fmt.Errorf("no backup of the previous installation to roll back to")
+	}
+	if _, err := os.Stat(plan.BackupDir); err != nil {
+		return fmt.Errorf("no backup of the previous installation to roll back to: %w", err)
+	}
+
+	strategicDir := filepath.Join(plan.TargetDir, config.FrameworkDir())
+	if err := os.RemoveAll(strategicDir); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, strategicDir, err)
+	}
+	// The backup is put back as it was, links included
+	if err := s.filesystemService.SetSymlinkPolicy(models.SymlinkPolicyKeep); err != nil {
+		return err
+	}
+	if err := s.filesystemService.CopyDirectory(plan.BackupDir, strategicDir); err != nil {
+		return models.NewAppError(models.ErrorCodeRestoreFailed, fmt.Sprintf("Failed to restore %s from %s", config.FrameworkDir(), plan.BackupDir), err)
+	}
+	// The record of the backup is not part of the installation
+	if err := os.Remove(filepath.Join(strategicDir, config.BackupInfoFile)); err != nil && !os.IsNotExist(err) {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, strategicDir, err)
+	}
+	return nil
+}
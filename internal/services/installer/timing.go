@@ -0,0 +1,56 @@
+package installer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Installation steps whose duration is measured
+const (
+	stepBackup       = "backup"
+	stepClone        = "clone"
+	stepVerify       = "verify"
+	stepPreInstall   = "pre-install script"
+	stepCopy         = "copy"
+	stepHookDeps     = "hook dependencies"
+	stepSymlinks     = "symlinks"
+	stepIntegrations = "integrations"
+	stepSettings     = "settings"
+	stepCodex        = "codex"
+	stepPostInstall  = "post-install script"
+	stepGitignore    = "gitignore"
+	stepMetadata     = "metadata"
+	stepReadOnly     = "read-only core"
+	stepValidate     = "validation"
+	stepVerifyHooks  = "hook check"
+)
+
+// stepTimer reports the steps of an installation and records how long each took
+type stepTimer struct {
+	reporter reporter.Reporter
+	verbose  bool
+	timings  models.StepTimings
+}
+
+func newStepTimer(r reporter.Reporter, verbose bool) *stepTimer {
+	return &stepTimer{reporter: r, verbose: verbose}
+}
+
+// start reports that a step begins and returns the function to call once it is
+// done. Steps that fail are not recorded.
+func (t *stepTimer) start(name, message string) func() {
+	t.reporter.Step(message)
+	started := time.Now()
+
+	return func() {
+		duration := time.Since(started)
+		t.timings = append(t.timings, models.StepTiming{Name: name, Duration: duration})
+		if t.verbose {
+			t.reporter.Info(fmt.Sprintf("Step %s took %s", name, utils.FormatDuration(duration)))
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package manifest records the framework files an installation wrote, so status
+// can list the files that were modified or removed since.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Manifest lists the framework files of an installation with their content hashes
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []File    `json:"files"`
+}
+
+// File is a framework file, relative to .strategic-claude-basic with slashes
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Service builds, stores and verifies installation manifests
+type Service struct{}
+
+// New creates a new manifest service instance
+func New() *Service {
+	return &Service{}
+}
+
+// Path returns where the manifest of an installation is stored
+func Path(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), config.InstallManifestFile)
+}
+
+// Build hashes the regular files in the core directories of an installation.
+// Directories linked by dev mode installs are not followed.
+func (s *Service) Build(targetDir string) (*Manifest, error) {
+	return s.BuildWithHashes(targetDir, nil)
+}
+
+// BuildWithHashes is Build taking the hashes of files computed while they were
+// copied, by absolute path, from known; only the other files are read
+func (s *Service) BuildWithHashes(targetDir string, known map[string]models.FileHash) (*Manifest, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	manifest := &Manifest{
+		Version:   config.InstallManifestFormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Files:     make([]File, 0),
+	}
+
+	for _, dir := range config.GetCoreDirectories() {
+		root := filepath.Join(strategicDir, dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(strategicDir, path)
+			if err != nil {
+				return err
+			}
+			if hash, ok := known[path]; ok {
+				manifest.Files = append(manifest.Files, File{Path: filepath.ToSlash(relPath), Size: hash.Size, SHA256: hash.SHA256})
+				return nil
+			}
+			sum, size, err := hashFile(path)
+			if err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, File{Path: filepath.ToSlash(relPath), Size: size, SHA256: sum})
+			return nil
+		})
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	return manifest, nil
+}
+
+// Write stores the manifest of an installation
+func (s *Service) Write(targetDir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode install manifest: %w", err)
+	}
+
+	path := Path(targetDir)
+	if err := os.WriteFile(path, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// Read loads the manifest of an installation; it returns nil without an error
+// when the installation has none, e.g. when an older version installed it
+func (s *Service) Read(targetDir string) (*Manifest, error) {
+	path := Path(targetDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, fmt.Errorf("invalid install manifest: %w", err))
+	}
+	if manifest.Version != config.InstallManifestFormatVersion {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path,
+			fmt.Errorf("unsupported install manifest version %d", manifest.Version))
+	}
+	return &manifest, nil
+}
+
+// Verify compares the files of an installation with its manifest. Every file is
+// checked for existence and size; the content of a random sample of sampleSize
+// files is hashed, or of every file when sampleSize is not positive.
+func (s *Service) Verify(targetDir string, manifest *Manifest, sampleSize int) (*models.DriftCheck, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	check := &models.DriftCheck{Files: len(manifest.Files), Deep: sampleSize <= 0, Changed: make([]models.FileDrift, 0)}
+
+	// Files that still look unchanged without reading them
+	var candidates []File
+	for _, file := range manifest.Files {
+		path := filepath.Join(strategicDir, filepath.FromSlash(file.Path))
+		info, err := os.Lstat(path)
+		switch {
+		case os.IsNotExist(err):
+			check.AddFile(file.Path, models.FileDriftMissing)
+		case err != nil:
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+		case !info.Mode().IsRegular() || info.Size() != file.Size:
+			check.AddFile(file.Path, models.FileDriftModified)
+		default:
+			candidates = append(candidates, file)
+		}
+	}
+
+	if !check.Deep && sampleSize < len(candidates) {
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		candidates = candidates[:sampleSize]
+	}
+
+	for _, file := range candidates {
+		path := filepath.Join(strategicDir, filepath.FromSlash(file.Path))
+		sum, _, err := hashFile(path)
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+		}
+		check.Hashed++
+		if sum != file.SHA256 {
+			check.AddFile(file.Path, models.FileDriftModified)
+		}
+	}
+
+	// Report changes in manifest order
+	sort.Slice(check.Changed, func(i, j int) bool {
+		return check.Changed[i].Path < check.Changed[j].Path
+	})
+	return check, nil
+}
+
+// hashFile returns the SHA-256 and size of a file's content
+func hashFile(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
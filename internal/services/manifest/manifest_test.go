@@ -0,0 +1,155 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// writeFiles creates files below .strategic-claude-basic in targetDir
+func writeFiles(t *testing.T, targetDir string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		fullPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func TestService_BuildWriteRead(t *testing.T) {
+	targetDir := t.TempDir()
+	writeFiles(t, targetDir, map[string]string{
+		"core/agents/planner.md":  "planner",
+		"guides/workflow.md":      "workflow",
+		"templates/plan.md":       "plan",
+		"plan/my-plan.md":         "user content",
+		config.TemplateInfoFile:   "{}",
+		"core/hooks/notify.py":    "print()",
+		"core/commands/commit.md": "commit",
+	})
+
+	service := New()
+	built, err := service.Build(targetDir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := []string{"core/agents/planner.md", "core/commands/commit.md", "core/hooks/notify.py", "guides/workflow.md", "templates/plan.md"}
+	if len(built.Files) != len(want) {
+		t.Fatalf("Build() files = %+v, want %v", built.Files, want)
+	}
+	for i, file := range built.Files {
+		if file.Path != want[i] {
+			t.Errorf("Build() file %d = %s, want %s", i, file.Path, want[i])
+		}
+	}
+
+	if err := service.Write(targetDir, built); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	read, err := service.Read(targetDir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if read == nil || len(read.Files) != len(built.Files) || read.Files[0] != built.Files[0] {
+		t.Errorf("Read() = %+v, want %+v", read, built)
+	}
+
+	// Installations from older versions have no manifest
+	missing, err := service.Read(t.TempDir())
+	if err != nil || missing != nil {
+		t.Errorf("Read() without a manifest = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestService_BuildWithHashes(t *testing.T) {
+	targetDir := t.TempDir()
+	writeFiles(t, targetDir, map[string]string{
+		"core/agents/planner.md": "planner",
+		"guides/workflow.md":     "workflow",
+	})
+
+	// Known hashes are taken as they are, the other files are hashed
+	plannerPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, "core", "agents", "planner.md")
+	known := map[string]models.FileHash{plannerPath: {SHA256: "copied", Size: 7}}
+	built, err := New().BuildWithHashes(targetDir, known)
+	if err != nil {
+		t.Fatalf("BuildWithHashes() error = %v", err)
+	}
+	if len(built.Files) != 2 {
+		t.Fatalf("BuildWithHashes() files = %+v, want 2", built.Files)
+	}
+	if built.Files[0].SHA256 != "copied" {
+		t.Errorf("planner.md hash = %s, want the known hash", built.Files[0].SHA256)
+	}
+	if len(built.Files[1].SHA256) != 64 || built.Files[1].Size != int64(len("workflow")) {
+		t.Errorf("workflow.md = %+v, want it hashed", built.Files[1])
+	}
+}
+
+func TestService_Verify(t *testing.T) {
+	targetDir := t.TempDir()
+	writeFiles(t, targetDir, map[string]string{
+		"core/agents/planner.md": "planner",
+		"core/agents/coder.md":   "coder",
+		"guides/workflow.md":     "workflow",
+	})
+
+	service := New()
+	installed, err := service.Build(targetDir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Same size, different content: only hashing notices
+	writeFiles(t, targetDir, map[string]string{"core/agents/coder.md": "CODER"})
+	if err := os.Remove(filepath.Join(targetDir, config.StrategicClaudeBasicDir, "guides", "workflow.md")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	deep, err := service.Verify(targetDir, installed, 0)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	want := []models.FileDrift{
+		{Path: "core/agents/coder.md", Change: models.FileDriftModified},
+		{Path: "guides/workflow.md", Change: models.FileDriftMissing},
+	}
+	if len(deep.Changed) != len(want) {
+		t.Fatalf("Verify() changed = %+v, want %+v", deep.Changed, want)
+	}
+	for i := range want {
+		if deep.Changed[i] != want[i] {
+			t.Errorf("Verify() changed[%d] = %+v, want %+v", i, deep.Changed[i], want[i])
+		}
+	}
+	if !deep.Deep || deep.Hashed != 2 {
+		t.Errorf("Verify() Deep = %v, Hashed = %d, want true and 2", deep.Deep, deep.Hashed)
+	}
+
+	// A sample hashes fewer files, but still finds every missing file
+	sampled, err := service.Verify(targetDir, installed, 1)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if sampled.Deep || sampled.Hashed != 1 {
+		t.Errorf("Verify() sampled Deep = %v, Hashed = %d, want false and 1", sampled.Deep, sampled.Hashed)
+	}
+	foundMissing := false
+	for _, file := range sampled.Changed {
+		if file.Change == models.FileDriftMissing {
+			foundMissing = true
+		}
+	}
+	if !foundMissing {
+		t.Errorf("Verify() sampled changed = %+v, want the missing file", sampled.Changed)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
@@ -109,7 +108,7 @@ func (s *Service) readMCPTemplate(filePath string) (models.MCPServer, error) {
 
 // AnalyzeInstallation analyzes what will be done during MCP installation
 func (s *Service) AnalyzeInstallation(targetDir string, selectedMCPs []models.MCPTemplate) (*models.MCPInstallationPlan, error) {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 	templatesDir := filepath.Join(strategicDir, config.TemplatesDir, "mcps")
 	mcpPath := filepath.Join(targetDir, ".mcp.json")
 
@@ -129,8 +128,8 @@ func (s *Service) AnalyzeInstallation(targetDir string, selectedMCPs []models.MC
 	}
 
 	// Generate backup path
-	timestamp := time.Now().Format("20060102-150405")
-	backupPath := filepath.Join(targetDir, fmt.Sprintf(".mcp-backup-%s.json", timestamp))
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	backupPath := filepath.Join(backupsRoot, config.UniqueBackupName(backupsRoot, config.MCPBackupPrefix, ".json"))
 
 	plan := &models.MCPInstallationPlan{
 		TargetDir:       targetDir,
@@ -179,6 +178,10 @@ func (s *Service) backupExistingMCP(mcpPath, backupPath string) error {
 		return fmt.Errorf("failed to read existing .mcp.json: %w", err)
 	}
 
+	if err := os.MkdirAll(filepath.Dir(backupPath), config.DirPermissions); err != nil {
+		return fmt.Errorf("failed to create backups directory: %w", err)
+	}
+
 	if err := os.WriteFile(backupPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write backup: %w", err)
 	}
@@ -0,0 +1,218 @@
+// Package migrate brings installations made by older versions of the CLI and
+// its templates up to the current layout.
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/backup"
+)
+
+// Legacy layouts an installation can have
+const (
+	// Installed before .codex support: no integrations are recorded and there
+	// is no .codex directory, so only .claude was set up
+	LayoutPreCodex = "pre-codex"
+
+	// Installed from a template without some of the core subdirectories the
+	// .claude and .codex symlinks point to
+	LayoutMissingCoreDirs = "missing-core-dirs"
+
+	// Symlinks into the framework directory with another target than the
+	// current one, e.g. an absolute path where the installation records relative
+	// targets, or core/ where an overlay is used now
+	LayoutLegacySymlinks = "legacy-symlinks"
+
+	// Backups left in the project root, .claude or .codex; only moved by Migrate,
+	// as status reports them with the backups
+	LayoutLegacyBackups = "legacy-backups"
+)
+
+// Step is a change bringing a legacy layout up to date
+type Step struct {
+	Layout      string `json:"layout"`
+	Description string `json:"description"`
+
+	apply func() error
+}
+
+// Service detects and migrates legacy installation layouts
+type Service struct {
+	backupService *backup.Service
+}
+
+// New creates a new migrate service instance
+func New() *Service {
+	return &Service{backupService: backup.New()}
+}
+
+// Detect returns the steps migrating the installation in targetDir to the
+// current layout, without changing anything; none when it is current or there
+// is no installation
+func (s *Service) Detect(targetDir string) []Step {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	var steps []Step
+	steps = append(steps, detectPreCodex(targetDir)...)
+	steps = append(steps, detectMissingCoreDirs(targetDir)...)
+	steps = append(steps, detectLegacySymlinks(targetDir)...)
+	return steps
+}
+
+// PreCodex reports whether the installation in targetDir predates .codex support
+func (s *Service) PreCodex(targetDir string) bool {
+	return len(detectPreCodex(targetDir)) > 0
+}
+
+// Migrate applies the steps Detect returns and moves backups left outside the
+// backups directory, and returns the steps applied. It stops at the first step
+// that fails.
+func (s *Service) Migrate(targetDir string) ([]Step, error) {
+	var applied []Step
+	for _, step := range s.Detect(targetDir) {
+		if err := step.apply(); err != nil {
+			return applied, err
+		}
+		applied = append(applied, step)
+	}
+
+	moved, err := s.backupService.MigrateLegacyBackups(targetDir)
+	if len(moved) > 0 {
+		applied = append(applied, Step{
+			Layout:      LayoutLegacyBackups,
+			Description: fmt.Sprintf("Move %d old backup(s) to %s", len(moved), config.GetBackupsRoot(targetDir)),
+		})
+	}
+	return applied, err
+}
+
+// detectPreCodex returns the step recording claude as the only integration of
+// an installation made before .codex support
+func detectPreCodex(targetDir string) []Step {
+	templateInfoPath := filepath.Join(targetDir, config.FrameworkDir(), config.TemplateInfoFile)
+	data, err := os.ReadFile(templateInfoPath)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	if _, ok := fields["integrations"]; ok {
+		return nil
+	}
+	if _, err := os.Lstat(filepath.Join(targetDir, config.CodexDir)); err == nil {
+		return nil
+	}
+	if _, err := os.Lstat(filepath.Join(targetDir, config.ClaudeDir)); err != nil {
+		return nil
+	}
+
+	return []Step{{
+		Layout:      LayoutPreCodex,
+		Description: fmt.Sprintf("Record %s as the only integration in %s, as the installation predates %s support", models.IntegrationClaude, config.TemplateInfoFile, config.CodexDir),
+		apply: func() error {
+			integrations, err := json.Marshal([]string{models.IntegrationClaude})
+			if err != nil {
+				return err
+			}
+			fields["integrations"] = integrations
+			data, err := json.MarshalIndent(fields, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(templateInfoPath, data, config.FilePermissions); err != nil {
+				return models.NewFileSystemError(models.ErrorCodeFileSystemError, templateInfoPath, err)
+			}
+			return nil
+		},
+	}}
+}
+
+// detectMissingCoreDirs returns the steps creating the core subdirectories the
+// symlinks point to; a core directory linked to a dev mode checkout is left alone
+func detectMissingCoreDirs(targetDir string) []Step {
+	coreDir := filepath.Join(targetDir, config.FrameworkDir(), config.CoreDir)
+	if info, err := os.Lstat(coreDir); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	var steps []Step
+	for _, subdir := range []string{config.AgentsDir, config.CommandsDir, config.HooksDir} {
+		subdirPath := filepath.Join(coreDir, subdir)
+		if _, err := os.Lstat(subdirPath); !os.IsNotExist(err) {
+			continue
+		}
+		steps = append(steps, Step{
+			Layout:      LayoutMissingCoreDirs,
+			Description: fmt.Sprintf("Create the missing %s directory", filepath.Join(config.FrameworkDir(), config.CoreDir, subdir)),
+			apply: func() error {
+				if err := os.MkdirAll(subdirPath, config.DirPermissions); err != nil {
+					return models.NewFileSystemError(models.ErrorCodeFileSystemError, subdirPath, err)
+				}
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+// detectLegacySymlinks returns the steps pointing the .claude and .codex
+// symlinks into the framework directory at their current targets; links
+// pointing elsewhere are not the installation's and are left alone
+func detectLegacySymlinks(targetDir string) []Step {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	toolDirs := []struct {
+		dir      string
+		symlinks map[string]string
+	}{
+		{config.ClaudeDir, config.GetRequiredSymlinks()},
+		{config.CodexDir, config.GetCodexRequiredSymlinks()},
+	}
+
+	var steps []Step
+	for _, toolDir := range toolDirs {
+		expected := config.ResolveSymlinkTargets(targetDir, toolDir.symlinks)
+		for _, symlinkPath := range slices.Sorted(maps.Keys(expected)) {
+			linkPath := filepath.Join(targetDir, toolDir.dir, symlinkPath)
+			target, err := os.Readlink(linkPath)
+			if err != nil || target == expected[symlinkPath] {
+				continue
+			}
+			resolved := target
+			if !filepath.IsAbs(resolved) {
+				resolved = filepath.Join(filepath.Dir(linkPath), resolved)
+			}
+			if !strings.HasPrefix(filepath.Clean(resolved)+string(os.PathSeparator), strategicDir+string(os.PathSeparator)) {
+				continue
+			}
+
+			newTarget := expected[symlinkPath]
+			steps = append(steps, Step{
+				Layout:      LayoutLegacySymlinks,
+				Description: fmt.Sprintf("Point %s at %s instead of %s", filepath.Join(toolDir.dir, symlinkPath), newTarget, target),
+				apply: func() error {
+					if err := os.Remove(linkPath); err != nil {
+						return models.NewFileSystemError(models.ErrorCodeFileSystemError, linkPath, err)
+					}
+					if err := os.Symlink(newTarget, linkPath); err != nil {
+						return models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, linkPath, err)
+					}
+					return nil
+				},
+			})
+		}
+	}
+	return steps
+}
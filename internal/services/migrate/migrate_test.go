@@ -0,0 +1,180 @@
+package migrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// setupInstallation creates a current installation with the .claude symlinks
+// and returns its target directory
+func setupInstallation(t *testing.T) string {
+	t.Helper()
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	coreDir := filepath.Join(targetDir, config.FrameworkDir(), config.CoreDir)
+	for _, subdir := range []string{config.AgentsDir, config.CommandsDir, config.HooksDir} {
+		if err := os.MkdirAll(filepath.Join(coreDir, subdir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for symlinkPath, target := range config.GetRequiredSymlinks() {
+		linkPath := filepath.Join(targetDir, config.ClaudeDir, symlinkPath)
+		if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, linkPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTemplateInfo(t, targetDir, map[string]any{"template": map[string]any{"id": "main"}, "integrations": []string{models.IntegrationClaude}})
+	return targetDir
+}
+
+func writeTemplateInfo(t *testing.T, targetDir string, info map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(targetDir, config.FrameworkDir(), config.TemplateInfoFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func layouts(steps []Step) []string {
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Layout)
+	}
+	return names
+}
+
+func TestService_Detect_CurrentLayout(t *testing.T) {
+	targetDir := setupInstallation(t)
+
+	if steps := New().Detect(targetDir); len(steps) != 0 {
+		t.Errorf("Detect() = %v, want no steps for a current installation", layouts(steps))
+	}
+	if steps := New().Detect(t.TempDir()); len(steps) != 0 {
+		t.Errorf("Detect() = %v, want no steps without an installation", layouts(steps))
+	}
+}
+
+func TestService_Migrate_PreCodex(t *testing.T) {
+	targetDir := setupInstallation(t)
+	writeTemplateInfo(t, targetDir, map[string]any{"template": map[string]any{"id": "main"}, "installed_commit": "abc123"})
+
+	service := New()
+	if !service.PreCodex(targetDir) {
+		t.Fatal("PreCodex() = false, want true without integrations and .codex")
+	}
+	applied, err := service.Migrate(targetDir)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := layouts(applied); len(got) != 1 || got[0] != LayoutPreCodex {
+		t.Fatalf("Migrate() applied %v, want [%s]", got, LayoutPreCodex)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, config.FrameworkDir(), config.TemplateInfoFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info struct {
+		InstalledCommit string   `json:"installed_commit"`
+		Integrations    []string `json:"integrations"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Integrations) != 1 || info.Integrations[0] != models.IntegrationClaude {
+		t.Errorf("integrations = %v, want [%s]", info.Integrations, models.IntegrationClaude)
+	}
+	if info.InstalledCommit != "abc123" {
+		t.Errorf("installed_commit = %q, want it kept", info.InstalledCommit)
+	}
+	if service.PreCodex(targetDir) {
+		t.Error("PreCodex() = true after migration")
+	}
+}
+
+func TestService_Migrate_MissingCoreDirs(t *testing.T) {
+	targetDir := setupInstallation(t)
+	hooksDir := filepath.Join(targetDir, config.FrameworkDir(), config.CoreDir, config.HooksDir)
+	if err := os.Remove(hooksDir); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := New().Migrate(targetDir)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := layouts(applied); len(got) != 1 || got[0] != LayoutMissingCoreDirs {
+		t.Fatalf("Migrate() applied %v, want [%s]", got, LayoutMissingCoreDirs)
+	}
+	if info, err := os.Stat(hooksDir); err != nil || !info.IsDir() {
+		t.Errorf("%s not created: %v", hooksDir, err)
+	}
+}
+
+func TestService_Migrate_LegacySymlinks(t *testing.T) {
+	targetDir := setupInstallation(t)
+	symlinkPath := config.AgentsDir + "/" + config.StrategicLinkName()
+	linkPath := filepath.Join(targetDir, config.ClaudeDir, symlinkPath)
+	absoluteTarget := filepath.Join(targetDir, config.FrameworkDir(), config.CoreDir, config.AgentsDir)
+	if err := os.Remove(linkPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(absoluteTarget, linkPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// A link into another directory is the user's and is left alone
+	userLink := filepath.Join(targetDir, config.ClaudeDir, config.CommandsDir, config.StrategicLinkName())
+	if err := os.Remove(userLink); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(t.TempDir(), userLink); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := New().Migrate(targetDir)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := layouts(applied); len(got) != 1 || got[0] != LayoutLegacySymlinks {
+		t.Fatalf("Migrate() applied %v, want [%s]", got, LayoutLegacySymlinks)
+	}
+	target, err := os.Readlink(linkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := config.GetRequiredSymlinks()[symlinkPath]; target != want {
+		t.Errorf("symlink target = %q, want %q", target, want)
+	}
+}
+
+func TestService_Migrate_LegacyBackups(t *testing.T) {
+	targetDir := setupInstallation(t)
+	legacyBackup := filepath.Join(targetDir, config.BackupDirPrefix+"20240101-120000")
+	if err := os.MkdirAll(legacyBackup, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := New().Migrate(targetDir)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := layouts(applied); len(got) != 1 || got[0] != LayoutLegacyBackups {
+		t.Fatalf("Migrate() applied %v, want [%s]", got, LayoutLegacyBackups)
+	}
+	if _, err := os.Stat(legacyBackup); !os.IsNotExist(err) {
+		t.Error("legacy backup should have been moved")
+	}
+}
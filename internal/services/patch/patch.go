@@ -0,0 +1,315 @@
+// Package patch records what an installation would change in a project as a
+// reviewable patch and shell script, instead of applying the changes.
+package patch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// StageDirPrefix names the temporary copies installations are staged in
+const StageDirPrefix = "strategic-claude-patch-"
+
+// ChangeKind describes how a path differs between the project and the staged installation
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeModified ChangeKind = "modified"
+	ChangeRemoved  ChangeKind = "removed"
+)
+
+// Entry is the state of a single path: a regular file or a symlink
+type Entry struct {
+	Mode    fs.FileMode
+	Content []byte // File content
+	Target  string // Symlink target
+}
+
+// IsSymlink reports whether the entry is a symlink
+func (e *Entry) IsSymlink() bool {
+	return e != nil && e.Mode&fs.ModeSymlink != 0
+}
+
+// Change is a path whose state differs; Old is nil for added paths and New for removed ones
+type Change struct {
+	Path string // Slash-separated, relative to the project
+	Kind ChangeKind
+	Old  *Entry
+	New  *Entry
+}
+
+// Service stages installations and compares them with the project
+type Service struct{}
+
+// New creates a new patch service instance
+func New() *Service {
+	return &Service{}
+}
+
+// Stage copies the installed paths of targetDir into a new temporary directory
+// and returns it. The caller removes the directory when done.
+func (s *Service) Stage(targetDir string) (string, error) {
+	stageDir, err := os.MkdirTemp(config.TempDir(), StageDirPrefix)
+	if err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, config.TempDir(), err)
+	}
+
+	// The stage mirrors the installation, links included
+	fsService := filesystem.New()
+	_ = fsService.SetSymlinkPolicy(filesystem.SymlinkKeep)
+	for _, path := range config.GetInstalledPaths() {
+		source := filepath.Join(targetDir, path)
+		info, err := os.Lstat(source)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			_ = os.RemoveAll(stageDir)
+			return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, source, err)
+		}
+
+		dest := filepath.Join(stageDir, path)
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(source)
+			if err == nil {
+				err = os.Symlink(target, dest)
+			}
+			if err != nil {
+				_ = os.RemoveAll(stageDir)
+				return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, source, err)
+			}
+		case info.IsDir():
+			err = fsService.CopyDirectory(source, dest)
+		default:
+			err = fsService.CopyFile(source, dest)
+		}
+		if err != nil {
+			_ = os.RemoveAll(stageDir)
+			return "", err
+		}
+	}
+
+	return stageDir, nil
+}
+
+// Compare returns the changes that turn the installed paths of baseDir into those
+// of stagedDir, sorted by path. Hook virtual environments are left out.
+func (s *Service) Compare(baseDir, stagedDir string) ([]Change, error) {
+	before, err := collect(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	after, err := collect(stagedDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for path, newEntry := range after {
+		oldEntry, exists := before[path]
+		switch {
+		case !exists:
+			changes = append(changes, Change{Path: path, Kind: ChangeAdded, New: newEntry})
+		case !sameEntry(oldEntry, newEntry):
+			changes = append(changes, Change{Path: path, Kind: ChangeModified, Old: oldEntry, New: newEntry})
+		}
+	}
+	for path, oldEntry := range before {
+		if _, exists := after[path]; !exists {
+			changes = append(changes, Change{Path: path, Kind: ChangeRemoved, Old: oldEntry})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// collect reads every file and symlink below the installed paths of dir
+func collect(dir string) (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+
+	for _, installed := range config.GetInstalledPaths() {
+		root := filepath.Join(dir, installed)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() {
+				if info.Name() == config.HookVenvDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			entry := &Entry{Mode: info.Mode()}
+			if info.Mode()&os.ModeSymlink != 0 {
+				entry.Target, err = os.Readlink(path)
+			} else {
+				entry.Content, err = os.ReadFile(path)
+			}
+			if err != nil {
+				return err
+			}
+			entries[filepath.ToSlash(relPath)] = entry
+			return nil
+		})
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// sameEntry reports whether two entries have the same type, executable bit and content
+func sameEntry(a, b *Entry) bool {
+	if a.IsSymlink() || b.IsSymlink() {
+		return a.IsSymlink() && b.IsSymlink() && a.Target == b.Target
+	}
+	return gitMode(a) == gitMode(b) && bytes.Equal(a.Content, b.Content)
+}
+
+// gitMode returns the file mode git records for an entry
+func gitMode(e *Entry) string {
+	switch {
+	case e.IsSymlink():
+		return "120000"
+	case e.Mode&0111 != 0:
+		return "100755"
+	default:
+		return "100644"
+	}
+}
+
+// isBinary reports whether content cannot be shown as a text diff
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// WritePatch writes the file changes as a patch that git apply accepts. Symlinks
+// are left to WriteScript; files replaced by a symlink or the other way around
+// appear in both.
+func (s *Service) WritePatch(w io.Writer, changes []Change) error {
+	var out strings.Builder
+
+	for _, change := range changes {
+		oldEntry, newEntry := change.Old, change.New
+		if oldEntry.IsSymlink() {
+			oldEntry = nil
+		}
+		if newEntry.IsSymlink() {
+			newEntry = nil
+		}
+		if oldEntry == nil && newEntry == nil {
+			continue
+		}
+
+		fmt.Fprintf(&out, "diff --git a/%s b/%s\n", change.Path, change.Path)
+		switch {
+		case oldEntry == nil:
+			fmt.Fprintf(&out, "new file mode %s\n", gitMode(newEntry))
+		case newEntry == nil:
+			fmt.Fprintf(&out, "deleted file mode %s\n", gitMode(oldEntry))
+		case gitMode(oldEntry) != gitMode(newEntry):
+			fmt.Fprintf(&out, "old mode %s\nnew mode %s\n", gitMode(oldEntry), gitMode(newEntry))
+		}
+
+		var oldText, newText []byte
+		if oldEntry != nil {
+			oldText = oldEntry.Content
+		}
+		if newEntry != nil {
+			newText = newEntry.Content
+		}
+		if isBinary(oldText) || isBinary(newText) {
+			fmt.Fprintf(&out, "Binary files %s and %s differ\n", patchName("a", change.Path, oldEntry), patchName("b", change.Path, newEntry))
+			continue
+		}
+
+		diff := utils.UnifiedDiff("a/"+change.Path, "b/"+change.Path, oldText, newText)
+		out.WriteString(diff)
+		// Whole-file additions and removals end on the last line of the file
+		if diff != "" && (oldEntry == nil || newEntry == nil) && !bytes.HasSuffix(oldText, []byte("\n")) && !bytes.HasSuffix(newText, []byte("\n")) {
+			out.WriteString("\\ No newline at end of file\n")
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// patchName returns the name of a side of a patch, or /dev/null when it is missing
+func patchName(side, path string, entry *Entry) string {
+	if entry == nil {
+		return "/dev/null"
+	}
+	return side + "/" + path
+}
+
+// WriteScript writes a POSIX shell script that applies the symlink changes,
+// to run from the project directory after the patch is applied
+func (s *Service) WriteScript(w io.Writer, changes []Change) error {
+	var out strings.Builder
+	out.WriteString("#!/bin/sh\n")
+	out.WriteString("# Symlinks of the Strategic Claude Basic installation; run from the project directory\n")
+	out.WriteString("set -e\n")
+
+	for _, change := range changes {
+		if !change.Old.IsSymlink() && !change.New.IsSymlink() {
+			continue
+		}
+
+		path := shellQuote(change.Path)
+		out.WriteString("\n")
+		if change.Old.IsSymlink() {
+			fmt.Fprintf(&out, "rm -f %s\n", path)
+		}
+		if change.New.IsSymlink() {
+			if dir := filepath.ToSlash(filepath.Dir(filepath.FromSlash(change.Path))); dir != "." {
+				fmt.Fprintf(&out, "mkdir -p %s\n", shellQuote(dir))
+			}
+			fmt.Fprintf(&out, "ln -s %s %s\n", shellQuote(change.New.Target), path)
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// HasSymlinkChanges reports whether any change needs the script
+func HasSymlinkChanges(changes []Change) bool {
+	for _, change := range changes {
+		if change.Old.IsSymlink() || change.New.IsSymlink() {
+			return true
+		}
+	}
+	return false
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,150 @@
+package patch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestService_StageAndCompare(t *testing.T) {
+	projectDir := t.TempDir()
+	writeFile(t, filepath.Join(projectDir, ".claude", "settings.json"), "{}\n", 0644)
+	writeFile(t, filepath.Join(projectDir, ".claude", "old.md"), "old\n", 0644)
+	writeFile(t, filepath.Join(projectDir, ".claude", "same.md"), "same\n", 0644)
+	writeFile(t, filepath.Join(projectDir, "README.md"), "not installed\n", 0644)
+	if err := os.Symlink("../elsewhere", filepath.Join(projectDir, ".claude", "agents")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	service := New()
+	stageDir, err := service.Stage(projectDir)
+	if err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	defer os.RemoveAll(stageDir)
+
+	if _, err := os.Stat(filepath.Join(stageDir, "README.md")); !os.IsNotExist(err) {
+		t.Errorf("Stage() copied a path outside the installation: %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(stageDir, ".claude", "agents")); err != nil || target != "../elsewhere" {
+		t.Errorf("Stage() symlink = %q, %v, want %q", target, err, "../elsewhere")
+	}
+
+	// Simulate an installation in the staged copy
+	writeFile(t, filepath.Join(stageDir, ".claude", "settings.json"), "{\"hooks\": {}}\n", 0644)
+	writeFile(t, filepath.Join(stageDir, ".strategic-claude-basic", "run.sh"), "echo hi", 0755)
+	writeFile(t, filepath.Join(stageDir, ".strategic-claude-basic", ".venv", "bin", "python"), "binary", 0755)
+	if err := os.Remove(filepath.Join(stageDir, ".claude", "old.md")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(stageDir, ".claude", "agents")); err != nil {
+		t.Fatalf("Failed to remove symlink: %v", err)
+	}
+	if err := os.Symlink("../.strategic-claude-basic/core/agents", filepath.Join(stageDir, ".claude", "agents")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	changes, err := service.Compare(projectDir, stageDir)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	want := []struct {
+		path string
+		kind ChangeKind
+	}{
+		{".claude/agents", ChangeModified},
+		{".claude/old.md", ChangeRemoved},
+		{".claude/settings.json", ChangeModified},
+		{".strategic-claude-basic/run.sh", ChangeAdded},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Compare() returned %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		if changes[i].Path != w.path || changes[i].Kind != w.kind {
+			t.Errorf("Compare()[%d] = %s %s, want %s %s", i, changes[i].Kind, changes[i].Path, w.kind, w.path)
+		}
+	}
+	if !HasSymlinkChanges(changes) {
+		t.Error("HasSymlinkChanges() = false, want true")
+	}
+}
+
+func TestService_WritePatch(t *testing.T) {
+	changes := []Change{
+		{Path: ".claude/agents", Kind: ChangeAdded, New: &Entry{Mode: os.ModeSymlink, Target: "../core/agents"}},
+		{Path: ".claude/old.md", Kind: ChangeRemoved, Old: &Entry{Mode: 0644, Content: []byte("old\n")}},
+		{Path: ".claude/settings.json", Kind: ChangeModified, Old: &Entry{Mode: 0644, Content: []byte("{}\n")}, New: &Entry{Mode: 0644, Content: []byte("{\"hooks\": {}}\n")}},
+		{Path: "bin/tool", Kind: ChangeModified, Old: &Entry{Mode: 0644, Content: []byte("x\n")}, New: &Entry{Mode: 0755, Content: []byte("x\n")}},
+		{Path: "run.sh", Kind: ChangeAdded, New: &Entry{Mode: 0755, Content: []byte("echo hi")}},
+	}
+
+	var out strings.Builder
+	if err := New().WritePatch(&out, changes); err != nil {
+		t.Fatalf("WritePatch() error = %v", err)
+	}
+
+	want := `diff --git a/.claude/old.md b/.claude/old.md
+deleted file mode 100644
+--- a/.claude/old.md
++++ /dev/null
+@@ -1,1 +0,0 @@
+-old
+diff --git a/.claude/settings.json b/.claude/settings.json
+--- a/.claude/settings.json
++++ b/.claude/settings.json
+@@ -1,1 +1,1 @@
+-{}
++{"hooks": {}}
+diff --git a/bin/tool b/bin/tool
+old mode 100644
+new mode 100755
+diff --git a/run.sh b/run.sh
+new file mode 100755
+--- /dev/null
++++ b/run.sh
+@@ -0,0 +1,1 @@
++echo hi
+\ No newline at end of file
+`
+	if out.String() != want {
+		t.Errorf("WritePatch() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestService_WriteScript(t *testing.T) {
+	changes := []Change{
+		{Path: ".claude/agents/strategic", Kind: ChangeAdded, New: &Entry{Mode: os.ModeSymlink, Target: "../../core/agents"}},
+		{Path: ".claude/settings.json", Kind: ChangeAdded, New: &Entry{Mode: 0644, Content: []byte("{}\n")}},
+		{Path: "it's", Kind: ChangeRemoved, Old: &Entry{Mode: os.ModeSymlink, Target: "gone"}},
+	}
+
+	var out strings.Builder
+	if err := New().WriteScript(&out, changes); err != nil {
+		t.Fatalf("WriteScript() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"mkdir -p '.claude/agents'\nln -s '../../core/agents' '.claude/agents/strategic'\n",
+		"rm -f 'it'\\''s'\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("WriteScript() = %q, want it to contain %q", out.String(), want)
+		}
+	}
+	if strings.Contains(out.String(), "settings.json") {
+		t.Errorf("WriteScript() includes a regular file: %q", out.String())
+	}
+}
@@ -0,0 +1,137 @@
+// Package plugin finds and runs an organization's plugins: executables init
+// runs at defined points of an installation, e.g. to register the project in
+// an internal catalog, with the installation described as JSON on stdin.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Plugin is an executable run at a point of an installation
+type Plugin struct {
+	Name  string `json:"name"`  // File name of the executable
+	Path  string `json:"path"`  // Absolute path of the executable
+	Point string `json:"point"` // One of config.GetPluginPoints()
+}
+
+// Context describes the installation a plugin runs for; it is written to the
+// plugin's stdin as JSON
+type Context struct {
+	Point            string   `json:"point"`
+	TargetDir        string   `json:"target_dir"`
+	FrameworkDir     string   `json:"framework_dir"`
+	Instance         string   `json:"instance,omitempty"`
+	Template         string   `json:"template"`
+	TemplateRepo     string   `json:"template_repo"`
+	TemplateCommit   string   `json:"template_commit"`
+	InstallationType string   `json:"installation_type"`
+	Integrations     []string `json:"integrations"`
+	Components       []string `json:"components,omitempty"` // Empty when all components are installed
+	SourceDir        string   `json:"source_dir"`           // The fetched template
+}
+
+// Discover returns the plugins to run: the ones listed in the config for each
+// point, then the executables in the point's subdirectory of the plugins
+// directory, by name. Hidden and non-executable files are left out.
+func Discover() ([]Plugin, error) {
+	cfg := config.Current()
+	dir := config.PluginsDir()
+
+	var plugins []Plugin
+	for _, point := range config.GetPluginPoints() {
+		for _, path := range cfg.Plugins[point] {
+			if _, err := os.Stat(path); err != nil {
+				return nil, models.NewAppError(
+					models.ErrorCodeInvalidPath,
+					fmt.Sprintf("Configured %s plugin not found: %s", point, path),
+					err,
+				)
+			}
+			plugins = append(plugins, Plugin{Name: filepath.Base(path), Path: path, Point: point})
+		}
+
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(dir, point))
+		if err != nil {
+			continue // No plugins for this point
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, point, entry.Name())
+			if strings.HasPrefix(entry.Name(), ".") || !isExecutable(path) {
+				continue
+			}
+			plugins = append(plugins, Plugin{Name: entry.Name(), Path: path, Point: point})
+		}
+	}
+	return plugins, nil
+}
+
+// isExecutable reports whether path is a file that can be run; Windows has no
+// executable bit, so any file is
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0111 != 0
+}
+
+// Service runs plugins
+type Service struct {
+	ctx context.Context
+}
+
+// New creates a new plugin service instance
+func New() *Service {
+	return &Service{ctx: context.Background()}
+}
+
+// SetContext makes running plugins stop when ctx is done, e.g. at the deadline
+// of the installation
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Run runs a plugin in the target directory with the context on stdin; its
+// output is shown as it runs
+func (s *Service) Run(plugin Plugin, pluginContext Context) error {
+	input, err := json.Marshal(pluginContext)
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin context: %w", err)
+	}
+
+	cmd := exec.CommandContext(s.ctx, plugin.Path)
+	cmd.Dir = pluginContext.TargetDir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.WaitDelay = config.ScriptStopTimeout
+
+	if err := cmd.Run(); err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return models.NewAppError(
+			models.ErrorCodePluginFailed,
+			fmt.Sprintf("Plugin %s failed at %s (exit code %d)", plugin.Name, plugin.Point, exitCode),
+			err,
+		).WithContext("path", plugin.Path)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// usePlugins makes the config list plugins and search dir until the test ends
+func usePlugins(t *testing.T, plugins map[string][]string, dir string) {
+	t.Helper()
+
+	previous := config.Current()
+	cfg := previous
+	cfg.Plugins = plugins
+	cfg.PluginsDir = dir
+	if err := config.SetCurrent(cfg); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	t.Cleanup(func() { _ = config.SetCurrent(previous) })
+}
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by their executable bit")
+	}
+
+	dir := t.TempDir()
+	configured := filepath.Join(t.TempDir(), "register")
+	writeFile(t, configured, "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(dir, config.PluginPointPostInstall, "b-notify"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(dir, config.PluginPointPostInstall, "a-audit"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(dir, config.PluginPointPostInstall, "README"), "not a plugin\n", 0644)
+	writeFile(t, filepath.Join(dir, config.PluginPointPostInstall, ".hidden"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(dir, config.PluginPointPreInstall, "check"), "#!/bin/sh\n", 0755)
+	usePlugins(t, map[string][]string{config.PluginPointPostInstall: {configured}}, dir)
+
+	plugins, err := Discover()
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	want := []Plugin{
+		{Name: "check", Path: filepath.Join(dir, config.PluginPointPreInstall, "check"), Point: config.PluginPointPreInstall},
+		{Name: "register", Path: configured, Point: config.PluginPointPostInstall},
+		{Name: "a-audit", Path: filepath.Join(dir, config.PluginPointPostInstall, "a-audit"), Point: config.PluginPointPostInstall},
+		{Name: "b-notify", Path: filepath.Join(dir, config.PluginPointPostInstall, "b-notify"), Point: config.PluginPointPostInstall},
+	}
+	if !reflect.DeepEqual(plugins, want) {
+		t.Errorf("Discover() = %+v, want %+v", plugins, want)
+	}
+
+	// A configured plugin that does not exist is an error rather than skipped
+	usePlugins(t, map[string][]string{config.PluginPointPostCopy: {filepath.Join(dir, "missing")}}, dir)
+	if _, err := Discover(); err == nil {
+		t.Error("Discover() with a missing configured plugin returned no error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+
+	target := t.TempDir()
+	path := filepath.Join(t.TempDir(), "record")
+	writeFile(t, path, "#!/bin/sh\ncat > context.json\n", 0755)
+
+	pluginContext := Context{
+		Point:            config.PluginPointPostInstall,
+		TargetDir:        target,
+		Template:         "main",
+		InstallationType: "new",
+		Integrations:     []string{"claude"},
+	}
+	service := New()
+	if err := service.Run(Plugin{Name: "record", Path: path, Point: config.PluginPointPostInstall}, pluginContext); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// The plugin ran in the target directory with the context on stdin
+	data, err := os.ReadFile(filepath.Join(target, "context.json"))
+	if err != nil {
+		t.Fatalf("Plugin did not write its input: %v", err)
+	}
+	var got Context
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Plugin input is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, pluginContext) {
+		t.Errorf("Plugin input = %+v, want %+v", got, pluginContext)
+	}
+
+	failing := filepath.Join(t.TempDir(), "fail")
+	writeFile(t, failing, "#!/bin/sh\nexit 3\n", 0755)
+	err = service.Run(Plugin{Name: "fail", Path: failing, Point: config.PluginPointPostInstall}, pluginContext)
+	if !models.IsErrorCode(err, models.ErrorCodePluginFailed) {
+		t.Errorf("Run() of a failing plugin error = %v, want %s", err, models.ErrorCodePluginFailed)
+	}
+}
@@ -0,0 +1,232 @@
+// Package registry records the projects this CLI installed into, so commands
+// can work on all of them without searching the disk.
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Entry is a project in the registry
+type Entry struct {
+	Path        string    `json:"path"` // Absolute
+	Template    string    `json:"template"`
+	InstalledAt time.Time `json:"installed_at"` // First installation recorded
+	UpdatedAt   time.Time `json:"updated_at"`   // Latest installation or update recorded
+}
+
+// file is the format of the registry file
+type file struct {
+	Version  int     `json:"version"`
+	Projects []Entry `json:"projects"`
+}
+
+// Service reads and changes the registry file
+type Service struct {
+	path string
+	now  func() time.Time
+}
+
+// New creates a registry service for the registry file in Dir(); it is
+// disabled when there is no data directory
+func New() *Service {
+	dir, err := Dir()
+	if err != nil {
+		return NewWithPath("")
+	}
+	return NewWithPath(filepath.Join(dir, config.RegistryFile))
+}
+
+// NewWithPath creates a registry service for the registry file at path
+func NewWithPath(path string) *Service {
+	return &Service{path: path, now: time.Now}
+}
+
+// Dir returns the data directory: $SCB_DATA_DIR, or below XDG_DATA_HOME or
+// ~/.local/share, or the user config directory on Windows
+func Dir() (string, error) {
+	if dir := os.Getenv(config.DataDirEnvVar); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Clean(dir), nil
+	}
+	if xdgData := os.Getenv("XDG_DATA_HOME"); xdgData != "" && filepath.IsAbs(xdgData) {
+		return filepath.Join(xdgData, config.DataDirName), nil
+	}
+
+	if runtime.GOOS == "windows" {
+		userConfig, err := os.UserConfigDir()
+		if err != nil {
+			return "", models.NewAppError(models.ErrorCodeFileSystemError, "Failed to determine data directory", err)
+		}
+		return filepath.Join(userConfig, config.DataDirName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", models.NewAppError(models.ErrorCodeFileSystemError, "Failed to determine data directory", err)
+	}
+	return filepath.Join(home, ".local", "share", config.DataDirName), nil
+}
+
+// Path returns the registry file
+func (s *Service) Path() string {
+	return s.path
+}
+
+// Enabled reports whether the registry has a usable location
+func (s *Service) Enabled() bool {
+	return s.path != ""
+}
+
+// List returns the projects in the registry, ordered by path
+func (s *Service) List() ([]Entry, error) {
+	registry, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Projects, nil
+}
+
+// Add records an installation of templateID into dir, or an update of it
+func (s *Service) Add(dir, templateID string) error {
+	path, err := entryPath(dir)
+	if err != nil {
+		return err
+	}
+	now := s.now().UTC()
+	return s.change(func(registry *file) bool {
+		index := slices.IndexFunc(registry.Projects, func(entry Entry) bool { return entry.Path == path })
+		if index < 0 {
+			registry.Projects = append(registry.Projects, Entry{Path: path, InstalledAt: now})
+			index = len(registry.Projects) - 1
+		}
+		registry.Projects[index].Template = templateID
+		registry.Projects[index].UpdatedAt = now
+		return true
+	})
+}
+
+// Remove forgets dir; it is not an error when dir is not in the registry
+func (s *Service) Remove(dir string) error {
+	path, err := entryPath(dir)
+	if err != nil {
+		return err
+	}
+	if !s.exists() {
+		return nil
+	}
+	return s.change(func(registry *file) bool {
+		before := len(registry.Projects)
+		registry.Projects = slices.DeleteFunc(registry.Projects, func(entry Entry) bool { return entry.Path == path })
+		return len(registry.Projects) != before
+	})
+}
+
+// Prune forgets the projects whose directories no longer exist and returns them
+func (s *Service) Prune() ([]Entry, error) {
+	if !s.exists() {
+		return nil, nil
+	}
+	var pruned []Entry
+	err := s.change(func(registry *file) bool {
+		registry.Projects = slices.DeleteFunc(registry.Projects, func(entry Entry) bool {
+			if Exists(entry) {
+				return false
+			}
+			pruned = append(pruned, entry)
+			return true
+		})
+		return len(pruned) > 0
+	})
+	return pruned, err
+}
+
+// Exists reports whether the directory of a project still exists
+func Exists(entry Entry) bool {
+	info, err := os.Stat(entry.Path)
+	return err == nil && info.IsDir()
+}
+
+// exists reports whether the registry file has been written
+func (s *Service) exists() bool {
+	if !s.Enabled() {
+		return false
+	}
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+// entryPath returns how dir is recorded in the registry
+func entryPath(dir string) (string, error) {
+	path, err := filepath.Abs(dir)
+	if err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeInvalidPath, dir, err)
+	}
+	return path, nil
+}
+
+// read loads the registry file; a missing file is an empty registry
+func (s *Service) read() (*file, error) {
+	registry := &file{Version: config.RegistryFormatVersion}
+	if !s.Enabled() {
+		return registry, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, s.path, err)
+	}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, models.NewAppError(models.ErrorCodeInvalidConfiguration, "Failed to parse the project registry "+s.path, err)
+	}
+	if registry.Version > config.RegistryFormatVersion {
+		return nil, models.NewAppError(models.ErrorCodeInvalidConfiguration,
+			"The project registry "+s.path+" was written by a newer version of this CLI", nil)
+	}
+	slices.SortFunc(registry.Projects, func(a, b Entry) int { return strings.Compare(a.Path, b.Path) })
+	return registry, nil
+}
+
+// change applies fn to the registry under the registry lock and writes the
+// result when fn reports a change
+func (s *Service) change(fn func(registry *file) bool) error {
+	if !s.Enabled() {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(s.path), err)
+	}
+
+	// Installations running at once must not lose each other's entries
+	lock, err := utils.AcquireFileLock(s.path, config.RegistryLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	registry, err := s.read()
+	if err != nil {
+		return err
+	}
+	if !fn(registry) {
+		return nil
+	}
+
+	registry.Version = config.RegistryFormatVersion
+	slices.SortFunc(registry.Projects, func(a, b Entry) int { return strings.Compare(a.Path, b.Path) })
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(s.path, append(data, '\n'), config.FilePermissions)
+}
@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestService_AddRemovePrune(t *testing.T) {
+	dataDir := t.TempDir()
+	projects := t.TempDir()
+	api := filepath.Join(projects, "api")
+	web := filepath.Join(projects, "web")
+	for _, dir := range []string{api, web} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewWithPath(filepath.Join(dataDir, "registry", "projects.json"))
+	installed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	service.now = func() time.Time { return installed }
+	if err := service.Add(web, "main"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := service.Add(api, "main"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// Adding a project again records the update and keeps the installation time
+	updated := installed.Add(time.Hour)
+	service.now = func() time.Time { return updated }
+	if err := service.Add(web, "minimal"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	entries, err := service.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path != api || entries[1].Path != web {
+		t.Fatalf("List() = %+v, want api and web ordered by path", entries)
+	}
+	if entries[1].Template != "minimal" || !entries[1].InstalledAt.Equal(installed) || !entries[1].UpdatedAt.Equal(updated) {
+		t.Errorf("List()[1] = %+v, want the update recorded", entries[1])
+	}
+
+	if err := os.RemoveAll(api); err != nil {
+		t.Fatal(err)
+	}
+	pruned, err := service.Prune()
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Path != api {
+		t.Errorf("Prune() = %+v, want api", pruned)
+	}
+
+	if err := service.Remove(web); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := service.Remove(web); err != nil {
+		t.Errorf("Remove() of a missing project error = %v", err)
+	}
+	if entries, err := service.List(); err != nil || len(entries) != 0 {
+		t.Errorf("List() = %+v, %v, want an empty registry", entries, err)
+	}
+}
+
+func TestService_Disabled(t *testing.T) {
+	service := NewWithPath("")
+	if err := service.Add(t.TempDir(), "main"); err != nil {
+		t.Errorf("Add() error = %v", err)
+	}
+	if entries, err := service.List(); err != nil || len(entries) != 0 {
+		t.Errorf("List() = %+v, %v, want an empty registry", entries, err)
+	}
+}
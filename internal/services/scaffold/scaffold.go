@@ -0,0 +1,224 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// scriptPermissions are used for install scripts and hooks
+const scriptPermissions = 0755
+
+// exampleHook is the script name of the example hook. It is one of the hooks
+// the CLI recognizes as strategic, so installs point it at .claude/hooks/strategic.
+const exampleHook = "stop-session-notify.py"
+
+// skeletonFile is a file of the generated template
+type skeletonFile struct {
+	content    string
+	executable bool
+}
+
+// userDirectoryDescriptions describe the user directories in their CLAUDE.md
+var userDirectoryDescriptions = map[string]string{
+	config.ArchivesDir:   "Completed and retired documents.",
+	config.DecisionsDir:  "Architecture and product decisions.",
+	config.IssuesDir:     "Issues tracked alongside the code.",
+	config.PlanDir:       "Implementation plans.",
+	config.ProductDir:    "Product documentation and roadmaps.",
+	config.ResearchDir:   "Research notes and findings.",
+	config.SummaryDir:    "Summaries of completed work.",
+	config.ToolsDir:      "Project-specific tools and scripts.",
+	config.ValidationDir: "Validation notes and scripts.",
+}
+
+// Service generates template repository skeletons
+type Service struct{}
+
+// New creates a new scaffold service instance
+func New() *Service {
+	return &Service{}
+}
+
+// Create writes a minimal template repository named name to dir, which must
+// not exist or be empty. Returns the created files relative to dir, sorted.
+func (s *Service) Create(dir, name string) ([]string, error) {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, models.NewFileSystemError(models.ErrorCodeDirectoryNotEmpty, dir, nil)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+	}
+
+	files := skeleton(name)
+	paths := make([]string, 0, len(files))
+	for relPath := range files {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	for _, relPath := range paths {
+		file := files[relPath]
+		fullPath := filepath.Join(dir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), config.DirPermissions); err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(fullPath), err)
+		}
+
+		perm := os.FileMode(config.FilePermissions)
+		if file.executable {
+			perm = scriptPermissions
+		}
+		if err := os.WriteFile(fullPath, []byte(file.content), perm); err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, fullPath, err)
+		}
+		// WriteFile applies the umask, scripts must stay executable
+		if err := os.Chmod(fullPath, perm); err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodePermissionDenied, fullPath, err)
+		}
+	}
+
+	return paths, nil
+}
+
+// skeleton returns the files of a template repository, keyed by slash-separated path
+func skeleton(name string) map[string]skeletonFile {
+	framework := config.StrategicClaudeBasicDir + "/"
+	hooksDir := framework + config.CoreDir + "/" + config.HooksDir
+
+	files := map[string]skeletonFile{
+		"README.md": {content: fmt.Sprintf(readmeTemplate, name)},
+
+		config.PreInstallScript:  {content: preInstallScript, executable: true},
+		config.PostInstallScript: {content: postInstallScript, executable: true},
+
+		framework + config.CoreDir + "/" + config.AgentsDir + "/example-agent.md": {content: exampleAgent},
+		framework + config.CoreDir + "/" + config.CommandsDir + "/example.md":     {content: exampleCommand},
+		hooksDir + "/" + exampleHook:                {content: exampleHookScript, executable: true},
+		framework + config.GuidesDir + "/README.md": {content: fmt.Sprintf(guideTemplate, name)},
+		framework + config.SettingsTemplateFile:     {content: settingsTemplate},
+		framework + config.ConventionsTemplateFile:  {content: fmt.Sprintf(conventionsTemplate, name)},
+	}
+
+	ignoreTemplates := map[string]string{
+		config.ClaudeIgnoreTemplate:           claudeIgnoreTemplate,
+		config.StrategicIgnoreAllTemplate:     strategicIgnoreAllTemplate,
+		config.StrategicIgnoreNonUserTemplate: strategicIgnoreNonUserTemplate,
+	}
+	for name, content := range ignoreTemplates {
+		files[framework+config.IgnoreTemplatesDir+"/"+name] = skeletonFile{content: content}
+	}
+
+	for _, dir := range config.GetUserPreservedDirectories() {
+		files[framework+dir+"/"+config.ClaudeConfigFile] = skeletonFile{
+			content: fmt.Sprintf("# %s\n\n%s This directory is preserved when the framework is updated.\n",
+				strings.ToUpper(dir[:1])+dir[1:], userDirectoryDescriptions[dir]),
+		}
+	}
+
+	return files
+}
+
+const readmeTemplate = `# %s
+
+A Strategic Claude Basic template.
+
+- ` + "`.strategic-claude-basic/core/`" + ` holds the agents, commands and hooks linked into
+  ` + "`.claude/`" + ` on install, and is replaced on core updates
+- ` + "`.strategic-claude-basic/guides/`" + ` and ` + "`templates/`" + ` are replaced on core updates too
+- The other directories in ` + "`.strategic-claude-basic/`" + ` belong to users and are preserved
+- ` + "`pre-install.sh`" + ` and ` + "`post-install.sh`" + ` run in the project directory around installs
+
+Check the template before pinning a commit:
+
+    strategic-claude-basic-cli template lint .
+`
+
+const preInstallScript = `#!/bin/bash
+# Runs in the project directory before the framework is copied.
+set -euo pipefail
+`
+
+const postInstallScript = `#!/bin/bash
+# Runs in the project directory after the framework is installed.
+set -euo pipefail
+`
+
+const exampleAgent = `---
+name: example-agent
+description: Example agent, replace it with your own
+---
+
+You are an example agent. Describe the agent's role and how it should work here.
+`
+
+const exampleCommand = `---
+description: Example command, replace it with your own
+---
+
+Describe what Claude should do when /example is run. $ARGUMENTS holds the
+command's arguments.
+`
+
+const exampleHookScript = `#!/usr/bin/env python3
+"""Example Stop hook: reports that Claude finished responding."""
+
+import json
+import sys
+
+
+def main():
+    event = json.load(sys.stdin)
+    print(f"Session {event.get('session_id', 'unknown')} stopped", file=sys.stderr)
+
+
+if __name__ == "__main__":
+    main()
+`
+
+const guideTemplate = `# %s guides
+
+Documentation for the team using this template. Guides are replaced on core updates.
+`
+
+const conventionsTemplate = `# %s conventions
+
+Conventions shared with Aider and OpenCode. Describe how the team works with the
+framework here: where plans and research go and how changes are reviewed.
+`
+
+const settingsTemplate = `{
+  "hooks": {
+    "Stop": [
+      {
+        "matcher": "",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/` + exampleHook + `"
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+
+const claudeIgnoreTemplate = `# Strategic Claude Basic symlinks
+agents/strategic
+commands/strategic
+hooks/strategic
+`
+
+const strategicIgnoreAllTemplate = `# Ignore the whole framework installation
+*
+`
+
+const strategicIgnoreNonUserTemplate = `# Ignore framework content, keep user directories tracked
+core/
+guides/
+templates/
+`
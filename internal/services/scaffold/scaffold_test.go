@@ -0,0 +1,45 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/templatelint"
+)
+
+func TestService_Create(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "acme-template")
+
+	files, err := New().Create(dir, "acme-template")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !slices.IsSorted(files) || !slices.Contains(files, config.PostInstallScript) {
+		t.Errorf("Create() files = %v, want sorted and including %s", files, config.PostInstallScript)
+	}
+
+	// The skeleton is a compliant template
+	report, err := templatelint.New().Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Lint() issues = %+v, want none", report.Issues)
+	}
+}
+
+func TestService_Create_NotEmpty(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "existing.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := New().Create(dir, "template")
+	if !models.IsErrorCode(err, models.ErrorCodeDirectoryNotEmpty) {
+		t.Errorf("Create() error = %v, want code %s", err, models.ErrorCodeDirectoryNotEmpty)
+	}
+}
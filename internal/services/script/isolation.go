@@ -0,0 +1,87 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// SetIsolation selects where ExecuteScript runs scripts. With
+// ScriptIsolationDocker they run in a container that only has the target
+// directory mounted; when Docker is not available, an error is returned and
+// scripts keep running without isolation.
+func (s *Service) SetIsolation(mode string) error {
+	if err := models.ValidateScriptIsolation(mode); err != nil {
+		return err
+	}
+	s.isolation = models.ScriptIsolationNone
+	if mode != models.ScriptIsolationDocker {
+		return nil
+	}
+	if err := dockerAvailable(); err != nil {
+		return err
+	}
+	s.isolation = models.ScriptIsolationDocker
+	return nil
+}
+
+// Isolation returns where ExecuteScript runs scripts
+func (s *Service) Isolation() string {
+	if s.isolation == "" {
+		return models.ScriptIsolationNone
+	}
+	return s.isolation
+}
+
+// command builds the command running the script in targetDir
+func (s *Service) command(targetDir, scriptName string) *exec.Cmd {
+	if s.Isolation() != models.ScriptIsolationDocker {
+		cmd := exec.CommandContext(s.ctx, "bash", s.GetScriptPath(targetDir, scriptName))
+		cmd.Dir = targetDir
+		cmd.WaitDelay = config.ScriptStopTimeout // Children may keep the output open
+		return cmd
+	}
+
+	// Killing the docker client would leave the container running; an
+	// interrupt is passed on to the script, which gets a moment to stop
+	cmd := exec.CommandContext(s.ctx, "docker", dockerArgs(targetDir, scriptName)...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = config.ScriptStopTimeout
+	return cmd
+}
+
+// dockerArgs returns the docker arguments running scriptName in a throwaway
+// container with only targetDir mounted, as the current user so files the
+// script creates are not owned by root
+func dockerArgs(targetDir, scriptName string) []string {
+	args := []string{
+		"run", "--rm",
+		"--volume", targetDir + ":" + config.ScriptContainerWorkdir,
+		"--workdir", config.ScriptContainerWorkdir,
+	}
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 {
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+	return append(args, config.ScriptContainerImage, "bash", path.Join(config.ScriptContainerWorkdir, scriptName))
+}
+
+// dockerAvailable returns an error when the docker client is not installed or
+// cannot reach a daemon
+func dockerAvailable() error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return models.NewAppError(models.ErrorCodeDockerUnavailable, "Docker is not installed", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), config.DockerCheckTimeout)
+	defer cancel()
+	if err := exec.CommandContext(ctx, "docker", "info").Run(); err != nil {
+		return models.NewAppError(models.ErrorCodeDockerUnavailable, "Docker is not running", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package script
+
+// tailBuffer keeps the last limit bytes written to it
+type tailBuffer struct {
+	limit     int
+	data      []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	if excess := len(b.data) - b.limit; excess > 0 {
+		b.data = append(b.data[:0], b.data[excess:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
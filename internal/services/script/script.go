@@ -1,20 +1,72 @@
 package script
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
 // Service handles script operations for the Strategic Claude Basic CLI
-type Service struct{}
+type Service struct {
+	ctx           context.Context
+	pins          map[string]string // Expected SHA-256 by script name
+	allowUnpinned bool
+	isolation     string // models.ScriptIsolationNone or models.ScriptIsolationDocker
+	pathValidator *utils.PathValidator
+}
 
 // New creates a new script service instance
 func New() *Service {
-	return &Service{}
+	return &Service{ctx: context.Background(), pathValidator: utils.NewPathValidator()}
+}
+
+// SetContext makes running scripts stop when ctx is done, e.g. at the deadline
+// of the installation
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// SetPins makes ExecuteScript refuse scripts whose SHA-256 differs from the
+// hash pinned for their name. Once any hash is pinned, scripts without one are
+// refused too. allowUnpinned runs them all anyway.
+func (s *Service) SetPins(pins map[string]string, allowUnpinned bool) {
+	s.pins = pins
+	s.allowUnpinned = allowUnpinned
+}
+
+// CheckPin returns an error when the pinned hashes do not allow running the script
+func (s *Service) CheckPin(info models.ScriptInfo) error {
+	if len(s.pins) == 0 || s.allowUnpinned {
+		return nil
+	}
+
+	pinned, ok := s.pins[info.Name]
+	if !ok {
+		return models.NewAppError(
+			models.ErrorCodeScriptHashMismatch,
+			fmt.Sprintf("No hash is pinned for %s (sha256 %s)", info.Name, info.SHA256),
+			nil,
+		)
+	}
+	if !strings.EqualFold(pinned, info.SHA256) {
+		return models.NewAppError(
+			models.ErrorCodeScriptHashMismatch,
+			fmt.Sprintf("%s has sha256 %s, but %s is pinned", info.Name, info.SHA256, pinned),
+			nil,
+		)
+	}
+	return nil
 }
 
 // CopyScript copies a script from the template source directory to the target directory
@@ -27,8 +79,11 @@ func (s *Service) CopyScript(sourceDir, targetDir, scriptName string) error {
 		)
 	}
 
-	// Source script path
-	sourcePath := filepath.Join(sourceDir, scriptName)
+	// Source script path; script names must stay in the template directory
+	sourcePath, err := s.pathValidator.SafeJoin(sourceDir, scriptName)
+	if err != nil {
+		return err
+	}
 
 	// Check if source script exists
 	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
@@ -38,7 +93,10 @@ func (s *Service) CopyScript(sourceDir, targetDir, scriptName string) error {
 	}
 
 	// Target script path
-	targetPath := filepath.Join(targetDir, scriptName)
+	targetPath, err := s.pathValidator.SafeJoin(targetDir, scriptName)
+	if err != nil {
+		return err
+	}
 
 	// Copy the script file
 	sourceFile, err := os.Open(sourcePath)
@@ -61,45 +119,104 @@ func (s *Service) CopyScript(sourceDir, targetDir, scriptName string) error {
 	return nil
 }
 
-// ExecuteScript executes a script in the target directory
-func (s *Service) ExecuteScript(targetDir, scriptName string) error {
+// ExecuteScript executes a script in the target directory. The output is shown
+// as the script runs and its end is kept in the result, which is returned
+// whenever the script ran, also when it failed; it is nil when the script
+// does not exist.
+func (s *Service) ExecuteScript(targetDir, scriptName string) (*models.ScriptResult, error) {
 	if targetDir == "" || scriptName == "" {
-		return models.NewAppError(
+		return nil, models.NewAppError(
 			models.ErrorCodeValidationFailed,
 			"Target directory and script name cannot be empty",
 			nil,
 		)
 	}
 
-	scriptPath := filepath.Join(targetDir, scriptName)
+	scriptPath, err := s.pathValidator.SafeJoin(targetDir, scriptName)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if script exists
-	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
-		return nil // Script doesn't exist, not an error
-	} else if err != nil {
-		return models.NewFileSystemError(models.ErrorCodeFileSystemError, scriptPath, err)
+	info, err := s.InspectScript(targetDir, scriptName)
+	if err != nil || info == nil {
+		return nil, err // Nil when the script doesn't exist, not an error
+	}
+	if err := s.CheckPin(*info); err != nil {
+		return nil, err
 	}
 
 	// Make sure script is executable
 	if err := os.Chmod(scriptPath, 0755); err != nil {
-		return models.NewFileSystemError(models.ErrorCodePermissionDenied, scriptPath, err)
+		return nil, models.NewFileSystemError(models.ErrorCodePermissionDenied, scriptPath, err)
 	}
 
 	// Execute the script in the target directory
-	cmd := exec.Command("bash", scriptPath)
-	cmd.Dir = targetDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	output := &tailBuffer{limit: config.MaxScriptOutputBytes}
+	cmd := s.command(targetDir, scriptName)
+	cmd.Stdout = io.MultiWriter(os.Stdout, output)
+	cmd.Stderr = io.MultiWriter(os.Stderr, output)
 
-	if err := cmd.Run(); err != nil {
-		return models.NewAppError(
+	started := time.Now()
+	err = cmd.Run()
+	result := &models.ScriptResult{
+		ScriptInfo:      *info,
+		Duration:        time.Since(started),
+		Output:          string(output.data),
+		OutputTruncated: output.truncated,
+		Isolation:       s.Isolation(),
+	}
+
+	if err != nil {
+		result.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		return result, models.NewAppError(
 			models.ErrorCodeInstallationFailed,
-			fmt.Sprintf("Script execution failed: %s", scriptName),
+			fmt.Sprintf("Script execution failed: %s (exit code %d)", scriptName, result.ExitCode),
 			err,
 		)
 	}
 
-	return nil
+	return result, nil
+}
+
+// InspectScript returns the size and SHA-256 of a script in dir, or nil when
+// the script does not exist
+func (s *Service) InspectScript(dir, scriptName string) (*models.ScriptInfo, error) {
+	if dir == "" || scriptName == "" {
+		return nil, models.NewAppError(
+			models.ErrorCodeValidationFailed,
+			"Directory and script name cannot be empty",
+			nil,
+		)
+	}
+
+	scriptPath, err := s.pathValidator.SafeJoin(dir, scriptName)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(scriptPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, scriptPath, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, scriptPath, err)
+	}
+
+	return &models.ScriptInfo{
+		Name:   scriptName,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
 }
 
 // RemoveScript removes a script from the target directory
@@ -112,7 +229,10 @@ func (s *Service) RemoveScript(targetDir, scriptName string) error {
 		)
 	}
 
-	scriptPath := filepath.Join(targetDir, scriptName)
+	scriptPath, err := s.pathValidator.SafeJoin(targetDir, scriptName)
+	if err != nil {
+		return err
+	}
 
 	// Check if script exists
 	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
@@ -135,8 +255,11 @@ func (s *Service) ScriptExists(sourceDir, scriptName string) bool {
 		return false
 	}
 
-	scriptPath := filepath.Join(sourceDir, scriptName)
-	_, err := os.Stat(scriptPath)
+	scriptPath, err := s.pathValidator.SafeJoin(sourceDir, scriptName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(scriptPath)
 	return err == nil
 }
 
@@ -145,5 +268,9 @@ func (s *Service) GetScriptPath(targetDir, scriptName string) string {
 	if targetDir == "" || scriptName == "" {
 		return ""
 	}
-	return filepath.Join(targetDir, scriptName)
+	scriptPath, err := s.pathValidator.SafeJoin(targetDir, scriptName)
+	if err != nil {
+		return ""
+	}
+	return scriptPath
 }
@@ -3,7 +3,11 @@ package script
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 )
 
 func TestService_ScriptExists(t *testing.T) {
@@ -232,3 +236,154 @@ func TestService_GetScriptPath(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ExecuteScript(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantExitCode  int
+		wantErr       bool
+		wantOutput    string
+		wantTruncated bool
+	}{
+		{"success", "echo done\n", 0, false, "done\n", false},
+		{"failure", "echo broken >&2\nexit 3\n", 3, true, "broken\n", false},
+		{"long output", "head -c 10000 /dev/zero | tr '\\0' x\necho end\n", 0, false, "end\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(targetDir, "script.sh"), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write script: %v", err)
+			}
+
+			result, err := New().ExecuteScript(targetDir, "script.sh")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteScript() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result == nil {
+				t.Fatal("ExecuteScript() result = nil")
+			}
+			if result.ExitCode != tt.wantExitCode {
+				t.Errorf("ExitCode = %d, want %d", result.ExitCode, tt.wantExitCode)
+			}
+			if result.OutputTruncated != tt.wantTruncated {
+				t.Errorf("OutputTruncated = %v, want %v", result.OutputTruncated, tt.wantTruncated)
+			}
+			if !strings.HasSuffix(result.Output, tt.wantOutput) || len(result.Output) > config.MaxScriptOutputBytes {
+				t.Errorf("Output = %q (%d bytes), want it to end with %q", result.Output, len(result.Output), tt.wantOutput)
+			}
+			if result.Size != int64(len(tt.content)) || len(result.SHA256) != 64 {
+				t.Errorf("ScriptInfo = %+v, want the size and hash of the script", result.ScriptInfo)
+			}
+		})
+	}
+
+	result, err := New().ExecuteScript(t.TempDir(), "missing.sh")
+	if err != nil || result != nil {
+		t.Errorf("ExecuteScript() of a missing script = %v, %v, want nil, nil", result, err)
+	}
+}
+
+func TestService_CheckPin(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	info := models.ScriptInfo{Name: config.PostInstallScript, SHA256: hash}
+
+	tests := []struct {
+		name          string
+		pins          map[string]string
+		allowUnpinned bool
+		wantErr       bool
+	}{
+		{"nothing pinned", nil, false, false},
+		{"matching hash", map[string]string{config.PostInstallScript: hash}, false, false},
+		{"matching uppercase hash", map[string]string{config.PostInstallScript: strings.ToUpper(hash)}, false, false},
+		{"different hash", map[string]string{config.PostInstallScript: strings.Repeat("cd", 32)}, false, true},
+		{"not pinned", map[string]string{config.PreInstallScript: hash}, false, true},
+		{"different hash allowed", map[string]string{config.PostInstallScript: strings.Repeat("cd", 32)}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New()
+			service.SetPins(tt.pins, tt.allowUnpinned)
+			err := service.CheckPin(info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !models.IsErrorCode(err, models.ErrorCodeScriptHashMismatch) {
+				t.Errorf("CheckPin() error = %v, want %s", err, models.ErrorCodeScriptHashMismatch)
+			}
+		})
+	}
+
+	// A script that does not match is not run
+	targetDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(targetDir, config.PostInstallScript), []byte("touch ran\n"), 0644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	service := New()
+	service.SetPins(map[string]string{config.PostInstallScript: hash}, false)
+	if _, err := service.ExecuteScript(targetDir, config.PostInstallScript); err == nil {
+		t.Error("ExecuteScript() ran a script that does not match its pinned hash")
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "ran")); err == nil {
+		t.Error("The script ran")
+	}
+}
+
+func TestService_SetIsolation(t *testing.T) {
+	service := New()
+	if err := service.SetIsolation("vm"); err == nil {
+		t.Error("SetIsolation() accepted an unknown mode")
+	}
+	if err := service.SetIsolation(models.ScriptIsolationNone); err != nil || service.Isolation() != models.ScriptIsolationNone {
+		t.Errorf("SetIsolation(none) = %v, isolation %q", err, service.Isolation())
+	}
+
+	// Without Docker, scripts keep running directly
+	t.Setenv("PATH", t.TempDir())
+	err := service.SetIsolation(models.ScriptIsolationDocker)
+	if !models.IsErrorCode(err, models.ErrorCodeDockerUnavailable) {
+		t.Errorf("SetIsolation(docker) without Docker = %v, want %s", err, models.ErrorCodeDockerUnavailable)
+	}
+	if service.Isolation() != models.ScriptIsolationNone {
+		t.Errorf("Isolation() = %q, want %q", service.Isolation(), models.ScriptIsolationNone)
+	}
+}
+
+func TestDockerArgs(t *testing.T) {
+	args := strings.Join(dockerArgs("/work/project", config.PostInstallScript), " ")
+	for _, want := range []string{
+		"run --rm",
+		"--volume /work/project:" + config.ScriptContainerWorkdir,
+		"--workdir " + config.ScriptContainerWorkdir,
+		config.ScriptContainerImage + " bash " + config.ScriptContainerWorkdir + "/" + config.PostInstallScript,
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("dockerArgs() = %q, want it to contain %q", args, want)
+		}
+	}
+}
+
+func TestService_ScriptNameTraversal(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "project")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(projectDir), "outside.sh"), []byte("touch ran\n"), 0644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	service := New()
+	if _, err := service.ExecuteScript(projectDir, "../outside.sh"); !models.IsErrorCode(err, models.ErrorCodeInvalidPath) {
+		t.Errorf("ExecuteScript() error = %v, want %s", err, models.ErrorCodeInvalidPath)
+	}
+	if err := service.CopyScript(projectDir, t.TempDir(), "../outside.sh"); !models.IsErrorCode(err, models.ErrorCodeInvalidPath) {
+		t.Errorf("CopyScript() error = %v, want %s", err, models.ErrorCodeInvalidPath)
+	}
+	if service.ScriptExists(projectDir, "../outside.sh") {
+		t.Error("ScriptExists() found a script outside the directory")
+	}
+}
@@ -0,0 +1,169 @@
+package settings
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// SetHookPython sets the interpreter command written into strategic hook commands.
+// An empty command restores automatic detection.
+func (s *Service) SetHookPython(command string) {
+	s.hookPython = strings.TrimSpace(command)
+}
+
+// SetHookRunner sets the runner used for strategic hook commands (python, uv,
+// poetry or custom:<command>). An empty runner uses the Python interpreter.
+func (s *Service) SetHookRunner(runner string) error {
+	if err := models.ValidateHookRunner(runner); err != nil {
+		return err
+	}
+	s.hookRunner = runner
+	return nil
+}
+
+// hookRunnerCommand returns the command placed before strategic hook scripts
+func (s *Service) hookRunnerCommand(targetDir string) string {
+	python := s.hookPython
+	if python == "" && (s.hookRunner == "" || s.hookRunner == models.HookRunnerPython) {
+		python = DetectPython(targetDir)
+	}
+	return models.HookRunnerCommand(s.hookRunner, python)
+}
+
+// DetectPython returns the best command for running Python hooks: the hook
+// virtualenv created by --install-hook-deps, the active virtualenv, the project's
+// .venv, python3 on PATH, the Windows py launcher, and finally config.DefaultHookPython
+func DetectPython(targetDir string) string {
+	return detectPython(targetDir, runtime.GOOS, os.Getenv, exec.LookPath)
+}
+
+// detectPython implements DetectPython with injectable environment lookups
+func detectPython(targetDir, goos string, getenv func(string) string, lookPath func(string) (string, error)) string {
+	var venvs []string
+	if targetDir != "" {
+		venvs = append(venvs, HookVenvPath(targetDir))
+	}
+	venvs = append(venvs, getenv("VIRTUAL_ENV"))
+	if targetDir != "" {
+		venvs = append(venvs, filepath.Join(targetDir, ".venv"))
+	}
+
+	for _, venv := range venvs {
+		if venv == "" {
+			continue
+		}
+		if python := venvPython(venv, goos); python != "" {
+			return projectRelative(targetDir, python)
+		}
+	}
+
+	if _, err := lookPath("python3"); err == nil {
+		return "python3"
+	}
+
+	if goos == "windows" {
+		if _, err := lookPath("py"); err == nil {
+			return "py -3"
+		}
+		if _, err := lookPath("python"); err == nil {
+			return "python"
+		}
+	}
+
+	return config.DefaultHookPython
+}
+
+// HookVenvPath returns the location of the hook dependency virtualenv inside a project
+func HookVenvPath(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), config.HookVenvDir)
+}
+
+// HookVenvCommand returns the hook command for the hook virtualenv's interpreter,
+// whether or not the virtualenv has been created yet
+func HookVenvCommand(targetDir string) string {
+	return projectRelative(targetDir, venvInterpreters(HookVenvPath(targetDir), runtime.GOOS)[0])
+}
+
+// VenvPython returns the interpreter inside a virtualenv, or "" when there is none
+func VenvPython(venv string) string {
+	return venvPython(venv, runtime.GOOS)
+}
+
+// venvInterpreters returns the interpreter locations of a virtualenv, preferred first
+func venvInterpreters(venv, goos string) []string {
+	if goos == "windows" {
+		return []string{filepath.Join(venv, "Scripts", "python.exe")}
+	}
+	return []string{filepath.Join(venv, "bin", "python3"), filepath.Join(venv, "bin", "python")}
+}
+
+// venvPython returns the interpreter inside a virtualenv, or "" when there is none
+func venvPython(venv, goos string) string {
+	candidates := venvInterpreters(venv, goos)
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// projectRelative expresses paths inside the project through $CLAUDE_PROJECT_DIR so
+// settings.json stays valid when the project is moved or cloned elsewhere
+func projectRelative(targetDir, path string) string {
+	if targetDir != "" {
+		if relPath, err := filepath.Rel(targetDir, path); err == nil && !strings.HasPrefix(relPath, "..") {
+			return "$CLAUDE_PROJECT_DIR/" + filepath.ToSlash(relPath)
+		}
+	}
+	return quoteCommand(path)
+}
+
+// quoteCommand quotes an interpreter path that contains spaces
+func quoteCommand(path string) string {
+	if strings.ContainsAny(path, " \t") {
+		return `"` + path + `"`
+	}
+	return path
+}
+
+// CommandArgs splits a hook command into program and arguments, expanding
+// $CLAUDE_PROJECT_DIR to the project directory and honoring double quotes
+func CommandArgs(targetDir, command string) []string {
+	expanded := strings.ReplaceAll(command, "$CLAUDE_PROJECT_DIR", targetDir)
+
+	var args []string
+	for i, part := range strings.Split(expanded, `"`) {
+		if i%2 == 1 {
+			args = append(args, part) // Quoted section
+			continue
+		}
+		args = append(args, strings.Fields(part)...)
+	}
+	return args
+}
+
+// CommandAvailable reports whether the program of a hook runner command can be
+// found, expanding $CLAUDE_PROJECT_DIR to the project directory
+func CommandAvailable(targetDir, command string) bool {
+	args := CommandArgs(targetDir, command)
+	if len(args) == 0 || args[0] == "" {
+		return false
+	}
+
+	program := args[0]
+	if strings.ContainsAny(program, `/\`) {
+		info, err := os.Stat(program)
+		return err == nil && !info.IsDir()
+	}
+
+	_, err := exec.LookPath(program)
+	return err == nil
+}
@@ -0,0 +1,212 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestDetectPython(t *testing.T) {
+	activeVenv := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(activeVenv, "bin"), 0755); err != nil {
+		t.Fatalf("Failed to create venv: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(activeVenv, "bin", "python3"), []byte(""), 0755); err != nil {
+		t.Fatalf("Failed to create venv python: %v", err)
+	}
+
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".venv", "bin"), 0755); err != nil {
+		t.Fatalf("Failed to create project venv: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ".venv", "bin", "python"), []byte(""), 0755); err != nil {
+		t.Fatalf("Failed to create project venv python: %v", err)
+	}
+
+	onPath := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, n := range names {
+				if n == name {
+					return "/found/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	tests := []struct {
+		name      string
+		targetDir string
+		goos      string
+		env       map[string]string
+		path      []string
+		want      string
+	}{
+		{"active virtualenv", "", "linux", map[string]string{"VIRTUAL_ENV": activeVenv}, []string{"python3"}, filepath.Join(activeVenv, "bin", "python3")},
+		{"project venv", projectDir, "linux", nil, []string{"python3"}, "$CLAUDE_PROJECT_DIR/.venv/bin/python"},
+		{"python3 on PATH", t.TempDir(), "darwin", nil, []string{"python3"}, "python3"},
+		{"py launcher on windows", "", "windows", nil, []string{"py", "python"}, "py -3"},
+		{"python on windows", "", "windows", nil, []string{"python"}, "python"},
+		{"py launcher ignored elsewhere", "", "linux", nil, []string{"py"}, config.DefaultHookPython},
+		{"nothing found", "", "linux", nil, nil, config.DefaultHookPython},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectPython(tt.targetDir, tt.goos, env(tt.env), onPath(tt.path...))
+			if got != tt.want {
+				t.Errorf("detectPython() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandAvailable(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".venv", "bin"), 0755); err != nil {
+		t.Fatalf("Failed to create project venv: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ".venv", "bin", "python"), []byte(""), 0755); err != nil {
+		t.Fatalf("Failed to create project venv python: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		want    bool
+	}{
+		{"project venv", "$CLAUDE_PROJECT_DIR/.venv/bin/python", true},
+		{"quoted path", `"` + filepath.Join(projectDir, ".venv", "bin", "python") + `"`, true},
+		{"missing path", "/nonexistent/bin/python3", false},
+		{"missing program", "scb-missing-python -3", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommandAvailable(projectDir, tt.command); got != tt.want {
+				t.Errorf("CommandAvailable(%q) = %v, want %v", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ProcessSettings_HookPython(t *testing.T) {
+	targetDir := t.TempDir()
+	templatePath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.SettingsTemplateFile)
+	if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
+		t.Fatalf("Failed to create template directory: %v", err)
+	}
+
+	template := models.ClaudeSettings{
+		Hooks: &models.HooksSection{
+			Stop: []models.HookMatcher{{
+				Hooks: []models.HookEntry{
+					{Type: "command", Command: "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/stop-session-notify.py"},
+					{Type: "command", Command: "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/user-hook.py"},
+				},
+			}},
+		},
+	}
+	data, err := json.Marshal(template)
+	if err != nil {
+		t.Fatalf("Failed to encode template: %v", err)
+	}
+	if err := os.WriteFile(templatePath, data, 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	service := New()
+	service.SetHookPython("py -3")
+	if err := service.ProcessSettings(targetDir); err != nil {
+		t.Fatalf("ProcessSettings() error = %v", err)
+	}
+
+	written, err := os.ReadFile(filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile))
+	if err != nil {
+		t.Fatalf("Failed to read settings: %v", err)
+	}
+
+	var settings models.ClaudeSettings
+	if err := json.Unmarshal(written, &settings); err != nil {
+		t.Fatalf("Failed to parse settings: %v", err)
+	}
+
+	for _, hook := range settings.Hooks.Stop[0].Hooks {
+		if models.IsStrategicHook(hook.Command) {
+			want := "py -3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/stop-session-notify.py"
+			if hook.Command != want {
+				t.Errorf("strategic hook command = %q, want %q", hook.Command, want)
+			}
+		} else if !strings.HasPrefix(hook.Command, "/usr/bin/python3 ") {
+			t.Errorf("user hook command was rewritten to %q", hook.Command)
+		}
+	}
+}
+
+func TestService_HookRunner(t *testing.T) {
+	targetDir := t.TempDir()
+	templatePath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.SettingsTemplateFile)
+	if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
+		t.Fatalf("Failed to create template directory: %v", err)
+	}
+
+	template := `{"hooks": {"Stop": [{"matcher": "", "hooks": [{"type": "command", "command": "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/stop-session-notify.py"}]}]}}`
+	if err := os.WriteFile(templatePath, []byte(template), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	service := New()
+	if err := service.SetHookRunner("pipenv"); err == nil {
+		t.Errorf("SetHookRunner(pipenv) error = nil, want error")
+	}
+	if err := service.SetHookRunner(models.HookRunnerUV); err != nil {
+		t.Fatalf("SetHookRunner() error = %v", err)
+	}
+	if err := service.ProcessSettings(targetDir); err != nil {
+		t.Fatalf("ProcessSettings() error = %v", err)
+	}
+
+	settingsPath := filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile)
+	settings, err := service.loadExistingSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	want := "uv run $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/stop-session-notify.py"
+	if got := settings.Hooks.Stop[0].Hooks[0].Command; got != want {
+		t.Errorf("strategic hook command = %q, want %q", got, want)
+	}
+
+	// Updating with another runner replaces the command instead of adding a second hook
+	if err := service.SetHookRunner("custom:pipenv run python"); err != nil {
+		t.Fatalf("SetHookRunner() error = %v", err)
+	}
+	if err := service.ProcessSettings(targetDir); err != nil {
+		t.Fatalf("ProcessSettings() error = %v", err)
+	}
+	settings, err = service.loadExistingSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if hooks := settings.Hooks.Stop[0].Hooks; len(hooks) != 1 || !strings.HasPrefix(hooks[0].Command, "pipenv run python ") {
+		t.Errorf("strategic hooks after runner change = %v, want a single pipenv command", hooks)
+	}
+
+	// Cleaning recognizes the runner form
+	if err := service.CleanSettings(targetDir); err != nil {
+		t.Fatalf("CleanSettings() error = %v", err)
+	}
+	if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+		t.Errorf("CleanSettings() left settings.json behind, error = %v", err)
+	}
+}
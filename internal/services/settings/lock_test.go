@@ -0,0 +1,70 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestService_modifySettings_ConcurrentModification(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), config.ClaudeDir, config.ClaudeSettingsFile)
+	service := New()
+
+	// The first merge races with another writer that adds a permission
+	calls := 0
+	err := service.modifySettings(settingsPath, func(existing *models.ClaudeSettings) *models.ClaudeSettings {
+		calls++
+		if calls == 1 {
+			if err := os.WriteFile(settingsPath, []byte(`{"permissions": {"allow": ["Bash(ls:*)"]}}`), 0644); err != nil {
+				t.Fatalf("Failed to simulate concurrent write: %v", err)
+			}
+		}
+
+		result := &models.ClaudeSettings{Hooks: &models.HooksSection{}}
+		if existing != nil {
+			result.Permissions = existing.Permissions
+		}
+		return result
+	})
+	if err != nil {
+		t.Fatalf("modifySettings() error = %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("modifySettings() applied the change %d times, want 2", calls)
+	}
+
+	settings, err := service.loadExistingSettings(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if settings.Permissions == nil || len(settings.Permissions.Allow) != 1 {
+		t.Errorf("modifySettings() dropped the concurrent change: %+v", settings.Permissions)
+	}
+
+	if _, err := os.Stat(settingsPath + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("modifySettings() left the lock file behind")
+	}
+}
+
+func TestService_modifySettings_KeepsChanging(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), config.ClaudeSettingsFile)
+	service := New()
+
+	calls := 0
+	err := service.modifySettings(settingsPath, func(existing *models.ClaudeSettings) *models.ClaudeSettings {
+		calls++
+		content := fmt.Sprintf(`{"permissions": {"allow": ["Bash(echo %d)"]}}`, calls)
+		if err := os.WriteFile(settingsPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to simulate concurrent write: %v", err)
+		}
+		return &models.ClaudeSettings{}
+	})
+	if !models.IsErrorCode(err, models.ErrorCodeConcurrentModification) {
+		t.Errorf("modifySettings() error = %v, want %s", err, models.ErrorCodeConcurrentModification)
+	}
+}
@@ -0,0 +1,105 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestService_PreviewSettings(t *testing.T) {
+	targetDir := t.TempDir()
+	t.Setenv(config.BackupsDirEnvVar, "")
+
+	templatePath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.SettingsTemplateFile)
+	settingsPath := filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile)
+	for _, dir := range []string{filepath.Dir(templatePath), filepath.Dir(settingsPath)} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeJSON := func(path string, settings models.ClaudeSettings) {
+		data, err := json.Marshal(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeJSON(templatePath, models.ClaudeSettings{
+		Hooks: &models.HooksSection{
+			PreToolUse: []models.HookMatcher{
+				{Matcher: "Write", Hooks: []models.HookEntry{{Type: "command", Command: "python3 .claude/hooks/pre-tool-use.py"}}},
+				{Matcher: "Bash", Hooks: []models.HookEntry{{Type: "command", Command: "python3 .claude/hooks/pre-bash.py"}}},
+			},
+		},
+	})
+	writeJSON(settingsPath, models.ClaudeSettings{
+		Hooks: &models.HooksSection{
+			PreToolUse: []models.HookMatcher{
+				{Matcher: "Edit", Hooks: []models.HookEntry{{Type: "command", Command: "./lint.sh"}}},
+			},
+		},
+	})
+	original, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := New()
+	service.SetHookPython("python3") // Same runner for ProcessSettings below
+	current, merged, err := service.PreviewSettings(targetDir, templatePath, "python3")
+	if err != nil {
+		t.Fatalf("PreviewSettings() error = %v", err)
+	}
+
+	if !bytes.Equal(current, original) {
+		t.Errorf("PreviewSettings() current = %s, want %s", current, original)
+	}
+
+	// Previewing does not touch settings.json
+	if data, _ := os.ReadFile(settingsPath); !bytes.Equal(data, original) {
+		t.Errorf("PreviewSettings() modified settings.json: %s", data)
+	}
+
+	// The preview is exactly what an installation writes, matchers in a stable order
+	for i := 0; i < 5; i++ {
+		_, again, err := service.PreviewSettings(targetDir, templatePath, "python3")
+		if err != nil {
+			t.Fatalf("PreviewSettings() error = %v", err)
+		}
+		if !bytes.Equal(again, merged) {
+			t.Fatalf("PreviewSettings() is not deterministic:\n%s\n%s", merged, again)
+		}
+	}
+
+	if err := service.ProcessSettings(targetDir); err != nil {
+		t.Fatalf("ProcessSettings() error = %v", err)
+	}
+	written, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(written, merged) {
+		t.Errorf("ProcessSettings() wrote\n%s\nwant preview\n%s", written, merged)
+	}
+}
+
+func TestService_PreviewSettings_NoTemplate(t *testing.T) {
+	targetDir := t.TempDir()
+
+	current, merged, err := New().PreviewSettings(targetDir, filepath.Join(targetDir, "missing.json"), "python3")
+	if err != nil {
+		t.Fatalf("PreviewSettings() error = %v", err)
+	}
+	if current != nil || merged != nil {
+		t.Errorf("PreviewSettings() = %s, %s, want no settings", current, merged)
+	}
+}
@@ -1,19 +1,27 @@
 package settings
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
+// maxSettingsAttempts bounds how often an update is redone when settings.json
+// keeps changing underneath it
+const maxSettingsAttempts = 3
+
 // Service provides settings management functionality
-type Service struct{}
+type Service struct {
+	hookPython string // Interpreter for strategic hooks, detected when empty
+	hookRunner string // Runner for strategic hooks, see models.HookRunnerCommand
+}
 
 // New creates a new settings service instance
 func New() *Service {
@@ -22,7 +30,7 @@ func New() *Service {
 
 // ProcessSettings is the main entry point for managing .claude/settings.json
 func (s *Service) ProcessSettings(targetDir string) error {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
 	settingsPath := filepath.Join(claudeDir, config.ClaudeSettingsFile)
 	templatePath := filepath.Join(strategicDir, config.SettingsTemplateFile)
@@ -39,42 +47,91 @@ func (s *Service) ProcessSettings(targetDir string) error {
 		return fmt.Errorf("failed to load settings template: %w", err)
 	}
 
-	// Handle existing settings
-	var existingSettings *models.ClaudeSettings
+	// Backup existing settings
 	if _, err := os.Stat(settingsPath); err == nil {
-		// Backup existing settings
-		if err := s.backupExistingSettings(settingsPath); err != nil {
+		if err := s.backupExistingSettings(targetDir, settingsPath); err != nil {
 			return fmt.Errorf("failed to backup existing settings: %w", err)
 		}
-
-		// Load existing settings
-		existingSettings, err = s.loadExistingSettings(settingsPath)
-		if err != nil {
-			return fmt.Errorf("failed to load existing settings: %w", err)
-		}
 	}
 
-	// Merge settings
-	mergedSettings := s.mergeSettings(templateSettings, existingSettings)
-
-	// Update hook paths to point to strategic directory
-	s.updateStrategicHookPaths(mergedSettings)
+	runner := s.hookRunnerCommand(targetDir)
 
-	// Write merged settings
-	if err := s.writeSettings(settingsPath, mergedSettings); err != nil {
+	// Merge under lock, redoing the merge if settings.json changes meanwhile
+	err = s.modifySettings(settingsPath, func(existingSettings *models.ClaudeSettings) *models.ClaudeSettings {
+		return s.applyTemplate(templateSettings, existingSettings, runner)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to write settings: %w", err)
 	}
 
 	return nil
 }
 
-// backupExistingSettings creates a timestamped backup of existing settings
-func (s *Service) backupExistingSettings(settingsPath string) error {
-	timestamp := time.Now().Format("20060102-150405")
-	backupPath := filepath.Join(
-		filepath.Dir(settingsPath),
-		config.SettingsBackupPrefix+timestamp+".json",
-	)
+// PreviewSettings returns the current settings.json of a project and the content it
+// would have after merging the settings template at templatePath, with strategic
+// hooks run by runner. current is nil when settings.json does not exist; merged
+// equals current when there is no template.
+func (s *Service) PreviewSettings(targetDir, templatePath, runner string) (current, merged []byte, err error) {
+	settingsPath := filepath.Join(targetDir, config.ClaudeDir, config.ClaudeSettingsFile)
+
+	current, err = readIfExists(settingsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
+		return current, current, nil
+	}
+
+	templateSettings, err := s.loadTemplate(templatePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load settings template: %w", err)
+	}
+
+	var existing *models.ClaudeSettings
+	if current != nil {
+		existing = &models.ClaudeSettings{}
+		if err := json.Unmarshal(current, existing); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", settingsPath, err)
+		}
+	}
+
+	merged, err = marshalSettings(s.applyTemplate(templateSettings, existing, runner))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return current, merged, nil
+}
+
+// applyTemplate merges template settings into existing settings and points strategic
+// hooks at the symlinked hooks directory
+func (s *Service) applyTemplate(templateSettings, existing *models.ClaudeSettings, runner string) *models.ClaudeSettings {
+	// Point the template's hooks at the hooks symlink of this installation before
+	// merging, so hooks of other named installations are not mistaken for them
+	s.updateStrategicHookPaths(templateSettings, runner, true)
+	mergedSettings := s.mergeSettings(templateSettings, existing)
+
+	// Apply the runner to hooks of this installation that were already there
+	s.updateStrategicHookPaths(mergedSettings, runner, false)
+	return mergedSettings
+}
+
+// marshalSettings renders settings the way settings.json is written
+func marshalSettings(settings *models.ClaudeSettings) ([]byte, error) {
+	// Pretty print JSON
+	return json.MarshalIndent(settings, "", "  ")
+}
+
+// backupExistingSettings creates a timestamped backup of existing settings in the
+// project's backups directory
+func (s *Service) backupExistingSettings(targetDir, settingsPath string) error {
+	backupsRoot := config.GetBackupsRoot(targetDir)
+	if err := os.MkdirAll(backupsRoot, config.DirPermissions); err != nil {
+		return err
+	}
+
+	backupPath := filepath.Join(backupsRoot, config.UniqueBackupName(backupsRoot, config.SettingsBackupPrefix, ".json"))
 
 	// Read existing file
 	data, err := os.ReadFile(settingsPath)
@@ -161,15 +218,22 @@ func (s *Service) mergeHooks(templateHooks *models.HooksSection, existing *model
 // mergeHookType merges hooks for a specific hook type (PreToolUse, PostToolUse, etc.)
 func (s *Service) mergeHookType(templateMatchers []models.HookMatcher, existingMatchers []models.HookMatcher) []models.HookMatcher {
 	matcherMap := make(map[string][]models.HookEntry)
+	var order []string // Matchers in first-seen order, so merges are deterministic
 
 	// Add existing hooks first to preserve user customizations
 	for _, matcher := range existingMatchers {
+		if _, seen := matcherMap[matcher.Matcher]; !seen {
+			order = append(order, matcher.Matcher)
+		}
 		matcherMap[matcher.Matcher] = append(matcherMap[matcher.Matcher], matcher.Hooks...)
 	}
 
 	// Add template hooks, avoiding duplicates
 	for _, templateMatcher := range templateMatchers {
-		existing := matcherMap[templateMatcher.Matcher]
+		existing, seen := matcherMap[templateMatcher.Matcher]
+		if !seen {
+			order = append(order, templateMatcher.Matcher)
+		}
 
 		// Add template hooks that don't already exist
 		for _, templateHook := range templateMatcher.Hooks {
@@ -182,8 +246,8 @@ func (s *Service) mergeHookType(templateMatchers []models.HookMatcher, existingM
 
 	// Convert back to slice format
 	var result []models.HookMatcher
-	for matcher, hooks := range matcherMap {
-		if len(hooks) > 0 {
+	for _, matcher := range order {
+		if hooks := matcherMap[matcher]; len(hooks) > 0 {
 			result = append(result, models.HookMatcher{
 				Matcher: matcher,
 				Hooks:   hooks,
@@ -210,62 +274,121 @@ func (s *Service) normalizeHookCommand(command string) string {
 	// Remove common variations and focus on the script name
 	command = strings.TrimSpace(command)
 
-	// Extract script name for strategic hooks
-	if models.IsStrategicHook(command) {
-		parts := strings.Split(command, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1] // Return just the script name
-		}
+	// Strategic hooks are identified by script name and the installation they
+	// belong to, whatever runner invokes them
+	if scriptName, ok := models.StrategicHookScript(command); ok {
+		return models.StrategicHookLink(command) + "/" + scriptName
 	}
 
 	return command
 }
 
-// updateStrategicHookPaths updates paths for strategic hooks to use the symlinked directory
-func (s *Service) updateStrategicHookPaths(settings *models.ClaudeSettings) {
-	if settings.Hooks == nil {
+// updateStrategicHookPaths updates paths for strategic hooks to use the symlinked
+// directory of this installation. Unless allLinks is set, only hooks that already
+// belong to this installation are updated.
+func (s *Service) updateStrategicHookPaths(settings *models.ClaudeSettings, runner string, allLinks bool) {
+	if settings == nil || settings.Hooks == nil {
 		return
 	}
 
-	s.updateHookTypePaths(settings.Hooks.PreToolUse)
-	s.updateHookTypePaths(settings.Hooks.PostToolUse)
-	s.updateHookTypePaths(settings.Hooks.Stop)
-	s.updateHookTypePaths(settings.Hooks.PreCompact)
-	s.updateHookTypePaths(settings.Hooks.Notification)
+	s.updateHookTypePaths(settings.Hooks.PreToolUse, runner, allLinks)
+	s.updateHookTypePaths(settings.Hooks.PostToolUse, runner, allLinks)
+	s.updateHookTypePaths(settings.Hooks.Stop, runner, allLinks)
+	s.updateHookTypePaths(settings.Hooks.PreCompact, runner, allLinks)
+	s.updateHookTypePaths(settings.Hooks.Notification, runner, allLinks)
 }
 
-// updateHookTypePaths updates paths for a specific hook type
-func (s *Service) updateHookTypePaths(matchers []models.HookMatcher) {
+// updateHookTypePaths updates paths and runner command for a specific hook type
+func (s *Service) updateHookTypePaths(matchers []models.HookMatcher, runner string, allLinks bool) {
+	link := config.StrategicLinkName()
 	for i := range matchers {
 		for j := range matchers[i].Hooks {
 			hook := &matchers[i].Hooks[j]
-			if models.IsStrategicHook(hook.Command) {
-				// Extract script name
-				parts := strings.Split(hook.Command, "/")
-				scriptName := parts[len(parts)-1]
-
+			if !allLinks && models.StrategicHookLink(hook.Command) != link {
+				continue
+			}
+			if scriptName, ok := models.StrategicHookScript(hook.Command); ok {
 				// Update to use symlinked strategic directory
-				hook.Command = fmt.Sprintf("/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/%s", scriptName)
+				hook.Command = fmt.Sprintf("%s $CLAUDE_PROJECT_DIR/.claude/hooks/%s/%s", runner, link, scriptName)
 			}
 		}
 	}
 }
 
-// writeSettings writes the merged settings to the settings file
-func (s *Service) writeSettings(settingsPath string, settings *models.ClaudeSettings) error {
-	// Pretty print JSON
-	data, err := json.MarshalIndent(settings, "", "  ")
-	if err != nil {
+// modifySettings applies change to settings.json while holding an advisory lock.
+// Claude Code does not take the lock, so the file is read again before it is
+// atomically replaced; if it changed in the meantime, change is applied to the new
+// content. A nil result removes the file.
+func (s *Service) modifySettings(settingsPath string, change func(existing *models.ClaudeSettings) *models.ClaudeSettings) error {
+	// Ensure parent directory exists
+	if err := os.MkdirAll(filepath.Dir(settingsPath), config.DirPermissions); err != nil {
 		return err
 	}
 
-	// Ensure parent directory exists
-	if err := os.MkdirAll(filepath.Dir(settingsPath), config.DirPermissions); err != nil {
+	lock, err := utils.AcquireFileLock(settingsPath, config.SettingsLockTimeout)
+	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = lock.Release() // Stale locks expire on their own
+	}()
+
+	for attempt := 0; attempt < maxSettingsAttempts; attempt++ {
+		original, err := readIfExists(settingsPath)
+		if err != nil {
+			return err
+		}
+
+		var existing *models.ClaudeSettings
+		if original != nil {
+			existing = &models.ClaudeSettings{}
+			if err := json.Unmarshal(original, existing); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", settingsPath, err)
+			}
+		}
 
-	// Write settings file
-	return os.WriteFile(settingsPath, data, config.FilePermissions)
+		result := change(existing)
+
+		var data []byte
+		if result != nil {
+			if data, err = marshalSettings(result); err != nil {
+				return err
+			}
+		}
+
+		// Start over if another process wrote the file while we merged
+		current, err := readIfExists(settingsPath)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(original, current) || (original == nil) != (current == nil) {
+			continue
+		}
+
+		if result == nil {
+			if err := os.Remove(settingsPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+
+		return utils.WriteFileAtomic(settingsPath, data, config.FilePermissions)
+	}
+
+	return models.NewAppError(
+		models.ErrorCodeConcurrentModification,
+		fmt.Sprintf("%s changed %d times while it was being updated", settingsPath, maxSettingsAttempts),
+		nil,
+	)
+}
+
+// readIfExists returns the content of a file, or nil when it does not exist
+func readIfExists(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
 }
 
 // CleanSettings removes strategic hooks from settings.json while preserving user customizations
@@ -279,29 +402,22 @@ func (s *Service) CleanSettings(targetDir string) error {
 	}
 
 	// Backup existing settings
-	if err := s.backupExistingSettings(settingsPath); err != nil {
+	if err := s.backupExistingSettings(targetDir, settingsPath); err != nil {
 		return fmt.Errorf("failed to backup settings: %w", err)
 	}
 
-	// Load current settings
-	currentSettings, err := s.loadExistingSettings(settingsPath)
-	if err != nil {
-		return fmt.Errorf("failed to load settings: %w", err)
-	}
-
-	// Remove strategic hooks
-	cleanedSettings := s.removeStrategicHooks(currentSettings)
-
-	// If settings are now empty, remove the file
-	if s.isEmptySettings(cleanedSettings) {
-		return os.Remove(settingsPath)
-	}
-
-	// Write cleaned settings
-	return s.writeSettings(settingsPath, cleanedSettings)
+	// Remove strategic hooks under lock; settings left empty remove the file
+	return s.modifySettings(settingsPath, func(currentSettings *models.ClaudeSettings) *models.ClaudeSettings {
+		cleanedSettings := s.removeStrategicHooks(currentSettings)
+		if s.isEmptySettings(cleanedSettings) {
+			return nil
+		}
+		return cleanedSettings
+	})
 }
 
-// removeStrategicHooks removes all strategic hooks from settings while preserving user content
+// removeStrategicHooks removes the strategic hooks of this installation from
+// settings while preserving user content and other named installations
 func (s *Service) removeStrategicHooks(settings *models.ClaudeSettings) *models.ClaudeSettings {
 	if settings == nil {
 		return nil
@@ -325,7 +441,7 @@ func (s *Service) removeStrategicHooks(settings *models.ClaudeSettings) *models.
 	return result
 }
 
-// filterNonStrategicHooks returns only non-strategic hooks from a list of hook matchers
+// filterNonStrategicHooks returns the hooks that are not strategic hooks of this installation
 func (s *Service) filterNonStrategicHooks(matchers []models.HookMatcher) []models.HookMatcher {
 	if matchers == nil {
 		return nil
@@ -337,7 +453,7 @@ func (s *Service) filterNonStrategicHooks(matchers []models.HookMatcher) []model
 		var nonStrategicHooks []models.HookEntry
 
 		for _, hook := range matcher.Hooks {
-			if !models.IsStrategicHook(hook.Command) {
+			if models.StrategicHookLink(hook.Command) != config.StrategicLinkName() {
 				nonStrategicHooks = append(nonStrategicHooks, hook)
 			}
 		}
@@ -143,9 +143,9 @@ func TestService_ProcessSettings(t *testing.T) {
 
 			// Check if backup was created
 			if tt.expectBackup {
-				files, err := os.ReadDir(claudeDir)
+				files, err := os.ReadDir(filepath.Join(tempDir, config.BackupsDir))
 				if err != nil {
-					t.Fatalf("Failed to read claude directory: %v", err)
+					t.Fatalf("Failed to read backups directory: %v", err)
 				}
 
 				hasBackup := false
@@ -330,6 +330,26 @@ func TestModels_IsStrategicHook(t *testing.T) {
 			command:  "/usr/bin/python3 /some/other/path/block-skip-hooks.py",
 			expected: true,
 		},
+		{
+			name:     "uv runner",
+			command:  "uv run $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/block-skip-hooks.py",
+			expected: true,
+		},
+		{
+			name:     "custom runner with quoted path and arguments",
+			command:  `pipenv run python "$CLAUDE_PROJECT_DIR/.claude/hooks/strategic/stop-session-notify.py" --quiet`,
+			expected: true,
+		},
+		{
+			name:     "windows path",
+			command:  `py -3 C:\project\.claude\hooks\strategic\precompact-notify.py`,
+			expected: true,
+		},
+		{
+			name:     "script name as suffix of another script",
+			command:  "uv run $CLAUDE_PROJECT_DIR/.claude/hooks/my-notification-hook.py",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -460,9 +480,9 @@ func TestService_CleanSettings(t *testing.T) {
 
 			// Check if backup was created
 			if tt.expectBackup {
-				files, err := os.ReadDir(claudeDir)
+				files, err := os.ReadDir(filepath.Join(tempDir, config.BackupsDir))
 				if err != nil {
-					t.Fatalf("Failed to read claude directory: %v", err)
+					t.Fatalf("Failed to read backups directory: %v", err)
 				}
 
 				hasBackup := false
@@ -699,3 +719,55 @@ func checkStrategicHookPaths(t *testing.T, hooks *models.HooksSection) {
 		checkHookTypePaths(hooks.Notification, "Notification")
 	}
 }
+
+func TestService_CleanSettings_Instance(t *testing.T) {
+	previous := config.Current()
+	if err := config.SetCurrent(config.DefaultConfig().WithInstance("research")); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	t.Cleanup(func() { _ = config.SetCurrent(previous) })
+
+	tempDir := t.TempDir()
+	claudeDir := filepath.Join(tempDir, config.ClaudeDir)
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatalf("Failed to create claude dir: %v", err)
+	}
+	const defaultHook = "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/block-skip-hooks.py"
+	settings := &models.ClaudeSettings{
+		Hooks: &models.HooksSection{
+			PreToolUse: []models.HookMatcher{
+				{
+					Matcher: "Bash",
+					Hooks: []models.HookEntry{
+						{Type: "command", Command: defaultHook},
+						{Type: "command", Command: "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic-research/block-skip-hooks.py"},
+					},
+				},
+			},
+		},
+	}
+	settingsPath := filepath.Join(claudeDir, config.ClaudeSettingsFile)
+	settingsData, _ := json.MarshalIndent(settings, "", "  ")
+	if err := os.WriteFile(settingsPath, settingsData, 0644); err != nil {
+		t.Fatalf("Failed to write existing settings: %v", err)
+	}
+
+	if err := New().CleanSettings(tempDir); err != nil {
+		t.Fatalf("CleanSettings() error = %v", err)
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to read cleaned settings: %v", err)
+	}
+	var result models.ClaudeSettings
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal cleaned settings: %v", err)
+	}
+	if result.Hooks == nil || len(result.Hooks.PreToolUse) != 1 || len(result.Hooks.PreToolUse[0].Hooks) != 1 {
+		t.Fatalf("CleanSettings() left %+v, want only the default installation's hook", result.Hooks)
+	}
+	if got := result.Hooks.PreToolUse[0].Hooks[0].Command; got != defaultHook {
+		t.Errorf("remaining hook = %q, want %q", got, defaultHook)
+	}
+}
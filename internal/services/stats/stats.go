@@ -0,0 +1,167 @@
+package stats
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/backup"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+)
+
+// pythonCacheDir holds compiled hook bytecode, which is not counted as hooks
+const pythonCacheDir = "__pycache__"
+
+// DirectoryStats summarizes the documents in one user directory
+type DirectoryStats struct {
+	Name      string `json:"name"`
+	Documents int    `json:"documents"`
+	Size      int64  `json:"size_bytes"`
+}
+
+// Stats summarizes how an installation is used
+type Stats struct {
+	TargetDir   string `json:"target_dir"`
+	Template    string `json:"template,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	LastUpdated string `json:"last_updated,omitempty"` // When the framework was last installed or updated
+
+	UserDirectories []DirectoryStats `json:"user_directories"`
+	Documents       int              `json:"documents"`
+	UserContentSize int64            `json:"user_content_size_bytes"`
+
+	Agents   int `json:"agents"`
+	Commands int `json:"commands"`
+	Hooks    int `json:"hooks"`
+
+	Backups    int   `json:"backups"`
+	BackupSize int64 `json:"backup_size_bytes"`
+}
+
+// Service collects usage statistics for an installation
+type Service struct {
+	statusService *status.Service
+	backupService *backup.Service
+}
+
+// New creates a new stats service instance
+func New() *Service {
+	return &Service{
+		statusService: status.NewService(),
+		backupService: backup.New(),
+	}
+}
+
+// Collect gathers the statistics of the installation in targetDir
+func (s *Service) Collect(targetDir string) (*Stats, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	stats := &Stats{TargetDir: targetDir}
+
+	statusInfo, err := s.statusService.CheckInstallation(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	if info := statusInfo.InstalledTemplate; info != nil {
+		stats.Template = info.Template.ID
+		stats.Commit = info.InstalledCommit
+		stats.LastUpdated = info.InstalledAt
+	}
+
+	for _, dir := range config.GetUserPreservedDirectories() {
+		dirStats := DirectoryStats{Name: dir}
+		err := walkFiles(filepath.Join(strategicDir, dir), isDocument, func(info fs.FileInfo) {
+			dirStats.Documents++
+			dirStats.Size += info.Size()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		stats.UserDirectories = append(stats.UserDirectories, dirStats)
+		stats.Documents += dirStats.Documents
+		stats.UserContentSize += dirStats.Size
+	}
+
+	coreDir := filepath.Join(strategicDir, config.CoreDir)
+	counts := []struct {
+		dir   string
+		count *int
+	}{
+		{config.AgentsDir, &stats.Agents},
+		{config.CommandsDir, &stats.Commands},
+		{config.HooksDir, &stats.Hooks},
+	}
+	for _, c := range counts {
+		if err := walkFiles(filepath.Join(coreDir, c.dir), isFrameworkFile, func(fs.FileInfo) { *c.count++ }); err != nil {
+			return nil, err
+		}
+	}
+
+	backups, err := s.backupService.List(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range backups {
+		stats.Backups++
+		stats.BackupSize += entry.Size
+	}
+
+	return stats, nil
+}
+
+// isDocument reports whether a user directory entry is a document rather than a
+// placeholder or guide shipped by the framework
+func isDocument(name string) bool {
+	return name != config.ClaudeConfigFile && !strings.HasPrefix(name, ".")
+}
+
+// isFrameworkFile reports whether a core entry counts as an agent, command or hook
+func isFrameworkFile(name string) bool {
+	return !strings.HasPrefix(name, ".")
+}
+
+// walkFiles calls visit for the regular files below root whose names pass keep.
+// Hidden and Python cache directories are skipped; a missing root has no files.
+func walkFiles(root string, keep func(string) bool, visit func(fs.FileInfo)) error {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == pythonCacheDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !keep(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		visit(info)
+		return nil
+	})
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+	}
+	return nil
+}
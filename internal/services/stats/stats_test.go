@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// writeFile writes content to a path relative to targetDir
+func writeFile(t *testing.T, targetDir, relPath, content string) {
+	t.Helper()
+
+	fullPath := filepath.Join(targetDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Collect(t *testing.T) {
+	targetDir := t.TempDir()
+	files := map[string]string{
+		"plan/one.md":                  "12345",
+		"plan/two.md":                  "123",
+		"plan/CLAUDE.md":               "framework guide",
+		"research/2025/deep.md":        "1234567890",
+		"archives/.gitkeep":            "",
+		"core/agents/a.md":             "agent",
+		"core/agents/b.md":             "agent",
+		"core/commands/c.md":           "command",
+		"core/hooks/hook.py":           "hook",
+		"core/hooks/__pycache__/x.pyc": "bytecode",
+		"core/hooks/.DS_Store":         "",
+	}
+	for relPath, content := range files {
+		writeFile(t, targetDir, config.StrategicClaudeBasicDir+"/"+relPath, content)
+	}
+
+	info, err := json.Marshal(templates.TemplateInfo{
+		Template:        templates.Template{ID: "main"},
+		InstalledAt:     "2025-03-14T09:30:00Z",
+		InstalledCommit: "abc123",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, targetDir, config.StrategicClaudeBasicDir+"/"+config.TemplateInfoFile, string(info))
+	writeFile(t, targetDir, config.BackupsDir+"/"+config.SettingsBackupPrefix+"20250314-093000.json", "backup")
+
+	got, err := New().Collect(targetDir)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if got.Template != "main" || got.Commit != "abc123" || got.LastUpdated != "2025-03-14T09:30:00Z" {
+		t.Errorf("Collect() template = %s@%s updated %s, want main@abc123 updated 2025-03-14T09:30:00Z",
+			got.Template, got.Commit, got.LastUpdated)
+	}
+	if got.Documents != 3 || got.UserContentSize != 18 {
+		t.Errorf("Collect() documents = %d (%d bytes), want 3 (18 bytes)", got.Documents, got.UserContentSize)
+	}
+	if got.Agents != 2 || got.Commands != 1 || got.Hooks != 1 {
+		t.Errorf("Collect() agents/commands/hooks = %d/%d/%d, want 2/1/1", got.Agents, got.Commands, got.Hooks)
+	}
+	if got.Backups != 1 || got.BackupSize != 6 {
+		t.Errorf("Collect() backups = %d (%d bytes), want 1 (6 bytes)", got.Backups, got.BackupSize)
+	}
+
+	wantDirs := map[string]int{config.PlanDir: 2, config.ResearchDir: 1, config.ArchivesDir: 0}
+	if len(got.UserDirectories) != len(config.GetUserPreservedDirectories()) {
+		t.Errorf("Collect() user directories = %v, want one entry per user directory", got.UserDirectories)
+	}
+	for _, dir := range got.UserDirectories {
+		if want, ok := wantDirs[dir.Name]; ok && dir.Documents != want {
+			t.Errorf("Collect() %s documents = %d, want %d", dir.Name, dir.Documents, want)
+		}
+	}
+}
+
+func TestService_Collect_NotInstalled(t *testing.T) {
+	_, err := New().Collect(t.TempDir())
+	if !models.IsErrorCode(err, models.ErrorCodeNotInstalled) {
+		t.Errorf("Collect() error = %v, want code %s", err, models.ErrorCodeNotInstalled)
+	}
+}
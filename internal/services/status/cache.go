@@ -0,0 +1,166 @@
+package status
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// Cache keeps computed status results for a short time, so shell prompts and
+// editors can ask for the status often. A result is used only while the paths
+// the status is computed from keep their modification times and the template
+// metadata keeps its content.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is a cached status result
+type cacheEntry struct {
+	Version     int                `json:"version"`
+	Fingerprint string             `json:"fingerprint"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Status      *models.StatusInfo `json:"status"`
+}
+
+// NewCache creates a cache storing results in dir for ttl
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Load returns the cached status of targetDir if it is fresh and matches fingerprint
+func (c *Cache) Load(targetDir string, fast bool, fingerprint string) (*models.StatusInfo, bool) {
+	data, err := os.ReadFile(c.entryPath(targetDir, fast))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Status == nil {
+		return nil, false
+	}
+	if entry.Version != config.StatusCacheFormatVersion || entry.Fingerprint != fingerprint {
+		return nil, false
+	}
+	if age := c.now().Sub(entry.CreatedAt); age < 0 || age > c.ttl {
+		return nil, false
+	}
+
+	return entry.Status, true
+}
+
+// Store caches the status of targetDir computed while the paths had fingerprint
+func (c *Cache) Store(targetDir string, fast bool, fingerprint string, status *models.StatusInfo) error {
+	data, err := json.Marshal(cacheEntry{
+		Version:     config.StatusCacheFormatVersion,
+		Fingerprint: fingerprint,
+		CreatedAt:   c.now(),
+		Status:      status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode status cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, c.dir, err)
+	}
+	entryPath := c.entryPath(targetDir, fast)
+	if err := os.WriteFile(entryPath, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryPath, err)
+	}
+
+	return nil
+}
+
+// Remove deletes the cached results of targetDir
+func (c *Cache) Remove(targetDir string) error {
+	for _, fast := range []bool{false, true} {
+		entryPath := c.entryPath(targetDir, fast)
+		if err := os.Remove(entryPath); err != nil && !os.IsNotExist(err) {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, entryPath, err)
+		}
+	}
+	return nil
+}
+
+// entryPath returns the cache file for a target directory and check mode
+func (c *Cache) entryPath(targetDir string, fast bool) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%t\x00%s", targetDir, fast, config.FrameworkDir())))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// fingerprintPaths returns the paths, relative to the target directory, whose
+// changes invalidate a cached status
+func fingerprintPaths() []string {
+	strategicDir := config.FrameworkDir()
+	coreDir := filepath.Join(strategicDir, config.CoreDir)
+
+	paths := []string{
+		".",
+		strategicDir,
+		filepath.Join(strategicDir, config.TemplateInfoFile),
+		filepath.Join(strategicDir, config.ConventionsFile),
+		filepath.Join(strategicDir, config.OverlayDir),
+		coreDir,
+		config.ClaudeDir,
+		filepath.Join(config.ClaudeDir, config.ClaudeSettingsFile),
+		config.CodexDir,
+		filepath.Join(config.CursorDir, config.CursorRulesDir),
+		filepath.Join(config.CursorDir, config.CursorRulesLink),
+		config.AiderConfigFile,
+		config.OpenCodeConfigFile,
+	}
+	for _, dir := range config.GetFrameworkDirectories() {
+		paths = append(paths, filepath.Join(strategicDir, dir))
+	}
+	for _, dir := range []string{config.AgentsDir, config.CommandsDir, config.HooksDir} {
+		paths = append(paths, filepath.Join(coreDir, dir))
+	}
+	for symlinkPath := range config.GetRequiredSymlinks() {
+		paths = append(paths, filepath.Dir(filepath.Join(config.ClaudeDir, symlinkPath)), filepath.Join(config.ClaudeDir, symlinkPath))
+	}
+	for symlinkPath := range config.GetCodexRequiredSymlinks() {
+		paths = append(paths, filepath.Dir(filepath.Join(config.CodexDir, symlinkPath)), filepath.Join(config.CodexDir, symlinkPath))
+	}
+	return paths
+}
+
+// Fingerprint summarizes the state of the paths the status of targetDir depends on:
+// the type, size and modification time of each path and the template metadata content
+func Fingerprint(targetDir string) string {
+	// Map iteration order must not change the fingerprint
+	paths := fingerprintPaths()
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	hash := sha256.New()
+	for _, path := range paths {
+		info, err := os.Lstat(filepath.Join(targetDir, path))
+		if err != nil {
+			fmt.Fprintf(hash, "%s\x00-\n", path)
+			continue
+		}
+		fmt.Fprintf(hash, "%s\x00%v\x00%d\x00%d\n", path, info.Mode(), info.Size(), info.ModTime().UnixNano())
+	}
+
+	if data, err := os.ReadFile(filepath.Join(targetDir, config.FrameworkDir(), config.TemplateInfoFile)); err == nil {
+		sum := sha256.Sum256(data)
+		hash.Write(sum[:])
+	}
+
+	// Backups are added and removed in the backups directory, which may live elsewhere
+	if info, err := os.Lstat(config.GetBackupsRoot(targetDir)); err == nil {
+		fmt.Fprintf(hash, "backups\x00%v\x00%d\n", info.Mode(), info.ModTime().UnixNano())
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,99 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+// createInstallation creates a complete installation with valid .claude symlinks
+func createInstallation(t *testing.T) string {
+	t.Helper()
+
+	tempDir := createTestDirectory(t, map[string]interface{}{
+		config.StrategicClaudeBasicDir: map[string]interface{}{
+			config.CoreDir: map[string]interface{}{
+				config.AgentsDir:   nil,
+				config.CommandsDir: nil,
+				config.HooksDir:    nil,
+			},
+			config.GuidesDir:    nil,
+			config.TemplatesDir: nil,
+		},
+		config.ClaudeDir: map[string]interface{}{
+			config.ClaudeSettingsFile: `{"hooks": {"Stop": [{"matcher": "", "hooks": [{"type": "command", "command": "python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/missing.py"}]}]}}`,
+		},
+	})
+	for symlinkPath, target := range config.GetRequiredSymlinks() {
+		createSymlink(t, target, filepath.Join(tempDir, config.ClaudeDir, symlinkPath))
+	}
+	return tempDir
+}
+
+func TestService_CheckInstallation_Cache(t *testing.T) {
+	tempDir := createInstallation(t)
+	cache := NewCache(t.TempDir(), time.Minute)
+
+	service := NewService()
+	service.SetCache(cache)
+
+	first, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if first.ValidSymlinks() != 3 {
+		t.Fatalf("ValidSymlinks() = %d, want 3", first.ValidSymlinks())
+	}
+	if _, ok := cache.Load(tempDir, false, Fingerprint(tempDir)); !ok {
+		t.Fatal("Load() after CheckInstallation() found no entry")
+	}
+	if _, ok := cache.Load(tempDir, true, Fingerprint(tempDir)); ok {
+		t.Error("Load() returned a full check for a fast one")
+	}
+
+	// Removing a symlink changes the fingerprint
+	if err := os.Remove(filepath.Join(tempDir, config.ClaudeDir, config.AgentsDir, "strategic")); err != nil {
+		t.Fatalf("Failed to remove symlink: %v", err)
+	}
+	second, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if second.ValidSymlinks() != 2 {
+		t.Errorf("ValidSymlinks() after change = %d, want 2", second.ValidSymlinks())
+	}
+
+	// Entries expire after the TTL
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, ok := cache.Load(tempDir, false, Fingerprint(tempDir)); ok {
+		t.Error("Load() returned an expired entry")
+	}
+}
+
+func TestService_CheckInstallation_Fast(t *testing.T) {
+	tempDir := createInstallation(t)
+
+	service := NewService()
+	full, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if len(full.BrokenHooks()) != 1 {
+		t.Errorf("BrokenHooks() = %v, want the missing hook script", full.BrokenHooks())
+	}
+
+	service.SetFast(true)
+	fast, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if len(fast.Hooks) != 0 {
+		t.Errorf("Hooks with --fast = %v, want none inspected", fast.Hooks)
+	}
+	if fast.ValidSymlinks() != 3 || !fast.IsInstalled {
+		t.Errorf("fast check: ValidSymlinks() = %d, IsInstalled = %v, want 3 and true", fast.ValidSymlinks(), fast.IsInstalled)
+	}
+}
@@ -0,0 +1,210 @@
+package status
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// projectDirVar is the variable Claude Code expands to the project directory in hook commands
+const projectDirVar = "CLAUDE_PROJECT_DIR"
+
+// validateHooks checks every hook script referenced in .claude/settings.json
+func (s *Service) validateHooks(status *models.StatusInfo) {
+	if !status.ClaudeDir {
+		return
+	}
+
+	settingsPath := filepath.Join(status.ClaudeDirPath, config.ClaudeSettingsFile)
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			status.AddFinding(models.FindingSettingsInvalid, models.SeverityError, fmt.Sprintf("Failed to read %s: %v", config.ClaudeSettingsFile, err))
+		}
+		return
+	}
+
+	var settings models.ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		status.AddFinding(models.FindingSettingsInvalid, models.SeverityError, fmt.Sprintf("Failed to parse %s: %v", config.ClaudeSettingsFile, err))
+		return
+	}
+
+	for _, hookType := range models.GetHookTypesInOrder() {
+		for _, matcher := range settings.Hooks.Matchers(hookType) {
+			for _, hook := range matcher.Hooks {
+				if hookStatus, ok := inspectHookCommand(status.TargetDir, hookType, hook.Command); ok {
+					status.AddHook(hookStatus)
+				}
+			}
+		}
+	}
+}
+
+// inspectHookCommand resolves the script and interpreter of a hook command and checks
+// that the script exists, is executable and that its interpreter can be found.
+// Commands that do not reference a script file are not inspected and return false.
+func inspectHookCommand(targetDir, event, command string) (models.HookStatus, bool) {
+	interpreter, script, ok := parseHookCommand(targetDir, command)
+	if !ok {
+		return models.HookStatus{}, false
+	}
+
+	hookStatus := models.HookStatus{
+		Event:       event,
+		Command:     command,
+		Script:      script,
+		Interpreter: interpreter,
+	}
+
+	var problems []string
+
+	info, err := os.Stat(script)
+	switch {
+	case err == nil && info.Mode().IsRegular():
+		hookStatus.Exists = true
+		hookStatus.Executable = info.Mode().Perm()&0111 != 0
+		if !hookStatus.Executable {
+			problems = append(problems, "script is not executable")
+		}
+	case err == nil:
+		problems = append(problems, "script is not a regular file")
+	default:
+		if linkInfo, lerr := os.Lstat(script); lerr == nil && linkInfo.Mode()&os.ModeSymlink != 0 {
+			problems = append(problems, "script symlink is broken")
+		} else if isSymlinkedPath(script) {
+			problems = append(problems, "script not found through symlink")
+		} else {
+			problems = append(problems, "script not found")
+		}
+	}
+
+	// Scripts run directly rely on their #! line
+	if hookStatus.Interpreter == "" && hookStatus.Exists {
+		hookStatus.Interpreter = readShebangInterpreter(script)
+		if hookStatus.Interpreter == "" {
+			problems = append(problems, "script has no #! interpreter line")
+		}
+	}
+
+	if hookStatus.Interpreter != "" {
+		hookStatus.InterpreterFound = resolveInterpreter(hookStatus.Interpreter)
+		if !hookStatus.InterpreterFound {
+			problems = append(problems, fmt.Sprintf("interpreter %s not found", hookStatus.Interpreter))
+		}
+	}
+
+	hookStatus.Error = strings.Join(problems, "; ")
+	return hookStatus, true
+}
+
+// parseHookCommand splits a hook command into its interpreter and script path.
+// The interpreter is empty when the script is run directly.
+func parseHookCommand(targetDir, command string) (string, string, bool) {
+	expanded := os.Expand(command, func(name string) string {
+		if name == projectDirVar {
+			return targetDir
+		}
+		return os.Getenv(name)
+	})
+	expanded = strings.NewReplacer(`"`, "", `'`, "").Replace(expanded)
+
+	fields := strings.Fields(expanded)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	if isScriptPath(fields[0]) {
+		return "", absScriptPath(targetDir, fields[0]), true
+	}
+
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		// Runners such as "uv run" or "poetry run python" precede the script
+		if isScriptPath(field) {
+			return fields[0], absScriptPath(targetDir, field), true
+		}
+	}
+
+	return "", "", false
+}
+
+// isScriptPath reports whether a command argument names a hook script
+func isScriptPath(arg string) bool {
+	return slices.Contains(config.GetHookScriptExtensions(), filepath.Ext(arg))
+}
+
+// absScriptPath resolves a script path relative to the project directory, where hooks run
+func absScriptPath(targetDir, script string) string {
+	if filepath.IsAbs(script) {
+		return filepath.Clean(script)
+	}
+	return filepath.Join(targetDir, script)
+}
+
+// isSymlinkedPath reports whether any existing parent of path is a symlink
+func isSymlinkedPath(path string) bool {
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// readShebangInterpreter returns the interpreter named on the #! line of a script,
+// looking through /usr/bin/env to the program it starts
+func readShebangInterpreter(script string) string {
+	file, err := os.Open(script)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+
+	line, ok := strings.CutPrefix(strings.TrimSpace(line), "#!")
+	if !ok {
+		return ""
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if filepath.Base(fields[0]) == "env" {
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				return field
+			}
+		}
+		return ""
+	}
+
+	return fields[0]
+}
+
+// resolveInterpreter reports whether an interpreter path exists or a bare name is on PATH
+func resolveInterpreter(interpreter string) bool {
+	if strings.ContainsRune(interpreter, filepath.Separator) {
+		info, err := os.Stat(interpreter)
+		return err == nil && !info.IsDir() && info.Mode().Perm()&0111 != 0
+	}
+
+	_, err := exec.LookPath(interpreter)
+	return err == nil
+}
@@ -0,0 +1,134 @@
+//go:build unix
+
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestInspectHookCommand(t *testing.T) {
+	targetDir := t.TempDir()
+	hooksDir := filepath.Join(targetDir, config.ClaudeDir, config.HooksDir)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Failed to create hooks directory: %v", err)
+	}
+
+	scripts := map[string]struct {
+		content string
+		mode    os.FileMode
+	}{
+		"ok.sh":          {"#!/bin/sh\nexit 0\n", 0755},
+		"env.sh":         {"#!/usr/bin/env sh\nexit 0\n", 0755},
+		"noexec.sh":      {"#!/bin/sh\nexit 0\n", 0644},
+		"noshebang.sh":   {"exit 0\n", 0755},
+		"badinterp.py":   {"#!/usr/bin/env scb-missing-interpreter\n", 0755},
+		"interpreted.py": {"print('ok')\n", 0755},
+	}
+	for name, script := range scripts {
+		path := filepath.Join(hooksDir, name)
+		if err := os.WriteFile(path, []byte(script.content), 0644); err != nil {
+			t.Fatalf("Failed to write script: %v", err)
+		}
+		if err := os.Chmod(path, script.mode); err != nil {
+			t.Fatalf("Failed to chmod script: %v", err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(targetDir, "missing"), filepath.Join(hooksDir, "strategic")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		command   string
+		inspected bool
+		valid     bool
+		wantError string
+	}{
+		{"direct script", "$CLAUDE_PROJECT_DIR/.claude/hooks/ok.sh", true, true, ""},
+		{"quoted project dir", `"$CLAUDE_PROJECT_DIR"/.claude/hooks/env.sh`, true, true, ""},
+		{"relative script", ".claude/hooks/ok.sh", true, true, ""},
+		{"interpreter with flags", "/bin/sh -e $CLAUDE_PROJECT_DIR/.claude/hooks/ok.sh", true, true, ""},
+		{"not executable", "$CLAUDE_PROJECT_DIR/.claude/hooks/noexec.sh", true, false, "script is not executable"},
+		{"no shebang", "$CLAUDE_PROJECT_DIR/.claude/hooks/noshebang.sh", true, false, "script has no #! interpreter line"},
+		{"missing shebang interpreter", "$CLAUDE_PROJECT_DIR/.claude/hooks/badinterp.py", true, false, "interpreter scb-missing-interpreter not found"},
+		{"missing interpreter", "scb-missing-python $CLAUDE_PROJECT_DIR/.claude/hooks/interpreted.py", true, false, "interpreter scb-missing-python not found"},
+		{"missing script", "$CLAUDE_PROJECT_DIR/.claude/hooks/missing.sh", true, false, "script not found"},
+		{"broken symlink", "$CLAUDE_PROJECT_DIR/.claude/hooks/strategic/check.py", true, false, "script not found through symlink"},
+		{"hook runner", "scb-missing-uv run $CLAUDE_PROJECT_DIR/.claude/hooks/interpreted.py", true, false, "interpreter scb-missing-uv not found"},
+		{"not a script", "npx prettier --write .", false, false, ""},
+		{"empty command", "", false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hookStatus, inspected := inspectHookCommand(targetDir, "PreToolUse", tt.command)
+			if inspected != tt.inspected {
+				t.Fatalf("inspectHookCommand() inspected = %v, want %v", inspected, tt.inspected)
+			}
+			if !inspected {
+				return
+			}
+			if hookStatus.Valid() != tt.valid {
+				t.Errorf("inspectHookCommand() Valid() = %v, want %v (error: %q)", hookStatus.Valid(), tt.valid, hookStatus.Error)
+			}
+			if hookStatus.Error != tt.wantError {
+				t.Errorf("inspectHookCommand() Error = %q, want %q", hookStatus.Error, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestService_CheckInstallation_BrokenHooks(t *testing.T) {
+	targetDir := t.TempDir()
+	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
+	if err := os.MkdirAll(filepath.Join(claudeDir, config.HooksDir), 0755); err != nil {
+		t.Fatalf("Failed to create hooks directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, config.HooksDir, "ok.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	settings := `{
+  "hooks": {
+    "PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "$CLAUDE_PROJECT_DIR/.claude/hooks/ok.sh"}]}],
+    "Stop": [{"matcher": "", "hooks": [{"type": "command", "command": "/usr/bin/python3 $CLAUDE_PROJECT_DIR/.claude/hooks/strategic/stop-session-notify.py"}]}]
+  }
+}`
+	if err := os.WriteFile(filepath.Join(claudeDir, config.ClaudeSettingsFile), []byte(settings), 0644); err != nil {
+		t.Fatalf("Failed to write settings: %v", err)
+	}
+
+	statusInfo, err := NewService().CheckInstallation(targetDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+
+	if len(statusInfo.Hooks) != 2 {
+		t.Fatalf("CheckInstallation() found %d hooks, want 2", len(statusInfo.Hooks))
+	}
+
+	broken := statusInfo.BrokenHooks()
+	if len(broken) != 1 || broken[0].Event != "Stop" {
+		t.Fatalf("BrokenHooks() = %v, want only the Stop hook", broken)
+	}
+
+	want := "Stop hook .claude/hooks/strategic/stop-session-notify.py is broken: " + broken[0].Error
+	if !containsIssue(statusInfo, want) {
+		t.Errorf("CheckInstallation() issues = %v, want %q", statusInfo.Issues, want)
+	}
+}
+
+// containsIssue reports whether the status info contains the given issue
+func containsIssue(statusInfo *models.StatusInfo, issue string) bool {
+	for _, existing := range statusInfo.Issues {
+		if existing == issue {
+			return true
+		}
+	}
+	return false
+}
@@ -5,29 +5,66 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/backup"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cursor"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/manifest"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/migrate"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/toolconfig"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 )
 
 // Service provides status checking functionality
 type Service struct {
-	pathValidator  *utils.PathValidator
-	fsValidator    *utils.FileSystemValidator
-	inputValidator *utils.InputValidator
+	pathValidator     *utils.PathValidator
+	fsValidator       *utils.FileSystemValidator
+	inputValidator    *utils.InputValidator
+	cursorService     *cursor.Service
+	toolConfigService *toolconfig.Service
+	backupService     *backup.Service
+	manifestService   *manifest.Service
+	migrateService    *migrate.Service
+	cache             *Cache
+	fast              bool
+	deep              bool
 }
 
 // NewService creates a new status service
 func NewService() *Service {
 	return &Service{
-		pathValidator:  utils.NewPathValidator(),
-		fsValidator:    utils.NewFileSystemValidator(),
-		inputValidator: utils.NewInputValidator(),
+		pathValidator:     utils.NewPathValidator(),
+		fsValidator:       utils.NewFileSystemValidator(),
+		inputValidator:    utils.NewInputValidator(),
+		cursorService:     cursor.New(),
+		toolConfigService: toolconfig.New(),
+		backupService:     backup.New(),
+		manifestService:   manifest.New(),
+		migrateService:    migrate.New(),
 	}
 }
 
+// SetCache makes CheckInstallation reuse results from cache; nil disables caching
+func (s *Service) SetCache(cache *Cache) {
+	s.cache = cache
+}
+
+// SetFast makes CheckInstallation check symlinks by their link text only, without
+// following them to hook scripts, interpreters, Cursor rules and dev checkouts
+func (s *Service) SetFast(fast bool) {
+	s.fast = fast
+}
+
+// SetDeep makes CheckInstallation hash every framework file listed in the install
+// manifest instead of a sample
+func (s *Service) SetDeep(deep bool) {
+	s.deep = deep
+}
+
 // CheckInstallation performs comprehensive status checking for a target directory
 func (s *Service) CheckInstallation(targetDir string) (*models.StatusInfo, error) {
 	// Resolve target directory to absolute path
@@ -41,9 +78,32 @@ func (s *Service) CheckInstallation(targetDir string) (*models.StatusInfo, error
 		return nil, fmt.Errorf("invalid target directory: %w", err)
 	}
 
+	if s.cache == nil {
+		return s.checkInstallation(absTarget)
+	}
+
+	if status, ok := s.cache.Load(absTarget, s.fast, Fingerprint(absTarget)); ok {
+		return status, nil
+	}
+
+	status, err := s.checkInstallation(absTarget)
+	if err != nil {
+		return nil, err
+	}
+	// Fingerprint after checking: the writability checks touch the directories.
+	// Caching is best effort; the result is valid either way.
+	_ = s.cache.Store(absTarget, s.fast, Fingerprint(absTarget), status)
+
+	return status, nil
+}
+
+// checkInstallation checks an existing absolute target directory
+func (s *Service) checkInstallation(absTarget string) (*models.StatusInfo, error) {
 	// Initialize status info
 	status := models.NewStatusInfo(absTarget)
-	status.StrategicClaudeDirPath = filepath.Join(absTarget, config.StrategicClaudeBasicDir)
+	status.StrategicClaudeDirPath = filepath.Join(absTarget, config.FrameworkDir())
+	status.Instance = config.Instance()
+	status.Instances = config.FindInstances(absTarget)
 	status.ClaudeDirPath = filepath.Join(absTarget, config.ClaudeDir)
 	status.CodexDirPath = filepath.Join(absTarget, config.CodexDir)
 
@@ -52,6 +112,28 @@ func (s *Service) CheckInstallation(targetDir string) (*models.StatusInfo, error
 		return nil, fmt.Errorf("failed to check strategic-claude-basic directory: %w", err)
 	}
 
+	// Load template information if installation exists; it records the selected integrations
+	if status.StrategicClaudeDir {
+		templateInfo, err := s.LoadTemplateInfo(absTarget)
+		if err != nil {
+			status.AddFinding(models.FindingTemplateInfoInvalid, models.SeverityWarning, fmt.Sprintf("Failed to load template information: %v", err))
+		} else {
+			status.InstalledTemplate = templateInfo
+		}
+		if templateInfo != nil && len(templateInfo.Integrations) > 0 {
+			status.Integrations = templateInfo.Integrations
+		}
+
+		// Installations made by older versions have layouts the checks below
+		// would report as broken; one made before .codex support has only .claude
+		for _, step := range s.migrateService.Detect(absTarget) {
+			if step.Layout == migrate.LayoutPreCodex {
+				status.Integrations = []string{models.IntegrationClaude}
+			}
+			status.AddFinding(models.FindingLegacyLayout, models.SeverityWarning, fmt.Sprintf("Older layout (%s): %s; run 'doctor --migrate' to migrate it", step.Layout, step.Description))
+		}
+	}
+
 	// Check .claude directory structure
 	if err := s.verifyClaudeDirectory(status); err != nil {
 		return nil, fmt.Errorf("failed to verify claude directory: %w", err)
@@ -62,29 +144,107 @@ func (s *Service) CheckInstallation(targetDir string) (*models.StatusInfo, error
 		return nil, fmt.Errorf("failed to verify codex directory: %w", err)
 	}
 
-	// Load template information if installation exists
-	if status.StrategicClaudeDir {
-		templateInfo, err := s.loadTemplateInfo(absTarget)
-		if err != nil {
-			status.AddIssue(fmt.Sprintf("Failed to load template information: %v", err))
-		} else {
-			status.InstalledTemplate = templateInfo
-		}
-	}
+	// Detect installations in ancestor directories
+	status.ParentInstallations = s.FindParentInstallations(absTarget)
 
 	// Validate symlinks
 	s.validateSymlinks(status)
 	s.validateCodexSymlinks(status)
 
+	// Validate Cursor rules when they are installed
+	if !s.fast {
+		s.validateCursorRules(status)
+	}
+
+	// Validate the Aider and OpenCode configurations of the selected integrations
+	s.validateToolConfigs(status)
+
+	// Validate hook scripts referenced in settings.json
+	if !s.fast {
+		s.validateHooks(status)
+	} else if status.StrategicClaudeDir {
+		status.AddFinding(models.FindingFastCheck, models.SeverityInfo, "Hook scripts, interpreters and Cursor rules were not checked (fast check)")
+	}
+
+	// Summarize the backups, which walks every backup to size it
+	if !s.fast {
+		s.inventoryBackups(status)
+	}
+
 	// Identify any issues
 	s.identifyIssues(status)
 
+	// Compare the installation with the template this CLI pins
+	s.detectDrift(status)
+
+	// Compare the framework files with the install manifest
+	if !s.fast {
+		s.detectFileDrift(status)
+	}
+
 	// Determine overall installation status
 	status.IsInstalled = status.StrategicClaudeDir && (status.ClaudeDir || status.CodexDir) && (status.ValidSymlinks() > 0 || status.ValidCodexSymlinks() > 0)
 
 	return status, nil
 }
 
+// validateCursorRules checks the Cursor rules in .cursor/rules/strategic, and that
+// they exist when the cursor integration is selected
+func (s *Service) validateCursorRules(status *models.StatusInfo) {
+	rules, err := s.cursorService.CheckRules(status.TargetDir)
+	if err != nil {
+		status.AddFinding(models.FindingIssue, models.SeverityWarning, fmt.Sprintf("Failed to check Cursor rules: %v", err))
+		return
+	}
+	status.CursorRules = rules
+	if rules == nil {
+		if status.StrategicClaudeDir && status.HasIntegration(models.IntegrationCursor) && s.cursorService.HasRules(status.TargetDir) {
+			status.AddFinding(models.FindingCursorRulesMissing, models.SeverityWarning, "Cursor rules are not installed in .cursor/rules/strategic")
+		}
+		return
+	}
+
+	for _, issue := range rules.Issues {
+		status.AddFinding(models.FindingCursorRulesOutdated, models.SeverityWarning, fmt.Sprintf("Cursor rules: %s", issue))
+	}
+}
+
+// validateToolConfigs checks that the selected Aider and OpenCode integrations
+// reference the generated conventions file, and that it exists
+func (s *Service) validateToolConfigs(status *models.StatusInfo) {
+	aider := status.HasIntegration(models.IntegrationAider)
+	openCode := status.HasIntegration(models.IntegrationOpenCode)
+	if !status.StrategicClaudeDir || (!aider && !openCode) {
+		return
+	}
+
+	if _, err := os.Stat(toolconfig.ConventionsPath(status.TargetDir)); os.IsNotExist(err) {
+		status.AddFinding(models.FindingConventionsMissing, models.SeverityWarning, fmt.Sprintf("%s/%s does not exist", config.FrameworkDir(), config.ConventionsFile))
+	}
+	if aider && !s.toolConfigService.HasAider(status.TargetDir) {
+		status.AddFinding(models.FindingToolConfigIncomplete, models.SeverityWarning, fmt.Sprintf("%s does not read the framework conventions", config.AiderConfigFile))
+	}
+	if openCode && !s.toolConfigService.HasOpenCode(status.TargetDir) {
+		status.AddFinding(models.FindingToolConfigIncomplete, models.SeverityWarning, fmt.Sprintf("%s does not list the framework conventions in its instructions", config.OpenCodeConfigFile))
+	}
+}
+
+// FindParentInstallations walks up from targetDir and returns every ancestor
+// directory that contains a .strategic-claude-basic directory, nearest first
+func (s *Service) FindParentInstallations(targetDir string) []string {
+	var parents []string
+
+	dir := filepath.Dir(targetDir)
+	for dir != targetDir {
+		if info, err := os.Stat(filepath.Join(dir, config.FrameworkDir())); err == nil && info.IsDir() {
+			parents = append(parents, dir)
+		}
+		targetDir, dir = dir, filepath.Dir(dir)
+	}
+
+	return parents
+}
+
 // detectStrategicClaudeBasic checks if the .strategic-claude-basic directory exists and is properly structured
 func (s *Service) detectStrategicClaudeBasic(status *models.StatusInfo) error {
 	strategicDir := status.StrategicClaudeDirPath
@@ -94,7 +254,7 @@ func (s *Service) detectStrategicClaudeBasic(status *models.StatusInfo) error {
 	if err != nil {
 		if os.IsNotExist(err) {
 			status.StrategicClaudeDir = false
-			status.AddIssue(".strategic-claude-basic directory does not exist")
+			status.AddFinding(models.FindingFrameworkMissing, models.SeverityError, config.FrameworkDir()+" directory does not exist")
 			return nil
 		}
 		return fmt.Errorf("failed to stat strategic-claude-basic directory: %w", err)
@@ -102,7 +262,7 @@ func (s *Service) detectStrategicClaudeBasic(status *models.StatusInfo) error {
 
 	if !info.IsDir() {
 		status.StrategicClaudeDir = false
-		status.AddIssue(".strategic-claude-basic exists but is not a directory")
+		status.AddFinding(models.FindingFrameworkMissing, models.SeverityError, config.FrameworkDir()+" exists but is not a directory")
 		return nil
 	}
 
@@ -112,8 +272,9 @@ func (s *Service) detectStrategicClaudeBasic(status *models.StatusInfo) error {
 	requiredDirs := config.GetFrameworkDirectories()
 	for _, dir := range requiredDirs {
 		dirPath := filepath.Join(strategicDir, dir)
+		s.detectDevLink(status, dir, dirPath)
 		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
-			status.AddIssue(fmt.Sprintf("Missing framework directory: %s", dir))
+			status.AddFinding(models.FindingFrameworkDirMissing, models.SeverityError, fmt.Sprintf("Missing framework directory: %s", dir))
 		}
 	}
 
@@ -124,13 +285,43 @@ func (s *Service) detectStrategicClaudeBasic(status *models.StatusInfo) error {
 	for _, subdir := range requiredCoreSubdirs {
 		subdirPath := filepath.Join(coreDir, subdir)
 		if _, err := os.Stat(subdirPath); os.IsNotExist(err) {
-			status.AddIssue(fmt.Sprintf("Missing core subdirectory: core/%s", subdir))
+			status.AddFinding(models.FindingFrameworkDirMissing, models.SeverityError, fmt.Sprintf("Missing core subdirectory: core/%s", subdir))
 		}
 	}
 
 	return nil
 }
 
+// detectDevLink records the template checkout a framework directory links to
+// when it was installed with init --dev
+func (s *Service) detectDevLink(status *models.StatusInfo, dir, dirPath string) {
+	info, err := os.Lstat(dirPath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return
+	}
+
+	target, err := os.Readlink(dirPath)
+	if err != nil {
+		status.AddFinding(models.FindingDevLinkBroken, models.SeverityError, fmt.Sprintf("Cannot read framework directory link %s: %v", dir, err))
+		return
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(dirPath), target)
+	}
+
+	// Links point to <checkout>/.strategic-claude-basic/<dir>
+	if status.DevTemplatePath == "" {
+		status.DevTemplatePath = filepath.Dir(filepath.Dir(target))
+		status.AddFinding(models.FindingDevMode, models.SeverityInfo, fmt.Sprintf("Framework is linked from the template checkout %s (dev mode)", status.DevTemplatePath))
+	}
+	if s.fast {
+		return
+	}
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		status.AddFinding(models.FindingDevLinkBroken, models.SeverityError, fmt.Sprintf("Framework directory %s links to missing template checkout path %s", dir, target))
+	}
+}
+
 // verifyClaudeDirectory checks if the .claude directory exists and has the correct structure
 func (s *Service) verifyClaudeDirectory(status *models.StatusInfo) error {
 	claudeDir := status.ClaudeDirPath
@@ -140,7 +331,7 @@ func (s *Service) verifyClaudeDirectory(status *models.StatusInfo) error {
 	if err != nil {
 		if os.IsNotExist(err) {
 			status.ClaudeDir = false
-			status.AddIssue(".claude directory does not exist")
+			status.AddFinding(models.FindingClaudeMissing, models.SeverityError, ".claude directory does not exist")
 			return nil
 		}
 		return fmt.Errorf("failed to stat claude directory: %w", err)
@@ -148,7 +339,7 @@ func (s *Service) verifyClaudeDirectory(status *models.StatusInfo) error {
 
 	if !info.IsDir() {
 		status.ClaudeDir = false
-		status.AddIssue(".claude exists but is not a directory")
+		status.AddFinding(models.FindingClaudeMissing, models.SeverityError, ".claude exists but is not a directory")
 		return nil
 	}
 
@@ -159,7 +350,7 @@ func (s *Service) verifyClaudeDirectory(status *models.StatusInfo) error {
 	for _, subdir := range requiredSubdirs {
 		subdirPath := filepath.Join(claudeDir, subdir)
 		if _, err := os.Stat(subdirPath); os.IsNotExist(err) {
-			status.AddIssue(fmt.Sprintf("Missing .claude subdirectory: %s", subdir))
+			status.AddFinding(models.FindingClaudeDirMissing, models.SeverityError, fmt.Sprintf("Missing .claude subdirectory: %s", subdir))
 		}
 	}
 
@@ -168,46 +359,148 @@ func (s *Service) verifyClaudeDirectory(status *models.StatusInfo) error {
 
 // validateSymlinks checks all required symlinks and their targets
 func (s *Service) validateSymlinks(status *models.StatusInfo) {
-	requiredSymlinks := config.GetRequiredSymlinks()
+	requiredSymlinks := config.ResolveSymlinkTargets(status.TargetDir, config.GetRequiredSymlinks())
 
 	for symlinkPath, expectedTarget := range requiredSymlinks {
 		fullSymlinkPath := filepath.Join(status.ClaudeDirPath, symlinkPath)
 		// Use the relative target as-is - the ValidateSymlink function will handle path resolution
 
-		symlinkStatus, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget)
+		symlinkStatus, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget, status.StrategicClaudeDirPath)
 		if err != nil {
 			// Log error but continue checking other symlinks
-			status.AddIssue(fmt.Sprintf("Failed to check symlink %s: %v", symlinkPath, err))
+			status.AddFinding(models.FindingSymlinkUnchecked, models.SeverityError, fmt.Sprintf("Failed to check symlink %s: %v", symlinkPath, err))
 		}
 
 		if symlinkStatus != nil {
+			s.followSymlink(symlinkStatus)
+			s.flagEscape(status, filepath.Join(config.ClaudeDir, symlinkPath), symlinkStatus)
 			status.AddSymlink(*symlinkStatus)
 		}
 	}
 }
 
+// flagEscape reports a symlink whose target resolves outside the framework
+// directory, e.g. through a link a template ships in core/
+func (s *Service) flagEscape(status *models.StatusInfo, name string, symlinkStatus *models.SymlinkStatus) {
+	if symlinkStatus.Escapes {
+		status.AddFinding(models.FindingSymlinkEscapes, models.SeverityError, fmt.Sprintf("Symlink %s leaves %s: %s", name, config.FrameworkDir(), symlinkStatus.Error))
+	}
+}
+
+// followSymlink records whether an existing symlink resolves to an existing target;
+// fast checks do not follow symlinks
+func (s *Service) followSymlink(symlinkStatus *models.SymlinkStatus) {
+	if s.fast || !symlinkStatus.Exists || symlinkStatus.Target == "" {
+		return
+	}
+	_, err := os.Stat(symlinkStatus.Path)
+	targetExists := err == nil
+	symlinkStatus.TargetExists = &targetExists
+}
+
+// inventoryBackups records the backups of the project and suggests removing old
+// ones when they pile up
+func (s *Service) inventoryBackups(status *models.StatusInfo) {
+	inventory, err := s.backupService.Inventory(status.TargetDir)
+	if err != nil {
+		status.AddFinding(models.FindingIssue, models.SeverityInfo, fmt.Sprintf("Failed to inventory backups: %v", err))
+		return
+	}
+	if inventory.Count == 0 {
+		return
+	}
+	status.Backups = inventory
+
+	if inventory.NeedsPruning() {
+		status.AddFinding(models.FindingBackupsAccumulated, models.SeverityInfo,
+			fmt.Sprintf("%d backup(s) use %s; 'backup prune' removes old ones from %s", inventory.Count, utils.FormatSize(inventory.TotalSize), inventory.Dir))
+	}
+	if inventory.Legacy > 0 {
+		status.AddFinding(models.FindingBackupsAccumulated, models.SeverityInfo,
+			fmt.Sprintf("%d backup(s) are outside %s; 'backup list' moves them there", inventory.Legacy, inventory.Dir))
+	}
+}
+
+// detectDrift records when the installed template commit is not the one this CLI pins.
+// Dev mode installs link a checkout and have no commit to compare.
+func (s *Service) detectDrift(status *models.StatusInfo) {
+	info := status.InstalledTemplate
+	if info == nil || status.DevTemplatePath != "" || info.Template.Commit == "" {
+		return
+	}
+
+	pinned, err := templates.GetTemplate(info.Template.ID)
+	if err != nil {
+		return // Templates unknown to this CLI have nothing to compare with
+	}
+	if pinned.Commit != info.Template.Commit {
+		status.AddDrift(fmt.Sprintf("Template %s is installed at commit %s, this CLI installs %s", info.Template.ID, shortCommit(info.Template.Commit), shortCommit(pinned.Commit)))
+	}
+}
+
+// detectFileDrift lists the framework files that were modified or removed since
+// installation, when the installation has a manifest
+func (s *Service) detectFileDrift(status *models.StatusInfo) {
+	if !status.StrategicClaudeDir {
+		return
+	}
+
+	installed, err := s.manifestService.Read(status.TargetDir)
+	if err != nil {
+		status.AddFinding(models.FindingManifestInvalid, models.SeverityInfo, fmt.Sprintf("Cannot compare framework files with the install manifest: %v", err))
+		return
+	}
+	if installed == nil {
+		return
+	}
+
+	sampleSize := config.DriftSampleSize
+	if s.deep {
+		sampleSize = 0
+	}
+	check, err := s.manifestService.Verify(status.TargetDir, installed, sampleSize)
+	if err != nil {
+		status.AddFinding(models.FindingManifestInvalid, models.SeverityInfo, fmt.Sprintf("Cannot compare framework files with the install manifest: %v", err))
+		return
+	}
+	status.DriftCheck = check
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
 // identifyIssues performs additional issue identification based on the gathered information
 func (s *Service) identifyIssues(status *models.StatusInfo) {
 	// Check for permission issues
 	if status.StrategicClaudeDir {
 		if err := s.pathValidator.ValidateDirectoryWritable(status.StrategicClaudeDirPath); err != nil {
-			status.AddIssue(fmt.Sprintf("Strategic Claude Basic directory is not writable: %v", err))
+			status.AddFinding(models.FindingNotWritable, models.SeverityError, fmt.Sprintf("Strategic Claude Basic directory is not writable: %v", err))
 		}
 	}
 
 	if status.ClaudeDir {
 		if err := s.pathValidator.ValidateDirectoryWritable(status.ClaudeDirPath); err != nil {
-			status.AddIssue(fmt.Sprintf("Claude directory is not writable: %v", err))
+			status.AddFinding(models.FindingNotWritable, models.SeverityError, fmt.Sprintf("Claude directory is not writable: %v", err))
 		}
 	}
 
 	// Check for partial installation
 	if status.StrategicClaudeDir && !status.ClaudeDir {
-		status.AddIssue("Partial installation detected: .strategic-claude-basic exists but .claude directory is missing")
+		status.AddFinding(models.FindingPartialInstallation, models.SeverityError, fmt.Sprintf("Partial installation detected: %s exists but .claude directory is missing", config.FrameworkDir()))
 	}
 
 	if !status.StrategicClaudeDir && status.ClaudeDir {
-		status.AddIssue("Partial installation detected: .claude directory exists but .strategic-claude-basic is missing")
+		status.AddFinding(models.FindingPartialInstallation, models.SeverityError, "Partial installation detected: .claude directory exists but .strategic-claude-basic is missing")
+	}
+
+	// Check for installations in parent directories, which wire hooks a second time
+	for _, parent := range status.ParentInstallations {
+		status.AddFinding(models.FindingParentInstallation, models.SeverityWarning, fmt.Sprintf("Parent directory %s also contains a Strategic Claude Basic installation", parent))
 	}
 
 	// Check for symlink integrity
@@ -215,12 +508,25 @@ func (s *Service) identifyIssues(status *models.StatusInfo) {
 	totalSymlinks := len(status.Symlinks)
 
 	if totalSymlinks > 0 && validSymlinks < totalSymlinks {
-		status.AddIssue(fmt.Sprintf("Some symlinks are broken or invalid (%d/%d valid)", validSymlinks, totalSymlinks))
+		status.AddFinding(models.FindingSymlinksBroken, models.SeverityError, fmt.Sprintf("Some symlinks are broken or invalid (%d/%d valid)", validSymlinks, totalSymlinks))
 	}
 
 	if status.StrategicClaudeDir && status.ClaudeDir && totalSymlinks == 0 {
-		status.AddIssue("Installation directories exist but no strategic symlinks were found")
+		status.AddFinding(models.FindingSymlinksMissing, models.SeverityError, "Installation directories exist but no strategic symlinks were found")
+	}
+
+	// Check for hooks that cannot run
+	for _, hook := range status.BrokenHooks() {
+		status.AddFinding(models.FindingHookBroken, models.SeverityError, fmt.Sprintf("%s hook %s is broken: %s", hook.Event, s.displayPath(status, hook.Script), hook.Error))
+	}
+}
+
+// displayPath shortens paths inside the target directory for messages
+func (s *Service) displayPath(status *models.StatusInfo, path string) string {
+	if relPath, err := filepath.Rel(status.TargetDir, path); err == nil && !strings.HasPrefix(relPath, "..") {
+		return relPath
 	}
+	return path
 }
 
 // verifyCodexDirectory checks if the .codex directory exists and has the correct structure
@@ -232,9 +538,9 @@ func (s *Service) verifyCodexDirectory(status *models.StatusInfo) error {
 	if err != nil {
 		if os.IsNotExist(err) {
 			status.CodexDir = false
-			// Only report as issue if strategic-claude-basic is installed (indicating expectation for integration dirs)
-			if status.StrategicClaudeDir {
-				status.AddIssue(".codex directory does not exist")
+			// Only report as issue if strategic-claude-basic is installed with the codex integration
+			if status.StrategicClaudeDir && status.HasIntegration(models.IntegrationCodex) {
+				status.AddFinding(models.FindingCodexMissing, models.SeverityError, ".codex directory does not exist")
 			}
 			return nil
 		}
@@ -243,7 +549,7 @@ func (s *Service) verifyCodexDirectory(status *models.StatusInfo) error {
 
 	if !info.IsDir() {
 		status.CodexDir = false
-		status.AddIssue(".codex exists but is not a directory")
+		status.AddFinding(models.FindingCodexMissing, models.SeverityError, ".codex exists but is not a directory")
 		return nil
 	}
 
@@ -254,7 +560,7 @@ func (s *Service) verifyCodexDirectory(status *models.StatusInfo) error {
 	for _, subdir := range requiredSubdirs {
 		subdirPath := filepath.Join(codexDir, subdir)
 		if _, err := os.Stat(subdirPath); os.IsNotExist(err) {
-			status.AddIssue(fmt.Sprintf("Missing codex subdirectory: %s", subdir))
+			status.AddFinding(models.FindingCodexDirMissing, models.SeverityError, fmt.Sprintf("Missing codex subdirectory: %s", subdir))
 		}
 	}
 
@@ -263,31 +569,34 @@ func (s *Service) verifyCodexDirectory(status *models.StatusInfo) error {
 
 // validateCodexSymlinks validates all Codex symlinks and populates status
 func (s *Service) validateCodexSymlinks(status *models.StatusInfo) {
-	if !status.CodexDir {
-		return // Skip if .codex directory doesn't exist
+	if !status.CodexDir || !status.HasIntegration(models.IntegrationCodex) {
+		return // Skip if .codex directory doesn't exist or is not managed by the installation
 	}
 
 	codexDir := status.CodexDirPath
-	requiredSymlinks := config.GetCodexRequiredSymlinks()
+	requiredSymlinks := config.ResolveSymlinkTargets(status.TargetDir, config.GetCodexRequiredSymlinks())
 
 	for symlinkPath, expectedTarget := range requiredSymlinks {
 		fullSymlinkPath := filepath.Join(codexDir, symlinkPath)
 
-		symlinkStatus, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget)
+		symlinkStatus, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget, status.StrategicClaudeDirPath)
 		if err != nil {
 			// Create a basic status entry for the error
 			status.AddCodexSymlink(models.SymlinkStatus{
-				Name:   filepath.Base(symlinkPath),
-				Path:   fullSymlinkPath,
-				Valid:  false,
-				Target: "",
-				Exists: false,
-				Error:  fmt.Sprintf("Failed to validate codex symlink: %v", err),
+				Name:     filepath.Base(symlinkPath),
+				Path:     fullSymlinkPath,
+				Valid:    false,
+				Target:   "",
+				Exists:   false,
+				Error:    fmt.Sprintf("Failed to validate codex symlink: %v", err),
+				Expected: expectedTarget,
 			})
 			continue
 		}
 
 		if symlinkStatus != nil {
+			s.followSymlink(symlinkStatus)
+			s.flagEscape(status, filepath.Join(config.CodexDir, symlinkPath), symlinkStatus)
 			status.AddCodexSymlink(*symlinkStatus)
 		}
 	}
@@ -296,19 +605,20 @@ func (s *Service) validateCodexSymlinks(status *models.StatusInfo) {
 // GetStatusSummary returns a human-readable summary of the installation status
 func (s *Service) GetStatusSummary(status *models.StatusInfo) string {
 	if !status.IsInstalled {
-		return "Strategic Claude Basic is not installed"
+		return i18n.T("Strategic Claude Basic is not installed")
 	}
 
 	if status.HasIssues() {
-		return fmt.Sprintf("Strategic Claude Basic is installed but has %d issue(s)", len(status.Issues))
+		return i18n.T("Strategic Claude Basic is installed but has %d issue(s)", len(status.Issues))
 	}
 
-	return "Strategic Claude Basic is installed and configured correctly"
+	return i18n.T("Strategic Claude Basic is installed and configured correctly")
 }
 
-// loadTemplateInfo loads template metadata from the installation directory
-func (s *Service) loadTemplateInfo(targetDir string) (*templates.TemplateInfo, error) {
-	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+// LoadTemplateInfo loads template metadata from the installation directory; it
+// returns nil without an error when the installation has none
+func (s *Service) LoadTemplateInfo(targetDir string) (*templates.TemplateInfo, error) {
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
 	templateInfoPath := filepath.Join(strategicDir, config.TemplateInfoFile)
 
 	// Check if file exists
@@ -1,12 +1,14 @@
 package status
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 )
 
 // createTestDirectory creates a temporary directory structure for testing
@@ -224,6 +226,76 @@ func TestService_CheckInstallation_CompleteInstallation(t *testing.T) {
 	}
 }
 
+func TestService_CheckInstallation_Integrations(t *testing.T) {
+	tests := []struct {
+		name          string
+		templateInfo  string
+		expectedIssue string
+	}{
+		{
+			name:          "installation before .codex support",
+			templateInfo:  `{"id":"main"}`,
+			expectedIssue: "Older layout (pre-codex): Record claude as the only integration in .template-info, as the installation predates .codex support; run 'doctor --migrate' to migrate it",
+		},
+		{
+			name:         "codex not selected",
+			templateInfo: `{"id":"main","integrations":["claude"]}`,
+		},
+		{
+			name:          "cursor selected without installed rules",
+			templateInfo:  `{"id":"main","integrations":["claude","cursor"]}`,
+			expectedIssue: "Cursor rules are not installed in .cursor/rules/strategic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			structure := map[string]interface{}{
+				config.StrategicClaudeBasicDir: map[string]interface{}{
+					config.CoreDir: map[string]interface{}{
+						config.AgentsDir:   nil,
+						config.CommandsDir: nil,
+						config.HooksDir:    nil,
+					},
+					config.GuidesDir: nil,
+					config.TemplatesDir: map[string]interface{}{
+						"cursor": map[string]interface{}{
+							"rules": map[string]interface{}{"workflow.mdc": "rule"},
+						},
+					},
+					config.TemplateInfoFile: tt.templateInfo,
+				},
+				config.ClaudeDir: nil,
+			}
+			tempDir := createTestDirectory(t, structure)
+			for symlinkPath, target := range config.GetRequiredSymlinks() {
+				createSymlink(t, target, filepath.Join(tempDir, config.ClaudeDir, symlinkPath))
+			}
+
+			status, err := NewService().CheckInstallation(tempDir)
+			if err != nil {
+				t.Fatalf("CheckInstallation() error = %v", err)
+			}
+
+			if tt.expectedIssue == "" {
+				if len(status.Issues) > 0 {
+					t.Errorf("CheckInstallation() issues = %v, want none", status.Issues)
+				}
+				return
+			}
+			found := false
+			for _, issue := range status.Issues {
+				if issue == tt.expectedIssue {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("CheckInstallation() issues = %v, want %q", status.Issues, tt.expectedIssue)
+			}
+		})
+	}
+}
+
 func TestService_CheckInstallation_BrokenSymlinks(t *testing.T) {
 	// Create installation with broken symlinks
 	structure := map[string]interface{}{
@@ -507,3 +579,238 @@ func TestService_detectStrategicClaudeBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestService_detectStrategicClaudeBasic_DevMode(t *testing.T) {
+	service := NewService()
+
+	checkout := createTestDirectory(t, map[string]interface{}{
+		config.StrategicClaudeBasicDir: map[string]interface{}{
+			config.CoreDir: map[string]interface{}{
+				config.AgentsDir:   nil,
+				config.CommandsDir: nil,
+				config.HooksDir:    nil,
+			},
+			config.GuidesDir: nil,
+		},
+	})
+	tempDir := createTestDirectory(t, map[string]interface{}{
+		config.StrategicClaudeBasicDir: nil,
+	})
+
+	strategicDir := filepath.Join(tempDir, config.StrategicClaudeBasicDir)
+	for _, dir := range config.GetFrameworkDirectories() {
+		createSymlink(t, filepath.Join(checkout, config.StrategicClaudeBasicDir, dir), filepath.Join(strategicDir, dir))
+	}
+
+	status := models.NewStatusInfo(tempDir)
+	status.StrategicClaudeDirPath = strategicDir
+	if err := service.detectStrategicClaudeBasic(status); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if status.DevTemplatePath != checkout {
+		t.Errorf("DevTemplatePath = %v, want %v", status.DevTemplatePath, checkout)
+	}
+
+	// templates/ is missing from the checkout
+	wantIssue := fmt.Sprintf("Framework directory %s links to missing template checkout path %s",
+		config.TemplatesDir, filepath.Join(checkout, config.StrategicClaudeBasicDir, config.TemplatesDir))
+	found := false
+	for _, issue := range status.Issues {
+		if issue == wantIssue {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected issue '%s' not found in: %v", wantIssue, status.Issues)
+	}
+}
+
+func TestService_FindParentInstallations(t *testing.T) {
+	tempDir := createTestDirectory(t, map[string]interface{}{
+		config.StrategicClaudeBasicDir: nil,
+		"apps": map[string]interface{}{
+			config.StrategicClaudeBasicDir: nil,
+			"web":                          map[string]interface{}{},
+		},
+		"libs": map[string]interface{}{},
+	})
+
+	service := NewService()
+
+	tests := []struct {
+		name     string
+		target   string
+		expected []string
+	}{
+		{
+			name:     "nested two levels deep",
+			target:   filepath.Join(tempDir, "apps", "web"),
+			expected: []string{filepath.Join(tempDir, "apps"), tempDir},
+		},
+		{
+			name:     "nested one level deep",
+			target:   filepath.Join(tempDir, "libs"),
+			expected: []string{tempDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents := service.FindParentInstallations(tt.target)
+
+			// Only compare the part of the tree created by the test
+			var found []string
+			for _, parent := range parents {
+				if parent == tempDir || filepath.Dir(parent) == tempDir {
+					found = append(found, parent)
+				}
+			}
+
+			if len(found) != len(tt.expected) {
+				t.Fatalf("FindParentInstallations() = %v, want %v", found, tt.expected)
+			}
+			for i := range found {
+				if found[i] != tt.expected[i] {
+					t.Errorf("FindParentInstallations()[%d] = %v, want %v", i, found[i], tt.expected[i])
+				}
+			}
+		})
+	}
+
+	statusInfo, err := service.CheckInstallation(filepath.Join(tempDir, "libs"))
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if len(statusInfo.ParentInstallations) == 0 {
+		t.Error("Expected CheckInstallation to report the parent installation")
+	}
+}
+
+func TestService_detectDrift(t *testing.T) {
+	pinned, err := templates.GetTemplate("main")
+	if err != nil {
+		t.Fatalf("GetTemplate() error = %v", err)
+	}
+	outdated := pinned
+	outdated.Commit = "1234567890abcdef1234567890abcdef12345678"
+	unknown := outdated
+	unknown.ID = "unknown"
+
+	tests := []struct {
+		name      string
+		template  *templates.Template
+		devPath   string
+		wantDrift bool
+	}{
+		{name: "no template info", template: nil},
+		{name: "pinned commit", template: &pinned},
+		{name: "outdated commit", template: &outdated, wantDrift: true},
+		{name: "dev mode", template: &outdated, devPath: "/src/template"},
+		{name: "unknown template", template: &unknown},
+	}
+
+	service := NewService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusInfo := &models.StatusInfo{DevTemplatePath: tt.devPath}
+			if tt.template != nil {
+				statusInfo.InstalledTemplate = &templates.TemplateInfo{Template: *tt.template}
+			}
+
+			service.detectDrift(statusInfo)
+
+			if statusInfo.HasDrift() != tt.wantDrift {
+				t.Errorf("HasDrift() = %v, want %v (drift: %v)", statusInfo.HasDrift(), tt.wantDrift, statusInfo.Drift)
+			}
+		})
+	}
+}
+
+func TestService_CheckInstallation_SymlinkTargets(t *testing.T) {
+	tempDir := createInstallation(t)
+
+	// A symlink whose target is missing
+	agentsLink := filepath.Join(tempDir, config.ClaudeDir, config.AgentsDir, "strategic")
+	if err := os.Remove(agentsLink); err != nil {
+		t.Fatalf("Failed to remove symlink: %v", err)
+	}
+	createSymlink(t, "../../missing", agentsLink)
+
+	service := NewService()
+	statusInfo, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	for _, symlink := range statusInfo.Symlinks {
+		if symlink.Expected == "" {
+			t.Errorf("%s: Expected is empty", symlink.Name)
+		}
+		if symlink.TargetExists == nil {
+			t.Errorf("%s: TargetExists not checked", symlink.Name)
+			continue
+		}
+		wantExists := symlink.Path != agentsLink
+		if *symlink.TargetExists != wantExists {
+			t.Errorf("%s: TargetExists = %v, want %v", symlink.Name, *symlink.TargetExists, wantExists)
+		}
+	}
+
+	// Fast checks do not follow symlinks
+	service.SetFast(true)
+	statusInfo, err = service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	for _, symlink := range statusInfo.Symlinks {
+		if symlink.TargetExists != nil {
+			t.Errorf("%s: TargetExists = %v with a fast check, want nil", symlink.Name, *symlink.TargetExists)
+		}
+	}
+}
+
+func TestService_CheckInstallation_FileDrift(t *testing.T) {
+	tempDir := createInstallation(t)
+	agentPath := filepath.Join(tempDir, config.StrategicClaudeBasicDir, config.CoreDir, config.AgentsDir, "planner.md")
+	if err := os.WriteFile(agentPath, []byte("planner"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	service := NewService()
+
+	// Without a manifest there is nothing to compare with
+	statusInfo, err := service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if statusInfo.DriftCheck != nil {
+		t.Errorf("DriftCheck without a manifest = %+v, want nil", statusInfo.DriftCheck)
+	}
+	issues := len(statusInfo.Issues)
+
+	installed, err := service.manifestService.Build(tempDir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if err := service.manifestService.Write(tempDir, installed); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := os.WriteFile(agentPath, []byte("PLANNER"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	service.SetDeep(true)
+	statusInfo, err = service.CheckInstallation(tempDir)
+	if err != nil {
+		t.Fatalf("CheckInstallation() error = %v", err)
+	}
+	if statusInfo.DriftCheck == nil || len(statusInfo.DriftCheck.Changed) != 1 {
+		t.Fatalf("DriftCheck = %+v, want the modified agent", statusInfo.DriftCheck)
+	}
+	if !statusInfo.HasDrift() {
+		t.Error("HasDrift() = false, want true")
+	}
+	if len(statusInfo.Issues) != issues {
+		t.Errorf("Modified framework files reported as issues: %v", statusInfo.Issues)
+	}
+}
@@ -12,16 +12,34 @@ import (
 
 // Service handles symlink operations for the Strategic Claude Basic CLI
 type Service struct {
-	fsValidator *utils.FileSystemValidator
+	fsValidator   *utils.FileSystemValidator
+	pathValidator *utils.PathValidator
+	style         string
 }
 
 // New creates a new symlink service instance
 func New() *Service {
 	return &Service{
-		fsValidator: utils.NewFileSystemValidator(),
+		fsValidator:   utils.NewFileSystemValidator(),
+		pathValidator: utils.NewPathValidator(),
 	}
 }
 
+// SetStyle makes the symlinks be written and validated in style, relative or
+// absolute; empty uses the style the installation recorded
+func (s *Service) SetStyle(style string) {
+	s.style = style
+}
+
+// resolveTargets returns symlinks with the targets used in targetDir, written
+// in the style set with SetStyle or the recorded one
+func (s *Service) resolveTargets(targetDir string, symlinks map[string]string) map[string]string {
+	if s.style == "" {
+		return config.ResolveSymlinkTargets(targetDir, symlinks)
+	}
+	return config.ResolveSymlinkTargetsInStyle(targetDir, symlinks, s.style)
+}
+
 // CreateSymlinks creates all required symlinks from .claude subdirectories to strategic-claude-basic core
 func (s *Service) CreateSymlinks(targetDir string) error {
 	if targetDir == "" {
@@ -33,7 +51,7 @@ func (s *Service) CreateSymlinks(targetDir string) error {
 	}
 
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
-	requiredSymlinks := config.GetRequiredSymlinks()
+	requiredSymlinks := s.resolveTargets(targetDir, config.GetRequiredSymlinks())
 
 	// Ensure .claude directory exists
 	if err := s.ensureClaudeDirectoryStructure(claudeDir); err != nil {
@@ -61,7 +79,7 @@ func (s *Service) CreateCodexSymlinks(targetDir string) error {
 	}
 
 	codexDir := filepath.Join(targetDir, config.CodexDir)
-	requiredSymlinks := config.GetCodexRequiredSymlinks()
+	requiredSymlinks := s.resolveTargets(targetDir, config.GetCodexRequiredSymlinks())
 
 	// Ensure .codex directory exists
 	if err := s.ensureCodexDirectoryStructure(codexDir); err != nil {
@@ -155,13 +173,13 @@ func (s *Service) ValidateSymlinks(targetDir string) ([]models.SymlinkStatus, er
 	}
 
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
-	requiredSymlinks := config.GetRequiredSymlinks()
+	requiredSymlinks := s.resolveTargets(targetDir, config.GetRequiredSymlinks())
 	var statuses []models.SymlinkStatus
 
 	for symlinkPath, expectedTarget := range requiredSymlinks {
 		fullSymlinkPath := filepath.Join(claudeDir, symlinkPath)
 
-		status, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget)
+		status, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget, filepath.Join(targetDir, config.FrameworkDir()))
 		if err != nil {
 			// Create a basic status entry for the error
 			statuses = append(statuses, models.SymlinkStatus{
@@ -194,13 +212,13 @@ func (s *Service) ValidateCodexSymlinks(targetDir string) ([]models.SymlinkStatu
 	}
 
 	codexDir := filepath.Join(targetDir, config.CodexDir)
-	requiredSymlinks := config.GetCodexRequiredSymlinks()
+	requiredSymlinks := s.resolveTargets(targetDir, config.GetCodexRequiredSymlinks())
 	var statuses []models.SymlinkStatus
 
 	for symlinkPath, expectedTarget := range requiredSymlinks {
 		fullSymlinkPath := filepath.Join(codexDir, symlinkPath)
 
-		status, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget)
+		status, err := s.fsValidator.ValidateSymlink(fullSymlinkPath, expectedTarget, filepath.Join(targetDir, config.FrameworkDir()))
 		if err != nil {
 			// Create a basic status entry for the error
 			statuses = append(statuses, models.SymlinkStatus{
@@ -286,7 +304,7 @@ func (s *Service) RepairSymlinks(targetDir string) ([]string, error) {
 
 	var repairedSymlinks []string
 	claudeDir := filepath.Join(targetDir, config.ClaudeDir)
-	requiredSymlinks := config.GetRequiredSymlinks()
+	requiredSymlinks := s.resolveTargets(targetDir, config.GetRequiredSymlinks())
 
 	// Repair invalid symlinks
 	for _, status := range statuses {
@@ -380,9 +398,27 @@ func (s *Service) ensureCodexDirectoryStructure(codexDir string) error {
 	return nil
 }
 
-// createRelativeSymlink creates a single symlink with proper error handling
+// createRelativeSymlink creates a single symlink with proper error handling.
+// The symlink and what it points to must stay in the project directory.
 func (s *Service) createRelativeSymlink(claudeDir, symlinkPath, target string) error {
-	fullSymlinkPath := filepath.Join(claudeDir, symlinkPath)
+	fullSymlinkPath, err := s.pathValidator.SafeJoin(claudeDir, symlinkPath)
+	if err != nil {
+		return err
+	}
+	relTarget := target
+	if filepath.IsAbs(target) {
+		// Absolute targets are checked as the relative target they stand for
+		linkDir, err := filepath.Abs(filepath.Dir(fullSymlinkPath))
+		if err == nil {
+			relTarget, err = filepath.Rel(linkDir, target)
+		}
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeSymlinkCreationFailed, fullSymlinkPath, err)
+		}
+	}
+	if _, err := s.pathValidator.SafeJoin(filepath.Dir(claudeDir), filepath.Base(claudeDir), filepath.Dir(symlinkPath), relTarget); err != nil {
+		return err
+	}
 
 	// Ensure parent directory exists
 	parentDir := filepath.Dir(fullSymlinkPath)
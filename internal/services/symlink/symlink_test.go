@@ -81,6 +81,48 @@ func TestCreateSymlinks(t *testing.T) {
 	}
 }
 
+func TestCreateSymlinksAbsoluteStyle(t *testing.T) {
+	tempDir := t.TempDir()
+	strategicDir := filepath.Join(tempDir, config.StrategicClaudeBasicDir)
+	for _, subdir := range []string{config.AgentsDir, config.CommandsDir, config.HooksDir} {
+		if err := os.MkdirAll(filepath.Join(strategicDir, config.CoreDir, subdir), 0755); err != nil {
+			t.Fatalf("Failed to create subdir %s: %v", subdir, err)
+		}
+	}
+
+	service := New()
+	service.SetStyle(config.SymlinkStyleAbsolute)
+	if err := service.CreateSymlinks(tempDir); err != nil {
+		t.Fatalf("CreateSymlinks failed: %v", err)
+	}
+
+	for symlinkPath := range config.GetRequiredSymlinks() {
+		target, err := os.Readlink(filepath.Join(tempDir, config.ClaudeDir, symlinkPath))
+		if err != nil {
+			t.Fatalf("Failed to read symlink target for %s: %v", symlinkPath, err)
+		}
+		want := filepath.Join(strategicDir, config.CoreDir, filepath.Dir(symlinkPath))
+		if target != want {
+			t.Errorf("Symlink %s has wrong target: expected %s, got %s", symlinkPath, want, target)
+		}
+	}
+
+	// The recorded style is used without SetStyle
+	info := `{"symlink_style":"` + config.SymlinkStyleAbsolute + `"}`
+	if err := os.WriteFile(filepath.Join(strategicDir, config.TemplateInfoFile), []byte(info), 0644); err != nil {
+		t.Fatalf("Failed to write template info: %v", err)
+	}
+	statuses, err := New().ValidateSymlinks(tempDir)
+	if err != nil {
+		t.Fatalf("ValidateSymlinks failed: %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Valid || !filepath.IsAbs(status.Expected) {
+			t.Errorf("Symlink %s: valid = %v, expected target %s, want a valid absolute target", status.Name, status.Valid, status.Expected)
+		}
+	}
+}
+
 func TestCreateSymlinksEmptyTargetDir(t *testing.T) {
 	service := New()
 
@@ -0,0 +1,351 @@
+package templatelint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+)
+
+// Severity of a lint issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // Breaks installations of the template
+	SeverityWarning Severity = "warning" // Installs, but likely not as intended
+)
+
+// Issue is a problem found in a template, with how to fix it
+type Issue struct {
+	Severity Severity
+	Path     string // Relative to the template root
+	Message  string
+	Fix      string
+}
+
+// Report lists the issues found in a template
+type Report struct {
+	Source string // Directory or repository URL that was linted
+	Issues []Issue
+}
+
+// Errors returns the number of issues with SeverityError
+func (r *Report) Errors() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Warnings returns the number of issues with SeverityWarning
+func (r *Report) Warnings() int {
+	return len(r.Issues) - r.Errors()
+}
+
+func (r *Report) add(severity Severity, path, message, fix string) {
+	r.Issues = append(r.Issues, Issue{
+		Severity: severity,
+		Path:     filepath.ToSlash(path),
+		Message:  message,
+		Fix:      fix,
+	})
+}
+
+// Service checks template repositories for problems before they are pinned
+type Service struct {
+	gitService *git.Service
+}
+
+// New creates a new template lint service instance
+func New() *Service {
+	return &Service{
+		gitService: git.New(),
+	}
+}
+
+// SetAuthToken sets the HTTPS token used to clone private template repositories
+func (s *Service) SetAuthToken(token string) {
+	s.gitService.SetAuthToken(token)
+}
+
+// IsRepositoryURL reports whether source names a git repository rather than a local directory
+func IsRepositoryURL(source string) bool {
+	return strings.Contains(source, "://") || git.IsSSHURL(source)
+}
+
+// LintRepository clones branch of the repository at url (the default branch
+// when empty), checks out commit when given, and lints the clone
+func (s *Service) LintRepository(url, branch, commit string) (*Report, error) {
+	tempDir, err := s.gitService.CloneRepositoryWithBranch(url, branch, commit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = s.gitService.CleanupTempDir(tempDir) // Best effort cleanup
+	}()
+
+	report, err := s.Lint(tempDir)
+	if err != nil {
+		return nil, err
+	}
+	report.Source = url
+	return report, nil
+}
+
+// Lint checks the template repository checked out in dir: the framework
+// directories, the targets of the symlinks created on install, symlinks within
+// the template, the settings and gitignore templates, and install and hook
+// script permissions
+func (s *Service) Lint(dir string) (*Report, error) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, models.NewValidationError("path", dir, "must be a directory containing a template repository")
+	}
+
+	report := &Report{Source: dir}
+
+	frameworkDir := filepath.Join(dir, config.StrategicClaudeBasicDir)
+	if info, err := os.Stat(frameworkDir); err != nil || !info.IsDir() {
+		report.add(SeverityError, config.StrategicClaudeBasicDir, "framework directory is missing",
+			fmt.Sprintf("Put the framework content in %s/ at the repository root", config.StrategicClaudeBasicDir))
+		return report, nil
+	}
+
+	checkDirectories(report, dir)
+	checkSymlinkTargets(report, dir)
+	if err := checkTemplateSymlinks(report, dir); err != nil {
+		return nil, err
+	}
+	checkSettingsTemplate(report, dir)
+	checkIgnoreTemplates(report, dir)
+	if err := checkScripts(report, dir); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// checkDirectories reports missing framework and user directories
+func checkDirectories(report *Report, dir string) {
+	for _, name := range config.GetFrameworkDirectories() {
+		relPath := filepath.Join(config.StrategicClaudeBasicDir, name)
+		if !isDir(filepath.Join(dir, relPath)) {
+			report.add(SeverityError, relPath, "required framework directory is missing",
+				fmt.Sprintf("Add %s/; it is copied on install and replaced on core updates", filepath.ToSlash(relPath)))
+		}
+	}
+
+	for _, name := range config.GetUserPreservedDirectories() {
+		relPath := filepath.Join(config.StrategicClaudeBasicDir, name)
+		if !isDir(filepath.Join(dir, relPath)) {
+			report.add(SeverityWarning, relPath, "user directory is missing",
+				fmt.Sprintf("Add %s/ with a %s or .gitkeep so new installations get it", filepath.ToSlash(relPath), config.ClaudeConfigFile))
+		}
+	}
+}
+
+// checkSymlinkTargets reports core directories that the .claude and .codex
+// symlinks created on install would point to but that the template lacks
+func checkSymlinkTargets(report *Report, dir string) {
+	manifests := []struct {
+		root     string
+		symlinks map[string]string
+	}{
+		{config.ClaudeDir, config.GetRequiredSymlinks()},
+		{config.CodexDir, config.GetCodexRequiredSymlinks()},
+	}
+
+	var reported []string
+	for _, manifest := range manifests {
+		for symlinkPath, target := range manifest.symlinks {
+			linkDir := filepath.Join(manifest.root, filepath.Dir(symlinkPath))
+			targetPath, err := filepath.Rel(dir, filepath.Join(dir, linkDir, target))
+			if err != nil || slices.Contains(reported, targetPath) {
+				continue
+			}
+			if isDir(filepath.Join(dir, targetPath)) {
+				continue
+			}
+
+			reported = append(reported, targetPath)
+			report.add(SeverityError, targetPath,
+				fmt.Sprintf("symlink %s would point to a missing directory", filepath.ToSlash(filepath.Join(manifest.root, symlinkPath))),
+				fmt.Sprintf("Add %s/ (with a .gitkeep if it has no other files)", filepath.ToSlash(targetPath)))
+		}
+	}
+}
+
+// checkTemplateSymlinks reports symlinks in the framework directory that are
+// absolute, leave the template or point nowhere
+func checkTemplateSymlinks(report *Report, dir string) error {
+	frameworkDir := filepath.Join(dir, config.StrategicClaudeBasicDir)
+
+	err := filepath.WalkDir(frameworkDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+
+		resolved := filepath.Join(filepath.Dir(path), target)
+		relTarget, relErr := filepath.Rel(dir, resolved)
+		switch {
+		case filepath.IsAbs(target):
+			report.add(SeverityError, relPath, fmt.Sprintf("symlink points to absolute path %s", target),
+				"Use a relative target, absolute paths do not exist on other machines")
+		case relErr != nil || relTarget == ".." || strings.HasPrefix(relTarget, ".."+string(filepath.Separator)):
+			report.add(SeverityError, relPath, fmt.Sprintf("symlink points outside the template to %s", target),
+				"Point it to a file within the repository or replace it with a copy")
+		default:
+			if _, err := os.Stat(resolved); err != nil {
+				report.add(SeverityError, relPath, fmt.Sprintf("symlink target %s does not exist", target),
+					"Fix the target or remove the symlink")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, frameworkDir, err)
+	}
+
+	return nil
+}
+
+// checkSettingsTemplate reports a missing or invalid settings template and
+// strategic hooks whose script is not in core/hooks
+func checkSettingsTemplate(report *Report, dir string) {
+	relPath := filepath.Join(config.StrategicClaudeBasicDir, config.SettingsTemplateFile)
+	data, err := os.ReadFile(filepath.Join(dir, relPath))
+	if err != nil {
+		report.add(SeverityWarning, relPath, "settings template is missing, installations will not register hooks",
+			"Add it if the template ships hooks")
+		return
+	}
+
+	var settings models.ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		report.add(SeverityError, relPath, fmt.Sprintf("settings template is not valid: %s", describeJSONError(data, err)),
+			"Fix the JSON; it is merged into .claude/settings.json on every install")
+		return
+	}
+	if settings.Hooks == nil {
+		return
+	}
+
+	hooksDir := filepath.Join(config.StrategicClaudeBasicDir, config.CoreDir, config.HooksDir)
+	for _, hookType := range models.GetHookTypesInOrder() {
+		for _, matcher := range settings.Hooks.Matchers(hookType) {
+			for _, hook := range matcher.Hooks {
+				scriptName, ok := models.StrategicHookScript(hook.Command)
+				if !ok {
+					continue
+				}
+				if _, err := os.Stat(filepath.Join(dir, hooksDir, scriptName)); err != nil {
+					report.add(SeverityError, relPath,
+						fmt.Sprintf("%s hook runs %s, which is not in %s", hookType, scriptName, filepath.ToSlash(hooksDir)),
+						fmt.Sprintf("Add %s/%s or remove the hook", filepath.ToSlash(hooksDir), scriptName))
+				}
+			}
+		}
+	}
+}
+
+// describeJSONError adds the line number to JSON syntax errors
+func describeJSONError(data []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := 1 + strings.Count(string(data[:syntaxErr.Offset]), "\n")
+		return fmt.Sprintf("line %d: %v", line, err)
+	}
+	return err.Error()
+}
+
+// checkIgnoreTemplates reports missing gitignore templates
+func checkIgnoreTemplates(report *Report, dir string) {
+	for _, name := range config.GetIgnoreTemplates() {
+		relPath := filepath.Join(config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir, name)
+		if _, err := os.Stat(filepath.Join(dir, relPath)); err != nil {
+			report.add(SeverityError, relPath, "gitignore template is missing, --gitignore-mode will skip it",
+				"Add the file, even if it is empty")
+		}
+	}
+}
+
+// checkScripts reports install scripts and hook scripts without execute permission
+func checkScripts(report *Report, dir string) error {
+	// Windows has no execute bits to check
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	for _, name := range []string{config.PreInstallScript, config.PostInstallScript} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if info.Mode().Perm()&0111 == 0 {
+			report.add(SeverityError, name, "install script is not executable",
+				fmt.Sprintf("Run 'chmod +x %s' and 'git update-index --chmod=+x %s'", name, name))
+		}
+	}
+
+	hooksDir := filepath.Join(dir, config.StrategicClaudeBasicDir, config.CoreDir, config.HooksDir)
+	err := filepath.WalkDir(hooksDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == hooksDir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() || !slices.Contains(config.GetHookScriptExtensions(), filepath.Ext(path)) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode().Perm()&0111 == 0 {
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			slashPath := filepath.ToSlash(relPath)
+			report.add(SeverityWarning, relPath, "hook script is not executable",
+				fmt.Sprintf("Run 'chmod +x %s' and 'git update-index --chmod=+x %s'", slashPath, slashPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, hooksDir, err)
+	}
+
+	return nil
+}
+
+// isDir reports whether path is a directory, following symlinks
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
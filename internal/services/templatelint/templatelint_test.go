@@ -0,0 +1,273 @@
+package templatelint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+const validSettings = `{
+  "hooks": {
+    "Stop": [
+      {"matcher": "", "hooks": [{"type": "command", "command": "python3 .strategic-claude-basic/core/hooks/stop-session-notify.py"}]}
+    ]
+  }
+}`
+
+// createTemplate creates a template repository that passes every check
+func createTemplate(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"core/agents/helper.md":     "agent",
+		"core/commands/plan.md":     "command",
+		"guides/README.md":          "guide",
+		config.SettingsTemplateFile: validSettings,
+	}
+	for _, name := range config.GetUserPreservedDirectories() {
+		files[name+"/"+config.ClaudeConfigFile] = "guide"
+	}
+	for _, name := range config.GetIgnoreTemplates() {
+		files[config.IgnoreTemplatesDir+"/"+name] = "*\n"
+	}
+	for relPath, content := range files {
+		writeFile(t, dir, config.StrategicClaudeBasicDir+"/"+relPath, content, 0644)
+	}
+	writeFile(t, dir, config.StrategicClaudeBasicDir+"/core/hooks/stop-session-notify.py", "print()\n", 0755)
+	writeFile(t, dir, config.PostInstallScript, "#!/bin/bash\n", 0755)
+
+	return dir
+}
+
+// writeFile writes content to a path relative to dir
+func writeFile(t *testing.T, dir, relPath, content string, perm os.FileMode) {
+	t.Helper()
+
+	fullPath := filepath.Join(dir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(fullPath, perm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Lint_Valid(t *testing.T) {
+	report, err := New().Lint(createTemplate(t))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Lint() issues = %+v, want none", report.Issues)
+	}
+}
+
+func TestService_Lint_Issues(t *testing.T) {
+	framework := config.StrategicClaudeBasicDir + "/"
+
+	tests := []struct {
+		name     string
+		unix     bool // Relies on execute bits or symlinks
+		modify   func(t *testing.T, dir string)
+		severity Severity
+		path     string
+		contains string
+	}{
+		{
+			name:     "missing framework directory",
+			modify:   func(t *testing.T, dir string) { os.RemoveAll(filepath.Join(dir, framework, config.GuidesDir)) },
+			severity: SeverityError,
+			path:     framework + config.GuidesDir,
+			contains: "framework directory is missing",
+		},
+		{
+			name:     "missing user directory",
+			modify:   func(t *testing.T, dir string) { os.RemoveAll(filepath.Join(dir, framework, config.PlanDir)) },
+			severity: SeverityWarning,
+			path:     framework + config.PlanDir,
+			contains: "user directory is missing",
+		},
+		{
+			name:     "missing symlink target",
+			modify:   func(t *testing.T, dir string) { os.RemoveAll(filepath.Join(dir, framework, "core/agents")) },
+			severity: SeverityError,
+			path:     framework + "core/agents",
+			contains: ".claude/agents/strategic",
+		},
+		{
+			name: "invalid settings template",
+			modify: func(t *testing.T, dir string) {
+				writeFile(t, dir, framework+config.SettingsTemplateFile, "{\n  \"hooks\": {,\n}", 0644)
+			},
+			severity: SeverityError,
+			path:     framework + config.SettingsTemplateFile,
+			contains: "line 2",
+		},
+		{
+			name: "hook script not shipped",
+			modify: func(t *testing.T, dir string) {
+				os.Remove(filepath.Join(dir, framework, "core/hooks/stop-session-notify.py"))
+			},
+			severity: SeverityError,
+			path:     framework + config.SettingsTemplateFile,
+			contains: "stop-session-notify.py",
+		},
+		{
+			name: "missing settings template",
+			modify: func(t *testing.T, dir string) {
+				os.Remove(filepath.Join(dir, framework, config.SettingsTemplateFile))
+			},
+			severity: SeverityWarning,
+			path:     framework + config.SettingsTemplateFile,
+			contains: "settings template is missing",
+		},
+		{
+			name: "missing ignore template",
+			modify: func(t *testing.T, dir string) {
+				os.Remove(filepath.Join(dir, framework, config.IgnoreTemplatesDir, config.ClaudeIgnoreTemplate))
+			},
+			severity: SeverityError,
+			path:     framework + config.IgnoreTemplatesDir + "/" + config.ClaudeIgnoreTemplate,
+			contains: "gitignore template is missing",
+		},
+		{
+			name:     "install script not executable",
+			unix:     true,
+			modify:   func(t *testing.T, dir string) { writeFile(t, dir, config.PostInstallScript, "#!/bin/bash\n", 0644) },
+			severity: SeverityError,
+			path:     config.PostInstallScript,
+			contains: "not executable",
+		},
+		{
+			name: "hook script not executable",
+			unix: true,
+			modify: func(t *testing.T, dir string) {
+				writeFile(t, dir, framework+"core/hooks/stop-session-notify.py", "print()\n", 0644)
+			},
+			severity: SeverityWarning,
+			path:     framework + "core/hooks/stop-session-notify.py",
+			contains: "not executable",
+		},
+		{
+			name: "symlink outside the template",
+			unix: true,
+			modify: func(t *testing.T, dir string) {
+				if err := os.Symlink("../../../elsewhere", filepath.Join(dir, framework, "guides/link.md")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			severity: SeverityError,
+			path:     framework + "guides/link.md",
+			contains: "outside the template",
+		},
+		{
+			name: "dangling symlink",
+			unix: true,
+			modify: func(t *testing.T, dir string) {
+				if err := os.Symlink("missing.md", filepath.Join(dir, framework, "guides/link.md")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			severity: SeverityError,
+			path:     framework + "guides/link.md",
+			contains: "does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.unix && runtime.GOOS == "windows" {
+				t.Skip("Execute bits and symlinks are not checked on Windows")
+			}
+
+			dir := createTemplate(t)
+			tt.modify(t, dir)
+
+			report, err := New().Lint(dir)
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if len(report.Issues) != 1 {
+				t.Fatalf("Lint() issues = %+v, want exactly one", report.Issues)
+			}
+
+			issue := report.Issues[0]
+			if issue.Severity != tt.severity || issue.Path != tt.path || !strings.Contains(issue.Message, tt.contains) {
+				t.Errorf("Lint() issue = %+v, want %s at %s containing %q", issue, tt.severity, tt.path, tt.contains)
+			}
+			if issue.Fix == "" {
+				t.Error("Lint() issue has no fix")
+			}
+		})
+	}
+}
+
+func TestService_Lint_NoFramework(t *testing.T) {
+	report, err := New().Lint(t.TempDir())
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.Errors() != 1 || report.Issues[0].Path != config.StrategicClaudeBasicDir {
+		t.Errorf("Lint() issues = %+v, want only the missing framework directory", report.Issues)
+	}
+}
+
+func TestService_LintRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not available, skipping repository lint test")
+	}
+
+	dir := createTemplate(t)
+	os.Remove(filepath.Join(dir, config.PostInstallScript))
+	os.Remove(filepath.Join(dir, config.StrategicClaudeBasicDir, config.IgnoreTemplatesDir, config.ClaudeIgnoreTemplate))
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"commit", "-q", "-m", "template"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	url := "file://" + filepath.ToSlash(dir)
+	report, err := New().LintRepository(url, "", "")
+	if err != nil {
+		t.Fatalf("LintRepository() error = %v", err)
+	}
+	if report.Source != url {
+		t.Errorf("LintRepository() source = %s, want %s", report.Source, url)
+	}
+	if report.Errors() != 1 || !strings.Contains(report.Issues[0].Path, config.ClaudeIgnoreTemplate) {
+		t.Errorf("LintRepository() issues = %+v, want only the missing ignore template", report.Issues)
+	}
+}
+
+func TestIsRepositoryURL(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{"https://github.com/me/template.git", true},
+		{"git@github.com:me/template.git", true},
+		{"file:///tmp/template", true},
+		{"./template", false},
+		{"/home/me/template", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRepositoryURL(tt.source); got != tt.want {
+			t.Errorf("IsRepositoryURL(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package toolconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/catalog"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// aiderBlock marks the lines managed in .aider.conf.yml
+var aiderBlock = filesystem.HashCommentBlock
+
+// openCodeSchema is written to opencode.json files created by the CLI
+const openCodeSchema = "https://opencode.ai/config.json"
+
+// aiderReadKey matches a top-level read option in .aider.conf.yml
+var aiderReadKey = regexp.MustCompile(`(?m)^read\s*:`)
+
+// defaultConventions introduces the framework when a template ships no conventions file
+const defaultConventions = `# Strategic Claude Basic
+
+This project uses the Strategic Claude Basic framework. Its documents live in
+` + "`.strategic-claude-basic/`" + `: plans in ` + "`plan/`" + `, research in ` + "`research/`" + `, decisions in
+` + "`decisions/`" + `, issues in ` + "`issues/`" + ` and summaries of completed work in ` + "`summary/`" + `.
+Guides for working with the framework are in ` + "`.strategic-claude-basic/guides/`" + `.
+`
+
+// Service shares the framework conventions with AI coding tools that read
+// instructions from their own configuration: Aider and OpenCode
+type Service struct {
+	catalogService    *catalog.Service
+	filesystemService *filesystem.Service
+}
+
+// New creates a new tool config service instance
+func New() *Service {
+	return &Service{
+		catalogService:    catalog.New(),
+		filesystemService: filesystem.New(),
+	}
+}
+
+// ConventionsPath returns the path of the generated conventions file in a project
+func ConventionsPath(targetDir string) string {
+	return filepath.Join(targetDir, config.FrameworkDir(), config.ConventionsFile)
+}
+
+// conventionsRef returns the conventions file as referenced from tool configurations
+func conventionsRef() string {
+	return config.FrameworkDir() + "/" + config.ConventionsFile
+}
+
+// GenerateConventions writes .strategic-claude-basic/.conventions.md: the
+// template's conventions file followed by hints for the framework commands,
+// which tools without Claude Code commands run by reading the command file
+func (s *Service) GenerateConventions(targetDir string) error {
+	templatePath := filepath.Join(targetDir, config.FrameworkDir(), filepath.FromSlash(config.ConventionsTemplateFile))
+	conventions, err := os.ReadFile(templatePath)
+	if os.IsNotExist(err) {
+		conventions = []byte(defaultConventions)
+	} else if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, templatePath, err)
+	}
+
+	commands, err := s.catalogService.List(targetDir, catalog.KindCommands)
+	if err != nil {
+		return err
+	}
+
+	var content strings.Builder
+	content.WriteString("<!-- Generated by strategic-claude-basic-cli on install; edit " + config.ConventionsTemplateFile + " in the template instead -->\n\n")
+	content.Write(bytes.TrimRight(conventions, "\n"))
+	content.WriteString("\n")
+
+	var hints []string
+	for _, command := range commands {
+		if command.Source != catalog.SourceFramework || command.Disabled {
+			continue
+		}
+		path := command.Path
+		if command.Override != "" {
+			path = command.Override
+		}
+		hint := fmt.Sprintf("- /%s: `%s`", command.Name, path)
+		if command.Description != "" {
+			hint = fmt.Sprintf("- /%s: %s (`%s`)", command.Name, command.Description, path)
+		}
+		hints = append(hints, hint)
+	}
+	if len(hints) > 0 {
+		content.WriteString("\n## Framework commands\n\n")
+		content.WriteString("When asked to run one of these commands, read its file and follow the instructions in it,\n")
+		content.WriteString("with any text after the command name as its arguments.\n\n")
+		content.WriteString(strings.Join(hints, "\n"))
+		content.WriteString("\n")
+	}
+
+	path := ConventionsPath(targetDir)
+	if err := utils.WriteFileAtomic(path, []byte(content.String()), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// InstallAider adds the managed block reading the conventions file to
+// .aider.conf.yml, creating the file if needed. Returns false without changes
+// when the user's configuration already sets read, since a second read key
+// would replace theirs.
+func (s *Service) InstallAider(targetDir string) (bool, error) {
+	configPath := filepath.Join(targetDir, config.AiderConfigFile)
+	existing, err := readIfExists(configPath)
+	if err != nil {
+		return false, err
+	}
+
+	userContent, _, _, err := filesystem.SplitManagedBlock(string(existing), aiderBlock)
+	if err != nil {
+		return false, models.NewFileSystemError(models.ErrorCodeManagedBlockConflict, configPath, err)
+	}
+	if aiderReadKey.MatchString(userContent) {
+		return false, nil
+	}
+
+	if _, err := s.filesystemService.ApplyManagedBlock(configPath, aiderBlock, "read:\n  - "+conventionsRef()+"\n"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AiderReadConflict reports whether .aider.conf.yml sets read outside the
+// managed block, which keeps InstallAider from adding the conventions
+func (s *Service) AiderReadConflict(targetDir string) bool {
+	existing, err := readIfExists(filepath.Join(targetDir, config.AiderConfigFile))
+	if err != nil {
+		return false
+	}
+	userContent, _, _, err := filesystem.SplitManagedBlock(string(existing), aiderBlock)
+	return err == nil && aiderReadKey.MatchString(userContent)
+}
+
+// HasAider reports whether .aider.conf.yml contains the managed block
+func (s *Service) HasAider(targetDir string) bool {
+	_, found, _ := s.filesystemService.ReadManagedBlock(filepath.Join(targetDir, config.AiderConfigFile), aiderBlock)
+	return found
+}
+
+// RemoveAider removes the managed block from .aider.conf.yml, and the file
+// itself when nothing else is left. Returns false when there was no block.
+func (s *Service) RemoveAider(targetDir string) (bool, error) {
+	return s.filesystemService.RemoveManagedBlock(filepath.Join(targetDir, config.AiderConfigFile), aiderBlock)
+}
+
+// InstallOpenCode adds the conventions file to the instructions in
+// opencode.json, creating the file if needed. Other settings are kept.
+func (s *Service) InstallOpenCode(targetDir string) error {
+	configPath := filepath.Join(targetDir, config.OpenCodeConfigFile)
+	settings, err := loadOpenCodeConfig(configPath)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		settings = map[string]any{"$schema": openCodeSchema}
+	}
+
+	instructions := openCodeInstructions(settings)
+	if slices.Contains(instructions, conventionsRef()) {
+		return nil
+	}
+	settings["instructions"] = append(instructions, conventionsRef())
+
+	return writeOpenCodeConfig(configPath, settings)
+}
+
+// HasOpenCode reports whether opencode.json lists the conventions file in its instructions
+func (s *Service) HasOpenCode(targetDir string) bool {
+	settings, err := loadOpenCodeConfig(filepath.Join(targetDir, config.OpenCodeConfigFile))
+	if err != nil || settings == nil {
+		return false
+	}
+	return slices.Contains(openCodeInstructions(settings), conventionsRef())
+}
+
+// RemoveOpenCode removes the conventions file from the instructions in
+// opencode.json, and the file itself when only the schema is left. Returns
+// false when it was not listed.
+func (s *Service) RemoveOpenCode(targetDir string) (bool, error) {
+	configPath := filepath.Join(targetDir, config.OpenCodeConfigFile)
+	settings, err := loadOpenCodeConfig(configPath)
+	if err != nil || settings == nil {
+		return false, err
+	}
+
+	instructions := openCodeInstructions(settings)
+	index := slices.Index(instructions, conventionsRef())
+	if index < 0 {
+		return false, nil
+	}
+	instructions = slices.Delete(instructions, index, index+1)
+	if len(instructions) == 0 {
+		delete(settings, "instructions")
+	} else {
+		settings["instructions"] = instructions
+	}
+
+	if _, hasSchema := settings["$schema"]; len(settings) == 0 || (len(settings) == 1 && hasSchema) {
+		if err := os.Remove(configPath); err != nil {
+			return false, models.NewFileSystemError(models.ErrorCodeFileSystemError, configPath, err)
+		}
+		return true, nil
+	}
+	return true, writeOpenCodeConfig(configPath, settings)
+}
+
+// loadOpenCodeConfig parses opencode.json, returning nil when it does not exist
+func loadOpenCodeConfig(configPath string) (map[string]any, error) {
+	data, err := readIfExists(configPath)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, models.NewAppError(
+			models.ErrorCodeValidationFailed,
+			fmt.Sprintf("failed to parse %s", configPath),
+			err,
+		)
+	}
+	if settings == nil {
+		settings = map[string]any{}
+	}
+	return settings, nil
+}
+
+// openCodeInstructions returns the instruction files listed in an OpenCode configuration
+func openCodeInstructions(settings map[string]any) []string {
+	values, _ := settings["instructions"].([]any)
+	instructions := make([]string, 0, len(values))
+	for _, value := range values {
+		if instruction, ok := value.(string); ok {
+			instructions = append(instructions, instruction)
+		}
+	}
+	return instructions
+}
+
+// writeOpenCodeConfig writes an OpenCode configuration as indented JSON
+func writeOpenCodeConfig(configPath string, settings map[string]any) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", config.OpenCodeConfigFile, err)
+	}
+	data = append(data, '\n')
+
+	if err := utils.WriteFileAtomic(configPath, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, configPath, err)
+	}
+	return nil
+}
+
+// readIfExists reads a file, returning nil when it does not exist
+func readIfExists(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return data, nil
+}
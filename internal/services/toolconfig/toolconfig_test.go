@@ -0,0 +1,200 @@
+package toolconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+// setupFramework creates an installed framework with one command and a conventions file
+func setupFramework(t *testing.T) string {
+	t.Helper()
+
+	targetDir := t.TempDir()
+	strategicDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+	files := map[string]string{
+		filepath.Join(config.CoreDir, config.CommandsDir, "create_plan.md"): "---\ndescription: Create a plan\n---\nPlan.\n",
+		filepath.FromSlash(config.ConventionsTemplateFile):                  "# Team conventions\n\nWrite plans first.\n",
+	}
+	for relPath, content := range files {
+		path := filepath.Join(strategicDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", relPath, err)
+		}
+	}
+	return targetDir
+}
+
+func TestService_GenerateConventions(t *testing.T) {
+	targetDir := setupFramework(t)
+
+	if err := New().GenerateConventions(targetDir); err != nil {
+		t.Fatalf("GenerateConventions() error = %v", err)
+	}
+
+	content, err := os.ReadFile(ConventionsPath(targetDir))
+	if err != nil {
+		t.Fatalf("Failed to read conventions: %v", err)
+	}
+	for _, want := range []string{
+		"Write plans first.",
+		"/create_plan: Create a plan (`.strategic-claude-basic/core/commands/create_plan.md`)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("GenerateConventions() content = %q, want it to contain %q", content, want)
+		}
+	}
+}
+
+func TestService_Aider(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      string
+		wantInstalled bool
+		wantRemaining string // Content after RemoveAider, "" when the file is removed
+	}{
+		{
+			name:          "new config",
+			wantInstalled: true,
+		},
+		{
+			name:          "user settings are kept",
+			existing:      "model: sonnet\nauto-commits: false\n",
+			wantInstalled: true,
+			wantRemaining: "model: sonnet\nauto-commits: false\n",
+		},
+		{
+			name:          "user read option is not replaced",
+			existing:      "read:\n  - NOTES.md\n",
+			wantRemaining: "read:\n  - NOTES.md\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New()
+			targetDir := t.TempDir()
+			configPath := filepath.Join(targetDir, config.AiderConfigFile)
+			if tt.existing != "" {
+				if err := os.WriteFile(configPath, []byte(tt.existing), 0644); err != nil {
+					t.Fatalf("Failed to write config: %v", err)
+				}
+			}
+
+			// Installing twice keeps a single block
+			for i := 0; i < 2; i++ {
+				installed, err := service.InstallAider(targetDir)
+				if err != nil {
+					t.Fatalf("InstallAider() error = %v", err)
+				}
+				if installed != tt.wantInstalled {
+					t.Fatalf("InstallAider() = %v, want %v", installed, tt.wantInstalled)
+				}
+			}
+			content, _ := os.ReadFile(configPath)
+			if got := strings.Count(string(content), aiderBlock.Start); got != boolToInt(tt.wantInstalled) {
+				t.Errorf("config has %d managed blocks, want %d:\n%s", got, boolToInt(tt.wantInstalled), content)
+			}
+			if service.HasAider(targetDir) != tt.wantInstalled {
+				t.Errorf("HasAider() = %v, want %v", service.HasAider(targetDir), tt.wantInstalled)
+			}
+			if service.AiderReadConflict(targetDir) == tt.wantInstalled {
+				t.Errorf("AiderReadConflict() = %v, want %v", service.AiderReadConflict(targetDir), !tt.wantInstalled)
+			}
+
+			removed, err := service.RemoveAider(targetDir)
+			if err != nil {
+				t.Fatalf("RemoveAider() error = %v", err)
+			}
+			if removed != tt.wantInstalled {
+				t.Errorf("RemoveAider() = %v, want %v", removed, tt.wantInstalled)
+			}
+
+			content, err = os.ReadFile(configPath)
+			if tt.wantRemaining == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("config should be removed, err = %v", err)
+				}
+				return
+			}
+			if string(content) != tt.wantRemaining {
+				t.Errorf("config after RemoveAider() = %q, want %q", content, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestService_OpenCode(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+	configPath := filepath.Join(targetDir, config.OpenCodeConfigFile)
+	existing := `{"model": "anthropic/claude-sonnet-4", "instructions": ["docs/style.md"]}`
+	if err := os.WriteFile(configPath, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := service.InstallOpenCode(targetDir); err != nil {
+			t.Fatalf("InstallOpenCode() error = %v", err)
+		}
+	}
+	settings := readJSON(t, configPath)
+	if got := settings["instructions"]; len(got.([]any)) != 2 || settings["model"] == nil {
+		t.Errorf("opencode.json = %v, want user settings and two instructions", settings)
+	}
+	if !service.HasOpenCode(targetDir) {
+		t.Error("HasOpenCode() = false, want true")
+	}
+
+	removed, err := service.RemoveOpenCode(targetDir)
+	if err != nil || !removed {
+		t.Fatalf("RemoveOpenCode() = %v, %v, want true", removed, err)
+	}
+	settings = readJSON(t, configPath)
+	if got := settings["instructions"].([]any); len(got) != 1 || got[0] != "docs/style.md" {
+		t.Errorf("instructions after RemoveOpenCode() = %v, want [docs/style.md]", got)
+	}
+}
+
+func TestService_OpenCode_CreatedFileIsRemoved(t *testing.T) {
+	service := New()
+	targetDir := t.TempDir()
+
+	if err := service.InstallOpenCode(targetDir); err != nil {
+		t.Fatalf("InstallOpenCode() error = %v", err)
+	}
+	if _, err := service.RemoveOpenCode(targetDir); err != nil {
+		t.Fatalf("RemoveOpenCode() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, config.OpenCodeConfigFile)); !os.IsNotExist(err) {
+		t.Errorf("opencode.json should be removed, err = %v", err)
+	}
+}
+
+func readJSON(t *testing.T, path string) map[string]any {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Failed to parse %s: %v", path, err)
+	}
+	return settings
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+// Package trash moves files and directories out of the way instead of deleting
+// them, so destructive commands run with --trash can be undone.
+package trash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+)
+
+// Service moves paths to the trash of the OS, or to a graveyard directory
+type Service struct {
+	dir               string // Graveyard directory, empty for the trash of the OS
+	filesystemService *filesystem.Service
+	now               func() time.Time
+}
+
+// New creates a trash service using the configured trash directory
+func New() *Service {
+	// Trashed copies stand in for the originals, links included
+	filesystemService := filesystem.New()
+	_ = filesystemService.SetSymlinkPolicy(filesystem.SymlinkKeep)
+	return &Service{
+		dir:               config.Current().TrashDir,
+		filesystemService: filesystemService,
+		now:               time.Now,
+	}
+}
+
+// SetDir sets the graveyard directory; empty uses the trash of the OS
+func (s *Service) SetDir(dir string) {
+	s.dir = dir
+}
+
+// Location describes where Move puts paths
+func (s *Service) Location() string {
+	if s.dir != "" {
+		return s.dir
+	}
+	return osTrashName
+}
+
+// Move moves path to the trash and returns its new location
+func (s *Service) Move(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeInvalidPath, path, err)
+	}
+	if _, err := os.Lstat(absPath); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, absPath, err)
+	}
+
+	if s.dir == "" {
+		return s.moveToOSTrash(absPath)
+	}
+	return s.moveToGraveyard(absPath)
+}
+
+// moveToGraveyard moves path into the graveyard under its name and the time of removal
+func (s *Service) moveToGraveyard(path string) (string, error) {
+	if err := os.MkdirAll(s.dir, config.DirPermissions); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, s.dir, err)
+	}
+	dest := filepath.Join(s.dir, uniqueName(s.dir, filepath.Base(path)+"-"+s.now().Format(config.BackupTimestampLayout)))
+	if err := s.moveTo(path, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// moveTo renames path to dest, copying it when they are on different file systems
+func (s *Service) moveTo(path, dest string) error {
+	if err := os.Rename(path, dest); err == nil {
+		return nil
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+		}
+		if err := os.Symlink(target, dest); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, dest, err)
+		}
+	case info.IsDir():
+		err = s.filesystemService.CopyDirectory(path, dest)
+	default:
+		err = s.filesystemService.CopyFile(path, dest)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dest)
+		return fmt.Errorf("failed to move %s to the trash: %w", path, err)
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+	return nil
+}
+
+// uniqueName returns name, or name with a counter, whichever is free in dir
+func uniqueName(dir, name string) string {
+	candidate := name
+	for counter := 2; ; counter++ {
+		if _, err := os.Lstat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d", name, counter)
+	}
+}
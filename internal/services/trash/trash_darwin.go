@@ -0,0 +1,30 @@
+//go:build darwin
+
+package trash
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+const osTrashName = "the Trash"
+
+// moveToOSTrash moves path to ~/.Trash, where Finder shows it
+func (s *Service) moveToOSTrash(path string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", models.NewAppError(models.ErrorCodeFileSystemError, "Failed to find the Trash", err)
+	}
+	trashDir := filepath.Join(home, ".Trash")
+	if err := os.MkdirAll(trashDir, 0700); err != nil {
+		return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, trashDir, err)
+	}
+
+	dest := filepath.Join(trashDir, uniqueName(trashDir, filepath.Base(path)))
+	if err := s.moveTo(path, dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
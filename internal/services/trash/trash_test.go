@@ -0,0 +1,82 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestService_Move_Graveyard(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project", ".strategic-claude-basic")
+	if err := os.MkdirAll(filepath.Join(dir, "plan"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plan", "plan.md"), []byte("plan"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	graveyard := t.TempDir()
+	service := New()
+	service.SetDir(graveyard)
+
+	dest, err := service.Move(dir)
+	if err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if filepath.Dir(dest) != graveyard || !strings.HasPrefix(filepath.Base(dest), ".strategic-claude-basic-") {
+		t.Errorf("Move() = %s, want a timestamped name in %s", dest, graveyard)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("Move() left the original in place")
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "plan", "plan.md")); err != nil || string(data) != "plan" {
+		t.Errorf("moved content = %q, %v, want plan", data, err)
+	}
+
+	// A second removal within the same second gets a name of its own
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	second, err := service.Move(dir)
+	if err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if second == dest {
+		t.Errorf("Move() reused %s", dest)
+	}
+
+	if _, err := service.Move(dir); err == nil {
+		t.Error("Move() of a missing path returned no error")
+	}
+}
+
+func TestService_Move_OSTrash(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("uses the freedesktop.org trash")
+	}
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	file := filepath.Join(t.TempDir(), "settings backup.json")
+	if err := os.WriteFile(file, []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	dest, err := New().Move(file)
+	if err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if want := filepath.Join(dataHome, "Trash", "files", "settings backup.json"); dest != want {
+		t.Errorf("Move() = %s, want %s", dest, want)
+	}
+
+	info, err := os.ReadFile(filepath.Join(dataHome, "Trash", "info", "settings backup.json.trashinfo"))
+	if err != nil {
+		t.Fatalf("Failed to read trash info: %v", err)
+	}
+	if !strings.Contains(string(info), "Path="+filepath.ToSlash(filepath.Dir(file))+"/settings%20backup.json") {
+		t.Errorf("trash info = %q, want the escaped original path", info)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package trash
+
+import (
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/cache"
+)
+
+const osTrashName = "the trash folder in the cache directory"
+
+// moveToOSTrash moves path to a trash folder in the cache directory; the
+// Recycle Bin cannot be used without the shell API
+func (s *Service) moveToOSTrash(path string) (string, error) {
+	cacheDir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	graveyard := &Service{dir: filepath.Join(cacheDir, "trash"), filesystemService: s.filesystemService, now: s.now}
+	return graveyard.moveToGraveyard(path)
+}
@@ -0,0 +1,80 @@
+//go:build !darwin && !windows
+
+package trash
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+const osTrashName = "the trash"
+
+// moveToOSTrash follows the freedesktop.org trash specification: the path goes
+// to files/ and a .trashinfo file in info/ records where it came from, so file
+// managers can restore it
+func (s *Service) moveToOSTrash(path string) (string, error) {
+	trashDir, err := xdgTrashDir()
+	if err != nil {
+		return "", err
+	}
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	for _, dir := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+		}
+	}
+
+	// Creating the info file exclusively reserves the name
+	base := filepath.Base(path)
+	for counter := 1; ; counter++ {
+		name := base
+		if counter > 1 {
+			name = fmt.Sprintf("%s.%d", base, counter)
+		}
+		if _, err := os.Lstat(filepath.Join(filesDir, name)); err == nil {
+			continue
+		}
+
+		infoPath := filepath.Join(infoDir, name+".trashinfo")
+		info, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, infoPath, err)
+		}
+		_, err = fmt.Fprintf(info, "[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+			(&url.URL{Path: path}).EscapedPath(), s.now().Format("2006-01-02T15:04:05"))
+		if closeErr := info.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(infoPath)
+			return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, infoPath, err)
+		}
+
+		dest := filepath.Join(filesDir, name)
+		if err := s.moveTo(path, dest); err != nil {
+			os.Remove(infoPath)
+			return "", err
+		}
+		return dest, nil
+	}
+}
+
+// xdgTrashDir returns the trash of the user's home, $XDG_DATA_HOME/Trash
+func xdgTrashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" && filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", models.NewAppError(models.ErrorCodeFileSystemError, "Failed to find the trash directory", err)
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
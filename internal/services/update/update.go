@@ -0,0 +1,151 @@
+// Package update compares installations with the template commit this CLI
+// installs for them, and updates their core files to that commit.
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/installer"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/migrate"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// Service checks and updates installations
+type Service struct {
+	statusService    *status.Service
+	installerOptions []installer.Option
+	reporter         reporter.Reporter
+	ctx              context.Context
+}
+
+// New creates a new update service instance; opts configure the installer
+// that applies updates
+func New(opts ...installer.Option) *Service {
+	return &Service{
+		statusService:    status.NewService(),
+		installerOptions: opts,
+		reporter:         reporter.Default(),
+		ctx:              context.Background(),
+	}
+}
+
+// SetReporter sets where the progress of updates is reported
+func (s *Service) SetReporter(r reporter.Reporter) {
+	s.reporter = r
+}
+
+// SetContext makes updates stop when ctx is done
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Check compares the installation in targetDir with the commit an update would
+// install: the commit a vendored copy pins, else the one this CLI pins for the
+// installed template
+func (s *Service) Check(targetDir string) (*models.UpdateStatus, error) {
+	statusInfo, err := s.statusService.CheckInstallation(targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check installation status: %w", err)
+	}
+	installed := statusInfo.InstalledTemplate
+	if !statusInfo.StrategicClaudeDir || installed == nil {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			nil,
+		)
+	}
+
+	result := &models.UpdateStatus{
+		TargetDir:       targetDir,
+		Template:        installed.Template.ID,
+		Source:          models.TemplateSourceRepository,
+		InstalledCommit: installed.InstalledCommit,
+	}
+	if result.InstalledCommit == "" {
+		result.InstalledCommit = installed.Template.Commit
+	}
+
+	switch {
+	case statusInfo.DevTemplatePath != "":
+		result.Source = models.TemplateSourceDev
+		result.Unsupported = fmt.Sprintf("dev mode installations follow their checkout %s", statusInfo.DevTemplatePath)
+		return result, nil
+	case bundle.IsVendored(targetDir):
+		manifest, err := bundle.New().ReadVendorManifest(bundle.VendorPath(targetDir))
+		if err != nil {
+			return nil, err
+		}
+		// Only a known template at its pinned commit, as the manifest can be edited
+		template, err := bundle.RegisteredTemplate(manifest, bundle.VendorPath(targetDir))
+		if err != nil {
+			return nil, err
+		}
+		result.Source = models.TemplateSourceVendored
+		result.TargetCommit = template.Commit
+	default:
+		pinned, err := templates.GetTemplate(installed.Template.ID)
+		if err != nil {
+			result.Unsupported = fmt.Sprintf("template %s is not known to this CLI", installed.Template.ID)
+			return result, nil
+		}
+		result.TargetCommit = pinned.Commit
+	}
+
+	result.UpdateAvailable = result.TargetCommit != "" && result.TargetCommit != result.InstalledCommit
+	return result, nil
+}
+
+// Apply installs the target commit of a checked installation over its core
+// files, like init --force-core: user content and settings are kept, and the
+// framework directory is backed up unless options.NoBackup is set. options
+// carries the flags of the update; the target and template come from the status.
+func (s *Service) Apply(updateStatus *models.UpdateStatus, options models.InstallConfig) error {
+	if updateStatus.Unsupported != "" {
+		return models.NewAppError(
+			models.ErrorCodeValidationFailed,
+			fmt.Sprintf("%s cannot be updated: %s", updateStatus.TargetDir, updateStatus.Unsupported),
+			nil,
+		)
+	}
+
+	installConfig := options
+	installConfig.TargetDir = updateStatus.TargetDir
+	installConfig.TemplateID = updateStatus.Template
+	installConfig.Force = false
+	installConfig.ForceCore = true
+	installConfig.SkipConfirm = true
+	if installConfig.GitignoreMode == "" {
+		// Gitignore files stay as the installation left them
+		installConfig.GitignoreMode = "track"
+	}
+	if installConfig.GitTimeout == 0 {
+		installConfig.GitTimeout = config.Current().GitTimeout
+	}
+	if installConfig.Instance == "" {
+		installConfig.Instance = config.Instance()
+	}
+	if err := installConfig.Validate(); err != nil {
+		return err
+	}
+
+	// Bring installations made by older versions to the current layout first
+	applied, err := migrate.New().Migrate(updateStatus.TargetDir)
+	for _, step := range applied {
+		s.reporter.Info(fmt.Sprintf("Migrated older layout (%s): %s", step.Layout, step.Description))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate legacy layout: %w", err)
+	}
+
+	installerService := installer.New(s.installerOptions...)
+	installerService.SetReporter(s.reporter)
+	installerService.SetContext(s.ctx)
+	return installerService.Install(installConfig)
+}
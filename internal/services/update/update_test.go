@@ -0,0 +1,126 @@
+package update
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/reporter"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/bundle"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/installer"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/installer/installertest"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/scaffold"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+// setInstalledCommit rewrites the commit recorded by the installation in targetDir
+func setInstalledCommit(t *testing.T, targetDir, commit string) {
+	t.Helper()
+
+	path := filepath.Join(targetDir, config.StrategicClaudeBasicDir, config.TemplateInfoFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read template info: %v", err)
+	}
+	var info templates.TemplateInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		t.Fatalf("Failed to parse template info: %v", err)
+	}
+	info.Template.Commit = commit
+	info.InstalledCommit = commit
+	if data, err = json.Marshal(info); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_CheckAndApply(t *testing.T) {
+	t.Setenv(config.BackupsDirEnvVar, "")
+	checkout := t.TempDir()
+	if _, err := scaffold.New().Create(checkout, "fake"); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	opts := []installer.Option{
+		installer.WithGitClient(installertest.NewLocalGit(checkout)),
+		installer.WithScriptRunner(installertest.NewRecordingScripts()),
+	}
+
+	service := New(opts...)
+	service.SetReporter(reporter.NewSilent())
+	targetDir := t.TempDir()
+
+	if _, err := service.Check(targetDir); !models.IsErrorCode(err, models.ErrorCodeNotInstalled) {
+		t.Fatalf("Check() without an installation error = %v, want %s", err, models.ErrorCodeNotInstalled)
+	}
+
+	installerService := installer.New(opts...)
+	installerService.SetReporter(reporter.NewSilent())
+	if err := installerService.Install(models.InstallConfig{
+		TargetDir:     targetDir,
+		TemplateID:    templates.DefaultTemplateID,
+		SkipConfirm:   true,
+		NoBackup:      true,
+		NoCache:       true,
+		NoVerify:      true,
+		GitignoreMode: "track",
+	}); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	pinned, err := templates.GetTemplate(templates.DefaultTemplateID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updateStatus, err := service.Check(targetDir)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if updateStatus.UpdateAvailable || updateStatus.TargetCommit != pinned.Commit || updateStatus.Unsupported != "" {
+		t.Errorf("Check() of a fresh installation = %+v, want it up to date at %s", updateStatus, pinned.Commit)
+	}
+
+	// An installation of an older commit is updated to the pinned one
+	setInstalledCommit(t, targetDir, "0000000000000000000000000000000000000000")
+	updateStatus, err = service.Check(targetDir)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !updateStatus.UpdateAvailable || updateStatus.InstalledCommit != "0000000000000000000000000000000000000000" || updateStatus.TargetCommit != pinned.Commit {
+		t.Fatalf("Check() of an old installation = %+v, want an update to %s", updateStatus, pinned.Commit)
+	}
+
+	if err := service.Apply(updateStatus, models.InstallConfig{NoCache: true, NoVerify: true}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	updateStatus, err = service.Check(targetDir)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if updateStatus.UpdateAvailable {
+		t.Errorf("Check() after Apply() = %+v, want it up to date", updateStatus)
+	}
+	// The installation was backed up before the update
+	if entries, _ := os.ReadDir(filepath.Join(targetDir, config.BackupsDir)); len(entries) == 0 {
+		t.Error("Apply() took no backup")
+	}
+
+	// A vendored copy whose manifest names another commit is not installed
+	edited := pinned
+	edited.Commit = "1111111111111111111111111111111111111111"
+	data, _ := json.Marshal(bundle.Manifest{FormatVersion: config.BundleFormatVersion, Template: edited, TreeHash: "sha256:00"})
+	vendorDir := bundle.VendorPath(targetDir)
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vendorDir, config.BundleManifestFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.Check(targetDir); !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+		t.Errorf("Check() of an edited vendored copy error = %v, want %s", err, models.ErrorCodeInvalidBundle)
+	}
+}
@@ -0,0 +1,169 @@
+// Package updatecheck looks up, at most once per check interval, which template
+// commits the newest CLI release pins, so commands can hint at available updates.
+package updatecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// maxReleaseSize bounds the release metadata read from the network
+const maxReleaseSize = 1 << 20
+
+// Release is the metadata published with every CLI release
+type Release struct {
+	FormatVersion int               `json:"format_version"`
+	Version       string            `json:"version"`
+	Templates     map[string]string `json:"templates"` // Template ID to pinned commit
+}
+
+// state is what the check remembers between runs
+type state struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release   *Release  `json:"release,omitempty"` // From the last successful check
+}
+
+// Service fetches release metadata and remembers it in a state file
+type Service struct {
+	statePath string
+	url       string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// New creates an update check that remembers its results in statePath
+func New(statePath string) *Service {
+	return &Service{
+		statePath: statePath,
+		url:       config.ReleaseMetadataURL,
+		client:    &http.Client{Timeout: config.UpdateCheckTimeout},
+		now:       time.Now,
+	}
+}
+
+// SetURL changes where the release metadata is fetched from
+func (s *Service) SetURL(url string) {
+	s.url = url
+}
+
+// Refresh fetches the newest release metadata when the last check is older than
+// the check interval. Failed checks count as checks, so machines without network
+// access try again a day later instead of on every command.
+func (s *Service) Refresh(ctx context.Context) error {
+	current := s.load()
+	if s.now().Sub(current.CheckedAt) < config.UpdateCheckInterval {
+		return nil
+	}
+
+	release, fetchErr := s.fetch(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err() // Interrupted checks are retried by the next command
+	}
+
+	current.CheckedAt = s.now()
+	if fetchErr == nil {
+		current.Release = release
+	}
+	if err := s.save(current); err != nil {
+		return err
+	}
+	return fetchErr
+}
+
+// Latest returns the release metadata of the last successful check, or nil
+func (s *Service) Latest() *Release {
+	return s.load().Release
+}
+
+// fetch downloads and decodes the release metadata
+func (s *Service) fetch(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, models.NewAppError(models.ErrorCodeNetworkError, "Failed to fetch release metadata", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, models.NewAppError(models.ErrorCodeNetworkError,
+			fmt.Sprintf("Failed to fetch release metadata: %s", resp.Status), nil)
+	}
+
+	var release Release
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxReleaseSize)).Decode(&release); err != nil {
+		return nil, fmt.Errorf("invalid release metadata: %w", err)
+	}
+	if release.FormatVersion != config.ReleaseFormatVersion {
+		return nil, fmt.Errorf("unsupported release metadata version %d", release.FormatVersion)
+	}
+	return &release, nil
+}
+
+// load reads the state file; a missing or unreadable state means never checked
+func (s *Service) load() state {
+	var current state
+	if data, err := os.ReadFile(s.statePath); err == nil {
+		_ = json.Unmarshal(data, &current)
+	}
+	return current
+}
+
+// save writes the state file
+func (s *Service) save(current state) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode update check state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(s.statePath), err)
+	}
+	if err := os.WriteFile(s.statePath, data, config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, s.statePath, err)
+	}
+	return nil
+}
+
+// Hint returns a one-line hint when the newest release pins another commit of the
+// installed template than the installation has, or "" when it is up to date.
+// pinned is the template as this CLI pins it; dev mode installs have no commit.
+func Hint(latest *Release, installed *templates.TemplateInfo, pinned templates.Template, cliVersion string) string {
+	if latest == nil || installed == nil || installed.Template.Commit == "" {
+		return ""
+	}
+	latestCommit, ok := latest.Templates[installed.Template.ID]
+	if !ok || latestCommit == installed.Template.Commit {
+		return ""
+	}
+
+	if pinned.Commit == latestCommit {
+		return fmt.Sprintf(utils.Symbols("Template %s has an update (%s → %s); run 'strategic-claude-basic-cli update' to install it"),
+			installed.Template.ID, shortCommit(installed.Template.Commit), shortCommit(latestCommit))
+	}
+	if latest.Version != cliVersion {
+		return fmt.Sprintf("Template %s has an update in strategic-claude-basic-cli %s (you have %s); upgrade, then run 'update'",
+			installed.Template.ID, latest.Version, cliVersion)
+	}
+	return ""
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
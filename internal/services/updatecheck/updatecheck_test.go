@@ -0,0 +1,99 @@
+package updatecheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
+)
+
+func TestService_Refresh(t *testing.T) {
+	var requests atomic.Int32
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"format_version": 1, "version": "0.2.0", "templates": {"main": "bbbbbbbbbb"}}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := New(filepath.Join(t.TempDir(), config.UpdateCheckFile))
+	service.SetURL(server.URL)
+	service.now = func() time.Time { return now }
+
+	if service.Latest() != nil {
+		t.Error("Latest() before any check is not nil")
+	}
+	if err := service.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if latest := service.Latest(); latest == nil || latest.Version != "0.2.0" || latest.Templates["main"] != "bbbbbbbbbb" {
+		t.Errorf("Latest() = %+v, want version 0.2.0 pinning bbbbbbbbbb", latest)
+	}
+
+	// Checks within the interval reuse the stored result
+	now = now.Add(time.Hour)
+	if err := service.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("requests within the interval = %d, want 1", requests.Load())
+	}
+
+	// A failed check keeps the last release and is not retried within the interval
+	status = http.StatusNotFound
+	now = now.Add(config.UpdateCheckInterval)
+	if err := service.Refresh(context.Background()); err == nil {
+		t.Error("Refresh() with a failing server error = nil, want an error")
+	}
+	if service.Latest() == nil {
+		t.Error("Latest() after a failed check = nil, want the last release")
+	}
+	if err := service.Refresh(context.Background()); err != nil || requests.Load() != 2 {
+		t.Errorf("Refresh() after a failed check = %v with %d requests, want no new request", err, requests.Load())
+	}
+}
+
+func TestHint(t *testing.T) {
+	installed := &templates.TemplateInfo{Template: templates.Template{ID: "main", Commit: "aaaaaaaaaa"}}
+	devInstall := &templates.TemplateInfo{Template: templates.Template{ID: "main"}}
+	latest := &Release{Version: "0.2.0", Templates: map[string]string{"main": "bbbbbbbbbb"}}
+	oldPin := templates.Template{ID: "main", Commit: "aaaaaaaaaa"}
+	newPin := templates.Template{ID: "main", Commit: "bbbbbbbbbb"}
+
+	tests := []struct {
+		name      string
+		latest    *Release
+		installed *templates.TemplateInfo
+		pinned    templates.Template
+		version   string
+		want      string // Substring of the hint, "" for no hint
+	}{
+		{name: "never checked", installed: installed, pinned: oldPin, version: "0.1.0"},
+		{name: "up to date", latest: &Release{Version: "0.2.0", Templates: map[string]string{"main": "aaaaaaaaaa"}}, installed: installed, pinned: oldPin, version: "0.1.0"},
+		{name: "dev install", latest: latest, installed: devInstall, pinned: oldPin, version: "0.1.0"},
+		{name: "unknown template", latest: &Release{Version: "0.2.0", Templates: map[string]string{"ccr": "bbbbbbbbbb"}}, installed: installed, pinned: oldPin, version: "0.1.0"},
+		{name: "cli already pins the update", latest: latest, installed: installed, pinned: newPin, version: "0.2.0", want: "run 'strategic-claude-basic-cli update'"},
+		{name: "cli upgrade needed", latest: latest, installed: installed, pinned: oldPin, version: "0.1.0", want: "0.2.0 (you have 0.1.0)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Hint(tt.latest, tt.installed, tt.pinned, tt.version)
+			if tt.want == "" && got != "" {
+				t.Errorf("Hint() = %q, want none", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("Hint() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package usercontent
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+)
+
+// Collision policies for files that already exist in the target installation
+const (
+	CollisionSkip      = "skip"
+	CollisionRename    = "rename"
+	CollisionOverwrite = "overwrite"
+)
+
+// maxRenameAttempts bounds the search for a free name when renaming
+const maxRenameAttempts = 1000
+
+// ImportResult lists what happened to each file of an imported archive.
+// Paths are relative to .strategic-claude-basic.
+type ImportResult struct {
+	Manifest    Manifest
+	Imported    []string
+	Skipped     []string
+	Renamed     map[string]string // Archive path to the path it was written to
+	Overwritten []string
+}
+
+// GetCollisionPolicies returns the supported collision policies
+func GetCollisionPolicies() []string {
+	return []string{CollisionSkip, CollisionRename, CollisionOverwrite}
+}
+
+// ValidateCollisionPolicy checks that policy is a supported collision policy
+func ValidateCollisionPolicy(policy string) error {
+	if !slices.Contains(GetCollisionPolicies(), policy) {
+		return models.NewValidationError("on-conflict", policy,
+			fmt.Sprintf("must be one of: %s", strings.Join(GetCollisionPolicies(), ", ")))
+	}
+	return nil
+}
+
+// Import unpacks an archive created by Export into the user directories of the
+// installation in targetDir. Files that already exist are handled by policy.
+func (s *Service) Import(targetDir, archivePath, policy string) (*ImportResult, error) {
+	if err := ValidateCollisionPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	statusInfo, err := status.NewService().CheckInstallation(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	if !statusInfo.IsInstalled {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			nil,
+		)
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	result := &ImportResult{Renamed: make(map[string]string)}
+	foundManifest := false
+
+	err = walkArchive(archivePath, func(header *tar.Header, reader io.Reader) error {
+		if header.Name == config.UserContentManifestFile {
+			manifest, err := decodeManifest(reader)
+			if err != nil {
+				return err
+			}
+			result.Manifest = *manifest
+			foundManifest = true
+			return nil
+		}
+
+		// The manifest comes first; anything else is not one of our archives
+		if !foundManifest {
+			return invalidArchive(archivePath, "manifest not found", nil)
+		}
+
+		relPath, err := userContentPath(header.Name)
+		if err != nil {
+			return invalidArchive(archivePath, err.Error(), nil)
+		}
+
+		// A symlink imported earlier, or one in the installation, must not be written through
+		destPath := filepath.Join(strategicDir, filepath.FromSlash(relPath))
+		link, err := utils.SymlinkedParent(strategicDir, destPath)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+		}
+		if link != "" {
+			return invalidArchive(archivePath, fmt.Sprintf("%s is below the symlink %s", header.Name, link), nil)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(destPath, config.DirPermissions); err != nil {
+				return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+			}
+			return nil
+		case tar.TypeSymlink:
+			if err := validateLinkTarget(relPath, header.Linkname); err != nil {
+				return invalidArchive(archivePath, err.Error(), nil)
+			}
+			if !utils.LinkResolvesWithin(strategicDir, destPath, header.Linkname) {
+				return invalidArchive(archivePath, fmt.Sprintf("symlink %s points outside the installation", relPath), nil)
+			}
+		case tar.TypeReg:
+		default:
+			return invalidArchive(archivePath, fmt.Sprintf("unsupported entry type for %s", header.Name), nil)
+		}
+
+		return s.importEntry(strategicDir, relPath, header, reader, policy, result)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if !foundManifest {
+		return result, invalidArchive(archivePath, "manifest not found", nil)
+	}
+
+	if err := checkImportedLinks(strategicDir, archivePath, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// checkImportedLinks checks the imported symlinks again with the whole archive
+// in place, as a later entry may have turned a path a link goes through into
+// another link. Links that lead outside the installation are removed.
+func checkImportedLinks(strategicDir, archivePath string, result *ImportResult) error {
+	written := slices.Concat(result.Imported, result.Overwritten, slices.Collect(maps.Values(result.Renamed)))
+	for _, relPath := range written {
+		linkPath := filepath.Join(strategicDir, filepath.FromSlash(relPath))
+		info, err := os.Lstat(linkPath)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+		target, err := os.Readlink(linkPath)
+		if err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, linkPath, err)
+		}
+		if !utils.LinkResolvesWithin(strategicDir, linkPath, target) {
+			if err := os.Remove(linkPath); err != nil {
+				return models.NewFileSystemError(models.ErrorCodeFileSystemError, linkPath, err)
+			}
+			return invalidArchive(archivePath, fmt.Sprintf("symlink %s points outside the installation", relPath), nil)
+		}
+	}
+	return nil
+}
+
+// importEntry writes a file or symlink from the archive, applying the collision policy
+func (s *Service) importEntry(strategicDir, relPath string, header *tar.Header, reader io.Reader, policy string, result *ImportResult) error {
+	destRel := relPath
+	destPath := filepath.Join(strategicDir, filepath.FromSlash(relPath))
+
+	existing, err := os.Lstat(destPath)
+	switch {
+	case os.IsNotExist(err):
+		result.Imported = append(result.Imported, relPath)
+	case err != nil:
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+	case policy == CollisionSkip:
+		result.Skipped = append(result.Skipped, relPath)
+		return nil
+	case policy == CollisionRename:
+		if destRel, err = freeName(strategicDir, relPath); err != nil {
+			return err
+		}
+		destPath = filepath.Join(strategicDir, filepath.FromSlash(destRel))
+		result.Renamed[relPath] = destRel
+	default:
+		if existing.IsDir() {
+			return models.NewFileSystemError(models.ErrorCodeFileAlreadyExists, destPath,
+				fmt.Errorf("cannot overwrite a directory with a file"))
+		}
+		// Remove first so symlinks in the target are replaced rather than written through
+		if err := os.Remove(destPath); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+		}
+		result.Overwritten = append(result.Overwritten, relPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(destPath), err)
+	}
+
+	if header.Typeflag == tar.TypeSymlink {
+		if err := os.Symlink(header.Linkname, destPath); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+		}
+		return nil
+	}
+
+	if err := writeNewFile(destPath, reader, header.FileInfo().Mode().Perm()); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, destPath, err)
+	}
+	return nil
+}
+
+// freeName returns the first unused name of the form "name-N.ext" next to relPath
+func freeName(strategicDir, relPath string) (string, error) {
+	ext := path.Ext(relPath)
+	base := strings.TrimSuffix(relPath, ext)
+
+	for counter := 1; counter <= maxRenameAttempts; counter++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, counter, ext)
+		if _, err := os.Lstat(filepath.Join(strategicDir, filepath.FromSlash(candidate))); os.IsNotExist(err) {
+			return candidate, nil
+		}
+	}
+
+	return "", models.NewAppError(
+		models.ErrorCodeFileAlreadyExists,
+		fmt.Sprintf("No free name found for %s", relPath),
+		nil,
+	)
+}
+
+// writeNewFile creates a file that must not exist yet with the recorded permissions
+func writeNewFile(destPath string, reader io.Reader, mode fs.FileMode) error {
+	file, err := os.OpenFile(destPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+
+	_, copyErr := io.Copy(file, reader)
+	return errors.Join(copyErr, file.Close())
+}
+
+// walkArchive calls visit for every entry of a user content archive
+func walkArchive(archivePath string, visit func(*tar.Header, io.Reader) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, archivePath, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return invalidArchive(archivePath, "not a gzip archive", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return invalidArchive(archivePath, "failed to read archive", err)
+		}
+
+		if err := visit(header, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+// userContentPath validates an archive entry name and returns it cleaned. Entries
+// must lie within one of the user-preserved directories.
+func userContentPath(name string) (string, error) {
+	if path.IsAbs(name) {
+		return "", fmt.Errorf("unsafe entry path: %s", name)
+	}
+
+	cleaned := path.Clean(name)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("unsafe entry path: %s", name)
+	}
+
+	topDir, _, _ := strings.Cut(cleaned, "/")
+	if !slices.Contains(config.GetUserPreservedDirectories(), topDir) {
+		return "", fmt.Errorf("entry outside the user directories: %s", name)
+	}
+
+	return cleaned, nil
+}
+
+// validateLinkTarget ensures a symlink stays within .strategic-claude-basic
+func validateLinkTarget(relPath, target string) error {
+	if path.IsAbs(target) {
+		return fmt.Errorf("absolute symlink target for %s", relPath)
+	}
+
+	resolved := path.Join(path.Dir(relPath), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("symlink %s points outside the installation", relPath)
+	}
+
+	return nil
+}
+
+// decodeManifest parses and validates a user content manifest
+func decodeManifest(reader io.Reader) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, models.NewAppError(models.ErrorCodeInvalidBundle, "Failed to parse user content manifest", err)
+	}
+
+	if manifest.FormatVersion != config.UserContentFormatVersion {
+		return nil, models.NewAppError(
+			models.ErrorCodeInvalidBundle,
+			fmt.Sprintf("Unsupported user content format version: %d", manifest.FormatVersion),
+			nil,
+		)
+	}
+
+	return &manifest, nil
+}
+
+// invalidArchive builds an invalid archive error for the given file
+func invalidArchive(archivePath, reason string, cause error) error {
+	return models.NewAppError(
+		models.ErrorCodeInvalidBundle,
+		fmt.Sprintf("Invalid user content archive %s: %s", archivePath, reason),
+		cause,
+	).WithContext("archive", archivePath)
+}
@@ -0,0 +1,208 @@
+package usercontent
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/filesystem"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/symlink"
+)
+
+// createWorkingInstallation creates a project that status reports as installed
+func createWorkingInstallation(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	targetDir := createInstallation(t, files)
+	if err := filesystem.New().EnsureDirectoryStructure(targetDir); err != nil {
+		t.Fatalf("Failed to create directory structure: %v", err)
+	}
+	if err := symlink.New().CreateSymlinks(targetDir); err != nil {
+		t.Fatalf("Failed to create symlinks: %v", err)
+	}
+	return targetDir
+}
+
+// readUserFile returns the content of a file below .strategic-claude-basic
+func readUserFile(t *testing.T, targetDir, relPath string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(relPath)))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", relPath, err)
+	}
+	return string(data)
+}
+
+func TestService_Import(t *testing.T) {
+	source := createInstallation(t, map[string]string{
+		"plan/plan.md":         "exported plan",
+		"research/research.md": "exported research",
+	})
+	archive := filepath.Join(t.TempDir(), "export.tar.gz")
+	if _, err := New().Export(source, archive, nil); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	tests := []struct {
+		policy       string
+		wantPlan     string
+		wantRenamed  map[string]string
+		wantSkipped  []string
+		wantReplaced []string
+	}{
+		{
+			policy:      CollisionSkip,
+			wantPlan:    "existing plan",
+			wantSkipped: []string{"plan/plan.md"},
+		},
+		{
+			policy:      CollisionRename,
+			wantPlan:    "existing plan",
+			wantRenamed: map[string]string{"plan/plan.md": "plan/plan-1.md"},
+		},
+		{
+			policy:       CollisionOverwrite,
+			wantPlan:     "exported plan",
+			wantReplaced: []string{"plan/plan.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			targetDir := createWorkingInstallation(t, map[string]string{"plan/plan.md": "existing plan"})
+
+			result, err := New().Import(targetDir, archive, tt.policy)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+
+			if !slices.Equal(result.Imported, []string{"research/research.md"}) {
+				t.Errorf("Import() imported = %v, want [research/research.md]", result.Imported)
+			}
+			if !slices.Equal(result.Skipped, tt.wantSkipped) {
+				t.Errorf("Import() skipped = %v, want %v", result.Skipped, tt.wantSkipped)
+			}
+			if !slices.Equal(result.Overwritten, tt.wantReplaced) {
+				t.Errorf("Import() overwritten = %v, want %v", result.Overwritten, tt.wantReplaced)
+			}
+			if len(result.Renamed) != len(tt.wantRenamed) {
+				t.Errorf("Import() renamed = %v, want %v", result.Renamed, tt.wantRenamed)
+			}
+			for from, to := range tt.wantRenamed {
+				if result.Renamed[from] != to {
+					t.Errorf("Import() renamed %s to %s, want %s", from, result.Renamed[from], to)
+				}
+				if got := readUserFile(t, targetDir, to); got != "exported plan" {
+					t.Errorf("renamed file content = %q, want %q", got, "exported plan")
+				}
+			}
+
+			if got := readUserFile(t, targetDir, "plan/plan.md"); got != tt.wantPlan {
+				t.Errorf("plan/plan.md = %q, want %q", got, tt.wantPlan)
+			}
+			if got := readUserFile(t, targetDir, "research/research.md"); got != "exported research" {
+				t.Errorf("research/research.md = %q, want %q", got, "exported research")
+			}
+		})
+	}
+}
+
+func TestService_Import_NotInstalled(t *testing.T) {
+	source := createInstallation(t, map[string]string{"plan/plan.md": "plan"})
+	archive := filepath.Join(t.TempDir(), "export.tar.gz")
+	if _, err := New().Export(source, archive, nil); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	// Only a .strategic-claude-basic directory, no .claude integration
+	_, err := New().Import(source, archive, CollisionSkip)
+	if !models.IsErrorCode(err, models.ErrorCodeNotInstalled) {
+		t.Errorf("Import() error = %v, want code %s", err, models.ErrorCodeNotInstalled)
+	}
+}
+
+func TestService_Import_UnsafeArchives(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []tar.Header
+	}{
+		{"path traversal", []tar.Header{{Name: "plan/../../escape.md", Typeflag: tar.TypeReg, Mode: 0644}}},
+		{"framework directory", []tar.Header{{Name: "core/agents/agent.md", Typeflag: tar.TypeReg, Mode: 0644}}},
+		{"absolute symlink", []tar.Header{{Name: "plan/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}}},
+		{"escaping symlink", []tar.Header{{Name: "plan/link", Typeflag: tar.TypeSymlink, Linkname: "../../../outside"}}},
+		// Each link stays inside by its text, but the second one is created
+		// through the first and the file through both
+		{"symlink chain", []tar.Header{
+			{Name: "plan/a/b/l", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+			{Name: "plan/a/b/l/l2", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			{Name: "plan/a/b/l/l2/ESCAPED", Typeflag: tar.TypeReg, Mode: 0644},
+		}},
+		// Going up from a link that points to the root leaves it
+		{"symlink through symlink", []tar.Header{
+			{Name: "plan/a/b/l", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+			{Name: "plan/a/b/m", Typeflag: tar.TypeSymlink, Linkname: "l/.."},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "unsafe.tar.gz")
+			writeRawArchive(t, archive, tt.headers...)
+
+			targetDir := createWorkingInstallation(t, nil)
+			_, err := New().Import(targetDir, archive, CollisionOverwrite)
+			if !models.IsErrorCode(err, models.ErrorCodeInvalidBundle) {
+				t.Errorf("Import() error = %v, want code %s", err, models.ErrorCodeInvalidBundle)
+			}
+			for _, dir := range []string{targetDir, filepath.Dir(targetDir)} {
+				if _, err := os.Lstat(filepath.Join(dir, "ESCAPED")); err == nil {
+					t.Errorf("Import() wrote ESCAPED to %s", dir)
+				}
+			}
+		})
+	}
+}
+
+// writeRawArchive writes a valid manifest followed by the entries of headers
+func writeRawArchive(t *testing.T, archivePath string, headers ...tar.Header) {
+	t.Helper()
+
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifest, err := json.Marshal(Manifest{FormatVersion: config.UserContentFormatVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tarWriter.WriteHeader(&tar.Header{Name: config.UserContentManifestFile, Mode: 0644, Size: int64(len(manifest))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarWriter.Write(manifest); err != nil {
+		t.Fatal(err)
+	}
+	for _, header := range headers {
+		if err := tarWriter.WriteHeader(&header); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,377 @@
+package usercontent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/status"
+)
+
+// Identity used for sync commits when git has no user configured
+const (
+	syncAuthorName  = "strategic-claude-basic-cli"
+	syncAuthorEmail = "strategic-claude-basic-cli@localhost"
+)
+
+// SyncOptions selects the remote that user content is synced with. The remote
+// is remembered after the first sync, so later syncs may leave it empty.
+type SyncOptions struct {
+	Remote string
+	Branch string
+}
+
+// SyncResult describes the sync repository after a push or pull
+type SyncResult struct {
+	Remote   string
+	Branch   string
+	Commit   string // Checked out commit, empty if nothing has been synced yet
+	UpToDate bool   // Nothing had to be transferred
+}
+
+// syncRepo runs git on the sync repository of an installation. Its git directory
+// lives in .strategic-claude-basic/.sync and its work tree is .strategic-claude-basic
+// itself, restricted to the user directories, so the project's own repository
+// is never touched.
+type syncRepo struct {
+	git          *git.Service
+	strategicDir string
+	gitDir       string
+	remote       string
+	branch       string
+}
+
+// SyncPush commits the user content of the installation in targetDir and pushes
+// it to the sync remote
+func (s *Service) SyncPush(targetDir string, opts SyncOptions) (*SyncResult, error) {
+	repo, err := s.openSyncRepo(targetDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.commitChanges(); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Remote: repo.remote, Branch: repo.branch}
+	if result.Commit = repo.head(); result.Commit == "" {
+		result.UpToDate = true
+		return result, nil
+	}
+
+	output, err := repo.runRemote("push", "--porcelain", "origin", "HEAD:refs/heads/"+repo.branch)
+	if err != nil {
+		if strings.Contains(output, "[rejected]") {
+			return nil, models.NewAppError(
+				models.ErrorCodeConcurrentModification,
+				fmt.Sprintf("Branch %s of %s has changes that are not here yet; run 'sync pull' first", repo.branch, repo.remote),
+				err,
+			)
+		}
+		return nil, err
+	}
+
+	result.UpToDate = strings.Contains(output, "[up to date]")
+	return result, nil
+}
+
+// SyncPull fetches user content from the sync remote and merges it into the
+// installation in targetDir. Local changes are committed first so they are
+// never overwritten; conflicting edits abort the merge and leave both sides as
+// they were. Fetched content with files outside the user directories is
+// refused before anything is checked out.
+func (s *Service) SyncPull(targetDir string, opts SyncOptions) (*SyncResult, error) {
+	repo, err := s.openSyncRepo(targetDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.commitChanges(); err != nil {
+		return nil, err
+	}
+
+	if _, err := repo.runRemote("fetch", "-q", "origin", repo.branch); err != nil {
+		return nil, err
+	}
+	fetched, err := repo.run("rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.checkFetched(fetched); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Remote: repo.remote, Branch: repo.branch, Commit: fetched}
+	head := repo.head()
+	switch {
+	case head == "":
+		// Fresh machine: nothing local to merge with
+		if _, err := repo.run("checkout", "-q", "-B", repo.branch, fetched); err != nil {
+			return nil, err
+		}
+	case head == fetched:
+		result.UpToDate = true
+	default:
+		if _, err := repo.run("merge-base", "--is-ancestor", fetched, head); err == nil {
+			result.UpToDate = true
+			result.Commit = head
+			return result, nil
+		}
+
+		args := append(repo.identityArgs(), "merge", "-q", "--no-edit", "--allow-unrelated-histories", fetched)
+		if _, err := repo.run(args...); err != nil {
+			return nil, repo.abortMerge(err)
+		}
+		result.Commit = repo.head()
+	}
+
+	return result, nil
+}
+
+// openSyncRepo validates the installation and creates or reconfigures its sync repository
+func (s *Service) openSyncRepo(targetDir string, opts SyncOptions) (*syncRepo, error) {
+	statusInfo, err := status.NewService().CheckInstallation(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	if !statusInfo.IsInstalled {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			nil,
+		)
+	}
+
+	if err := s.gitService.ValidateGitInstalled(); err != nil {
+		return nil, err
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	repo := &syncRepo{
+		git:          s.gitService,
+		strategicDir: strategicDir,
+		gitDir:       filepath.Join(strategicDir, config.UserContentSyncDir),
+	}
+
+	if _, err := os.Stat(repo.gitDir); os.IsNotExist(err) {
+		if opts.Remote == "" {
+			return nil, models.NewValidationError("remote", "", "no sync remote configured yet; pass --remote")
+		}
+		if err := repo.init(opts.Branch); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, repo.gitDir, err)
+	}
+
+	// Refresh the exclude rules so newly added user directories are synced too
+	if err := repo.writeExcludes(); err != nil {
+		return nil, err
+	}
+
+	if err := repo.configureRemote(opts.Remote); err != nil {
+		return nil, err
+	}
+	if err := repo.configureBranch(opts.Branch); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// init creates the sync repository with branch as its initial branch
+func (r *syncRepo) init(branch string) error {
+	if branch == "" {
+		branch = config.UserContentSyncBranch
+	}
+
+	if _, err := r.run("init", "-q"); err != nil {
+		return err
+	}
+	if _, err := r.run("symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+		return err
+	}
+
+	// Keep the project's own repository from picking up the sync repository
+	ignorePath := filepath.Join(r.gitDir, ".gitignore")
+	if err := os.WriteFile(ignorePath, []byte("*\n"), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, ignorePath, err)
+	}
+
+	return nil
+}
+
+// writeExcludes limits the work tree to the user-preserved directories
+func (r *syncRepo) writeExcludes() error {
+	var rules strings.Builder
+	rules.WriteString("# Managed by strategic-claude-basic-cli: only user directories are synced\n/*\n")
+	for _, dir := range config.GetUserPreservedDirectories() {
+		fmt.Fprintf(&rules, "!/%s/\n", dir)
+	}
+
+	excludePath := filepath.Join(r.gitDir, "info", "exclude")
+	if err := os.MkdirAll(filepath.Dir(excludePath), config.DirPermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, filepath.Dir(excludePath), err)
+	}
+	if err := os.WriteFile(excludePath, []byte(rules.String()), config.FilePermissions); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, excludePath, err)
+	}
+
+	return nil
+}
+
+// checkFetched refuses a fetched commit holding files outside the user
+// directories; the exclude rules only keep untracked files out, so merging
+// such a commit would overwrite framework files such as hooks
+func (r *syncRepo) checkFetched(commit string) error {
+	files, err := r.run("ls-tree", "-r", "-z", "--name-only", commit)
+	if err != nil {
+		return err
+	}
+
+	userDirs := config.GetUserPreservedDirectories()
+	var outside []string
+	for _, file := range strings.Split(files, "\x00") {
+		if file == "" {
+			continue
+		}
+		dir, _, found := strings.Cut(file, "/")
+		if !found || !slices.Contains(userDirs, dir) {
+			outside = append(outside, file)
+		}
+	}
+	if len(outside) == 0 {
+		return nil
+	}
+
+	return models.NewAppError(
+		models.ErrorCodeValidationFailed,
+		fmt.Sprintf("Branch %s of %s holds files outside the user directories: %s",
+			r.branch, r.remote, strings.Join(outside, ", ")),
+		nil,
+	).WithContext("commit", commit)
+}
+
+// configureRemote points origin at remote, or loads the remembered remote when it is empty
+func (r *syncRepo) configureRemote(remote string) error {
+	current, err := r.run("remote", "get-url", "origin")
+	hasOrigin := err == nil
+
+	switch {
+	case remote == "" && !hasOrigin:
+		return models.NewValidationError("remote", "", "no sync remote configured yet; pass --remote")
+	case remote == "" || remote == current:
+		r.remote = current
+		return nil
+	case hasOrigin:
+		_, err = r.run("remote", "set-url", "origin", remote)
+	default:
+		_, err = r.run("remote", "add", "origin", remote)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.remote = remote
+	return nil
+}
+
+// configureBranch checks out branch, which is only possible before the first commit
+func (r *syncRepo) configureBranch(branch string) error {
+	current, err := r.run("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	if branch == "" || branch == current {
+		r.branch = current
+		return nil
+	}
+
+	if r.head() != "" {
+		return models.NewValidationError("branch", branch,
+			fmt.Sprintf("user content is already synced on branch %s", current))
+	}
+	if _, err := r.run("symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+		return err
+	}
+
+	r.branch = branch
+	return nil
+}
+
+// commitChanges records all changes in the user directories, if there are any
+func (r *syncRepo) commitChanges() error {
+	if _, err := r.run("add", "-A"); err != nil {
+		return err
+	}
+
+	changes, err := r.run("status", "--porcelain")
+	if err != nil || changes == "" {
+		return err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown host"
+	}
+
+	args := append(r.identityArgs(), "commit", "-q", "-m", "Sync user content from "+host)
+	_, err = r.run(args...)
+	return err
+}
+
+// abortMerge undoes a failed merge and explains how to resolve it by hand
+func (r *syncRepo) abortMerge(mergeErr error) error {
+	conflicts, _ := r.run("diff", "--name-only", "--diff-filter=U")
+	if _, err := r.run("merge", "--abort"); err != nil {
+		return mergeErr
+	}
+	if conflicts == "" {
+		return mergeErr
+	}
+
+	return models.NewAppError(
+		models.ErrorCodeConcurrentModification,
+		fmt.Sprintf("User content was changed on both sides: %s", strings.Join(strings.Fields(conflicts), ", ")),
+		mergeErr,
+	).WithContext("resolve", fmt.Sprintf("git --git-dir=%s --work-tree=%s pull origin %s",
+		r.gitDir, r.strategicDir, r.branch))
+}
+
+// identityArgs returns git options that provide a committer when none is configured
+func (r *syncRepo) identityArgs() []string {
+	if email, err := r.run("config", "user.email"); err == nil && email != "" {
+		return nil
+	}
+	return []string{"-c", "user.name=" + syncAuthorName, "-c", "user.email=" + syncAuthorEmail}
+}
+
+// head returns the checked out commit, or an empty string before the first commit
+func (r *syncRepo) head() string {
+	commit, err := r.run("rev-parse", "-q", "--verify", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return commit
+}
+
+// run runs a local git command against the sync repository
+func (r *syncRepo) run(args ...string) (string, error) {
+	return r.git.Run(r.strategicDir, "", r.repoArgs(args)...)
+}
+
+// runRemote runs a git command that contacts the sync remote
+func (r *syncRepo) runRemote(args ...string) (string, error) {
+	return r.git.Run(r.strategicDir, r.remote, r.repoArgs(args)...)
+}
+
+// repoArgs prefixes args with the sync repository's git and work tree directories
+func (r *syncRepo) repoArgs(args []string) []string {
+	return append([]string{"--git-dir=" + r.gitDir, "--work-tree=" + r.strategicDir}, args...)
+}
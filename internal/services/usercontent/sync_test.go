@@ -0,0 +1,194 @@
+package usercontent
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// createSyncRemote creates an empty bare repository to sync with
+func createSyncRemote(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to create remote: %v: %s", err, output)
+	}
+	return remote
+}
+
+// writeUserFile writes a file below .strategic-claude-basic
+func writeUserFile(t *testing.T, targetDir, relPath, content string) {
+	t.Helper()
+
+	fullPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(relPath))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Sync(t *testing.T) {
+	remote := createSyncRemote(t)
+	opts := SyncOptions{Remote: remote}
+
+	laptop := createWorkingInstallation(t, map[string]string{
+		"core/agents/agent.md": "framework",
+		"plan/plan.md":         "plan from laptop",
+	})
+	result, err := New().SyncPush(laptop, opts)
+	if err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	if result.UpToDate || result.Commit == "" || result.Branch != config.UserContentSyncBranch {
+		t.Errorf("SyncPush() = %+v, want a new commit on %s", result, config.UserContentSyncBranch)
+	}
+
+	// A fresh checkout gets the content, but never framework files
+	desktop := createWorkingInstallation(t, nil)
+	if _, err := New().SyncPull(desktop, opts); err != nil {
+		t.Fatalf("SyncPull() error = %v", err)
+	}
+	if got := readUserFile(t, desktop, "plan/plan.md"); got != "plan from laptop" {
+		t.Errorf("plan/plan.md = %q, want %q", got, "plan from laptop")
+	}
+	if _, err := os.Stat(filepath.Join(desktop, config.StrategicClaudeBasicDir, "core", "agents", "agent.md")); !os.IsNotExist(err) {
+		t.Error("SyncPull() restored a framework file")
+	}
+
+	// Changes flow back without passing --remote again
+	writeUserFile(t, desktop, "research/research.md", "research from desktop")
+	if _, err := New().SyncPush(desktop, SyncOptions{}); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+
+	writeUserFile(t, laptop, "summary/summary.md", "summary from laptop")
+	if _, err := New().SyncPush(laptop, SyncOptions{}); !models.IsErrorCode(err, models.ErrorCodeConcurrentModification) {
+		t.Fatalf("SyncPush() with remote changes error = %v, want code %s", err, models.ErrorCodeConcurrentModification)
+	}
+	if _, err := New().SyncPull(laptop, SyncOptions{}); err != nil {
+		t.Fatalf("SyncPull() error = %v", err)
+	}
+	if got := readUserFile(t, laptop, "research/research.md"); got != "research from desktop" {
+		t.Errorf("research/research.md = %q, want %q", got, "research from desktop")
+	}
+	if got := readUserFile(t, laptop, "summary/summary.md"); got != "summary from laptop" {
+		t.Errorf("summary/summary.md = %q, want %q", got, "summary from laptop")
+	}
+
+	result, err = New().SyncPush(laptop, SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncPush() after pull error = %v", err)
+	}
+	if result, err = New().SyncPush(laptop, SyncOptions{}); err != nil || !result.UpToDate {
+		t.Errorf("SyncPush() without changes = %+v, %v, want up to date", result, err)
+	}
+}
+
+func TestService_SyncPull_Conflict(t *testing.T) {
+	remote := createSyncRemote(t)
+	opts := SyncOptions{Remote: remote}
+
+	laptop := createWorkingInstallation(t, map[string]string{"plan/plan.md": "original"})
+	if _, err := New().SyncPush(laptop, opts); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	desktop := createWorkingInstallation(t, nil)
+	if _, err := New().SyncPull(desktop, opts); err != nil {
+		t.Fatalf("SyncPull() error = %v", err)
+	}
+
+	writeUserFile(t, laptop, "plan/plan.md", "laptop edit")
+	if _, err := New().SyncPush(laptop, opts); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	writeUserFile(t, desktop, "plan/plan.md", "desktop edit")
+
+	_, err := New().SyncPull(desktop, opts)
+	if !models.IsErrorCode(err, models.ErrorCodeConcurrentModification) {
+		t.Fatalf("SyncPull() error = %v, want code %s", err, models.ErrorCodeConcurrentModification)
+	}
+	if got := readUserFile(t, desktop, "plan/plan.md"); got != "desktop edit" {
+		t.Errorf("plan/plan.md after aborted merge = %q, want %q", got, "desktop edit")
+	}
+}
+
+func TestService_Sync_Errors(t *testing.T) {
+	remote := createSyncRemote(t)
+
+	tests := []struct {
+		name      string
+		targetDir string
+		opts      SyncOptions
+		wantCode  models.ErrorCode
+	}{
+		{"not installed", t.TempDir(), SyncOptions{Remote: remote}, models.ErrorCodeNotInstalled},
+		{"no remote", createWorkingInstallation(t, nil), SyncOptions{}, models.ErrorCodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().SyncPush(tt.targetDir, tt.opts); !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("SyncPush() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestService_SyncPull_FrameworkFiles(t *testing.T) {
+	remote := createSyncRemote(t)
+	opts := SyncOptions{Remote: remote}
+
+	desktop := createWorkingInstallation(t, map[string]string{
+		"core/hooks/hook.py": "framework",
+		"plan/plan.md":       "plan",
+	})
+	if _, err := New().SyncPush(desktop, opts); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+
+	// Someone with access to the remote commits a hook next to the plan
+	clone := filepath.Join(t.TempDir(), "clone")
+	git := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-C", clone, "-c", "user.name=test", "-c", "user.email=test@localhost"}, args...)
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+	if output, err := exec.Command("git", "clone", "-q", "-b", config.UserContentSyncBranch, remote, clone).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to clone remote: %v: %s", err, output)
+	}
+	if err := os.MkdirAll(filepath.Join(clone, "core", "hooks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(clone, "core", "hooks", "hook.py"), []byte("injected"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "-A")
+	git("commit", "-q", "-m", "hook")
+	git("push", "-q", "origin", "HEAD")
+
+	_, err := New().SyncPull(desktop, opts)
+	if !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Fatalf("SyncPull() error = %v, want code %s", err, models.ErrorCodeValidationFailed)
+	}
+	if got := readUserFile(t, desktop, "core/hooks/hook.py"); got != "framework" {
+		t.Errorf("core/hooks/hook.py = %q, want %q", got, "framework")
+	}
+
+	// A fresh checkout is refused as well
+	if _, err := New().SyncPull(createWorkingInstallation(t, nil), opts); !models.IsErrorCode(err, models.ErrorCodeValidationFailed) {
+		t.Errorf("SyncPull() on a fresh installation error = %v, want code %s", err, models.ErrorCodeValidationFailed)
+	}
+}
@@ -0,0 +1,262 @@
+package usercontent
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/services/git"
+)
+
+// Manifest describes the user content packed in an archive
+type Manifest struct {
+	FormatVersion int      `json:"format_version"`
+	CreatedAt     string   `json:"created_at"`
+	SourceDir     string   `json:"source_dir"`
+	Include       []string `json:"include,omitempty"`
+	Directories   []string `json:"directories"` // User directories with at least one exported file
+	Files         int      `json:"files"`
+}
+
+// Service archives and syncs the user content of an installation
+type Service struct {
+	gitService *git.Service
+}
+
+// New creates a new user content service instance
+func New() *Service {
+	return &Service{
+		gitService: git.New(),
+	}
+}
+
+// SetAuthToken sets the token used to authenticate against an HTTPS sync remote
+func (s *Service) SetAuthToken(token string) {
+	s.gitService.SetAuthToken(token)
+}
+
+// DefaultArchiveName returns the default file name for a user content archive
+func DefaultArchiveName() string {
+	return config.UserContentArchivePrefix + time.Now().Format(config.BackupTimestampLayout) + config.BundleFileExtension
+}
+
+// ValidatePatterns checks that include patterns are well-formed
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return models.NewValidationError("include", pattern, "invalid pattern")
+		}
+	}
+	return nil
+}
+
+// MatchesInclude reports whether a path relative to .strategic-claude-basic is
+// selected by the include patterns. Without patterns everything is selected.
+// Patterns without a slash match any path element, e.g. "plan" or "*.md"; patterns
+// with a slash match the path or one of its parent directories, e.g. "research/2024-*".
+func MatchesInclude(relPath string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	elements := strings.Split(relPath, "/")
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+		if !strings.Contains(pattern, "/") {
+			for _, element := range elements {
+				if matched, _ := path.Match(pattern, element); matched {
+					return true
+				}
+			}
+			continue
+		}
+
+		for i := range elements {
+			if matched, _ := path.Match(pattern, strings.Join(elements[:i+1], "/")); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Export packs the user-preserved directories of an installation into a tar.gz
+// archive at outputPath, keeping only files selected by the include patterns.
+// Paths in the archive are relative to .strategic-claude-basic.
+func (s *Service) Export(targetDir, outputPath string, include []string) (*Manifest, error) {
+	if err := ValidatePatterns(include); err != nil {
+		return nil, err
+	}
+
+	strategicDir := filepath.Join(targetDir, config.FrameworkDir())
+	if info, err := os.Stat(strategicDir); err != nil || !info.IsDir() {
+		return nil, models.NewAppError(
+			models.ErrorCodeNotInstalled,
+			fmt.Sprintf("Strategic Claude Basic is not installed in %s", targetDir),
+			err,
+		)
+	}
+
+	files, err := collectFiles(strategicDir, include)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, models.NewAppError(models.ErrorCodeValidationFailed, "No user content matches the export filters", nil)
+	}
+
+	manifest := &Manifest{
+		FormatVersion: config.UserContentFormatVersion,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+		SourceDir:     targetDir,
+		Include:       include,
+		Files:         len(files),
+	}
+	for _, dir := range config.GetUserPreservedDirectories() {
+		for _, file := range files {
+			if strings.HasPrefix(file, dir+"/") {
+				manifest.Directories = append(manifest.Directories, dir)
+				break
+			}
+		}
+	}
+
+	if err := writeArchive(outputPath, manifest, strategicDir, files); err != nil {
+		_ = os.Remove(outputPath)
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// collectFiles returns the slash-separated paths of the regular files and symlinks
+// in the user directories that match the include patterns
+func collectFiles(strategicDir string, include []string) ([]string, error) {
+	var files []string
+
+	for _, dir := range config.GetUserPreservedDirectories() {
+		root := filepath.Join(strategicDir, dir)
+		if _, err := os.Lstat(root); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !(d.Type().IsRegular() || d.Type()&fs.ModeSymlink != 0) {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(strategicDir, filePath)
+			if err != nil {
+				return err
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			if MatchesInclude(relPath, include) {
+				files = append(files, relPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+
+	return files, nil
+}
+
+// writeArchive writes the manifest followed by the selected files to a tar.gz file
+func writeArchive(outputPath string, manifest *Manifest, strategicDir string, files []string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode user content manifest: %w", err)
+	}
+
+	// The manifest goes first so it can be read without scanning the whole archive
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    config.UserContentManifestFile,
+		Mode:    config.FilePermissions,
+		Size:    int64(len(manifestData)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	if _, err := tarWriter.Write(manifestData); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+
+	for _, relPath := range files {
+		if err := addArchiveEntry(tarWriter, filepath.Join(strategicDir, filepath.FromSlash(relPath)), relPath); err != nil {
+			return models.NewFileSystemError(models.ErrorCodeFileSystemError, relPath, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, outputPath, err)
+	}
+
+	return nil
+}
+
+// addArchiveEntry writes a single file or symlink to the archive
+func addArchiveEntry(tarWriter *tar.Writer, filePath, name string) error {
+	info, err := os.Lstat(filePath)
+	if err != nil {
+		return err
+	}
+
+	linkTarget := ""
+	if info.Mode()&fs.ModeSymlink != 0 {
+		if linkTarget, err = os.Readlink(filePath); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
@@ -0,0 +1,174 @@
+package usercontent
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// createInstallation writes files below .strategic-claude-basic of a new project
+func createInstallation(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	targetDir := t.TempDir()
+	for relPath, content := range files {
+		fullPath := filepath.Join(targetDir, config.StrategicClaudeBasicDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return targetDir
+}
+
+// readArchive returns the manifest and file contents of an archive
+func readArchive(t *testing.T, archivePath string) (Manifest, map[string]string) {
+	t.Helper()
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest Manifest
+	contents := make(map[string]string)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Name == config.UserContentManifestFile {
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		contents[header.Name] = string(data)
+	}
+
+	return manifest, contents
+}
+
+func TestService_Export(t *testing.T) {
+	targetDir := createInstallation(t, map[string]string{
+		"core/agents/agent.md":         "framework",
+		"plan/0001-plan.md":            "plan",
+		"research/2024-01/research.md": "research",
+		"research/notes.txt":           "notes",
+		"summary/summary.md":           "summary",
+	})
+
+	tests := []struct {
+		name      string
+		include   []string
+		wantFiles []string
+		wantDirs  []string
+	}{
+		{
+			name:      "everything",
+			wantFiles: []string{"plan/0001-plan.md", "research/2024-01/research.md", "research/notes.txt", "summary/summary.md"},
+			wantDirs:  []string{config.PlanDir, config.ResearchDir, config.SummaryDir},
+		},
+		{
+			name:      "directory names",
+			include:   []string{"plan", "summary"},
+			wantFiles: []string{"plan/0001-plan.md", "summary/summary.md"},
+			wantDirs:  []string{config.PlanDir, config.SummaryDir},
+		},
+		{
+			name:      "file name pattern",
+			include:   []string{"*.txt"},
+			wantFiles: []string{"research/notes.txt"},
+			wantDirs:  []string{config.ResearchDir},
+		},
+		{
+			name:      "path pattern",
+			include:   []string{"research/2024-*"},
+			wantFiles: []string{"research/2024-01/research.md"},
+			wantDirs:  []string{config.ResearchDir},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "export.tar.gz")
+
+			manifest, err := New().Export(targetDir, output, tt.include)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if !slices.Equal(manifest.Directories, tt.wantDirs) {
+				t.Errorf("Export() directories = %v, want %v", manifest.Directories, tt.wantDirs)
+			}
+
+			archived, contents := readArchive(t, output)
+			if archived.Files != len(tt.wantFiles) || archived.FormatVersion != config.UserContentFormatVersion {
+				t.Errorf("archive manifest = %+v, want %d files", archived, len(tt.wantFiles))
+			}
+
+			var gotFiles []string
+			for name := range contents {
+				gotFiles = append(gotFiles, name)
+			}
+			slices.Sort(gotFiles)
+			if !slices.Equal(gotFiles, tt.wantFiles) {
+				t.Errorf("archive files = %v, want %v", gotFiles, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestService_Export_Errors(t *testing.T) {
+	installed := createInstallation(t, map[string]string{"plan/plan.md": "plan"})
+
+	tests := []struct {
+		name      string
+		targetDir string
+		include   []string
+		wantCode  models.ErrorCode
+	}{
+		{"not installed", t.TempDir(), nil, models.ErrorCodeNotInstalled},
+		{"nothing matches", installed, []string{"research"}, models.ErrorCodeValidationFailed},
+		{"invalid pattern", installed, []string{"plan/["}, models.ErrorCodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "export.tar.gz")
+
+			_, err := New().Export(tt.targetDir, output, tt.include)
+			if !models.IsErrorCode(err, tt.wantCode) {
+				t.Errorf("Export() error = %v, want code %s", err, tt.wantCode)
+			}
+			if _, statErr := os.Stat(output); !os.IsNotExist(statErr) {
+				t.Error("Export() left an archive behind after failing")
+			}
+		})
+	}
+}
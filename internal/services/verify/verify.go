@@ -0,0 +1,186 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// TreeHashPrefix identifies the algorithm used for tree hashes
+const TreeHashPrefix = "sha256:"
+
+// Service verifies the integrity and authenticity of fetched template content
+type Service struct{}
+
+// New creates a new verify service instance
+func New() *Service {
+	return &Service{}
+}
+
+// ComputeTreeHash computes a deterministic hash over a directory tree.
+// Every file contributes its type (file, executable, symlink), the SHA-256 of its
+// content (or link target) and its slash-separated relative path, in sorted order.
+// With paths, only the files and directories at those paths relative to dir are
+// hashed; missing ones contribute nothing.
+func (s *Service) ComputeTreeHash(dir string, paths ...string) (string, error) {
+	roots := []string{dir}
+	if len(paths) > 0 {
+		roots = roots[:0]
+		for _, p := range paths {
+			root := filepath.Join(dir, p)
+			if _, err := os.Lstat(root); os.IsNotExist(err) {
+				continue
+			}
+			roots = append(roots, root)
+		}
+	}
+
+	var entries []string
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			entry, err := s.hashEntry(path, d)
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, fmt.Sprintf("%s %s", entry, filepath.ToSlash(relPath)))
+			return nil
+		})
+		if err != nil {
+			return "", models.NewFileSystemError(models.ErrorCodeFileSystemError, root, err)
+		}
+	}
+
+	sort.Strings(entries)
+
+	tree := sha256.New()
+	for _, entry := range entries {
+		_, _ = io.WriteString(tree, entry+"\n")
+	}
+
+	return TreeHashPrefix + hex.EncodeToString(tree.Sum(nil)), nil
+}
+
+// hashEntry returns the "<type> <sha256>" description of a single tree entry
+func (s *Service) hashEntry(path string, d fs.DirEntry) (string, error) {
+	if d.Type()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256([]byte(filepath.ToSlash(target)))
+		return "link " + hex.EncodeToString(sum[:]), nil
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	kind := "file"
+	if info.Mode().Perm()&0111 != 0 {
+		kind = "exec"
+	}
+
+	return kind + " " + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifyTreeHash checks that a directory, or the paths within it, match the
+// expected tree hash
+func (s *Service) VerifyTreeHash(dir, expected string, paths ...string) error {
+	actual, err := s.ComputeTreeHash(dir, paths...)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(expected), actual) {
+		return models.NewAppError(
+			models.ErrorCodeVerificationFailed,
+			fmt.Sprintf("Template content hash mismatch: expected %s, got %s", expected, actual),
+			nil,
+		).WithContext("path", dir)
+	}
+
+	return nil
+}
+
+// VerifySignature checks a detached ed25519 signature over the tree hash of the
+// directory, or of the paths within it. The signature file contains the
+// base64-encoded signature and publicKey is the base64-encoded ed25519 public key
+// declared by the template.
+func (s *Service) VerifySignature(dir, signaturePath, publicKey string, paths ...string) error {
+	keyBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(keyBytes) != ed25519.PublicKeySize {
+		return models.NewAppError(
+			models.ErrorCodeVerificationFailed,
+			"Template public key is not a valid base64-encoded ed25519 key",
+			err,
+		)
+	}
+
+	data, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return models.NewAppError(
+			models.ErrorCodeVerificationFailed,
+			fmt.Sprintf("Template signature file could not be read: %s", signaturePath),
+			err,
+		)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return models.NewAppError(
+			models.ErrorCodeVerificationFailed,
+			"Template signature is not valid base64",
+			err,
+		)
+	}
+
+	treeHash, err := s.ComputeTreeHash(dir, paths...)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(keyBytes), []byte(treeHash), signature) {
+		return models.NewAppError(
+			models.ErrorCodeVerificationFailed,
+			fmt.Sprintf("Template signature does not match content (tree hash %s)", treeHash),
+			nil,
+		).WithContext("path", dir)
+	}
+
+	return nil
+}
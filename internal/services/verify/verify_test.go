@@ -0,0 +1,185 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func createTestTree(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"core/agents/agent.md":      "agent",
+		"core/hooks/notify.py":      "print('hi')",
+		"guides/getting-started.md": "guide",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	return dir
+}
+
+func TestService_ComputeTreeHash(t *testing.T) {
+	service := New()
+	dir := createTestTree(t)
+
+	first, err := service.ComputeTreeHash(dir)
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+	if !strings.HasPrefix(first, TreeHashPrefix) {
+		t.Errorf("Expected hash with %s prefix, got %s", TreeHashPrefix, first)
+	}
+
+	second, err := service.ComputeTreeHash(dir)
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+	if first != second {
+		t.Error("Expected tree hash to be deterministic")
+	}
+
+	// Content changes must change the hash
+	if err := os.WriteFile(filepath.Join(dir, "guides", "getting-started.md"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	changed, _ := service.ComputeTreeHash(dir)
+	if changed == first {
+		t.Error("Expected tree hash to change when content changes")
+	}
+
+	// Mode changes must change the hash
+	if err := os.Chmod(filepath.Join(dir, "core", "hooks", "notify.py"), 0755); err != nil {
+		t.Fatalf("Failed to chmod file: %v", err)
+	}
+	executable, _ := service.ComputeTreeHash(dir)
+	if executable == changed {
+		t.Error("Expected tree hash to change when the executable bit changes")
+	}
+}
+
+func TestService_ComputeTreeHash_Paths(t *testing.T) {
+	service := New()
+	dir := createTestTree(t)
+
+	first, err := service.ComputeTreeHash(dir, "core", "setup.sh")
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+
+	// Files outside the paths do not count, a listed file that appears does
+	if err := os.WriteFile(filepath.Join(dir, "guides", "getting-started.md"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	if unchanged, _ := service.ComputeTreeHash(dir, "core", "setup.sh"); unchanged != first {
+		t.Error("Expected tree hash to ignore files outside the paths")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "setup.sh"), []byte("echo"), 0755); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if added, _ := service.ComputeTreeHash(dir, "core", "setup.sh"); added == first {
+		t.Error("Expected tree hash to change when a listed file is added")
+	}
+}
+
+func TestService_VerifyTreeHash(t *testing.T) {
+	service := New()
+	dir := createTestTree(t)
+
+	expected, err := service.ComputeTreeHash(dir)
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+
+	if err := service.VerifyTreeHash(dir, expected); err != nil {
+		t.Errorf("VerifyTreeHash() unexpected error = %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "core", "agents", "injected.md"), []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to add file: %v", err)
+	}
+
+	err = service.VerifyTreeHash(dir, expected)
+	if !models.IsErrorCode(err, models.ErrorCodeVerificationFailed) {
+		t.Errorf("Expected ErrorCodeVerificationFailed, got %v", err)
+	}
+}
+
+func TestService_VerifySignature(t *testing.T) {
+	service := New()
+	dir := createTestTree(t)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	encodedKey := base64.StdEncoding.EncodeToString(publicKey)
+
+	treeHash, err := service.ComputeTreeHash(dir)
+	if err != nil {
+		t.Fatalf("ComputeTreeHash() error = %v", err)
+	}
+
+	signaturePath := filepath.Join(t.TempDir(), "template.sig")
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(treeHash)))
+	if err := os.WriteFile(signaturePath, []byte(signature+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write signature: %v", err)
+	}
+
+	if err := service.VerifySignature(dir, signaturePath, encodedKey); err != nil {
+		t.Errorf("VerifySignature() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		setup     func()
+		publicKey string
+		sigPath   string
+	}{
+		{
+			name:      "invalid public key",
+			setup:     func() {},
+			publicKey: "not-a-key",
+			sigPath:   signaturePath,
+		},
+		{
+			name:      "missing signature file",
+			setup:     func() {},
+			publicKey: encodedKey,
+			sigPath:   filepath.Join(dir, "missing.sig"),
+		},
+		{
+			name: "tampered content",
+			setup: func() {
+				_ = os.WriteFile(filepath.Join(dir, "core", "hooks", "notify.py"), []byte("evil"), 0644)
+			},
+			publicKey: encodedKey,
+			sigPath:   signaturePath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.VerifySignature(dir, tt.sigPath, tt.publicKey)
+			if !models.IsErrorCode(err, models.ErrorCodeVerificationFailed) {
+				t.Errorf("Expected ErrorCodeVerificationFailed, got %v", err)
+			}
+		})
+	}
+}
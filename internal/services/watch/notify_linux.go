@@ -0,0 +1,170 @@
+//go:build linux
+
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// inotifyMask selects the events that change what status reports
+const inotifyMask = syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MODIFY | syscall.IN_ATTRIB |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF |
+	syscall.IN_DONT_FOLLOW
+
+// inotifyNotifier watches the root and every directory below the watched entries with inotify
+type inotifyNotifier struct {
+	watcher *Watcher
+	fd      int
+	file    *os.File
+	changes chan struct{}
+
+	mu   sync.Mutex
+	dirs map[int32]string // Watch descriptor to directory
+}
+
+// newNotifier uses inotify and falls back to polling when it is unavailable,
+// for example when the watch limit is reached
+func newNotifier(w *Watcher) (notifier, error) {
+	if n, err := newInotifyNotifier(w); err == nil {
+		return n, nil
+	}
+	return newPollNotifier(w), nil
+}
+
+func newInotifyNotifier(w *Watcher) (*inotifyNotifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	n := &inotifyNotifier{
+		watcher: w,
+		fd:      fd,
+		// A non-blocking descriptor uses the runtime poller, so Close stops a pending Read.
+		// File.Fd would make it blocking again, so the descriptor is kept separately.
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan struct{}, 1),
+		dirs:    make(map[int32]string),
+	}
+
+	// The root is watched for watched entries being created or removed
+	if err := n.add(w.root); err != nil {
+		n.file.Close()
+		return nil, err
+	}
+	for _, name := range w.names {
+		if err := n.addTree(filepath.Join(w.root, name)); err != nil {
+			n.file.Close()
+			return nil, err
+		}
+	}
+
+	go n.read()
+	return n, nil
+}
+
+func (n *inotifyNotifier) Changes() <-chan struct{} {
+	return n.changes
+}
+
+func (n *inotifyNotifier) Close() error {
+	return n.file.Close()
+}
+
+// add watches a single directory
+func (n *inotifyNotifier) add(dir string) error {
+	wd, err := syscall.InotifyAddWatch(n.fd, dir, inotifyMask)
+	if err != nil {
+		return os.NewSyscallError("inotify_add_watch", err)
+	}
+
+	n.mu.Lock()
+	n.dirs[int32(wd)] = dir
+	n.mu.Unlock()
+	return nil
+}
+
+// addTree watches dir and every directory below it; a missing dir is not an error
+func (n *inotifyNotifier) addTree(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return n.add(path)
+	})
+	return err
+}
+
+// read turns inotify events into change notifications until the descriptor is closed
+func (n *inotifyNotifier) read() {
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		changed := false
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(event.Len)]
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+			// Names are padded with NUL bytes
+			name := string(nameBytes)
+			if i := bytes.IndexByte(nameBytes, 0); i >= 0 {
+				name = string(nameBytes[:i])
+			}
+			if n.handle(event, name) {
+				changed = true
+			}
+		}
+		if changed {
+			signal(n.changes)
+		}
+	}
+}
+
+// handle watches new directories and reports whether the event is a change
+func (n *inotifyNotifier) handle(event *syscall.InotifyEvent, name string) bool {
+	if event.Mask&syscall.IN_Q_OVERFLOW != 0 {
+		return true
+	}
+	if event.Mask&syscall.IN_IGNORED != 0 {
+		n.mu.Lock()
+		delete(n.dirs, event.Wd)
+		n.mu.Unlock()
+		return false
+	}
+
+	n.mu.Lock()
+	dir, ok := n.dirs[event.Wd]
+	n.mu.Unlock()
+	if !ok || ignored(name) {
+		return false
+	}
+
+	// Only the watched entries matter in the root
+	if dir == n.watcher.root && !n.watcher.watched(name) {
+		return false
+	}
+
+	if name != "" && event.Mask&syscall.IN_ISDIR != 0 && event.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+		// Best effort: a directory that cannot be watched is still reported as a change
+		_ = n.addTree(filepath.Join(dir, name))
+	}
+	return true
+}
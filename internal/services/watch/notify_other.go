@@ -0,0 +1,8 @@
+//go:build !linux
+
+package watch
+
+// newNotifier polls for changes on platforms without inotify support
+func newNotifier(w *Watcher) (notifier, error) {
+	return newPollNotifier(w), nil
+}
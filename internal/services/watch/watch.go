@@ -0,0 +1,185 @@
+// Package watch notices changes to the files of an installation, so status can
+// be checked again as soon as framework files are edited.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+const (
+	// DefaultDebounce coalesces the burst of events an editor save produces
+	DefaultDebounce = 200 * time.Millisecond
+	// DefaultPollInterval is how often the polling fallback looks for changes
+	DefaultPollInterval = time.Second
+)
+
+// notifier signals on Changes whenever something below the watched paths changed
+type notifier interface {
+	Changes() <-chan struct{}
+	Close() error
+}
+
+// Watcher watches entries of a directory recursively
+type Watcher struct {
+	root         string
+	names        []string
+	debounce     time.Duration
+	pollInterval time.Duration
+}
+
+// New creates a watcher for the named entries of root, e.g. .claude; entries
+// created after the watch started are picked up
+func New(root string, names ...string) *Watcher {
+	return &Watcher{
+		root:         root,
+		names:        names,
+		debounce:     DefaultDebounce,
+		pollInterval: DefaultPollInterval,
+	}
+}
+
+// Run calls onChange after the watched files change, until ctx is done.
+// Changes arriving within the debounce interval result in a single call.
+func (w *Watcher) Run(ctx context.Context, onChange func()) error {
+	n, err := newNotifier(w)
+	if err != nil {
+		return err
+	}
+	defer n.Close()
+
+	var timer *time.Timer
+	var fire <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return nil
+		case <-n.Changes():
+			if timer == nil {
+				timer = time.NewTimer(w.debounce)
+			} else {
+				timer.Reset(w.debounce)
+			}
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			onChange()
+		}
+	}
+}
+
+// watched reports whether a top-level entry of root is watched
+func (w *Watcher) watched(name string) bool {
+	for _, watchedName := range w.names {
+		if name == watchedName {
+			return true
+		}
+	}
+	return false
+}
+
+// ignored reports whether changes to a file are not worth a notification
+func ignored(name string) bool {
+	return name == config.WriteProbeFile
+}
+
+// signal sends a change notification unless one is already pending
+func signal(changes chan struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
+
+// pollNotifier compares snapshots of the watched files at a fixed interval
+type pollNotifier struct {
+	changes chan struct{}
+	done    chan struct{}
+}
+
+// fileState is what a snapshot records about a file. Directory modification
+// times are left out, since checking writability touches them.
+type fileState struct {
+	mode    fs.FileMode
+	size    int64
+	modTime time.Time
+}
+
+func newPollNotifier(w *Watcher) *pollNotifier {
+	p := &pollNotifier{changes: make(chan struct{}, 1), done: make(chan struct{})}
+
+	go func() {
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+
+		previous := w.snapshot()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				current := w.snapshot()
+				if !sameSnapshot(previous, current) {
+					signal(p.changes)
+				}
+				previous = current
+			}
+		}
+	}()
+
+	return p
+}
+
+func (p *pollNotifier) Changes() <-chan struct{} {
+	return p.changes
+}
+
+func (p *pollNotifier) Close() error {
+	close(p.done)
+	return nil
+}
+
+// snapshot records every path below the watched entries
+func (w *Watcher) snapshot() map[string]fileState {
+	states := make(map[string]fileState)
+	for _, name := range w.names {
+		_ = filepath.WalkDir(filepath.Join(w.root, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || ignored(d.Name()) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			state := fileState{mode: info.Mode()}
+			if !d.IsDir() {
+				state.size = info.Size()
+				state.modTime = info.ModTime()
+			}
+			states[path] = state
+			return nil
+		})
+	}
+	return states
+}
+
+// sameSnapshot reports whether two snapshots record the same files in the same state
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, ok := b[path]
+		if !ok || other.mode != state.mode || other.size != state.size || !other.modTime.Equal(state.modTime) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+// runWatcher runs w until the test ends and returns a channel receiving every onChange call
+func runWatcher(t *testing.T, w *Watcher) <-chan struct{} {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, func() { calls <- struct{}{} })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	})
+	return calls
+}
+
+// expectChange writes path until onChange is called; writing again covers
+// changes made before the watcher was ready
+func expectChange(t *testing.T, calls <-chan struct{}, path string) {
+	t.Helper()
+
+	deadline := time.After(5 * time.Second)
+	for i := 0; ; i++ {
+		if err := os.WriteFile(path, []byte{byte(i)}, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		select {
+		case <-calls:
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-deadline:
+			t.Fatalf("no change reported for %s", path)
+		}
+	}
+}
+
+// expectNoChange fails when onChange is called within a short time
+func expectNoChange(t *testing.T, calls <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-calls:
+		t.Error("change reported, want none")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func newTestWatcher(root string) *Watcher {
+	w := New(root, config.ClaudeDir, config.StrategicClaudeBasicDir)
+	w.debounce = 10 * time.Millisecond
+	w.pollInterval = 20 * time.Millisecond
+	return w
+}
+
+func TestWatcher_Run(t *testing.T) {
+	root := t.TempDir()
+	hooksDir := filepath.Join(root, config.ClaudeDir, config.HooksDir)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	calls := runWatcher(t, newTestWatcher(root))
+
+	// Files in nested directories
+	expectChange(t, calls, filepath.Join(hooksDir, "hook.py"))
+
+	// Directories created after the watch started
+	strategicDir := filepath.Join(root, config.StrategicClaudeBasicDir, config.CoreDir)
+	if err := os.MkdirAll(strategicDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	expectChange(t, calls, filepath.Join(strategicDir, "agent.md"))
+
+	// Drain the notifications of the last writes
+	time.Sleep(100 * time.Millisecond)
+	for len(calls) > 0 {
+		<-calls
+	}
+
+	// Unwatched entries and writability probes are not changes
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	probe := filepath.Join(root, config.ClaudeDir, config.WriteProbeFile)
+	if err := os.WriteFile(probe, nil, 0644); err != nil {
+		t.Fatalf("Failed to write probe: %v", err)
+	}
+	if err := os.Remove(probe); err != nil {
+		t.Fatalf("Failed to remove probe: %v", err)
+	}
+	expectNoChange(t, calls)
+}
+
+func TestPollNotifier(t *testing.T) {
+	root := t.TempDir()
+	claudeDir := filepath.Join(root, config.ClaudeDir)
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	n := newPollNotifier(newTestWatcher(root))
+	defer n.Close()
+
+	probe := filepath.Join(claudeDir, config.WriteProbeFile)
+	if err := os.WriteFile(probe, nil, 0644); err != nil {
+		t.Fatalf("Failed to write probe: %v", err)
+	}
+	select {
+	case <-n.Changes():
+		t.Error("writability probe reported as a change")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	select {
+	case <-n.Changes():
+	case <-time.After(5 * time.Second):
+		t.Error("no change reported for a new file")
+	}
+}
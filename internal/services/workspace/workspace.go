@@ -0,0 +1,113 @@
+// Package workspace finds the projects with an installation below a directory,
+// for commands that work on many projects at once.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// skippedDirs hold dependencies rather than projects and are not searched
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// Discover returns the projects below root, root included, whose framework
+// directory has template metadata, in lexical order. The framework directory is
+// the one the project config file names, else the current one. Hidden and
+// dependency directories are not searched, and symlinks are not followed.
+func Discover(root string) ([]string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeInvalidPath, root, err)
+	}
+	info, err := os.Stat(absRoot)
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeDirectoryNotFound, absRoot, err)
+	}
+	if !info.IsDir() {
+		return nil, models.NewValidationError("root", root, "must be a directory")
+	}
+
+	var projects []string
+	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories cannot hold projects we could update
+			if d != nil && d.IsDir() && path != absRoot {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != absRoot && (strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()]) {
+			return fs.SkipDir
+		}
+		if IsInstalled(path) {
+			projects = append(projects, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, absRoot, err)
+	}
+	return projects, nil
+}
+
+// IsInstalled reports whether dir has a framework directory with template
+// metadata, under the current name or the one its project config file sets
+func IsInstalled(dir string) bool {
+	frameworkDirs := []string{config.FrameworkDir()}
+	if _, err := os.Stat(config.ProjectConfigPath(dir)); err == nil {
+		if cfg, err := config.LoadProjectConfig(dir); err == nil {
+			frameworkDirs = append(frameworkDirs, cfg.FrameworkDir)
+		}
+	}
+	for _, frameworkDir := range frameworkDirs {
+		if _, err := os.Stat(filepath.Join(dir, frameworkDir, config.TemplateInfoFile)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Run calls fn for each project, with its index, with at most jobs calls
+// running at once, and returns the errors of the projects that failed joined in
+// project order, or nil. fn must be safe to call concurrently when jobs > 1.
+func Run(projects []string, jobs int, fn func(i int, project string) error) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	errs := make([]error, len(projects))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(jobs, len(projects)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(i, projects[i]); err != nil {
+					errs[i] = fmt.Errorf("%s: %w", projects[i], err)
+				}
+			}
+		}()
+	}
+	for i := range projects {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
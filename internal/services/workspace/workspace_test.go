@@ -0,0 +1,96 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	install := func(project, frameworkDir string) {
+		t.Helper()
+		path := filepath.Join(root, project, frameworkDir, config.TemplateInfoFile)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	install("", config.StrategicClaudeBasicDir)
+	install("api", config.StrategicClaudeBasicDir)
+	install(filepath.Join("services", "web"), ".scb")
+	install(filepath.Join("web", "node_modules", "pkg"), config.StrategicClaudeBasicDir)
+	install(filepath.Join(".cache", "copy"), config.StrategicClaudeBasicDir)
+	// A framework directory without metadata is not an installation
+	if err := os.MkdirAll(filepath.Join(root, "partial", config.StrategicClaudeBasicDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveProjectFrameworkDir(filepath.Join(root, "services", "web"), ".scb"); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	want := []string{root, filepath.Join(root, "api"), filepath.Join(root, "services", "web")}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("Discover() = %v, want %v", projects, want)
+	}
+
+	if _, err := Discover(filepath.Join(root, "missing")); err == nil {
+		t.Error("Discover() of a missing root returned no error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	projects := []string{"a", "b", "c", "d", "e", "f"}
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	visited := make(map[int]string)
+
+	err := Run(projects, 2, func(i int, project string) error {
+		n := running.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		defer running.Add(-1)
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		visited[i] = project
+		mu.Unlock()
+		if project == "b" || project == "e" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if len(visited) != len(projects) {
+		t.Errorf("Run() visited %d projects, want %d", len(visited), len(projects))
+	}
+	for i, project := range projects {
+		if visited[i] != project {
+			t.Errorf("Run() passed %q as project %d, want %q", visited[i], i, project)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Run() ran %d projects at once, want at most 2", peak.Load())
+	}
+	if err == nil || err.Error() != "b: failed\ne: failed" {
+		t.Errorf("Run() error = %v, want the failures of b and e in order", err)
+	}
+
+	if err := Run(projects, 0, func(int, string) error { return nil }); err != nil {
+		t.Errorf("Run() with jobs 0 error = %v", err)
+	}
+}
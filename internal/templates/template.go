@@ -31,6 +31,11 @@ type Template struct {
 
 	// Whether this template is deprecated
 	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Optional supply-chain verification of the fetched framework directory and
+	// installation scripts, see config.GetVerifiedTemplatePaths
+	TreeHash  string `json:"tree_hash,omitempty"`  // Expected "sha256:<hex>" tree hash
+	PublicKey string `json:"public_key,omitempty"` // Base64 ed25519 key that signed the tree hash
 }
 
 // TemplateInfo represents metadata about an installed template
@@ -44,6 +49,28 @@ type TemplateInfo struct {
 	// Version or commit at time of installation
 	InstalledCommit string `json:"installed_commit"`
 
+	// Tool directories set up by the installation; empty for installations that
+	// predate the selection, which set up the default integrations
+	Integrations []string `json:"integrations,omitempty"`
+
+	// How Cursor rules were installed, when the cursor integration is selected
+	CursorMode string `json:"cursor_mode,omitempty"`
+
+	// How the .claude and .codex symlink targets are written; empty for
+	// installations that predate the setting, which use relative targets
+	SymlinkStyle string `json:"symlink_style,omitempty"`
+
+	// Whether the core, guides and templates files were made read-only, so
+	// doctor can re-assert it
+	ReadOnlyCore bool `json:"readonly_core,omitempty"`
+
+	// Name of the framework directory the installation lives in; empty for
+	// installations that predate the setting, which use .strategic-claude-basic
+	FrameworkDir string `json:"framework_dir,omitempty"`
+
+	// Named installation, empty for the default one
+	Instance string `json:"instance,omitempty"`
+
 	// Any additional installation metadata
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
@@ -78,6 +105,11 @@ func (t *Template) IsValid() error {
 	return nil
 }
 
+// RequiresVerification returns true if the template declares a tree hash or signing key
+func (t *Template) RequiresVerification() bool {
+	return t.TreeHash != "" || t.PublicKey != ""
+}
+
 // DisplayName returns a formatted display name for UI
 func (t *Template) DisplayName() string {
 	if t.Deprecated {
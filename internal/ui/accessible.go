@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// accessible makes selectors ask with plain numbered prompts
+var accessible bool
+
+// SetAccessible makes selectors ask with plain numbered prompts instead of
+// Bubble Tea, and styled output drop its colors, for screen readers and dumb
+// terminals
+func SetAccessible(on bool) {
+	accessible = on
+	if on {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// Accessible reports whether output avoids cursor movement and colors
+func Accessible() bool {
+	return accessible
+}
+
+// interactive reports whether selectors can run the rich Bubble Tea UI
+func interactive() bool {
+	return !accessible && isTTY()
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Choices for installing over an existing installation
+const (
+	ConflictUpdate    = "update"    // Update the core files, like --force-core
+	ConflictOverwrite = "overwrite" // Replace the installation, like --force
+	ConflictCancel    = "cancel"    // Leave the installation alone
+)
+
+// ConflictOption represents a way to proceed with an existing installation
+type ConflictOption struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// ConflictSelectorModel represents the state of the existing installation prompt
+type ConflictSelectorModel struct {
+	targetDir string
+	options   []ConflictOption
+	cursor    int
+	selected  string
+	quitting  bool
+}
+
+// getConflictOptions returns the ways to proceed, the safest first
+func getConflictOptions() []ConflictOption {
+	return []ConflictOption{
+		{
+			ID:          ConflictUpdate,
+			Name:        i18n.T("Update core files (--force-core)"),
+			Description: i18n.T("Replace core, guides and templates; keep your documents and settings"),
+		},
+		{
+			ID:          ConflictOverwrite,
+			Name:        i18n.T("Overwrite everything (--force)"),
+			Description: i18n.T("Replace the whole framework directory, user content included; a backup is taken first"),
+		},
+		{
+			ID:          ConflictCancel,
+			Name:        i18n.T("Cancel"),
+			Description: i18n.T("Leave the installation as it is"),
+		},
+	}
+}
+
+// NewConflictSelectorModel creates a new existing installation prompt for targetDir
+func NewConflictSelectorModel(targetDir string) ConflictSelectorModel {
+	return ConflictSelectorModel{
+		targetDir: targetDir,
+		options:   getConflictOptions(),
+	}
+}
+
+// Init is called when the program starts
+func (m ConflictSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input events and updates the model state
+func (m ConflictSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case keyCtrlC, keyQ, keyEsc:
+			m.quitting = true
+			return m, tea.Quit
+		case keyEnter, "tab":
+			if len(m.options) > 0 {
+				m.selected = m.options[m.cursor].ID
+			}
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case keyDown, "j":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the existing installation prompt
+func (m ConflictSelectorModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var s strings.Builder
+
+	// Title and explanation
+	s.WriteString(titleStyle.Render(i18n.T("Strategic Claude Basic Is Already Installed")))
+	s.WriteString("\n\n")
+	s.WriteString(descriptionStyle.Render(i18n.T("%s already has an installation. Choose how to proceed:", m.targetDir)))
+	s.WriteString("\n\n")
+
+	// Options list
+	for i, option := range m.options {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %s", cursor, option.Name)
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.Render(line))
+		} else {
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+
+		if option.Description != "" {
+			var desc string
+			if i == m.cursor {
+				desc = selectedDescriptionStyle.Render(option.Description)
+			} else {
+				desc = descriptionStyle.Render(option.Description)
+			}
+			s.WriteString(desc)
+			s.WriteString("\n")
+		}
+		s.WriteString("\n")
+	}
+
+	// Help text
+	s.WriteString(helpStyle.Render(utils.Symbols(i18n.T("↑/↓: navigate • enter: select • q: cancel"))))
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+// GetSelectedOption returns the selected option ID
+func (m ConflictSelectorModel) GetSelectedOption() string {
+	return m.selected
+}
+
+// IsQuitting returns whether the user cancelled the prompt
+func (m ConflictSelectorModel) IsQuitting() bool {
+	return m.quitting && m.selected == ""
+}
+
+// fallbackSelectConflict provides a simple prompt-based selector when TTY isn't available
+func fallbackSelectConflict(targetDir string, availableOptions []ConflictOption) (string, error) {
+	fmt.Println()
+	fmt.Println(i18n.T("%s already has a Strategic Claude Basic installation. Choose how to proceed:", targetDir))
+	for i, option := range availableOptions {
+		fmt.Printf("  %d. %s\n", i+1, option.Name)
+		if option.Description != "" {
+			fmt.Printf("     %s\n", option.Description)
+		}
+	}
+	fmt.Println()
+
+	interactionService := utils.NewInteractionService()
+	for {
+		input, err := interactionService.PromptWithDefault(i18n.T("Select an option (1-%d)", len(availableOptions)), "1")
+		if err != nil {
+			return "", fmt.Errorf("failed to get user input: %w", err)
+		}
+
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil || choice < 1 || choice > len(availableOptions) {
+			fmt.Println(i18n.T("Invalid selection. Please enter a number between 1 and %d.", len(availableOptions)))
+			continue
+		}
+
+		selectedOption := availableOptions[choice-1]
+		fmt.Println(i18n.T("Selected: %s", selectedOption.Name))
+		return selectedOption.ID, nil
+	}
+}
+
+// SelectConflictResolution asks how to proceed with the installation in
+// targetDir and returns ConflictUpdate, ConflictOverwrite or ConflictCancel
+func SelectConflictResolution(targetDir string) (string, error) {
+	availableOptions := getConflictOptions()
+
+	// Check if we have a TTY for interactive mode
+	if !interactive() {
+		// Fallback to simple prompts
+		return fallbackSelectConflict(targetDir, availableOptions)
+	}
+
+	// Run interactive Bubble Tea selector
+	p := tea.NewProgram(NewConflictSelectorModel(targetDir))
+	finalModel, err := p.Run()
+	if err != nil {
+		// If Bubble Tea fails, fallback to simple prompts
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
+		return fallbackSelectConflict(targetDir, availableOptions)
+	}
+
+	model := finalModel.(ConflictSelectorModel)
+	if model.IsQuitting() {
+		return ConflictCancel, nil
+	}
+
+	for _, option := range availableOptions {
+		if option.ID == model.GetSelectedOption() {
+			fmt.Printf("\n%s\n", i18n.T("Selected: %s", option.Name))
+			break
+		}
+	}
+	return model.GetSelectedOption(), nil
+}
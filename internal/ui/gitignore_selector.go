@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -152,7 +153,7 @@ func (m GitignoreModeSelectorModel) View() string {
 	}
 
 	// Help text
-	s.WriteString(helpStyle.Render("↑/↓: navigate • enter: select • q: quit"))
+	s.WriteString(helpStyle.Render(utils.Symbols(i18n.T("↑/↓: navigate • enter: select • q: quit"))))
 	s.WriteString("\n")
 
 	return s.String()
@@ -191,12 +192,12 @@ func fallbackSelectGitignoreMode(availableOptions []GitignoreModeOption) (string
 
 		choice, err := strconv.Atoi(strings.TrimSpace(input))
 		if err != nil || choice < 1 || choice > len(availableOptions) {
-			fmt.Printf("Invalid selection. Please enter a number between 1 and %d.\n", len(availableOptions))
+			fmt.Println(i18n.T("Invalid selection. Please enter a number between 1 and %d.", len(availableOptions)))
 			continue
 		}
 
 		selectedOption := availableOptions[choice-1]
-		fmt.Printf("Selected: %s\n", selectedOption.Name)
+		fmt.Println(i18n.T("Selected: %s", selectedOption.Name))
 		return selectedOption.ID, nil
 	}
 }
@@ -209,7 +210,7 @@ func SelectGitignoreMode() (string, error) {
 	}
 
 	// Check if we have a TTY for interactive mode
-	if !isTTY() {
+	if !interactive() {
 		// Fallback to simple prompts
 		return fallbackSelectGitignoreMode(availableOptions)
 	}
@@ -221,7 +222,7 @@ func SelectGitignoreMode() (string, error) {
 	finalModel, err := p.Run()
 	if err != nil {
 		// If Bubble Tea fails, fallback to simple prompts
-		fmt.Printf("Interactive mode failed (%v), falling back to simple mode...\n", err)
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
 		return fallbackSelectGitignoreMode(availableOptions)
 	}
 
@@ -244,6 +245,6 @@ func SelectGitignoreMode() (string, error) {
 		}
 	}
 
-	fmt.Printf("\nSelected: %s\n", selectedOption.Name)
+	fmt.Printf("\n%s\n", i18n.T("Selected: %s", selectedOption.Name))
 	return selectedID, nil
 }
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IntegrationOption represents an integration choice
+type IntegrationOption struct {
+	ID          string
+	Name        string
+	Description string
+	Required    bool // Always selected, cannot be toggled
+}
+
+// IntegrationSelectorModel represents the state of the integration multi-select
+type IntegrationSelectorModel struct {
+	options   []IntegrationOption
+	cursor    int
+	selected  map[string]bool
+	confirmed bool
+	quitting  bool
+}
+
+// getIntegrationOptions returns available integration options
+func getIntegrationOptions() []IntegrationOption {
+	return []IntegrationOption{
+		{
+			ID:          models.IntegrationClaude,
+			Name:        "Claude Code (.claude)",
+			Description: "Agents, commands, hooks and settings.json - required",
+			Required:    true,
+		},
+		{
+			ID:          models.IntegrationCodex,
+			Name:        "Codex (.codex)",
+			Description: "Prompts, hooks and config.toml",
+		},
+		{
+			ID:          models.IntegrationCursor,
+			Name:        "Cursor (.cursor)",
+			Description: "The template's Cursor rules in .cursor/rules/strategic",
+		},
+		{
+			ID:          models.IntegrationAider,
+			Name:        "Aider (.aider.conf.yml)",
+			Description: "Reads the framework conventions and command hints",
+		},
+		{
+			ID:          models.IntegrationOpenCode,
+			Name:        "OpenCode (opencode.json)",
+			Description: "Framework conventions and command hints as instructions",
+		},
+	}
+}
+
+// NewIntegrationSelectorModel creates a new integration selector model with
+// the given integrations preselected
+func NewIntegrationSelectorModel(preselected []string) IntegrationSelectorModel {
+	options := getIntegrationOptions()
+
+	selected := make(map[string]bool)
+	for _, option := range options {
+		selected[option.ID] = option.Required || slices.Contains(preselected, option.ID)
+	}
+
+	return IntegrationSelectorModel{
+		options:  options,
+		selected: selected,
+	}
+}
+
+// Init is called when the program starts
+func (m IntegrationSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input events and updates the model state
+func (m IntegrationSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case keyCtrlC, keyQ, keyEsc:
+			m.quitting = true
+			return m, tea.Quit
+		case keyEnter:
+			m.confirmed = true
+			return m, tea.Quit
+		case " ":
+			if option := m.options[m.cursor]; !option.Required {
+				m.selected[option.ID] = !m.selected[option.ID]
+			}
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case keyDown, "j":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the integration selector UI
+func (m IntegrationSelectorModel) View() string {
+	if m.quitting {
+		if !m.confirmed {
+			return quitTextStyle.Render("Selection cancelled.\n")
+		}
+		return ""
+	}
+
+	var s strings.Builder
+
+	// Title
+	s.WriteString(titleStyle.Render("Select Integrations"))
+	s.WriteString("\n\n")
+
+	// Integration options list
+	for i, option := range m.options {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		checkbox := "[ ]"
+		if m.selected[option.ID] {
+			checkbox = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s %s", cursor, checkbox, option.Name)
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.Render(line))
+		} else {
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+
+		// Description
+		if option.Description != "" {
+			var desc string
+			if i == m.cursor {
+				desc = selectedDescriptionStyle.Render(option.Description)
+			} else {
+				desc = descriptionStyle.Render(option.Description)
+			}
+			s.WriteString(desc)
+			s.WriteString("\n")
+		}
+		s.WriteString("\n")
+	}
+
+	// Help text
+	s.WriteString(helpStyle.Render(utils.Symbols(i18n.T("↑/↓: navigate • space: toggle • enter: confirm • q: quit"))))
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+// GetSelectedIntegrations returns the selected integration IDs in display order
+func (m IntegrationSelectorModel) GetSelectedIntegrations() []string {
+	var integrations []string
+	for _, option := range m.options {
+		if m.selected[option.ID] {
+			integrations = append(integrations, option.ID)
+		}
+	}
+	return integrations
+}
+
+// IsQuitting returns whether the user cancelled the selection
+func (m IntegrationSelectorModel) IsQuitting() bool {
+	return m.quitting && !m.confirmed
+}
+
+// fallbackSelectIntegrations provides a simple prompt-based selector when TTY isn't available
+func fallbackSelectIntegrations(availableOptions []IntegrationOption, preselected []string) ([]string, error) {
+	// Display integration options
+	fmt.Println()
+	fmt.Println("Available integrations:")
+	for i, option := range availableOptions {
+		fmt.Printf("  %d. %s: %s\n", i+1, option.ID, option.Name)
+		if option.Description != "" {
+			fmt.Printf("     %s\n", option.Description)
+		}
+	}
+	fmt.Println()
+
+	// Get user selection
+	interactionService := utils.NewInteractionService()
+	for {
+		input, err := interactionService.PromptWithDefault("Select integrations by number or name (comma-separated)", strings.Join(preselected, ","))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user input: %w", err)
+		}
+
+		// Numbers select the integration listed with them
+		items := strings.Split(input, ",")
+		for j, item := range items {
+			if choice, err := strconv.Atoi(strings.TrimSpace(item)); err == nil && choice >= 1 && choice <= len(availableOptions) {
+				items[j] = availableOptions[choice-1].ID
+			}
+		}
+
+		integrations, err := models.ParseIntegrations(strings.Join(items, ","))
+		if err != nil {
+			fmt.Println(i18n.T("Invalid selection: %v", err))
+			continue
+		}
+
+		fmt.Println(i18n.T("Selected: %s", strings.Join(integrations, ", ")))
+		return integrations, nil
+	}
+}
+
+// SelectIntegrations runs the interactive integration selector with the given
+// integrations preselected and returns the selected integration IDs
+func SelectIntegrations(preselected []string) ([]string, error) {
+	availableOptions := getIntegrationOptions()
+
+	// Check if we have a TTY for interactive mode
+	if !interactive() {
+		// Fallback to simple prompts
+		return fallbackSelectIntegrations(availableOptions, preselected)
+	}
+
+	// Run interactive Bubble Tea selector
+	m := NewIntegrationSelectorModel(preselected)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		// If Bubble Tea fails, fallback to simple prompts
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
+		return fallbackSelectIntegrations(availableOptions, preselected)
+	}
+
+	model := finalModel.(IntegrationSelectorModel)
+	if model.IsQuitting() {
+		return nil, fmt.Errorf("integration selection cancelled by user")
+	}
+
+	integrations := model.GetSelectedIntegrations()
+	fmt.Printf("\n%s\n", i18n.T("Selected: %s", strings.Join(integrations, ", ")))
+	return integrations, nil
+}
@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -146,7 +149,7 @@ func (m MCPSelectorModel) View() string {
 	}
 
 	// Help text
-	s.WriteString(mcpHelpStyle.Render("↑/↓: navigate • space: toggle selection • enter: confirm • q: quit"))
+	s.WriteString(mcpHelpStyle.Render(utils.Symbols("↑/↓: navigate • space: toggle selection • enter: confirm • q: quit")))
 	s.WriteString("\n")
 
 	return s.String()
@@ -173,19 +176,64 @@ func (m MCPSelectorModel) IsQuitting() bool {
 	return m.quitting && !m.confirmed
 }
 
+// fallbackSelectMCPs provides a simple prompt-based selector when TTY isn't available
+func fallbackSelectMCPs(availableMCPs []models.MCPTemplate) ([]models.MCPTemplate, error) {
+	fmt.Println()
+	fmt.Println("Available MCP servers:")
+	for i, mcp := range availableMCPs {
+		fmt.Printf("  %d. %s\n", i+1, mcp.Name)
+		fmt.Printf("     Command: %s %s\n", mcp.Server.Command, strings.Join(mcp.Server.Args, " "))
+	}
+	fmt.Println()
+
+	interactionService := utils.NewInteractionService()
+	for {
+		input, err := interactionService.PromptWithDefault(fmt.Sprintf("Select MCP servers (comma-separated numbers, 1-%d)", len(availableMCPs)), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user input: %w", err)
+		}
+
+		var selected []models.MCPTemplate
+		valid := true
+		for _, item := range strings.Split(input, ",") {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+			choice, err := strconv.Atoi(strings.TrimSpace(item))
+			if err != nil || choice < 1 || choice > len(availableMCPs) {
+				fmt.Println(i18n.T("Invalid selection. Please enter a number between 1 and %d.", len(availableMCPs)))
+				valid = false
+				break
+			}
+			selected = append(selected, availableMCPs[choice-1])
+		}
+		if valid {
+			return selected, nil
+		}
+	}
+}
+
 // SelectMCPs runs the interactive MCP selector and returns the selected MCPs
 func SelectMCPs(availableMCPs []models.MCPTemplate) ([]models.MCPTemplate, error) {
 	if len(availableMCPs) == 0 {
 		return nil, fmt.Errorf("no MCP servers available for installation")
 	}
 
+	// Check if we have a TTY for interactive mode
+	if !interactive() {
+		// Fallback to simple prompts
+		return fallbackSelectMCPs(availableMCPs)
+	}
+
 	// Run interactive selector
 	m := NewMCPSelectorModel(availableMCPs)
 	p := tea.NewProgram(m)
 
 	finalModel, err := p.Run()
 	if err != nil {
-		return nil, fmt.Errorf("failed to run MCP selector: %w", err)
+		// If Bubble Tea fails, fallback to simple prompts
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
+		return fallbackSelectMCPs(availableMCPs)
 	}
 
 	model := finalModel.(MCPSelectorModel)
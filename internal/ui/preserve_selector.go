@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PreserveOption is a user directory holding documents that a full overwrite can keep
+type PreserveOption struct {
+	Dir       string
+	Documents int
+}
+
+// PreserveSelectorModel represents the state of the checklist of user
+// directories to carry over into a full overwrite
+type PreserveSelectorModel struct {
+	targetDir string
+	options   []PreserveOption
+	cursor    int
+	selected  map[string]bool
+	confirmed bool
+	quitting  bool
+}
+
+// NewPreserveSelectorModel creates a new preserve selector model with every
+// directory selected
+func NewPreserveSelectorModel(targetDir string, options []PreserveOption) PreserveSelectorModel {
+	selected := make(map[string]bool)
+	for _, option := range options {
+		selected[option.Dir] = true
+	}
+
+	return PreserveSelectorModel{
+		targetDir: targetDir,
+		options:   options,
+		selected:  selected,
+	}
+}
+
+// Init is called when the program starts
+func (m PreserveSelectorModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles input events and updates the model state
+func (m PreserveSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case keyCtrlC, keyQ, keyEsc:
+			m.quitting = true
+			return m, tea.Quit
+		case keyEnter:
+			m.confirmed = true
+			return m, tea.Quit
+		case " ":
+			dir := m.options[m.cursor].Dir
+			m.selected[dir] = !m.selected[dir]
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case keyDown, "j":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the preserve selector UI
+func (m PreserveSelectorModel) View() string {
+	if m.quitting {
+		if !m.confirmed {
+			return quitTextStyle.Render("Selection cancelled.\n")
+		}
+		return ""
+	}
+
+	var s strings.Builder
+
+	// Title
+	s.WriteString(titleStyle.Render(i18n.T("Select User Content to Keep")))
+	s.WriteString("\n\n")
+	s.WriteString(descriptionStyle.Render(i18n.T("--force replaces the framework directory of %s; the checked directories are carried over:", m.targetDir)))
+	s.WriteString("\n\n")
+
+	// Directory list
+	for i, option := range m.options {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		checkbox := "[ ]"
+		if m.selected[option.Dir] {
+			checkbox = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s %s", cursor, checkbox, i18n.T("%s (%d documents)", option.Dir, option.Documents))
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.Render(line))
+		} else {
+			s.WriteString(itemStyle.Render(line))
+		}
+		s.WriteString("\n")
+	}
+
+	// Help text
+	s.WriteString(helpStyle.Render(utils.Symbols(i18n.T("↑/↓: navigate • space: toggle • enter: confirm • q: quit"))))
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+// GetSelectedDirs returns the selected directories in display order
+func (m PreserveSelectorModel) GetSelectedDirs() []string {
+	dirs := make([]string, 0, len(m.options))
+	for _, option := range m.options {
+		if m.selected[option.Dir] {
+			dirs = append(dirs, option.Dir)
+		}
+	}
+	return dirs
+}
+
+// IsQuitting returns whether the user cancelled the selection
+func (m PreserveSelectorModel) IsQuitting() bool {
+	return m.quitting && !m.confirmed
+}
+
+// fallbackSelectPreserved provides a simple prompt-based selector when TTY isn't available
+func fallbackSelectPreserved(targetDir string, options []PreserveOption) ([]string, error) {
+	// Display directory options
+	fmt.Println()
+	fmt.Println(i18n.T("--force replaces the framework directory of %s; the listed directories can be carried over:", targetDir))
+	all := make([]string, 0, len(options))
+	for i, option := range options {
+		fmt.Printf("  %d. %s\n", i+1, i18n.T("%s (%d documents)", option.Dir, option.Documents))
+		all = append(all, option.Dir)
+	}
+	fmt.Println()
+
+	// Get user selection
+	interactionService := utils.NewInteractionService()
+	for {
+		input, err := interactionService.PromptWithDefault(i18n.T("Directories to keep by number or name (comma-separated, none for none)"), strings.Join(all, ","))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user input: %w", err)
+		}
+		if strings.EqualFold(strings.TrimSpace(input), "none") {
+			return []string{}, nil
+		}
+
+		// Numbers select the directory listed with them
+		var dirs []string
+		var invalid string
+		for _, item := range strings.Split(input, ",") {
+			item = strings.TrimSpace(item)
+			if choice, err := strconv.Atoi(item); err == nil && choice >= 1 && choice <= len(options) {
+				item = options[choice-1].Dir
+			}
+			switch {
+			case item == "":
+			case !slices.Contains(all, item):
+				invalid = item
+			case !slices.Contains(dirs, item):
+				dirs = append(dirs, item)
+			}
+		}
+		if invalid != "" {
+			fmt.Println(i18n.T("Invalid selection: %v", invalid))
+			continue
+		}
+
+		fmt.Println(i18n.T("Selected: %s", strings.Join(dirs, ", ")))
+		return dirs, nil
+	}
+}
+
+// SelectPreserved runs the checklist of the user directories of targetDir a
+// full overwrite carries over, all of them checked, and returns the checked ones
+func SelectPreserved(targetDir string, options []PreserveOption) ([]string, error) {
+	// Check if we have a TTY for interactive mode
+	if !interactive() {
+		// Fallback to simple prompts
+		return fallbackSelectPreserved(targetDir, options)
+	}
+
+	// Run interactive Bubble Tea selector
+	m := NewPreserveSelectorModel(targetDir, options)
+	p := tea.NewProgram(m)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		// If Bubble Tea fails, fallback to simple prompts
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
+		return fallbackSelectPreserved(targetDir, options)
+	}
+
+	model := finalModel.(PreserveSelectorModel)
+	if model.IsQuitting() {
+		return nil, fmt.Errorf("preserve selection cancelled by user")
+	}
+
+	dirs := model.GetSelectedDirs()
+	fmt.Printf("\n%s\n", i18n.T("Selected: %s", strings.Join(dirs, ", ")))
+	return dirs, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/templates"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/utils"
 
@@ -153,7 +154,7 @@ func (m TemplateSelectorModel) View() string {
 	}
 
 	// Help text
-	s.WriteString(helpStyle.Render("↑/↓: navigate • enter: select • q: quit"))
+	s.WriteString(helpStyle.Render(utils.Symbols(i18n.T("↑/↓: navigate • enter: select • q: quit"))))
 	s.WriteString("\n")
 
 	return s.String()
@@ -201,12 +202,12 @@ func fallbackSelectTemplate(availableTemplates []templates.Template) (string, er
 
 		choice, err := strconv.Atoi(strings.TrimSpace(input))
 		if err != nil || choice < 1 || choice > len(availableTemplates) {
-			fmt.Printf("Invalid selection. Please enter a number between 1 and %d.\n", len(availableTemplates))
+			fmt.Println(i18n.T("Invalid selection. Please enter a number between 1 and %d.", len(availableTemplates)))
 			continue
 		}
 
 		selectedTemplate := availableTemplates[choice-1]
-		fmt.Printf("Selected: %s (%s)\n", selectedTemplate.DisplayName(), selectedTemplate.ID)
+		fmt.Println(i18n.T("Selected: %s (%s)", selectedTemplate.DisplayName(), selectedTemplate.ID))
 		return selectedTemplate.ID, nil
 	}
 }
@@ -226,7 +227,7 @@ func SelectTemplate() (string, error) {
 	}
 
 	// Check if we have a TTY for interactive mode
-	if !isTTY() {
+	if !interactive() {
 		// Fallback to simple prompts
 		return fallbackSelectTemplate(availableTemplates)
 	}
@@ -238,7 +239,7 @@ func SelectTemplate() (string, error) {
 	finalModel, err := p.Run()
 	if err != nil {
 		// If Bubble Tea fails, fallback to simple prompts
-		fmt.Printf("Interactive mode failed (%v), falling back to simple mode...\n", err)
+		fmt.Println(i18n.T("Interactive mode failed (%v), falling back to simple mode...", err))
 		return fallbackSelectTemplate(availableTemplates)
 	}
 
@@ -258,6 +259,6 @@ func SelectTemplate() (string, error) {
 		return "", fmt.Errorf("failed to get selected template: %w", err)
 	}
 
-	fmt.Printf("\nSelected: %s (%s)\n", selectedTemplate.DisplayName(), selectedTemplate.ID)
+	fmt.Printf("\n%s\n", i18n.T("Selected: %s (%s)", selectedTemplate.DisplayName(), selectedTemplate.ID))
 	return selectedID, nil
 }
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+// lockRetryInterval is how often a held lock is polled
+const lockRetryInterval = 50 * time.Millisecond
+
+// FileLock is an advisory lock on a file, held through a sibling "<file>.lock" file
+type FileLock struct {
+	path string
+}
+
+// AcquireFileLock locks path for exclusive modification, waiting up to timeout for
+// other holders. Locks older than config.StaleLockAge are considered abandoned.
+func AcquireFileLock(path string, timeout time.Duration) (*FileLock, error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(timeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, config.FilePermissions)
+		if err == nil {
+			_, writeErr := file.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			closeErr := file.Close()
+			if err := errors.Join(writeErr, closeErr); err != nil {
+				_ = os.Remove(lockPath)
+				return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, lockPath, err)
+			}
+			return &FileLock{path: lockPath}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, models.NewFileSystemError(models.ErrorCodeFileSystemError, lockPath, err)
+		}
+
+		// Break locks left behind by crashed processes
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > config.StaleLockAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, models.NewAppError(
+				models.ErrorCodeFileLocked,
+				fmt.Sprintf("Timed out waiting for lock on %s", path),
+				nil,
+			).WithContext("lock_file", lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Release removes the lock file
+func (l *FileLock) Release() error {
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, l.path, err)
+	}
+	return nil
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it into
+// place, so readers see either the old or the new content, never a partial file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, dir, err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath) // No-op once renamed
+
+	_, writeErr := tempFile.Write(data)
+	syncErr := tempFile.Sync()
+	closeErr := tempFile.Close()
+	if err := errors.Join(writeErr, syncErr, closeErr); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, tempPath, err)
+	}
+
+	if err := os.Chmod(tempPath, perm); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return models.NewFileSystemError(models.ErrorCodeFileSystemError, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "new" {
+		t.Errorf("WriteFileAtomic() content = %q, want %q", data, "new")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("WriteFileAtomic() mode = %v, want %v", info.Mode().Perm(), os.FileMode(0644))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("WriteFileAtomic() left %d files behind, want 1", len(entries))
+	}
+}
+
+func TestAcquireFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	lock, err := AcquireFileLock(path, time.Second)
+	if err != nil {
+		t.Fatalf("AcquireFileLock() error = %v", err)
+	}
+
+	// A second holder times out
+	if _, err := AcquireFileLock(path, 100*time.Millisecond); !models.IsErrorCode(err, models.ErrorCodeFileLocked) {
+		t.Errorf("AcquireFileLock() on held lock error = %v, want %s", err, models.ErrorCodeFileLocked)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("Release() left the lock file behind")
+	}
+
+	// Once released the lock can be taken again
+	lock, err = AcquireFileLock(path, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireFileLock() after release error = %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+}
+
+func TestAcquireFileLock_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	lockPath := path + ".lock"
+
+	if err := os.WriteFile(lockPath, []byte("12345\n"), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+	abandoned := time.Now().Add(-2 * config.StaleLockAge)
+	if err := os.Chtimes(lockPath, abandoned, abandoned); err != nil {
+		t.Fatalf("Failed to age lock file: %v", err)
+	}
+
+	lock, err := AcquireFileLock(path, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AcquireFileLock() with stale lock error = %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText []byte
+		newText []byte
+		want    string
+	}{
+		{
+			name:    "equal",
+			oldText: []byte("a\nb\n"),
+			newText: []byte("a\nb"),
+			want:    "",
+		},
+		{
+			name:    "new file",
+			oldText: nil,
+			newText: []byte("a\nb\n"),
+			want:    "--- /dev/null\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:    "changed line with context",
+			oldText: []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n"),
+			newText: []byte("1\n2\n3\n4\nfive\n6\n7\n8\n9\n"),
+			want:    "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: []byte("a\n1\n2\n3\n4\n5\n6\n7\nb\n"),
+			newText: []byte("A\n1\n2\n3\n4\n5\n6\n7\nB\n"),
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+		},
+		{
+			name:    "removed file",
+			oldText: []byte("a\n"),
+			newText: nil,
+			want:    "--- old\n+++ /dev/null\n@@ -1,1 +0,0 @@\n-a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("old", "new", tt.oldText, tt.newText); got != tt.want {
+				t.Errorf("UnifiedDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
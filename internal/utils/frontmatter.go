@@ -0,0 +1,32 @@
+package utils
+
+import "strings"
+
+// ParseFrontMatter returns the top-level scalar fields of a markdown document's
+// front matter, keyed by lower-case name. Quotes around values are removed.
+func ParseFrontMatter(content string) map[string]string {
+	fields := make(map[string]string)
+
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return fields
+	}
+
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return fields
+}
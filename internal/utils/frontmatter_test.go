@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			name:    "scalar fields",
+			content: "---\nName: planner\ndescription: \"Plans: carefully\"\ntools: 'Read'\n---\n# Body\nkey: not front matter\n",
+			want:    map[string]string{"name": "planner", "description": "Plans: carefully", "tools": "Read"},
+		},
+		{
+			name:    "nested values are skipped",
+			content: "---\ntags:\n  - a\n  - b\nstatus: draft\r\n---\n",
+			want:    map[string]string{"tags": "", "status": "draft"},
+		},
+		{
+			name:    "no front matter",
+			content: "# Title\nname: value\n",
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseFrontMatter(tt.content); !maps.Equal(got, tt.want) {
+				t.Errorf("ParseFrontMatter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/i18n"
+
+	"github.com/mattn/go-isatty"
 )
 
 // InteractionService provides utilities for user interaction
@@ -19,9 +26,17 @@ func NewInteractionService() *InteractionService {
 	}
 }
 
-// ConfirmPrompt displays a confirmation prompt and returns the user's choice
+// IsInteractive reports whether stdin is a terminal a prompt can be answered
+// at; unlike a character device check, this is false for /dev/null
+func IsInteractive() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// ConfirmPrompt displays a confirmation prompt and returns the user's choice;
+// besides y and yes, the current locale's words for yes are accepted
 func (i *InteractionService) ConfirmPrompt(message string) (bool, error) {
-	fmt.Printf("%s (y/N): ", message)
+	fmt.Printf("%s %s: ", message, i18n.T("(y/N)"))
 
 	if !i.scanner.Scan() {
 		if err := i.scanner.Err(); err != nil {
@@ -31,8 +46,35 @@ func (i *InteractionService) ConfirmPrompt(message string) (bool, error) {
 		return false, nil
 	}
 
-	response := strings.TrimSpace(strings.ToLower(i.scanner.Text()))
-	return response == "y" || response == "yes", nil
+	return i18n.Affirmative(i.scanner.Text()), nil
+}
+
+// ConfirmTyped asks to type expected to confirm, like deleting a repository on
+// GitHub, and reports whether it was typed exactly; anything else, or no
+// input, declines
+func (i *InteractionService) ConfirmTyped(message, expected string) (bool, error) {
+	fmt.Println(message)
+	fmt.Printf("%s: ", i18n.T("Type %s to confirm", expected))
+
+	if !i.scanner.Scan() {
+		if err := i.scanner.Err(); err != nil {
+			return false, fmt.Errorf("failed to read input: %w", err)
+		}
+		return false, nil
+	}
+	return strings.TrimSpace(i.scanner.Text()) == expected, nil
+}
+
+// ConfirmFiles asks to confirm replacing or removing files in targetDir: y/N,
+// or typing the directory name when they are more than the typed confirmation
+// limit of the configuration
+func (i *InteractionService) ConfirmFiles(message, targetDir string, files int) (bool, error) {
+	limit := config.Current().TypedConfirmFiles
+	if limit <= 0 || files <= limit {
+		return i.ConfirmPrompt(message)
+	}
+	fmt.Printf(Symbols("⚠️  %s\n"), i18n.T("%d files will be replaced or removed.", files))
+	return i.ConfirmTyped(message, filepath.Base(targetDir))
 }
 
 // PromptWithDefault prompts for input with a default value
@@ -60,46 +102,100 @@ func (i *InteractionService) PromptWithDefault(message, defaultValue string) (st
 
 // DisplayError displays an error message in a formatted way
 func DisplayError(err error) {
-	fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+	fmt.Fprintf(os.Stderr, Symbols("❌ %s: %v\n"), i18n.T("Error"), err)
 }
 
 // DisplaySuccess displays a success message
 func DisplaySuccess(message string) {
-	fmt.Printf("✅ %s\n", message)
+	fmt.Printf(Symbols("✅ %s\n"), message)
 }
 
 // DisplayWarning displays a warning message
 func DisplayWarning(message string) {
-	fmt.Printf("⚠️  %s\n", message)
+	fmt.Printf(Symbols("⚠️  %s\n"), message)
 }
 
 // DisplayInfo displays an informational message
 func DisplayInfo(message string) {
-	fmt.Printf("ℹ️  %s\n", message)
+	fmt.Printf(Symbols("ℹ️  %s\n"), message)
 }
 
 // VerbosePrintln prints a message only if verbose mode is enabled
 func VerbosePrintln(verbose bool, message string) {
 	if verbose {
-		fmt.Printf("🔍 %s\n", message)
+		fmt.Printf(Symbols("🔍 %s\n"), message)
 	}
 }
 
 // VerbosePrintf prints a formatted message only if verbose mode is enabled
 func VerbosePrintf(verbose bool, format string, args ...interface{}) {
 	if verbose {
-		fmt.Printf("🔍 "+format, args...)
+		fmt.Printf(Symbols("🔍 ")+format, args...)
 	}
 }
 
-// ConfirmCleanup displays a cleanup confirmation prompt with directory information
-func (i *InteractionService) ConfirmCleanup(targetDir string) (bool, error) {
-	fmt.Printf("\n⚠️  This will remove Strategic Claude Basic from: %s\n", targetDir)
-	fmt.Println("This action will:")
-	fmt.Println("  • Remove the .strategic-claude-basic directory")
-	fmt.Println("  • Remove Strategic Claude symlinks from .claude directory")
-	fmt.Println("  • Preserve any user-created content in .claude")
+// FormatSize formats a byte count for display, e.g. "1.5 MB"
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatDuration rounds a duration for display, e.g. "1.23s" or "45.6ms"
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// FormatAge describes how long ago something happened in the largest whole unit,
+// e.g. "3 days ago"
+func FormatAge(d time.Duration) string {
+	plural := func(n int64, one, many string) string {
+		if n == 1 {
+			return i18n.T(one)
+		}
+		return i18n.T(many, n)
+	}
+
+	switch {
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		return plural(int64(d/time.Minute), "1 minute ago", "%d minutes ago")
+	case d < 24*time.Hour:
+		return plural(int64(d/time.Hour), "1 hour ago", "%d hours ago")
+	default:
+		return plural(int64(d/(24*time.Hour)), "1 day ago", "%d days ago")
+	}
+}
+
+// ConfirmCleanup displays a cleanup confirmation prompt with directory information;
+// all adds what clean --all removes, and files is how many files it removes
+func (i *InteractionService) ConfirmCleanup(targetDir string, all bool, files int) (bool, error) {
+	fmt.Printf(Symbols("\n⚠️  %s\n"), i18n.T("This will remove Strategic Claude Basic from: %s", targetDir))
+	fmt.Println(i18n.T("This action will:"))
+	fmt.Printf(Symbols("  • %s\n"), i18n.T("Remove the %s directory", config.FrameworkDir()))
+	fmt.Printf(Symbols("  • %s\n"), i18n.T("Remove Strategic Claude symlinks from .claude directory"))
+	if all {
+		fmt.Printf(Symbols("  • %s\n"), i18n.T("Remove all backups, the project config file and the .gitignore entries"))
+	}
+	fmt.Printf(Symbols("  • %s\n"), i18n.T("Preserve any user-created content in .claude"))
 	fmt.Println()
 
-	return i.ConfirmPrompt("Are you sure you want to proceed?")
+	return i.ConfirmFiles(i18n.T("Are you sure you want to proceed?"), targetDir, files)
 }
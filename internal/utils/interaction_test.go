@@ -8,6 +8,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 )
 
 func TestInteractionService_ConfirmPrompt(t *testing.T) {
@@ -142,6 +145,61 @@ func TestInteractionService_ConfirmPrompt(t *testing.T) {
 	}
 }
 
+func TestInteractionService_ConfirmTyped(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "directory name", input: "my-project\n", expected: true},
+		{name: "surrounding spaces", input: "  my-project \n", expected: true},
+		{name: "yes", input: "y\n", expected: false},
+		{name: "different case", input: "My-Project\n", expected: false},
+		{name: "empty", input: "\n", expected: false},
+		{name: "no input", input: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &InteractionService{scanner: bufio.NewScanner(strings.NewReader(tt.input))}
+			result, err := service.ConfirmTyped("Remove everything?", "my-project")
+			if err != nil {
+				t.Fatalf("ConfirmTyped() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ConfirmTyped(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInteractionService_ConfirmFiles(t *testing.T) {
+	limit := config.Current().TypedConfirmFiles
+	tests := []struct {
+		name     string
+		input    string
+		files    int
+		expected bool
+	}{
+		{name: "few files yes", input: "y\n", files: limit, expected: true},
+		{name: "many files yes", input: "y\n", files: limit + 1, expected: false},
+		{name: "many files directory name", input: "my-project\n", files: limit + 1, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &InteractionService{scanner: bufio.NewScanner(strings.NewReader(tt.input))}
+			result, err := service.ConfirmFiles("Proceed?", "/tmp/my-project", tt.files)
+			if err != nil {
+				t.Fatalf("ConfirmFiles() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ConfirmFiles(%q, %d files) = %v, want %v", tt.input, tt.files, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestInteractionService_PromptWithDefault(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -556,3 +614,60 @@ func TestIORedirection(t *testing.T) {
 		t.Errorf("IO redirection test failed, got: %q", result)
 	}
 }
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSize(tt.bytes); got != tt.expected {
+			t.Errorf("FormatSize(%d) = %v, want %v", tt.bytes, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{1500 * time.Nanosecond, "2µs"},
+		{12345 * time.Microsecond, "12.3ms"},
+		{1234567 * time.Microsecond, "1.23s"},
+		{75 * time.Second, "1m15s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.duration); got != tt.expected {
+			t.Errorf("FormatDuration(%v) = %v, want %v", tt.duration, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{30 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{90 * time.Minute, "1 hour ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{45 * 24 * time.Hour, "45 days ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAge(tt.age); got != tt.expected {
+			t.Errorf("FormatAge(%v) = %v, want %v", tt.age, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+// StripJSONComments turns JSON with comments (JSONC, as used by devcontainer.json
+// and VS Code settings) into plain JSON: line and block comments are removed,
+// as are trailing commas before a closing brace or bracket. Strings are left intact.
+func StripJSONComments(data []byte) []byte {
+	result := make([]byte, 0, len(data))
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if inString {
+			result = append(result, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				result = append(result, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			result = append(result, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i < len(data) && !(data[i] == '*' && i+1 < len(data) && data[i+1] == '/') {
+				i++
+			}
+			i++ // Skip the closing slash
+		case c == '}' || c == ']':
+			// Drop a trailing comma, keeping the whitespace after it
+			for j := len(result) - 1; j >= 0; j-- {
+				if result[j] == ',' {
+					result = append(result[:j], result[j+1:]...)
+					break
+				}
+				if result[j] != ' ' && result[j] != '\t' && result[j] != '\n' && result[j] != '\r' {
+					break
+				}
+			}
+			result = append(result, c)
+		default:
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStripJSONComments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain JSON",
+			input: `{"a": [1, 2]}`,
+			want:  `{"a": [1, 2]}`,
+		},
+		{
+			name:  "line and block comments",
+			input: "{\n  // The image\n  \"image\": \"go\", /* pinned */\n  \"b\": 1\n}",
+			want:  "{\n  \n  \"image\": \"go\", \n  \"b\": 1\n}",
+		},
+		{
+			name:  "trailing commas",
+			input: "{\"a\": [1, 2,],\n}",
+			want:  "{\"a\": [1, 2]\n}",
+		},
+		{
+			name:  "comment markers in strings",
+			input: `{"url": "https://example.com/*x*/", "q": "say \"//\","}`,
+			want:  `{"url": "https://example.com/*x*/", "q": "say \"//\","}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StripJSONComments([]byte(tt.input))
+			if string(got) != tt.want {
+				t.Errorf("StripJSONComments() = %q, want %q", got, tt.want)
+			}
+			if !json.Valid(got) {
+				t.Errorf("StripJSONComments() = %q, not valid JSON", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+// SudoInfo identifies the user that invoked the CLI through sudo
+type SudoInfo struct {
+	UID  int
+	GID  int // -1 when unknown, which leaves the group unchanged on chown
+	User string
+}
+
+// DetectSudo reports whether the process runs as root on behalf of another user via sudo
+func DetectSudo() (*SudoInfo, bool) {
+	return detectSudo(os.Geteuid(), os.Getenv)
+}
+
+// detectSudo implements DetectSudo with injectable process state
+func detectSudo(euid int, getenv func(string) string) (*SudoInfo, bool) {
+	if euid != 0 {
+		return nil, false
+	}
+
+	uid, err := strconv.Atoi(getenv("SUDO_UID"))
+	if err != nil || uid == 0 {
+		return nil, false // Real root login, or sudo invoked by root
+	}
+
+	gid, err := strconv.Atoi(getenv("SUDO_GID"))
+	if err != nil {
+		gid = -1
+	}
+
+	return &SudoInfo{UID: uid, GID: gid, User: getenv("SUDO_USER")}, true
+}
@@ -0,0 +1,64 @@
+package utils
+
+import "testing"
+
+func TestDetectSudo(t *testing.T) {
+	tests := []struct {
+		name       string
+		euid       int
+		env        map[string]string
+		expectSudo bool
+		expectUID  int
+		expectGID  int
+	}{
+		{
+			name:       "regular user",
+			euid:       1000,
+			env:        map[string]string{"SUDO_UID": "1000"},
+			expectSudo: false,
+		},
+		{
+			name:       "root without sudo",
+			euid:       0,
+			env:        map[string]string{},
+			expectSudo: false,
+		},
+		{
+			name:       "sudo from regular user",
+			euid:       0,
+			env:        map[string]string{"SUDO_UID": "1000", "SUDO_GID": "100", "SUDO_USER": "dev"},
+			expectSudo: true,
+			expectUID:  1000,
+			expectGID:  100,
+		},
+		{
+			name:       "sudo without group",
+			euid:       0,
+			env:        map[string]string{"SUDO_UID": "1000"},
+			expectSudo: true,
+			expectUID:  1000,
+			expectGID:  -1,
+		},
+		{
+			name:       "sudo from root",
+			euid:       0,
+			env:        map[string]string{"SUDO_UID": "0"},
+			expectSudo: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := detectSudo(tt.euid, func(key string) string { return tt.env[key] })
+			if ok != tt.expectSudo {
+				t.Fatalf("detectSudo() ok = %v, want %v", ok, tt.expectSudo)
+			}
+			if !ok {
+				return
+			}
+			if info.UID != tt.expectUID || info.GID != tt.expectGID {
+				t.Errorf("detectSudo() = %d:%d, want %d:%d", info.UID, info.GID, tt.expectUID, tt.expectGID)
+			}
+		})
+	}
+}
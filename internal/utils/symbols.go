@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// asciiSymbols replaces the emoji and Unicode symbols of the output with ASCII.
+// A marker and the two spaces that pad its emoji become one space, so columns
+// stay as wide as with emoji.
+var asciiSymbols = strings.NewReplacer(
+	"⚠️  ", "[!] ",
+	"ℹ️  ", "[i] ",
+	"⚠️", "[!]",
+	"ℹ️", "[i]",
+	"✅", "[ok]",
+	"❌", "[x]",
+	"🔍", "[.]",
+	"💡", "[tip]",
+	"🔧", "[fix]",
+	"📜", "-",
+	"🎉 ", "",
+	"🎉", "",
+	"✓", "ok",
+	"✗", "x",
+	"•", "*",
+	"→", "->",
+	"↻", "~",
+	"↑", "up",
+	"↓", "down",
+	"·", ".",
+	"…", "...",
+)
+
+var ascii bool
+
+// SetASCII makes Symbols replace emoji and Unicode symbols with ASCII
+func SetASCII(on bool) {
+	ascii = on
+}
+
+// ASCII reports whether output is limited to ASCII markers
+func ASCII() bool {
+	return ascii
+}
+
+// Symbols returns text, usually a format string, with its emoji and Unicode
+// symbols replaced by ASCII ones when output is limited to ASCII
+func Symbols(text string) string {
+	if !ascii {
+		return text
+	}
+	return asciiSymbols.Replace(text)
+}
+
+// LimitedTerminal reports whether the terminal is unlikely to show emoji: the
+// Linux console, a dumb terminal, or a locale whose character set is not UTF-8.
+// An unset locale counts as UTF-8, as most terminals default to it.
+func LimitedTerminal() bool {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux":
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			value = strings.ToLower(value)
+			return !strings.Contains(value, "utf-8") && !strings.Contains(value, "utf8")
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package utils
+
+import "testing"
+
+func TestSymbols(t *testing.T) {
+	t.Cleanup(func() { SetASCII(false) })
+
+	text := "⚠️  %s → %s"
+	if got := Symbols(text); got != text {
+		t.Errorf("Symbols() without ASCII = %q, want it unchanged", got)
+	}
+
+	SetASCII(true)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "⚠️  %s → %s", want: "[!] %s -> %s"},
+		{text: "✅ done", want: "[ok] done"},
+		{text: "  • %s", want: "  * %s"},
+		{text: "🎉 Installed!", want: "Installed!"},
+		{text: "plain text", want: "plain text"},
+	}
+	for _, tt := range tests {
+		if got := Symbols(tt.text); got != tt.want {
+			t.Errorf("Symbols(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLimitedTerminal(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "UTF-8 terminal", env: map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, want: false},
+		{name: "unset locale", env: map[string]string{"TERM": "xterm-256color"}, want: false},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb", "LANG": "en_US.UTF-8"}, want: true},
+		{name: "Linux console", env: map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, want: true},
+		{name: "C locale", env: map[string]string{"TERM": "xterm", "LANG": "C"}, want: true},
+		{name: "LC_ALL over LANG", env: map[string]string{"TERM": "xterm", "LC_ALL": "en_US.utf8", "LANG": "C"}, want: false},
+		{name: "LC_CTYPE", env: map[string]string{"TERM": "xterm", "LC_CTYPE": "en_US.ISO-8859-1", "LANG": "en_US.UTF-8"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"TERM", "LC_ALL", "LC_CTYPE", "LANG"} {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := LimitedTerminal(); got != tt.want {
+				t.Errorf("LimitedTerminal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
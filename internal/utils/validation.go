@@ -5,8 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/fsys"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 )
 
@@ -57,7 +61,7 @@ func (p *PathValidator) ValidateDirectoryWritable(path string) error {
 	}
 
 	// Try to create a temporary file to test write permissions
-	tempFile := filepath.Join(path, ".strategic-claude-basic-test-write")
+	tempFile := filepath.Join(path, config.WriteProbeFile)
 	file, err := os.Create(tempFile)
 	if err != nil {
 		if os.IsPermission(err) {
@@ -103,6 +107,71 @@ func (p *PathValidator) ResolvePath(path string) (string, error) {
 	return absPath, nil
 }
 
+// SafeJoin joins elems, which come from a template, to base like filepath.Join.
+// It returns an error when an element is absolute or the result is outside
+// base, e.g. for "../../outside".
+func (p *PathValidator) SafeJoin(base string, elems ...string) (string, error) {
+	for _, elem := range elems {
+		if filepath.IsAbs(elem) || filepath.VolumeName(elem) != "" {
+			return "", models.NewAppError(
+				models.ErrorCodeInvalidPath,
+				fmt.Sprintf("Path must be relative: %s", elem),
+				nil,
+			).WithContext("path", elem)
+		}
+	}
+
+	joined := filepath.Join(append([]string{base}, elems...)...)
+	rel, err := filepath.Rel(filepath.Clean(base), joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", models.NewAppError(
+			models.ErrorCodeInvalidPath,
+			fmt.Sprintf("Path escapes %s: %s", base, filepath.Join(elems...)),
+			err,
+		).WithContext("path", filepath.Join(elems...))
+	}
+
+	return joined, nil
+}
+
+// ValidateNotSensitive refuses the filesystem root, the user's home directory,
+// well-known system directories and any path listed in $SCB_FORBIDDEN_PATHS
+func (p *PathValidator) ValidateNotSensitive(path string) error {
+	absPath, err := p.ResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	candidates := []string{filepath.Clean(absPath)}
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		candidates = append(candidates, resolved)
+	}
+
+	forbidden := config.GetSensitiveDirectories()
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		forbidden = append(forbidden, home)
+	}
+	for _, extra := range filepath.SplitList(os.Getenv(config.ForbiddenPathsEnvVar)) {
+		if extra != "" {
+			forbidden = append(forbidden, extra)
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, dir := range forbidden {
+			if candidate == filepath.Clean(dir) || candidate == filepath.VolumeName(candidate)+string(filepath.Separator) {
+				return models.NewAppError(
+					models.ErrorCodeSensitiveDirectory,
+					fmt.Sprintf("Refusing to install into sensitive directory: %s", candidate),
+					nil,
+				).WithContext("path", candidate)
+			}
+		}
+	}
+
+	return nil
+}
+
 // InputValidator provides utilities for input validation
 type InputValidator struct{}
 
@@ -162,8 +231,10 @@ func NewFileSystemValidator() *FileSystemValidator {
 	return &FileSystemValidator{}
 }
 
-// ValidateSymlink validates that a symlink exists and points to the correct target
-func (f *FileSystemValidator) ValidateSymlink(symlinkPath, expectedTarget string) (*models.SymlinkStatus, error) {
+// ValidateSymlink validates that a symlink exists and points to the correct
+// target. With a frameworkDir, the target must also resolve inside it; a
+// symlink that escapes it is invalid and marked with Escapes.
+func (f *FileSystemValidator) ValidateSymlink(symlinkPath, expectedTarget, frameworkDir string) (*models.SymlinkStatus, error) {
 	// Extract a more descriptive name that includes parent directory for Claude symlinks
 	// For paths like "/path/to/.claude/agents/strategic", extract "agents/strategic"
 	name := filepath.Base(symlinkPath)
@@ -175,12 +246,13 @@ func (f *FileSystemValidator) ValidateSymlink(symlinkPath, expectedTarget string
 	}
 
 	status := &models.SymlinkStatus{
-		Name:   name,
-		Path:   symlinkPath,
-		Valid:  false,
-		Target: "",
-		Exists: false,
-		Error:  "",
+		Name:     name,
+		Path:     symlinkPath,
+		Valid:    false,
+		Target:   "",
+		Exists:   false,
+		Error:    "",
+		Expected: expectedTarget,
 	}
 
 	// Check if symlink exists
@@ -228,6 +300,15 @@ func (f *FileSystemValidator) ValidateSymlink(symlinkPath, expectedTarget string
 		}
 	}
 
+	if frameworkDir != "" {
+		if resolved, inside := ResolvesWithin(symlinkPath, frameworkDir); !inside {
+			status.Valid = false
+			status.Escapes = true
+			status.Error = fmt.Sprintf("symlink resolves to '%s', outside %s", resolved, frameworkDir)
+			return status, nil
+		}
+	}
+
 	if !status.Valid {
 		status.Error = fmt.Sprintf("symlink points to '%s', expected '%s'", target, expectedTarget)
 	}
@@ -235,6 +316,155 @@ func (f *FileSystemValidator) ValidateSymlink(symlinkPath, expectedTarget string
 	return status, nil
 }
 
+// ResolvesWithin follows the symlink at symlinkPath and any links below it and
+// reports whether it lands inside frameworkDir, along with where it lands. Dev
+// mode links the framework directories to <checkout>/.strategic-claude-basic/<dir>;
+// a target below such a link must land inside the linked directory. Targets that
+// do not exist are judged by their path.
+func ResolvesWithin(symlinkPath, frameworkDir string) (string, bool) {
+	target, err := os.Readlink(symlinkPath)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(symlinkPath), target)
+	}
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return target, false
+	}
+	frameworkDir, err = filepath.Abs(frameworkDir)
+	if err != nil {
+		return target, false
+	}
+
+	rel, ok := relativeWithin(frameworkDir, target)
+	if !ok {
+		return target, false
+	}
+	if rel == "." {
+		return target, true
+	}
+
+	// The framework directory a target is below, followed when it is a dev mode link
+	dir := strings.Split(rel, string(filepath.Separator))[0]
+	dirPath := filepath.Join(frameworkDir, dir)
+	if info, err := os.Lstat(dirPath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		devTarget, err := os.Readlink(dirPath)
+		if err != nil || filepath.Base(devTarget) != dir || filepath.Base(filepath.Dir(devTarget)) != config.StrategicClaudeBasicDir {
+			return target, false
+		}
+	}
+	root, err := filepath.EvalSymlinks(dirPath)
+	if err != nil {
+		return target, true
+	}
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return target, true
+	}
+	if _, ok := relativeWithin(root, resolved); !ok {
+		return resolved, false
+	}
+	return resolved, true
+}
+
+// relativeWithin returns path relative to dir, or false when it is outside dir
+func relativeWithin(dir, path string) (string, bool) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// maxLinkDepth bounds the symlinks followed by LinkResolvesWithin, like the
+// limit of the operating system, so that link loops end
+const maxLinkDepth = 40
+
+// SymlinkedParent returns the first directory between root and path, root
+// excluded, that is a symlink, or "" when there is none. Archives are extracted
+// with it checked before every write, so that a symlink an earlier entry created
+// is not written through. Directories that do not exist yet are not links.
+func SymlinkedParent(root, path string) (string, error) {
+	rel, ok := relativeWithin(root, path)
+	if !ok {
+		return "", fmt.Errorf("%s is outside %s", path, root)
+	}
+
+	dir := root
+	parts := strings.Split(rel, string(filepath.Separator))
+	for _, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return dir, nil
+		}
+	}
+	return "", nil
+}
+
+// LinkResolvesWithin reports whether a symlink at linkPath pointing to target
+// leads inside root. Symlinks on disk are followed, so "l/.." is judged by where
+// l points rather than by its text; parts that do not exist yet are judged by
+// their path.
+func LinkResolvesWithin(root, linkPath, target string) bool {
+	return LinkResolvesWithinFS(fsys.OS(), root, linkPath, target)
+}
+
+// LinkResolvesWithinFS is LinkResolvesWithin on the file system fileSystem
+func LinkResolvesWithinFS(fileSystem fsys.FS, root, linkPath, target string) bool {
+	resolved, ok := resolveLink(fileSystem, filepath.Dir(linkPath), target, 0)
+	if !ok {
+		return false
+	}
+	_, ok = relativeWithin(root, resolved)
+	return ok
+}
+
+// resolveLink returns where target leads from dir, following symlinks one
+// component at a time
+func resolveLink(fileSystem fsys.FS, dir, target string, depth int) (string, bool) {
+	if depth > maxLinkDepth {
+		return "", false
+	}
+
+	current := dir
+	if filepath.IsAbs(target) {
+		current = filepath.VolumeName(target) + string(filepath.Separator)
+	}
+	parts := strings.FieldsFunc(target, func(r rune) bool { return r == '/' || r == filepath.Separator })
+	for _, part := range parts {
+		switch part {
+		case ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			continue
+		}
+
+		next := filepath.Join(current, part)
+		if info, err := fileSystem.Lstat(next); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			linked, err := fileSystem.Readlink(next)
+			if err != nil {
+				return "", false
+			}
+			var ok bool
+			if next, ok = resolveLink(fileSystem, current, linked, depth+1); !ok {
+				return "", false
+			}
+		}
+		current = next
+	}
+	return current, true
+}
+
 // CheckGitAvailable checks if git is available in the system
 func CheckGitAvailable() error {
 	_, err := os.Stat("/usr/bin/git")
@@ -277,3 +507,27 @@ func ValidateDirectoryName(name string) error {
 
 	return nil
 }
+
+// ParseAge parses a duration that may also be given in days, e.g. "30d", "12h" or "90m"
+func ParseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, models.NewValidationError("age", value, "expected a number of days such as 30d")
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, models.NewValidationError("age", value, "expected a duration such as 30d, 12h or 90m")
+	}
+
+	return duration, nil
+}
+
+// EnvBool reports whether the environment variable is set to a true value such as "1" or "true"
+func EnvBool(name string) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(name)))
+	return err == nil && value
+}
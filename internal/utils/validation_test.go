@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/config"
 	"github.com/Fomo-Driven-Development/strategic-claude-basic-cli/internal/models"
 )
 
@@ -232,6 +234,41 @@ func TestPathValidator_ResolvePath(t *testing.T) {
 	}
 }
 
+func TestPathValidator_SafeJoin(t *testing.T) {
+	validator := NewPathValidator()
+	base := filepath.Join(string(filepath.Separator), "project")
+
+	tests := []struct {
+		name      string
+		elems     []string
+		want      string
+		shouldErr bool
+	}{
+		{"nested path", []string{".claude", "agents/strategic"}, filepath.Join(base, ".claude", "agents", "strategic"), false},
+		{"dot dot inside", []string{".claude/agents", "../../.strategic-claude-basic/core"}, filepath.Join(base, ".strategic-claude-basic", "core"), false},
+		{"base itself", []string{"."}, base, false},
+		{"escaping", []string{"../../outside"}, "", true},
+		{"escaping through elements", []string{".claude", "../../outside"}, "", true},
+		{"sibling with base prefix", []string{"../project-other"}, "", true},
+		{"absolute", []string{"/etc/passwd"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validator.SafeJoin(base, tt.elems...)
+			if tt.shouldErr {
+				if !models.IsErrorCode(err, models.ErrorCodeInvalidPath) {
+					t.Errorf("SafeJoin() error = %v, want %s", err, models.ErrorCodeInvalidPath)
+				}
+				return
+			}
+			if err != nil || result != tt.want {
+				t.Errorf("SafeJoin() = %q, %v, want %q", result, err, tt.want)
+			}
+		})
+	}
+}
+
 func TestInputValidator_ValidateInstallConfig(t *testing.T) {
 	validator := NewInputValidator()
 	tempDir := t.TempDir()
@@ -437,7 +474,7 @@ func TestFileSystemValidator_ValidateSymlink(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			symlinkPath, expectedTarget := tt.setup()
 
-			status, err := validator.ValidateSymlink(symlinkPath, expectedTarget)
+			status, err := validator.ValidateSymlink(symlinkPath, expectedTarget, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -470,6 +507,91 @@ func TestFileSystemValidator_ValidateSymlink(t *testing.T) {
 	}
 }
 
+func TestFileSystemValidator_ValidateSymlinkEscapes(t *testing.T) {
+	validator := NewFileSystemValidator()
+	expectedTarget := "../../" + config.StrategicClaudeBasicDir + "/core/agents"
+
+	tests := []struct {
+		name        string
+		setupCore   func(t *testing.T, coreDir string)
+		expectValid bool
+	}{
+		{
+			name: "target inside the framework directory",
+			setupCore: func(t *testing.T, coreDir string) {
+				if err := os.MkdirAll(filepath.Join(coreDir, "agents"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectValid: true,
+		},
+		{
+			name: "link in core leaving the framework directory",
+			setupCore: func(t *testing.T, coreDir string) {
+				if err := os.MkdirAll(coreDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(t.TempDir(), filepath.Join(coreDir, "agents")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectValid: false,
+		},
+		{
+			name: "dev mode link to a template checkout",
+			setupCore: func(t *testing.T, coreDir string) {
+				checkoutCore := filepath.Join(t.TempDir(), config.StrategicClaudeBasicDir, "core")
+				if err := os.MkdirAll(filepath.Join(checkoutCore, "agents"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.MkdirAll(filepath.Dir(coreDir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(checkoutCore, coreDir); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectValid: true,
+		},
+		{
+			name: "core linked to another directory",
+			setupCore: func(t *testing.T, coreDir string) {
+				if err := os.MkdirAll(filepath.Dir(coreDir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(t.TempDir(), coreDir); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			frameworkDir := filepath.Join(targetDir, config.StrategicClaudeBasicDir)
+			tt.setupCore(t, filepath.Join(frameworkDir, "core"))
+
+			symlinkPath := filepath.Join(targetDir, config.ClaudeDir, "agents", "strategic")
+			if err := os.MkdirAll(filepath.Dir(symlinkPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Symlink(expectedTarget, symlinkPath); err != nil {
+				t.Fatal(err)
+			}
+
+			status, err := validator.ValidateSymlink(symlinkPath, expectedTarget, frameworkDir)
+			if err != nil {
+				t.Fatalf("ValidateSymlink() error = %v", err)
+			}
+			if status.Valid != tt.expectValid || status.Escapes == tt.expectValid {
+				t.Errorf("ValidateSymlink() valid = %v, escapes = %v, want valid = %v (%s)", status.Valid, status.Escapes, tt.expectValid, status.Error)
+			}
+		})
+	}
+}
+
 func TestValidateDirectoryName(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -594,6 +716,77 @@ func TestSymlinkHelpers(t *testing.T) {
 	}
 }
 
+func TestSymlinkedParent(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("..", filepath.Join(root, "a", "b", "l")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{filepath.Join(root, "a", "b", "file"), ""},
+		{filepath.Join(root, "a", "new", "file"), ""},
+		{filepath.Join(root, "a", "b", "l"), ""},
+		{filepath.Join(root, "a", "b", "l", "file"), filepath.Join(root, "a", "b", "l")},
+		{filepath.Join(root, "a", "b", "l", "c", "file"), filepath.Join(root, "a", "b", "l")},
+	}
+	for _, tt := range tests {
+		got, err := SymlinkedParent(root, tt.path)
+		if err != nil || got != tt.want {
+			t.Errorf("SymlinkedParent(%s) = %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	if _, err := SymlinkedParent(root, filepath.Dir(root)); err == nil {
+		t.Error("SymlinkedParent() outside root error = nil, want an error")
+	}
+}
+
+func TestLinkResolvesWithin(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "root")
+	if err := os.MkdirAll(filepath.Join(root, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// l points to the root, so going up from it leaves the root
+	if err := os.Symlink("../..", filepath.Join(root, "a", "b", "l")); err != nil {
+		t.Fatal(err)
+	}
+
+	linkPath := filepath.Join(root, "a", "b", "new")
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"file", true},
+		{"../../file", true},
+		{"../../../file", false},
+		{"l", true},
+		{"l/a", true},
+		{"l/..", false},
+		{"missing/..", true},
+		{filepath.Join(root, "a"), true},
+		{"/etc", false},
+	}
+	for _, tt := range tests {
+		if got := LinkResolvesWithin(root, linkPath, tt.target); got != tt.want {
+			t.Errorf("LinkResolvesWithin(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+
+	// Link loops end
+	if err := os.Symlink("loop", filepath.Join(root, "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if LinkResolvesWithin(root, filepath.Join(root, "new"), "loop/x") {
+		t.Error("LinkResolvesWithin() through a loop = true, want false")
+	}
+}
+
 // Benchmark tests for performance-critical validation functions
 func BenchmarkPathValidator_ValidateDirectory(b *testing.B) {
 	validator := NewPathValidator()
@@ -613,3 +806,91 @@ func BenchmarkValidateDirectoryName(b *testing.B) {
 		_ = ValidateDirectoryName(testName)
 	}
 }
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, false},
+		{"-1d", 0, true},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAge(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAge(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseAge(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPathValidator_ValidateNotSensitive(t *testing.T) {
+	validator := NewPathValidator()
+
+	home := t.TempDir()
+	forbidden := t.TempDir()
+	project := filepath.Join(home, "project")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+
+	t.Setenv("HOME", home)
+	t.Setenv(config.ForbiddenPathsEnvVar, forbidden)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"filesystem root", "/", true},
+		{"system directory", "/usr", true},
+		{"home directory", home, true},
+		{"home directory with trailing slash", home + "/", true},
+		{"configured forbidden path", forbidden, true},
+		{"project below home", project, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateNotSensitive(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateNotSensitive(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if tt.wantErr && !models.IsErrorCode(err, models.ErrorCodeSensitiveDirectory) {
+				t.Errorf("Expected ErrorCodeSensitiveDirectory, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEnvBool(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"1", true},
+		{"true", true},
+		{" TRUE ", true},
+		{"0", false},
+		{"no", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SCB_TEST_BOOL", tt.value)
+		if got := EnvBool("SCB_TEST_BOOL"); got != tt.expected {
+			t.Errorf("EnvBool() with %q = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}